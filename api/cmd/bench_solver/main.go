@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+// bench_solver runs a fixed seed list per difficulty through generation and
+// SolveWithSteps and reports solve-time percentiles, moves per solve, and
+// per-technique cumulative time. Solves run sequentially on one goroutine so
+// timings are comparable between runs; store the -json output and diff it
+// against a later commit with -compare.
+
+// DifficultyStats summarizes solve times for one difficulty tier.
+type DifficultyStats struct {
+	P50Ms     float64 `json:"p50_ms"`
+	P95Ms     float64 `json:"p95_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MeanMoves float64 `json:"mean_moves"`
+	Solved    int     `json:"solved"`
+	Stalled   int     `json:"stalled"`
+}
+
+// Report is the JSON document written by -json and read by -compare.
+type Report struct {
+	GeneratedAt        string                     `json:"generated_at"`
+	SeedsPerDifficulty int                        `json:"seeds_per_difficulty"`
+	StartSeed          int64                      `json:"start_seed"`
+	Difficulties       map[string]DifficultyStats `json:"difficulties"`
+	TechniqueTimeMs    map[string]float64         `json:"technique_time_ms"`
+}
+
+func main() {
+	n := flag.Int("n", 500, "Seeds per difficulty")
+	startSeed := flag.Int64("seed", 1, "Starting seed value")
+	jsonOut := flag.String("json", "", "Write the report as JSON to this file (\"-\" for stdout)")
+	compare := flag.String("compare", "", "Compare against a previous -json report and print regressions")
+	threshold := flag.Float64("threshold", 0.15, "Relative slowdown that counts as a regression with -compare")
+	slowdown := flag.Float64("slowdown", 1, "Multiply measured solve times (test hook for validating -compare)")
+	flag.Parse()
+
+	report := runBenchmark(*n, *startSeed, *slowdown)
+	printReport(report)
+
+	if *jsonOut != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling report: %v\n", err)
+			os.Exit(1)
+		}
+		if *jsonOut == "-" {
+			fmt.Println(string(data))
+		} else if err := os.WriteFile(*jsonOut, data, 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *compare != "" {
+		old, err := loadReport(*compare)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+			os.Exit(1)
+		}
+		if regressions := compareReports(old, report, *threshold); len(regressions) > 0 {
+			fmt.Printf("\n%d regressions above %.0f%%:\n", len(regressions), *threshold*100)
+			for _, r := range regressions {
+				fmt.Printf("  %s\n", r)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("\nNo regressions above %.0f%%\n", *threshold*100)
+	}
+}
+
+func runBenchmark(n int, startSeed int64, slowdown float64) *Report {
+	report := &Report{
+		GeneratedAt:        time.Now().UTC().Format(time.RFC3339),
+		SeedsPerDifficulty: n,
+		StartSeed:          startSeed,
+		Difficulties:       make(map[string]DifficultyStats),
+		TechniqueTimeMs:    make(map[string]float64),
+	}
+
+	solver := human.NewSolver()
+	solver.EnableStats()
+
+	for _, spec := range constants.DifficultySpecs() {
+		durations := make([]time.Duration, 0, n)
+		totalMoves, solved, stalled := 0, 0, 0
+
+		for i := 0; i < n; i++ {
+			seed := startSeed + int64(i)
+			fullGrid := dp.GenerateFullGrid(seed)
+			givens := dp.CarveGivens(fullGrid, spec.TargetGivens, seed)
+
+			start := time.Now()
+			board := human.NewBoard(givens)
+			moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+			elapsed := time.Duration(float64(time.Since(start)) * slowdown)
+
+			durations = append(durations, elapsed)
+			totalMoves += len(moves)
+			if status == constants.StatusCompleted {
+				solved++
+			} else {
+				stalled++
+			}
+		}
+
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		report.Difficulties[spec.Name] = DifficultyStats{
+			P50Ms:     percentileMs(durations, 0.50),
+			P95Ms:     percentileMs(durations, 0.95),
+			P99Ms:     percentileMs(durations, 0.99),
+			MeanMoves: float64(totalMoves) / float64(n),
+			Solved:    solved,
+			Stalled:   stalled,
+		}
+	}
+
+	for slug, d := range solver.Stats().DetectorTime {
+		report.TechniqueTimeMs[slug] = float64(d) / float64(time.Millisecond) * slowdown
+	}
+
+	return report
+}
+
+// percentileMs returns the q-th percentile of sorted durations in
+// milliseconds.
+func percentileMs(sorted []time.Duration, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)) * q)
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return float64(sorted[idx]) / float64(time.Millisecond)
+}
+
+func printReport(report *Report) {
+	fmt.Printf("Solver benchmark: %d seeds per difficulty starting at %d\n\n", report.SeedsPerDifficulty, report.StartSeed)
+	fmt.Printf("%-12s %10s %10s %10s %10s %8s %8s\n", "Difficulty", "p50 (ms)", "p95 (ms)", "p99 (ms)", "moves", "solved", "stalled")
+	for _, spec := range constants.DifficultySpecs() {
+		stats := report.Difficulties[spec.Name]
+		fmt.Printf("%-12s %10.2f %10.2f %10.2f %10.1f %8d %8d\n",
+			spec.Name, stats.P50Ms, stats.P95Ms, stats.P99Ms, stats.MeanMoves, stats.Solved, stats.Stalled)
+	}
+
+	type techTime struct {
+		slug string
+		ms   float64
+	}
+	var times []techTime
+	for slug, ms := range report.TechniqueTimeMs {
+		times = append(times, techTime{slug, ms})
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].ms > times[j].ms })
+
+	fmt.Println("\nCumulative detector time:")
+	for i, t := range times {
+		if i >= 15 {
+			break
+		}
+		fmt.Printf("  %-28s %10.1f ms\n", t.slug, t.ms)
+	}
+}
+
+func loadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// compareReports returns one line per metric that slowed down by more than
+// the threshold relative to the baseline. Tiny baseline values are skipped:
+// their relative change is noise.
+func compareReports(old, new *Report, threshold float64) []string {
+	const minBaselineMs = 1.0
+	var regressions []string
+
+	for _, spec := range constants.DifficultySpecs() {
+		oldStats, ok := old.Difficulties[spec.Name]
+		if !ok {
+			continue
+		}
+		newStats := new.Difficulties[spec.Name]
+		for _, m := range []struct {
+			name     string
+			old, new float64
+		}{
+			{"p50", oldStats.P50Ms, newStats.P50Ms},
+			{"p95", oldStats.P95Ms, newStats.P95Ms},
+		} {
+			if m.old < minBaselineMs {
+				continue
+			}
+			if m.new > m.old*(1+threshold) {
+				regressions = append(regressions, fmt.Sprintf("%s %s: %.2fms -> %.2fms (+%.0f%%)",
+					spec.Name, m.name, m.old, m.new, (m.new/m.old-1)*100))
+			}
+		}
+	}
+
+	for slug, oldMs := range old.TechniqueTimeMs {
+		if oldMs < minBaselineMs*5 {
+			continue
+		}
+		if newMs := new.TechniqueTimeMs[slug]; newMs > oldMs*(1+threshold) {
+			regressions = append(regressions, fmt.Sprintf("technique %s: %.1fms -> %.1fms (+%.0f%%)",
+				slug, oldMs, newMs, (newMs/oldMs-1)*100))
+		}
+	}
+
+	sort.Strings(regressions)
+	return regressions
+}
@@ -0,0 +1,98 @@
+// Calibrates per-technique difficulty scores against human solve-time data.
+//
+// Reads a CSV of (puzzleId, difficulty, medianSolveSeconds, abandonRate),
+// re-analyzes each puzzle's technique counts with the human solver, fits
+// per-technique weights by least squares, and writes a weights JSON that the
+// server loads at startup (TECHNIQUE_WEIGHTS_FILE) to override the built-in
+// registry scores.
+//
+// Usage: calibrate -data solves.csv -f puzzles.json -o weights.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"sudoku-api/internal/calibrate"
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+func main() {
+	data := flag.String("data", "solves.csv", "Solve-time CSV: puzzleId,difficulty,medianSolveSeconds,abandonRate")
+	input := flag.String("f", "puzzles.json", "Puzzle file the IDs refer to")
+	output := flag.String("o", "weights.json", "Output weights JSON")
+	minScore := flag.Int("min", 5, "Lower bound for fitted weights")
+	maxScore := flag.Int("max", 200, "Upper bound for fitted weights")
+	flag.Parse()
+
+	loader, err := puzzles.Load(*input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading puzzle file: %v\n", err)
+		os.Exit(1)
+	}
+
+	csvFile, err := os.Open(*data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening solve data: %v\n", err)
+		os.Exit(1)
+	}
+	observations, warnings := calibrate.ParseCSV(csvFile)
+	csvFile.Close()
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	fmt.Printf("Parsed %d observations from %s (%d rows skipped)\n", len(observations), *data, len(warnings))
+
+	// Re-analyze each observed puzzle to get its technique counts
+	solver := human.NewSolver()
+	var samples []calibrate.Sample
+	skipped := 0
+	for _, obs := range observations {
+		givens, _, _, err := loader.GetPuzzleBySeed(obs.PuzzleID, obs.Difficulty)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: puzzle %s (%s): %v\n", obs.PuzzleID, obs.Difficulty, err)
+			skipped++
+			continue
+		}
+		_, counts, status := solver.AnalyzePuzzleDifficulty(givens)
+		if status != constants.StatusCompleted {
+			fmt.Fprintf(os.Stderr, "Warning: puzzle %s (%s): solver status %s, skipping\n", obs.PuzzleID, obs.Difficulty, status)
+			skipped++
+			continue
+		}
+		samples = append(samples, calibrate.Sample{Counts: counts, Target: obs.Target()})
+	}
+	fmt.Printf("Analyzed %d puzzles (%d skipped)\n", len(samples), skipped)
+
+	weights, err := calibrate.FitWeights(samples, calibrate.Bounds{Min: float64(*minScore), Max: float64(*maxScore)})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Calibration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	file := human.ScoreWeightsFile{
+		MinScore: *minScore,
+		MaxScore: *maxScore,
+		Weights:  weights,
+	}
+	// Reject the fit up front if the server-side loader would
+	if err := human.SetScoreOverrides(file); err != nil {
+		fmt.Fprintf(os.Stderr, "Fitted weights failed validation: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoded, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding weights: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, append(encoded, '\n'), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d technique weights to %s (bounds %d-%d)\n", len(weights), *output, *minScore, *maxScore)
+}
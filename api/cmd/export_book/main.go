@@ -0,0 +1,115 @@
+// export_book renders a printable puzzle book: N puzzles of one difficulty
+// pulled from a pre-generated bank (or carved on demand), with the solutions
+// in a back section keyed by puzzle number. Output is plain text/markdown or
+// a minimal dependency-free PDF, four puzzles per page.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/render"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+func main() {
+	difficulty := flag.String("d", constants.DifficultyMedium, "Difficulty tier for every puzzle in the book")
+	count := flag.Int("n", 8, "Number of puzzles")
+	startSeed := flag.Int64("seed", 1, "First seed; puzzles use seed, seed+1, ...")
+	puzzlePath := flag.String("puzzles", "", "Optional path to a pre-generated puzzles.json bank")
+	format := flag.String("format", "text", "Output format: text or pdf")
+	output := flag.String("o", "", "Output file (default book.txt / book.pdf)")
+	title := flag.String("title", "Sudoku Book", "Book title")
+	flag.Parse()
+
+	spec, ok := constants.DifficultyByName(*difficulty)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown difficulty %q\n", *difficulty)
+		os.Exit(1)
+	}
+	if *count < 1 {
+		fmt.Fprintln(os.Stderr, "Error: count must be at least 1")
+		os.Exit(1)
+	}
+
+	var loader *puzzles.Loader
+	if *puzzlePath != "" {
+		var err error
+		if loader, err = puzzles.Load(*puzzlePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading puzzles: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	// Bank-only tiers guarantee a technique predicate the on-demand carve
+	// cannot honor, mirroring the API's puzzle endpoint
+	if loader == nil && len(spec.RequireAnyOf) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: difficulty %q is only served from pre-generated banks (use -puzzles)\n", *difficulty)
+		os.Exit(1)
+	}
+
+	entries := make([]render.BookEntry, *count)
+	for i := range entries {
+		entries[i] = resolveEntry(loader, *startSeed+int64(i), *difficulty, i+1)
+	}
+
+	var data []byte
+	path := *output
+	switch *format {
+	case "text":
+		data = []byte(render.BookText(*title, entries))
+		if path == "" {
+			path = "book.txt"
+		}
+	case "pdf":
+		data = render.BookPDF(*title, entries)
+		if path == "" {
+			path = "book.pdf"
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (want text or pdf)\n", *format)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d puzzles (%s) to %s\n", *count, *difficulty, path)
+}
+
+// resolveEntry pulls one puzzle from the bank when available, falling back to
+// the same deterministic carve the generator uses for that seed
+func resolveEntry(loader *puzzles.Loader, seedVal int64, difficulty string, number int) render.BookEntry {
+	seed := strconv.FormatInt(seedVal, 10)
+	entry := render.BookEntry{Number: number, Seed: seed, Difficulty: difficulty}
+
+	if loader != nil {
+		if givens, solution, index, err := loader.GetPuzzleBySeed(seed, difficulty); err == nil {
+			entry.Givens, entry.Solution = givens, solution
+			if counts, err := loader.GetTechniques(index, difficulty); err == nil {
+				entry.Techniques = techniqueNames(counts)
+			}
+			return entry
+		}
+	}
+
+	full := dp.GenerateFullGrid(seedVal)
+	allPuzzles, _ := dp.CarveGivensWithSubset(full, seedVal)
+	entry.Givens, entry.Solution = allPuzzles[difficulty], full
+	return entry
+}
+
+// techniqueNames flattens a technique count map into sorted caption names
+func techniqueNames(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
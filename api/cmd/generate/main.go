@@ -6,19 +6,23 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
 )
 
 // CompactPuzzle stores a puzzle in minimal format
 // Solution: 81-char string of digits
 // Givens: map of difficulty -> indices to reveal
 type CompactPuzzle struct {
-	S string           `json:"s"` // solution as 81-char string
-	G map[string][]int `json:"g"` // givens: difficulty -> cell indices to show
+	S string                    `json:"s"`           // solution as 81-char string
+	G map[string][]int          `json:"g"`           // givens: difficulty -> cell indices to show
+	T map[string]map[string]int `json:"t,omitempty"` // proof: difficulty -> technique -> times used
 }
 
 // PuzzleFile is the top-level structure for the JSON file
@@ -33,8 +37,30 @@ func main() {
 	output := flag.String("o", "puzzles.json", "Output file path")
 	workers := flag.Int("w", 0, "Number of worker goroutines (default: num CPUs)")
 	startSeed := flag.Int64("seed", 1, "Starting seed value")
+	minimal := flag.Bool("minimal", false, "Minimize the hardest tier's givens after carving (preserves the subset property)")
+	withProof := flag.Bool("with-proof", false, "Prove every tier solvable by the human technique set and embed the technique counts (slower)")
+	mode := flag.String("mode", "givens", "Carving mode: givens (classic count targets) or techniques (predicate-driven, much slower)")
+	openingQuality := flag.Bool("opening-quality", false, "Re-carve seeds whose easy/medium openings cluster in too few boxes or rows (slower)")
 	flag.Parse()
 
+	if *mode != "givens" && *mode != "techniques" {
+		fmt.Fprintf(os.Stderr, "Unknown mode %q: want givens or techniques\n", *mode)
+		os.Exit(1)
+	}
+	if *mode == "techniques" && *minimal {
+		// Minimizing changes the solve path, which would invalidate the
+		// technique predicates the carve just satisfied
+		fmt.Fprintln(os.Stderr, "-minimal only applies to -mode=givens")
+		os.Exit(1)
+	}
+	if *mode == "techniques" && *openingQuality {
+		// The opening filter wraps the classic carve; technique-mode tiers
+		// are defined by solve-path predicates a re-carve would invalidate
+		fmt.Fprintln(os.Stderr, "-opening-quality only applies to -mode=givens")
+		os.Exit(1)
+	}
+	techniqueMode := *mode == "techniques"
+
 	if *workers <= 0 {
 		*workers = runtime.NumCPU()
 	}
@@ -43,7 +69,7 @@ func main() {
 	start := time.Now()
 
 	puzzles := make([]CompactPuzzle, *count)
-	var generated int64
+	var generated, regenerated int64
 
 	// Create work channel
 	work := make(chan int, *count)
@@ -77,9 +103,21 @@ func main() {
 		wg.Add(1)
 		go func(workerID int) {
 			defer wg.Done()
+			var solver *human.Solver
+			if *withProof {
+				solver = human.NewSolver()
+			}
 			for idx := range work {
 				seed := *startSeed + int64(idx)
-				puzzle := generatePuzzle(seed)
+				puzzle, ok := generatePuzzle(seed, *minimal, techniqueMode, *openingQuality, solver)
+				// A failed proof (or an unsatisfied technique predicate)
+				// means the seed cannot produce every tier; regenerate from
+				// a distant seed so retries never collide with another
+				// index's seed
+				for attempt := int64(1); !ok; attempt++ {
+					atomic.AddInt64(&regenerated, 1)
+					puzzle, ok = generatePuzzle(seed+attempt*retrySeedStride, *minimal, techniqueMode, *openingQuality, solver)
+				}
 				puzzles[idx] = puzzle
 				atomic.AddInt64(&generated, 1)
 			}
@@ -91,6 +129,26 @@ func main() {
 
 	elapsed := time.Since(start)
 	fmt.Printf("Generated %d puzzles in %v (%.1f puzzles/sec)\n", *count, elapsed, float64(*count)/elapsed.Seconds())
+	if *withProof {
+		fmt.Printf("Regenerated %d puzzles whose proof failed\n", regenerated)
+	}
+
+	if *minimal {
+		// Given count distribution for the minimized tier
+		distribution := make(map[int]int)
+		for _, p := range puzzles {
+			distribution[len(p.G["i"])]++
+		}
+		var counts []int
+		for c := range distribution {
+			counts = append(counts, c)
+		}
+		sort.Ints(counts)
+		fmt.Println("Minimal given counts (impossible tier):")
+		for _, c := range counts {
+			fmt.Printf("  %d givens: %d puzzles\n", c, distribution[c])
+		}
+	}
 
 	// Write to file
 	fmt.Printf("Writing to %s...\n", *output)
@@ -118,7 +176,28 @@ func main() {
 	fmt.Printf("Done! File size: %.2f MB\n", sizeMB)
 }
 
-func generatePuzzle(seed int64) CompactPuzzle {
+// retrySeedStride separates proof-retry seeds from the contiguous index
+// range, so a retried puzzle cannot duplicate a neighbor
+const retrySeedStride = int64(1) << 33
+
+// diffKeys maps full difficulty names to the compact single-letter keys used
+// in the puzzle file
+var diffKeys = map[string]string{
+	"easy":       "e",
+	"medium":     "m",
+	"hard":       "h",
+	"extreme":    "x",
+	"impossible": "i",
+	"diabolical": "d",
+}
+
+// generatePuzzle builds one puzzle. When solver is non-nil, every tier is
+// solved with the human technique set and the technique counts embedded; a
+// tier the solver cannot complete returns ok=false so the caller retries
+// with a different seed. In technique mode the tiers are carved against
+// their technique predicates instead of given counts, and a seed whose
+// predicates cannot all be satisfied is likewise retried.
+func generatePuzzle(seed int64, minimal bool, techniqueMode bool, openingQuality bool, solver *human.Solver) (CompactPuzzle, bool) {
 	// Generate complete grid
 	fullGrid := dp.GenerateFullGrid(seed)
 
@@ -129,18 +208,59 @@ func generatePuzzle(seed int64) CompactPuzzle {
 	}
 
 	// Generate puzzles for all difficulties with subset property
-	allPuzzles := dp.CarveGivensWithSubset(fullGrid, seed)
+	var allPuzzles map[string][]int
+	if techniqueMode {
+		var carveFlags map[string]human.CarveFlags
+		allPuzzles, carveFlags = human.CarveGivensByTechniques(fullGrid, seed, human.DefaultTechniqueCarveSpecs())
+		for name, f := range carveFlags {
+			if f.Unsatisfied {
+				return CompactPuzzle{}, false
+			}
+			if f.SubsetBroken {
+				fmt.Printf("  warning: seed %d %s tier carved without the subset property\n", seed, name)
+			}
+		}
+	} else {
+		var degraded map[string]bool
+		if openingQuality {
+			allPuzzles, degraded = human.CarveWithOpeningQuality(fullGrid, seed, human.DefaultOpeningQuality())
+		} else {
+			allPuzzles, degraded = dp.CarveGivensWithSubset(fullGrid, seed)
+		}
+		if degraded["impossible"] {
+			fmt.Printf("  warning: seed %d impossible tier degraded to an easier tier's givens\n", seed)
+		}
+	}
 
-	// Extract indices for each difficulty
-	givens := make(map[string][]int)
-	diffKeys := map[string]string{
-		"easy":       "e",
-		"medium":     "m",
-		"hard":       "h",
-		"extreme":    "x",
-		"impossible": "i",
+	// Minimizing only the hardest tier keeps the subset property: it removes
+	// givens from the innermost set, so every easier tier remains a superset
+	if minimal {
+		allPuzzles["impossible"] = dp.MinimizeGivens(allPuzzles["impossible"], seed)
+	}
+
+	// The classic carve skips the bank-only diabolical tier; continue from
+	// the (possibly minimized) impossible givens so the subset property
+	// holds. The tier is best-effort: seeds whose continuation never
+	// requires an extreme technique simply ship without it.
+	if !techniqueMode {
+		if diabolical, ok := human.CarveDiabolical(allPuzzles["impossible"], seed); ok {
+			allPuzzles["diabolical"] = diabolical
+		} else {
+			fmt.Printf("  note: seed %d has no diabolical tier\n", seed)
+		}
 	}
 
+	var proof map[string]map[string]int
+	if solver != nil {
+		var ok bool
+		proof, ok = solveProof(solver, allPuzzles)
+		if !ok {
+			return CompactPuzzle{}, false
+		}
+	}
+
+	// Extract indices for each difficulty
+	givens := make(map[string][]int)
 	for diff, puzzle := range allPuzzles {
 		var indices []int
 		for i, v := range puzzle {
@@ -154,5 +274,26 @@ func generatePuzzle(seed int64) CompactPuzzle {
 	return CompactPuzzle{
 		S: string(solStr),
 		G: givens,
+		T: proof,
+	}, true
+}
+
+// solveProof solves every tier with the full human technique registry and
+// returns the technique multiset per difficulty key, or ok=false if any tier
+// stalls
+func solveProof(solver *human.Solver, allPuzzles map[string][]int) (map[string]map[string]int, bool) {
+	proof := make(map[string]map[string]int, len(allPuzzles))
+	for diff, givens := range allPuzzles {
+		board := human.NewBoard(givens)
+		moves, status := solver.SolveWithStepsOpts(board, constants.MaxSolverSteps, human.SolveOpts{CandidateMode: human.CandidateModeNone})
+		if status != constants.StatusCompleted {
+			return nil, false
+		}
+		counts := make(map[string]int)
+		for _, move := range moves {
+			counts[move.Technique]++
+		}
+		proof[diffKeys[diff]] = counts
 	}
+	return proof, true
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,8 +34,16 @@ func main() {
 	output := flag.String("o", "practice_puzzles.json", "Output file path")
 	workers := flag.Int("w", 0, "Number of worker goroutines (default: num CPUs)")
 	maxPerTechnique := flag.Int("max", 10, "Max puzzles per technique")
+	technique := flag.String("technique", "", "Generate a new puzzle requiring this technique instead of analyzing puzzles.json")
+	seed := flag.Int64("seed", 1, "Starting seed for -technique generation")
+	attempts := flag.Int("attempts", 2000, "Max generation attempts for -technique")
 	flag.Parse()
 
+	if *technique != "" {
+		backfillTechnique(*technique, *seed, *attempts)
+		return
+	}
+
 	if *workers <= 0 {
 		*workers = runtime.NumCPU()
 	}
@@ -124,12 +133,18 @@ func main() {
 					continue
 				}
 
-				// Analyze the puzzle
-				_, techniqueCounts, status := solver.AnalyzePuzzleDifficulty(givens)
+				// Tag by the minimal technique set rather than the raw solve
+				// counts: a puzzle that merely happened to use a technique
+				// the solver could have skipped is a poor practice example
+				minimal, status := solver.MinimalTechniqueAnalysis(givens)
 				if status != "completed" {
 					atomic.AddInt64(&analyzed, 1)
 					continue
 				}
+				techniqueCounts := make(map[string]int, len(minimal))
+				for _, member := range minimal {
+					techniqueCounts[member.Slug] = 1
+				}
 
 				results <- Result{
 					Index:      item.Index,
@@ -214,3 +229,26 @@ func main() {
 	info, _ := os.Stat(*output)
 	fmt.Printf("Done! File size: %d bytes\n", info.Size())
 }
+
+// backfillTechnique generates a puzzle that genuinely requires the given
+// technique and prints it as an 81-character string, for techniques the
+// static puzzle pool doesn't cover.
+func backfillTechnique(slug string, seed int64, maxAttempts int) {
+	fmt.Printf("Searching for a puzzle requiring %s (seed %d, up to %d attempts)...\n",
+		slug, seed, maxAttempts)
+	start := time.Now()
+
+	givens, attempts, err := human.GenerateRequiringTechnique(slug, seed, maxAttempts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var sb strings.Builder
+	for _, v := range givens {
+		sb.WriteByte(byte('0' + v))
+	}
+
+	fmt.Printf("Found in %d attempts (%v)\n", attempts, time.Since(start))
+	fmt.Printf("Puzzle: %s\n", sb.String())
+}
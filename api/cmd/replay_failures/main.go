@@ -0,0 +1,68 @@
+// replay_failures re-runs a failure corpus (NDJSON written by
+// test_all_puzzles/test_techniques -out) against the current solver and
+// reports which entries are fixed and which still fail, so a technique fix
+// can be verified against old failures without regenerating them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"sudoku-api/internal/corpus"
+)
+
+func main() {
+	inPath := flag.String("in", "failures.ndjson", "Failure corpus to replay")
+	verbose := flag.Bool("v", false, "Print one line per record instead of only still-failing ones")
+	flag.Parse()
+
+	f, err := os.Open(*inPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open %s: %v\n", *inPath, err)
+		os.Exit(1)
+	}
+	records, err := corpus.ReadAll(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", *inPath, err)
+		os.Exit(1)
+	}
+	if len(records) == 0 {
+		fmt.Printf("%s holds no failure records\n", *inPath)
+		return
+	}
+
+	fmt.Printf("Replaying %d failure records from %s\n", len(records), *inPath)
+	fmt.Println()
+
+	var fixed, stillFailing int
+	for _, rec := range records {
+		outcome := corpus.ReplayRecord(rec)
+
+		label := "STILL FAILING"
+		if outcome.Fixed {
+			label = "fixed"
+			fixed++
+		} else {
+			stillFailing++
+		}
+		if *verbose || !outcome.Fixed {
+			restriction := ""
+			if len(rec.EnabledTechniques) > 0 {
+				restriction = fmt.Sprintf(" [techniques: %s]", strings.Join(rec.EnabledTechniques, ","))
+			}
+			fmt.Printf("  seed %-12d was %-16s now %-16s %s%s\n",
+				rec.Seed, rec.Status, outcome.Status, label, restriction)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Fixed:         %d/%d\n", fixed, len(records))
+	fmt.Printf("Still failing: %d/%d\n", stillFailing, len(records))
+
+	if stillFailing > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: go run main.go <state_string>")
+		fmt.Println("Decodes a state string from encodeState, prints the board,")
+		fmt.Println("and replays the solver on it.")
+		os.Exit(1)
+	}
+
+	cells, candidates, err := core.DecodeState(os.Args[1])
+	if err != nil {
+		fmt.Printf("Failed to decode state: %v\n", err)
+		os.Exit(1)
+	}
+
+	printGrid(cells)
+
+	var board *human.Board
+	if candidates != nil {
+		board = human.NewBoardWithCandidates(cells, candidates)
+	} else {
+		fmt.Println("State carries no pencil marks; candidates recomputed")
+		board = human.NewBoard(cells)
+	}
+
+	solver := human.NewSolver()
+	move := solver.FindNextMove(board.Clone())
+	if move == nil {
+		fmt.Println("Next move: none (solver is stuck)")
+	} else {
+		fmt.Printf("Next move: %s (%s)\n", move.Technique, move.Action)
+	}
+
+	moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	fmt.Printf("Status: %s\n", status)
+	for _, m := range moves {
+		if m.Action == constants.ActionContradiction {
+			fmt.Printf("Contradiction: %s at %v\n", m.Technique, m.Targets)
+		}
+	}
+	fmt.Printf("Total moves: %d\n", len(moves))
+}
+
+// printGrid renders the decoded cells as a 9x9 grid with dots for empties
+func printGrid(cells []int) {
+	for row := 0; row < constants.GridSize; row++ {
+		for col := 0; col < constants.GridSize; col++ {
+			v := cells[row*constants.GridSize+col]
+			if v == 0 {
+				fmt.Print(".")
+			} else {
+				fmt.Print(v)
+			}
+			if col%constants.BoxSize == 2 && col != constants.GridSize-1 {
+				fmt.Print("|")
+			}
+		}
+		fmt.Println()
+		if row%constants.BoxSize == 2 && row != constants.GridSize-1 {
+			fmt.Println("---+---+---")
+		}
+	}
+}
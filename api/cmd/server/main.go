@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
 	httpTransport "sudoku-api/internal/transport/http"
 	"sudoku-api/pkg/config"
 
@@ -22,6 +23,15 @@ func main() {
 		log.Fatalf("Configuration error: %v", err)
 	}
 
+	// Calibrated technique weights must be in place before any solver is
+	// built; a bad file fails the deploy rather than silently mis-rating
+	if cfg.TechniqueWeightsFile != "" {
+		if err := human.LoadScoreWeights(cfg.TechniqueWeightsFile); err != nil {
+			log.Fatalf("Technique weights error: %v", err)
+		}
+		log.Printf("Loaded calibrated technique weights from %s", cfg.TechniqueWeightsFile)
+	}
+
 	// Load pre-generated puzzles
 	if err := puzzles.LoadGlobal(cfg.PuzzlesFile); err != nil {
 		log.Printf("Warning: Could not load puzzles from %s: %v", cfg.PuzzlesFile, err)
@@ -30,7 +40,10 @@ func main() {
 		log.Printf("Loaded %d pre-generated puzzles", puzzles.Global().Count())
 	}
 
-	r := gin.Default()
+	// The structured request log registered by RegisterRoutes replaces gin's
+	// access logger; keep the recovery middleware so panics still turn into 500s
+	r := gin.New()
+	r.Use(gin.Recovery())
 
 	httpTransport.RegisterRoutes(r, cfg)
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"sudoku-api/internal/corpus"
 	"sudoku-api/internal/sudoku/dp"
 	"sudoku-api/internal/sudoku/human"
 	"sudoku-api/pkg/constants"
@@ -22,14 +24,20 @@ type PuzzleResult struct {
 	Contradiction  bool
 	TechniqueUsage map[string]int
 	LastTechniques []string
+	Stats          *human.SolverStats
+	Failure        *corpus.FailureRecord
 }
 
-func solvePuzzle(seed int64) PuzzleResult {
+func solvePuzzle(seed int64, collectStats bool, failureOut *corpus.Writer) PuzzleResult {
 	// Generate puzzle
 	fullGrid := dp.GenerateFullGrid(seed)
-	givens := dp.CarveGivens(fullGrid, 20, seed) // 20 givens = impossible difficulty
+	impossible, _ := constants.DifficultyByName(constants.DifficultyImpossible)
+	givens := dp.CarveGivens(fullGrid, impossible.TargetGivens, seed)
 
 	solver := human.NewSolver()
+	if collectStats {
+		solver.EnableStats()
+	}
 	board := human.NewBoard(givens)
 	moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
 
@@ -67,6 +75,20 @@ func solvePuzzle(seed int64) PuzzleResult {
 		start = 0
 	}
 	result.LastTechniques = recentTechniques[start:]
+	result.Stats = solver.Stats()
+
+	// Capture the failure board for the regression corpus
+	if result.Contradiction || status != constants.StatusCompleted {
+		rec, err := corpus.NewFailureRecord(seed, impossible.TargetGivens, status, moves, board, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "seed %d: failed to build failure record: %v\n", seed, err)
+		} else {
+			result.Failure = &rec
+			if err := failureOut.Write(rec); err != nil {
+				fmt.Fprintf(os.Stderr, "seed %d: failed to write failure record: %v\n", seed, err)
+			}
+		}
+	}
 
 	return result
 }
@@ -75,8 +97,22 @@ func main() {
 	numPuzzles := flag.Int("n", 10000, "Number of puzzles to generate and test")
 	numWorkers := flag.Int("workers", 8, "Number of parallel workers")
 	startSeed := flag.Int64("seed", 1, "Starting seed")
+	collectStats := flag.Bool("stats", false, "Collect and print per-detector timing stats")
+	diagnose := flag.Bool("diagnose", false, "Dump a JSON stall report per stalled seed")
+	outPath := flag.String("out", "", "Write one NDJSON failure record per contradiction/stall to this file")
 	flag.Parse()
 
+	var failureOut *corpus.Writer
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", *outPath, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		failureOut = corpus.NewWriter(f)
+	}
+
 	fmt.Println("========================================")
 	fmt.Println("Sudoku Solver Stress Test")
 	fmt.Println("========================================")
@@ -101,7 +137,7 @@ func main() {
 		go func() {
 			defer wg.Done()
 			for seed := range jobs {
-				result := solvePuzzle(seed)
+				result := solvePuzzle(seed, *collectStats, failureOut)
 				results <- result
 				atomic.AddInt64(&completed, 1)
 			}
@@ -215,6 +251,28 @@ func main() {
 		fmt.Printf("First 20 stalled seeds: %v\n", stalledSeeds[:min(20, len(stalledSeeds))])
 	}
 
+	if *diagnose && len(stalledSeeds) > 0 {
+		fmt.Println()
+		fmt.Println("Stall diagnostics (one JSON object per seed):")
+		for _, seed := range stalledSeeds {
+			out, err := json.Marshal(map[string]interface{}{
+				"seed":   seed,
+				"report": diagnoseSeed(seed),
+			})
+			if err != nil {
+				fmt.Printf("seed %d: failed to marshal report: %v\n", seed, err)
+				continue
+			}
+			fmt.Println(string(out))
+		}
+	}
+
+	if failureOut != nil && contradictions+stalled > 0 {
+		fmt.Println()
+		fmt.Printf("Failure corpus: %d records written to %s (replay with cmd/replay_failures)\n",
+			contradictions+stalled, *outPath)
+	}
+
 	// Print technique usage
 	fmt.Println()
 	fmt.Println("Global technique usage:")
@@ -237,6 +295,10 @@ func main() {
 		}
 	}
 
+	if *collectStats {
+		printDetectorStats(allResults)
+	}
+
 	// Exit status
 	fmt.Println()
 	fmt.Println("========================================")
@@ -252,6 +314,61 @@ func main() {
 	os.Exit(0)
 }
 
+// diagnoseSeed re-runs a stalled seed to its stall point and produces the
+// solver's stall report for it
+func diagnoseSeed(seed int64) *human.StallReport {
+	fullGrid := dp.GenerateFullGrid(seed)
+	impossible, _ := constants.DifficultyByName(constants.DifficultyImpossible)
+	givens := dp.CarveGivens(fullGrid, impossible.TargetGivens, seed)
+
+	solver := human.NewSolver()
+	board := human.NewBoard(givens)
+	solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	return solver.DiagnoseStall(board)
+}
+
+// printDetectorStats merges per-puzzle solver stats and prints a table of
+// where detector time went, sorted by cumulative time
+func printDetectorStats(results []PuzzleResult) {
+	calls := make(map[string]int)
+	times := make(map[string]time.Duration)
+	hits := make(map[string]int)
+	for _, r := range results {
+		if r.Stats == nil {
+			continue
+		}
+		for slug, n := range r.Stats.DetectorCalls {
+			calls[slug] += n
+		}
+		for slug, d := range r.Stats.DetectorTime {
+			times[slug] += d
+		}
+		for slug, n := range r.Stats.Hits {
+			hits[slug] += n
+		}
+	}
+
+	var slugs []string
+	for slug := range calls {
+		slugs = append(slugs, slug)
+	}
+	sort.Slice(slugs, func(i, j int) bool {
+		return times[slugs[i]] > times[slugs[j]]
+	})
+
+	fmt.Println()
+	fmt.Println("Detector timing:")
+	fmt.Println("----------------------------------------")
+	fmt.Printf("  %-25s %10s %8s %12s %10s\n", "detector", "calls", "hits", "total", "avg")
+	for _, slug := range slugs {
+		avg := time.Duration(0)
+		if calls[slug] > 0 {
+			avg = times[slug] / time.Duration(calls[slug])
+		}
+		fmt.Printf("  %-25s %10d %8d %12v %10v\n", slug, calls[slug], hits[slug], times[slug].Round(time.Millisecond), avg.Round(time.Microsecond))
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -8,6 +8,7 @@ import (
 
 	"sudoku-api/internal/sudoku/dp"
 	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
 )
 
 func main() {
@@ -26,10 +27,10 @@ func main() {
 	})
 
 	allTechniques := []string{
-		"naked-single", "hidden-single", "pointing-pair", "box-line-reduction",
-		"naked-pair", "hidden-pair", "naked-triple", "hidden-triple",
+		"naked-single", "hidden-single", "pointing-pair", "pointing-triple", "box-line-reduction",
+		"naked-pair", "locked-pair", "hidden-pair", "naked-triple", "locked-triple", "hidden-triple",
 		"naked-quad", "hidden-quad", "x-wing", "xy-wing", "simple-coloring",
-		"swordfish", "skyscraper", "finned-x-wing", "unique-rectangle",
+		"swordfish", "skyscraper", "2-string-kite", "turbot-fish", "finned-x-wing", "unique-rectangle",
 		"bug", "jellyfish", "x-chain", "xy-chain", "w-wing", "empty-rectangle",
 	}
 
@@ -46,12 +47,10 @@ func main() {
 
 	totalStart := time.Now()
 
-	// Target givens per difficulty
-	targetGivens := map[string]int{
-		"easy":    40,
-		"medium":  34,
-		"hard":    28,
-		"extreme": 24,
+	// Target givens per difficulty, from the active spec list
+	targetGivens := map[string]int{}
+	for _, spec := range constants.DifficultySpecs() {
+		targetGivens[spec.Name] = spec.TargetGivens
 	}
 
 	for _, difficulty := range difficulties {
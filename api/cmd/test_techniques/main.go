@@ -9,60 +9,19 @@ import (
 	"sync/atomic"
 	"time"
 
+	"sudoku-api/internal/corpus"
 	"sudoku-api/internal/sudoku/dp"
 	"sudoku-api/internal/sudoku/human"
 	"sudoku-api/pkg/constants"
 )
 
-// Techniques to test - everything except "simple" tier basics
-var advancedTechniques = []string{
-	// Medium tier
-	"naked-quad",
-	"hidden-quad",
-	"x-wing",
-	"swordfish",
-	"xy-wing",
-	"xyz-wing",
-	"simple-coloring",
-	"bug",
-	"unique-rectangle",
-	// Hard tier
-	"jellyfish",
-	"skyscraper",
-	"x-chain",
-	"xy-chain",
-	"w-wing",
-	"wxyz-wing",
-	"empty-rectangle",
-	"medusa-3d",
-	"unique-rectangle-type-2",
-	"unique-rectangle-type-3",
-	"unique-rectangle-type-4",
-	// Extreme tier
-	"finned-x-wing",
-	"finned-swordfish",
-	"grouped-x-cycles",
-	"aic",
-	"als-xz",
-	"als-xy-wing",
-	"als-xy-chain",
-	"sue-de-coq",
-	"death-blossom",
-	"digit-forcing-chain",
-	"forcing-chain",
-}
+// Techniques to test - everything except "simple" tier basics. Derived from
+// the registry manifest so new techniques are stress-tested without touching
+// this file; the conformance tests in the human package freeze the lists.
+var advancedTechniques = human.NewTechniqueRegistry().AdvancedTechniqueSlugs()
 
 // Simple techniques that are always enabled as baseline
-var simpleTechniques = []string{
-	"naked-single",
-	"hidden-single",
-	"naked-pair",
-	"hidden-pair",
-	"pointing-pair",
-	"box-line-reduction",
-	"naked-triple",
-	"hidden-triple",
-}
+var simpleTechniques = human.NewTechniqueRegistry().SimpleTechniqueSlugs()
 
 type TechniqueTestResult struct {
 	Slug           string
@@ -73,7 +32,42 @@ type TechniqueTestResult struct {
 	Duration       time.Duration
 }
 
-func testTechnique(slug string, numPuzzles int, numWorkers int, startSeed int64) TechniqueTestResult {
+// targetGivens is how far puzzles are carved for the per-technique runs
+const targetGivens = 20
+
+// solvePuzzle runs one seed with only simple techniques + slug enabled,
+// returning the stop status, the target technique's usage count, and a
+// failure record when the solve contradicted or stalled.
+func solvePuzzle(slug string, seed int64) (status string, hasContradiction bool, usage int, failure *corpus.FailureRecord) {
+	fullGrid := dp.GenerateFullGrid(seed)
+	givens := dp.CarveGivens(fullGrid, targetGivens, seed)
+
+	enabled := append(append([]string{}, simpleTechniques...), slug)
+	solver := human.CreateSolverWithOnlyTechniques(enabled...)
+	board := human.NewBoard(givens)
+	moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+
+	for _, m := range moves {
+		if m.Technique == "contradiction" {
+			hasContradiction = true
+		}
+		if m.Technique == slug {
+			usage++
+		}
+	}
+
+	if hasContradiction || status != constants.StatusCompleted {
+		rec, err := corpus.NewFailureRecord(seed, targetGivens, status, moves, board, enabled)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "seed %d: failed to build failure record: %v\n", seed, err)
+		} else {
+			failure = &rec
+		}
+	}
+	return status, hasContradiction, usage, failure
+}
+
+func testTechnique(slug string, numPuzzles int, numWorkers int, startSeed int64, failureOut *corpus.Writer) TechniqueTestResult {
 	result := TechniqueTestResult{Slug: slug}
 	start := time.Now()
 
@@ -88,26 +82,7 @@ func testTechnique(slug string, numPuzzles int, numWorkers int, startSeed int64)
 		go func() {
 			defer wg.Done()
 			for seed := range jobs {
-				// Generate puzzle
-				fullGrid := dp.GenerateFullGrid(seed)
-				givens := dp.CarveGivens(fullGrid, 20, seed)
-
-				// Create solver with only simple techniques + this one technique
-				solver := human.CreateSolverWithOnlyTechniques(append(simpleTechniques, slug)...)
-				board := human.NewBoard(givens)
-				moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
-
-				// Check for contradiction
-				hasContradiction := false
-				usage := 0
-				for _, m := range moves {
-					if m.Technique == "contradiction" {
-						hasContradiction = true
-					}
-					if m.Technique == slug {
-						usage++
-					}
-				}
+				status, hasContradiction, usage, failure := solvePuzzle(slug, seed)
 
 				if hasContradiction {
 					atomic.AddInt64(&contradictions, 1)
@@ -117,6 +92,12 @@ func testTechnique(slug string, numPuzzles int, numWorkers int, startSeed int64)
 					atomic.AddInt64(&stalls, 1)
 				}
 				atomic.AddInt64(&totalUsage, int64(usage))
+
+				if failure != nil {
+					if err := failureOut.Write(*failure); err != nil {
+						fmt.Fprintf(os.Stderr, "seed %d: failed to write failure record: %v\n", seed, err)
+					}
+				}
 			}
 		}()
 	}
@@ -142,8 +123,20 @@ func main() {
 	numWorkers := flag.Int("workers", 8, "Number of parallel workers")
 	startSeed := flag.Int64("seed", 1, "Starting seed")
 	singleTechnique := flag.String("technique", "", "Test only this single technique (optional)")
+	outPath := flag.String("out", "", "Write one NDJSON failure record per contradiction/stall to this file")
 	flag.Parse()
 
+	var failureOut *corpus.Writer
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", *outPath, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		failureOut = corpus.NewWriter(f)
+	}
+
 	fmt.Println("========================================")
 	fmt.Println("Individual Technique Stress Test")
 	fmt.Println("========================================")
@@ -164,7 +157,7 @@ func main() {
 	for i, slug := range techniquesToTest {
 		fmt.Printf("[%d/%d] Testing: %s ... ", i+1, len(techniquesToTest), slug)
 
-		result := testTechnique(slug, *numPuzzles, *numWorkers, *startSeed)
+		result := testTechnique(slug, *numPuzzles, *numWorkers, *startSeed, failureOut)
 		results = append(results, result)
 
 		status := "OK"
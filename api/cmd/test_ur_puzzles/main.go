@@ -122,7 +122,7 @@ func main() {
 		} else {
 			for _, elim := range move.Eliminations {
 				idx := elim.Row*9 + elim.Col
-				board.Candidates[idx] = board.Candidates[idx].Clear(elim.Digit)
+				board.RemoveCandidate(idx, elim.Digit)
 			}
 		}
 
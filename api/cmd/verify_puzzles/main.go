@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/puzzles/verify"
+)
+
+func main() {
+	input := flag.String("f", "puzzles.json", "Puzzle file to verify")
+	workers := flag.Int("w", 0, "Number of worker goroutines (default: num CPUs)")
+	analyze := flag.Bool("analyze", false, "Also run the human solver to check technique tiers")
+	flag.Parse()
+
+	if *workers <= 0 {
+		*workers = runtime.NumCPU()
+	}
+
+	loader, err := puzzles.Load(*input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading puzzle file: %v\n", err)
+		os.Exit(1)
+	}
+	count := loader.Count()
+
+	fmt.Printf("Verifying %d puzzles from %s with %d workers...\n", count, *input, *workers)
+	start := time.Now()
+
+	var verified int64
+	var mu sync.Mutex
+	var failures []verify.Failure
+
+	// Create work channel
+	work := make(chan int, count)
+	for i := 0; i < count; i++ {
+		work <- i
+	}
+	close(work)
+
+	// Progress reporter
+	done := make(chan bool)
+	go func() {
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				v := atomic.LoadInt64(&verified)
+				elapsed := time.Since(start)
+				rate := float64(v) / elapsed.Seconds()
+				remaining := float64(count-int(v)) / rate
+				fmt.Printf("  Progress: %d/%d (%.1f/sec, ~%.0fs remaining)\n", v, count, rate, remaining)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// Worker pool
+	var wg sync.WaitGroup
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				if fails := verify.Puzzle(loader, idx, *analyze); len(fails) > 0 {
+					mu.Lock()
+					failures = append(failures, fails...)
+					mu.Unlock()
+				}
+				atomic.AddInt64(&verified, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	done <- true
+
+	elapsed := time.Since(start)
+	fmt.Printf("Verified %d puzzles in %v (%.1f puzzles/sec)\n", count, elapsed, float64(count)/elapsed.Seconds())
+
+	if len(failures) == 0 {
+		fmt.Println("All checks passed")
+		return
+	}
+
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Index < failures[j].Index })
+	fmt.Printf("\n%d failures:\n", len(failures))
+	for _, f := range failures {
+		fmt.Printf("  %s\n", f)
+	}
+	os.Exit(1)
+}
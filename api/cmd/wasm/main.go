@@ -3,18 +3,26 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"syscall/js"
+	"time"
 
 	"sudoku-api/internal/core"
+	"sudoku-api/internal/puzzles"
 	"sudoku-api/internal/sudoku/dp"
 	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/internal/tutorial"
 	"sudoku-api/pkg/constants"
 )
 
 // Global solver instance (reused across calls)
 var solver *human.Solver
 
+// Optional puzzle pack registered via loadPuzzlePack; when set,
+// getPuzzleForSeed serves from it instead of regenerating
+var packLoader *puzzles.Loader
+
 func init() {
 	solver = human.NewSolver()
 }
@@ -77,6 +85,46 @@ func int2DSliceToJSArray(slice [][]int) js.Value {
 	return arr
 }
 
+// jsArrayToAnnotations converts a JS array of {colorIndex, cornerMarks,
+// centerMarks} objects into cell annotations. Returns nil for null/undefined.
+func jsArrayToAnnotations(arr js.Value) []core.CellAnnotation {
+	if arr.IsNull() || arr.IsUndefined() {
+		return nil
+	}
+	length := arr.Length()
+	result := make([]core.CellAnnotation, length)
+	for i := 0; i < length; i++ {
+		entry := arr.Index(i)
+		if entry.IsNull() || entry.IsUndefined() {
+			continue
+		}
+		if color := entry.Get("colorIndex"); !color.IsNull() && !color.IsUndefined() {
+			result[i].ColorIndex = uint8(color.Int())
+		}
+		if marks := entry.Get("cornerMarks"); !marks.IsNull() && !marks.IsUndefined() {
+			result[i].CornerMarks = jsArrayToIntSlice(marks)
+		}
+		if marks := entry.Get("centerMarks"); !marks.IsNull() && !marks.IsUndefined() {
+			result[i].CenterMarks = jsArrayToIntSlice(marks)
+		}
+	}
+	return result
+}
+
+// annotationsToJSArray converts cell annotations back into the JS object
+// shape jsArrayToAnnotations accepts
+func annotationsToJSArray(annotations []core.CellAnnotation) js.Value {
+	arr := js.Global().Get("Array").New(len(annotations))
+	for i, a := range annotations {
+		obj := js.Global().Get("Object").New()
+		obj.Set("colorIndex", int(a.ColorIndex))
+		obj.Set("cornerMarks", intSliceToJSArray(a.CornerMarks))
+		obj.Set("centerMarks", intSliceToJSArray(a.CenterMarks))
+		arr.SetIndex(i, obj)
+	}
+	return arr
+}
+
 // errorToJS creates a JS object with an error field
 func errorToJS(msg string) js.Value {
 	obj := js.Global().Get("Object").New()
@@ -84,6 +132,53 @@ func errorToJS(msg string) js.Value {
 	return obj
 }
 
+// unsolvableToJS renders a dp.UnsolvableReport: the classification plus
+// either the conflicting given pairs or the removal hints pointing at the
+// likely mis-transcribed given
+func unsolvableToJS(report *dp.UnsolvableReport) js.Value {
+	obj := js.Global().Get("Object").New()
+	obj.Set("reason", report.Reason)
+
+	if len(report.Conflicts) > 0 {
+		conflicts := js.Global().Get("Array").New(len(report.Conflicts))
+		for i, conflict := range report.Conflicts {
+			entry := js.Global().Get("Object").New()
+			entry.Set("cell1", cellIndexToJS(conflict.Cell1))
+			entry.Set("cell2", cellIndexToJS(conflict.Cell2))
+			entry.Set("digit", conflict.Value)
+			entry.Set("type", conflict.Type)
+			conflicts.SetIndex(i, entry)
+		}
+		obj.Set("conflicts", conflicts)
+	}
+
+	if len(report.RemovableGivens) > 0 {
+		hints := js.Global().Get("Array").New(len(report.RemovableGivens))
+		for i, cell := range report.RemovableGivens {
+			hints.SetIndex(i, cellIndexToJS(cell))
+		}
+		obj.Set("removalHints", hints)
+	}
+	return obj
+}
+
+// cellIndexToJS renders a flat cell index as a {row, col} object
+func cellIndexToJS(cell int) js.Value {
+	obj := js.Global().Get("Object").New()
+	obj.Set("row", cell/constants.GridSize)
+	obj.Set("col", cell%constants.GridSize)
+	return obj
+}
+
+// stringSliceToJSArray converts []string to JS array
+func stringSliceToJSArray(s []string) js.Value {
+	arr := js.Global().Get("Array").New(len(s))
+	for i, v := range s {
+		arr.SetIndex(i, v)
+	}
+	return arr
+}
+
 // stringIntMapToJS converts map[string]int to JS object
 func stringIntMapToJS(m map[string]int) js.Value {
 	obj := js.Global().Get("Object").New()
@@ -155,6 +250,18 @@ func highlightsToJS(h core.Highlights) js.Value {
 	if len(h.Secondary) > 0 {
 		obj.Set("secondary", cellRefSliceToJS(h.Secondary))
 	}
+	if len(h.Target) > 0 {
+		obj.Set("target", cellRefSliceToJS(h.Target))
+	}
+	if len(h.Defining) > 0 {
+		obj.Set("defining", cellRefSliceToJS(h.Defining))
+	}
+	if len(h.Justifying) > 0 {
+		obj.Set("justifying", candidateSliceToJS(h.Justifying))
+	}
+	if len(h.Eliminated) > 0 {
+		obj.Set("eliminated", candidateSliceToJS(h.Eliminated))
+	}
 	return obj
 }
 
@@ -190,12 +297,31 @@ func moveToJS(m *core.Move) js.Value {
 	obj.Set("action", m.Action)
 	obj.Set("digit", m.Digit)
 	obj.Set("targets", cellRefSliceToJS(m.Targets))
+	if len(m.TargetCandidates) > 0 {
+		obj.Set("target_candidates", int2DSliceToJSArray(m.TargetCandidates))
+	}
 	if len(m.Eliminations) > 0 {
 		obj.Set("eliminations", candidateSliceToJS(m.Eliminations))
 	}
 	obj.Set("explanation", m.Explanation)
 	obj.Set("refs", techniqueRefToJS(m.Refs))
+	if m.Tier != "" {
+		obj.Set("tier", m.Tier)
+		obj.Set("score", m.Score)
+	}
 	obj.Set("highlights", highlightsToJS(m.Highlights))
+	if m.Indirect {
+		obj.Set("indirect", true)
+	}
+	return obj
+}
+
+// solveSummaryToJS converts human.SolveSummary to a JS object
+func solveSummaryToJS(summary human.SolveSummary) js.Value {
+	obj := js.Global().Get("Object").New()
+	obj.Set("toughest_move", summary.ToughestMove)
+	obj.Set("tier_counts", stringIntMapToJS(summary.TierCounts))
+	obj.Set("score", summary.Score)
 	return obj
 }
 
@@ -292,6 +418,41 @@ func moveResultSliceToJS(results []MoveResult) js.Value {
 	return arr
 }
 
+// moveDiffToJS converts a core.MoveDiff to a JS object
+func moveDiffToJS(diff core.MoveDiff) js.Value {
+	obj := js.Global().Get("Object").New()
+	cells := js.Global().Get("Array").New(len(diff.Cells))
+	for i, cd := range diff.Cells {
+		cellObj := js.Global().Get("Object").New()
+		cellObj.Set("index", cd.Index)
+		cellObj.Set("old", cd.Old)
+		cellObj.Set("new", cd.New)
+		cells.SetIndex(i, cellObj)
+	}
+	obj.Set("cells", cells)
+	candObj := js.Global().Get("Object").New()
+	candObj.Set("added", candidateSliceToJS(diff.Candidates.Added))
+	candObj.Set("removed", candidateSliceToJS(diff.Candidates.Removed))
+	obj.Set("candidates", candObj)
+	return obj
+}
+
+// deltaMoveResultSliceToJS converts []MoveResult to a JS array of delta
+// entries: each carries the move plus a diff against the previous state
+// instead of full board and candidate snapshots
+func deltaMoveResultSliceToJS(initialCells []int, initialCands [][]int, results []MoveResult) js.Value {
+	prevCells, prevCands := initialCells, initialCands
+	arr := js.Global().Get("Array").New(len(results))
+	for i, r := range results {
+		obj := js.Global().Get("Object").New()
+		obj.Set("move", r.Move)
+		obj.Set("diff", moveDiffToJS(human.DiffStates(prevCells, prevCands, r.Board, r.Candidates)))
+		arr.SetIndex(i, obj)
+		prevCells, prevCands = r.Board, r.Candidates
+	}
+	return arr
+}
+
 // errorMoveToJS creates an error move JS object
 func errorMoveToJS(explanation string) js.Value {
 	obj := js.Global().Get("Object").New()
@@ -420,6 +581,12 @@ type solveResult struct {
 	solved          bool
 	finalBoard      []int
 	finalCandidates [][]int
+	// err reports a move that failed validation during the solve — a solver
+	// bug surfaced to the caller instead of a silently corrupted board
+	err error
+	// unsolvable is set when the givens admit no solution; no moves are
+	// attempted and the report classifies the failure
+	unsolvable *dp.UnsolvableReport
 }
 
 // ==================== Human Solver Functions ====================
@@ -441,6 +608,7 @@ func createBoard(this js.Value, args []js.Value) interface{} {
 	obj := js.Global().Get("Object").New()
 	obj.Set("cells", intSliceToJSArray(board.GetCells()))
 	obj.Set("candidates", int2DSliceToJSArray(board.GetCandidates()))
+	obj.Set("marks", intSliceToJSArray(board.GetMarks()))
 	return obj
 }
 
@@ -467,6 +635,9 @@ func createBoardWithCandidates(this js.Value, args []js.Value) interface{} {
 
 	// Call the internal solver with maxMoves=1
 	result := solveAllInternal(cells, candidates, givens, 1)
+	if result.err != nil {
+		return errorToJS(result.err.Error())
+	}
 
 	// Return first move only (or nil if no moves)
 	var move js.Value
@@ -486,6 +657,9 @@ func createBoardWithCandidates(this js.Value, args []js.Value) interface{} {
 	boardObj := js.Global().Get("Object").New()
 	boardObj.Set("cells", intSliceToJSArray(boardCells))
 	boardObj.Set("candidates", int2DSliceToJSArray(boardCandidates))
+	// Marks provenance reflects the submitted state: which cells carry the
+	// user's own pencil marks (1) versus solver-filled or unset ones
+	boardObj.Set("marks", intSliceToJSArray(human.NewBoardWithCandidates(cells, candidates).GetMarks()))
 
 	obj := js.Global().Get("Object").New()
 	obj.Set("move", move)
@@ -494,9 +668,52 @@ func createBoardWithCandidates(this js.Value, args []js.Value) interface{} {
 	return obj
 }
 
+// applyTierCascade runs every detector at or below a tier cap until none
+// fires, applying eliminations as it goes - the auto-pencil-mark assist.
+// Assignments are skipped unless allowAssignments is set.
+// Input: cells (number[%d]), candidates (number[%d][] or null), maxTier (string, default "simple"), allowAssignments (boolean, default false)
+// Output: { moves: Move[], board: { cells: number[%d], candidates: number[%d][] } }
+func applyTierCascade(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("cells required")
+	}
+
+	cells := jsArrayToIntSlice(args[0])
+	if len(cells) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("cells must have %d elements", constants.TotalCells))
+	}
+
+	var board *human.Board
+	if len(args) > 1 && !args[1].IsNull() && !args[1].IsUndefined() {
+		board = human.NewBoardWithCandidates(cells, jsArrayTo2DIntSlice(args[1]))
+	} else {
+		board = human.NewBoard(cells)
+	}
+
+	maxTier := constants.TierSimple
+	if len(args) > 2 && args[2].Type() == js.TypeString {
+		maxTier = args[2].String()
+	}
+	allowAssignments := len(args) > 3 && args[3].Truthy()
+
+	moves, err := human.NewSolver().ApplyTierCascade(board, maxTier, allowAssignments)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	boardObj := js.Global().Get("Object").New()
+	boardObj.Set("cells", intSliceToJSArray(board.GetCells()))
+	boardObj.Set("candidates", int2DSliceToJSArray(board.GetCandidates()))
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("moves", moveSliceToJS(moves))
+	obj.Set("board", boardObj)
+	return obj
+}
+
 // solveWithSteps solves the puzzle returning all steps
 // Input: givens (number[%d]), maxSteps (number)
-// Output: { moves: Move[], status: string, finalBoard: number[%d] }
+// Output: { moves: Move[], status: string, finalBoard: number[%d], solved: boolean, remaining: number, canContinue: boolean, suggestedMaxSteps?: number, cycleMove?: Move, summary: SolveSummary }
 func solveWithSteps(this js.Value, args []js.Value) interface{} {
 	if len(args) < 1 {
 		return errorToJS("givens required")
@@ -513,19 +730,72 @@ func solveWithSteps(this js.Value, args []js.Value) interface{} {
 	}
 
 	board := human.NewBoard(givens)
-	moves, status := solver.SolveWithSteps(board, maxSteps)
+	result := solver.SolveWithStepsResult(board, maxSteps)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("moves", moveSliceToJS(result.Moves))
+	obj.Set("status", result.Status)
+	obj.Set("finalBoard", intSliceToJSArray(board.GetCells()))
+	obj.Set("solved", board.IsSolved())
+	obj.Set("remaining", result.Remaining)
+	obj.Set("canContinue", result.CanContinue)
+	if result.CanContinue {
+		obj.Set("suggestedMaxSteps", result.SuggestedMaxSteps)
+	}
+	if result.CycleMove != nil {
+		obj.Set("cycleMove", moveToJS(result.CycleMove))
+	}
+	obj.Set("summary", solveSummaryToJS(solver.Summarize(result.Moves)))
+	return obj
+}
+
+// solveWithCallback is solveWithSteps with a per-move callback, the WASM
+// counterpart of the streaming solve endpoint (the browser side can't consume
+// SSE from a worker, so it gets the callback surface directly). onMove is
+// invoked with each move and the board cells after it; returning false aborts
+// the solve and status comes back "aborted".
+// Input: givens (number[%d]), onMove ((move: Move, board: number[%d]) => boolean), maxSteps (number)
+// Output: { moves: Move[], status: string, finalBoard: number[%d], solved: boolean, summary: SolveSummary }
+func solveWithCallback(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return errorToJS("givens and onMove callback required")
+	}
+
+	givens := jsArrayToIntSlice(args[0])
+	if len(givens) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("givens must have %d elements", constants.TotalCells))
+	}
+
+	onMove := args[1]
+	if onMove.Type() != js.TypeFunction {
+		return errorToJS("onMove must be a function")
+	}
+
+	maxSteps := 2000
+	if len(args) >= 3 {
+		maxSteps = args[2].Int()
+	}
+
+	board := human.NewBoard(givens)
+	moves, status := solver.SolveWithStepsFunc(board, maxSteps, func(move core.Move, cells []int) bool {
+		result := onMove.Invoke(moveToJS(&move), intSliceToJSArray(cells))
+		// Anything but an explicit false keeps the solve running, so
+		// callbacks that return nothing behave as pure progress hooks
+		return !(result.Type() == js.TypeBoolean && !result.Bool())
+	})
 
 	obj := js.Global().Get("Object").New()
 	obj.Set("moves", moveSliceToJS(moves))
 	obj.Set("status", status)
 	obj.Set("finalBoard", intSliceToJSArray(board.GetCells()))
 	obj.Set("solved", board.IsSolved())
+	obj.Set("summary", solveSummaryToJS(solver.Summarize(moves)))
 	return obj
 }
 
 // analyzePuzzle analyzes a puzzle and returns difficulty and technique counts
 // Input: givens (number[%d])
-// Output: { difficulty: string, techniques: { [name]: count }, status: string }
+// Output: { difficulty: string, techniques: { [name]: count }, status: string, uniquenessDependent: boolean, summary: SolveSummary }
 func analyzePuzzle(this js.Value, args []js.Value) interface{} {
 	if len(args) < 1 {
 		return errorToJS("givens required")
@@ -536,12 +806,14 @@ func analyzePuzzle(this js.Value, args []js.Value) interface{} {
 		return errorToJS(fmt.Sprintf("givens must have %d elements", constants.TotalCells))
 	}
 
-	difficulty, techniques, status := solver.AnalyzePuzzleDifficulty(givens)
+	moves, difficulty, techniques, usedUniqueness, status := solver.AnalyzePuzzleSolve(givens)
 
 	obj := js.Global().Get("Object").New()
 	obj.Set("difficulty", difficulty)
 	obj.Set("techniques", stringIntMapToJS(techniques))
 	obj.Set("status", status)
+	obj.Set("uniquenessDependent", usedUniqueness)
+	obj.Set("summary", solveSummaryToJS(solver.Summarize(moves)))
 	return obj
 }
 
@@ -617,13 +889,24 @@ func findConflicts(this js.Value, args []js.Value) interface{} {
 	return conflictSliceToJS(conflicts)
 }
 
+// seedFromJS converts a JS seed argument to int64. String seeds are hashed
+// with the FNV path and are the preferred form: JS numbers travel through
+// float64, which silently loses precision above 2^53 and breaks determinism
+// against the server. Numeric seeds remain supported for old callers.
+func seedFromJS(arg js.Value) int64 {
+	if arg.Type() == js.TypeString {
+		return hashSeed(arg.String())
+	}
+	return int64(arg.Float())
+}
+
 // generateFullGrid generates a complete valid sudoku grid
-// Input: seed (number)
+// Input: seed (string | number; strings are FNV-hashed and preferred)
 // Output: number[%d]
 func generateFullGrid(this js.Value, args []js.Value) interface{} {
 	seed := int64(0)
 	if len(args) >= 1 {
-		seed = int64(args[0].Float()) // Use Float for larger numbers
+		seed = seedFromJS(args[0])
 	}
 
 	grid := dp.GenerateFullGrid(seed)
@@ -631,7 +914,7 @@ func generateFullGrid(this js.Value, args []js.Value) interface{} {
 }
 
 // carveGivens creates a puzzle from a full grid
-// Input: fullGrid (number[%d]), targetGivens (number), seed (number)
+// Input: fullGrid (number[%d]), targetGivens (number), seed (string | number)
 // Output: number[%d]
 func carveGivens(this js.Value, args []js.Value) interface{} {
 	if len(args) < 3 {
@@ -644,15 +927,15 @@ func carveGivens(this js.Value, args []js.Value) interface{} {
 	}
 
 	targetGivens := args[1].Int()
-	seed := int64(args[2].Float())
+	seed := seedFromJS(args[2])
 
 	puzzle := dp.CarveGivens(fullGrid, targetGivens, seed)
 	return intSliceToJSArray(puzzle)
 }
 
 // carveGivensWithSubset generates puzzles for all difficulty levels
-// Input: fullGrid (number[%d]), seed (number)
-// Output: { easy: number[%d], medium: number[%d], hard: number[%d], extreme: number[%d], impossible: number[%d] }
+// Input: fullGrid (number[%d]), seed (string | number)
+// Output: { easy: number[%d], medium: number[%d], hard: number[%d], extreme: number[%d], impossible: number[%d], degraded: { [difficulty]: boolean } }
 func carveGivensWithSubset(this js.Value, args []js.Value) interface{} {
 	if len(args) < 2 {
 		return js.Null()
@@ -663,23 +946,30 @@ func carveGivensWithSubset(this js.Value, args []js.Value) interface{} {
 		return js.Null()
 	}
 
-	seed := int64(args[1].Float())
+	seed := seedFromJS(args[1])
 
-	puzzles := dp.CarveGivensWithSubset(fullGrid, seed)
+	puzzles, degraded := dp.CarveGivensWithSubset(fullGrid, seed)
 
 	// Build JS object explicitly
 	obj := js.Global().Get("Object").New()
 	for diff, givens := range puzzles {
 		obj.Set(diff, intSliceToJSArray(givens))
 	}
+	degradedObj := js.Global().Get("Object").New()
+	for diff, flag := range degraded {
+		degradedObj.Set(diff, flag)
+	}
+	obj.Set("degraded", degradedObj)
 	return obj
 }
 
 // ==================== Combined Solve Functions ====================
 
 // solveAll solves from current state, returning all moves (like /api/solve/all)
-// Input: cells (number[%d]), candidates (number[%d][]), givens (number[%d])
+// Input: cells (number[%d]), candidates (number[%d][]), givens (number[%d]), delta (boolean, optional)
 // Output: { moves: MoveResult[], solved: boolean, finalBoard: number[%d] }
+// When delta is true, moves carry diffs instead of full board/candidates
+// snapshots and the result includes initialBoard and initialCandidates
 func solveAll(this js.Value, args []js.Value) interface{} {
 	if len(args) < 3 {
 		return errorToJS("cells, candidates, and givens required")
@@ -696,12 +986,34 @@ func solveAll(this js.Value, args []js.Value) interface{} {
 		return errorToJS(fmt.Sprintf("givens must have %d elements", constants.TotalCells))
 	}
 
+	delta := len(args) > 3 && args[3].Truthy()
+
 	// Call internal implementation with default maxMoves
 	result := solveAllInternal(cells, candidates, givens, 2000)
+	if result.err != nil {
+		return errorToJS(result.err.Error())
+	}
+	if result.unsolvable != nil {
+		obj := js.Global().Get("Object").New()
+		obj.Set("unsolvable", unsolvableToJS(result.unsolvable))
+		obj.Set("moves", js.Global().Get("Array").New(0))
+		obj.Set("solved", false)
+		obj.Set("finalBoard", intSliceToJSArray(result.finalBoard))
+		obj.Set("finalCandidates", int2DSliceToJSArray(result.finalCandidates))
+		return obj
+	}
 
 	// Build result object explicitly
 	obj := js.Global().Get("Object").New()
-	obj.Set("moves", moveResultSliceToJS(result.moves))
+	if delta {
+		// The first move diffs against the state as submitted
+		obj.Set("format", "delta")
+		obj.Set("initialBoard", intSliceToJSArray(cells))
+		obj.Set("initialCandidates", int2DSliceToJSArray(candidates))
+		obj.Set("moves", deltaMoveResultSliceToJS(cells, candidates, result.moves))
+	} else {
+		obj.Set("moves", moveResultSliceToJS(result.moves))
+	}
 	obj.Set("solved", result.solved)
 	obj.Set("finalBoard", intSliceToJSArray(result.finalBoard))
 	obj.Set("finalCandidates", int2DSliceToJSArray(result.finalCandidates))
@@ -712,6 +1024,13 @@ func solveAll(this js.Value, args []js.Value) interface{} {
 // It accepts a maxMoves parameter to limit the number of moves returned
 // When maxMoves=1, this enables efficient single-move hints
 func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLimit int) solveResult {
+	// Givens that admit no solution (typically a board imported with a
+	// mis-transcribed given) get a structured verdict before any technique
+	// runs, instead of a hint that marches into a contradiction
+	if report := dp.AnalyzeUnsolvable(givens); report != nil {
+		return solveResult{finalBoard: cells, finalCandidates: candidates, unsolvable: report}
+	}
+
 	// Reset solver state to ensure each request starts fresh.
 	// This prevents state from previous hint requests (e.g., Technique Hint)
 	// from affecting subsequent requests (e.g., Regular Hint).
@@ -781,7 +1100,13 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 						Candidates: board.GetCandidates(),
 						Move: fixErrorMoveToJS(
 							badDigit,
-							formatExplanation("R%dC%d and R%dC%d both have %d in the same %s. Removing %d from R%dC%d.", cell1Row+1, cell1Col+1, cell2Row+1, cell2Col+1, badDigit, conflict.Type, badDigit, badRow+1, badCol+1),
+							renderExplanation("solver.conflict.remove", map[string]interface{}{
+								"cell1":    cellLabel(cell1Row, cell1Col),
+								"cell2":    cellLabel(cell2Row, cell2Col),
+								"digit":    badDigit,
+								"unitType": conflict.Type,
+								"cell":     cellLabel(badRow, badCol),
+							}),
 							badRow, badCol,
 							[][]int{{cell1Row, cell1Col}, {cell2Row, cell2Col}},
 							nil,
@@ -837,7 +1162,11 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 					Candidates: board.GetCandidates(),
 					Move: fixErrorMoveToJS(
 						badDigit,
-						formatExplanation("Solver stuck! R%dC%d has no valid candidates. The %d at R%dC%d was causing the problem.", zeroCandRow+1, zeroCandCol+1, badDigit, badRow+1, badCol+1),
+						renderExplanation("solver.stuck.no-candidates", map[string]interface{}{
+							"cell":   cellLabel(zeroCandRow, zeroCandCol),
+							"digit":  badDigit,
+							"source": cellLabel(badRow, badCol),
+						}),
 						badRow, badCol,
 						[][]int{{badRow, badCol}},
 						[][]int{{zeroCandRow, zeroCandCol}},
@@ -861,7 +1190,10 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 					Candidates: board.GetCandidates(),
 					Move: fixErrorMoveToJS(
 						badDigit,
-						formatExplanation("Solver stuck! Removing %d from R%dC%d allows progress.", badDigit, badRow+1, badCol+1),
+						renderExplanation("solver.stuck.remove", map[string]interface{}{
+							"digit": badDigit,
+							"cell":  cellLabel(badRow, badCol),
+						}),
 						badRow, badCol,
 						[][]int{{badRow, badCol}},
 						nil,
@@ -899,7 +1231,10 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 						Candidates: board.GetCandidates(),
 						Move: fixCandidateMoveToJS(
 							missingDigit,
-							formatExplanation("Solver stuck! The candidate %d was incorrectly removed from R%dC%d. Restoring it.", missingDigit, row+1, col+1),
+							renderExplanation("solver.stuck.restore", map[string]interface{}{
+								"digit": missingDigit,
+								"cell":  cellLabel(row, col),
+							}),
 							row, col,
 						),
 					})
@@ -930,7 +1265,7 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 			// Try to find the blocking user cell
 			if len(move.Targets) > 0 {
 				contradictionCell := move.Targets[0].Row*constants.GridSize + move.Targets[0].Col
-				badCell, badDigit := findBlockingUserCell(board, contradictionCell, originalUserBoard, givens)
+				badCell, badDigit := human.FindBlockingUserCell(board, contradictionCell, originalUserBoard, givens, dp.Solve(givens))
 
 				if badCell >= 0 {
 					badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
@@ -946,7 +1281,10 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 						Candidates: board.GetCandidates(),
 						Move: fixErrorMoveToJS(
 							badDigit,
-							formatExplanation("Removing incorrect %d from R%dC%d.", badDigit, badRow+1, badCol+1),
+							renderExplanation("solver.remove-incorrect", map[string]interface{}{
+								"digit": badDigit,
+								"cell":  cellLabel(badRow, badCol),
+							}),
 							badRow, badCol,
 							[][]int{{badRow, badCol}},
 							[][]int{{move.Targets[0].Row, move.Targets[0].Col}},
@@ -973,7 +1311,11 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 					Candidates: board.GetCandidates(),
 					Move: fixErrorMoveToJS(
 						badDigit,
-						formatExplanation("Found it! R%dC%d has no valid candidates. The %d at R%dC%d was causing the problem.", zeroCandRow+1, zeroCandCol+1, badDigit, badRow+1, badCol+1),
+						renderExplanation("solver.contradiction.found", map[string]interface{}{
+							"cell":   cellLabel(zeroCandRow, zeroCandCol),
+							"digit":  badDigit,
+							"source": cellLabel(badRow, badCol),
+						}),
 						badRow, badCol,
 						[][]int{{badRow, badCol}},
 						[][]int{{zeroCandRow, zeroCandCol}},
@@ -997,7 +1339,10 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 					Candidates: board.GetCandidates(),
 					Move: fixErrorMoveToJS(
 						badDigit,
-						formatExplanation("Contradiction detected! Removing %d from R%dC%d allows progress.", badDigit, badRow+1, badCol+1),
+						renderExplanation("solver.contradiction.remove", map[string]interface{}{
+							"digit": badDigit,
+							"cell":  cellLabel(badRow, badCol),
+						}),
 						badRow, badCol,
 						[][]int{{badRow, badCol}},
 						nil,
@@ -1016,7 +1361,14 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 		}
 
 		// Apply normal move
-		solver.ApplyMove(board, move)
+		if _, err := solver.ApplyMove(board, move); err != nil {
+			return solveResult{
+				moves:           moves,
+				finalBoard:      board.GetCells(),
+				finalCandidates: board.GetCandidates(),
+				err:             fmt.Errorf("solver produced an invalid move (%s): %w", move.Technique, err),
+			}
+		}
 		moves = append(moves, MoveResult{
 			Board:      board.GetCells(),
 			Candidates: board.GetCandidates(),
@@ -1056,7 +1408,12 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 				Candidates: board.GetCandidates(),
 				Move: fixErrorMoveToJS(
 					conflict.Value,
-					formatExplanation("R%dC%d and R%dC%d both have %d in the same %s.", cell1Row+1, cell1Col+1, cell2Row+1, cell2Col+1, conflict.Value, conflict.Type),
+					renderExplanation("solver.conflict.pair", map[string]interface{}{
+						"cell1":    cellLabel(cell1Row, cell1Col),
+						"cell2":    cellLabel(cell2Row, cell2Col),
+						"digit":    conflict.Value,
+						"unitType": conflict.Type,
+					}),
 					badRow, badCol,
 					[][]int{{cell1Row, cell1Col}, {cell2Row, cell2Col}},
 					nil,
@@ -1072,81 +1429,12 @@ func solveAllInternal(cells []int, candidates [][]int, givens []int, maxMovesLim
 
 	return solveResult{
 		moves:           moves,
-		solved:          board.IsSolved() && dp.IsValid(finalCells),
+		solved:          boardCompleted(finalCells),
 		finalBoard:      finalCells,
 		finalCandidates: board.GetCandidates(),
 	}
 }
 
-// findBlockingUserCell finds which user-entered cell is blocking candidates
-func findBlockingUserCell(board *human.Board, contradictionCell int, originalUserBoard []int, givens []int) (int, int) {
-	row, col := contradictionCell/constants.GridSize, contradictionCell%constants.GridSize
-	boxRow, boxCol := (row/3)*3, (col/3)*3
-
-	type blockingCell struct {
-		idx   int
-		digit int
-	}
-	var userBlockers []blockingCell
-
-	for digit := 1; digit <= 9; digit++ {
-		// Check row
-		for c := 0; c < 9; c++ {
-			idx := row*constants.GridSize + c
-			if board.Cells[idx] == digit && originalUserBoard[idx] != 0 && givens[idx] == 0 {
-				userBlockers = append(userBlockers, blockingCell{idx, digit})
-				break
-			}
-		}
-
-		// Check column
-		for r := 0; r < 9; r++ {
-			idx := r*constants.GridSize + col
-			if board.Cells[idx] == digit && originalUserBoard[idx] != 0 && givens[idx] == 0 {
-				userBlockers = append(userBlockers, blockingCell{idx, digit})
-				break
-			}
-		}
-
-		// Check box
-		for r := boxRow; r < boxRow+3; r++ {
-			for c := boxCol; c < boxCol+3; c++ {
-				idx := r*constants.GridSize + c
-				if board.Cells[idx] == digit && originalUserBoard[idx] != 0 && givens[idx] == 0 {
-					userBlockers = append(userBlockers, blockingCell{idx, digit})
-					break
-				}
-			}
-		}
-	}
-
-	if len(userBlockers) == 0 {
-		return -1, 0
-	}
-
-	// Find cell blocking the most candidates
-	cellCount := make(map[int]int)
-	cellDigit := make(map[int]int)
-	for _, b := range userBlockers {
-		cellCount[b.idx]++
-		cellDigit[b.idx] = b.digit
-	}
-
-	maxCount := 0
-	maxCell := -1
-	for idx, count := range cellCount {
-		if count > maxCount {
-			maxCount = count
-			maxCell = idx
-		}
-	}
-
-	if maxCell >= 0 {
-		return maxCell, cellDigit[maxCell]
-	}
-	return -1, 0
-}
-
 // findErrorByCandidateRefill clears all candidates, refills them, and looks for cells with zero candidates.
 // This is the "human-like" approach: when stuck, clear your pencil marks and start fresh.
 // If a cell has zero candidates, trace back to find which user-entered cell is blocking it.
@@ -1321,41 +1609,19 @@ func findMissingCandidates(cells []int, userCandidates [][]int, solver *human.So
 	return -1, 0, false
 }
 
-// formatExplanation provides sprintf-style formatting for error and move explanations
-//
-// This is a lightweight string formatter for WebAssembly environments where
-// full fmt.Sprintf may not be available. Supports %d (int) and %s (string) placeholders.
-//
-// Parameters:
-//
-//	format: String with %d/%s placeholders
-//	args: Variable arguments for placeholder replacement
-//
-// Returns: Formatted string with all placeholders replaced
-func formatExplanation(format string, args ...interface{}) string {
-	// Simple implementation for common case
-	result := format
-	for _, arg := range args {
-		switch v := arg.(type) {
-		case int:
-			// Replace first %d
-			for i := 0; i < len(result)-1; i++ {
-				if result[i] == '%' && result[i+1] == 'd' {
-					result = result[:i] + intToString(v) + result[i+2:]
-					break
-				}
-			}
-		case string:
-			// Replace first %s
-			for i := 0; i < len(result)-1; i++ {
-				if result[i] == '%' && result[i+1] == 's' {
-					result = result[:i] + v + result[i+2:]
-					break
-				}
-			}
-		}
+// renderExplanation renders a message key with the shared English templates
+// from internal/core, falling back to the key itself on error
+func renderExplanation(key string, params map[string]interface{}) string {
+	s, err := core.RenderExplanation(key, params, core.DefaultLang)
+	if err != nil {
+		return key
 	}
-	return result
+	return s
+}
+
+// cellLabel formats a 0-based row/col pair as R#C# for explanations
+func cellLabel(row, col int) string {
+	return "R" + intToString(row+1) + "C" + intToString(col+1)
 }
 
 // intToString converts an integer to its decimal string representation
@@ -1444,7 +1710,9 @@ func validationResultWithSolutionToJS(valid bool, unique bool, solution []int) j
 
 // validateCustomPuzzle validates a custom puzzle
 // Input: givens (number[%d])
-// Output: { valid: boolean, unique?: boolean, reason?: string, puzzleId?: string }
+// Output: { valid: boolean, unique?: boolean, reason?: string, puzzleId?: string,
+//
+//	solutionCount?: number | string, differingCells?: number[] }
 func validateCustomPuzzle(this js.Value, args []js.Value) interface{} {
 	if len(args) < 1 {
 		return validationResultToJS(false, "givens required")
@@ -1472,15 +1740,27 @@ func validateCustomPuzzle(this js.Value, args []js.Value) interface{} {
 		return validationResultToJS(false, "puzzle contains conflicts")
 	}
 
-	// Check solvability
-	solutions := dp.CountSolutions(givens, 2)
+	// Check solvability, sampling the solution space so authors can tell a
+	// near-fixable puzzle from a structurally broken one
+	sample := dp.SampleSolutions(givens, constants.SolutionSampleLimit,
+		constants.SolutionSampleBudgetMs*time.Millisecond)
 
-	if solutions == 0 {
+	if sample.Count == 0 {
 		return validationResultToJS(false, "puzzle has no solution")
 	}
 
-	if solutions > 1 {
-		return validationResultWithUniqueToJS(true, false, "puzzle has multiple solutions")
+	if sample.Count > 1 {
+		obj := validationResultWithUniqueToJS(true, false, "puzzle has multiple solutions")
+		if sample.Capped {
+			obj.Set("solutionCount", fmt.Sprintf(">=%d", sample.Count))
+		} else {
+			obj.Set("solutionCount", sample.Count)
+			if sample.Count == 2 {
+				// One given on any differing cell makes the puzzle unique
+				obj.Set("differingCells", intSliceToJSArray(sample.DifferingCells()))
+			}
+		}
+		return obj
 	}
 
 	// Solve to get the solution
@@ -1536,6 +1816,173 @@ func validateBoard(this js.Value, args []js.Value) interface{} {
 	return obj
 }
 
+// isWinnable answers only whether the game can still reach a solution,
+// without revealing which cells are wrong - the spoiler-free counterpart of
+// validateBoard. Strictness "strict" (default) checks entries against the
+// provided solution; "loose" asks whether any completion exists, treating
+// user entries as givens (the right mode for non-unique custom puzzles).
+// Input: board (number[81]), solution (number[81] | null), strictness ("strict" | "loose")
+// Output: { winnable: bool } - deliberately nothing else
+func isWinnable(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("board required")
+	}
+
+	board := jsArrayToIntSlice(args[0])
+	if len(board) != constants.TotalCells {
+		return errorToJS("board must have " + intToString(constants.TotalCells) + " elements")
+	}
+
+	strictness := "strict"
+	if len(args) > 2 && args[2].Type() == js.TypeString {
+		strictness = args[2].String()
+	}
+
+	var winnable bool
+	switch strictness {
+	case "strict":
+		if len(args) < 2 || args[1].Type() != js.TypeObject {
+			return errorToJS("solution required for strict mode")
+		}
+		solution := jsArrayToIntSlice(args[1])
+		if len(solution) != constants.TotalCells {
+			return errorToJS("solution must have " + intToString(constants.TotalCells) + " elements")
+		}
+		winnable = true
+		for i, v := range board {
+			if v != 0 && v != solution[i] {
+				winnable = false
+				break
+			}
+		}
+	case "loose":
+		winnable = dp.CountSolutions(board, 1) > 0
+	default:
+		return errorToJS("strictness must be strict or loose")
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("winnable", winnable)
+	return obj
+}
+
+// checkCandidates diffs the player's pencil marks against the true candidate set
+// Input: board (number[%d]), candidates (number[][]), givens (number[%d]), strictness ("peers" | "solution")
+// Output: { strictness: string, wrongMarks: Candidate[], missingMarks: Candidate[], cellsWithIssues: number[] }
+func checkCandidates(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return errorToJS("board and candidates required")
+	}
+
+	board := jsArrayToIntSlice(args[0])
+	candidates := jsArrayTo2DIntSlice(args[1])
+
+	var givens []int
+	if len(args) > 2 && args[2].Type() == js.TypeObject {
+		givens = jsArrayToIntSlice(args[2])
+	}
+
+	strictness := human.CheckPeers
+	if len(args) > 3 && args[3].Type() == js.TypeString {
+		strictness = args[3].String()
+	}
+
+	diff, err := human.DiffCandidates(board, candidates, givens, strictness)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("strictness", strictness)
+	obj.Set("wrongMarks", candidateSliceToJS(diff.WrongMarks))
+	obj.Set("missingMarks", candidateSliceToJS(diff.MissingMarks))
+	obj.Set("cellsWithIssues", intSliceToJSArray(diff.CellsWithIssues))
+	return obj
+}
+
+// explainCell answers why a digit is impossible or required at a cell
+// Input: board (number[%d]), candidates (number[][] | null), row, col, digit
+// Output: { kind: string, cell: number, digit: number, peerCell: number,
+//
+//	technique?: string, unit?: string, explanation: string }
+func explainCell(this js.Value, args []js.Value) interface{} {
+	if len(args) < 5 {
+		return errorToJS("board, candidates, row, col, and digit required")
+	}
+
+	board := jsArrayToIntSlice(args[0])
+	if len(board) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("board must have %d elements", constants.TotalCells))
+	}
+
+	var b *human.Board
+	if args[1].Type() == js.TypeObject {
+		b = human.NewBoardWithCandidates(board, jsArrayTo2DIntSlice(args[1]))
+	} else {
+		b = human.NewBoard(board)
+	}
+
+	row, col, digit := args[2].Int(), args[3].Int(), args[4].Int()
+	if row < 0 || row >= constants.GridSize || col < 0 || col >= constants.GridSize {
+		return errorToJS("row and col must be within the grid")
+	}
+
+	explanation, err := human.ExplainCell(b, row*constants.GridSize+col, digit)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("kind", explanation.Kind)
+	obj.Set("cell", explanation.Cell)
+	obj.Set("digit", explanation.Digit)
+	obj.Set("peerCell", explanation.PeerCell)
+	if explanation.Technique != "" {
+		obj.Set("technique", explanation.Technique)
+	}
+	if explanation.Unit != "" {
+		obj.Set("unit", explanation.Unit)
+	}
+	obj.Set("explanation", explanation.Explanation)
+	return obj
+}
+
+// findMoveForCell returns the cheapest move that touches the requested cell:
+// an assignment into it or an elimination of one of its candidates. When no
+// technique touches the cell, the nearest move highlighting the cell or one
+// of its peers is returned with indirect: true
+// Input: board (number[%d]), candidates (number[][] | null), row, col
+// Output: Move | null
+func findMoveForCell(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return errorToJS("board, candidates, row, and col required")
+	}
+
+	board := jsArrayToIntSlice(args[0])
+	if len(board) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("board must have %d elements", constants.TotalCells))
+	}
+
+	var b *human.Board
+	if args[1].Type() == js.TypeObject {
+		b = human.NewBoardWithCandidates(board, jsArrayTo2DIntSlice(args[1]))
+	} else {
+		b = human.NewBoard(board)
+	}
+
+	row, col := args[2].Int(), args[3].Int()
+	if row < 0 || row >= constants.GridSize || col < 0 || col >= constants.GridSize {
+		return errorToJS("row and col must be within the grid")
+	}
+
+	solver := human.NewSolver()
+	move := solver.FindMoveForCell(b, row*constants.GridSize+col)
+	if move == nil {
+		return js.Null()
+	}
+	return moveToJS(move)
+}
+
 // ==================== Utility Functions ====================
 
 // getPuzzleForSeed generates or retrieves a puzzle for a given seed
@@ -1546,24 +1993,55 @@ func getPuzzleForSeed(this js.Value, args []js.Value) interface{} {
 		return errorToJS("seed and difficulty required")
 	}
 
-	seed := args[0].String()
+	ref, err := core.ParsePuzzleRef(args[0].String())
+	if err != nil {
+		return errorToJS("invalid seed: " + err.Error())
+	}
+	seed := ref.String()
 	difficulty := args[1].String()
 
-	// Validate difficulty
-	validDifficulties := map[string]bool{
-		"easy": true, "medium": true, "hard": true, "extreme": true, "impossible": true,
-	}
-	if !validDifficulties[difficulty] {
+	// Validate difficulty against the active spec list
+	spec, ok := constants.DifficultyByName(difficulty)
+	if !ok {
 		return errorToJS("invalid difficulty")
 	}
 
+	// Serve from a registered puzzle pack when one is loaded; fall back to
+	// generation if the pack can't satisfy the request
+	if packLoader != nil {
+		if givens, solution, _, err := packLoader.GetPuzzleBySeed(seed, difficulty); err == nil {
+			obj := js.Global().Get("Object").New()
+			obj.Set("givens", intSliceToJSArray(givens))
+			obj.Set("solution", intSliceToJSArray(solution))
+			obj.Set("puzzleId", ref.PuzzleID(difficulty))
+			obj.Set("seed", seed)
+			obj.Set("difficulty", difficulty)
+			return obj
+		}
+	}
+
+	// Bank-only tiers (diabolical) guarantee a technique predicate the
+	// on-demand carve cannot honor; without a pack entry there is nothing
+	// to serve
+	if len(spec.RequireAnyOf) > 0 {
+		return errorToJS("difficulty '" + difficulty + "' is only served from pre-generated puzzle packs")
+	}
+
 	// Generate deterministic seed hash
+	// Seeds with the "v2:" prefix opt into the transformation-based fast
+	// grid generator; all other seeds keep the original backtracking path
+	// so existing puzzles are unchanged.
 	seedHash := hashSeed(seed)
-	fullGrid := dp.GenerateFullGrid(seedHash)
-	allPuzzles := dp.CarveGivensWithSubset(fullGrid, seedHash)
+	var fullGrid []int
+	if dp.UseFastGrid(seed) {
+		fullGrid = dp.GenerateFullGridFast(seedHash)
+	} else {
+		fullGrid = dp.GenerateFullGrid(seedHash)
+	}
+	allPuzzles, degradedTiers := dp.CarveGivensWithSubset(fullGrid, seedHash)
 	givens := allPuzzles[difficulty]
 
-	puzzleID := seed + "-" + difficulty
+	puzzleID := ref.PuzzleID(difficulty)
 
 	obj := js.Global().Get("Object").New()
 	obj.Set("givens", intSliceToJSArray(givens))
@@ -1571,13 +2049,145 @@ func getPuzzleForSeed(this js.Value, args []js.Value) interface{} {
 	obj.Set("puzzleId", puzzleID)
 	obj.Set("seed", seed)
 	obj.Set("difficulty", difficulty)
+	// degraded: carving bottomed out above this tier's target, so the
+	// puzzle matches an easier tier for this seed
+	obj.Set("degraded", degradedTiers[difficulty])
 	return obj
 }
 
-// hashSeed converts a string seed to int64
-func hashSeed(seed string) int64 {
-	// FNV-1a hash
-	var hash uint64 = 14695981039346656037
+// seedGivens resolves the deterministic carve for a seed and difficulty,
+// preferring a loaded puzzle pack and generating on demand otherwise
+func seedGivens(seed, difficulty string) []int {
+	if packLoader != nil {
+		if givens, _, _, err := packLoader.GetPuzzleBySeed(seed, difficulty); err == nil {
+			return givens
+		}
+	}
+	seedHash := hashSeed(seed)
+	var fullGrid []int
+	if dp.UseFastGrid(seed) {
+		fullGrid = dp.GenerateFullGridFast(seedHash)
+	} else {
+		fullGrid = dp.GenerateFullGrid(seedHash)
+	}
+	allPuzzles, _ := dp.CarveGivensWithSubset(fullGrid, seedHash)
+	return allPuzzles[difficulty]
+}
+
+// puzzleDiff reports what dropping from a harder difficulty to an easier one
+// buys the player on the same seed: the extra givens and the technique
+// changes along the solving path. Mirrors GET /api/puzzle/:seed/diff.
+// Input: seed (string), from (string), to (string) — from must be harder
+// Output: { addedGivens: {index, digit}[], removedTechniques: string[], addedTechniques: string[] }
+func puzzleDiff(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 {
+		return errorToJS("seed, from, and to required")
+	}
+
+	ref, err := core.ParsePuzzleRef(args[0].String())
+	if err != nil {
+		return errorToJS("invalid seed: " + err.Error())
+	}
+	seed := ref.String()
+
+	from, to := args[1].String(), args[2].String()
+	fromRank, ok := constants.DifficultyRank(from)
+	if !ok {
+		return errorToJS("invalid difficulty: " + from)
+	}
+	toRank, ok := constants.DifficultyRank(to)
+	if !ok {
+		return errorToJS("invalid difficulty: " + to)
+	}
+	if fromRank <= toRank {
+		return errorToJS("from must be harder than to")
+	}
+
+	diff := human.NewSolver().DiffPuzzles(seedGivens(seed, from), seedGivens(seed, to))
+
+	added := js.Global().Get("Array").New(len(diff.AddedGivens))
+	for i, given := range diff.AddedGivens {
+		obj := js.Global().Get("Object").New()
+		obj.Set("index", given.Index)
+		obj.Set("digit", given.Digit)
+		added.SetIndex(i, obj)
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("addedGivens", added)
+	obj.Set("removedTechniques", stringSliceToJSArray(diff.RemovedTechniques))
+	obj.Set("addedTechniques", stringSliceToJSArray(diff.AddedTechniques))
+	return obj
+}
+
+// loadPuzzlePack registers a downloaded puzzle pack for offline use
+// Input: packJSON (string) - JSON with a "puzzles" array of compact puzzles,
+// as returned by /api/puzzles/pack or a generated puzzle file
+// Output: { loaded: number }
+func loadPuzzlePack(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("pack JSON required")
+	}
+
+	var file puzzles.PuzzleFile
+	if err := json.Unmarshal([]byte(args[0].String()), &file); err != nil {
+		return errorToJS("invalid pack JSON: " + err.Error())
+	}
+	if len(file.Puzzles) == 0 {
+		return errorToJS("pack contains no puzzles")
+	}
+
+	packLoader = puzzles.NewLoaderFromPuzzles(file.Puzzles)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("loaded", len(file.Puzzles))
+	return obj
+}
+
+// applyTransform maps a canonical board into a presented orientation. The
+// spec is the comma-composed format core.ParseTransform accepts: "rotate90",
+// "rotate180", "rotate270", "mirror-h", "mirror-v", "relabel:<9 digits>".
+// Input: spec (string), cells (number[81])
+// Output: { cells: number[81] }
+func applyTransform(this js.Value, args []js.Value) interface{} {
+	return transformCells(args, false)
+}
+
+// invertTransform maps a presented board back to canonical orientation, the
+// round-trip counterpart of applyTransform
+// Input: spec (string), cells (number[81])
+// Output: { cells: number[81] }
+func invertTransform(this js.Value, args []js.Value) interface{} {
+	return transformCells(args, true)
+}
+
+func transformCells(args []js.Value, invert bool) interface{} {
+	if len(args) < 2 {
+		return errorToJS("spec and cells required")
+	}
+
+	transform, err := core.ParseTransform(args[0].String())
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	if invert {
+		transform = transform.Invert()
+	}
+
+	cells := jsArrayToIntSlice(args[1])
+	if len(cells) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("cells must have %d values", constants.TotalCells))
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("cells", intSliceToJSArray(transform.ApplyCells(cells)))
+	return obj
+}
+
+// hashSeed converts a string seed to int64
+func hashSeed(seed string) int64 {
+	// FNV-1a hash
+	var hash uint64 = 14695981039346656037
 	for i := 0; i < len(seed); i++ {
 		hash ^= uint64(seed[i])
 		hash *= 1099511628211
@@ -1598,10 +2208,218 @@ func getVersion(this js.Value, args []js.Value) interface{} {
 	return js.ValueOf(constants.SolverVersion)
 }
 
+// getTutorial returns one embedded tutorial puzzle plus its script metadata
+// Input: id (string)
+// Output: { id, title, concept, intro, givens, stepCount,
+//
+//	steps: [{ boardHash, allowedUserActions }] } or { error }
+func getTutorial(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("tutorial id required")
+	}
+	t, ok := tutorial.Get(args[0].String())
+	if !ok {
+		return errorToJS("no tutorial " + args[0].String())
+	}
+
+	steps := js.Global().Get("Array").New(len(t.Steps))
+	for i, step := range t.Steps {
+		s := js.Global().Get("Object").New()
+		s.Set("boardHash", step.BoardHash)
+		s.Set("allowedUserActions", stringSliceToJSArray(step.AllowedUserActions))
+		steps.SetIndex(i, s)
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("id", t.ID)
+	obj.Set("title", t.Title)
+	obj.Set("concept", t.Concept)
+	obj.Set("intro", t.Intro)
+	obj.Set("givens", intSliceToJSArray(t.Givens))
+	obj.Set("stepCount", len(t.Steps))
+	obj.Set("steps", steps)
+	return obj
+}
+
+// getTutorialStep reveals one scripted tutorial step once the board matches
+// the state the script expects; a diverged board gets a resync payload
+// listing the differing cells
+// Input: id (string), step (number), board (array of 81 numbers)
+// Output: { step, move, allowedUserActions, done } or
+//
+//	{ resync: true, step, expectedHash, differences: [{row, col, expected, actual}] } or { error }
+func getTutorialStep(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 {
+		return errorToJS("tutorial id, step, and board required")
+	}
+	t, ok := tutorial.Get(args[0].String())
+	if !ok {
+		return errorToJS("no tutorial " + args[0].String())
+	}
+	stepIdx := args[1].Int()
+	if stepIdx < 0 || stepIdx >= len(t.Steps) {
+		return errorToJS(fmt.Sprintf("step must be between 0 and %d", len(t.Steps)-1))
+	}
+	board := jsArrayToIntSlice(args[2])
+	if len(board) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("board must have %d cells", constants.TotalCells))
+	}
+
+	if diffs := t.Diff(stepIdx, board); len(diffs) > 0 {
+		arr := js.Global().Get("Array").New(len(diffs))
+		for i, d := range diffs {
+			obj := js.Global().Get("Object").New()
+			obj.Set("row", d.Row)
+			obj.Set("col", d.Col)
+			obj.Set("expected", d.Expected)
+			obj.Set("actual", d.Actual)
+			arr.SetIndex(i, obj)
+		}
+		obj := js.Global().Get("Object").New()
+		obj.Set("resync", true)
+		obj.Set("step", stepIdx)
+		obj.Set("expectedHash", t.Steps[stepIdx].BoardHash)
+		obj.Set("differences", arr)
+		return obj
+	}
+
+	step := t.Steps[stepIdx]
+	obj := js.Global().Get("Object").New()
+	obj.Set("step", stepIdx)
+	obj.Set("move", moveValueToJS(step.Move))
+	obj.Set("allowedUserActions", stringSliceToJSArray(step.AllowedUserActions))
+	obj.Set("done", stepIdx == len(t.Steps)-1)
+	return obj
+}
+
+// getTechniques returns metadata for all registered solving techniques
+// Output: [{ slug, displayName, tier, description, score, docUrl, enabled }]
+func getTechniques(this js.Value, args []js.Value) interface{} {
+	infos := solver.GetRegistry().GetTechniqueInfos()
+	arr := js.Global().Get("Array").New(len(infos))
+	for i, info := range infos {
+		obj := js.Global().Get("Object").New()
+		obj.Set("slug", info.Slug)
+		obj.Set("displayName", info.DisplayName)
+		obj.Set("tier", info.Tier)
+		obj.Set("description", info.Description)
+		obj.Set("score", info.Score)
+		obj.Set("docUrl", info.DocURL)
+		obj.Set("enabled", info.Enabled)
+		arr.SetIndex(i, obj)
+	}
+	return arr
+}
+
+// boardCompleted reports a genuinely finished board: every cell filled with
+// no rule violations. The single-move path (completedBoardMove) and the
+// solve-all terminal verdict both settle completion through this check,
+// mirroring the server handlers, so the two paths cannot diverge.
+func boardCompleted(cells []int) bool {
+	for _, v := range cells {
+		if v == 0 {
+			return false
+		}
+	}
+	return dp.IsValid(cells)
+}
+
+// completionStats splits a finished board's non-given cells into the
+// player's own entries and hint placements, from the client's optional hint
+// log. Without one every entry counts as the player's.
+func completionStats(cells, givens, hintCells []int) js.Value {
+	filledByHints := 0
+	hinted := make(map[int]bool, len(hintCells))
+	for _, idx := range hintCells {
+		if idx < 0 || idx >= len(cells) || hinted[idx] {
+			continue
+		}
+		if givens[idx] == 0 && cells[idx] != 0 {
+			hinted[idx] = true
+			filledByHints++
+		}
+	}
+	filledByUser := 0
+	for i, v := range cells {
+		if v != 0 && givens[i] == 0 {
+			filledByUser++
+		}
+	}
+	obj := js.Global().Get("Object").New()
+	obj.Set("filledByUser", filledByUser-filledByHints)
+	obj.Set("filledByHints", filledByHints)
+	return obj
+}
+
 // findNextMove finds the next solving step with full error detection
 // Input: cells (number[%d]), candidates (number[%d][]), givens (number[%d])
-// Output: { move: Move | null, board: { cells, candidates }, solved: boolean }
+// Output: { move: Move | null, board: { cells, candidates }, solved: boolean, stats?: { filledByUser, filledByHints } }
 // This is equivalent to solveAll with maxMoves=1, returning the first move only
+// completedBoardMove settles the single-move paths for a fully filled
+// board, which the solver would otherwise answer with a null hint. A full
+// board with no direct conflicts is the solution by definition and gets a
+// terminal "solved" move; a conflicting full board is diffed against
+// dp.Solve to produce a fix-error move for the first wrong user entry,
+// with userEntryCount so the frontend can phrase the message. handled is
+// false when the board isn't full or no wrong user cell can be pinned
+// down, leaving the existing fix machinery to run.
+func completedBoardMove(cells []int, candidates [][]int, givens []int) (move js.Value, newCells []int, newCandidates [][]int, solved, handled bool) {
+	for _, v := range cells {
+		if v == 0 {
+			return js.Value{}, nil, nil, false, false
+		}
+	}
+
+	if boardCompleted(cells) {
+		move = inlineMoveToJS("solved", "done", 0,
+			renderExplanation("solver.full-board.solved", nil), nil, nil)
+		return move, cells, candidates, true, true
+	}
+
+	solution := dp.Solve(givens)
+	if solution == nil {
+		return js.Value{}, nil, nil, false, false
+	}
+
+	badCell := -1
+	userEntries := 0
+	for i, v := range cells {
+		if givens[i] != 0 {
+			continue
+		}
+		userEntries++
+		if badCell < 0 && v != solution[i] {
+			badCell = i
+		}
+	}
+	if badCell < 0 {
+		// Every user entry matches, so the conflict involves givens only;
+		// let the conflict machinery report the broken puzzle
+		return js.Value{}, nil, nil, false, false
+	}
+
+	badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+	badDigit := cells[badCell]
+
+	fixedCells := make([]int, len(cells))
+	copy(fixedCells, cells)
+	fixedCells[badCell] = 0
+	fixedBoard := human.NewBoardWithCandidates(fixedCells, nil)
+
+	move = fixErrorMoveWithCountToJS(
+		badDigit,
+		renderExplanation("solver.full-board.wrong", map[string]interface{}{
+			"cell":  cellLabel(badRow, badCol),
+			"digit": badDigit,
+		}),
+		badRow, badCol,
+		[][]int{{badRow, badCol}},
+		nil,
+		userEntries,
+	)
+	return move, fixedBoard.GetCells(), fixedBoard.GetCandidates(), false, true
+}
+
 func findNextMove(this js.Value, args []js.Value) interface{} {
 	if len(args) < 3 {
 		return errorToJS("cells, candidates, and givens required")
@@ -1618,8 +2436,65 @@ func findNextMove(this js.Value, args []js.Value) interface{} {
 		return errorToJS(fmt.Sprintf("givens must have %d elements", constants.TotalCells))
 	}
 
+	// Optional strategy argument controls move ordering when several
+	// techniques tie ("first", "prefer-assign", "max-impact")
+	strategy := human.StrategyFirst
+	if len(args) >= 4 && !args[3].IsNull() && !args[3].IsUndefined() {
+		parsed, ok := human.ParseStrategy(args[3].String())
+		if !ok {
+			return errorToJS("invalid strategy")
+		}
+		strategy = parsed
+	}
+	solver.SetStrategy(strategy)
+
+	// Optional annotations argument: when no candidates were passed, the
+	// user's center marks stand in as their candidate set
+	if candidates == nil && len(args) >= 5 {
+		annotations := jsArrayToAnnotations(args[4])
+		if err := core.ValidateAnnotations(annotations); err != nil {
+			return errorToJS(err.Error())
+		}
+		candidates = core.AnnotationsToCandidates(annotations)
+	}
+
+	// A full board never yields a technique move; settle completion first
+	if move, newCells, newCandidates, solved, handled := completedBoardMove(cells, candidates, givens); handled {
+		obj := js.Global().Get("Object").New()
+		obj.Set("move", move)
+		boardObj := js.Global().Get("Object").New()
+		boardObj.Set("cells", intSliceToJSArray(newCells))
+		boardObj.Set("candidates", int2DSliceToJSArray(newCandidates))
+		obj.Set("board", boardObj)
+		obj.Set("solved", solved)
+		if solved {
+			// Optional hint log argument splits the filled cells between the
+			// player and earlier hints for the completion screen
+			var hintCells []int
+			if len(args) >= 6 && !args[5].IsNull() && !args[5].IsUndefined() {
+				hintCells = jsArrayToIntSlice(args[5])
+			}
+			obj.Set("stats", completionStats(cells, givens, hintCells))
+		}
+		return obj
+	}
+
 	// Call internal implementation with maxMoves=1 for single move
 	result := solveAllInternal(cells, candidates, givens, 1)
+	if result.err != nil {
+		return errorToJS(result.err.Error())
+	}
+	if result.unsolvable != nil {
+		obj := js.Global().Get("Object").New()
+		obj.Set("move", js.Null())
+		obj.Set("unsolvable", unsolvableToJS(result.unsolvable))
+		boardObj := js.Global().Get("Object").New()
+		boardObj.Set("cells", intSliceToJSArray(result.finalBoard))
+		boardObj.Set("candidates", int2DSliceToJSArray(result.finalCandidates))
+		obj.Set("board", boardObj)
+		obj.Set("solved", false)
+		return obj
+	}
 
 	// Extract first move if available
 	var move interface{}
@@ -1650,6 +2525,245 @@ func findNextMove(this js.Value, args []js.Value) interface{} {
 	return obj
 }
 
+// ==================== Binary Typed-Array Interface ====================
+//
+// An alternative to findNextMove that avoids per-element JS marshaling: board
+// state crosses the boundary as typed arrays copied in bulk with
+// js.CopyBytesToGo/CopyBytesToJS. Cells travel as 81 bytes (0 = empty); the
+// candidate masks travel as 81 little-endian uint16 bitmasks where bit d set
+// means digit d+1 is possible (bit 0 = digit 1) - callers pass a Uint8Array
+// view over the Uint16Array's buffer, since CopyBytesToGo only accepts byte
+// arrays. Encoding lives in the human package (EncodeBoard et al.) so it can
+// be unit-tested outside the wasm build.
+
+// copyBytesFromJS bulk-copies a Uint8Array of the expected length into Go
+func copyBytesFromJS(arr js.Value, expected int) ([]byte, bool) {
+	if arr.Type() != js.TypeObject || arr.Get("length").Type() != js.TypeNumber ||
+		arr.Get("length").Int() != expected {
+		return nil, false
+	}
+	buf := make([]byte, expected)
+	if js.CopyBytesToGo(buf, arr) != expected {
+		return nil, false
+	}
+	return buf, true
+}
+
+// findNextMoveBinary finds the next solving step using the binary interface
+// Input: cells (Uint8Array[%d]), candidates (Uint8Array[%d] over a Uint16Array
+// buffer), givens (Uint8Array[%d]), outCells (Uint8Array[%d]), outCandidates
+// (Uint8Array[%d]), strategy (string, optional)
+// The updated board is written back into outCells/outCandidates (the input
+// arrays may be reused as outputs).
+// Output: { move: Move | null, solved: boolean }
+func findNextMoveBinary(this js.Value, args []js.Value) interface{} {
+	if len(args) < 5 {
+		return errorToJS("cells, candidates, givens, outCells, and outCandidates required")
+	}
+
+	cellsBuf, ok := copyBytesFromJS(args[0], constants.TotalCells)
+	if !ok {
+		return errorToJS(fmt.Sprintf("cells must be a Uint8Array of %d bytes", constants.TotalCells))
+	}
+	candBuf, ok := copyBytesFromJS(args[1], human.CandidateMaskBytes)
+	if !ok {
+		return errorToJS(fmt.Sprintf("candidates must be a Uint8Array of %d bytes", human.CandidateMaskBytes))
+	}
+	givensBuf, ok := copyBytesFromJS(args[2], constants.TotalCells)
+	if !ok {
+		return errorToJS(fmt.Sprintf("givens must be a Uint8Array of %d bytes", constants.TotalCells))
+	}
+
+	cells, err := human.DecodeBoard(cellsBuf)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	candidates, err := human.DecodeCandidates(candBuf)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	givens, err := human.DecodeBoard(givensBuf)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	strategy := human.StrategyFirst
+	if len(args) >= 6 && !args[5].IsNull() && !args[5].IsUndefined() {
+		parsed, ok := human.ParseStrategy(args[5].String())
+		if !ok {
+			return errorToJS("invalid strategy")
+		}
+		strategy = parsed
+	}
+	solver.SetStrategy(strategy)
+
+	var move interface{}
+	newBoard := cells
+	newCandidates := candidates
+	var solved bool
+
+	// A full board never yields a technique move; settle completion first
+	if m, fixedCells, fixedCandidates, isSolved, handled := completedBoardMove(cells, candidates, givens); handled {
+		move = m
+		newBoard = fixedCells
+		newCandidates = fixedCandidates
+		solved = isSolved
+	} else {
+		result := solveAllInternal(cells, candidates, givens, 1)
+		if result.err != nil {
+			return errorToJS(result.err.Error())
+		}
+		if len(result.moves) > 0 {
+			firstMove := result.moves[0]
+			move = firstMove.Move
+			newBoard = firstMove.Board
+			newCandidates = firstMove.Candidates
+		} else {
+			move = nil
+		}
+		solved = result.solved
+	}
+
+	outCells, err := human.EncodeBoard(newBoard)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	outCands, err := human.EncodeCandidates(newCandidates)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	js.CopyBytesToJS(args[3], outCells)
+	js.CopyBytesToJS(args[4], outCands)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("move", move)
+	obj.Set("solved", solved)
+	return obj
+}
+
+// encodeBoard packs board state into the binary wire format, for frontend
+// compatibility checks against the decoder
+// Input: cells (number[%d]), candidates (number[%d][]), outCells
+// (Uint8Array[%d]), outCandidates (Uint8Array[%d])
+// Output: { cellBytes: number, candidateBytes: number }
+func encodeBoard(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return errorToJS("cells, candidates, outCells, and outCandidates required")
+	}
+
+	cells := jsArrayToIntSlice(args[0])
+	candidates := jsArrayTo2DIntSlice(args[1])
+
+	cellsBuf, err := human.EncodeBoard(cells)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	candBuf, err := human.EncodeCandidates(candidates)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	js.CopyBytesToJS(args[2], cellsBuf)
+	js.CopyBytesToJS(args[3], candBuf)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("cellBytes", len(cellsBuf))
+	obj.Set("candidateBytes", len(candBuf))
+	return obj
+}
+
+// decodeBoard unpacks the binary wire format back into plain arrays, the
+// round-trip counterpart of encodeBoard
+// Input: cells (Uint8Array[%d]), candidates (Uint8Array[%d])
+// Output: { cells: number[%d], candidates: number[%d][] }
+func decodeBoard(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return errorToJS("cells and candidates required")
+	}
+
+	cellsBuf, ok := copyBytesFromJS(args[0], constants.TotalCells)
+	if !ok {
+		return errorToJS(fmt.Sprintf("cells must be a Uint8Array of %d bytes", constants.TotalCells))
+	}
+	candBuf, ok := copyBytesFromJS(args[1], human.CandidateMaskBytes)
+	if !ok {
+		return errorToJS(fmt.Sprintf("candidates must be a Uint8Array of %d bytes", human.CandidateMaskBytes))
+	}
+
+	cells, err := human.DecodeBoard(cellsBuf)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+	candidates, err := human.DecodeCandidates(candBuf)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("cells", intSliceToJSArray(cells))
+	obj.Set("candidates", int2DSliceToJSArray(candidates))
+	return obj
+}
+
+// encodeState packs board state into the compact versioned string format
+// used for bug reports and the replay_state tool
+// Input: cells (number[%d]), candidates (number[%d][] or null),
+// annotations ({colorIndex, cornerMarks, centerMarks}[%d] or null)
+// Output: { state: string }
+func encodeState(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("cells required")
+	}
+
+	cells := jsArrayToIntSlice(args[0])
+	var candidates [][]int
+	if len(args) >= 2 && !args[1].IsNull() && !args[1].IsUndefined() {
+		candidates = jsArrayTo2DIntSlice(args[1])
+	}
+	var annotations []core.CellAnnotation
+	if len(args) >= 3 {
+		annotations = jsArrayToAnnotations(args[2])
+	}
+
+	state, err := core.EncodeStateWithAnnotations(cells, candidates, annotations)
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("state", state)
+	return obj
+}
+
+// decodeState unpacks a state string back into plain arrays, the round-trip
+// counterpart of encodeState
+// Input: state (string)
+// Output: { cells: number[%d], candidates: number[%d][] or null,
+// annotations: object[%d] or null }
+func decodeState(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("state string required")
+	}
+
+	cells, candidates, annotations, err := core.DecodeStateWithAnnotations(args[0].String())
+	if err != nil {
+		return errorToJS(err.Error())
+	}
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("cells", intSliceToJSArray(cells))
+	if candidates == nil {
+		obj.Set("candidates", js.Null())
+	} else {
+		obj.Set("candidates", int2DSliceToJSArray(candidates))
+	}
+	if annotations == nil {
+		obj.Set("annotations", js.Null())
+	} else {
+		obj.Set("annotations", annotationsToJSArray(annotations))
+	}
+	return obj
+}
+
 // checkAndFixWithSolution compares the current board against the known solution,
 // removes any incorrect user entries, then continues solving using techniques.
 // Input: cells (current board), candidates, givens, solution (correct answer)
@@ -1704,7 +2818,11 @@ func checkAndFixWithSolution(this js.Value, args []js.Value) interface{} {
 				Candidates: nil,                                   // Will be recalculated
 				Move: fixErrorMoveToJS(
 					badDigit,
-					formatExplanation("Removed incorrect %d from R%dC%d (should be %d)", badDigit, row+1, col+1, solution[i]),
+					renderExplanation("solver.removed-incorrect", map[string]interface{}{
+						"digit": badDigit,
+						"cell":  cellLabel(row, col),
+						"value": solution[i],
+					}),
 					row, col,
 					[][]int{{row, col}},
 					nil,
@@ -1747,6 +2865,139 @@ func checkAndFixWithSolution(this js.Value, args []js.Value) interface{} {
 	return obj
 }
 
+// ==================== Board History ====================
+
+// histories is the opaque handle table for board histories. WASM is
+// single-threaded, so plain map access is safe.
+var (
+	histories     = map[int]*human.History{}
+	nextHistoryID = 1
+)
+
+// historyFromArgs resolves the handle in args[0], or returns nil if it is
+// missing or unknown
+func historyFromArgs(args []js.Value) *human.History {
+	if len(args) < 1 || args[0].Type() != js.TypeNumber {
+		return nil
+	}
+	return histories[args[0].Int()]
+}
+
+// historySnapshotToJS returns the history's current cells and pencil marks
+func historySnapshotToJS(h *human.History) js.Value {
+	cells, candidates := h.Snapshot()
+	obj := js.Global().Get("Object").New()
+	obj.Set("cells", intSliceToJSArray(cells))
+	obj.Set("candidates", int2DSliceToJSArray(candidates))
+	return obj
+}
+
+// newHistory creates a board history and returns its opaque handle
+// Input: board (number[%d]), candidates (number[][] | null)
+// Output: { handle: number }
+func newHistory(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return errorToJS("board required")
+	}
+	board := jsArrayToIntSlice(args[0])
+	if len(board) != constants.TotalCells {
+		return errorToJS(fmt.Sprintf("board must have %d elements", constants.TotalCells))
+	}
+
+	var b *human.Board
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		b = human.NewBoardWithCandidates(board, jsArrayTo2DIntSlice(args[1]))
+	} else {
+		b = human.NewBoard(board)
+	}
+
+	handle := nextHistoryID
+	nextHistoryID++
+	histories[handle] = human.NewHistory(b)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("handle", handle)
+	return obj
+}
+
+// doOp applies one operation to a board history
+// Input: handle (number), kind (string), cell (number), digit (number)
+// Output: { cells: number[], candidates: number[][] }
+func doOp(this js.Value, args []js.Value) interface{} {
+	h := historyFromArgs(args)
+	if h == nil {
+		return errorToJS("unknown history handle")
+	}
+	if len(args) < 4 {
+		return errorToJS("handle, kind, cell, and digit required")
+	}
+
+	op := human.HistoryOp{
+		Kind:  args[1].String(),
+		Cell:  args[2].Int(),
+		Digit: args[3].Int(),
+	}
+	if err := h.Do(op); err != nil {
+		return errorToJS(err.Error())
+	}
+	return historySnapshotToJS(h)
+}
+
+// undo reverts the most recent operation on a board history
+// Input: handle (number)
+// Output: { ok: boolean, cells: number[], candidates: number[][] }
+func undo(this js.Value, args []js.Value) interface{} {
+	h := historyFromArgs(args)
+	if h == nil {
+		return errorToJS("unknown history handle")
+	}
+	ok := h.Undo()
+	obj := historySnapshotToJS(h)
+	obj.Set("ok", ok)
+	return obj
+}
+
+// redo reapplies the most recently undone operation on a board history
+// Input: handle (number)
+// Output: { ok: boolean, cells: number[], candidates: number[][] }
+func redo(this js.Value, args []js.Value) interface{} {
+	h := historyFromArgs(args)
+	if h == nil {
+		return errorToJS("unknown history handle")
+	}
+	ok := h.Redo()
+	obj := historySnapshotToJS(h)
+	obj.Set("ok", ok)
+	return obj
+}
+
+// historySnapshot returns the current board state of a history
+// Input: handle (number)
+// Output: { cells: number[], candidates: number[][] }
+func historySnapshot(this js.Value, args []js.Value) interface{} {
+	h := historyFromArgs(args)
+	if h == nil {
+		return errorToJS("unknown history handle")
+	}
+	return historySnapshotToJS(h)
+}
+
+// freeHistory drops a history from the handle table so it can be collected
+// Input: handle (number)
+// Output: { ok: boolean }
+func freeHistory(this js.Value, args []js.Value) interface{} {
+	obj := js.Global().Get("Object").New()
+	if len(args) < 1 || args[0].Type() != js.TypeNumber {
+		obj.Set("ok", false)
+		return obj
+	}
+	handle := args[0].Int()
+	_, ok := histories[handle]
+	delete(histories, handle)
+	obj.Set("ok", ok)
+	return obj
+}
+
 func debugLog(format string, args ...interface{}) {
 	logger := js.Global().Get("logger")
 	if logger.IsUndefined() || logger.IsNull() {
@@ -1765,9 +3016,17 @@ func main() {
 		"createBoard":               js.FuncOf(createBoard),
 		"createBoardWithCandidates": js.FuncOf(createBoardWithCandidates),
 		"findNextMove":              js.FuncOf(findNextMove),
+		"findNextMoveBinary":        js.FuncOf(findNextMoveBinary),
+		"findMoveForCell":           js.FuncOf(findMoveForCell),
+		"encodeBoard":               js.FuncOf(encodeBoard),
+		"decodeBoard":               js.FuncOf(decodeBoard),
+		"encodeState":               js.FuncOf(encodeState),
+		"decodeState":               js.FuncOf(decodeState),
 		"solveWithSteps":            js.FuncOf(solveWithSteps),
+		"solveWithCallback":         js.FuncOf(solveWithCallback),
 		"analyzePuzzle":             js.FuncOf(analyzePuzzle),
 		"solveAll":                  js.FuncOf(solveAll),
+		"applyTierCascade":          js.FuncOf(applyTierCascade),
 		"checkAndFixWithSolution":   js.FuncOf(checkAndFixWithSolution),
 
 		// DP solver
@@ -1782,10 +3041,28 @@ func main() {
 		// Validation
 		"validateCustomPuzzle": js.FuncOf(validateCustomPuzzle),
 		"validateBoard":        js.FuncOf(validateBoard),
+		"isWinnable":           js.FuncOf(isWinnable),
+		"checkCandidates":      js.FuncOf(checkCandidates),
+		"explainCell":          js.FuncOf(explainCell),
+
+		// Board history
+		"newHistory":      js.FuncOf(newHistory),
+		"doOp":            js.FuncOf(doOp),
+		"undo":            js.FuncOf(undo),
+		"redo":            js.FuncOf(redo),
+		"historySnapshot": js.FuncOf(historySnapshot),
+		"freeHistory":     js.FuncOf(freeHistory),
 
 		// Utility
+		"applyTransform":   js.FuncOf(applyTransform),
+		"invertTransform":  js.FuncOf(invertTransform),
 		"getPuzzleForSeed": js.FuncOf(getPuzzleForSeed),
+		"puzzleDiff":       js.FuncOf(puzzleDiff),
+		"loadPuzzlePack":   js.FuncOf(loadPuzzlePack),
 		"getVersion":       js.FuncOf(getVersion),
+		"getTutorial":      js.FuncOf(getTutorial),
+		"getTutorialStep":  js.FuncOf(getTutorialStep),
+		"getTechniques":    js.FuncOf(getTechniques),
 	}
 	debugLog("All function exports created successfully")
 
@@ -0,0 +1,209 @@
+// Package calibrate fits per-technique difficulty weights against anonymized
+// human solve-time data.
+//
+// Each observation pairs a puzzle with the median time humans took to solve
+// it and the rate at which they gave up. The caller re-analyzes the puzzle
+// with the human solver to get its technique counts; this package then fits
+// one weight per technique by ordinary least squares so that the weighted
+// technique counts predict the observed effort. The normal equations are
+// solved with plain Gaussian elimination - no external dependencies. Fitted
+// weights are clamped into configured positive bounds before they are
+// written, so a thin or skewed dataset cannot produce a zero or runaway
+// score.
+package calibrate
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Observation is one row of the solve-time CSV: a puzzle, the difficulty it
+// was served at, the median human solve time, and the abandonment rate.
+type Observation struct {
+	PuzzleID           string
+	Difficulty         string
+	MedianSolveSeconds float64
+	AbandonRate        float64
+}
+
+// Target converts an observation into the effort figure the fit predicts.
+// Abandonment inflates the median: a puzzle half the players gave up on took
+// more effort than its finishers' times alone suggest.
+func (o Observation) Target() float64 {
+	return o.MedianSolveSeconds * (1 + o.AbandonRate)
+}
+
+// Sample is one fitted data point: the technique counts from re-analyzing a
+// puzzle, and the observed effort target those counts should predict.
+type Sample struct {
+	Counts map[string]int
+	Target float64
+}
+
+// Bounds clamp fitted weights. Min must be positive so no technique can be
+// rated free, and Max caps what a single noisy technique can absorb.
+type Bounds struct {
+	Min float64
+	Max float64
+}
+
+// ParseCSV reads observations from a solve-time CSV with columns
+// (puzzleId, difficulty, medianSolveSeconds, abandonRate). A header row is
+// detected and skipped. Malformed rows - wrong field count, unparsable
+// numbers, non-positive times, abandon rates outside [0,1) - are skipped,
+// each with a warning describing the row, so one bad export line does not
+// abort a calibration run.
+func ParseCSV(r io.Reader) ([]Observation, []string) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // row length is validated per-row below
+
+	var observations []Observation
+	var warnings []string
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("row %d: %v", line, err))
+			continue
+		}
+		if len(record) != 4 {
+			warnings = append(warnings, fmt.Sprintf("row %d: expected 4 fields, got %d", line, len(record)))
+			continue
+		}
+		seconds, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			if line == 1 {
+				continue // header row
+			}
+			warnings = append(warnings, fmt.Sprintf("row %d: invalid solve seconds %q", line, record[2]))
+			continue
+		}
+		abandon, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("row %d: invalid abandon rate %q", line, record[3]))
+			continue
+		}
+		if seconds <= 0 {
+			warnings = append(warnings, fmt.Sprintf("row %d: solve seconds must be positive, got %v", line, seconds))
+			continue
+		}
+		if abandon < 0 || abandon >= 1 {
+			warnings = append(warnings, fmt.Sprintf("row %d: abandon rate must be in [0,1), got %v", line, abandon))
+			continue
+		}
+		observations = append(observations, Observation{
+			PuzzleID:           record[0],
+			Difficulty:         record[1],
+			MedianSolveSeconds: seconds,
+			AbandonRate:        abandon,
+		})
+	}
+	return observations, warnings
+}
+
+// FitWeights fits one weight per technique appearing in the samples by least
+// squares, minimizing the squared error between weighted technique counts and
+// each sample's target, then clamps the result into bounds. It errors when
+// the system is under-determined - fewer samples than techniques, or counts
+// so collinear that no unique fit exists - rather than guessing.
+func FitWeights(samples []Sample, bounds Bounds) (map[string]float64, error) {
+	if bounds.Min <= 0 || bounds.Max < bounds.Min {
+		return nil, fmt.Errorf("invalid bounds %v-%v: min must be positive and at most max", bounds.Min, bounds.Max)
+	}
+
+	// Collect the techniques observed across all samples, in stable order
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, sample := range samples {
+		for slug, count := range sample.Counts {
+			if count > 0 && !seen[slug] {
+				seen[slug] = true
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+	sort.Strings(slugs)
+	if len(slugs) == 0 {
+		return nil, fmt.Errorf("no technique counts in any sample")
+	}
+	if len(samples) < len(slugs) {
+		return nil, fmt.Errorf("under-determined fit: %d samples for %d techniques", len(samples), len(slugs))
+	}
+
+	// Normal equations: (AᵀA) w = Aᵀy, where A holds per-sample technique
+	// counts and y the observed effort targets
+	n := len(slugs)
+	index := make(map[string]int, n)
+	for i, slug := range slugs {
+		index[slug] = i
+	}
+	normal := make([][]float64, n)
+	for i := range normal {
+		normal[i] = make([]float64, n+1) // last column is Aᵀy
+	}
+	for _, sample := range samples {
+		for slugI, countI := range sample.Counts {
+			i := index[slugI]
+			for slugJ, countJ := range sample.Counts {
+				normal[i][index[slugJ]] += float64(countI) * float64(countJ)
+			}
+			normal[i][n] += float64(countI) * sample.Target
+		}
+	}
+
+	weights, err := solveLinearSystem(normal)
+	if err != nil {
+		return nil, err
+	}
+
+	fitted := make(map[string]float64, n)
+	for i, slug := range slugs {
+		fitted[slug] = math.Min(bounds.Max, math.Max(bounds.Min, weights[i]))
+	}
+	return fitted, nil
+}
+
+// solveLinearSystem solves the augmented system [M|b] in place by Gaussian
+// elimination with partial pivoting. A vanishing pivot means the technique
+// counts are collinear (two techniques always appearing together, say) and
+// the data cannot separate their weights.
+func solveLinearSystem(augmented [][]float64) ([]float64, error) {
+	n := len(augmented)
+	for col := 0; col < n; col++ {
+		// Pick the row with the largest remaining entry in this column
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(augmented[row][col]) > math.Abs(augmented[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(augmented[pivot][col]) < 1e-9 {
+			return nil, fmt.Errorf("singular system: technique counts are collinear, more varied data needed")
+		}
+		augmented[col], augmented[pivot] = augmented[pivot], augmented[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := augmented[row][col] / augmented[col][col]
+			for k := col; k <= n; k++ {
+				augmented[row][k] -= factor * augmented[col][k]
+			}
+		}
+	}
+
+	// Back-substitution
+	solution := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := augmented[row][n]
+		for col := row + 1; col < n; col++ {
+			sum -= augmented[row][col] * solution[col]
+		}
+		solution[row] = sum / augmented[row][row]
+	}
+	return solution, nil
+}
@@ -0,0 +1,135 @@
+package calibrate
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// syntheticSamples builds samples whose targets are generated exactly from
+// known per-technique weights, so a correct fit must recover them
+func syntheticSamples(trueWeights map[string]float64, count int, seed int64) []Sample {
+	rng := rand.New(rand.NewSource(seed))
+	slugs := make([]string, 0, len(trueWeights))
+	for slug := range trueWeights {
+		slugs = append(slugs, slug)
+	}
+
+	samples := make([]Sample, count)
+	for i := range samples {
+		counts := make(map[string]int)
+		target := 0.0
+		for _, slug := range slugs {
+			n := rng.Intn(12)
+			if n == 0 {
+				continue
+			}
+			counts[slug] = n
+			target += float64(n) * trueWeights[slug]
+		}
+		samples[i] = Sample{Counts: counts, Target: target}
+	}
+	return samples
+}
+
+func TestFitRecoversKnownWeights(t *testing.T) {
+	trueWeights := map[string]float64{
+		"naked-single":  9,
+		"hidden-single": 14,
+		"naked-pair":    23,
+		"x-wing":        58,
+		"xy-chain":      81,
+	}
+
+	samples := syntheticSamples(trueWeights, 40, 7)
+	fitted, err := FitWeights(samples, Bounds{Min: 1, Max: 200})
+	if err != nil {
+		t.Fatalf("FitWeights failed: %v", err)
+	}
+
+	if len(fitted) != len(trueWeights) {
+		t.Fatalf("fitted %d weights, want %d", len(fitted), len(trueWeights))
+	}
+	for slug, want := range trueWeights {
+		if got := fitted[slug]; math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s: fitted %v, want %v", slug, got, want)
+		}
+	}
+}
+
+func TestFitClampsToBounds(t *testing.T) {
+	trueWeights := map[string]float64{
+		"naked-single": 2,
+		"xy-chain":     150,
+	}
+
+	samples := syntheticSamples(trueWeights, 20, 11)
+	fitted, err := FitWeights(samples, Bounds{Min: 5, Max: 100})
+	if err != nil {
+		t.Fatalf("FitWeights failed: %v", err)
+	}
+
+	if got := fitted["naked-single"]; got != 5 {
+		t.Errorf("naked-single: fitted %v, want clamp to lower bound 5", got)
+	}
+	if got := fitted["xy-chain"]; got != 100 {
+		t.Errorf("xy-chain: fitted %v, want clamp to upper bound 100", got)
+	}
+}
+
+func TestFitRejectsCollinearCounts(t *testing.T) {
+	// Two techniques that always appear together in the same ratio cannot
+	// have their weights separated
+	var samples []Sample
+	for i := 1; i <= 10; i++ {
+		samples = append(samples, Sample{
+			Counts: map[string]int{"a": i, "b": 2 * i},
+			Target: float64(100 * i),
+		})
+	}
+	if _, err := FitWeights(samples, Bounds{Min: 1, Max: 200}); err == nil {
+		t.Error("expected an error for collinear technique counts")
+	}
+}
+
+func TestFitRejectsUnderDeterminedData(t *testing.T) {
+	samples := []Sample{{Counts: map[string]int{"a": 3, "b": 1}, Target: 50}}
+	if _, err := FitWeights(samples, Bounds{Min: 1, Max: 200}); err == nil {
+		t.Error("expected an error when samples are fewer than techniques")
+	}
+}
+
+func TestParseCSVSkipsMalformedRows(t *testing.T) {
+	input := strings.Join([]string{
+		"puzzleId,difficulty,medianSolveSeconds,abandonRate",
+		"abc123,medium,412.5,0.08",
+		"def456,hard,only-three-fields",
+		"ghi789,extreme,not-a-number,0.2",
+		"jkl012,easy,-30,0.1",
+		"mno345,easy,95,1.5",
+		"pqr678,impossible,1820,0.41",
+	}, "\n")
+
+	observations, warnings := ParseCSV(strings.NewReader(input))
+
+	if len(observations) != 2 {
+		t.Fatalf("parsed %d observations, want 2: %+v", len(observations), observations)
+	}
+	if observations[0].PuzzleID != "abc123" || observations[0].MedianSolveSeconds != 412.5 || observations[0].AbandonRate != 0.08 {
+		t.Errorf("first observation parsed wrong: %+v", observations[0])
+	}
+	if observations[1].PuzzleID != "pqr678" || observations[1].Difficulty != "impossible" {
+		t.Errorf("second observation parsed wrong: %+v", observations[1])
+	}
+	if len(warnings) != 4 {
+		t.Errorf("got %d warnings, want 4 (one per malformed row): %v", len(warnings), warnings)
+	}
+}
+
+func TestObservationTargetInflatesForAbandonment(t *testing.T) {
+	obs := Observation{MedianSolveSeconds: 600, AbandonRate: 0.25}
+	if got := obs.Target(); got != 750 {
+		t.Errorf("Target() = %v, want 750", got)
+	}
+}
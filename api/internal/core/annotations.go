@@ -0,0 +1,79 @@
+package core
+
+import (
+	"fmt"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Cell annotations: the per-cell metadata advanced players layer on top of a
+// board — a highlight color plus Snyder-style corner marks and center marks.
+// The server validates ranges and round-trips annotations untouched; the only
+// semantic bridge is AnnotationsToCandidates, which lets the solver treat
+// center marks as the user's candidate set when no candidates are given.
+
+// MaxColorIndex bounds CellAnnotation.ColorIndex. The palette itself belongs
+// to the frontend; the server only caps the index so stored state stays sane.
+const MaxColorIndex = 15
+
+// CellAnnotation is one cell's display metadata. The zero value means an
+// unannotated cell, so a slice of annotations can be sparse in practice while
+// staying fixed-length on the wire.
+type CellAnnotation struct {
+	ColorIndex  uint8 `json:"color_index,omitempty"`
+	CornerMarks []int `json:"corner_marks,omitempty"`
+	CenterMarks []int `json:"center_marks,omitempty"`
+}
+
+// ValidateAnnotations checks an annotation slice for structural sanity: one
+// entry per cell, marks in digit range, colors within the palette bound. A
+// nil slice is valid and means no annotations at all.
+func ValidateAnnotations(annotations []CellAnnotation) error {
+	if annotations == nil {
+		return nil
+	}
+	if len(annotations) != constants.TotalCells {
+		return fmt.Errorf("annotations must have %d elements, got %d", constants.TotalCells, len(annotations))
+	}
+	for i, a := range annotations {
+		if a.ColorIndex > MaxColorIndex {
+			return fmt.Errorf("cell %d holds invalid color index %d", i, a.ColorIndex)
+		}
+		for _, d := range a.CornerMarks {
+			if d < 1 || d > constants.GridSize {
+				return fmt.Errorf("cell %d holds invalid corner mark %d", i, d)
+			}
+		}
+		for _, d := range a.CenterMarks {
+			if d < 1 || d > constants.GridSize {
+				return fmt.Errorf("cell %d holds invalid center mark %d", i, d)
+			}
+		}
+	}
+	return nil
+}
+
+// AnnotationsToCandidates maps center marks onto the solver's candidate
+// shape, for callers that want hints to respect the user's center marks when
+// no explicit candidate set was provided. Returns nil when annotations are
+// nil or carry no center marks anywhere, so callers fall back to recomputing
+// candidates from the board.
+func AnnotationsToCandidates(annotations []CellAnnotation) [][]int {
+	if annotations == nil {
+		return nil
+	}
+	any := false
+	candidates := make([][]int, len(annotations))
+	for i, a := range annotations {
+		digits := make([]int, len(a.CenterMarks))
+		copy(digits, a.CenterMarks)
+		candidates[i] = digits
+		if len(digits) > 0 {
+			any = true
+		}
+	}
+	if !any {
+		return nil
+	}
+	return candidates
+}
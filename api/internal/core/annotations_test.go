@@ -0,0 +1,96 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// blankAnnotations returns an all-zero annotation slice of the right length
+func blankAnnotations() []CellAnnotation {
+	return make([]CellAnnotation, constants.TotalCells)
+}
+
+func TestValidateAnnotations(t *testing.T) {
+	if err := ValidateAnnotations(nil); err != nil {
+		t.Errorf("nil annotations should be valid, got %v", err)
+	}
+	if err := ValidateAnnotations(blankAnnotations()); err != nil {
+		t.Errorf("blank annotations should be valid, got %v", err)
+	}
+
+	good := blankAnnotations()
+	good[12] = CellAnnotation{ColorIndex: MaxColorIndex, CornerMarks: []int{1, 9}, CenterMarks: []int{4, 5}}
+	if err := ValidateAnnotations(good); err != nil {
+		t.Errorf("in-range annotations should be valid, got %v", err)
+	}
+
+	cases := []struct {
+		name string
+		want string
+		mut  func([]CellAnnotation) []CellAnnotation
+	}{
+		{"short slice", "elements", func(a []CellAnnotation) []CellAnnotation { return a[:80] }},
+		{"color out of range", "color index", func(a []CellAnnotation) []CellAnnotation {
+			a[3].ColorIndex = MaxColorIndex + 1
+			return a
+		}},
+		{"corner mark zero", "corner mark", func(a []CellAnnotation) []CellAnnotation {
+			a[7].CornerMarks = []int{0}
+			return a
+		}},
+		{"center mark too large", "center mark", func(a []CellAnnotation) []CellAnnotation {
+			a[80].CenterMarks = []int{10}
+			return a
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAnnotations(tc.mut(blankAnnotations()))
+			if err == nil {
+				t.Fatalf("expected error for %s", tc.name)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestAnnotationsToCandidates(t *testing.T) {
+	if got := AnnotationsToCandidates(nil); got != nil {
+		t.Errorf("nil annotations should map to nil candidates, got %v", got)
+	}
+
+	// Colors and corner marks alone carry no candidate meaning
+	colored := blankAnnotations()
+	colored[4] = CellAnnotation{ColorIndex: 2, CornerMarks: []int{1, 2}}
+	if got := AnnotationsToCandidates(colored); got != nil {
+		t.Errorf("annotations without center marks should map to nil, got %v", got)
+	}
+
+	marked := blankAnnotations()
+	marked[10] = CellAnnotation{CenterMarks: []int{2, 7}}
+	marked[60] = CellAnnotation{ColorIndex: 1, CenterMarks: []int{5}}
+	got := AnnotationsToCandidates(marked)
+	if got == nil {
+		t.Fatal("expected candidates from center marks, got nil")
+	}
+	if len(got) != constants.TotalCells {
+		t.Fatalf("expected %d candidate cells, got %d", constants.TotalCells, len(got))
+	}
+	if !reflect.DeepEqual(got[10], []int{2, 7}) || !reflect.DeepEqual(got[60], []int{5}) {
+		t.Errorf("center marks did not map through: %v, %v", got[10], got[60])
+	}
+	if len(got[0]) != 0 {
+		t.Errorf("unmarked cell should map to no candidates, got %v", got[0])
+	}
+
+	// The mapping copies, so mutating the result leaves the marks alone
+	got[10][0] = 9
+	if marked[10].CenterMarks[0] != 2 {
+		t.Error("mapping aliased the center mark slice")
+	}
+}
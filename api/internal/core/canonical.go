@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Canonicalization maps a grid to a representative that is identical for every
+// grid reachable by the Sudoku symmetry group: digit relabeling, transposition,
+// permuting the three row bands and three column stacks, and permuting rows
+// and columns within a band or stack. Two puzzles that differ only by such a
+// transformation are the same puzzle for dedup and leaderboard purposes.
+//
+// The grid automorphisms alone (ignoring relabeling) number
+// 2 * 6^4 * 6^4 = 3,359,232. Relabeling does not multiply the search: for any
+// fixed cell ordering the lexicographically smallest labeling is obtained by
+// numbering digits in order of first occurrence, so each automorphism
+// contributes exactly one candidate string. The search walks all automorphisms
+// and keeps the smallest candidate, pruning each one at the first character
+// that exceeds the best found so far.
+
+// canonicalSearchBudget caps the total cells rendered across the search.
+// Pruning kills most candidates within a few cells, but near-symmetric grids
+// can tie on long prefixes; when the budget runs out CanonicalForm falls back
+// to a hash (see fallbackForm). The cap is a cell count rather than a
+// deadline so the result is deterministic across machines.
+const canonicalSearchBudget = 64_000_000
+
+// canonicalFallbackPrefix marks a fallback hash. Exact forms are 81
+// characters of '0'-'9', so the two can never collide.
+const canonicalFallbackPrefix = "h:"
+
+// Transform records the symmetry operation that maps a grid onto its
+// canonical form: an optional transpose followed by band-preserving row and
+// column permutations and a digit relabeling.
+type Transform struct {
+	Transpose bool    `json:"transpose"`
+	RowOrder  [9]int  `json:"row_order"` // RowOrder[i] = source row placed at row i (after any transpose)
+	ColOrder  [9]int  `json:"col_order"` // ColOrder[i] = source column placed at column i
+	DigitMap  [10]int `json:"digit_map"` // DigitMap[d] = canonical digit for source digit d; 0 for unused digits
+	Exact     bool    `json:"exact"`     // false when the search budget ran out and a fallback hash was returned
+}
+
+// lineOrders holds every band-preserving permutation of nine rows (or nine
+// columns): 6 band orders times 6 orders within each of the three bands,
+// 1296 in total. Built once on first use.
+var (
+	lineOrdersOnce sync.Once
+	lineOrders     [][9]int
+)
+
+// perms3 lists the six permutations of {0, 1, 2}
+var perms3 = [6][3]int{
+	{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+}
+
+func buildLineOrders() {
+	lineOrders = make([][9]int, 0, 6*6*6*6)
+	for _, bands := range perms3 {
+		for _, p0 := range perms3 {
+			for _, p1 := range perms3 {
+				for _, p2 := range perms3 {
+					inner := [3][3]int{p0, p1, p2}
+					var order [9]int
+					for i := 0; i < constants.GridSize; i++ {
+						band := i / constants.BoxSize
+						order[i] = bands[band]*constants.BoxSize + inner[band][i%constants.BoxSize]
+					}
+					lineOrders = append(lineOrders, order)
+				}
+			}
+		}
+	}
+}
+
+// CanonicalForm returns the canonical 81-character representation of a grid
+// (0 for empty cells) and the transform that produces it. Givens must hold
+// constants.TotalCells values; callers are expected to have validated the
+// board shape.
+//
+// When the pruned search exhausts its budget the returned string is a
+// fallback hash instead (prefixed "h:", see fallbackForm) and the transform's
+// Exact field is false. Fallback forms still dedup exact relabelings but miss
+// geometric transformations, so a budget overrun degrades detection rather
+// than breaking it.
+func CanonicalForm(givens []int) (string, Transform) {
+	if len(givens) != constants.TotalCells {
+		return fallbackForm(givens)
+	}
+	lineOrdersOnce.Do(buildLineOrders)
+
+	// The transposed grid reuses the same row/column order enumeration, so
+	// transposition only doubles the outer loop
+	transposed := make([]int, constants.TotalCells)
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			transposed[c*constants.GridSize+r] = givens[r*constants.GridSize+c]
+		}
+	}
+
+	var (
+		best     [constants.TotalCells]byte
+		buf      [constants.TotalCells]byte
+		haveBest bool
+		bestT    Transform
+		budget   = canonicalSearchBudget
+	)
+	for t, grid := range [2][]int{givens, transposed} {
+		for ri := range lineOrders {
+			rowOrder := &lineOrders[ri]
+			for ci := range lineOrders {
+				colOrder := &lineOrders[ci]
+
+				// Render this candidate with first-occurrence relabeling,
+				// pruning at the first character worse than the best so far
+				var relabel [10]byte
+				next := byte('1')
+				decided := !haveBest // strictly smaller than best on some prefix
+				pos := 0
+				for pos < constants.TotalCells {
+					v := grid[rowOrder[pos/constants.GridSize]*constants.GridSize+colOrder[pos%constants.GridSize]]
+					ch := byte('0')
+					if v != 0 {
+						if relabel[v] == 0 {
+							relabel[v] = next
+							next++
+						}
+						ch = relabel[v]
+					}
+					if !decided {
+						if ch > best[pos] {
+							break
+						}
+						decided = ch < best[pos]
+					}
+					buf[pos] = ch
+					pos++
+				}
+				budget -= pos + 1
+				if pos == constants.TotalCells && decided {
+					best = buf
+					haveBest = true
+					bestT = Transform{Transpose: t == 1, RowOrder: *rowOrder, ColOrder: *colOrder, Exact: true}
+					for d := 1; d <= constants.GridSize; d++ {
+						if relabel[d] != 0 {
+							bestT.DigitMap[d] = int(relabel[d] - '0')
+						}
+					}
+				}
+				if budget <= 0 {
+					return fallbackForm(givens)
+				}
+			}
+		}
+	}
+	return string(best[:]), bestT
+}
+
+// fallbackForm hashes the first-occurrence relabeling of the untransformed
+// grid. The hash is stable and invariant under digit relabeling, but not
+// under geometric transformations, so two grids that only fall back compare
+// equal only when one is a pure relabeling of the other.
+func fallbackForm(givens []int) (string, Transform) {
+	var t Transform
+	for i := 0; i < constants.GridSize; i++ {
+		t.RowOrder[i] = i
+		t.ColOrder[i] = i
+	}
+	next := 1
+	relabeled := make([]byte, 0, len(givens))
+	for _, v := range givens {
+		ch := byte('0')
+		if v >= 1 && v <= constants.GridSize {
+			if t.DigitMap[v] == 0 {
+				t.DigitMap[v] = next
+				next++
+			}
+			ch = byte('0' + t.DigitMap[v])
+		}
+		relabeled = append(relabeled, ch)
+	}
+	h := fnv.New64a()
+	h.Write(relabeled)
+	return fmt.Sprintf("%s%016x", canonicalFallbackPrefix, h.Sum64()), t
+}
+
+// AreEquivalent reports whether two grids are the same puzzle up to digit
+// relabeling and grid automorphisms. When either side hit the search budget
+// and fell back to a hash, equivalence degrades to relabel-only detection and
+// transformed copies may go unrecognized.
+func AreEquivalent(a, b []int) bool {
+	formA, _ := CanonicalForm(a)
+	formB, _ := CanonicalForm(b)
+	return formA == formB
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// testGrid is a 24-given classic puzzle used as the canonicalization subject
+var testGrid = parseGrid(
+	"000260701" +
+		"680070090" +
+		"190004500" +
+		"820100040" +
+		"004602900" +
+		"050003028" +
+		"009300074" +
+		"040050036" +
+		"703018000")
+
+func parseGrid(s string) []int {
+	grid := make([]int, len(s))
+	for i, ch := range s {
+		grid[i] = int(ch - '0')
+	}
+	return grid
+}
+
+// applyTransform renders a grid under a Transform, for round-trip checks
+func applyTransform(givens []int, t Transform) string {
+	grid := givens
+	if t.Transpose {
+		grid = make([]int, constants.TotalCells)
+		for r := 0; r < constants.GridSize; r++ {
+			for c := 0; c < constants.GridSize; c++ {
+				grid[c*constants.GridSize+r] = givens[r*constants.GridSize+c]
+			}
+		}
+	}
+	out := make([]byte, constants.TotalCells)
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			v := grid[t.RowOrder[r]*constants.GridSize+t.ColOrder[c]]
+			out[r*constants.GridSize+c] = byte('0' + t.DigitMap[v])
+		}
+	}
+	return string(out)
+}
+
+func TestCanonicalForm(t *testing.T) {
+	form, transform := CanonicalForm(testGrid)
+
+	if len(form) != constants.TotalCells {
+		t.Fatalf("expected %d-char form, got %d: %q", constants.TotalCells, len(form), form)
+	}
+	if !transform.Exact {
+		t.Fatal("expected an exact canonical form for a single grid")
+	}
+
+	t.Run("transform round-trips to the form", func(t *testing.T) {
+		if rendered := applyTransform(testGrid, transform); rendered != form {
+			t.Errorf("applying the returned transform yields %q, want %q", rendered, form)
+		}
+	})
+
+	t.Run("transpose canonicalizes identically", func(t *testing.T) {
+		transposed := make([]int, constants.TotalCells)
+		for r := 0; r < constants.GridSize; r++ {
+			for c := 0; c < constants.GridSize; c++ {
+				transposed[c*constants.GridSize+r] = testGrid[r*constants.GridSize+c]
+			}
+		}
+		if other, _ := CanonicalForm(transposed); other != form {
+			t.Errorf("transpose canonicalized to %q, want %q", other, form)
+		}
+	})
+
+	t.Run("digit relabeling canonicalizes identically", func(t *testing.T) {
+		// Permute digits 1..9 -> 3,1,4,5,9,2,6,8,7
+		relabel := [10]int{0, 3, 1, 4, 5, 9, 2, 6, 8, 7}
+		relabeled := make([]int, constants.TotalCells)
+		for i, v := range testGrid {
+			relabeled[i] = relabel[v]
+		}
+		if other, _ := CanonicalForm(relabeled); other != form {
+			t.Errorf("relabeled grid canonicalized to %q, want %q", other, form)
+		}
+	})
+
+	t.Run("band and row swaps canonicalize identically", func(t *testing.T) {
+		// Swap the first two row bands, then the last two rows of the grid
+		swapped := make([]int, constants.TotalCells)
+		rowMap := [9]int{3, 4, 5, 0, 1, 2, 6, 8, 7}
+		for r := 0; r < constants.GridSize; r++ {
+			copy(swapped[r*constants.GridSize:(r+1)*constants.GridSize],
+				testGrid[rowMap[r]*constants.GridSize:(rowMap[r]+1)*constants.GridSize])
+		}
+		if other, _ := CanonicalForm(swapped); other != form {
+			t.Errorf("row-permuted grid canonicalized to %q, want %q", other, form)
+		}
+	})
+
+	t.Run("genuinely different puzzle does not match", func(t *testing.T) {
+		moved := make([]int, constants.TotalCells)
+		copy(moved, testGrid)
+		// Drop one given and add another elsewhere: no symmetry maps one
+		// given layout onto the other
+		moved[2] = 0
+		moved[0] = 4
+		if other, _ := CanonicalForm(moved); other == form {
+			t.Error("different puzzles canonicalized to the same form")
+		}
+	})
+}
+
+func TestAreEquivalent(t *testing.T) {
+	transposed := make([]int, constants.TotalCells)
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			transposed[c*constants.GridSize+r] = testGrid[r*constants.GridSize+c]
+		}
+	}
+	if !AreEquivalent(testGrid, transposed) {
+		t.Error("expected a grid and its transpose to be equivalent")
+	}
+
+	different := make([]int, constants.TotalCells)
+	copy(different, testGrid)
+	different[80] = 5
+	if AreEquivalent(testGrid, different) {
+		t.Error("expected grids with different givens to differ")
+	}
+}
+
+func TestCanonicalFallbackForm(t *testing.T) {
+	// A wrong-sized board can't be searched; it gets the documented fallback
+	form, transform := CanonicalForm([]int{1, 2, 3})
+	if !strings.HasPrefix(form, canonicalFallbackPrefix) {
+		t.Errorf("expected fallback prefix %q, got %q", canonicalFallbackPrefix, form)
+	}
+	if transform.Exact {
+		t.Error("fallback forms must not report an exact transform")
+	}
+
+	// Fallback hashes are still relabel-invariant
+	a := []int{1, 2, 3}
+	b := []int{7, 4, 9}
+	formA, _ := CanonicalForm(a)
+	formB, _ := CanonicalForm(b)
+	if formA != formB {
+		t.Errorf("relabelings should share a fallback hash: %q vs %q", formA, formB)
+	}
+}
+
+func BenchmarkCanonicalForm(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		CanonicalForm(testGrid)
+	}
+}
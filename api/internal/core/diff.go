@@ -0,0 +1,41 @@
+package core
+
+// Move deltas. A full solve can run to hundreds of moves, and shipping the
+// complete board and candidate arrays after every one of them makes the
+// payload enormous. These types carry only what a move changed, so a client
+// holding the initial state can replay the solve without recomputing
+// candidates.
+
+// CellDiff records one cell value change
+type CellDiff struct {
+	Index int `json:"index"`
+	Old   int `json:"old"`
+	New   int `json:"new"`
+}
+
+// CandidateDiff records the pencil-mark changes produced by one move
+type CandidateDiff struct {
+	Added   []Candidate `json:"added,omitempty"`
+	Removed []Candidate `json:"removed,omitempty"`
+}
+
+// MoveDiff bundles the cell and candidate changes of one applied move
+type MoveDiff struct {
+	Cells      []CellDiff    `json:"cells,omitempty"`
+	Candidates CandidateDiff `json:"candidates"`
+}
+
+// ApplyDiffs replays a sequence of move diffs on top of an initial board and
+// returns the resulting cells. The input is not modified.
+func ApplyDiffs(initial []int, diffs []MoveDiff) []int {
+	cells := make([]int, len(initial))
+	copy(cells, initial)
+	for _, diff := range diffs {
+		for _, cd := range diff.Cells {
+			if cd.Index >= 0 && cd.Index < len(cells) {
+				cells[cd.Index] = cd.New
+			}
+		}
+	}
+	return cells
+}
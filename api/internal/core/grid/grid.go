@@ -0,0 +1,139 @@
+// Package grid is the single source of truth for classic Sudoku geometry:
+// which cells form each row, column, and box, and which cells see each other.
+// Every table is precomputed once at init, so callers iterate peers and units
+// without per-call allocation, and ArePeers is a constant-time lookup.
+//
+// The human solver, the dp solver, and the WASM build all derive their
+// geometry from here rather than recomputing row/col/box math locally.
+package grid
+
+import "sudoku-api/pkg/constants"
+
+// PeersPerCell is the number of distinct peers every cell has: the rest of
+// its row, the rest of its column, and the box cells not already counted.
+const PeersPerCell = 2*(constants.GridSize-1) + (constants.BoxSize-1)*(constants.BoxSize-1)
+
+var (
+	// Rows maps row number to the cell indices in that row, left to right
+	Rows [constants.GridSize][constants.GridSize]int
+
+	// Cols maps column number to the cell indices in that column, top to bottom
+	Cols [constants.GridSize][constants.GridSize]int
+
+	// Boxes maps box number to the cell indices in that box, in reading order
+	Boxes [constants.GridSize][constants.GridSize]int
+
+	// peers holds each cell's full peer list in ascending index order
+	peers [constants.TotalCells][PeersPerCell]int
+
+	// rowPeers/colPeers/boxPeers hold the per-unit peer lists (self excluded)
+	rowPeers [constants.TotalCells][constants.GridSize - 1]int
+	colPeers [constants.TotalCells][constants.GridSize - 1]int
+	boxPeers [constants.TotalCells][constants.GridSize - 1]int
+
+	// peerMatrix answers "do these two cells see each other" in one lookup
+	peerMatrix [constants.TotalCells][constants.TotalCells]bool
+)
+
+func init() {
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			idx := r*constants.GridSize + c
+			Rows[r][c] = idx
+			Cols[c][r] = idx
+			box := (r/constants.BoxSize)*constants.BoxSize + c/constants.BoxSize
+			pos := (r%constants.BoxSize)*constants.BoxSize + c%constants.BoxSize
+			Boxes[box][pos] = idx
+		}
+	}
+
+	for i := 0; i < constants.TotalCells; i++ {
+		rn, cn, bn := 0, 0, 0
+		for _, idx := range Rows[RowOf(i)] {
+			if idx != i {
+				rowPeers[i][rn] = idx
+				rn++
+				peerMatrix[i][idx] = true
+			}
+		}
+		for _, idx := range Cols[ColOf(i)] {
+			if idx != i {
+				colPeers[i][cn] = idx
+				cn++
+				peerMatrix[i][idx] = true
+			}
+		}
+		for _, idx := range Boxes[BoxOf(i)] {
+			if idx != i {
+				boxPeers[i][bn] = idx
+				bn++
+				peerMatrix[i][idx] = true
+			}
+		}
+
+		// Walking the matrix in index order yields the sorted peer list
+		n := 0
+		for idx := 0; idx < constants.TotalCells; idx++ {
+			if peerMatrix[i][idx] {
+				peers[i][n] = idx
+				n++
+			}
+		}
+	}
+}
+
+// RowOf returns the row number for a cell index
+func RowOf(idx int) int {
+	return idx / constants.GridSize
+}
+
+// ColOf returns the column number for a cell index
+func ColOf(idx int) int {
+	return idx % constants.GridSize
+}
+
+// BoxOf returns the box number for a cell index
+func BoxOf(idx int) int {
+	return (RowOf(idx)/constants.BoxSize)*constants.BoxSize + ColOf(idx)/constants.BoxSize
+}
+
+// IndexOf returns the cell index for given row and column
+func IndexOf(row, col int) int {
+	return row*constants.GridSize + col
+}
+
+// PeersOf returns all peers of a cell in ascending order. The slice is a view
+// into the shared table: do not modify it.
+func PeersOf(idx int) []int {
+	return peers[idx][:]
+}
+
+// RowPeersOf returns the other cells in idx's row, as a view into the table
+func RowPeersOf(idx int) []int {
+	return rowPeers[idx][:]
+}
+
+// ColPeersOf returns the other cells in idx's column, as a view into the table
+func ColPeersOf(idx int) []int {
+	return colPeers[idx][:]
+}
+
+// BoxPeersOf returns the other cells in idx's box, as a view into the table
+func BoxPeersOf(idx int) []int {
+	return boxPeers[idx][:]
+}
+
+// UnitsOf returns the three units containing a cell (row, column, box), each
+// as a view into the shared tables. The cell itself is included in each unit.
+func UnitsOf(idx int) [3][]int {
+	return [3][]int{
+		Rows[RowOf(idx)][:],
+		Cols[ColOf(idx)][:],
+		Boxes[BoxOf(idx)][:],
+	}
+}
+
+// ArePeers returns true if two distinct cells share a row, column, or box
+func ArePeers(idx1, idx2 int) bool {
+	return peerMatrix[idx1][idx2]
+}
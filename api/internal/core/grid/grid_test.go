@@ -0,0 +1,167 @@
+package grid
+
+import (
+	"sort"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// computedArePeers is the arithmetic definition the table is built from; the
+// tests and benchmark compare the precomputed answers against it.
+func computedArePeers(idx1, idx2 int) bool {
+	if idx1 == idx2 {
+		return false
+	}
+	return RowOf(idx1) == RowOf(idx2) ||
+		ColOf(idx1) == ColOf(idx2) ||
+		BoxOf(idx1) == BoxOf(idx2)
+}
+
+func TestUnitsPartitionTheGrid(t *testing.T) {
+	for name, units := range map[string][constants.GridSize][constants.GridSize]int{
+		"rows": Rows, "cols": Cols, "boxes": Boxes,
+	} {
+		seen := make(map[int]int)
+		for u := 0; u < constants.GridSize; u++ {
+			for _, idx := range units[u] {
+				seen[idx]++
+			}
+		}
+		if len(seen) != constants.TotalCells {
+			t.Errorf("%s cover %d cells, want %d", name, len(seen), constants.TotalCells)
+		}
+		for idx, count := range seen {
+			if count != 1 {
+				t.Errorf("%s contain cell %d %d times, want once", name, idx, count)
+			}
+		}
+	}
+}
+
+func TestUnitsMatchCoordinateHelpers(t *testing.T) {
+	for u := 0; u < constants.GridSize; u++ {
+		for _, idx := range Rows[u] {
+			if RowOf(idx) != u {
+				t.Errorf("cell %d listed in row %d but RowOf says %d", idx, u, RowOf(idx))
+			}
+		}
+		for _, idx := range Cols[u] {
+			if ColOf(idx) != u {
+				t.Errorf("cell %d listed in col %d but ColOf says %d", idx, u, ColOf(idx))
+			}
+		}
+		for _, idx := range Boxes[u] {
+			if BoxOf(idx) != u {
+				t.Errorf("cell %d listed in box %d but BoxOf says %d", idx, u, BoxOf(idx))
+			}
+		}
+	}
+}
+
+func TestPeersOfEveryCell(t *testing.T) {
+	for i := 0; i < constants.TotalCells; i++ {
+		peers := PeersOf(i)
+		if len(peers) != PeersPerCell {
+			t.Fatalf("cell %d has %d peers, want %d", i, len(peers), PeersPerCell)
+		}
+		if !sort.IntsAreSorted(peers) {
+			t.Errorf("peers of cell %d are not sorted: %v", i, peers)
+		}
+		seen := make(map[int]bool)
+		for _, p := range peers {
+			if p == i {
+				t.Errorf("cell %d lists itself as a peer", i)
+			}
+			if seen[p] {
+				t.Errorf("cell %d lists peer %d twice", i, p)
+			}
+			seen[p] = true
+			if !computedArePeers(i, p) {
+				t.Errorf("cell %d lists %d as a peer but they share no unit", i, p)
+			}
+		}
+		// Nothing missing: every cell sharing a unit is in the list
+		for j := 0; j < constants.TotalCells; j++ {
+			if computedArePeers(i, j) && !seen[j] {
+				t.Errorf("cell %d is missing peer %d", i, j)
+			}
+		}
+	}
+}
+
+func TestUnitPeerViews(t *testing.T) {
+	for i := 0; i < constants.TotalCells; i++ {
+		for _, p := range RowPeersOf(i) {
+			if p == i || RowOf(p) != RowOf(i) {
+				t.Errorf("cell %d has bad row peer %d", i, p)
+			}
+		}
+		for _, p := range ColPeersOf(i) {
+			if p == i || ColOf(p) != ColOf(i) {
+				t.Errorf("cell %d has bad col peer %d", i, p)
+			}
+		}
+		for _, p := range BoxPeersOf(i) {
+			if p == i || BoxOf(p) != BoxOf(i) {
+				t.Errorf("cell %d has bad box peer %d", i, p)
+			}
+		}
+	}
+}
+
+func TestUnitsOf(t *testing.T) {
+	for i := 0; i < constants.TotalCells; i++ {
+		units := UnitsOf(i)
+		for u, unit := range units {
+			if len(unit) != constants.GridSize {
+				t.Fatalf("cell %d unit %d has %d cells, want %d", i, u, len(unit), constants.GridSize)
+			}
+			found := false
+			for _, idx := range unit {
+				if idx == i {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("cell %d is not in its own unit %d", i, u)
+			}
+		}
+	}
+}
+
+func TestArePeersMatchesComputed(t *testing.T) {
+	for i := 0; i < constants.TotalCells; i++ {
+		for j := 0; j < constants.TotalCells; j++ {
+			if got, want := ArePeers(i, j), computedArePeers(i, j); got != want {
+				t.Errorf("ArePeers(%d, %d) = %v, want %v", i, j, got, want)
+			}
+			if ArePeers(i, j) != ArePeers(j, i) {
+				t.Errorf("ArePeers(%d, %d) is not symmetric", i, j)
+			}
+		}
+	}
+}
+
+var benchSink bool
+
+func BenchmarkArePeersTable(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < constants.TotalCells; i++ {
+			for j := 0; j < constants.TotalCells; j++ {
+				benchSink = ArePeers(i, j)
+			}
+		}
+	}
+}
+
+func BenchmarkArePeersComputed(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < constants.TotalCells; i++ {
+			for j := 0; j < constants.TotalCells; j++ {
+				benchSink = computedArePeers(i, j)
+			}
+		}
+	}
+}
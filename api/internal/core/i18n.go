@@ -0,0 +1,376 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// Explanation rendering
+// ============================================================================
+//
+// Move explanations are stored as a message key plus parameters so clients
+// can localize them; the English sentence in Move.Explanation is rendered
+// from the same template table and kept for backward compatibility.
+//
+// Templates use {name} placeholders filled from the params map. Values are
+// formatted locale-neutrally (digits, cell names like R3C7, comma-joined
+// lists), except for the unit words ("row", "column", "box" and their
+// plurals), which are translated through a per-language vocabulary so
+// detectors can keep passing UnitRef type strings as parameters.
+
+// DefaultLang is the language explanations are rendered in when no language
+// is requested, and the fallback for keys missing from a translation.
+const DefaultLang = "en"
+
+// SupportedLang reports whether a template table is bundled for the language
+func SupportedLang(lang string) bool {
+	_, ok := explanationTemplates[lang]
+	return ok
+}
+
+// unitVocab translates the unit words detectors embed in parameters
+var unitVocab = map[string]map[string]string{
+	"es": {
+		"row":     "fila",
+		"column":  "columna",
+		"box":     "caja",
+		"rows":    "filas",
+		"columns": "columnas",
+		"boxes":   "cajas",
+	},
+}
+
+// ChainStep is one inference of a chain narration: the candidate it talks
+// about, whether it asserts the digit true or false there, and whether the
+// inference follows a strong link ("then") or a weak link ("so").
+type ChainStep struct {
+	Cell   string `json:"cell"`
+	Digit  int    `json:"digit"`
+	True   bool   `json:"true"`
+	Strong bool   `json:"strong"`
+}
+
+// ChainNarration renders as an "if ..., then ..., so ..." walk through a
+// chain, so clients localize the connective words rather than a prebuilt
+// English sentence
+type ChainNarration []ChainStep
+
+// narrationVocab holds the connective words chain narrations render with
+var narrationVocab = map[string]struct {
+	ifWord, thenWord, soWord, isWord, isNotWord string
+}{
+	"en": {"if", "then", "so", "is", "is not"},
+	"es": {"si", "entonces", "así que", "es", "no es"},
+}
+
+var explanationTemplates = map[string]map[string]string{
+	"en": {
+		"technique.naked-single":  "Cell {cell} has only one candidate: {digit}",
+		"technique.hidden-single": "In {unitType} {unitIndex}, {digit} can only go in {cell}",
+
+		"technique.pointing-pair.row":      "In box {box}, {digit} is confined to row {row}: eliminate {digit} from rest of row {row}.",
+		"technique.pointing-pair.col":      "In box {box}, {digit} is confined to column {col}: eliminate {digit} from rest of column {col}.",
+		"technique.pointing-triple.row":    "In box {box}, {digit} is confined to row {row}: eliminate {digit} from rest of row {row}.",
+		"technique.pointing-triple.col":    "In box {box}, {digit} is confined to column {col}: eliminate {digit} from rest of column {col}.",
+		"technique.box-line-reduction.row": "In row {row}, {digit} is confined to box {box}: eliminate {digit} from rest of box {box}.",
+		"technique.box-line-reduction.col": "In column {col}, {digit} is confined to box {box}: eliminate {digit} from rest of box {box}.",
+
+		"technique.naked-pair":    "Naked Pair {digits} in {unitType} {unitIndex}",
+		"technique.naked-triple":  "Naked Triple {digits} in {unitType} {unitIndex}",
+		"technique.naked-quad":    "Naked Quad {digits} in {unitType} {unitIndex}",
+		"technique.hidden-pair":   "Hidden Pair {digits} in {unitType} {unitIndex}",
+		"technique.hidden-triple": "Hidden Triple {digits} in {unitType} {unitIndex}",
+		"technique.hidden-quad":   "Hidden Quad {digits} in {unitType} {unitIndex}",
+		"technique.locked-pair":   "Locked Pair {digits} in box {box} and {unitType} {unitIndex} at {cells}",
+		"technique.locked-triple": "Locked Triple {digits} in box {box} and {unitType} {unitIndex} at {cells}",
+
+		"technique.x-wing.rows":     "X-Wing: {digit} in rows {rows} columns {cols}",
+		"technique.x-wing.cols":     "X-Wing: {digit} in columns {cols} rows {rows}",
+		"technique.swordfish.rows":  "Swordfish: {digit} in rows {rows} columns {cols}",
+		"technique.swordfish.cols":  "Swordfish: {digit} in columns {cols} rows {rows}",
+		"technique.jellyfish":       "Jellyfish: {digit} in {unitTypePlural} {units}",
+		"technique.xy-wing":         "XY-Wing: pivot at {pivot} with wings: eliminate {digit}.",
+		"technique.xyz-wing":        "XYZ-Wing: pivot {pivot} with wings {wing1} and {wing2}: eliminate {digit}.",
+		"technique.wxyz-wing":       "WXYZ-Wing: cells {cells} contain {digits}: eliminate non-restricted {digit}.",
+		"technique.simple-coloring": "Simple Coloring: cell {cell} sees both colors for {digit}",
+
+		"technique.finned-x-wing.rows":             "Finned X-Wing: {digit} in rows {rows} with fin at {fin}",
+		"technique.finned-x-wing.cols":             "Finned X-Wing: {digit} in columns {cols} with fin at {fin}",
+		"technique.sashimi-x-wing.rows":            "Sashimi X-Wing: {digit} in rows {rows} with fin at {fin}",
+		"technique.sashimi-x-wing.cols":            "Sashimi X-Wing: {digit} in columns {cols} with fin at {fin}",
+		"technique.finned-swordfish.rows":          "Finned Swordfish: {digit} in rows {rows} with fin at {fin}",
+		"technique.finned-swordfish.cols":          "Finned Swordfish: {digit} in columns {cols} with fin at {fin}",
+		"technique.sashimi-swordfish.rows":         "Sashimi Swordfish: {digit} in rows {rows} with fin at {fin}",
+		"technique.sashimi-swordfish.cols":         "Sashimi Swordfish: {digit} in columns {cols} with fin at {fin}",
+		"technique.skyscraper":                     "Skyscraper: {digit} with base {base1} and {base2}",
+		"technique.2-string-kite":                  "2-String Kite: {digit} linked through box {box}",
+		"technique.turbot-fish":                    "Turbot Fish: {digit} chained from {end1} to {end2}",
+		"technique.empty-rectangle.row":            "Empty Rectangle: {digit} in box {box} with conjugate pair in row {row}: eliminate from {cell}.",
+		"technique.empty-rectangle.col":            "Empty Rectangle: {digit} in box {box} with conjugate pair in column {col}: eliminate from {cell}.",
+		"technique.unique-rectangle":               "Unique Rectangle Type 1: {d1}/{d2} would form deadly pattern: eliminate from {cell}.",
+		"technique.unique-rectangle-type-2":        "Unique Rectangle Type 2: {d1}/{d2} with extra {digit}: eliminate {digit} from cells seeing both {roof1} and {roof2}.",
+		"technique.unique-rectangle-type-3.pair":   "Unique Rectangle Type 3: {d1}/{d2}: pseudo-cell with {digits} forms naked pair with {cell} in {unitType}.",
+		"technique.unique-rectangle-type-3.triple": "Unique Rectangle Type 3: {d1}/{d2}: pseudo-cell forms naked triple with {cell1} and {cell2} in {unitType}.",
+		"technique.unique-rectangle-type-4":        "Unique Rectangle Type 4: {d1}/{d2}: {keep} confined to UR in {unitType} {unitIndex}: eliminate {remove}.",
+		"technique.unique-rectangle-type-5":        "Unique Rectangle Type 5: {d1}/{d2} with extra {digit} on {cells}: one must hold {digit}: eliminate it from cells seeing all of them.",
+		"technique.unique-rectangle-type-6":        "Unique Rectangle Type 6: {d1}/{d2}: {digit} forms an X-Wing on the rectangle {unitType}: eliminate {digit} from the roof corners.",
+		"technique.hidden-rectangle":               "Hidden Rectangle: {d1}/{d2}: {strong} is conjugate-paired through {cell}: eliminate {digit} there.",
+		"technique.bug":                            "BUG+1: All other cells are bi-value; {cell} must be {digit} to avoid multiple solutions",
+
+		"technique.x-chain":                          "X-Chain on {digit} from {head} to {tail}: one end must be {digit}, eliminate it from cells seeing both.",
+		"technique.xy-chain":                         "XY-Chain of {length} cells from {head} to {tail}: both ends force a {digit}, eliminate it from cells seeing both.",
+		"technique.nice-loop":                        "Nice Loop from {head} to {tail}: {narration}; one end is always true, so eliminate candidates seeing both ends.",
+		"technique.nice-loop.loop":                   "Nice Loop closing back on {head}: {narration}; every weak link in the loop eliminates the candidates it covers.",
+		"technique.w-wing":                           "W-Wing: {digits} cells connected by strong link on {linkDigit}",
+		"technique.aic":                              "AIC: either {head}={headDigit} or {tail}={tailDigit}, candidates seeing both ends are eliminated.",
+		"technique.aic.loop":                         "AIC: continuous loop through {cell}: every weak link eliminates the candidates it covers.",
+		"technique.grouped-x-cycles.assign":          "X-Cycle: two strong links on {digit} meet at {cell}, so it must be {digit}",
+		"technique.grouped-x-cycles.weak":            "X-Cycle: two weak links on {digit} meet at {node}, eliminating {digit} there",
+		"technique.grouped-x-cycles.loop":            "X-Cycle Nice Loop on {digit}: eliminate {digit} from cells seeing both ends of a weak link",
+		"technique.medusa-3d.twice-in-cell":          "3D Medusa: Color {color} has two candidates in {cell}: eliminate all color {color}.",
+		"technique.medusa-3d.twice-in-unit":          "3D Medusa: Color {color} has {digit} twice in same unit ({cell1}, {cell2}): eliminate all color {color}.",
+		"technique.medusa-3d.sees-both":              "3D Medusa: {cell} sees {digit} in both colors: eliminate {digit}.",
+		"technique.medusa-3d.both-in-cell":           "3D Medusa: {cell} has candidates in both colors: eliminate uncolored {digit}.",
+		"technique.medusa-3d.sees-color":             "3D Medusa: {cell} has color {cellColor} and sees {digit} in color {otherColor}: eliminate {digit}.",
+		"technique.medusa-3d.all-in-color":           "3D Medusa: {cell} has all candidates in color {color}: eliminate all color {color}.",
+		"technique.als-xz":                           "ALS-XZ: ALS A {alsA} and ALS B {alsB} with restricted common {x}: eliminate {z}.",
+		"technique.als-xy-wing":                      "ALS-XY-Wing: A={alsA}, B={alsB}, C={alsC}; RC(A-B)={x}, RC(A-C)={y}; eliminate {z}",
+		"technique.als-xy-chain":                     "ALS-XY-Chain: {length} ALS linked by RCs {rcs}; eliminate {z}",
+		"technique.sue-de-coq":                       "Sue de Coq: intersection of box {box} and {unitType} {unitIndex} with candidates {digits}; line ALS {lineCells} holds {lineDigits}, box ALS {boxCells} holds {boxDigits}",
+		"technique.death-blossom":                    "Death Blossom: stem {stem} {stemDigits} with {petals} petals; eliminate {z}",
+		"technique.digit-forcing-chain.assign":       "Digit Forcing Chain: {digit} in {unitType} {unitIndex} can only go in {count} positions; trying each leads to {cell}={value}",
+		"technique.digit-forcing-chain.eliminate":    "Digit Forcing Chain: {digit} in {unitType} {unitIndex} can only go in {count} positions; trying each eliminates {elim} from {cell}",
+		"technique.forcing-chain.contradiction":      "Cell Forcing Chain: If {cell}={digit}, contradiction follows. Therefore {cell}={value}",
+		"technique.forcing-chain.assign":             "Cell Forcing Chain: whether {cell} is {candidates}, {target} must be {digit}",
+		"technique.forcing-chain.eliminate":          "Cell Forcing Chain: whether {cell} is {candidates}, {target} cannot be {digit}",
+		"technique.forcing-chain.unit-contradiction": "Unit Forcing Chain: In {unitType} {unitIndex}, {digit} at other positions leads to contradiction. {cell}={digit}",
+		"technique.forcing-chain.unit-assign":        "Unit Forcing Chain: Wherever {digit} goes in {unitType} {unitIndex}, {cell}={value}",
+		"technique.forcing-chain.unit-eliminate":     "Unit Forcing Chain: Wherever {digit} goes in {unitType} {unitIndex}: eliminate {elim} from {cell}.",
+
+		"solver.constraint-violation": "Constraint violation: {digit} appears twice in {unitType} {unitIndex} at {cell1} and {cell2}",
+		"solver.no-candidates":        "No candidates available for {cell}: contradiction detected",
+		"solver.invalid-candidate":    "Invalid candidate: {cell} has candidate {digit}, but {digit} already exists in this cell's row, column, or box",
+		"solver.add-candidate":        "Added {digit} as a candidate to {cell}",
+		"solver.batch-candidates":     "Pencilled in {count} starting candidates",
+		"solver.forced-single":        "{cell} must be {digit}: only cell in {unitType} {unitIndex} that can contain {digit}",
+
+		"solver.conflict.pair":        "{cell1} and {cell2} both have {digit} in the same {unitType}.",
+		"solver.conflict.remove":      "{cell1} and {cell2} both have {digit} in the same {unitType}. Removing {digit} from {cell}.",
+		"solver.stuck.no-candidates":  "Solver stuck! {cell} has no valid candidates. The {digit} at {source} was causing the problem.",
+		"solver.stuck.remove":         "Solver stuck! Removing {digit} from {cell} allows progress.",
+		"solver.stuck.restore":        "Solver stuck! The candidate {digit} was incorrectly removed from {cell}. Restoring it.",
+		"solver.remove-incorrect":     "Removing incorrect {digit} from {cell}.",
+		"solver.contradiction.found":  "Found it! {cell} has no valid candidates. The {digit} at {source} was causing the problem.",
+		"solver.contradiction.remove": "Contradiction detected! Removing {digit} from {cell} allows progress.",
+		"solver.removed-incorrect":    "Removed incorrect {digit} from {cell} (should be {value})",
+		"solver.full-board.wrong":     "The board is full, but the {digit} at {cell} doesn't match the solution. Removing it.",
+		"solver.full-board.solved":    "The puzzle is solved!",
+	},
+	"es": {
+		"technique.naked-single":  "La celda {cell} tiene un solo candidato: {digit}",
+		"technique.hidden-single": "En {unitType} {unitIndex}, el {digit} solo cabe en {cell}",
+
+		"technique.pointing-pair.row":      "En la caja {box}, el {digit} queda confinado a la fila {row}: eliminarlo del resto de la fila {row}.",
+		"technique.pointing-pair.col":      "En la caja {box}, el {digit} queda confinado a la columna {col}: eliminarlo del resto de la columna {col}.",
+		"technique.pointing-triple.row":    "En la caja {box}, el {digit} queda confinado a la fila {row}: eliminarlo del resto de la fila {row}.",
+		"technique.pointing-triple.col":    "En la caja {box}, el {digit} queda confinado a la columna {col}: eliminarlo del resto de la columna {col}.",
+		"technique.box-line-reduction.row": "En la fila {row}, el {digit} queda confinado a la caja {box}: eliminarlo del resto de la caja {box}.",
+		"technique.box-line-reduction.col": "En la columna {col}, el {digit} queda confinado a la caja {box}: eliminarlo del resto de la caja {box}.",
+
+		"technique.naked-pair":    "Par desnudo {digits} en {unitType} {unitIndex}",
+		"technique.naked-triple":  "Trío desnudo {digits} en {unitType} {unitIndex}",
+		"technique.naked-quad":    "Cuarteto desnudo {digits} en {unitType} {unitIndex}",
+		"technique.hidden-pair":   "Par oculto {digits} en {unitType} {unitIndex}",
+		"technique.hidden-triple": "Trío oculto {digits} en {unitType} {unitIndex}",
+		"technique.hidden-quad":   "Cuarteto oculto {digits} en {unitType} {unitIndex}",
+		"technique.locked-pair":   "Par bloqueado {digits} en la caja {box} y {unitType} {unitIndex} en {cells}",
+		"technique.locked-triple": "Trío bloqueado {digits} en la caja {box} y {unitType} {unitIndex} en {cells}",
+
+		"technique.x-wing.rows":     "X-Wing: {digit} en las filas {rows}, columnas {cols}",
+		"technique.x-wing.cols":     "X-Wing: {digit} en las columnas {cols}, filas {rows}",
+		"technique.swordfish.rows":  "Pez espada: {digit} en las filas {rows}, columnas {cols}",
+		"technique.swordfish.cols":  "Pez espada: {digit} en las columnas {cols}, filas {rows}",
+		"technique.jellyfish":       "Medusa: {digit} en {unitTypePlural} {units}",
+		"technique.xy-wing":         "XY-Wing: pivote en {pivot} con alas: eliminar el {digit}.",
+		"technique.xyz-wing":        "XYZ-Wing: pivote {pivot} con alas {wing1} y {wing2}: eliminar el {digit}.",
+		"technique.wxyz-wing":       "WXYZ-Wing: las celdas {cells} contienen {digits}: eliminar el {digit} no restringido.",
+		"technique.simple-coloring": "Coloreado simple: la celda {cell} ve ambos colores para el {digit}",
+
+		"technique.finned-x-wing.rows":             "X-Wing con aleta: {digit} en las filas {rows} con aleta en {fin}",
+		"technique.finned-x-wing.cols":             "X-Wing con aleta: {digit} en las columnas {cols} con aleta en {fin}",
+		"technique.sashimi-x-wing.rows":            "X-Wing sashimi: {digit} en las filas {rows} con aleta en {fin}",
+		"technique.sashimi-x-wing.cols":            "X-Wing sashimi: {digit} en las columnas {cols} con aleta en {fin}",
+		"technique.finned-swordfish.rows":          "Pez espada con aleta: {digit} en las filas {rows} con aleta en {fin}",
+		"technique.finned-swordfish.cols":          "Pez espada con aleta: {digit} en las columnas {cols} con aleta en {fin}",
+		"technique.sashimi-swordfish.rows":         "Pez espada sashimi: {digit} en las filas {rows} con aleta en {fin}",
+		"technique.sashimi-swordfish.cols":         "Pez espada sashimi: {digit} en las columnas {cols} con aleta en {fin}",
+		"technique.skyscraper":                     "Rascacielos: {digit} con base {base1} y {base2}",
+		"technique.2-string-kite":                  "Cometa de dos cuerdas: {digit} enlazado a través de la caja {box}",
+		"technique.turbot-fish":                    "Pez turbot: {digit} encadenado de {end1} a {end2}",
+		"technique.empty-rectangle.row":            "Rectángulo vacío: {digit} en la caja {box} con par conjugado en la fila {row}: eliminar de {cell}.",
+		"technique.empty-rectangle.col":            "Rectángulo vacío: {digit} en la caja {box} con par conjugado en la columna {col}: eliminar de {cell}.",
+		"technique.unique-rectangle":               "Rectángulo único tipo 1: {d1}/{d2} formaría un patrón mortal: eliminar de {cell}.",
+		"technique.unique-rectangle-type-2":        "Rectángulo único tipo 2: {d1}/{d2} con {digit} extra: eliminar el {digit} de las celdas que ven {roof1} y {roof2}.",
+		"technique.unique-rectangle-type-3.pair":   "Rectángulo único tipo 3: {d1}/{d2}: la pseudocelda con {digits} forma un par desnudo con {cell} en {unitType}.",
+		"technique.unique-rectangle-type-3.triple": "Rectángulo único tipo 3: {d1}/{d2}: la pseudocelda forma un trío desnudo con {cell1} y {cell2} en {unitType}.",
+		"technique.unique-rectangle-type-4":        "Rectángulo único tipo 4: {d1}/{d2}: el {keep} queda confinado al rectángulo en {unitType} {unitIndex}: eliminar el {remove}.",
+		"technique.unique-rectangle-type-5":        "Rectángulo único tipo 5: {d1}/{d2} con {digit} extra en {cells}: una debe contener el {digit}: eliminarlo de las celdas que las ven todas.",
+		"technique.unique-rectangle-type-6":        "Rectángulo único tipo 6: {d1}/{d2}: el {digit} forma un X-Wing en el rectángulo ({unitType}): eliminar el {digit} de las esquinas del techo.",
+		"technique.hidden-rectangle":               "Rectángulo oculto: {d1}/{d2}: el {strong} forma pares conjugados a través de {cell}: eliminar el {digit} allí.",
+		"technique.bug":                            "BUG+1: todas las demás celdas son bivaluadas; {cell} debe ser {digit} para evitar soluciones múltiples",
+
+		"technique.x-chain":                          "Cadena X sobre el {digit} de {head} a {tail}: un extremo debe ser {digit}, eliminarlo de las celdas que ven ambos.",
+		"technique.xy-chain":                         "Cadena XY de {length} celdas de {head} a {tail}: ambos extremos fuerzan un {digit}, eliminarlo de las celdas que ven ambos.",
+		"technique.nice-loop":                        "Nice Loop de {head} a {tail}: {narration}; un extremo siempre es verdadero, así que se eliminan los candidatos que ven ambos extremos.",
+		"technique.nice-loop.loop":                   "Nice Loop que se cierra sobre {head}: {narration}; cada enlace débil del bucle elimina los candidatos que cubre.",
+		"technique.w-wing":                           "W-Wing: celdas {digits} conectadas por un enlace fuerte sobre el {linkDigit}",
+		"technique.aic":                              "AIC: o bien {head}={headDigit} o bien {tail}={tailDigit}; se eliminan los candidatos que ven ambos extremos.",
+		"technique.aic.loop":                         "AIC: bucle continuo a través de {cell}: cada enlace débil elimina los candidatos que cubre.",
+		"technique.grouped-x-cycles.assign":          "Ciclo X: dos enlaces fuertes sobre el {digit} se encuentran en {cell}, así que debe ser {digit}",
+		"technique.grouped-x-cycles.weak":            "Ciclo X: dos enlaces débiles sobre el {digit} se encuentran en {node}, eliminándolo allí",
+		"technique.grouped-x-cycles.loop":            "Bucle perfecto de ciclo X sobre el {digit}: eliminarlo de las celdas que ven ambos extremos de un enlace débil",
+		"technique.medusa-3d.twice-in-cell":          "Medusa 3D: el color {color} tiene dos candidatos en {cell}: eliminar todo el color {color}.",
+		"technique.medusa-3d.twice-in-unit":          "Medusa 3D: el color {color} tiene el {digit} dos veces en la misma unidad ({cell1}, {cell2}): eliminar todo el color {color}.",
+		"technique.medusa-3d.sees-both":              "Medusa 3D: {cell} ve el {digit} en ambos colores: eliminar el {digit}.",
+		"technique.medusa-3d.both-in-cell":           "Medusa 3D: {cell} tiene candidatos en ambos colores: eliminar el {digit} sin color.",
+		"technique.medusa-3d.sees-color":             "Medusa 3D: {cell} tiene el color {cellColor} y ve el {digit} en el color {otherColor}: eliminar el {digit}.",
+		"technique.medusa-3d.all-in-color":           "Medusa 3D: {cell} tiene todos sus candidatos en el color {color}: eliminar todo el color {color}.",
+		"technique.als-xz":                           "ALS-XZ: ALS A {alsA} y ALS B {alsB} con común restringido {x}: eliminar el {z}.",
+		"technique.als-xy-wing":                      "ALS-XY-Wing: A={alsA}, B={alsB}, C={alsC}; CR(A-B)={x}, CR(A-C)={y}; eliminar el {z}",
+		"technique.als-xy-chain":                     "Cadena ALS-XY: {length} ALS enlazados por CR {rcs}; eliminar el {z}",
+		"technique.sue-de-coq":                       "Sue de Coq: intersección de la caja {box} y {unitType} {unitIndex} con candidatos {digits}; el ALS de línea {lineCells} contiene {lineDigits}, el ALS de caja {boxCells} contiene {boxDigits}",
+		"technique.death-blossom":                    "Flor de la muerte: tallo {stem} {stemDigits} con {petals} pétalos; eliminar el {z}",
+		"technique.digit-forcing-chain.assign":       "Cadena forzada de dígito: el {digit} en {unitType} {unitIndex} solo cabe en {count} posiciones; probar cada una lleva a {cell}={value}",
+		"technique.digit-forcing-chain.eliminate":    "Cadena forzada de dígito: el {digit} en {unitType} {unitIndex} solo cabe en {count} posiciones; probar cada una elimina el {elim} de {cell}",
+		"technique.forcing-chain.contradiction":      "Cadena forzada de celda: si {cell}={digit}, sigue una contradicción. Por tanto {cell}={value}",
+		"technique.forcing-chain.assign":             "Cadena forzada de celda: sea {cell} {candidates}, {target} debe ser {digit}",
+		"technique.forcing-chain.eliminate":          "Cadena forzada de celda: sea {cell} {candidates}, {target} no puede ser {digit}",
+		"technique.forcing-chain.unit-contradiction": "Cadena forzada de unidad: en {unitType} {unitIndex}, el {digit} en las demás posiciones lleva a contradicción. {cell}={digit}",
+		"technique.forcing-chain.unit-assign":        "Cadena forzada de unidad: vaya donde vaya el {digit} en {unitType} {unitIndex}, {cell}={value}",
+		"technique.forcing-chain.unit-eliminate":     "Cadena forzada de unidad: vaya donde vaya el {digit} en {unitType} {unitIndex}: eliminar el {elim} de {cell}.",
+
+		"solver.constraint-violation": "Violación de restricción: el {digit} aparece dos veces en {unitType} {unitIndex}, en {cell1} y {cell2}",
+		"solver.no-candidates":        "No quedan candidatos para {cell}: contradicción detectada",
+		"solver.invalid-candidate":    "Candidato inválido: {cell} tiene el candidato {digit}, pero el {digit} ya existe en su fila, columna o caja",
+		"solver.add-candidate":        "Añadido el {digit} como candidato en {cell}",
+		"solver.batch-candidates":     "Se anotaron {count} candidatos iniciales",
+		"solver.forced-single":        "{cell} debe ser {digit}: es la única celda de {unitType} {unitIndex} que puede contener el {digit}",
+
+		"solver.conflict.pair":        "{cell1} y {cell2} tienen ambas el {digit} en la misma {unitType}.",
+		"solver.conflict.remove":      "{cell1} y {cell2} tienen ambas el {digit} en la misma {unitType}. Quitando el {digit} de {cell}.",
+		"solver.stuck.no-candidates":  "¡Solucionador atascado! {cell} no tiene candidatos válidos. El {digit} en {source} causaba el problema.",
+		"solver.stuck.remove":         "¡Solucionador atascado! Quitar el {digit} de {cell} permite avanzar.",
+		"solver.stuck.restore":        "¡Solucionador atascado! El candidato {digit} se quitó de {cell} por error. Restaurándolo.",
+		"solver.remove-incorrect":     "Quitando el {digit} incorrecto de {cell}.",
+		"solver.contradiction.found":  "¡Encontrado! {cell} no tiene candidatos válidos. El {digit} en {source} causaba el problema.",
+		"solver.contradiction.remove": "¡Contradicción detectada! Quitar el {digit} de {cell} permite avanzar.",
+		"solver.removed-incorrect":    "Quitado el {digit} incorrecto de {cell} (debería ser {value})",
+		"solver.full-board.wrong":     "El tablero está completo, pero el {digit} en {cell} no coincide con la solución. Quitándolo.",
+		"solver.full-board.solved":    "¡El puzle está resuelto!",
+	},
+}
+
+// RenderExplanation renders a message key in the given language. Unknown
+// languages and untranslated keys fall back to English; a key missing from
+// the English table, or a placeholder with no parameter, is an error.
+func RenderExplanation(key string, params map[string]interface{}, lang string) (string, error) {
+	tmpl, ok := explanationTemplates[lang][key]
+	if !ok {
+		tmpl, ok = explanationTemplates[DefaultLang][key]
+		if !ok {
+			return "", fmt.Errorf("unknown explanation key %q", key)
+		}
+	}
+
+	var sb strings.Builder
+	for len(tmpl) > 0 {
+		open := strings.IndexByte(tmpl, '{')
+		if open < 0 {
+			sb.WriteString(tmpl)
+			break
+		}
+		sb.WriteString(tmpl[:open])
+		tmpl = tmpl[open+1:]
+		closing := strings.IndexByte(tmpl, '}')
+		if closing < 0 {
+			return "", fmt.Errorf("explanation key %q: unterminated placeholder", key)
+		}
+		name := tmpl[:closing]
+		tmpl = tmpl[closing+1:]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("explanation key %q: missing parameter %q", key, name)
+		}
+		sb.WriteString(formatExplanationValue(value, lang))
+	}
+	return sb.String(), nil
+}
+
+// formatExplanationValue renders one parameter. Lists join with ", "; unit
+// words translate through the vocabulary; chain narrations render with the
+// per-language connectives; everything else formats as %v.
+func formatExplanationValue(v interface{}, lang string) string {
+	switch value := v.(type) {
+	case string:
+		if word, ok := unitVocab[lang][value]; ok {
+			return word
+		}
+		return value
+	case ChainNarration:
+		vocab, ok := narrationVocab[lang]
+		if !ok {
+			vocab = narrationVocab[DefaultLang]
+		}
+		parts := make([]string, len(value))
+		for i, step := range value {
+			connective := vocab.ifWord
+			if i > 0 {
+				connective = vocab.soWord
+				if step.Strong {
+					connective = vocab.thenWord
+				}
+			}
+			verb := vocab.isNotWord
+			if step.True {
+				verb = vocab.isWord
+			}
+			parts[i] = fmt.Sprintf("%s %s %s %d", connective, step.Cell, verb, step.Digit)
+		}
+		return strings.Join(parts, ", ")
+	case []int:
+		parts := make([]string, len(value))
+		for i, n := range value {
+			parts[i] = fmt.Sprintf("%d", n)
+		}
+		return strings.Join(parts, ", ")
+	case []string:
+		return strings.Join(value, ", ")
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// SetExplanation fills the structured explanation fields and renders the
+// backward-compatible English sentence from the same template
+func (m *Move) SetExplanation(key string, params map[string]interface{}) {
+	m.ExplanationKey = key
+	m.ExplanationParams = params
+	if text, err := RenderExplanation(key, params, DefaultLang); err == nil {
+		m.Explanation = text
+	} else {
+		m.Explanation = key
+	}
+}
+
+// LocalizeExplanation re-renders Explanation in the requested language. Moves
+// without structured explanation data, and render failures, keep the English
+// sentence.
+func (m *Move) LocalizeExplanation(lang string) {
+	if m == nil || m.ExplanationKey == "" || lang == "" || lang == DefaultLang {
+		return
+	}
+	if text, err := RenderExplanation(m.ExplanationKey, m.ExplanationParams, lang); err == nil {
+		m.Explanation = text
+	}
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderExplanationEnglish(t *testing.T) {
+	got, err := RenderExplanation("technique.naked-single", map[string]interface{}{
+		"cell":  "R3C7",
+		"digit": 5,
+	}, "en")
+	if err != nil {
+		t.Fatalf("RenderExplanation: %v", err)
+	}
+	want := "Cell R3C7 has only one candidate: 5"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderExplanationTranslatesUnitWords(t *testing.T) {
+	got, err := RenderExplanation("technique.hidden-single", map[string]interface{}{
+		"unitType":  "row",
+		"unitIndex": 4,
+		"digit":     9,
+		"cell":      "R4C2",
+	}, "es")
+	if err != nil {
+		t.Fatalf("RenderExplanation: %v", err)
+	}
+	if !strings.Contains(got, "fila 4") {
+		t.Errorf("expected unit word translated to \"fila\", got %q", got)
+	}
+}
+
+func TestRenderExplanationJoinsLists(t *testing.T) {
+	got, err := RenderExplanation("technique.x-wing.rows", map[string]interface{}{
+		"digit": 7,
+		"rows":  []int{2, 6},
+		"cols":  []int{3, 8},
+	}, "en")
+	if err != nil {
+		t.Fatalf("RenderExplanation: %v", err)
+	}
+	if !strings.Contains(got, "rows 2, 6") {
+		t.Errorf("expected []int joined with commas, got %q", got)
+	}
+}
+
+func TestRenderExplanationUnknownLangFallsBack(t *testing.T) {
+	params := map[string]interface{}{"cell": "R1C1", "digit": 3}
+	want, err := RenderExplanation("technique.naked-single", params, "en")
+	if err != nil {
+		t.Fatalf("RenderExplanation(en): %v", err)
+	}
+	got, err := RenderExplanation("technique.naked-single", params, "zz")
+	if err != nil {
+		t.Fatalf("RenderExplanation(zz): %v", err)
+	}
+	if got != want {
+		t.Errorf("unknown language should render English: got %q, want %q", got, want)
+	}
+}
+
+func TestRenderExplanationErrors(t *testing.T) {
+	if _, err := RenderExplanation("technique.no-such-key", nil, "en"); err == nil {
+		t.Error("expected error for unknown key")
+	}
+	if _, err := RenderExplanation("technique.naked-single", map[string]interface{}{"cell": "R1C1"}, "en"); err == nil {
+		t.Error("expected error for missing parameter")
+	}
+}
+
+// templatePlaceholders extracts the placeholder names used by a template
+func templatePlaceholders(t *testing.T, key, tmpl string) map[string]bool {
+	t.Helper()
+	names := make(map[string]bool)
+	for len(tmpl) > 0 {
+		open := strings.IndexByte(tmpl, '{')
+		if open < 0 {
+			break
+		}
+		tmpl = tmpl[open+1:]
+		closing := strings.IndexByte(tmpl, '}')
+		if closing < 0 {
+			t.Fatalf("key %q: unterminated placeholder", key)
+		}
+		names[tmpl[:closing]] = true
+		tmpl = tmpl[closing+1:]
+	}
+	return names
+}
+
+// TestExplanationTemplateParity asserts every bundled language covers exactly
+// the English key set, with matching placeholders per key, so a move that
+// renders in English renders in every language.
+func TestExplanationTemplateParity(t *testing.T) {
+	english := explanationTemplates[DefaultLang]
+	for lang, table := range explanationTemplates {
+		if lang == DefaultLang {
+			continue
+		}
+		for key, tmpl := range table {
+			enTmpl, ok := english[key]
+			if !ok {
+				t.Errorf("%s: key %q has no English counterpart", lang, key)
+				continue
+			}
+			want := templatePlaceholders(t, key, enTmpl)
+			got := templatePlaceholders(t, key, tmpl)
+			for name := range want {
+				if !got[name] {
+					t.Errorf("%s: key %q is missing placeholder {%s}", lang, key, name)
+				}
+			}
+			for name := range got {
+				if !want[name] {
+					t.Errorf("%s: key %q has extra placeholder {%s}", lang, key, name)
+				}
+			}
+		}
+		for key := range english {
+			if _, ok := table[key]; !ok {
+				t.Errorf("%s: key %q is untranslated", lang, key)
+			}
+		}
+	}
+}
+
+func TestSetExplanationRendersEnglish(t *testing.T) {
+	var m Move
+	m.SetExplanation("technique.naked-single", map[string]interface{}{"cell": "R2C2", "digit": 8})
+	if m.ExplanationKey != "technique.naked-single" {
+		t.Errorf("ExplanationKey = %q", m.ExplanationKey)
+	}
+	if m.Explanation != "Cell R2C2 has only one candidate: 8" {
+		t.Errorf("Explanation = %q", m.Explanation)
+	}
+}
+
+func TestLocalizeExplanation(t *testing.T) {
+	var m Move
+	m.SetExplanation("technique.naked-single", map[string]interface{}{"cell": "R2C2", "digit": 8})
+	english := m.Explanation
+
+	m.LocalizeExplanation("es")
+	if m.Explanation == english {
+		t.Errorf("expected Spanish rendering, still %q", m.Explanation)
+	}
+
+	var unchanged Move
+	unchanged.SetExplanation("technique.naked-single", map[string]interface{}{"cell": "R2C2", "digit": 8})
+	unchanged.LocalizeExplanation("")
+	if unchanged.Explanation != english {
+		t.Errorf("empty language must keep English, got %q", unchanged.Explanation)
+	}
+
+	// Moves without structured data (older producers) are left untouched
+	plain := &Move{Explanation: "hand-written"}
+	plain.LocalizeExplanation("es")
+	if plain.Explanation != "hand-written" {
+		t.Errorf("move without key changed to %q", plain.Explanation)
+	}
+}
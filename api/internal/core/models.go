@@ -12,6 +12,19 @@ const (
 	DifficultyImpossible Difficulty = "impossible"
 )
 
+// ConstraintSet describes optional variant constraints applied on top of
+// classic Sudoku rules. The zero value is a classic puzzle.
+type ConstraintSet struct {
+	AntiKnight      bool `json:"anti_knight,omitempty"`      // no duplicate a knight's move away
+	NonConsecutive  bool `json:"non_consecutive,omitempty"`  // orthogonal neighbors can't differ by 1
+	DiagonalsUnique bool `json:"diagonals_unique,omitempty"` // main diagonals contain no duplicates
+}
+
+// IsClassic reports whether no variant constraints are active
+func (cs ConstraintSet) IsClassic() bool {
+	return !cs.AntiKnight && !cs.NonConsecutive && !cs.DiagonalsUnique
+}
+
 type User struct {
 	ID          string    `json:"id"`
 	DeviceID    string    `json:"device_id"`
@@ -56,15 +69,45 @@ type Result struct {
 
 // Move represents a single step in the human solver
 type Move struct {
-	StepIndex    int          `json:"step_index"`
-	Technique    string       `json:"technique"`
-	Action       string       `json:"action"` // "assign" or "eliminate"
-	Digit        int          `json:"digit"`
-	Targets      []CellRef    `json:"targets"`
-	Eliminations []Candidate  `json:"eliminations,omitempty"`
-	Explanation  string       `json:"explanation"`
-	Refs         TechniqueRef `json:"refs"`
-	Highlights   Highlights   `json:"highlights"`
+	StepIndex int       `json:"step_index"`
+	Technique string    `json:"technique"`
+	Action    string    `json:"action"` // "assign" or "eliminate"
+	Digit     int       `json:"digit"`
+	Targets   []CellRef `json:"targets"`
+	// TargetCandidates records the candidate set of each target cell as the
+	// detector saw it, aligned index-for-index with Targets. Clients render
+	// these instead of their local pencil marks, which may be stale when the
+	// move was computed after fill-candidate moves they have not applied yet
+	TargetCandidates [][]int     `json:"target_candidates,omitempty"`
+	Eliminations     []Candidate `json:"eliminations,omitempty"`
+	// CandidateDiff carries the pencil marks added by a synthetic batched
+	// fill-candidate move (solver CandidateModeBatched); ordinary moves
+	// leave it nil.
+	CandidateDiff *CandidateDiff `json:"candidate_diff,omitempty"`
+	Explanation   string         `json:"explanation"`
+	// ExplanationKey and ExplanationParams carry the explanation in
+	// structured form so clients can localize it; Explanation stays the
+	// English rendering for backward compatibility. See i18n.go.
+	ExplanationKey    string                 `json:"explanation_key,omitempty"`
+	ExplanationParams map[string]interface{} `json:"explanation_params,omitempty"`
+	Refs              TechniqueRef           `json:"refs"`
+	// Tier and Score grade the move with the registry's rating for its
+	// technique, so clients can show per-step difficulty without a second
+	// registry lookup. Bookkeeping moves (fill-candidate, contradiction)
+	// carry no grade and leave both empty.
+	Tier       string     `json:"tier,omitempty"`
+	Score      int        `json:"score,omitempty"`
+	Highlights Highlights `json:"highlights"`
+	// ChainLinks lists consecutive cell pairs for chain techniques so the UI
+	// can draw the links: [from1, to1, from2, to2, ...]
+	ChainLinks []CellRef `json:"chain_links,omitempty"`
+	// Unit identifies the row, column, or box the pattern was found in, for
+	// techniques that operate within a single unit, so the UI can highlight
+	// the relevant house instead of guessing from the targets
+	Unit *UnitRef `json:"unit,omitempty"`
+	// Indirect marks a cell-targeted hint that doesn't touch the requested
+	// cell itself, only one of its peers
+	Indirect bool `json:"indirect,omitempty"`
 }
 
 type CellRef struct {
@@ -72,6 +115,12 @@ type CellRef struct {
 	Col int `json:"col"`
 }
 
+// UnitRef names a single row, column, or box on the board
+type UnitRef struct {
+	Type  string `json:"type"`  // "row", "column", or "box"
+	Index int    `json:"index"` // 0-based unit index
+}
+
 type Candidate struct {
 	Row   int `json:"row"`
 	Col   int `json:"col"`
@@ -84,7 +133,38 @@ type TechniqueRef struct {
 	URL   string `json:"url"`
 }
 
+// CandidateRef pins one digit in one cell for candidate-level highlighting.
+// It shares Candidate's shape; the alias keeps the highlight roles below
+// self-describing without duplicating the type.
+type CandidateRef = Candidate
+
+// Highlights tells the UI what to light up for a move.
+//
+// Primary/Secondary/Tertiary are the legacy coarse roles: whole cells with no
+// stated meaning, which left frontends guessing (and highlighting every
+// pencil mark of a target cell). They stay populated for older clients.
+//
+// The typed roles are the rendering contract new clients should follow:
+//
+//   - Target: the cells the move writes to — the assigned cell, or the
+//     cells losing a candidate
+//   - Defining: the cells that form the pattern (the subset cells, the
+//     fish's corner cells, the hidden single's unit)
+//   - Justifying: the specific candidates that carry the logic. Render only
+//     these marks, never every candidate of their cells
+//   - Eliminated: the candidates the move removes, mirrored from the move's
+//     elimination list
+//
+// A detector that populates the typed roles does so precisely; empty typed
+// roles mean the technique has not been migrated and clients should fall
+// back to Primary/Secondary.
 type Highlights struct {
 	Primary   []CellRef `json:"primary"`
 	Secondary []CellRef `json:"secondary,omitempty"`
+	Tertiary  []CellRef `json:"tertiary,omitempty"`
+
+	Target     []CellRef      `json:"target,omitempty"`
+	Defining   []CellRef      `json:"defining,omitempty"`
+	Justifying []CandidateRef `json:"justifying,omitempty"`
+	Eliminated []CandidateRef `json:"eliminated,omitempty"`
 }
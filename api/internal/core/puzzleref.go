@@ -0,0 +1,168 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PuzzleRefKind names the seed families the API understands
+type PuzzleRefKind string
+
+const (
+	SeedDaily  PuzzleRefKind = "daily"  // "D2026-03-21", also "daily-..." and "daily:..."
+	SeedRandom PuzzleRefKind = "random" // "R1766071853863" (client timestamp)
+	SeedCustom PuzzleRefKind = "custom" // "P1766186192266" (client timestamp)
+	SeedStatic PuzzleRefKind = "static" // "static-162" (bank index)
+	SeedRemix  PuzzleRefKind = "remix"  // "remix:162:9041" (bank index + transform seed)
+	SeedOpaque PuzzleRefKind = "opaque" // anything else; resolved by hashing
+)
+
+// maxSeedLength bounds free-form seeds; anything longer is rejected rather
+// than hashed, since real seed families are all short
+const maxSeedLength = 64
+
+// seedDateFormat mirrors constants.DailyDateFormat; core stays import-free
+const seedDateFormat = "2006-01-02"
+
+// puzzleIDDelimiter separates seed and difficulty in a puzzle ID. Seeds may
+// themselves contain dashes, so parsing splits on the LAST delimiter.
+const puzzleIDDelimiter = "-"
+
+// PuzzleRef is a parsed seed. Every subsystem that used to re-parse seed
+// strings ad hoc (handlers, session tokens, the WASM bridge) goes through
+// this type, so puzzle IDs are constructed and parsed the same way
+// everywhere.
+type PuzzleRef struct {
+	Kind      PuzzleRefKind
+	Date      time.Time // set for daily seeds
+	Numeric   int64     // R/P timestamp or static/remix bank index
+	RemixSeed int64     // transform seed; set for remix seeds
+	Raw       string    // the seed as it arrived
+}
+
+// DailyPuzzleRef returns the reference for a date's daily puzzle
+func DailyPuzzleRef(date time.Time) PuzzleRef {
+	date = date.UTC().Truncate(24 * time.Hour)
+	return PuzzleRef{Kind: SeedDaily, Date: date, Raw: "D" + date.Format(seedDateFormat)}
+}
+
+// StaticPuzzleRef returns the reference for a bank index
+func StaticPuzzleRef(index int) PuzzleRef {
+	return PuzzleRef{Kind: SeedStatic, Numeric: int64(index), Raw: "static-" + strconv.Itoa(index)}
+}
+
+// RemixPuzzleRef returns the reference for a remixed bank puzzle: the puzzle
+// at the index, transformed by the seeded symmetry (see dp.RemixPuzzle)
+func RemixPuzzleRef(index int, seed int64) PuzzleRef {
+	return PuzzleRef{
+		Kind:      SeedRemix,
+		Numeric:   int64(index),
+		RemixSeed: seed,
+		Raw:       "remix:" + strconv.Itoa(index) + ":" + strconv.FormatInt(seed, 10),
+	}
+}
+
+// ParsePuzzleRef classifies a seed string into one of the known families.
+// Unrecognized but well-formed seeds parse as SeedOpaque; empty, oversized,
+// or non-printable-ASCII seeds are rejected.
+func ParsePuzzleRef(seed string) (PuzzleRef, error) {
+	if seed == "" {
+		return PuzzleRef{}, fmt.Errorf("seed must not be empty")
+	}
+	if len(seed) > maxSeedLength {
+		return PuzzleRef{}, fmt.Errorf("seed exceeds %d characters", maxSeedLength)
+	}
+	for _, c := range seed {
+		if c < '!' || c > '~' {
+			return PuzzleRef{}, fmt.Errorf("seed contains invalid character %q", c)
+		}
+	}
+
+	for _, prefix := range []string{"D", "daily-", "daily:"} {
+		if rest, ok := strings.CutPrefix(seed, prefix); ok {
+			if date, err := time.Parse(seedDateFormat, rest); err == nil {
+				return PuzzleRef{Kind: SeedDaily, Date: date, Raw: seed}, nil
+			}
+		}
+	}
+
+	for prefix, kind := range map[string]PuzzleRefKind{"R": SeedRandom, "P": SeedCustom} {
+		if rest, ok := strings.CutPrefix(seed, prefix); ok && allDigits(rest) {
+			n, err := strconv.ParseInt(rest, 10, 64)
+			if err != nil {
+				return PuzzleRef{}, fmt.Errorf("seed %q: numeric part out of range", seed)
+			}
+			return PuzzleRef{Kind: kind, Numeric: n, Raw: seed}, nil
+		}
+	}
+
+	// The remix prefix is reserved: a malformed remix seed is rejected
+	// rather than hashed as opaque, which would silently serve the
+	// untransformed puzzle at an unrelated index
+	if rest, ok := strings.CutPrefix(seed, "remix:"); ok {
+		idxStr, seedStr, found := strings.Cut(rest, ":")
+		if !found || !allDigits(idxStr) || !allDigits(seedStr) {
+			return PuzzleRef{}, fmt.Errorf("seed %q is not remix:<bank index>:<transform seed>", seed)
+		}
+		idx, idxErr := strconv.ParseInt(idxStr, 10, 64)
+		n, seedErr := strconv.ParseInt(seedStr, 10, 64)
+		if idxErr != nil || seedErr != nil {
+			return PuzzleRef{}, fmt.Errorf("seed %q: numeric part out of range", seed)
+		}
+		return PuzzleRef{Kind: SeedRemix, Numeric: idx, RemixSeed: n, Raw: seed}, nil
+	}
+
+	if rest, ok := strings.CutPrefix(seed, "static-"); ok && allDigits(rest) {
+		n, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil {
+			return PuzzleRef{}, fmt.Errorf("seed %q: bank index out of range", seed)
+		}
+		return PuzzleRef{Kind: SeedStatic, Numeric: n, Raw: seed}, nil
+	}
+
+	return PuzzleRef{Kind: SeedOpaque, Raw: seed}, nil
+}
+
+// String returns the canonical seed for the reference. Daily seeds normalize
+// to the "D<date>" form the daily endpoint announces; all other kinds keep
+// their raw spelling, which hashing-based resolution depends on.
+func (r PuzzleRef) String() string {
+	if r.Kind == SeedDaily {
+		return "D" + r.Date.Format(seedDateFormat)
+	}
+	return r.Raw
+}
+
+// PuzzleID builds the deterministic puzzle ID for a seed at a difficulty
+func (r PuzzleRef) PuzzleID(difficulty string) string {
+	return r.String() + puzzleIDDelimiter + difficulty
+}
+
+// ParsePuzzleID splits a puzzle ID back into its seed reference and
+// difficulty. Difficulty names never contain the delimiter, so splitting on
+// the last occurrence is lossless even for seeds with dashes in them.
+func ParsePuzzleID(id string) (PuzzleRef, string, error) {
+	pos := strings.LastIndex(id, puzzleIDDelimiter)
+	if pos <= 0 || pos == len(id)-1 {
+		return PuzzleRef{}, "", fmt.Errorf("puzzle ID %q is not <seed>%s<difficulty>", id, puzzleIDDelimiter)
+	}
+	ref, err := ParsePuzzleRef(id[:pos])
+	if err != nil {
+		return PuzzleRef{}, "", err
+	}
+	return ref, id[pos+1:], nil
+}
+
+func allDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePuzzleRefFamilies(t *testing.T) {
+	tests := []struct {
+		seed      string
+		kind      PuzzleRefKind
+		numeric   int64
+		date      string
+		canonical string
+	}{
+		{"D2026-03-21", SeedDaily, 0, "2026-03-21", "D2026-03-21"},
+		{"daily-2026-03-21", SeedDaily, 0, "2026-03-21", "D2026-03-21"},
+		{"daily:2026-03-21", SeedDaily, 0, "2026-03-21", "D2026-03-21"},
+		{"R1766071853863", SeedRandom, 1766071853863, "", "R1766071853863"},
+		{"P1766186192266", SeedCustom, 1766186192266, "", "P1766186192266"},
+		{"static-162", SeedStatic, 162, "", "static-162"},
+		{"static-0", SeedStatic, 0, "", "static-0"},
+		{"remix:162:9041", SeedRemix, 162, "", "remix:162:9041"},
+		{"remix:0:0", SeedRemix, 0, "", "remix:0:0"},
+		// Near misses stay opaque and resolve by hashing, as before
+		{"test-seed", SeedOpaque, 0, "", "test-seed"},
+		{"v2:D2024-01-01", SeedOpaque, 0, "", "v2:D2024-01-01"},
+		{"D2026-13-45", SeedOpaque, 0, "", "D2026-13-45"},
+		{"Rabc", SeedOpaque, 0, "", "Rabc"},
+		{"static-", SeedOpaque, 0, "", "static-"},
+		{"static-12x", SeedOpaque, 0, "", "static-12x"},
+	}
+
+	for _, tc := range tests {
+		ref, err := ParsePuzzleRef(tc.seed)
+		if err != nil {
+			t.Errorf("ParsePuzzleRef(%q) failed: %v", tc.seed, err)
+			continue
+		}
+		if ref.Kind != tc.kind {
+			t.Errorf("ParsePuzzleRef(%q): kind = %q, want %q", tc.seed, ref.Kind, tc.kind)
+		}
+		if ref.Numeric != tc.numeric {
+			t.Errorf("ParsePuzzleRef(%q): numeric = %d, want %d", tc.seed, ref.Numeric, tc.numeric)
+		}
+		if tc.date != "" && ref.Date.Format(seedDateFormat) != tc.date {
+			t.Errorf("ParsePuzzleRef(%q): date = %v, want %s", tc.seed, ref.Date, tc.date)
+		}
+		if ref.String() != tc.canonical {
+			t.Errorf("ParsePuzzleRef(%q): canonical = %q, want %q", tc.seed, ref.String(), tc.canonical)
+		}
+		if ref.Raw != tc.seed {
+			t.Errorf("ParsePuzzleRef(%q): raw = %q, want the input back", tc.seed, ref.Raw)
+		}
+	}
+}
+
+func TestParsePuzzleRefMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		seed string
+	}{
+		{"empty", ""},
+		{"unicode", "日本語-seed"},
+		{"control character", "seed\nwith-newline"},
+		{"space", "two words"},
+		{"300 characters", strings.Repeat("a", 300)},
+		{"overflowing numeric", "R99999999999999999999999999"},
+		{"overflowing bank index", "static-99999999999999999999"},
+		{"remix missing transform seed", "remix:12"},
+		{"remix empty transform seed", "remix:12:"},
+		{"remix non-numeric", "remix:a:b"},
+		{"overflowing remix seed", "remix:1:99999999999999999999"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParsePuzzleRef(tc.seed); err == nil {
+				t.Errorf("ParsePuzzleRef(%q) succeeded, want error", tc.seed)
+			}
+		})
+	}
+}
+
+func TestPuzzleIDRoundTrip(t *testing.T) {
+	seeds := []string{"D2026-03-21", "R1766071853863", "P1766186192266", "static-162", "test-seed", "daily-2026-03-21"}
+	for _, seed := range seeds {
+		ref, err := ParsePuzzleRef(seed)
+		if err != nil {
+			t.Fatalf("ParsePuzzleRef(%q) failed: %v", seed, err)
+		}
+		id := ref.PuzzleID("medium")
+		back, difficulty, err := ParsePuzzleID(id)
+		if err != nil {
+			t.Fatalf("ParsePuzzleID(%q) failed: %v", id, err)
+		}
+		if difficulty != "medium" {
+			t.Errorf("ParsePuzzleID(%q): difficulty = %q, want medium", id, difficulty)
+		}
+		if back.Kind != ref.Kind || back.String() != ref.String() {
+			t.Errorf("ParsePuzzleID(%q): got %+v, want same ref as %+v", id, back, ref)
+		}
+	}
+
+	for _, bad := range []string{"", "medium", "-medium", "seed-"} {
+		if _, _, err := ParsePuzzleID(bad); err == nil {
+			t.Errorf("ParsePuzzleID(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestDailyPuzzleRefConstructor(t *testing.T) {
+	date := time.Date(2026, 3, 21, 15, 4, 5, 0, time.FixedZone("x", 3*3600))
+	ref := DailyPuzzleRef(date)
+	if ref.String() != "D2026-03-21" {
+		t.Errorf("DailyPuzzleRef: got %q, want D2026-03-21", ref.String())
+	}
+	parsed, err := ParsePuzzleRef(ref.String())
+	if err != nil || parsed.Kind != SeedDaily {
+		t.Errorf("DailyPuzzleRef output doesn't parse back as daily: %+v, %v", parsed, err)
+	}
+}
+
+func TestStaticPuzzleRefConstructor(t *testing.T) {
+	ref := StaticPuzzleRef(162)
+	if ref.String() != "static-162" {
+		t.Errorf("StaticPuzzleRef: got %q, want static-162", ref.String())
+	}
+	parsed, err := ParsePuzzleRef(ref.String())
+	if err != nil || parsed.Kind != SeedStatic || parsed.Numeric != 162 {
+		t.Errorf("StaticPuzzleRef output doesn't parse back: %+v, %v", parsed, err)
+	}
+}
+
+func TestRemixPuzzleRefConstructor(t *testing.T) {
+	ref := RemixPuzzleRef(162, 9041)
+	if ref.String() != "remix:162:9041" {
+		t.Errorf("RemixPuzzleRef: got %q, want remix:162:9041", ref.String())
+	}
+	parsed, err := ParsePuzzleRef(ref.String())
+	if err != nil || parsed.Kind != SeedRemix || parsed.Numeric != 162 || parsed.RemixSeed != 9041 {
+		t.Errorf("RemixPuzzleRef output doesn't parse back: %+v, %v", parsed, err)
+	}
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"sudoku-api/pkg/constants"
+)
+
+// State codec: a compact, copy-pasteable string capturing a full board state
+// (cells plus pencil marks and annotations) for bug reports and replay
+// tooling. The format is
+//
+//	base64url( version byte | zlib( flags byte | 81 cell bytes | sections? ) )
+//
+// where the flags byte is a bitmask selecting optional fixed-size sections,
+// appended in flag-bit order. stateFlagCandidates adds one little-endian
+// uint16 mask per cell with bit d meaning digit d+1 — the same layout as the
+// binary board codec in the human package. stateFlagAnnotations adds five
+// bytes per cell: color index, corner-mark mask, center-mark mask (masks
+// little-endian uint16). States encoded before a flag existed decode
+// unchanged; the version byte sits outside the compressed body so future
+// versions can change the compression itself.
+
+// stateCodecVersion is the current (and only) wire version
+const stateCodecVersion = 0x01
+
+// Flag bits for the compressed body's optional sections
+const (
+	stateFlagCellsOnly   = 0x00
+	stateFlagCandidates  = 0x01
+	stateFlagAnnotations = 0x02
+)
+
+// Decompressed body section sizes: flags byte plus cells always, then two
+// mask bytes per cell for candidates and five bytes per cell for annotations
+// when the matching flag is set
+const (
+	stateBodyCellsOnly       = 1 + constants.TotalCells
+	stateBodyCandidateBytes  = constants.TotalCells * 2
+	stateBodyAnnotationBytes = constants.TotalCells * 5
+	stateBodyMax             = stateBodyCellsOnly + stateBodyCandidateBytes + stateBodyAnnotationBytes
+)
+
+// EncodeState packs a board state into the versioned string format.
+// Candidates may be nil, meaning the state carries no pencil marks and the
+// decoder's caller should recompute them.
+func EncodeState(cells []int, candidates [][]int) (string, error) {
+	return EncodeStateWithAnnotations(cells, candidates, nil)
+}
+
+// EncodeStateWithAnnotations is EncodeState plus an optional per-cell
+// annotation section. A nil annotations slice encodes no section at all, so
+// states without annotations stay byte-identical to the older format.
+func EncodeStateWithAnnotations(cells []int, candidates [][]int, annotations []CellAnnotation) (string, error) {
+	if len(cells) != constants.TotalCells {
+		return "", fmt.Errorf("cells must have %d elements, got %d", constants.TotalCells, len(cells))
+	}
+	if candidates != nil && len(candidates) != constants.TotalCells {
+		return "", fmt.Errorf("candidates must have %d elements, got %d", constants.TotalCells, len(candidates))
+	}
+	if err := ValidateAnnotations(annotations); err != nil {
+		return "", err
+	}
+
+	body := make([]byte, 1, stateBodyMax)
+	body[0] = stateFlagCellsOnly
+	if candidates != nil {
+		body[0] |= stateFlagCandidates
+	}
+	if annotations != nil {
+		body[0] |= stateFlagAnnotations
+	}
+	for i, v := range cells {
+		if v < 0 || v > constants.GridSize {
+			return "", fmt.Errorf("cell %d holds invalid value %d", i, v)
+		}
+		body = append(body, byte(v))
+	}
+	if candidates != nil {
+		for i, digits := range candidates {
+			var mask uint16
+			for _, d := range digits {
+				if d < 1 || d > constants.GridSize {
+					return "", fmt.Errorf("cell %d holds invalid candidate %d", i, d)
+				}
+				mask |= 1 << (d - 1)
+			}
+			body = append(body, byte(mask), byte(mask>>8))
+		}
+	}
+	for _, a := range annotations {
+		corner := digitsToMask(a.CornerMarks)
+		center := digitsToMask(a.CenterMarks)
+		body = append(body, a.ColorIndex, byte(corner), byte(corner>>8), byte(center), byte(center>>8))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(stateCodecVersion)
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return "", fmt.Errorf("compressing state: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("compressing state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeState unpacks a string produced by EncodeState, dropping any
+// annotation section. The returned candidates are nil when the state was
+// encoded without them.
+func DecodeState(s string) ([]int, [][]int, error) {
+	cells, candidates, _, err := DecodeStateWithAnnotations(s)
+	return cells, candidates, err
+}
+
+// DecodeStateWithAnnotations unpacks a string produced by either encoder.
+// Candidates and annotations are nil when the state was encoded without the
+// matching section.
+func DecodeStateWithAnnotations(s string) ([]int, [][]int, []CellAnnotation, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("state is not valid base64url: %w", err)
+	}
+	if len(raw) < 1 {
+		return nil, nil, nil, fmt.Errorf("state is empty")
+	}
+	if raw[0] != stateCodecVersion {
+		return nil, nil, nil, fmt.Errorf("unsupported state version %d", raw[0])
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(raw[1:]))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("state body is not valid zlib: %w", err)
+	}
+	// Read one byte past the largest valid body so oversized payloads are
+	// rejected without decompressing unbounded input
+	body, err := io.ReadAll(io.LimitReader(zr, stateBodyMax+1))
+	zr.Close()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("decompressing state: %w", err)
+	}
+	if len(body) < 1 {
+		return nil, nil, nil, fmt.Errorf("state body is empty")
+	}
+
+	flags := body[0]
+	if flags&^(stateFlagCandidates|stateFlagAnnotations) != 0 {
+		return nil, nil, nil, fmt.Errorf("unknown state flags %#x", flags)
+	}
+	expected := stateBodyCellsOnly
+	if flags&stateFlagCandidates != 0 {
+		expected += stateBodyCandidateBytes
+	}
+	if flags&stateFlagAnnotations != 0 {
+		expected += stateBodyAnnotationBytes
+	}
+	if len(body) != expected {
+		return nil, nil, nil, fmt.Errorf("state body must have %d bytes, got %d", expected, len(body))
+	}
+
+	cells := make([]int, constants.TotalCells)
+	for i := 0; i < constants.TotalCells; i++ {
+		v := body[1+i]
+		if int(v) > constants.GridSize {
+			return nil, nil, nil, fmt.Errorf("cell %d holds invalid value %d", i, v)
+		}
+		cells[i] = int(v)
+	}
+	offset := stateBodyCellsOnly
+
+	var candidates [][]int
+	if flags&stateFlagCandidates != 0 {
+		candidates = make([][]int, constants.TotalCells)
+		for i := 0; i < constants.TotalCells; i++ {
+			mask := uint16(body[offset+2*i]) | uint16(body[offset+2*i+1])<<8
+			if mask>>constants.GridSize != 0 {
+				return nil, nil, nil, fmt.Errorf("cell %d holds invalid candidate mask %#x", i, mask)
+			}
+			candidates[i] = maskToDigits(mask)
+		}
+		offset += stateBodyCandidateBytes
+	}
+
+	var annotations []CellAnnotation
+	if flags&stateFlagAnnotations != 0 {
+		annotations = make([]CellAnnotation, constants.TotalCells)
+		for i := 0; i < constants.TotalCells; i++ {
+			entry := body[offset+5*i : offset+5*i+5]
+			color := entry[0]
+			corner := uint16(entry[1]) | uint16(entry[2])<<8
+			center := uint16(entry[3]) | uint16(entry[4])<<8
+			if color > MaxColorIndex {
+				return nil, nil, nil, fmt.Errorf("cell %d holds invalid color index %d", i, color)
+			}
+			if corner>>constants.GridSize != 0 || center>>constants.GridSize != 0 {
+				return nil, nil, nil, fmt.Errorf("cell %d holds invalid annotation mask", i)
+			}
+			annotations[i] = CellAnnotation{
+				ColorIndex:  color,
+				CornerMarks: maskToDigits(corner),
+				CenterMarks: maskToDigits(center),
+			}
+		}
+	}
+	return cells, candidates, annotations, nil
+}
+
+// digitsToMask packs a digit list into the codec's uint16 bitmask layout.
+// Out-of-range digits are the caller's problem; both encoders validate first.
+func digitsToMask(digits []int) uint16 {
+	var mask uint16
+	for _, d := range digits {
+		mask |= 1 << (d - 1)
+	}
+	return mask
+}
+
+// maskToDigits unpacks a uint16 bitmask into a sorted digit list
+func maskToDigits(mask uint16) []int {
+	digits := []int{}
+	for d := 1; d <= constants.GridSize; d++ {
+		if mask&(1<<(d-1)) != 0 {
+			digits = append(digits, d)
+		}
+	}
+	return digits
+}
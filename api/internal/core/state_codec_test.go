@@ -0,0 +1,222 @@
+package core
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// randomState builds a plausible board state: some cells filled, the rest
+// carrying random candidate sets
+func randomState(rng *rand.Rand) ([]int, [][]int) {
+	cells := make([]int, constants.TotalCells)
+	candidates := make([][]int, constants.TotalCells)
+	for i := range cells {
+		if rng.Intn(2) == 0 {
+			cells[i] = rng.Intn(constants.GridSize) + 1
+			candidates[i] = []int{}
+			continue
+		}
+		digits := []int{}
+		for d := 1; d <= constants.GridSize; d++ {
+			if rng.Intn(3) == 0 {
+				digits = append(digits, d)
+			}
+		}
+		candidates[i] = digits
+	}
+	return cells, candidates
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		cells, candidates := randomState(rng)
+		encoded, err := EncodeState(cells, candidates)
+		if err != nil {
+			t.Fatalf("trial %d: EncodeState: %v", trial, err)
+		}
+		gotCells, gotCandidates, err := DecodeState(encoded)
+		if err != nil {
+			t.Fatalf("trial %d: DecodeState: %v", trial, err)
+		}
+		if !reflect.DeepEqual(gotCells, cells) {
+			t.Fatalf("trial %d: cells did not round-trip", trial)
+		}
+		if !reflect.DeepEqual(gotCandidates, candidates) {
+			t.Fatalf("trial %d: candidates did not round-trip", trial)
+		}
+	}
+}
+
+func TestStateRoundTripWithoutCandidates(t *testing.T) {
+	cells, _ := randomState(rand.New(rand.NewSource(2)))
+	encoded, err := EncodeState(cells, nil)
+	if err != nil {
+		t.Fatalf("EncodeState: %v", err)
+	}
+	gotCells, gotCandidates, err := DecodeState(encoded)
+	if err != nil {
+		t.Fatalf("DecodeState: %v", err)
+	}
+	if !reflect.DeepEqual(gotCells, cells) {
+		t.Fatal("cells did not round-trip")
+	}
+	if gotCandidates != nil {
+		t.Fatalf("expected nil candidates, got %v", gotCandidates)
+	}
+}
+
+// randomAnnotations builds annotations with explicit empty mark slices, the
+// shape the decoder produces, so round-trips compare with DeepEqual
+func randomAnnotations(rng *rand.Rand) []CellAnnotation {
+	annotations := make([]CellAnnotation, constants.TotalCells)
+	for i := range annotations {
+		annotations[i].CornerMarks = []int{}
+		annotations[i].CenterMarks = []int{}
+		if rng.Intn(3) != 0 {
+			continue
+		}
+		annotations[i].ColorIndex = uint8(rng.Intn(MaxColorIndex + 1))
+		for d := 1; d <= constants.GridSize; d++ {
+			if rng.Intn(4) == 0 {
+				annotations[i].CornerMarks = append(annotations[i].CornerMarks, d)
+			}
+			if rng.Intn(4) == 0 {
+				annotations[i].CenterMarks = append(annotations[i].CenterMarks, d)
+			}
+		}
+	}
+	return annotations
+}
+
+func TestStateRoundTripWithAnnotations(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 20; trial++ {
+		cells, candidates := randomState(rng)
+		if trial%2 == 0 {
+			candidates = nil // annotations must round-trip with and without candidates
+		}
+		annotations := randomAnnotations(rng)
+
+		encoded, err := EncodeStateWithAnnotations(cells, candidates, annotations)
+		if err != nil {
+			t.Fatalf("trial %d: EncodeStateWithAnnotations: %v", trial, err)
+		}
+		gotCells, gotCandidates, gotAnnotations, err := DecodeStateWithAnnotations(encoded)
+		if err != nil {
+			t.Fatalf("trial %d: DecodeStateWithAnnotations: %v", trial, err)
+		}
+		if !reflect.DeepEqual(gotCells, cells) {
+			t.Fatalf("trial %d: cells did not round-trip", trial)
+		}
+		if !reflect.DeepEqual(gotCandidates, candidates) {
+			t.Fatalf("trial %d: candidates did not round-trip", trial)
+		}
+		if !reflect.DeepEqual(gotAnnotations, annotations) {
+			t.Fatalf("trial %d: annotations did not round-trip", trial)
+		}
+
+		// The plain decoder must still read annotated states, dropping the
+		// annotation section
+		plainCells, _, err := DecodeState(encoded)
+		if err != nil {
+			t.Fatalf("trial %d: DecodeState on annotated state: %v", trial, err)
+		}
+		if !reflect.DeepEqual(plainCells, cells) {
+			t.Fatalf("trial %d: cells diverged through the plain decoder", trial)
+		}
+	}
+}
+
+func TestEncodeStateRejectsInvalidInput(t *testing.T) {
+	valid := make([]int, constants.TotalCells)
+	if _, err := EncodeState(valid[:80], nil); err == nil {
+		t.Error("expected error for short cells")
+	}
+	bad := make([]int, constants.TotalCells)
+	bad[3] = 10
+	if _, err := EncodeState(bad, nil); err == nil {
+		t.Error("expected error for out-of-range cell value")
+	}
+	candidates := make([][]int, constants.TotalCells)
+	for i := range candidates {
+		candidates[i] = []int{}
+	}
+	candidates[7] = []int{0}
+	if _, err := EncodeState(valid, candidates); err == nil {
+		t.Error("expected error for out-of-range candidate")
+	}
+	if _, err := EncodeState(valid, candidates[:1]); err == nil {
+		t.Error("expected error for short candidates")
+	}
+	annotations := make([]CellAnnotation, constants.TotalCells)
+	annotations[2].ColorIndex = MaxColorIndex + 1
+	if _, err := EncodeStateWithAnnotations(valid, nil, annotations); err == nil {
+		t.Error("expected error for out-of-range color index")
+	}
+	annotations[2] = CellAnnotation{CenterMarks: []int{10}}
+	if _, err := EncodeStateWithAnnotations(valid, nil, annotations); err == nil {
+		t.Error("expected error for out-of-range center mark")
+	}
+}
+
+// encodeRawState builds a state string from an arbitrary body, bypassing
+// EncodeState's validation, for exercising the decoder's failure paths
+func encodeRawState(t *testing.T, version byte, body []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.WriteByte(version)
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		t.Fatalf("compressing raw body: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("compressing raw body: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecodeStateFailureModes(t *testing.T) {
+	cellsOnly := make([]byte, 1+constants.TotalCells)
+	badCell := make([]byte, 1+constants.TotalCells)
+	badCell[5] = 10
+	badMask := make([]byte, 1+constants.TotalCells*3)
+	badMask[0] = 0x01
+	badMask[1+constants.TotalCells+1] = 0x02 // mask 0x200: bit above digit 9
+	badColor := make([]byte, 1+constants.TotalCells*6)
+	badColor[0] = 0x02
+	badColor[1+constants.TotalCells] = 16 // color index above MaxColorIndex
+	badAnnMask := make([]byte, 1+constants.TotalCells*6)
+	badAnnMask[0] = 0x02
+	badAnnMask[1+constants.TotalCells+4] = 0x02 // center mask 0x200: bit above digit 9
+
+	cases := []struct {
+		name  string
+		state string
+	}{
+		{"not base64url", "not!base64"},
+		{"not zlib", base64.RawURLEncoding.EncodeToString([]byte{0x01, 0xde, 0xad})},
+		{"empty", ""},
+		{"wrong version", encodeRawState(t, 0x02, cellsOnly)},
+		{"unknown flags", encodeRawState(t, 0x01, append([]byte{0x07}, cellsOnly[1:]...))},
+		{"truncated body", encodeRawState(t, 0x01, cellsOnly[:40])},
+		{"oversized body", encodeRawState(t, 0x01, append(cellsOnly, 0))},
+		{"invalid cell value", encodeRawState(t, 0x01, badCell)},
+		{"invalid candidate mask", encodeRawState(t, 0x01, badMask)},
+		{"invalid color index", encodeRawState(t, 0x01, badColor)},
+		{"invalid annotation mask", encodeRawState(t, 0x01, badAnnMask)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := DecodeState(tc.state); err == nil {
+				t.Errorf("expected decode error for %s", tc.name)
+			}
+		})
+	}
+}
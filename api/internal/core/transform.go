@@ -0,0 +1,306 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Board Transformations
+// ============================================================================
+//
+// A BoardTransform is a symmetry of the board: a permutation of cells composed
+// with an optional relabeling of digits. Sessions can present the same
+// canonical puzzle rotated, mirrored, or relabeled — left-handed players
+// report the given clustering feels awkward in the canonical orientation —
+// while the server keeps solving in canonical coordinates. Handlers map
+// submitted boards back with Invert and map outgoing moves forward with
+// ApplyMove, so move sync survives the reorientation.
+//
+// Explanation text is not transformed: row/column labels inside rendered
+// explanations describe the canonical orientation. Clients that present a
+// transform render coordinates from the (transformed) structured fields
+// instead.
+
+// BoardTransform maps canonical board positions and digits to their
+// presented counterparts. The zero value is not valid; build one with the
+// named constructors or ParseTransform.
+type BoardTransform struct {
+	// cells[canonicalIdx] = presentedIdx
+	cells [constants.TotalCells]int
+	// digits[canonicalDigit] = presentedDigit; digits[0] = 0 so empty cells
+	// pass through
+	digits [constants.GridSize + 1]int
+}
+
+// IdentityTransform returns the transform that changes nothing
+func IdentityTransform() BoardTransform {
+	var t BoardTransform
+	for i := range t.cells {
+		t.cells[i] = i
+	}
+	for d := range t.digits {
+		t.digits[d] = d
+	}
+	return t
+}
+
+// IsIdentity reports whether applying the transform changes anything
+func (t BoardTransform) IsIdentity() bool {
+	return t == IdentityTransform()
+}
+
+// cellTransform builds a pure cell permutation from a coordinate mapping
+func cellTransform(to func(row, col int) (int, int)) BoardTransform {
+	t := IdentityTransform()
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			nr, nc := to(r, c)
+			t.cells[r*constants.GridSize+c] = nr*constants.GridSize + nc
+		}
+	}
+	return t
+}
+
+// Rotate90 rotates the board a quarter turn clockwise. Compose it with
+// itself for half and three-quarter turns.
+func Rotate90() BoardTransform {
+	return cellTransform(func(r, c int) (int, int) {
+		return c, constants.GridSize - 1 - r
+	})
+}
+
+// MirrorH mirrors the board left-to-right
+func MirrorH() BoardTransform {
+	return cellTransform(func(r, c int) (int, int) {
+		return r, constants.GridSize - 1 - c
+	})
+}
+
+// MirrorV mirrors the board top-to-bottom
+func MirrorV() BoardTransform {
+	return cellTransform(func(r, c int) (int, int) {
+		return constants.GridSize - 1 - r, c
+	})
+}
+
+// RelabelDigits builds a digit relabeling from a permutation of 1..GridSize:
+// canonical digit d is presented as perm[d-1]
+func RelabelDigits(perm []int) (BoardTransform, error) {
+	if len(perm) != constants.GridSize {
+		return BoardTransform{}, fmt.Errorf("relabel permutation must have %d digits, got %d", constants.GridSize, len(perm))
+	}
+	t := IdentityTransform()
+	seen := make(map[int]bool, constants.GridSize)
+	for i, d := range perm {
+		if d < 1 || d > constants.GridSize || seen[d] {
+			return BoardTransform{}, fmt.Errorf("relabel permutation must use each digit 1-%d exactly once", constants.GridSize)
+		}
+		seen[d] = true
+		t.digits[i+1] = d
+	}
+	return t, nil
+}
+
+// Compose returns the transform that applies t first, then next
+func (t BoardTransform) Compose(next BoardTransform) BoardTransform {
+	var out BoardTransform
+	for i := range t.cells {
+		out.cells[i] = next.cells[t.cells[i]]
+	}
+	for d := range t.digits {
+		out.digits[d] = next.digits[t.digits[d]]
+	}
+	return out
+}
+
+// Invert returns the transform mapping presented coordinates back to
+// canonical ones
+func (t BoardTransform) Invert() BoardTransform {
+	var inv BoardTransform
+	for i, p := range t.cells {
+		inv.cells[p] = i
+	}
+	for d, p := range t.digits {
+		inv.digits[p] = d
+	}
+	return inv
+}
+
+// ApplyIndex maps one canonical cell index to its presented position
+func (t BoardTransform) ApplyIndex(idx int) int { return t.cells[idx] }
+
+// ApplyDigit maps one canonical digit (0 passes through for empty cells)
+func (t BoardTransform) ApplyDigit(digit int) int { return t.digits[digit] }
+
+// ApplyCellRef maps one canonical cell reference
+func (t BoardTransform) ApplyCellRef(ref CellRef) CellRef {
+	idx := t.cells[ref.Row*constants.GridSize+ref.Col]
+	return CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize}
+}
+
+// ApplyCandidate maps one canonical candidate, relabeling its digit
+func (t BoardTransform) ApplyCandidate(cand Candidate) Candidate {
+	idx := t.cells[cand.Row*constants.GridSize+cand.Col]
+	return Candidate{Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: t.digits[cand.Digit]}
+}
+
+// ApplyCells maps a full canonical board to its presented layout
+func (t BoardTransform) ApplyCells(cells []int) []int {
+	out := make([]int, len(cells))
+	for i, v := range cells {
+		if i < len(t.cells) {
+			out[t.cells[i]] = t.digits[v]
+		}
+	}
+	return out
+}
+
+// ApplyCandidateGrid maps a per-cell candidate grid, relabeling and sorting
+// each cell's digits. Nil cell entries stay nil so "no marks submitted"
+// survives the mapping. Clients may send grids shorter than the board; the
+// result is always padded to TotalCells so the mapping stays in bounds.
+func (t BoardTransform) ApplyCandidateGrid(grid [][]int) [][]int {
+	if grid == nil {
+		return nil
+	}
+	n := len(grid)
+	if n < len(t.cells) {
+		n = len(t.cells)
+	}
+	out := make([][]int, n)
+	for i, digits := range grid {
+		if i >= len(t.cells) || digits == nil {
+			continue
+		}
+		mapped := make([]int, len(digits))
+		for j, d := range digits {
+			mapped[j] = t.digits[d]
+		}
+		sort.Ints(mapped)
+		out[t.cells[i]] = mapped
+	}
+	return out
+}
+
+// applyCellRefs maps a CellRef slice in place
+func (t BoardTransform) applyCellRefs(refs []CellRef) {
+	for i := range refs {
+		refs[i] = t.ApplyCellRef(refs[i])
+	}
+}
+
+// applyCandidates maps a Candidate slice in place
+func (t BoardTransform) applyCandidates(cands []Candidate) {
+	for i := range cands {
+		cands[i] = t.ApplyCandidate(cands[i])
+	}
+}
+
+// applyUnit maps a unit reference by mapping its member cells and reading
+// off which unit they land in. Rotations and mirrors always send units to
+// units; a row may come back as a column and vice versa.
+func (t BoardTransform) applyUnit(u *UnitRef) *UnitRef {
+	if u == nil {
+		return nil
+	}
+	var first, last int
+	switch u.Type {
+	case "row":
+		first = t.cells[u.Index*constants.GridSize]
+		last = t.cells[u.Index*constants.GridSize+constants.GridSize-1]
+	case "column":
+		first = t.cells[u.Index]
+		last = t.cells[(constants.GridSize-1)*constants.GridSize+u.Index]
+	case "box":
+		// Boxes map to boxes under every grid symmetry; one member locates it
+		r, c := (u.Index/constants.BoxSize)*constants.BoxSize, (u.Index%constants.BoxSize)*constants.BoxSize
+		idx := t.cells[r*constants.GridSize+c]
+		box := (idx/constants.GridSize/constants.BoxSize)*constants.BoxSize + idx%constants.GridSize/constants.BoxSize
+		return &UnitRef{Type: "box", Index: box}
+	default:
+		return u
+	}
+	if first/constants.GridSize == last/constants.GridSize {
+		return &UnitRef{Type: "row", Index: first / constants.GridSize}
+	}
+	return &UnitRef{Type: "column", Index: first % constants.GridSize}
+}
+
+// ApplyMove rewrites every coordinate-bearing field of a move into the
+// presented orientation, in place. The explanation text is left alone; see
+// the package comment above.
+func (t BoardTransform) ApplyMove(m *Move) {
+	if m == nil {
+		return
+	}
+	m.Digit = t.digits[m.Digit]
+	t.applyCellRefs(m.Targets)
+	t.applyCellRefs(m.ChainLinks)
+	t.applyCandidates(m.Eliminations)
+	for i, digits := range m.TargetCandidates {
+		mapped := make([]int, len(digits))
+		for j, d := range digits {
+			mapped[j] = t.digits[d]
+		}
+		sort.Ints(mapped)
+		m.TargetCandidates[i] = mapped
+	}
+	if m.CandidateDiff != nil {
+		t.applyCandidates(m.CandidateDiff.Added)
+		t.applyCandidates(m.CandidateDiff.Removed)
+	}
+	t.applyCellRefs(m.Highlights.Primary)
+	t.applyCellRefs(m.Highlights.Secondary)
+	t.applyCellRefs(m.Highlights.Tertiary)
+	t.applyCellRefs(m.Highlights.Target)
+	t.applyCellRefs(m.Highlights.Defining)
+	t.applyCandidates(m.Highlights.Justifying)
+	t.applyCandidates(m.Highlights.Eliminated)
+	m.Unit = t.applyUnit(m.Unit)
+}
+
+// ParseTransform parses a comma-separated transform spec as carried on the
+// puzzle endpoint and in session tokens: any of "rotate90", "rotate180",
+// "rotate270", "mirror-h", "mirror-v", and "relabel:<GridSize digits>",
+// composed left to right. The empty spec is the identity.
+func ParseTransform(spec string) (BoardTransform, error) {
+	t := IdentityTransform()
+	if spec == "" {
+		return t, nil
+	}
+	for _, op := range strings.Split(spec, ",") {
+		switch op = strings.TrimSpace(op); {
+		case op == "rotate90":
+			t = t.Compose(Rotate90())
+		case op == "rotate180":
+			t = t.Compose(Rotate90()).Compose(Rotate90())
+		case op == "rotate270":
+			t = t.Compose(Rotate90()).Compose(Rotate90()).Compose(Rotate90())
+		case op == "mirror-h":
+			t = t.Compose(MirrorH())
+		case op == "mirror-v":
+			t = t.Compose(MirrorV())
+		case strings.HasPrefix(op, "relabel:"):
+			raw := strings.TrimPrefix(op, "relabel:")
+			perm := make([]int, 0, constants.GridSize)
+			for _, ch := range raw {
+				if ch < '1' || ch > '9' {
+					return BoardTransform{}, fmt.Errorf("invalid relabel digit %q in transform %q", string(ch), op)
+				}
+				perm = append(perm, int(ch-'0'))
+			}
+			relabel, err := RelabelDigits(perm)
+			if err != nil {
+				return BoardTransform{}, err
+			}
+			t = t.Compose(relabel)
+		default:
+			return BoardTransform{}, fmt.Errorf("unknown transform %q", op)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+func TestRotate90Geometry(t *testing.T) {
+	rot := Rotate90()
+
+	// The top-left corner lands in the top-right under a clockwise quarter turn
+	if got := rot.ApplyCellRef(CellRef{Row: 0, Col: 0}); got != (CellRef{Row: 0, Col: 8}) {
+		t.Errorf("R1C1 rotated to %+v, want R1C9", got)
+	}
+	// Four quarter turns are the identity
+	full := rot.Compose(rot).Compose(rot).Compose(rot)
+	if !full.IsIdentity() {
+		t.Error("four Rotate90 compositions are not the identity")
+	}
+}
+
+func TestInvertRoundTripsCellsAndDigits(t *testing.T) {
+	relabel, err := RelabelDigits([]int{9, 8, 7, 6, 5, 4, 3, 2, 1})
+	if err != nil {
+		t.Fatalf("RelabelDigits failed: %v", err)
+	}
+	tr := Rotate90().Compose(MirrorH()).Compose(relabel)
+
+	cells := make([]int, constants.TotalCells)
+	for i := range cells {
+		cells[i] = i % (constants.GridSize + 1) // mix of digits and empties
+	}
+	back := tr.Invert().ApplyCells(tr.ApplyCells(cells))
+	if !reflect.DeepEqual(back, cells) {
+		t.Error("Invert did not round-trip ApplyCells")
+	}
+	if !tr.Compose(tr.Invert()).IsIdentity() {
+		t.Error("t.Compose(t.Invert()) is not the identity")
+	}
+}
+
+func TestRelabelDigitsRejectsBadPerms(t *testing.T) {
+	if _, err := RelabelDigits([]int{1, 2, 3}); err == nil {
+		t.Error("short permutation accepted")
+	}
+	if _, err := RelabelDigits([]int{1, 1, 3, 4, 5, 6, 7, 8, 9}); err == nil {
+		t.Error("repeated digit accepted")
+	}
+}
+
+func TestParseTransform(t *testing.T) {
+	if tr, err := ParseTransform(""); err != nil || !tr.IsIdentity() {
+		t.Errorf("empty spec = (%v, %v), want identity", tr.IsIdentity(), err)
+	}
+	tr, err := ParseTransform("rotate90,rotate90")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+	half, err := ParseTransform("rotate180")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+	if tr != half {
+		t.Error("rotate90,rotate90 != rotate180")
+	}
+	if _, err := ParseTransform("shuffle"); err == nil {
+		t.Error("unknown op accepted")
+	}
+	if _, err := ParseTransform("relabel:123"); err == nil {
+		t.Error("short relabel accepted")
+	}
+}
+
+func TestApplyMoveMapsEveryCoordinateField(t *testing.T) {
+	tr, err := ParseTransform("rotate90,relabel:234567891")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+
+	move := &Move{
+		Technique:        "naked-pair",
+		Digit:            1,
+		Targets:          []CellRef{{Row: 2, Col: 3}},
+		TargetCandidates: [][]int{{1, 2}},
+		Eliminations:     []Candidate{{Row: 2, Col: 5, Digit: 1}},
+		ChainLinks:       []CellRef{{Row: 2, Col: 3}, {Row: 2, Col: 5}},
+		CandidateDiff:    &CandidateDiff{Added: []Candidate{{Row: 4, Col: 4, Digit: 9}}},
+		Unit:             &UnitRef{Type: "row", Index: 2},
+		Highlights: Highlights{
+			Primary:    []CellRef{{Row: 2, Col: 3}},
+			Justifying: []CandidateRef{{Row: 2, Col: 3, Digit: 1}},
+			Eliminated: []CandidateRef{{Row: 2, Col: 5, Digit: 1}},
+		},
+	}
+	tr.ApplyMove(move)
+
+	// (2,3) -> (3,6) under rotate90; digit 1 relabels to 2
+	if move.Targets[0] != (CellRef{Row: 3, Col: 6}) || move.Digit != 2 {
+		t.Errorf("targets/digit = %+v/%d, want R4C7/2", move.Targets[0], move.Digit)
+	}
+	if !reflect.DeepEqual(move.TargetCandidates[0], []int{2, 3}) {
+		t.Errorf("target candidates = %v, want [2 3]", move.TargetCandidates[0])
+	}
+	if move.Eliminations[0] != (Candidate{Row: 5, Col: 6, Digit: 2}) {
+		t.Errorf("elimination = %+v, want {5 6 2}", move.Eliminations[0])
+	}
+	if move.CandidateDiff.Added[0] != (Candidate{Row: 4, Col: 4, Digit: 1}) {
+		t.Errorf("candidate diff = %+v, want center cell with digit 9->1", move.CandidateDiff.Added[0])
+	}
+	// Row 2 becomes column 6 under a clockwise quarter turn
+	if move.Unit == nil || move.Unit.Type != "column" || move.Unit.Index != 6 {
+		t.Errorf("unit = %+v, want column 6", move.Unit)
+	}
+	if move.Highlights.Primary[0] != (CellRef{Row: 3, Col: 6}) {
+		t.Errorf("highlight primary = %+v", move.Highlights.Primary[0])
+	}
+	if move.Highlights.Justifying[0] != (CandidateRef{Row: 3, Col: 6, Digit: 2}) {
+		t.Errorf("highlight justifying = %+v", move.Highlights.Justifying[0])
+	}
+	if move.Highlights.Eliminated[0] != (CandidateRef{Row: 5, Col: 6, Digit: 2}) {
+		t.Errorf("highlight eliminated = %+v", move.Highlights.Eliminated[0])
+	}
+}
@@ -0,0 +1,187 @@
+// Package corpus reads and writes the machine-readable failure corpus
+// produced by the stress-test CLIs (cmd/test_all_puzzles,
+// cmd/test_techniques). Each failure is one NDJSON line, so a corpus can be
+// grepped, appended to, and replayed with cmd/replay_failures after a
+// technique fix without regenerating thousands of puzzles.
+package corpus
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+// lastMoveCount is how many trailing moves a record keeps for context
+const lastMoveCount = 10
+
+// FailureRecord is one NDJSON line: everything needed to inspect a failed
+// solve and to reproduce it deterministically from the seed.
+type FailureRecord struct {
+	Seed         int64 `json:"seed"`
+	TargetGivens int   `json:"target_givens"`
+	// Status is "contradiction" when the solve produced a contradiction
+	// move, otherwise the solver's stop status (stalled, max_steps_reached)
+	Status     string         `json:"status"`
+	Techniques map[string]int `json:"techniques,omitempty"`
+	// Board and Candidates hold the state at failure in the compact binary
+	// codec (human.EncodeBoard / EncodeCandidates), base64-encoded
+	Board      string      `json:"board"`
+	Candidates string      `json:"candidates"`
+	LastMoves  []core.Move `json:"last_moves,omitempty"`
+	// EnabledTechniques restricts the replay solver to these slugs, so
+	// isolation runs (test_techniques) fail the same way on replay. Empty
+	// means the full registry.
+	EnabledTechniques []string `json:"enabled_techniques,omitempty"`
+}
+
+// NewFailureRecord captures a failed solve. The board argument is the state
+// at failure; moves is the full move list, of which the last few are kept.
+func NewFailureRecord(seed int64, targetGivens int, status string, moves []core.Move, board *human.Board, enabled []string) (FailureRecord, error) {
+	cells, err := human.EncodeBoard(board.GetCells())
+	if err != nil {
+		return FailureRecord{}, fmt.Errorf("encoding board: %w", err)
+	}
+	candidates, err := human.EncodeCandidates(board.GetCandidates())
+	if err != nil {
+		return FailureRecord{}, fmt.Errorf("encoding candidates: %w", err)
+	}
+
+	techniques := make(map[string]int)
+	for _, m := range moves {
+		if m.Technique == "contradiction" {
+			status = "contradiction"
+		}
+		if m.Technique != "" && m.Technique != "fill-candidate" {
+			techniques[m.Technique]++
+		}
+	}
+
+	start := len(moves) - lastMoveCount
+	if start < 0 {
+		start = 0
+	}
+
+	return FailureRecord{
+		Seed:              seed,
+		TargetGivens:      targetGivens,
+		Status:            status,
+		Techniques:        techniques,
+		Board:             base64.StdEncoding.EncodeToString(cells),
+		Candidates:        base64.StdEncoding.EncodeToString(candidates),
+		LastMoves:         moves[start:],
+		EnabledTechniques: enabled,
+	}, nil
+}
+
+// FailureBoard decodes the board state captured at failure
+func (r FailureRecord) FailureBoard() ([]int, [][]int, error) {
+	cellBytes, err := base64.StdEncoding.DecodeString(r.Board)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding board: %w", err)
+	}
+	cells, err := human.DecodeBoard(cellBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidateBytes, err := base64.StdEncoding.DecodeString(r.Candidates)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding candidates: %w", err)
+	}
+	candidates, err := human.DecodeCandidates(candidateBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cells, candidates, nil
+}
+
+// Writer appends failure records as NDJSON lines. Safe for concurrent use
+// by the stress-test workers.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriter wraps w; each Write emits one JSON line
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+// Write appends one record. A nil Writer discards, so callers can thread an
+// optional writer without guarding every call site.
+func (w *Writer) Write(rec FailureRecord) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(rec)
+}
+
+// ReadAll parses an NDJSON corpus, skipping blank lines
+func ReadAll(r io.Reader) ([]FailureRecord, error) {
+	var records []FailureRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec FailureRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Outcome classifies one replayed record against the current solver
+type Outcome struct {
+	Record FailureRecord
+	// Status is the fresh solve's status, derived the same way as
+	// FailureRecord.Status
+	Status string
+	Fixed  bool
+}
+
+// ReplayRecord regenerates the record's puzzle from its seed and re-runs it
+// with the current solver, restricted to the record's enabled techniques if
+// it has any. A record is fixed when the fresh solve completes.
+func ReplayRecord(rec FailureRecord) Outcome {
+	fullGrid := dp.GenerateFullGrid(rec.Seed)
+	givens := dp.CarveGivens(fullGrid, rec.TargetGivens, rec.Seed)
+
+	var solver *human.Solver
+	if len(rec.EnabledTechniques) > 0 {
+		solver = human.CreateSolverWithOnlyTechniques(rec.EnabledTechniques...)
+	} else {
+		solver = human.NewSolver()
+	}
+	board := human.NewBoard(givens)
+	moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+
+	for _, m := range moves {
+		if m.Technique == "contradiction" {
+			status = "contradiction"
+		}
+	}
+
+	return Outcome{
+		Record: rec,
+		Status: status,
+		Fixed:  status == constants.StatusCompleted,
+	}
+}
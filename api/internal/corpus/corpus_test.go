@@ -0,0 +1,104 @@
+package corpus
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+// failingFixture builds a deterministic failing record: a carved puzzle
+// solved with only naked singles enabled, which stalls well short of done.
+func failingFixture(t *testing.T) (FailureRecord, *human.Board) {
+	t.Helper()
+	const seed, targetGivens = 1, 22
+
+	givens := dp.CarveGivens(dp.GenerateFullGrid(seed), targetGivens, seed)
+	solver := human.CreateSolverWithOnlyTechniques("naked-single")
+	board := human.NewBoard(givens)
+	moves, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	if status == constants.StatusCompleted {
+		t.Fatalf("fixture unexpectedly solved with naked singles only")
+	}
+
+	rec, err := NewFailureRecord(seed, targetGivens, status, moves, board, []string{"naked-single"})
+	if err != nil {
+		t.Fatalf("NewFailureRecord failed: %v", err)
+	}
+	return rec, board
+}
+
+func TestFailureRecordRoundTrip(t *testing.T) {
+	rec, board := failingFixture(t)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Write(rec); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	records, err := ReadAll(&buf)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("read %d records, want 1", len(records))
+	}
+	got := records[0]
+
+	if got.Seed != rec.Seed || got.TargetGivens != rec.TargetGivens || got.Status != rec.Status {
+		t.Errorf("round-trip changed header: got %+v", got)
+	}
+	if !reflect.DeepEqual(got.Techniques, rec.Techniques) {
+		t.Errorf("technique usage = %v, want %v", got.Techniques, rec.Techniques)
+	}
+	if len(got.LastMoves) == 0 || len(got.LastMoves) > lastMoveCount {
+		t.Errorf("kept %d last moves, want 1..%d", len(got.LastMoves), lastMoveCount)
+	}
+
+	cells, candidates, err := got.FailureBoard()
+	if err != nil {
+		t.Fatalf("FailureBoard failed: %v", err)
+	}
+	if !reflect.DeepEqual(cells, board.GetCells()) {
+		t.Error("decoded failure board differs from the captured one")
+	}
+	// Cells without candidates decode as empty rather than nil slices, so
+	// compare contents per cell
+	for i, want := range board.GetCandidates() {
+		if len(candidates[i]) == 0 && len(want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(candidates[i], want) {
+			t.Errorf("cell %d decoded candidates %v, want %v", i, candidates[i], want)
+		}
+	}
+}
+
+func TestReplayClassification(t *testing.T) {
+	stillFailing, _ := failingFixture(t)
+
+	// Unchanged solver restriction: the replay must fail the same way
+	outcome := ReplayRecord(stillFailing)
+	if outcome.Fixed {
+		t.Errorf("naked-single-only record classified as fixed (status %s)", outcome.Status)
+	}
+	if outcome.Status != stillFailing.Status {
+		t.Errorf("replay status = %s, want %s", outcome.Status, stillFailing.Status)
+	}
+
+	// The same puzzle with the full registry stands in for a "fixed"
+	// failure: the restriction that caused it is gone
+	fixed := stillFailing
+	fixed.EnabledTechniques = nil
+	outcome = ReplayRecord(fixed)
+	if !outcome.Fixed {
+		t.Errorf("full-registry replay not classified as fixed (status %s)", outcome.Status)
+	}
+	if outcome.Status != constants.StatusCompleted {
+		t.Errorf("replay status = %s, want %s", outcome.Status, constants.StatusCompleted)
+	}
+}
@@ -0,0 +1,237 @@
+// Package ingest resolves OCR grid readings into a valid puzzle.
+//
+// The companion app performs OCR client-side and submits an 81-cell array of
+// digit guesses with confidences. Unambiguous cells are taken as-is; cells
+// with alternative readings (a 1 that might be a 7) are resolved by a bounded
+// search over the alternative combinations, preferring the highest-confidence
+// combination that yields a valid, uniquely solvable puzzle. This is pure
+// constraint work on top of the DP solver - no image processing happens here.
+package ingest
+
+import (
+	"fmt"
+	"sort"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// DefaultSearchBudget bounds how many full reading combinations are checked
+// for uniqueness before the resolver gives up
+const DefaultSearchBudget = 256
+
+// Alternative is a secondary OCR reading for a cell
+type Alternative struct {
+	Digit      int     `json:"digit"`
+	Confidence float64 `json:"confidence"`
+}
+
+// CellReading is the OCR output for one cell. Digit 0 means the cell was read
+// as empty. A cell with alternatives is ambiguous: the resolver may
+// substitute one of them when the primary readings do not form a valid
+// puzzle.
+type CellReading struct {
+	Digit        int           `json:"digit"`
+	Confidence   float64       `json:"confidence"`
+	Alternatives []Alternative `json:"alternatives,omitempty"`
+}
+
+// Correction records a cell where the resolver chose an alternative reading
+// over the primary one
+type Correction struct {
+	Cell       int     `json:"cell"`
+	Digit      int     `json:"digit"`
+	Original   int     `json:"original"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Result is a successful resolution. Confidence is the product of the chosen
+// readings' confidences over the ambiguous cells (1 when nothing was
+// ambiguous), so a grid resolved entirely from primary readings scores
+// higher than one that needed low-confidence alternatives.
+type Result struct {
+	Givens      []int        `json:"givens"`
+	Corrections []Correction `json:"corrections"`
+	Confidence  float64      `json:"confidence"`
+}
+
+// UnresolvableError reports that no combination of readings within the
+// search budget produced a valid, uniquely solvable puzzle. ProblemCells
+// lists the ambiguous cell indices the resolver could not settle.
+type UnresolvableError struct {
+	ProblemCells []int
+	Combinations int
+}
+
+func (e *UnresolvableError) Error() string {
+	return fmt.Sprintf("no valid unique puzzle among %d reading combinations (%d ambiguous cells)",
+		e.Combinations, len(e.ProblemCells))
+}
+
+// reading is one candidate interpretation of an ambiguous cell
+type reading struct {
+	digit int
+	conf  float64
+}
+
+// Resolve resolves the readings with the default search budget
+func Resolve(cells []CellReading) (*Result, error) {
+	return ResolveWithBudget(cells, DefaultSearchBudget)
+}
+
+// ResolveWithBudget fixes every unambiguous reading, then searches the
+// ambiguous cells' readings in descending-confidence order for the first
+// combination that forms a valid puzzle with exactly one solution. The
+// budget caps how many complete combinations are tested for uniqueness.
+func ResolveWithBudget(cells []CellReading, budget int) (*Result, error) {
+	if len(cells) != constants.TotalCells {
+		return nil, fmt.Errorf("expected %d cell readings, got %d", constants.TotalCells, len(cells))
+	}
+
+	grid := make([]int, constants.TotalCells)
+	var ambiguous []int
+	options := make(map[int][]reading)
+
+	for i, cell := range cells {
+		if cell.Digit < 0 || cell.Digit > constants.GridSize {
+			return nil, fmt.Errorf("cell %d: digit %d out of range", i, cell.Digit)
+		}
+		grid[i] = cell.Digit
+		if len(cell.Alternatives) == 0 {
+			continue
+		}
+
+		opts := []reading{{digit: cell.Digit, conf: cell.Confidence}}
+		for _, alt := range cell.Alternatives {
+			if alt.Digit < 0 || alt.Digit > constants.GridSize {
+				return nil, fmt.Errorf("cell %d: alternative digit %d out of range", i, alt.Digit)
+			}
+			opts = append(opts, reading{digit: alt.Digit, conf: alt.Confidence})
+		}
+		sort.SliceStable(opts, func(a, b int) bool { return opts[a].conf > opts[b].conf })
+
+		ambiguous = append(ambiguous, i)
+		options[i] = opts
+		grid[i] = 0 // assigned during the search
+	}
+
+	// The fixed readings must be conflict-free regardless of how the
+	// ambiguous cells resolve
+	if !dp.IsValid(grid) {
+		return nil, &UnresolvableError{ProblemCells: conflictingCells(grid)}
+	}
+
+	tried := 0
+	result := searchReadings(grid, ambiguous, options, 0, &tried, budget)
+	if result == nil {
+		return nil, &UnresolvableError{ProblemCells: ambiguous, Combinations: tried}
+	}
+	return result, nil
+}
+
+// searchReadings assigns ambiguous cells depth-first in confidence order and
+// returns the first complete assignment that is valid and unique. Because
+// each cell's readings are sorted by confidence, the first hit is the
+// preferred combination.
+func searchReadings(grid []int, ambiguous []int, options map[int][]reading, depth int, tried *int, budget int) *Result {
+	if depth == len(ambiguous) {
+		if *tried >= budget {
+			return nil
+		}
+		*tried++
+		if dp.CountSolutions(grid, 2) != 1 {
+			return nil
+		}
+		return buildResult(grid, ambiguous, options)
+	}
+
+	idx := ambiguous[depth]
+	for _, opt := range options[idx] {
+		if opt.digit != 0 && conflicts(grid, idx, opt.digit) {
+			continue
+		}
+		grid[idx] = opt.digit
+		if result := searchReadings(grid, ambiguous, options, depth+1, tried, budget); result != nil {
+			return result
+		}
+		grid[idx] = 0
+		if *tried >= budget {
+			return nil
+		}
+	}
+	return nil
+}
+
+// buildResult snapshots the grid and records which ambiguous cells ended up
+// with an alternative reading
+func buildResult(grid []int, ambiguous []int, options map[int][]reading) *Result {
+	givens := make([]int, len(grid))
+	copy(givens, grid)
+
+	confidence := 1.0
+	corrections := []Correction{}
+	for _, idx := range ambiguous {
+		opts := options[idx]
+		primary := opts[0]
+		var chosen reading
+		for _, opt := range opts {
+			if opt.digit == grid[idx] {
+				chosen = opt
+				break
+			}
+		}
+		confidence *= chosen.conf
+		if chosen.digit != primary.digit {
+			corrections = append(corrections, Correction{
+				Cell:       idx,
+				Digit:      chosen.digit,
+				Original:   primary.digit,
+				Confidence: chosen.conf,
+			})
+		}
+	}
+
+	return &Result{Givens: givens, Corrections: corrections, Confidence: confidence}
+}
+
+// conflicts reports whether placing digit at idx duplicates a digit already
+// fixed in the same row, column or box
+func conflicts(grid []int, idx, digit int) bool {
+	row, col := idx/constants.GridSize, idx%constants.GridSize
+	for c := 0; c < constants.GridSize; c++ {
+		if c != col && grid[row*constants.GridSize+c] == digit {
+			return true
+		}
+	}
+	for r := 0; r < constants.GridSize; r++ {
+		if r != row && grid[r*constants.GridSize+col] == digit {
+			return true
+		}
+	}
+	boxRow, boxCol := (row/constants.BoxSize)*constants.BoxSize, (col/constants.BoxSize)*constants.BoxSize
+	for r := boxRow; r < boxRow+constants.BoxSize; r++ {
+		for c := boxCol; c < boxCol+constants.BoxSize; c++ {
+			if (r != row || c != col) && grid[r*constants.GridSize+c] == digit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// conflictingCells lists the cells involved in conflicts among the fixed
+// readings, for error reporting when resolution cannot even start
+func conflictingCells(grid []int) []int {
+	seen := make(map[int]bool)
+	var cells []int
+	for _, conflict := range dp.FindConflicts(grid) {
+		for _, idx := range []int{conflict.Cell1, conflict.Cell2} {
+			if !seen[idx] {
+				seen[idx] = true
+				cells = append(cells, idx)
+			}
+		}
+	}
+	sort.Ints(cells)
+	return cells
+}
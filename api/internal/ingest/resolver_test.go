@@ -0,0 +1,165 @@
+package ingest
+
+import (
+	"errors"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// uniquePuzzle returns the givens of a generated puzzle with exactly one
+// solution, plus its full solution grid
+func uniquePuzzle(t *testing.T) ([]int, []int) {
+	t.Helper()
+	fullGrid := dp.GenerateFullGrid(1)
+	puzzles, _ := dp.CarveGivensWithSubset(fullGrid, 1)
+	givens := puzzles[constants.DifficultyEasy]
+	if dp.CountSolutions(givens, 2) != 1 {
+		t.Fatal("carved easy puzzle is not uniquely solvable")
+	}
+	return givens, fullGrid
+}
+
+// readingsFromGivens wraps a grid as unambiguous high-confidence readings
+func readingsFromGivens(givens []int) []CellReading {
+	cells := make([]CellReading, len(givens))
+	for i, digit := range givens {
+		cells[i] = CellReading{Digit: digit, Confidence: 0.99}
+	}
+	return cells
+}
+
+// rowmate finds a second given in the same row as idx and returns its digit,
+// to build a primary reading that conflicts with a fixed cell
+func rowmate(t *testing.T, givens []int, idx int) int {
+	t.Helper()
+	row := idx / constants.GridSize
+	for c := 0; c < constants.GridSize; c++ {
+		other := row*constants.GridSize + c
+		if other != idx && givens[other] != 0 {
+			return givens[other]
+		}
+	}
+	t.Fatalf("no second given in row of cell %d", idx)
+	return 0
+}
+
+// TestResolveUnambiguous passes clean readings straight through
+func TestResolveUnambiguous(t *testing.T) {
+	givens, _ := uniquePuzzle(t)
+	result, err := Resolve(readingsFromGivens(givens))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	for i, digit := range givens {
+		if result.Givens[i] != digit {
+			t.Fatalf("cell %d: got %d, want %d", i, result.Givens[i], digit)
+		}
+	}
+	if len(result.Corrections) != 0 {
+		t.Errorf("expected no corrections, got %v", result.Corrections)
+	}
+	if result.Confidence != 1 {
+		t.Errorf("expected confidence 1 with no ambiguous cells, got %v", result.Confidence)
+	}
+}
+
+// TestResolvePicksValidAlternatives makes two givens ambiguous with a
+// misread primary whose row already holds that digit; only the combination
+// taking both alternatives yields a valid unique puzzle.
+func TestResolvePicksValidAlternatives(t *testing.T) {
+	givens, _ := uniquePuzzle(t)
+
+	// One ambiguous cell per row so each misread primary conflicts with a
+	// cell that stays fixed
+	var ambiguous []int
+	cells := readingsFromGivens(givens)
+	lastRow := -1
+	for i, digit := range givens {
+		if digit == 0 || i/constants.GridSize == lastRow || len(ambiguous) == 2 {
+			continue
+		}
+		lastRow = i / constants.GridSize
+		ambiguous = append(ambiguous, i)
+		cells[i] = CellReading{
+			Digit:      rowmate(t, givens, i),
+			Confidence: 0.55,
+			Alternatives: []Alternative{
+				{Digit: digit, Confidence: 0.45},
+			},
+		}
+	}
+	if len(ambiguous) != 2 {
+		t.Fatalf("expected 2 ambiguous cells, got %d", len(ambiguous))
+	}
+
+	result, err := Resolve(cells)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	for i, digit := range givens {
+		if result.Givens[i] != digit {
+			t.Fatalf("cell %d: got %d, want %d", i, result.Givens[i], digit)
+		}
+	}
+	if len(result.Corrections) != 2 {
+		t.Fatalf("expected 2 corrections, got %v", result.Corrections)
+	}
+	for n, idx := range ambiguous {
+		corr := result.Corrections[n]
+		if corr.Cell != idx {
+			t.Errorf("correction %d: cell %d, want %d", n, corr.Cell, idx)
+		}
+		if corr.Digit != givens[idx] {
+			t.Errorf("correction %d: digit %d, want %d", n, corr.Digit, givens[idx])
+		}
+		if corr.Original != cells[idx].Digit {
+			t.Errorf("correction %d: original %d, want %d", n, corr.Original, cells[idx].Digit)
+		}
+	}
+	want := 0.45 * 0.45
+	if result.Confidence < want-1e-9 || result.Confidence > want+1e-9 {
+		t.Errorf("confidence = %v, want %v", result.Confidence, want)
+	}
+}
+
+// TestResolveUnresolvableListsProblemCells makes every reading of one cell
+// conflict with fixed givens, so no combination can work
+func TestResolveUnresolvableListsProblemCells(t *testing.T) {
+	givens, _ := uniquePuzzle(t)
+
+	idx := -1
+	for i, digit := range givens {
+		if digit != 0 {
+			idx = i
+			break
+		}
+	}
+	clash := rowmate(t, givens, idx)
+
+	cells := readingsFromGivens(givens)
+	cells[idx] = CellReading{
+		Digit:      clash,
+		Confidence: 0.6,
+		Alternatives: []Alternative{
+			{Digit: clash, Confidence: 0.3},
+		},
+	}
+
+	_, err := Resolve(cells)
+	var unresolvable *UnresolvableError
+	if !errors.As(err, &unresolvable) {
+		t.Fatalf("expected UnresolvableError, got %v", err)
+	}
+	if len(unresolvable.ProblemCells) != 1 || unresolvable.ProblemCells[0] != idx {
+		t.Errorf("ProblemCells = %v, want [%d]", unresolvable.ProblemCells, idx)
+	}
+}
+
+// TestResolveRejectsWrongLength requires exactly 81 readings
+func TestResolveRejectsWrongLength(t *testing.T) {
+	if _, err := Resolve(make([]CellReading, 80)); err == nil {
+		t.Error("expected error for short reading slice")
+	}
+}
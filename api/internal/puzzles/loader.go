@@ -8,13 +8,15 @@ import (
 	"sync"
 	"time"
 
+	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
 
 // CompactPuzzle stores a puzzle in minimal format
 type CompactPuzzle struct {
-	S string           `json:"s"` // solution as TotalCells-char string
-	G map[string][]int `json:"g"` // givens: difficulty key -> cell indices
+	S string                    `json:"s"`           // solution as TotalCells-char string
+	G map[string][]int          `json:"g"`           // givens: difficulty key -> cell indices
+	T map[string]map[string]int `json:"t,omitempty"` // proof: difficulty key -> technique slug -> times used
 }
 
 // PuzzleFile is the top-level structure for the JSON file
@@ -26,8 +28,13 @@ type PuzzleFile struct {
 
 // Loader manages pre-generated puzzles
 type Loader struct {
-	puzzles []CompactPuzzle
-	mu      sync.RWMutex
+	puzzles  []CompactPuzzle
+	dailyKey string
+	mu       sync.RWMutex
+
+	// Per-difficulty search indices, built lazily on first Search (search.go)
+	searchMu sync.Mutex
+	search   map[string]*searchIndex
 }
 
 // DifficultyKey maps full difficulty names to compact keys
@@ -37,6 +44,7 @@ var DifficultyKey = map[string]string{
 	"hard":       "h",
 	"extreme":    "x",
 	"impossible": "i",
+	"diabolical": "d",
 }
 
 // KeyToDifficulty maps compact keys to full difficulty names
@@ -46,6 +54,7 @@ var KeyToDifficulty = map[string]string{
 	"h": "hard",
 	"x": "extreme",
 	"i": "impossible",
+	"d": "diabolical",
 }
 
 var (
@@ -108,11 +117,14 @@ func (l *Loader) GetPuzzle(index int, difficulty string) (givens []int, solution
 		return nil, nil, fmt.Errorf("puzzle index %d out of range (0-%d)", index, len(l.puzzles)-1)
 	}
 
-	puzzle := l.puzzles[index]
+	return l.puzzles[index].Expand(difficulty)
+}
 
+// Expand builds the full givens and solution arrays for one difficulty
+func (p CompactPuzzle) Expand(difficulty string) (givens []int, solution []int, err error) {
 	// Parse solution
 	solution = make([]int, constants.TotalCells)
-	for i, c := range puzzle.S {
+	for i, c := range p.S {
 		solution[i] = int(c - '0')
 	}
 
@@ -123,7 +135,7 @@ func (l *Loader) GetPuzzle(index int, difficulty string) (givens []int, solution
 	}
 
 	// Get indices for this difficulty
-	indices, ok := puzzle.G[key]
+	indices, ok := p.G[key]
 	if !ok {
 		return nil, nil, fmt.Errorf("difficulty %s not found in puzzle", difficulty)
 	}
@@ -137,17 +149,81 @@ func (l *Loader) GetPuzzle(index int, difficulty string) (givens []int, solution
 	return givens, solution, nil
 }
 
+// GetCompact returns the raw compact puzzle at the given index.
+// The returned value shares the givens maps with the loader; callers that
+// modify them must copy first.
+func (l *Loader) GetCompact(index int) (CompactPuzzle, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if index < 0 || index >= len(l.puzzles) {
+		return CompactPuzzle{}, fmt.Errorf("puzzle index %d out of range (0-%d)", index, len(l.puzzles)-1)
+	}
+	return l.puzzles[index], nil
+}
+
+// GetTechniques returns the technique multiset recorded for a puzzle at a
+// difficulty (slug -> times used), as computed by the human solver when the
+// bank was generated with proofs. Banks generated without proofs return nil
+// with no error; absence is expected, not a failure.
+func (l *Loader) GetTechniques(index int, difficulty string) (map[string]int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if index < 0 || index >= len(l.puzzles) {
+		return nil, fmt.Errorf("puzzle index %d out of range (0-%d)", index, len(l.puzzles)-1)
+	}
+
+	key, ok := DifficultyKey[difficulty]
+	if !ok {
+		return nil, fmt.Errorf("unknown difficulty: %s", difficulty)
+	}
+
+	stored, ok := l.puzzles[index].T[key]
+	if !ok {
+		return nil, nil
+	}
+
+	// Copy so callers cannot mutate the shared bank
+	techniques := make(map[string]int, len(stored))
+	for slug, count := range stored {
+		techniques[slug] = count
+	}
+	return techniques, nil
+}
+
 // GetPuzzleBySeed returns a puzzle for a given seed string
 // Uses FNV hash to deterministically map seed to puzzle index
 func (l *Loader) GetPuzzleBySeed(seed string, difficulty string) (givens []int, solution []int, puzzleIndex int, err error) {
 	l.mu.RLock()
 	count := len(l.puzzles)
+	keyed := l.dailyKey != ""
 	l.mu.RUnlock()
 
 	if count == 0 {
 		return nil, nil, 0, fmt.Errorf("no puzzles loaded")
 	}
 
+	if ref, refErr := core.ParsePuzzleRef(seed); refErr == nil {
+		switch ref.Kind {
+		case core.SeedStatic:
+			// Static seeds name a bank index directly; hashing them would
+			// serve an unrelated puzzle
+			puzzleIndex = int(ref.Numeric)
+			givens, solution, err = l.GetPuzzle(puzzleIndex, difficulty)
+			return
+		case core.SeedDaily:
+			// Daily seeds resolve through the keyed permutation when one is
+			// configured, so /puzzle/:seed returns the same puzzle the daily
+			// endpoint announced for that date
+			if keyed {
+				puzzleIndex = l.DailyIndex(ref.Date)
+				givens, solution, err = l.GetPuzzle(puzzleIndex, difficulty)
+				return
+			}
+		}
+	}
+
 	// Hash seed to get puzzle index
 	h := fnv.New64a()
 	h.Write([]byte(seed))
@@ -157,10 +233,106 @@ func (l *Loader) GetPuzzleBySeed(seed string, difficulty string) (givens []int,
 	return
 }
 
-// GetDailyPuzzle returns the puzzle for a given UTC date
+// SetDailyKey configures the secret that keys the daily puzzle permutation.
+// With a key set, daily puzzles walk the whole bank before any index repeats;
+// without one, daily selection falls back to the plain seed hash.
+func (l *Loader) SetDailyKey(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dailyKey = key
+}
+
+// DailyIndex maps a UTC date to a bank index using a keyed permutation over
+// [0, Count). Consecutive dates occupy consecutive slots of the permutation,
+// so every puzzle in the bank is used exactly once per Count-day cycle.
+func (l *Loader) DailyIndex(date time.Time) int {
+	l.mu.RLock()
+	count := len(l.puzzles)
+	key := l.dailyKey
+	l.mu.RUnlock()
+
+	if count == 0 {
+		return 0
+	}
+
+	day := date.UTC().Unix() / 86400
+	pos := int(day % int64(count))
+	if pos < 0 {
+		pos += count
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return permuteIndex(pos, count, h.Sum64())
+}
+
+// permuteIndex is a keyed bijection over [0, count). It cycle-walks a
+// four-round Feistel network sized to the smallest even bit width covering
+// count, so distinct positions always map to distinct indices.
+func permuteIndex(pos, count int, key uint64) int {
+	if count <= 1 {
+		return 0
+	}
+	bits := 2
+	for 1<<bits < count {
+		bits += 2
+	}
+	half := bits / 2
+	mask := (1 << half) - 1
+
+	for {
+		left, right := pos>>half, pos&mask
+		for round := 0; round < 4; round++ {
+			left, right = right, left^(feistelRound(right, round, key)&mask)
+		}
+		pos = left<<half | right
+		if pos < count {
+			return pos
+		}
+	}
+}
+
+// feistelRound mixes a half-block with the key and round number
+func feistelRound(v, round int, key uint64) int {
+	h := key ^ uint64(v)*0x9e3779b97f4a7c15 ^ uint64(round)*0xbf58476d1ce4e5b9 //nolint:gosec // v is a small non-negative half-block
+	h ^= h >> 29
+	h *= 0x94d049bb133111eb
+	h ^= h >> 32
+	return int(h & 0x7fffffff)
+}
+
+// Checksum returns a short FNV-1a digest of a puzzle's solution, for
+// cross-checking that two deployments serve the same bank entry at an index
+func (l *Loader) Checksum(index int) (string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if index < 0 || index >= len(l.puzzles) {
+		return "", fmt.Errorf("puzzle index %d out of range (0-%d)", index, len(l.puzzles)-1)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(l.puzzles[index].S))
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
+
+// GetDailyPuzzle returns the puzzle for a given UTC date. When a daily key is
+// configured the date is mapped through the no-repeat permutation; otherwise
+// it falls back to hashing the date seed as before.
 func (l *Loader) GetDailyPuzzle(date time.Time, difficulty string) (givens []int, solution []int, puzzleIndex int, err error) {
+	l.mu.RLock()
+	count := len(l.puzzles)
+	keyed := l.dailyKey != ""
+	l.mu.RUnlock()
+
+	if keyed && count > 0 {
+		puzzleIndex = l.DailyIndex(date)
+		givens, solution, err = l.GetPuzzle(puzzleIndex, difficulty)
+		return
+	}
+
 	// Use UTC date string as seed
-	dateStr := date.UTC().Format("2006-01-02")
+	dateStr := date.UTC().Format(constants.DailyDateFormat)
 	seed := "daily:" + dateStr
 	return l.GetPuzzleBySeed(seed, difficulty)
 }
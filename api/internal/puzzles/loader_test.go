@@ -1,6 +1,9 @@
 package puzzles
 
 import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
 	"testing"
@@ -245,6 +248,91 @@ func TestGetPuzzle_SolutionValuesInRange(t *testing.T) {
 	}
 }
 
+// GetTechniques() Tests
+
+func TestGetTechniques_RoundTrip(t *testing.T) {
+	// Build a file the way cmd/generate -with-proof does and check the
+	// proof survives marshaling and loading
+	proof := map[string]int{"naked-single": 31, "hidden-single": 12, "x-wing": 2}
+	file := PuzzleFile{
+		Version: 1,
+		Count:   2,
+		Puzzles: []CompactPuzzle{
+			{
+				S: "157924638362158974498736512531279486926483157784615293273561849619847325845392761",
+				G: map[string][]int{"e": {0, 1, 2}, "m": {0, 1}},
+				T: map[string]map[string]int{"m": proof},
+			},
+			{
+				S: "234978561978651432651342978492563817367814295815729346546297183789135624123486759",
+				G: map[string][]int{"e": {0, 1, 2}},
+			},
+		},
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	path := createTempPuzzleFile(t, string(data))
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	techniques, err := loader.GetTechniques(0, "medium")
+	if err != nil {
+		t.Fatalf("GetTechniques() failed: %v", err)
+	}
+	if len(techniques) != len(proof) {
+		t.Fatalf("Expected %d techniques, got %v", len(proof), techniques)
+	}
+	for slug, count := range proof {
+		if techniques[slug] != count {
+			t.Errorf("Expected %s count %d, got %d", slug, count, techniques[slug])
+		}
+	}
+
+	// Returned map is a copy, not the loader's
+	techniques["naked-single"] = 999
+	again, _ := loader.GetTechniques(0, "medium")
+	if again["naked-single"] != proof["naked-single"] {
+		t.Error("GetTechniques() should return a copy of the stored proof")
+	}
+}
+
+func TestGetTechniques_Absent(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	// Banks generated without -with-proof have no proofs; that is not an
+	// error
+	techniques, err := loader.GetTechniques(0, "easy")
+	if err != nil {
+		t.Fatalf("GetTechniques() failed: %v", err)
+	}
+	if techniques != nil {
+		t.Errorf("Expected nil techniques for bank without proofs, got %v", techniques)
+	}
+}
+
+func TestGetTechniques_Errors(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if _, err := loader.GetTechniques(5, "easy"); err == nil {
+		t.Error("GetTechniques() should fail for out-of-range index")
+	}
+	if _, err := loader.GetTechniques(0, "nightmare"); err == nil {
+		t.Error("GetTechniques() should fail for unknown difficulty")
+	}
+}
+
 func TestGetPuzzle_DifferentPuzzles(t *testing.T) {
 	path := createTempPuzzleFile(t, validPuzzleJSON)
 	loader, err := Load(path)
@@ -673,3 +761,201 @@ func TestGetPuzzle_HarderDifficultyHasFewerGivens(t *testing.T) {
 		t.Errorf("Hard should have more givens than impossible: hard=%d, impossible=%d", hardCount, impossibleCount)
 	}
 }
+
+// Daily Permutation Tests
+
+// syntheticBank builds a loader with count distinct solvable-looking entries,
+// enough for index-level daily permutation tests
+func syntheticBank(count int) *Loader {
+	bank := make([]CompactPuzzle, count)
+	for i := range bank {
+		bank[i] = CompactPuzzle{
+			S: "157924638362158974498736512531279486926483157784615293273561849619847325845392761",
+			G: map[string][]int{"e": {0, 1, 2, 3}, "m": {0, 1, 2}},
+		}
+	}
+	return NewLoaderFromPuzzles(bank)
+}
+
+func TestDailyIndex_NoRepeatsAcrossDates(t *testing.T) {
+	loader := syntheticBank(1000)
+	loader.SetDailyKey("daily-test-key")
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := make(map[int]string)
+	for day := 0; day < 365; day++ {
+		date := start.AddDate(0, 0, day)
+		idx := loader.DailyIndex(date)
+		if idx < 0 || idx >= 1000 {
+			t.Fatalf("DailyIndex(%s) out of range: %d", date.Format("2006-01-02"), idx)
+		}
+		if prev, ok := seen[idx]; ok {
+			t.Fatalf("index %d repeated on %s (first used %s)", idx, date.Format("2006-01-02"), prev)
+		}
+		seen[idx] = date.Format("2006-01-02")
+	}
+}
+
+func TestDailyIndex_StableAcrossRestarts(t *testing.T) {
+	first := syntheticBank(1000)
+	first.SetDailyKey("daily-test-key")
+	second := syntheticBank(1000)
+	second.SetDailyKey("daily-test-key")
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 30; day++ {
+		date := start.AddDate(0, 0, day)
+		if a, b := first.DailyIndex(date), second.DailyIndex(date); a != b {
+			t.Errorf("DailyIndex(%s) differs across loaders: %d vs %d", date.Format("2006-01-02"), a, b)
+		}
+	}
+}
+
+func TestGetDailyPuzzle_PermutationMatchesSeedLookup(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	loader.SetDailyKey("daily-test-key")
+
+	date := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	_, _, dailyIdx, err := loader.GetDailyPuzzle(date, "easy")
+	if err != nil {
+		t.Fatalf("GetDailyPuzzle() failed: %v", err)
+	}
+	if dailyIdx != loader.DailyIndex(date) {
+		t.Errorf("GetDailyPuzzle index %d does not match DailyIndex %d", dailyIdx, loader.DailyIndex(date))
+	}
+
+	// The date seed the handler hands out must resolve to the same entry
+	_, _, seedIdx, err := loader.GetPuzzleBySeed("D2025-03-15", "easy")
+	if err != nil {
+		t.Fatalf("GetPuzzleBySeed() failed: %v", err)
+	}
+	if seedIdx != dailyIdx {
+		t.Errorf("daily seed resolved to index %d, daily endpoint uses %d", seedIdx, dailyIdx)
+	}
+}
+
+func TestGetDailyPuzzle_FallbackWithoutKey(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	// Without a key, daily selection keeps the legacy date-seed hash
+	date := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	_, _, dailyIdx, err := loader.GetDailyPuzzle(date, "easy")
+	if err != nil {
+		t.Fatalf("GetDailyPuzzle() failed: %v", err)
+	}
+	_, _, hashIdx, err := loader.GetPuzzleBySeed("daily:2025-03-15", "easy")
+	if err != nil {
+		t.Fatalf("GetPuzzleBySeed() failed: %v", err)
+	}
+	if dailyIdx != hashIdx {
+		t.Errorf("keyless daily index %d should match seed hash index %d", dailyIdx, hashIdx)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	sum0, err := loader.Checksum(0)
+	if err != nil {
+		t.Fatalf("Checksum(0) failed: %v", err)
+	}
+	sum1, err := loader.Checksum(1)
+	if err != nil {
+		t.Fatalf("Checksum(1) failed: %v", err)
+	}
+	if len(sum0) != 16 || sum0 == sum1 {
+		t.Errorf("expected distinct 16-hex checksums, got %q and %q", sum0, sum1)
+	}
+	if _, err := loader.Checksum(5); err == nil {
+		t.Error("Checksum() should fail for an out-of-range index")
+	}
+}
+
+// Seed family resolution tests
+
+func TestGetPuzzleBySeed_StaticSeedsResolveToBankIndex(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	for want := 0; want < loader.Count(); want++ {
+		seed := fmt.Sprintf("static-%d", want)
+		_, _, idx, err := loader.GetPuzzleBySeed(seed, "easy")
+		if err != nil {
+			t.Fatalf("GetPuzzleBySeed(%q) failed: %v", seed, err)
+		}
+		if idx != want {
+			t.Errorf("GetPuzzleBySeed(%q) resolved to index %d, want %d", seed, idx, want)
+		}
+	}
+
+	// Out-of-range bank indices are a clean error, not a hash fallback
+	if _, _, _, err := loader.GetPuzzleBySeed("static-999", "easy"); err == nil {
+		t.Error("GetPuzzleBySeed(static-999) should fail for an out-of-range index")
+	}
+}
+
+// TestGetPuzzleBySeed_HashCompatibility pins the seed strings seen in issue
+// reports to the FNV mapping they have always resolved through, so the
+// structured parser can't silently move existing players' puzzles.
+func TestGetPuzzleBySeed_HashCompatibility(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	seeds := []string{"R1766071853863", "P1766186192266", "test-seed-123", "v2:D2024-01-01"}
+	for _, seed := range seeds {
+		h := fnv.New64a()
+		h.Write([]byte(seed))
+		want := int(h.Sum64() % uint64(loader.Count()))
+
+		_, _, idx, err := loader.GetPuzzleBySeed(seed, "easy")
+		if err != nil {
+			t.Fatalf("GetPuzzleBySeed(%q) failed: %v", seed, err)
+		}
+		if idx != want {
+			t.Errorf("GetPuzzleBySeed(%q) resolved to index %d, want the hash index %d", seed, idx, want)
+		}
+	}
+}
+
+func TestGetPuzzleBySeed_DailySeedSpellings(t *testing.T) {
+	path := createTempPuzzleFile(t, validPuzzleJSON)
+	loader, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	loader.SetDailyKey("test-key")
+
+	_, _, want, err := loader.GetPuzzleBySeed("D2026-03-21", "easy")
+	if err != nil {
+		t.Fatalf("GetPuzzleBySeed(D2026-03-21) failed: %v", err)
+	}
+
+	// All spellings of a daily seed resolve through the same keyed index
+	for _, seed := range []string{"daily-2026-03-21", "daily:2026-03-21"} {
+		_, _, idx, err := loader.GetPuzzleBySeed(seed, "easy")
+		if err != nil {
+			t.Fatalf("GetPuzzleBySeed(%q) failed: %v", seed, err)
+		}
+		if idx != want {
+			t.Errorf("GetPuzzleBySeed(%q) resolved to index %d, want %d", seed, idx, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package puzzles
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Bank search. The loader builds a small per-difficulty index (technique slug
+// -> ascending puzzle indices, plus a given-count table) the first time that
+// difficulty is searched, so startup pays nothing and a bank without proof
+// metadata never triggers solver analysis - technique filtering just reports
+// ErrNoTechniqueData instead.
+
+// ErrNoTechniqueData is returned by Search when a technique filter is
+// requested but the bank carries no technique proofs for that difficulty
+var ErrNoTechniqueData = errors.New("bank has no technique data for this difficulty")
+
+// SearchQuery selects bank puzzles at one difficulty. Technique is an
+// optional slug filter; MinGivens/MaxGivens of zero mean unbounded. Offset
+// and Limit paginate the matches in ascending bank order.
+type SearchQuery struct {
+	Difficulty string
+	Technique  string
+	MinGivens  int
+	MaxGivens  int
+	Offset     int
+	Limit      int
+}
+
+// PuzzleRef identifies a bank puzzle without shipping its givens; clients
+// fetch the full puzzle by index in a follow-up request
+type PuzzleRef struct {
+	Index      int    `json:"index"`
+	Difficulty string `json:"difficulty"`
+	Givens     int    `json:"givens"`
+}
+
+// searchIndex holds the lazily built lookup structures for one difficulty key
+type searchIndex struct {
+	byTechnique map[string][]int // slug -> puzzle indices, ascending
+	givenCounts []int            // per puzzle index; -1 when the difficulty is absent
+	hasProofs   bool             // any puzzle carries technique data at this difficulty
+}
+
+// ensureSearchIndex returns the index for a difficulty key, building it on
+// first use. The build is a single pass over stored metadata - len(G) and the
+// keys of T - and never analyzes puzzles.
+func (l *Loader) ensureSearchIndex(key string) *searchIndex {
+	l.searchMu.Lock()
+	defer l.searchMu.Unlock()
+
+	if idx, ok := l.search[key]; ok {
+		return idx
+	}
+
+	l.mu.RLock()
+	idx := &searchIndex{
+		byTechnique: make(map[string][]int),
+		givenCounts: make([]int, len(l.puzzles)),
+	}
+	for i, p := range l.puzzles {
+		if givens, ok := p.G[key]; ok {
+			idx.givenCounts[i] = len(givens)
+		} else {
+			idx.givenCounts[i] = -1
+		}
+		if proof, ok := p.T[key]; ok {
+			idx.hasProofs = true
+			// Bank order is ascending, so the slices come out sorted
+			for slug := range proof {
+				idx.byTechnique[slug] = append(idx.byTechnique[slug], i)
+			}
+		}
+	}
+	l.mu.RUnlock()
+
+	if l.search == nil {
+		l.search = make(map[string]*searchIndex)
+	}
+	l.search[key] = idx
+	return idx
+}
+
+// Search returns the page of puzzles matching the query plus the total match
+// count, in ascending bank order.
+func (l *Loader) Search(q SearchQuery) ([]PuzzleRef, int, error) {
+	key, ok := DifficultyKey[q.Difficulty]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown difficulty: %s", q.Difficulty)
+	}
+
+	idx := l.ensureSearchIndex(key)
+
+	candidates := make([]int, 0, len(idx.givenCounts))
+	if q.Technique != "" {
+		if !idx.hasProofs {
+			return nil, 0, ErrNoTechniqueData
+		}
+		candidates = idx.byTechnique[q.Technique]
+	} else {
+		for i := range idx.givenCounts {
+			candidates = append(candidates, i)
+		}
+	}
+
+	var matched []PuzzleRef
+	for _, i := range candidates {
+		count := idx.givenCounts[i]
+		if count < 0 {
+			continue
+		}
+		if q.MinGivens > 0 && count < q.MinGivens {
+			continue
+		}
+		if q.MaxGivens > 0 && count > q.MaxGivens {
+			continue
+		}
+		matched = append(matched, PuzzleRef{Index: i, Difficulty: q.Difficulty, Givens: count})
+	}
+
+	total := len(matched)
+	if q.Offset >= total {
+		return nil, total, nil
+	}
+	end := total
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+	}
+	return matched[q.Offset:end], total, nil
+}
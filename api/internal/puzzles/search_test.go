@@ -0,0 +1,144 @@
+package puzzles
+
+import (
+	"errors"
+	"testing"
+)
+
+// searchBank builds a small in-memory bank: five puzzles at "hard" with
+// varying given counts, technique proofs on a known subset, and one puzzle
+// missing the difficulty entirely.
+func searchBank() *Loader {
+	return NewLoaderFromPuzzles([]CompactPuzzle{
+		{G: map[string][]int{"h": make([]int, 24)}, T: map[string]map[string]int{"h": {"naked-single": 8}}},
+		{G: map[string][]int{"h": make([]int, 26)}, T: map[string]map[string]int{"h": {"naked-single": 6, "x-wing": 1}}},
+		{G: map[string][]int{"h": make([]int, 28)}, T: map[string]map[string]int{"h": {"naked-single": 4}}},
+		{G: map[string][]int{"h": make([]int, 30)}, T: map[string]map[string]int{"h": {"x-wing": 2, "swordfish": 1}}},
+		{G: map[string][]int{"e": make([]int, 40)}}, // no hard entry, no proofs
+	})
+}
+
+func refIndices(refs []PuzzleRef) []int {
+	indices := make([]int, len(refs))
+	for i, r := range refs {
+		indices[i] = r.Index
+	}
+	return indices
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSearchByTechnique(t *testing.T) {
+	loader := searchBank()
+
+	refs, total, err := loader.Search(SearchQuery{Difficulty: "hard", Technique: "x-wing"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if total != 2 || !equalInts(refIndices(refs), []int{1, 3}) {
+		t.Errorf("x-wing search returned %v (total %d), want indices [1 3]", refIndices(refs), total)
+	}
+	for _, ref := range refs {
+		if ref.Difficulty != "hard" {
+			t.Errorf("ref %d carries difficulty %q, want hard", ref.Index, ref.Difficulty)
+		}
+	}
+
+	// Given-count range narrows the technique matches
+	refs, total, err = loader.Search(SearchQuery{Difficulty: "hard", Technique: "x-wing", MinGivens: 28})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if total != 1 || !equalInts(refIndices(refs), []int{3}) {
+		t.Errorf("ranged x-wing search returned %v (total %d), want [3]", refIndices(refs), total)
+	}
+
+	// Unknown technique matches nothing but is not an error
+	refs, total, err = loader.Search(SearchQuery{Difficulty: "hard", Technique: "no-such-technique"})
+	if err != nil || total != 0 || len(refs) != 0 {
+		t.Errorf("unknown technique returned %v (total %d, err %v), want empty", refs, total, err)
+	}
+}
+
+func TestSearchGivenRangeAndMissingDifficulty(t *testing.T) {
+	loader := searchBank()
+
+	refs, total, err := loader.Search(SearchQuery{Difficulty: "hard", MinGivens: 26, MaxGivens: 28})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if total != 2 || !equalInts(refIndices(refs), []int{1, 2}) {
+		t.Errorf("range search returned %v (total %d), want [1 2]", refIndices(refs), total)
+	}
+	if refs[0].Givens != 26 || refs[1].Givens != 28 {
+		t.Errorf("refs carry given counts %d, %d, want 26, 28", refs[0].Givens, refs[1].Givens)
+	}
+
+	// Puzzle 4 has no hard entry and must never match at hard
+	refs, _, err = loader.Search(SearchQuery{Difficulty: "hard"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for _, ref := range refs {
+		if ref.Index == 4 {
+			t.Error("puzzle without a hard entry matched a hard search")
+		}
+	}
+
+	if _, _, err := loader.Search(SearchQuery{Difficulty: "nightmare"}); err == nil {
+		t.Error("expected an error for an unknown difficulty")
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	loader := searchBank()
+
+	var collected []int
+	for offset := 0; ; offset += 2 {
+		refs, total, err := loader.Search(SearchQuery{Difficulty: "hard", Offset: offset, Limit: 2})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if total != 4 {
+			t.Fatalf("total = %d at offset %d, want stable 4", total, offset)
+		}
+		if len(refs) == 0 {
+			break
+		}
+		if len(refs) > 2 {
+			t.Fatalf("page at offset %d has %d results, want at most 2", offset, len(refs))
+		}
+		collected = append(collected, refIndices(refs)...)
+	}
+	if !equalInts(collected, []int{0, 1, 2, 3}) {
+		t.Errorf("paginated walk collected %v, want [0 1 2 3]", collected)
+	}
+}
+
+func TestSearchWithoutTechniqueData(t *testing.T) {
+	loader := NewLoaderFromPuzzles([]CompactPuzzle{
+		{G: map[string][]int{"h": make([]int, 25)}},
+		{G: map[string][]int{"h": make([]int, 27)}},
+	})
+
+	// Technique filtering is unavailable without proofs
+	if _, _, err := loader.Search(SearchQuery{Difficulty: "hard", Technique: "x-wing"}); !errors.Is(err, ErrNoTechniqueData) {
+		t.Errorf("expected ErrNoTechniqueData, got %v", err)
+	}
+
+	// Non-technique searches still work
+	refs, total, err := loader.Search(SearchQuery{Difficulty: "hard"})
+	if err != nil || total != 2 || len(refs) != 2 {
+		t.Errorf("plain search returned %v (total %d, err %v), want both puzzles", refs, total, err)
+	}
+}
@@ -0,0 +1,180 @@
+// Package verify checks generated puzzle files before they ship: every
+// solution must be a valid complete grid, every difficulty's givens must
+// reproduce that solution uniquely, and the given-index lists must respect
+// the subset property (impossible ⊆ extreme ⊆ hard ⊆ medium ⊆ easy).
+// Optionally the human solver is run to confirm each difficulty stays
+// within its technique tier budget.
+package verify
+
+import (
+	"fmt"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+// Failure describes one check that failed for one puzzle entry.
+type Failure struct {
+	Index      int    `json:"index"`
+	Difficulty string `json:"difficulty,omitempty"`
+	Check      string `json:"check"`
+	Detail     string `json:"detail"`
+}
+
+func (f Failure) String() string {
+	if f.Difficulty != "" {
+		return fmt.Sprintf("puzzle %d [%s] %s: %s", f.Index, f.Difficulty, f.Check, f.Detail)
+	}
+	return fmt.Sprintf("puzzle %d %s: %s", f.Index, f.Check, f.Detail)
+}
+
+// subsetOrder lists difficulty keys from most to fewest givens; each
+// entry's given indices must contain the next entry's. Diabolical is
+// optional per entry, so its pair is only checked when present.
+var subsetOrder = []string{"e", "m", "h", "x", "i", "d"}
+
+// Puzzle runs every check against one entry of the loader and returns the
+// failures found. With analyze set, the human solver additionally verifies
+// that each difficulty completes using techniques within its tier budget.
+func Puzzle(l *puzzles.Loader, index int, analyze bool) []Failure {
+	var failures []Failure
+
+	compact, err := l.GetCompact(index)
+	if err != nil {
+		return append(failures, Failure{Index: index, Check: "entry", Detail: err.Error()})
+	}
+
+	// The solution must be a complete, conflict-free grid. Everything else
+	// is derived from it, so stop here if it is broken.
+	if len(compact.S) != constants.TotalCells {
+		return append(failures, Failure{
+			Index: index, Check: "solution",
+			Detail: fmt.Sprintf("solution has %d characters, want %d", len(compact.S), constants.TotalCells),
+		})
+	}
+	solution := make([]int, constants.TotalCells)
+	for i, c := range compact.S {
+		if c < '1' || c > '9' {
+			return append(failures, Failure{
+				Index: index, Check: "solution",
+				Detail: fmt.Sprintf("cell %d holds %q, want a digit 1-9", i, c),
+			})
+		}
+		solution[i] = int(c - '0')
+	}
+	if !dp.IsValid(solution) {
+		return append(failures, Failure{Index: index, Check: "solution", Detail: "grid has conflicts"})
+	}
+
+	failures = append(failures, checkSubsets(index, compact)...)
+
+	var solver *human.Solver
+	if analyze {
+		solver = human.NewSolver()
+	}
+	for _, spec := range constants.DifficultySpecs() {
+		if _, ok := compact.G[spec.Key]; !ok {
+			// Bank-only technique tiers (diabolical) are best-effort per
+			// seed; absence is expected, not a failure
+			if len(spec.RequireAnyOf) > 0 {
+				continue
+			}
+			failures = append(failures, Failure{
+				Index: index, Difficulty: spec.Name, Check: "givens", Detail: "difficulty missing from entry",
+			})
+			continue
+		}
+		givens, _, err := l.GetPuzzle(index, spec.Name)
+		if err != nil {
+			failures = append(failures, Failure{Index: index, Difficulty: spec.Name, Check: "givens", Detail: err.Error()})
+			continue
+		}
+		if !dp.HasUniqueSolution(givens) {
+			failures = append(failures, Failure{
+				Index: index, Difficulty: spec.Name, Check: "uniqueness",
+				Detail: "givens do not determine the solution uniquely",
+			})
+			continue
+		}
+		if analyze {
+			if f := analyzeDifficulty(solver, givens, spec.Name); f != nil {
+				f.Index = index
+				failures = append(failures, *f)
+			}
+		}
+	}
+
+	return failures
+}
+
+// checkSubsets verifies index ranges and the subset property between
+// adjacent difficulty tiers.
+func checkSubsets(index int, compact puzzles.CompactPuzzle) []Failure {
+	var failures []Failure
+
+	for key, indices := range compact.G {
+		for _, idx := range indices {
+			if idx < 0 || idx >= constants.TotalCells {
+				failures = append(failures, Failure{
+					Index: index, Difficulty: puzzles.KeyToDifficulty[key], Check: "givens",
+					Detail: fmt.Sprintf("given index %d out of range", idx),
+				})
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(subsetOrder); i++ {
+		easierKey, harderKey := subsetOrder[i], subsetOrder[i+1]
+		easier, ok := compact.G[easierKey]
+		if !ok {
+			continue // reported per difficulty by the caller
+		}
+		inEasier := make(map[int]bool, len(easier))
+		for _, idx := range easier {
+			inEasier[idx] = true
+		}
+		for _, idx := range compact.G[harderKey] {
+			if !inEasier[idx] {
+				failures = append(failures, Failure{
+					Index: index, Difficulty: puzzles.KeyToDifficulty[harderKey], Check: "subset",
+					Detail: fmt.Sprintf("given index %d is not in the %s givens", idx, puzzles.KeyToDifficulty[easierKey]),
+				})
+			}
+		}
+	}
+
+	return failures
+}
+
+// analyzeDifficulty runs the human solver and checks the techniques it
+// needed fit the difficulty's tier budget. Returns nil when the puzzle is
+// within budget.
+func analyzeDifficulty(solver *human.Solver, givens []int, difficulty string) *Failure {
+	required, _, status := solver.AnalyzePuzzleDifficulty(givens)
+	if status != constants.StatusCompleted {
+		return &Failure{
+			Difficulty: difficulty, Check: "analyze",
+			Detail: fmt.Sprintf("human solver did not complete: %s", status),
+		}
+	}
+	if difficultyRank(string(required)) > difficultyRank(difficulty) {
+		return &Failure{
+			Difficulty: difficulty, Check: "tier",
+			Detail: fmt.Sprintf("requires %s-level techniques", required),
+		}
+	}
+	return nil
+}
+
+// difficultyRank returns the position of a difficulty in the canonical
+// easy-to-impossible ordering.
+func difficultyRank(difficulty string) int {
+	for i, spec := range constants.DifficultySpecs() {
+		if spec.Name == difficulty {
+			return i
+		}
+	}
+	return len(constants.DifficultySpecs())
+}
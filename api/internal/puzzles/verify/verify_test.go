@@ -0,0 +1,116 @@
+package verify
+
+import (
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// fixtureEntry builds one compact puzzle the same way cmd/generate does, so
+// a fresh entry passes every check before a test breaks it.
+func fixtureEntry(seed int64) puzzles.CompactPuzzle {
+	full := dp.GenerateFullGrid(seed)
+	solStr := make([]byte, constants.TotalCells)
+	for i, v := range full {
+		solStr[i] = byte('0' + v)
+	}
+
+	grids, _ := dp.CarveGivensWithSubset(full, seed)
+	givens := make(map[string][]int)
+	for diff, grid := range grids {
+		var indices []int
+		for i, v := range grid {
+			if v != 0 {
+				indices = append(indices, i)
+			}
+		}
+		givens[puzzles.DifficultyKey[diff]] = indices
+	}
+
+	return puzzles.CompactPuzzle{S: string(solStr), G: givens}
+}
+
+func TestPuzzle_CleanEntry(t *testing.T) {
+	loader := puzzles.NewLoaderFromPuzzles([]puzzles.CompactPuzzle{fixtureEntry(11)})
+
+	failures := Puzzle(loader, 0, false)
+	for _, f := range failures {
+		t.Errorf("unexpected failure: %s", f)
+	}
+}
+
+func TestPuzzle_BrokenSubset(t *testing.T) {
+	entry := fixtureEntry(11)
+	// Drop a medium given from the easy list, so medium ⊄ easy
+	removed := entry.G["m"][0]
+	var easy []int
+	for _, idx := range entry.G["e"] {
+		if idx != removed {
+			easy = append(easy, idx)
+		}
+	}
+	entry.G["e"] = easy
+	loader := puzzles.NewLoaderFromPuzzles([]puzzles.CompactPuzzle{entry})
+
+	failures := Puzzle(loader, 0, false)
+	found := false
+	for _, f := range failures {
+		if f.Check == "subset" && f.Difficulty == "medium" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a subset failure for medium, got %v", failures)
+	}
+}
+
+func TestPuzzle_NonUniquePuzzle(t *testing.T) {
+	entry := fixtureEntry(11)
+	// No givens at all: every complete grid is a solution
+	entry.G["i"] = []int{}
+	loader := puzzles.NewLoaderFromPuzzles([]puzzles.CompactPuzzle{entry})
+
+	failures := Puzzle(loader, 0, false)
+	found := false
+	for _, f := range failures {
+		if f.Check == "uniqueness" && f.Difficulty == "impossible" {
+			found = true
+		}
+		if f.Check == "subset" {
+			t.Errorf("an empty given list must not trip the subset check: %s", f)
+		}
+	}
+	if !found {
+		t.Errorf("expected a uniqueness failure for impossible, got %v", failures)
+	}
+}
+
+func TestPuzzle_CorruptSolution(t *testing.T) {
+	entry := fixtureEntry(11)
+	entry.S = entry.S[:40] + "0" + entry.S[41:]
+	loader := puzzles.NewLoaderFromPuzzles([]puzzles.CompactPuzzle{entry})
+
+	failures := Puzzle(loader, 0, false)
+	if len(failures) != 1 || failures[0].Check != "solution" {
+		t.Errorf("expected a single solution failure, got %v", failures)
+	}
+}
+
+func TestPuzzle_MissingDifficulty(t *testing.T) {
+	entry := fixtureEntry(11)
+	delete(entry.G, "x")
+	loader := puzzles.NewLoaderFromPuzzles([]puzzles.CompactPuzzle{entry})
+
+	failures := Puzzle(loader, 0, false)
+	found := false
+	for _, f := range failures {
+		if f.Check == "givens" && f.Difficulty == "extreme" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a givens failure for extreme, got %v", failures)
+	}
+}
@@ -0,0 +1,45 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"sudoku-api/pkg/constants"
+)
+
+// BoardASCII renders the cells as a fixed-width text grid for printable
+// exports, with "." for empty cells and box borders drawn every BoxSize rows
+// and columns:
+//
+//	+-------+-------+-------+
+//	| 5 3 . | . 7 . | . . . |
+//	| 6 . . | 1 9 5 | . . . |
+//	...
+func BoardASCII(cells []int) string {
+	segment := strings.Repeat("-", 2*constants.BoxSize+1)
+	border := "+" + strings.Repeat(segment+"+", constants.GridSize/constants.BoxSize)
+
+	var sb strings.Builder
+	for row := 0; row < constants.GridSize; row++ {
+		if row%constants.BoxSize == 0 {
+			sb.WriteString(border)
+			sb.WriteByte('\n')
+		}
+		for col := 0; col < constants.GridSize; col++ {
+			if col%constants.BoxSize == 0 {
+				sb.WriteString("| ")
+			}
+			idx := row*constants.GridSize + col
+			if idx < len(cells) && cells[idx] != 0 {
+				sb.WriteString(strconv.Itoa(cells[idx]))
+			} else {
+				sb.WriteByte('.')
+			}
+			sb.WriteByte(' ')
+		}
+		sb.WriteString("|\n")
+	}
+	sb.WriteString(border)
+	sb.WriteByte('\n')
+	return sb.String()
+}
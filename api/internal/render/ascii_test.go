@@ -0,0 +1,44 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBoardASCIIMatchesGolden(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("testdata", "board_golden.txt"))
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	got := BoardASCII(goldenGivens())
+	if got != string(want) {
+		t.Errorf("ASCII output diverged from golden file\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBoardASCIIShape(t *testing.T) {
+	givens := goldenGivens()
+	empty := 0
+	for _, v := range givens {
+		if v == 0 {
+			empty++
+		}
+	}
+
+	ascii := BoardASCII(givens)
+	// 9 digit rows plus 4 border rows, every line the same width
+	lines := strings.Split(strings.TrimRight(ascii, "\n"), "\n")
+	if len(lines) != 13 {
+		t.Fatalf("got %d lines, want 13", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			t.Errorf("line %d has width %d, want %d", i, len(line), len(lines[0]))
+		}
+	}
+	if n := strings.Count(ascii, "."); n != empty {
+		t.Errorf("got %d placeholder dots, want %d (one per empty cell)", n, empty)
+	}
+}
@@ -0,0 +1,109 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"sudoku-api/pkg/constants"
+)
+
+// BookPageGrids is how many grids a PDF book page holds, laid out two by two.
+const BookPageGrids = 4
+
+// BookEntry is one puzzle of an exported book, already resolved to its givens
+// and solution. Number keys the puzzle to its solution in the back section.
+type BookEntry struct {
+	Number     int
+	Seed       string
+	Difficulty string
+	Techniques []string
+	Givens     []int
+	Solution   []int
+}
+
+// entryCaption renders the metadata line shown with each puzzle grid
+func entryCaption(e BookEntry) string {
+	return fmt.Sprintf("Puzzle %d - seed %s (%s)", e.Number, e.Seed, e.Difficulty)
+}
+
+// BookText assembles a plain-text puzzle book: every puzzle as an ASCII grid
+// under a metadata caption, then a solutions section keyed by puzzle number.
+func BookText(title string, entries []BookEntry) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "## %s\n\n", entryCaption(e))
+		if len(e.Techniques) > 0 {
+			fmt.Fprintf(&sb, "Techniques: %s\n\n", strings.Join(e.Techniques, ", "))
+		}
+		sb.WriteString(BoardASCII(e.Givens))
+		sb.WriteByte('\n')
+	}
+	sb.WriteString("## Solutions\n\n")
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "### Solution %d\n\n", e.Number)
+		sb.WriteString(BoardASCII(e.Solution))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// BookPDF assembles the same book as a minimal PDF: BookPageGrids puzzles per
+// page, then the solutions in a back section, each grid captioned with its
+// number and metadata.
+func BookPDF(title string, entries []BookEntry) []byte {
+	doc := &pdfDoc{}
+	font := doc.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+
+	streams := append(bookPages(title, entries, false), bookPages("Solutions", entries, true)...)
+	// Content/page object pairs follow the font object, so the page tree and
+	// catalog land at fixed numbers we can reference before adding them
+	pagesObj := font + 2*len(streams) + 1
+	kids := make([]string, len(streams))
+	for i, stream := range streams {
+		content := doc.add(pdfStream(stream))
+		page := doc.add(fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pagesObj, pdfPageWidth, pdfPageHeight, font, content))
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	doc.add(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(streams)))
+	catalog := doc.add(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	return doc.render(catalog)
+}
+
+// Book page geometry: two 216pt grids per row with captions underneath.
+const (
+	bookGridCell   = 24.0
+	bookGridSize   = bookGridCell * constants.GridSize
+	bookMarginLeft = 54.0
+	bookColumnStep = 276.0
+	bookFirstTop   = pdfPageHeight - 70.0
+	bookRowStep    = 330.0
+)
+
+// bookPages lays entries out BookPageGrids to a page under a heading,
+// rendering givens for the puzzle section and solutions for the back section
+func bookPages(heading string, entries []BookEntry, solutions bool) []string {
+	var pages []string
+	for start := 0; start < len(entries); start += BookPageGrids {
+		var sb strings.Builder
+		pdfText(&sb, bookMarginLeft, pdfPageHeight-40, 14, heading)
+		for i, e := range entries[start:min(start+BookPageGrids, len(entries))] {
+			x := bookMarginLeft + float64(i%2)*bookColumnStep
+			top := bookFirstTop - float64(i/2)*bookRowStep
+			if solutions {
+				pdfGrid(&sb, x, top, bookGridCell, e.Solution)
+				pdfText(&sb, x, top-bookGridSize-12, 8, fmt.Sprintf("Solution %d", e.Number))
+				continue
+			}
+			pdfGrid(&sb, x, top, bookGridCell, e.Givens)
+			pdfText(&sb, x, top-bookGridSize-12, 8, entryCaption(e))
+			if len(e.Techniques) > 0 {
+				pdfText(&sb, x, top-bookGridSize-22, 7, "Techniques: "+strings.Join(e.Techniques, ", "))
+			}
+		}
+		pages = append(pages, sb.String())
+	}
+	return pages
+}
@@ -0,0 +1,104 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+)
+
+// bookFixture carves n deterministic medium puzzles with their solutions
+func bookFixture(n int) []BookEntry {
+	entries := make([]BookEntry, n)
+	for i := range entries {
+		seed := int64(100 + i)
+		full := dp.GenerateFullGrid(seed)
+		puzzles, _ := dp.CarveGivensWithSubset(full, seed)
+		entries[i] = BookEntry{
+			Number:     i + 1,
+			Seed:       strconv.FormatInt(seed, 10),
+			Difficulty: "medium",
+			Techniques: []string{"naked-single", "hidden-single"},
+			Givens:     puzzles["medium"],
+			Solution:   full,
+		}
+	}
+	return entries
+}
+
+func TestBookTextPairsPuzzlesWithSolutions(t *testing.T) {
+	entries := bookFixture(3)
+	book := BookText("Club Book", entries)
+
+	front, back, found := strings.Cut(book, "## Solutions")
+	if !found {
+		t.Fatal("book has no solutions section")
+	}
+	if !strings.HasPrefix(book, "# Club Book\n") {
+		t.Error("book does not open with the title")
+	}
+
+	for _, e := range entries {
+		caption := fmt.Sprintf("## Puzzle %d - seed %s (medium)", e.Number, e.Seed)
+		if !strings.Contains(front, caption) {
+			t.Errorf("front section is missing %q", caption)
+		}
+		if !strings.Contains(front, BoardASCII(e.Givens)) {
+			t.Errorf("front section is missing the grid for puzzle %d", e.Number)
+		}
+		if strings.Contains(front, BoardASCII(e.Solution)) {
+			t.Errorf("front section leaks the solution for puzzle %d", e.Number)
+		}
+
+		// The solution grid must directly follow its numbered heading
+		heading := fmt.Sprintf("### Solution %d\n\n", e.Number)
+		idx := strings.Index(back, heading)
+		if idx < 0 {
+			t.Fatalf("solutions section is missing %q", heading)
+		}
+		if !strings.HasPrefix(back[idx+len(heading):], BoardASCII(e.Solution)) {
+			t.Errorf("solution %d is not paired with its own grid", e.Number)
+		}
+	}
+	if !strings.Contains(front, "Techniques: naked-single, hidden-single") {
+		t.Error("front section is missing the technique captions")
+	}
+}
+
+func TestBookPDFStructure(t *testing.T) {
+	// 5 puzzles = 2 puzzle pages + 2 solution pages at 4 grids per page
+	pdf := BookPDF("Club Book", bookFixture(5))
+	const wantPages = 4
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.")) {
+		t.Fatal("output does not start with a PDF header")
+	}
+	if !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("output does not end with the EOF marker")
+	}
+
+	if n := bytes.Count(pdf, []byte("/Type /Page /Parent")); n != wantPages {
+		t.Errorf("got %d page objects, want %d", n, wantPages)
+	}
+	if !bytes.Contains(pdf, []byte(fmt.Sprintf("/Count %d", wantPages))) {
+		t.Errorf("page tree does not declare %d pages", wantPages)
+	}
+
+	// startxref must point at the xref table
+	marker := []byte("startxref\n")
+	idx := bytes.LastIndex(pdf, marker)
+	if idx < 0 {
+		t.Fatal("output has no startxref")
+	}
+	offsetStr := string(bytes.TrimSpace(bytes.Split(pdf[idx+len(marker):], []byte("\n"))[0]))
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		t.Fatalf("startxref offset %q is not a number", offsetStr)
+	}
+	if offset <= 0 || offset >= len(pdf) || !bytes.HasPrefix(pdf[offset:], []byte("xref")) {
+		t.Errorf("startxref offset %d does not point at the xref table", offset)
+	}
+}
@@ -0,0 +1,95 @@
+package render
+
+// Minimal PDF generation: just enough of the format (Type1 text, line
+// primitives, uncompressed content streams, a hand-built xref table) to print
+// puzzle books without pulling in an external dependency.
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sudoku-api/pkg/constants"
+)
+
+// US Letter page geometry, in points.
+const (
+	pdfPageWidth  = 612
+	pdfPageHeight = 792
+)
+
+// pdfDoc accumulates numbered objects and renders the xref table and trailer
+// at the end. Object numbers are 1-based in the order added.
+type pdfDoc struct {
+	objects []string
+}
+
+// add appends one object body and returns its object number
+func (d *pdfDoc) add(body string) int {
+	d.objects = append(d.objects, body)
+	return len(d.objects)
+}
+
+// render assembles the final file around the catalog object
+func (d *pdfDoc) render(catalog int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(d.objects))
+	for i, body := range d.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(d.objects)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(d.objects)+1, catalog, xref)
+	return buf.Bytes()
+}
+
+// pdfStream wraps a content stream body with its length dictionary
+func pdfStream(content string) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+}
+
+// pdfEscape escapes the characters with meaning inside PDF string literals
+func pdfEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// pdfText emits one text show operation with its baseline at (x, y)
+func pdfText(sb *strings.Builder, x, y float64, size int, text string) {
+	fmt.Fprintf(sb, "BT /F1 %d Tf %.1f %.1f Td (%s) Tj ET\n", size, x, y, pdfEscape(text))
+}
+
+// pdfGrid draws one puzzle grid with its top-left corner at (x, top), digits
+// centered in their cells and box borders thicker than cell borders. PDF's
+// y axis points up, so rows grow downward from top.
+func pdfGrid(sb *strings.Builder, x, top, cell float64, cells []int) {
+	size := cell * float64(constants.GridSize)
+	for i := 0; i <= constants.GridSize; i++ {
+		width := 0.5
+		if i%constants.BoxSize == 0 {
+			width = 1.5
+		}
+		offset := float64(i) * cell
+		fmt.Fprintf(sb, "%.1f w %.1f %.1f m %.1f %.1f l S\n", width, x+offset, top, x+offset, top-size)
+		fmt.Fprintf(sb, "%.1f w %.1f %.1f m %.1f %.1f l S\n", width, x, top-offset, x+size, top-offset)
+	}
+
+	fontSize := int(cell * 0.6)
+	for idx, v := range cells {
+		if idx >= constants.TotalCells || v == 0 {
+			continue
+		}
+		row, col := idx/constants.GridSize, idx%constants.GridSize
+		// Centered via a digit-width nudge left and a baseline nudge down
+		cx := x + float64(col)*cell + cell/2 - float64(fontSize)*0.28
+		cy := top - float64(row)*cell - cell/2 - float64(fontSize)*0.35
+		pdfText(sb, cx, cy, fontSize, strconv.Itoa(v))
+	}
+}
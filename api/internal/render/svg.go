@@ -0,0 +1,127 @@
+// Package render draws sudoku boards as SVG, dependency-free, for share-link
+// thumbnails and other previews. The markup is built by hand so the package
+// stays small and the output stays byte-stable for caching.
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// Geometry of the rendered board. Cell size drives everything else so a
+// different thumbnail size only needs one change.
+const (
+	cellSize  = 40
+	boardSize = cellSize * constants.GridSize
+	margin    = 4
+	imageSize = boardSize + 2*margin
+)
+
+// Colors. Givens are near-black, solved-in digits a muted blue, and the
+// highlight fills match the frontend's primary/secondary scheme.
+const (
+	colorBackground = "#ffffff"
+	colorGridLine   = "#c0c0c0"
+	colorBoxLine    = "#333333"
+	colorGiven      = "#1a1a1a"
+	colorSolved     = "#3a6ea5"
+	colorPrimary    = "#ffd54f"
+	colorSecondary  = "#e3f2fd"
+)
+
+// Options controls the optional mid-solve state layered on top of the givens.
+type Options struct {
+	// CurrentBoard, when 81 cells long, supplies digits solved since the
+	// start; cells that differ from the givens render in the solved color.
+	CurrentBoard []int
+	// Highlights colors cell backgrounds (primary over secondary), matching
+	// Move.Highlights so a solve step can be previewed directly.
+	Highlights *core.Highlights
+}
+
+// BoardSVG renders the givens as a standalone SVG image.
+func BoardSVG(givens []int) string {
+	return BoardSVGWithOptions(givens, Options{})
+}
+
+// BoardSVGWithOptions renders the givens plus any mid-solve state from opts.
+func BoardSVGWithOptions(givens []int, opts Options) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d">`,
+		imageSize, imageSize, imageSize, imageSize)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="%s"/>`, imageSize, imageSize, colorBackground)
+
+	writeHighlights(&sb, opts.Highlights)
+	writeDigits(&sb, givens, opts.CurrentBoard)
+	writeGridLines(&sb)
+
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// writeHighlights fills highlighted cells before the grid lines are drawn so
+// the lines stay visible on top. Secondary goes first so primary wins where
+// they overlap.
+func writeHighlights(sb *strings.Builder, hl *core.Highlights) {
+	if hl == nil {
+		return
+	}
+	for _, ref := range hl.Secondary {
+		writeCellFill(sb, ref, colorSecondary)
+	}
+	for _, ref := range hl.Primary {
+		writeCellFill(sb, ref, colorPrimary)
+	}
+}
+
+func writeCellFill(sb *strings.Builder, ref core.CellRef, color string) {
+	if ref.Row < 0 || ref.Row >= constants.GridSize || ref.Col < 0 || ref.Col >= constants.GridSize {
+		return
+	}
+	fmt.Fprintf(sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+		margin+ref.Col*cellSize, margin+ref.Row*cellSize, cellSize, cellSize, color)
+}
+
+// writeDigits emits one <text> element per filled cell. The current board,
+// when present, takes precedence; digits it adds beyond the givens use the
+// solved color so shared mid-solve boards read naturally.
+func writeDigits(sb *strings.Builder, givens, current []int) {
+	for idx := 0; idx < constants.TotalCells && idx < len(givens); idx++ {
+		digit := givens[idx]
+		color := colorGiven
+		if len(current) == constants.TotalCells && current[idx] != 0 {
+			if digit == 0 {
+				color = colorSolved
+			}
+			digit = current[idx]
+		}
+		if digit == 0 {
+			continue
+		}
+		row, col := idx/constants.GridSize, idx%constants.GridSize
+		// Centered via text-anchor plus a baseline nudge of cellSize*0.36
+		x := margin + col*cellSize + cellSize/2
+		y := margin + row*cellSize + cellSize/2 + (cellSize*36)/100
+		fmt.Fprintf(sb, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" text-anchor="middle" fill="%s">%d</text>`,
+			x, y, (cellSize*60)/100, color, digit)
+	}
+}
+
+// writeGridLines draws the 10 horizontal and 10 vertical lines, with box
+// borders thicker than cell borders.
+func writeGridLines(sb *strings.Builder) {
+	for i := 0; i <= constants.GridSize; i++ {
+		color, width := colorGridLine, 1
+		if i%constants.BoxSize == 0 {
+			color, width = colorBoxLine, 3
+		}
+		pos := margin + i*cellSize
+		fmt.Fprintf(sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d"/>`,
+			pos, margin, pos, margin+boardSize, color, width)
+		fmt.Fprintf(sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d"/>`,
+			margin, pos, margin+boardSize, pos, color, width)
+	}
+}
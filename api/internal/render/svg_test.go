@@ -0,0 +1,89 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+)
+
+// goldenSeed pins the board used for the golden comparison. The generator is
+// deterministic, so the rendered SVG must be byte-identical across runs.
+const goldenSeed = int64(42)
+
+func goldenGivens() []int {
+	full := dp.GenerateFullGrid(goldenSeed)
+	puzzles, _ := dp.CarveGivensWithSubset(full, goldenSeed)
+	return puzzles["hard"]
+}
+
+func TestBoardSVGMatchesGolden(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("testdata", "thumbnail_golden.svg"))
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	got := BoardSVG(goldenGivens())
+	if got != string(want) {
+		t.Errorf("SVG output diverged from golden file\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestBoardSVGDigitCount(t *testing.T) {
+	givens := goldenGivens()
+	filled := 0
+	for _, v := range givens {
+		if v != 0 {
+			filled++
+		}
+	}
+	svg := BoardSVG(givens)
+	if n := strings.Count(svg, "<text"); n != filled {
+		t.Errorf("SVG has %d <text> elements, want %d (one per given)", n, filled)
+	}
+}
+
+func TestBoardSVGWithOptions(t *testing.T) {
+	givens := goldenGivens()
+
+	// Fill one empty cell on the current board; it should render in the
+	// solved color, not the given color
+	current := make([]int, len(givens))
+	copy(current, givens)
+	empty := -1
+	for i, v := range givens {
+		if v == 0 {
+			empty = i
+			break
+		}
+	}
+	if empty == -1 {
+		t.Fatal("expected at least one empty cell")
+	}
+	current[empty] = 5
+
+	svg := BoardSVGWithOptions(givens, Options{
+		CurrentBoard: current,
+		Highlights: &core.Highlights{
+			Primary:   []core.CellRef{{Row: 0, Col: 0}},
+			Secondary: []core.CellRef{{Row: 0, Col: 1}, {Row: 0, Col: 2}},
+		},
+	})
+
+	if !strings.Contains(svg, colorSolved) {
+		t.Error("expected solved-in digit to use the solved color")
+	}
+	if got, want := strings.Count(svg, colorPrimary), 1; got != want {
+		t.Errorf("got %d primary highlight fills, want %d", got, want)
+	}
+	if got, want := strings.Count(svg, colorSecondary), 2; got != want {
+		t.Errorf("got %d secondary highlight fills, want %d", got, want)
+	}
+
+	base := BoardSVG(givens)
+	if n, m := strings.Count(svg, "<text"), strings.Count(base, "<text"); n != m+1 {
+		t.Errorf("current board added %d <text> elements, want 1", n-m)
+	}
+}
@@ -0,0 +1,104 @@
+// Package state persists in-progress game state so players can resume a
+// session from another device. Storage sits behind a small Store interface;
+// the default implementation is an in-memory map with a TTL, and a database
+// or Redis-backed store can be plugged in later without touching handlers.
+package state
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"sudoku-api/internal/core"
+)
+
+// SavedGame is one snapshot of a player's progress. History entries are kept
+// as raw JSON because their shape belongs to the frontend; the server only
+// bounds and round-trips them. Annotations are validated on save but
+// otherwise pass through untouched, like history.
+type SavedGame struct {
+	CurrentBoard   []int                 `json:"current_board"`
+	Candidates     [][]int               `json:"candidates,omitempty"`
+	Annotations    []core.CellAnnotation `json:"annotations,omitempty"`
+	History        []json.RawMessage     `json:"history,omitempty"`
+	ElapsedSeconds int                   `json:"elapsed_seconds"`
+	SavedAt        time.Time             `json:"saved_at"`
+}
+
+// Store persists saved games keyed by an opaque session key
+type Store interface {
+	Save(key string, game SavedGame)
+	Load(key string) (SavedGame, bool)
+}
+
+// Clock abstracts time so expiry can be tested without sleeping
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the production Clock backed by time.Now
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// memoryEntry pairs a saved game with its expiry deadline
+type memoryEntry struct {
+	game      SavedGame
+	expiresAt time.Time
+}
+
+// MemoryStore is the default Store: an in-memory map whose entries expire
+// after a fixed TTL. Expired entries are dropped lazily on Load and swept
+// opportunistically on Save.
+type MemoryStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	clock   Clock
+	entries map[string]memoryEntry
+}
+
+// NewMemoryStore creates a store whose entries expire after ttl. Pass a nil
+// clock to use the system clock.
+func NewMemoryStore(ttl time.Duration, clock Clock) *MemoryStore {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &MemoryStore{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Save stores the game under key, replacing any previous snapshot
+func (s *MemoryStore) Save(key string, game SavedGame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	// Sweep expired entries so abandoned sessions don't accumulate
+	for k, e := range s.entries {
+		if now.After(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = memoryEntry{game: game, expiresAt: now.Add(s.ttl)}
+}
+
+// Load returns the saved game for key, or false when none exists or the
+// entry has expired
+func (s *MemoryStore) Load(key string) (SavedGame, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return SavedGame{}, false
+	}
+	if s.clock.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return SavedGame{}, false
+	}
+	return entry.game, true
+}
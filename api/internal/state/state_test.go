@@ -0,0 +1,111 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock for expiry tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+func (f *fakeClock) advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func TestMemoryStore_SaveLoadRoundTrip(t *testing.T) {
+	store := NewMemoryStore(time.Hour, nil)
+
+	board := make([]int, 81)
+	board[0] = 5
+	game := SavedGame{
+		CurrentBoard:   board,
+		ElapsedSeconds: 120,
+		SavedAt:        time.Now(),
+	}
+	store.Save("token-a", game)
+
+	loaded, ok := store.Load("token-a")
+	if !ok {
+		t.Fatal("Expected saved game to load")
+	}
+	if loaded.CurrentBoard[0] != 5 {
+		t.Errorf("Expected board cell 0 = 5, got %d", loaded.CurrentBoard[0])
+	}
+	if loaded.ElapsedSeconds != 120 {
+		t.Errorf("Expected elapsed 120, got %d", loaded.ElapsedSeconds)
+	}
+
+	if _, ok := store.Load("token-b"); ok {
+		t.Error("Expected miss for unknown key")
+	}
+}
+
+func TestMemoryStore_SaveOverwrites(t *testing.T) {
+	store := NewMemoryStore(time.Hour, nil)
+
+	store.Save("token-a", SavedGame{ElapsedSeconds: 10})
+	store.Save("token-a", SavedGame{ElapsedSeconds: 20})
+
+	loaded, ok := store.Load("token-a")
+	if !ok {
+		t.Fatal("Expected saved game to load")
+	}
+	if loaded.ElapsedSeconds != 20 {
+		t.Errorf("Expected latest save to win, got elapsed %d", loaded.ElapsedSeconds)
+	}
+}
+
+func TestMemoryStore_EntriesExpire(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	store := NewMemoryStore(time.Hour, clock)
+
+	store.Save("token-a", SavedGame{ElapsedSeconds: 10})
+
+	clock.advance(59 * time.Minute)
+	if _, ok := store.Load("token-a"); !ok {
+		t.Error("Expected entry to survive within TTL")
+	}
+
+	clock.advance(2 * time.Minute)
+	if _, ok := store.Load("token-a"); ok {
+		t.Error("Expected entry to expire after TTL")
+	}
+}
+
+func TestMemoryStore_SaveRefreshesTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	store := NewMemoryStore(time.Hour, clock)
+
+	store.Save("token-a", SavedGame{ElapsedSeconds: 10})
+	clock.advance(45 * time.Minute)
+	store.Save("token-a", SavedGame{ElapsedSeconds: 20})
+
+	clock.advance(45 * time.Minute)
+	loaded, ok := store.Load("token-a")
+	if !ok {
+		t.Fatal("Expected re-save to refresh the TTL")
+	}
+	if loaded.ElapsedSeconds != 20 {
+		t.Errorf("Expected latest save, got elapsed %d", loaded.ElapsedSeconds)
+	}
+}
+
+func TestMemoryStore_SaveSweepsExpired(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	store := NewMemoryStore(time.Hour, clock)
+
+	store.Save("token-a", SavedGame{})
+	clock.advance(2 * time.Hour)
+	store.Save("token-b", SavedGame{})
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if _, ok := store.entries["token-a"]; ok {
+		t.Error("Expected expired entry to be swept on Save")
+	}
+	if _, ok := store.entries["token-b"]; !ok {
+		t.Error("Expected fresh entry to remain")
+	}
+}
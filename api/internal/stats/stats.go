@@ -0,0 +1,244 @@
+// Package stats aggregates per-puzzle play statistics — completion rate,
+// median solve time, hint usage — for the "73% of players solved this"
+// surface. Recording sits behind a small Recorder interface; the default
+// implementation is an in-memory store with periodic JSON snapshots to disk,
+// and a database-backed recorder can be plugged in later without touching
+// handlers.
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MinSample is the play count below which GetPuzzleStats withholds
+// aggregates: a completion rate over three sessions is noise, not signal
+const MinSample = 5
+
+// SnapshotInterval is how often the background snapshot loop writes the
+// store to disk
+const SnapshotInterval = 5 * time.Minute
+
+// maxTimes caps the per-puzzle solve-time sample. The median stabilizes long
+// before this; without a cap a popular puzzle grows its slice forever.
+const maxTimes = 10000
+
+// PuzzleStats is the aggregate served for one (seed, difficulty) pair
+type PuzzleStats struct {
+	Plays          int     `json:"plays"`
+	Completions    int     `json:"completions"`
+	CompletionRate float64 `json:"completion_rate"`
+	// MedianTimeSeconds is the median over completed solves only; zero when
+	// nothing has completed
+	MedianTimeSeconds int     `json:"median_time_seconds"`
+	AvgHints          float64 `json:"avg_hints"`
+}
+
+// Recorder collects play events keyed by puzzle and session. The session key
+// dedupes: a player who saves five times and then completes is one play, and
+// a completion supersedes the abandon their earlier saves implied.
+type Recorder interface {
+	// RecordCompletion marks the session's play completed with its solve
+	// time and total hint count
+	RecordCompletion(seed, difficulty, session string, elapsedSeconds, hints int)
+	// RecordAbandon registers the session as played but not (yet)
+	// completed; a later RecordCompletion for the same session upgrades it
+	RecordAbandon(seed, difficulty, session string)
+	// RecordHint counts one hint against the puzzle and registers the
+	// session as a play if nothing else has yet
+	RecordHint(seed, difficulty, session string)
+	// GetPuzzleStats returns the aggregates, or false while the puzzle has
+	// fewer than MinSample plays
+	GetPuzzleStats(seed, difficulty string) (PuzzleStats, bool)
+}
+
+// puzzleRecord is the raw per-puzzle tally. Fields are exported only for the
+// JSON snapshot; all access goes through MemoryStore's mutex.
+type puzzleRecord struct {
+	// Sessions maps session key to whether that play completed
+	Sessions map[string]bool `json:"sessions"`
+	// Times holds elapsed seconds of completed solves, insertion order
+	Times []int `json:"times,omitempty"`
+	// Hints is the total hint count across all sessions
+	Hints int `json:"hints,omitempty"`
+}
+
+// MemoryStore is the default Recorder: one map guarded by a mutex. The
+// per-event work is a couple of map writes, so a single lock outlasts any
+// realistic handler concurrency; snapshot marshalling happens under the same
+// lock but on a background cadence.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*puzzleRecord
+}
+
+// NewMemoryStore creates an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*puzzleRecord)}
+}
+
+// statsKey joins seed and difficulty; the separator cannot appear in either
+func statsKey(seed, difficulty string) string { return seed + "|" + difficulty }
+
+// record returns the puzzle's tally, creating it on first touch. Callers
+// hold the lock.
+func (s *MemoryStore) record(seed, difficulty string) *puzzleRecord {
+	key := statsKey(seed, difficulty)
+	r, ok := s.records[key]
+	if !ok {
+		r = &puzzleRecord{Sessions: make(map[string]bool)}
+		s.records[key] = r
+	}
+	return r
+}
+
+// RecordCompletion marks the session completed, keeping its solve time. A
+// repeat completion for the same session is ignored — the session endpoints
+// already reject replays, but the stats must not trust that.
+func (s *MemoryStore) RecordCompletion(seed, difficulty, session string, elapsedSeconds, hints int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.record(seed, difficulty)
+	if r.Sessions[session] {
+		return
+	}
+	r.Sessions[session] = true
+	if len(r.Times) < maxTimes {
+		r.Times = append(r.Times, elapsedSeconds)
+	}
+	r.Hints += hints
+}
+
+// RecordAbandon registers the session as an uncompleted play. Completed
+// sessions stay completed.
+func (s *MemoryStore) RecordAbandon(seed, difficulty, session string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.record(seed, difficulty)
+	if _, seen := r.Sessions[session]; !seen {
+		r.Sessions[session] = false
+	}
+}
+
+// RecordHint counts one hint and registers the session as a play
+func (s *MemoryStore) RecordHint(seed, difficulty, session string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.record(seed, difficulty)
+	if _, seen := r.Sessions[session]; !seen {
+		r.Sessions[session] = false
+	}
+	r.Hints++
+}
+
+// GetPuzzleStats aggregates the tally, or reports false below MinSample
+func (s *MemoryStore) GetPuzzleStats(seed, difficulty string) (PuzzleStats, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[statsKey(seed, difficulty)]
+	if !ok || len(r.Sessions) < MinSample {
+		return PuzzleStats{}, false
+	}
+
+	stats := PuzzleStats{Plays: len(r.Sessions)}
+	for _, completed := range r.Sessions {
+		if completed {
+			stats.Completions++
+		}
+	}
+	stats.CompletionRate = float64(stats.Completions) / float64(stats.Plays)
+	stats.MedianTimeSeconds = median(r.Times)
+	stats.AvgHints = float64(r.Hints) / float64(stats.Plays)
+	return stats, true
+}
+
+// median returns the median of times, averaging the middle pair for even
+// sample sizes, or zero for an empty sample
+func median(times []int) int {
+	if len(times) == 0 {
+		return 0
+	}
+	sorted := make([]int, len(times))
+	copy(sorted, times)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// WriteSnapshot serializes the store to path atomically: the JSON lands in a
+// temp file first so a crash mid-write cannot truncate the previous snapshot
+func (s *MemoryStore) WriteSnapshot(path string) error {
+	s.mu.Lock()
+	data, err := json.Marshal(s.records)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadSnapshot replaces the store's contents with the snapshot at path.
+// A missing file is returned as-is so callers can treat first boot
+// (os.IsNotExist) differently from a corrupt snapshot.
+func (s *MemoryStore) ReadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	records := make(map[string]*puzzleRecord)
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+	// Snapshots written before any session was seen may hold null maps
+	for _, r := range records {
+		if r.Sessions == nil {
+			r.Sessions = make(map[string]bool)
+		}
+	}
+
+	s.mu.Lock()
+	s.records = records
+	s.mu.Unlock()
+	return nil
+}
+
+// StartSnapshotting writes the store to path every SnapshotInterval until
+// the returned stop function is called. A final snapshot is written on stop
+// so a clean shutdown never loses the tail.
+func (s *MemoryStore) StartSnapshotting(path string, onError func(error)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(SnapshotInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.WriteSnapshot(path); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				if err := s.WriteSnapshot(path); err != nil && onError != nil {
+					onError(err)
+				}
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
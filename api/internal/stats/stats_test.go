@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAggregatesFromMixedEvents(t *testing.T) {
+	s := NewMemoryStore()
+
+	// Six sessions: four complete, one saves and walks away, one only takes
+	// hints. Session c2 saves before completing, which must not double-count.
+	s.RecordAbandon("seed-1", "hard", "c2")
+	for i, secs := range []int{300, 420, 360, 900} {
+		s.RecordCompletion("seed-1", "hard", fmt.Sprintf("c%d", i+1), secs, i%2)
+	}
+	s.RecordAbandon("seed-1", "hard", "quitter")
+	s.RecordHint("seed-1", "hard", "hinter")
+	s.RecordHint("seed-1", "hard", "hinter")
+
+	got, ok := s.GetPuzzleStats("seed-1", "hard")
+	if !ok {
+		t.Fatal("GetPuzzleStats reported insufficient data for 6 plays")
+	}
+	if got.Plays != 6 || got.Completions != 4 {
+		t.Errorf("plays/completions = %d/%d, want 6/4", got.Plays, got.Completions)
+	}
+	if got.CompletionRate != 4.0/6.0 {
+		t.Errorf("CompletionRate = %v, want %v", got.CompletionRate, 4.0/6.0)
+	}
+	// Even sample: median of 300, 360, 420, 900 averages the middle pair
+	if got.MedianTimeSeconds != 390 {
+		t.Errorf("MedianTimeSeconds = %d, want 390", got.MedianTimeSeconds)
+	}
+	// Two completion hints (c2, c4) plus two live hints over six plays
+	if got.AvgHints != 4.0/6.0 {
+		t.Errorf("AvgHints = %v, want %v", got.AvgHints, 4.0/6.0)
+	}
+
+	// A repeat completion for an already-completed session changes nothing
+	s.RecordCompletion("seed-1", "hard", "c1", 10, 50)
+	again, _ := s.GetPuzzleStats("seed-1", "hard")
+	if again != got {
+		t.Errorf("replayed completion changed stats: %+v -> %+v", got, again)
+	}
+}
+
+func TestGetPuzzleStatsBelowMinSample(t *testing.T) {
+	s := NewMemoryStore()
+	if _, ok := s.GetPuzzleStats("never-played", "easy"); ok {
+		t.Error("unknown puzzle reported stats")
+	}
+
+	for i := 0; i < MinSample-1; i++ {
+		s.RecordCompletion("seed-2", "easy", fmt.Sprintf("s%d", i), 100, 0)
+	}
+	if _, ok := s.GetPuzzleStats("seed-2", "easy"); ok {
+		t.Errorf("%d plays reported stats, threshold is %d", MinSample-1, MinSample)
+	}
+
+	s.RecordCompletion("seed-2", "easy", "one-more", 100, 0)
+	if _, ok := s.GetPuzzleStats("seed-2", "easy"); !ok {
+		t.Errorf("%d plays still reported insufficient data", MinSample)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+
+	s := NewMemoryStore()
+	for i := 0; i < MinSample; i++ {
+		s.RecordCompletion("seed-3", "medium", fmt.Sprintf("s%d", i), 200+i, 1)
+	}
+	s.RecordAbandon("seed-3", "medium", "quitter")
+	want, _ := s.GetPuzzleStats("seed-3", "medium")
+
+	if err := s.WriteSnapshot(path); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	restored := NewMemoryStore()
+	if err := restored.ReadSnapshot(path); err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+	got, ok := restored.GetPuzzleStats("seed-3", "medium")
+	if !ok || got != want {
+		t.Errorf("restored stats = %+v (ok=%v), want %+v", got, ok, want)
+	}
+
+	// Session dedupe must survive the round trip, not just the aggregates
+	restored.RecordCompletion("seed-3", "medium", "s0", 1, 9)
+	if again, _ := restored.GetPuzzleStats("seed-3", "medium"); again != want {
+		t.Errorf("replayed completion after restore changed stats: %+v", again)
+	}
+
+	if err := restored.ReadSnapshot(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("ReadSnapshot of missing file = %v, want IsNotExist", err)
+	}
+}
+
+// The handlers feeding the store run in parallel; this test only proves its
+// worth under go test -race, where any unguarded access fails the build
+func TestConcurrentRecording(t *testing.T) {
+	s := NewMemoryStore()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				session := fmt.Sprintf("g%d-s%d", g, i)
+				s.RecordAbandon("seed-race", "hard", session)
+				s.RecordHint("seed-race", "hard", session)
+				s.RecordCompletion("seed-race", "hard", session, i, 1)
+				s.GetPuzzleStats("seed-race", "hard")
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	got, ok := s.GetPuzzleStats("seed-race", "hard")
+	if !ok || got.Plays != 800 || got.Completions != 800 {
+		t.Errorf("stats after concurrent recording = %+v (ok=%v), want 800 completed plays", got, ok)
+	}
+	// One live hint and one completion hint per session
+	if got.AvgHints != 2 {
+		t.Errorf("AvgHints = %v, want 2", got.AvgHints)
+	}
+}
@@ -0,0 +1,191 @@
+package dp
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// Variant constraint support for the DP solver.
+//
+// These functions mirror Solve/CountSolutions/IsValid but honor an optional
+// core.ConstraintSet (anti-knight, non-consecutive, unique diagonals).
+// The classic entry points delegate here with an empty set so behavior for
+// existing callers is unchanged.
+
+// knightOffsets are the (row, col) offsets of a knight's move
+var knightOffsets = [8][2]int{
+	{-2, -1}, {-2, 1}, {-1, -2}, {-1, 2},
+	{1, -2}, {1, 2}, {2, -1}, {2, 1},
+}
+
+// orthOffsets are the (row, col) offsets of orthogonal neighbors
+var orthOffsets = [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+// ConstraintsAllow checks only the variant rules for placing digit at
+// (row, col). Classic row/col/box rules are checked separately. Exported for
+// the human package's candidate initialization.
+func ConstraintsAllow(board []int, row, col, digit int, cs core.ConstraintSet) bool {
+	if cs.AntiKnight {
+		for _, off := range knightOffsets {
+			r, c := row+off[0], col+off[1]
+			if r < 0 || r >= constants.GridSize || c < 0 || c >= constants.GridSize {
+				continue
+			}
+			if board[r*constants.GridSize+c] == digit {
+				return false
+			}
+		}
+	}
+
+	if cs.NonConsecutive {
+		for _, off := range orthOffsets {
+			r, c := row+off[0], col+off[1]
+			if r < 0 || r >= constants.GridSize || c < 0 || c >= constants.GridSize {
+				continue
+			}
+			neighbor := board[r*constants.GridSize+c]
+			if neighbor != 0 && (neighbor == digit-1 || neighbor == digit+1) {
+				return false
+			}
+		}
+	}
+
+	if cs.DiagonalsUnique {
+		if row == col {
+			for i := 0; i < constants.GridSize; i++ {
+				if i != row && board[i*constants.GridSize+i] == digit {
+					return false
+				}
+			}
+		}
+		if row+col == constants.GridSize-1 {
+			for i := 0; i < constants.GridSize; i++ {
+				if i != row && board[i*constants.GridSize+(constants.GridSize-1-i)] == digit {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// isValidAt checks classic and variant rules for placing digit at (row, col)
+func isValidAt(board []int, row, col, digit int, cs core.ConstraintSet) bool {
+	return isValid(board, row, col, digit) && ConstraintsAllow(board, row, col, digit, cs)
+}
+
+// IsValidWithConstraints checks the grid for conflicts under classic rules
+// plus the given variant constraints.
+func IsValidWithConstraints(grid []int, cs core.ConstraintSet) bool {
+	if !IsValid(grid) {
+		return false
+	}
+	if cs.IsClassic() {
+		return true
+	}
+
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		digit := grid[idx]
+		if digit == 0 {
+			continue
+		}
+		row, col := idx/constants.GridSize, idx%constants.GridSize
+
+		// Temporarily clear the cell so constraintsAllow doesn't see itself
+		grid[idx] = 0
+		ok := ConstraintsAllow(grid, row, col, digit, cs)
+		grid[idx] = digit
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// SolveWithConstraints finds a solution honoring the given variant
+// constraints. Returns the solved grid or nil if unsolvable.
+func SolveWithConstraints(grid []int, cs core.ConstraintSet) []int {
+	board := make([]int, constants.TotalCells)
+	copy(board, grid)
+	if solveConstrained(board, cs) {
+		return board
+	}
+	return nil
+}
+
+func solveConstrained(board []int, cs core.ConstraintSet) bool {
+	idx := -1
+	for i := 0; i < constants.TotalCells; i++ {
+		if board[i] == 0 {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 {
+		return true
+	}
+
+	row, col := idx/constants.GridSize, idx%constants.GridSize
+
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		if isValidAt(board, row, col, digit, cs) {
+			board[idx] = digit
+			if solveConstrained(board, cs) {
+				return true
+			}
+			board[idx] = 0
+		}
+	}
+
+	return false
+}
+
+// CountSolutionsWithConstraints counts solutions up to maxCount honoring the
+// given variant constraints.
+func CountSolutionsWithConstraints(grid []int, maxCount int, cs core.ConstraintSet) int {
+	board := make([]int, constants.TotalCells)
+	copy(board, grid)
+	count := 0
+	countConstrainedHelper(board, &count, maxCount, cs)
+	return count
+}
+
+func countConstrainedHelper(board []int, count *int, maxCount int, cs core.ConstraintSet) {
+	if *count >= maxCount {
+		return
+	}
+
+	idx := -1
+	for i := 0; i < constants.TotalCells; i++ {
+		if board[i] == 0 {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 {
+		*count++
+		return
+	}
+
+	row, col := idx/constants.GridSize, idx%constants.GridSize
+
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		if isValidAt(board, row, col, digit, cs) {
+			board[idx] = digit
+			countConstrainedHelper(board, count, maxCount, cs)
+			board[idx] = 0
+			if *count >= maxCount {
+				return
+			}
+		}
+	}
+}
+
+// HasUniqueSolutionWithConstraints checks if the puzzle has exactly one
+// solution under the given variant constraints.
+func HasUniqueSolutionWithConstraints(grid []int, cs core.ConstraintSet) bool {
+	return CountSolutionsWithConstraints(grid, 2, cs) == 1
+}
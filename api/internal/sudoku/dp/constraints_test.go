@@ -0,0 +1,82 @@
+package dp
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+)
+
+// antiKnightPuzzle has multiple solutions under classic rules but exactly one
+// once the anti-knight constraint is applied (found by carving an anti-knight
+// grid while checking uniqueness only under the variant rule)
+var antiKnightPuzzle = []int{
+	0, 0, 0, 0, 0, 0, 0, 0, 8,
+	0, 0, 0, 0, 0, 0, 0, 7, 0,
+	0, 0, 0, 0, 0, 0, 3, 1, 0,
+	0, 0, 0, 2, 0, 0, 0, 0, 0,
+	4, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 9, 0, 0, 1, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 5, 0,
+	0, 3, 0, 0, 0, 4, 0, 9, 0,
+	0, 4, 0, 0, 0, 0, 0, 0, 0,
+}
+
+func TestCountSolutionsWithConstraints_AntiKnightUnique(t *testing.T) {
+	cs := core.ConstraintSet{AntiKnight: true}
+
+	classic := CountSolutions(antiKnightPuzzle, 2)
+	if classic < 2 {
+		t.Fatalf("expected multiple classic solutions, got %d", classic)
+	}
+
+	if got := CountSolutionsWithConstraints(antiKnightPuzzle, 2, cs); got != 1 {
+		t.Errorf("expected exactly 1 anti-knight solution, got %d", got)
+	}
+	if !HasUniqueSolutionWithConstraints(antiKnightPuzzle, cs) {
+		t.Error("HasUniqueSolutionWithConstraints should report unique")
+	}
+}
+
+func TestSolveWithConstraints_RespectsAntiKnight(t *testing.T) {
+	cs := core.ConstraintSet{AntiKnight: true}
+
+	solution := SolveWithConstraints(antiKnightPuzzle, cs)
+	if solution == nil {
+		t.Fatal("expected a solution")
+	}
+	if !IsValidWithConstraints(solution, cs) {
+		t.Error("solution violates anti-knight rules")
+	}
+
+	// Classic entry point must remain unaffected by an empty constraint set
+	if got := SolveWithConstraints(antiKnightPuzzle, core.ConstraintSet{}); got == nil {
+		t.Error("classic solve of the same puzzle should succeed")
+	}
+}
+
+func TestIsValidWithConstraints(t *testing.T) {
+	grid := make([]int, 81)
+	grid[4*9+4] = 5 // R5C5
+	grid[2*9+3] = 5 // R3C4, a knight's move away (different row/col/box)
+	if !IsValid(grid) {
+		t.Fatal("grid should be valid under classic rules")
+	}
+	if IsValidWithConstraints(grid, core.ConstraintSet{AntiKnight: true}) {
+		t.Error("knight-step duplicate should be rejected under anti-knight")
+	}
+
+	grid[2*9+3] = 0
+	grid[4*9+5] = 6 // R5C6, orthogonal neighbor of the 5
+	if IsValidWithConstraints(grid, core.ConstraintSet{NonConsecutive: true}) {
+		t.Error("consecutive orthogonal neighbors should be rejected under non-consecutive")
+	}
+
+	grid[4*9+5] = 0
+	grid[0] = 5 // R1C1, shares the main diagonal with R5C5
+	if IsValidWithConstraints(grid, core.ConstraintSet{DiagonalsUnique: true}) {
+		t.Error("diagonal duplicate should be rejected under unique diagonals")
+	}
+	if !IsValidWithConstraints(grid, core.ConstraintSet{}) {
+		t.Error("same grid should be valid with no constraints")
+	}
+}
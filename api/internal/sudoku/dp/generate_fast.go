@@ -0,0 +1,116 @@
+package dp
+
+import (
+	"strings"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Fast full-grid generation via validity-preserving transformations.
+//
+// GenerateFullGrid uses seeded backtracking, which is noticeably slow on
+// low-end devices when called from WASM (once per difficulty switch). The
+// fast path below starts from a canonical solved grid and applies
+// seed-derived transformations that preserve Sudoku validity:
+//   - digit relabeling
+//   - row swaps within bands, column swaps within stacks
+//   - band swaps, stack swaps
+//   - optional transpose
+//
+// The original GenerateFullGrid stays untouched and remains the default so
+// existing seeds keep producing identical puzzles. Only seeds carrying the
+// FastGridSeedPrefix opt into the fast path.
+
+// FastGridSeedPrefix marks string seeds that opt into GenerateFullGridFast.
+const FastGridSeedPrefix = "v2:"
+
+// UseFastGrid reports whether a string seed opts into the fast grid generator.
+func UseFastGrid(seed string) bool {
+	return strings.HasPrefix(seed, FastGridSeedPrefix)
+}
+
+// GenerateFullGridFast generates a complete valid Sudoku grid for the given
+// seed in microseconds by transforming a canonical solved grid.
+// Deterministic per seed.
+func GenerateFullGridFast(seed int64) []int {
+	rng := newRNG(seed)
+
+	// Canonical solved grid: cell(r,c) = (r*BoxSize + r/BoxSize + c) % GridSize + 1
+	grid := make([]int, constants.TotalCells)
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			grid[r*constants.GridSize+c] = (r*constants.BoxSize+r/constants.BoxSize+c)%constants.GridSize + 1
+		}
+	}
+
+	// Digit relabeling: apply a random permutation of 1..GridSize
+	relabel := make([]int, constants.GridSize)
+	for i := 0; i < constants.GridSize; i++ {
+		relabel[i] = i + 1
+	}
+	rng.shuffle(relabel)
+	for i, v := range grid {
+		grid[i] = relabel[v-1]
+	}
+
+	// Row swaps within each band, then band swaps
+	rowOrder := make([]int, constants.GridSize)
+	for band := 0; band < constants.BoxSize; band++ {
+		rows := make([]int, constants.BoxSize)
+		for i := 0; i < constants.BoxSize; i++ {
+			rows[i] = band*constants.BoxSize + i
+		}
+		rng.shuffle(rows)
+		copy(rowOrder[band*constants.BoxSize:], rows)
+	}
+	bands := make([]int, constants.BoxSize)
+	for i := 0; i < constants.BoxSize; i++ {
+		bands[i] = i
+	}
+	rng.shuffle(bands)
+	finalRowOrder := make([]int, 0, constants.GridSize)
+	for _, band := range bands {
+		finalRowOrder = append(finalRowOrder, rowOrder[band*constants.BoxSize:(band+1)*constants.BoxSize]...)
+	}
+
+	// Column swaps within each stack, then stack swaps
+	colOrder := make([]int, constants.GridSize)
+	for stack := 0; stack < constants.BoxSize; stack++ {
+		cols := make([]int, constants.BoxSize)
+		for i := 0; i < constants.BoxSize; i++ {
+			cols[i] = stack*constants.BoxSize + i
+		}
+		rng.shuffle(cols)
+		copy(colOrder[stack*constants.BoxSize:], cols)
+	}
+	stacks := make([]int, constants.BoxSize)
+	for i := 0; i < constants.BoxSize; i++ {
+		stacks[i] = i
+	}
+	rng.shuffle(stacks)
+	finalColOrder := make([]int, 0, constants.GridSize)
+	for _, stack := range stacks {
+		finalColOrder = append(finalColOrder, colOrder[stack*constants.BoxSize:(stack+1)*constants.BoxSize]...)
+	}
+
+	// Apply row and column permutations
+	permuted := make([]int, constants.TotalCells)
+	for r := 0; r < constants.GridSize; r++ {
+		for c := 0; c < constants.GridSize; c++ {
+			permuted[r*constants.GridSize+c] = grid[finalRowOrder[r]*constants.GridSize+finalColOrder[c]]
+		}
+	}
+
+	// Optional transpose
+	if rng.next()%2 == 1 {
+		transposed := make([]int, constants.TotalCells)
+		for r := 0; r < constants.GridSize; r++ {
+			for c := 0; c < constants.GridSize; c++ {
+				transposed[r*constants.GridSize+c] = permuted[c*constants.GridSize+r]
+			}
+		}
+		return transposed
+	}
+
+	return permuted
+}
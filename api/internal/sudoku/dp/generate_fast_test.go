@@ -0,0 +1,72 @@
+package dp
+
+import (
+	"fmt"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// TestGenerateFullGridFast
+// ============================================================================
+func TestGenerateFullGridFast(t *testing.T) {
+	t.Run("generates valid complete grids for many seeds", func(t *testing.T) {
+		for seed := int64(0); seed < 10000; seed++ {
+			grid := GenerateFullGridFast(seed)
+
+			if len(grid) != constants.TotalCells {
+				t.Fatalf("seed %d: grid has %d cells, want %d", seed, len(grid), constants.TotalCells)
+			}
+			for i, v := range grid {
+				if v < 1 || v > constants.GridSize {
+					t.Fatalf("seed %d: cell %d has invalid value %d", seed, i, v)
+				}
+			}
+			if !IsValid(grid) {
+				t.Fatalf("seed %d: grid has conflicts", seed)
+			}
+		}
+	})
+
+	t.Run("deterministic per seed", func(t *testing.T) {
+		for _, seed := range []int64{0, 1, 42, 99999, 1 << 40} {
+			grid1 := GenerateFullGridFast(seed)
+			grid2 := GenerateFullGridFast(seed)
+			for i := range grid1 {
+				if grid1[i] != grid2[i] {
+					t.Fatalf("seed %d: grids differ at cell %d", seed, i)
+				}
+			}
+		}
+	})
+
+	t.Run("distinct seeds produce distinct grids", func(t *testing.T) {
+		const numSeeds = 10000
+		seen := make(map[string]bool, numSeeds)
+		for seed := int64(0); seed < numSeeds; seed++ {
+			grid := GenerateFullGridFast(seed)
+			key := fmt.Sprint(grid)
+			seen[key] = true
+		}
+		// Collisions are possible but should be extremely rare (>99.9% distinct)
+		if len(seen) < numSeeds*999/1000 {
+			t.Errorf("only %d distinct grids from %d seeds", len(seen), numSeeds)
+		}
+	})
+}
+
+func TestUseFastGrid(t *testing.T) {
+	if !UseFastGrid("v2:D2024-01-01") {
+		t.Error("expected v2: prefixed seed to use fast grid")
+	}
+	if UseFastGrid("D2024-01-01") {
+		t.Error("expected unprefixed seed to keep the original generator")
+	}
+}
+
+func BenchmarkGenerateFullGridFast(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateFullGridFast(int64(i))
+	}
+}
@@ -0,0 +1,60 @@
+package dp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// goldenEntry pins the exact output of grid generation and carving for one
+// seed. The golden file is the cross-platform determinism contract: the same
+// test data must pass natively and under GOOS=js, so any platform-sensitive
+// randomness (or an accidental change to the rng constants) shows up as a
+// diff here instead of as diverging dailies in production.
+type goldenEntry struct {
+	Seed    int64             `json:"seed"`
+	Full    string            `json:"full_grid"`
+	Puzzles map[string]string `json:"puzzles"`
+}
+
+func gridString(grid []int) string {
+	b := make([]byte, len(grid))
+	for i, v := range grid {
+		b[i] = byte('0' + v)
+	}
+	return string(b)
+}
+
+func TestGenerationMatchesGolden(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "generation_golden.json"))
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	var entries []goldenEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("failed to parse golden file: %v", err)
+	}
+	if len(entries) != 50 {
+		t.Fatalf("golden file has %d entries, want 50", len(entries))
+	}
+
+	for _, entry := range entries {
+		full := GenerateFullGrid(entry.Seed)
+		if got := gridString(full); got != entry.Full {
+			t.Errorf("seed %d: full grid diverged from golden\ngot  %s\nwant %s", entry.Seed, got, entry.Full)
+			continue
+		}
+		puzzles, _ := CarveGivensWithSubset(full, entry.Seed)
+		for diff, want := range entry.Puzzles {
+			givens, ok := puzzles[diff]
+			if !ok {
+				t.Errorf("seed %d: missing %s puzzle", entry.Seed, diff)
+				continue
+			}
+			if got := gridString(givens); got != want {
+				t.Errorf("seed %d: %s givens diverged from golden\ngot  %s\nwant %s", entry.Seed, diff, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package dp
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// Minimal puzzles: every given is necessary for uniqueness. Removal
+// rejections are monotone (dropping more givens only grows the solution
+// space), so a given that cannot be removed now can never be removed later;
+// one productive pass therefore yields a minimal puzzle and the second pass
+// only confirms no progress is possible. Each removal attempt costs one
+// CountSolutions call, which early-exits at the second solution.
+
+// MinimizeGivens removes givens from the puzzle in seeded random order,
+// keeping removals that preserve uniqueness, until a full pass makes no
+// progress. Returns a new grid; the input is not modified.
+func MinimizeGivens(puzzle []int, seed int64) []int {
+	minimized := make([]int, constants.TotalCells)
+	copy(minimized, puzzle)
+
+	rng := newRNG(seed + 2) // offset seed, distinct from carving
+
+	var positions []int
+	for i, v := range minimized {
+		if v != 0 {
+			positions = append(positions, i)
+		}
+	}
+	rng.shuffle(positions)
+
+	for progress := true; progress; {
+		progress = false
+		remaining := positions[:0]
+		for _, pos := range positions {
+			oldVal := minimized[pos]
+			minimized[pos] = 0
+			if HasUniqueSolution(minimized) {
+				progress = true
+			} else {
+				minimized[pos] = oldVal
+				remaining = append(remaining, pos)
+			}
+		}
+		positions = remaining
+	}
+
+	return minimized
+}
+
+// IsMinimal reports whether the puzzle is unique and every given is
+// necessary: removing any single one admits a second solution.
+func IsMinimal(puzzle []int) bool {
+	return IsMinimalWithConstraints(puzzle, core.ConstraintSet{})
+}
+
+// IsMinimalWithConstraints is IsMinimal under variant constraints.
+func IsMinimalWithConstraints(puzzle []int, cs core.ConstraintSet) bool {
+	if !HasUniqueSolutionWithConstraints(puzzle, cs) {
+		return false
+	}
+	return len(RedundantGivensWithConstraints(puzzle, cs)) == 0
+}
+
+// RedundantGivens returns the indices of givens whose removal keeps the
+// solution unique. An empty result on a unique puzzle means it is minimal.
+func RedundantGivens(puzzle []int) []int {
+	return RedundantGivensWithConstraints(puzzle, core.ConstraintSet{})
+}
+
+// RedundantGivensWithConstraints is RedundantGivens under variant
+// constraints.
+func RedundantGivensWithConstraints(puzzle []int, cs core.ConstraintSet) []int {
+	grid := make([]int, constants.TotalCells)
+	copy(grid, puzzle)
+
+	var redundant []int
+	for i, v := range grid {
+		if v == 0 {
+			continue
+		}
+		grid[i] = 0
+		if HasUniqueSolutionWithConstraints(grid, cs) {
+			redundant = append(redundant, i)
+		}
+		grid[i] = v
+	}
+	return redundant
+}
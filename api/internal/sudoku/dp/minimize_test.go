@@ -0,0 +1,86 @@
+package dp
+
+import (
+	"testing"
+)
+
+func TestMinimizeGivens(t *testing.T) {
+	full := GenerateFullGrid(42)
+	puzzle := CarveGivens(full, 30, 42)
+
+	minimized := MinimizeGivens(puzzle, 42)
+
+	if !HasUniqueSolution(minimized) {
+		t.Fatal("minimized puzzle lost uniqueness")
+	}
+	if !IsMinimal(minimized) {
+		t.Error("minimized puzzle still has a removable given")
+	}
+	if countGivens(minimized) > countGivens(puzzle) {
+		t.Errorf("minimization added givens: %d -> %d", countGivens(puzzle), countGivens(minimized))
+	}
+
+	// Only removals: every remaining given matches the input
+	for i, v := range minimized {
+		if v != 0 && v != puzzle[i] {
+			t.Errorf("cell %d changed from %d to %d", i, puzzle[i], v)
+		}
+	}
+}
+
+func TestMinimizeGivens_Deterministic(t *testing.T) {
+	full := GenerateFullGrid(7)
+	puzzle := CarveGivens(full, 32, 7)
+
+	first := MinimizeGivens(puzzle, 99)
+	second := MinimizeGivens(puzzle, 99)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed produced different results at cell %d", i)
+		}
+	}
+}
+
+func TestRedundantGivens(t *testing.T) {
+	full := GenerateFullGrid(42)
+	puzzle := CarveGivens(full, 30, 42)
+	minimized := MinimizeGivens(puzzle, 42)
+
+	if redundant := RedundantGivens(minimized); len(redundant) != 0 {
+		t.Fatalf("minimal puzzle reported redundant givens: %v", redundant)
+	}
+
+	// Restore one removed given: it is redundant by construction
+	extra := -1
+	for i, v := range puzzle {
+		if v != 0 && minimized[i] == 0 {
+			extra = i
+			break
+		}
+	}
+	if extra < 0 {
+		t.Fatal("minimization removed nothing to restore")
+	}
+	padded := make([]int, len(minimized))
+	copy(padded, minimized)
+	padded[extra] = full[extra]
+
+	if IsMinimal(padded) {
+		t.Error("puzzle with a restored given should not be minimal")
+	}
+	found := false
+	for _, idx := range RedundantGivens(padded) {
+		if idx == extra {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected given %d to be reported redundant", extra)
+	}
+}
+
+func TestIsMinimal_NonUniquePuzzle(t *testing.T) {
+	if IsMinimal(make([]int, 81)) {
+		t.Error("an empty grid is not minimal")
+	}
+}
@@ -0,0 +1,102 @@
+package dp
+
+import (
+	"sort"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/pkg/constants"
+)
+
+// Puzzle remixing: seeded validity-preserving transformations.
+//
+// The sudoku symmetry group - digit relabeling, transposition, band and
+// stack permutations, and row/column permutations within each band or stack
+// - maps valid grids to valid grids and preserves solution uniqueness, so
+// one stored puzzle yields billions of visually distinct variants with the
+// same difficulty characteristics. Remixing stretches a pre-generated bank
+// without storing anything extra: the variant is reproduced from the bank
+// index and a transform seed.
+
+// remixTransform is one member of the symmetry group, flattened into a digit
+// relabeling and a cell permutation so it can be applied to solution strings
+// and given-index lists alike.
+type remixTransform struct {
+	relabel [constants.GridSize + 1]int
+	cellMap [constants.TotalCells]int // original cell index -> transformed index
+}
+
+// newRemixTransform draws a transform from the seeded generator. The draw
+// order is part of the seed compatibility contract (see rng.go): changing it
+// silently re-maps every existing remix seed's variant.
+func newRemixTransform(seed int64) remixTransform {
+	r := newRNG(seed)
+	perm := func(n int) []int {
+		p := make([]int, n)
+		for i := range p {
+			p[i] = i
+		}
+		r.shuffle(p)
+		return p
+	}
+
+	digits := perm(constants.GridSize)
+	bands := perm(constants.BoxSize)
+	stacks := perm(constants.BoxSize)
+	rowPerms := make([][]int, constants.BoxSize)
+	colPerms := make([][]int, constants.BoxSize)
+	for i := 0; i < constants.BoxSize; i++ {
+		rowPerms[i] = perm(constants.BoxSize)
+		colPerms[i] = perm(constants.BoxSize)
+	}
+	transpose := r.next()%2 == 1
+
+	var t remixTransform
+	for d := 1; d <= constants.GridSize; d++ {
+		t.relabel[d] = digits[d-1] + 1
+	}
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		row, col := idx/constants.GridSize, idx%constants.GridSize
+		nr := bands[row/constants.BoxSize]*constants.BoxSize + rowPerms[row/constants.BoxSize][row%constants.BoxSize]
+		nc := stacks[col/constants.BoxSize]*constants.BoxSize + colPerms[col/constants.BoxSize][col%constants.BoxSize]
+		if transpose {
+			nr, nc = nc, nr
+		}
+		t.cellMap[idx] = nr*constants.GridSize + nc
+	}
+	return t
+}
+
+// RemixPuzzle applies the seeded transform consistently to a bank puzzle's
+// solution string and to every difficulty's given-index list, so the variant
+// keeps each tier's given count and solution uniqueness. The recorded
+// technique proofs carry over unchanged: technique slugs don't distinguish
+// rows from columns or one digit from another, so the multiset stays a
+// faithful difficulty proof under every transform. Malformed puzzles (a
+// solution string of the wrong length) are returned untouched.
+func RemixPuzzle(puzzle puzzles.CompactPuzzle, seed int64) puzzles.CompactPuzzle {
+	if len(puzzle.S) != constants.TotalCells {
+		return puzzle
+	}
+	t := newRemixTransform(seed)
+
+	solution := make([]byte, constants.TotalCells)
+	for i := 0; i < constants.TotalCells; i++ {
+		d := int(puzzle.S[i] - '0')
+		if d < 1 || d > constants.GridSize {
+			return puzzle
+		}
+		solution[t.cellMap[i]] = byte('0' + t.relabel[d])
+	}
+
+	givens := make(map[string][]int, len(puzzle.G))
+	for key, indices := range puzzle.G {
+		mapped := make([]int, len(indices))
+		for i, idx := range indices {
+			mapped[i] = t.cellMap[idx]
+		}
+		sort.Ints(mapped)
+		givens[key] = mapped
+	}
+
+	return puzzles.CompactPuzzle{S: string(solution), G: givens, T: puzzle.T}
+}
@@ -0,0 +1,41 @@
+package dp
+
+// Seeded randomness for grid generation and carving.
+//
+// Every stored puzzle is reproduced from its seed, so this generator is a
+// compatibility contract, not an implementation detail: the same seed must
+// yield the same grid on every platform and in every build (server, CLI,
+// WASM). To guarantee that, the generator is integer-only and owned by this
+// package:
+//
+//   - state advances as state = (state*1103515245 + 12345) & 0x7fffffff, a
+//     classic LCG truncated to 31 bits. All arithmetic is int64, wraps the
+//     same way on every architecture Go supports, and never touches floats.
+//   - shuffle is Fisher-Yates drawing j = next() % (i+1). next() is always
+//     non-negative (31-bit mask), so the modulo is well defined everywhere.
+//   - math/rand is deliberately not used: its float64-based Shuffle/Perm
+//     paths are not part of Go's compatibility promise across versions.
+//
+// Changing any of these constants or the draw order silently regenerates
+// every existing seed's puzzles. An intentional break must ship behind a new
+// seed-format prefix (see FastGridSeedPrefix for the pattern) so old seeds
+// keep their boards.
+type rng struct {
+	state int64
+}
+
+func newRNG(seed int64) *rng {
+	return &rng{state: seed}
+}
+
+func (r *rng) next() int {
+	r.state = (r.state*1103515245 + 12345) & 0x7fffffff
+	return int(r.state)
+}
+
+func (r *rng) shuffle(arr []int) {
+	for i := len(arr) - 1; i > 0; i-- {
+		j := r.next() % (i + 1)
+		arr[i], arr[j] = arr[j], arr[i]
+	}
+}
@@ -0,0 +1,92 @@
+package dp
+
+import (
+	"testing"
+	"time"
+
+	"sudoku-api/pkg/constants"
+)
+
+func TestSampleSolutions_UniquePuzzle(t *testing.T) {
+	sample := SampleSolutions(validPuzzle, constants.SolutionSampleLimit, 0)
+
+	if sample.Count != 1 {
+		t.Fatalf("Count = %d, want 1", sample.Count)
+	}
+	if sample.Capped {
+		t.Error("unique puzzle should not be capped")
+	}
+	for i := range sample.First {
+		if sample.First[i] != validPuzzleSolution[i] {
+			t.Fatalf("First differs from known solution at position %d", i)
+		}
+	}
+	if sample.Second != nil {
+		t.Error("Second should be nil for a unique puzzle")
+	}
+	if sample.DifferingCells() != nil {
+		t.Error("DifferingCells should be nil for a unique puzzle")
+	}
+}
+
+func TestSampleSolutions_TwoSolutionPuzzle(t *testing.T) {
+	// Blank a deadly rectangle in validPuzzleSolution: cells (0,3), (0,4),
+	// (3,3), (3,4) hold 6/7 and 7/6 across two boxes, so the two digits can
+	// be swapped for exactly one extra solution
+	rectangle := []int{3, 4, 30, 31}
+	puzzle := make([]int, len(validPuzzleSolution))
+	copy(puzzle, validPuzzleSolution)
+	for _, idx := range rectangle {
+		puzzle[idx] = 0
+	}
+
+	sample := SampleSolutions(puzzle, constants.SolutionSampleLimit, 0)
+
+	if sample.Count != 2 {
+		t.Fatalf("Count = %d, want 2", sample.Count)
+	}
+	if sample.Capped {
+		t.Error("two-solution puzzle should not be capped")
+	}
+	cells := sample.DifferingCells()
+	if len(cells) != len(rectangle) {
+		t.Fatalf("DifferingCells = %v, want %v", cells, rectangle)
+	}
+	for i, idx := range rectangle {
+		if cells[i] != idx {
+			t.Fatalf("DifferingCells = %v, want %v", cells, rectangle)
+		}
+	}
+}
+
+func TestSampleSolutions_EmptyGridHitsCap(t *testing.T) {
+	budget := constants.SolutionSampleBudgetMs * time.Millisecond
+	start := time.Now()
+	sample := SampleSolutions(emptyGrid, constants.SolutionSampleLimit, budget)
+	elapsed := time.Since(start)
+
+	if !sample.Capped {
+		t.Error("empty grid should report a capped count")
+	}
+	if sample.Count > constants.SolutionSampleLimit {
+		t.Errorf("Count = %d, exceeds cap %d", sample.Count, constants.SolutionSampleLimit)
+	}
+	// Generous bound: the sampler must come back within the budget plus the
+	// slack of one deadline-check stride
+	if elapsed > 2*budget {
+		t.Errorf("sampling took %v, budget was %v", elapsed, budget)
+	}
+}
+
+func TestSampleSolutions_TimeBudgetExpires(t *testing.T) {
+	start := time.Now()
+	sample := SampleSolutions(emptyGrid, 1<<30, 10*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if !sample.Capped {
+		t.Error("expired budget should report a capped count")
+	}
+	if elapsed > time.Second {
+		t.Errorf("sampling took %v despite a 10ms budget", elapsed)
+	}
+}
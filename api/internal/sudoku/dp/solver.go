@@ -2,6 +2,7 @@ package dp
 
 import (
 	"sudoku-api/internal/core"
+	geom "sudoku-api/internal/core/grid" // aliased: most funcs here take a grid []int
 	"sudoku-api/pkg/constants"
 )
 
@@ -96,15 +97,12 @@ func FindConflicts(grid []int) []Conflict {
 
 	for box := 0; box < constants.GridSize; box++ {
 		positions := make(map[int][]int) // value -> list of cell indices
-		boxRow, boxCol := (box/constants.BoxSize)*constants.BoxSize, (box%constants.BoxSize)*constants.BoxSize
-		for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-			for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-				val := grid[r*constants.GridSize+c]
-				if val == 0 {
-					continue
-				}
-				positions[val] = append(positions[val], r*constants.GridSize+c)
+		for _, idx := range geom.Boxes[box] {
+			val := grid[idx]
+			if val == 0 {
+				continue
 			}
+			positions[val] = append(positions[val], idx)
 		}
 		for val, cells := range positions {
 			if len(cells) > 1 {
@@ -131,46 +129,11 @@ func conflictKey(cell1, cell2, val int) string {
 	return string(rune(cell1)) + "-" + string(rune(cell2)) + "-" + string(rune(val))
 }
 
-// CountSolutions counts solutions up to maxCount. Exported for custom puzzle validation.
+// CountSolutions counts solutions up to maxCount. Exported for custom puzzle
+// validation. Backed by the bitmask sampler (sample.go), so high caps stay
+// cheap even on sparse grids.
 func CountSolutions(grid []int, maxCount int) int {
-	board := make([]int, constants.TotalCells)
-	copy(board, grid)
-	count := 0
-	countSolutionsHelper(board, &count, maxCount)
-	return count
-}
-
-func countSolutionsHelper(board []int, count *int, maxCount int) {
-	if *count >= maxCount {
-		return
-	}
-
-	// Find next empty cell
-	idx := -1
-	for i := 0; i < constants.TotalCells; i++ {
-		if board[i] == 0 {
-			idx = i
-			break
-		}
-	}
-
-	if idx == -1 {
-		*count++
-		return
-	}
-
-	row, col := idx/constants.GridSize, idx%constants.GridSize
-
-	for digit := 1; digit <= constants.GridSize; digit++ {
-		if isValid(board, row, col, digit) {
-			board[idx] = digit
-			countSolutionsHelper(board, count, maxCount)
-			board[idx] = 0
-			if *count >= maxCount {
-				return
-			}
-		}
-	}
+	return SampleSolutions(grid, maxCount, 0).Count
 }
 
 func solve(board []int) bool {
@@ -203,25 +166,21 @@ func solve(board []int) bool {
 }
 
 func isValid(board []int, row, col, digit int) bool {
-	for c := 0; c < constants.GridSize; c++ {
-		if board[row*constants.GridSize+c] == digit {
+	for _, idx := range geom.Rows[row] {
+		if board[idx] == digit {
 			return false
 		}
 	}
 
-	for r := 0; r < constants.GridSize; r++ {
-		if board[r*constants.GridSize+col] == digit {
+	for _, idx := range geom.Cols[col] {
+		if board[idx] == digit {
 			return false
 		}
 	}
 
-	// Check 3x3 box
-	boxRow, boxCol := (row/constants.BoxSize)*constants.BoxSize, (col/constants.BoxSize)*constants.BoxSize
-	for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-		for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-			if board[r*constants.GridSize+c] == digit {
-				return false
-			}
+	for _, idx := range geom.Boxes[geom.BoxOf(geom.IndexOf(row, col))] {
+		if board[idx] == digit {
+			return false
 		}
 	}
 
@@ -236,27 +195,6 @@ func GenerateFullGrid(seed int64) []int {
 	return board
 }
 
-// Simple LCG random number generator for deterministic seeding
-type rng struct {
-	state int64
-}
-
-func newRNG(seed int64) *rng {
-	return &rng{state: seed}
-}
-
-func (r *rng) next() int {
-	r.state = (r.state*1103515245 + 12345) & 0x7fffffff
-	return int(r.state)
-}
-
-func (r *rng) shuffle(arr []int) {
-	for i := len(arr) - 1; i > 0; i-- {
-		j := r.next() % (i + 1)
-		arr[i], arr[j] = arr[j], arr[i]
-	}
-}
-
 func fillGrid(board []int, rng *rng) bool {
 	// Find next empty cell
 	idx := -1
@@ -330,38 +268,25 @@ func CarveGivens(fullGrid []int, targetGivens int, seed int64) []int {
 	return puzzle
 }
 
-// CarveGivensWithSubset generates puzzles for all difficulty levels ensuring subset property.
-// Returns a map of difficulty -> givens where impossible ⊂ extreme ⊂ hard ⊂ medium ⊂ easy.
-// The approach: carve to the minimum (impossible), then record which cells to restore for easier levels.
-// Also verifies that techniques required match the difficulty level.
-func CarveGivensWithSubset(fullGrid []int, seed int64) map[string][]int {
-	// Target givens for each difficulty (fewer givens = harder puzzle)
-	targets := map[string]int{
-		"easy":       40,
-		"medium":     34,
-		"hard":       28,
-		"extreme":    24,
-		"impossible": 20,
-	}
+// maxCarveAttempts bounds how many removal orders CarveGivensWithSubset tries
+// when a carve bottoms out above the impossible target. Each attempt restarts
+// from the full grid, so the cost is one full carve pass per attempt.
+const maxCarveAttempts = 4
 
+// carveToTarget removes up to targetRemoved cells from fullGrid in a
+// rng-shuffled order, keeping the solution unique. It returns the carved
+// puzzle and the successful removals in order.
+func carveToTarget(fullGrid []int, rng *rng, targetRemoved int) ([]int, []int) {
 	puzzle := make([]int, constants.TotalCells)
 	copy(puzzle, fullGrid)
 
-	rng := newRNG(seed + 1) // offset seed for carving
-
-	// Create list of filled positions in deterministic random order
 	positions := make([]int, constants.TotalCells)
 	for i := 0; i < constants.TotalCells; i++ {
 		positions[i] = i
 	}
 	rng.shuffle(positions)
 
-	// Track removal order - positions that were successfully removed
 	var removalOrder []int
-
-	// Carve down to impossible level (minimum givens)
-	targetRemoved := constants.TotalCells - targets["impossible"]
-
 	for _, pos := range positions {
 		if len(removalOrder) >= targetRemoved {
 			break
@@ -376,24 +301,95 @@ func CarveGivensWithSubset(fullGrid []int, seed int64) map[string][]int {
 			puzzle[pos] = oldVal
 		}
 	}
+	return puzzle, removalOrder
+}
+
+// CarveGivensWithSubset generates puzzles for all difficulty levels ensuring subset property.
+// Returns a map of difficulty -> givens where impossible ⊂ extreme ⊂ hard ⊂ medium ⊂ easy,
+// plus a parallel map of difficulty -> degraded flags. A tier is degraded when carving
+// bottomed out at or above the next easier tier's target, leaving both tiers with the
+// same givens (most often impossible matching extreme). Removal rejections are monotone
+// within one carve, so a stuck carve cannot be pushed further; instead we retry whole
+// carves with fresh removal orders, up to maxCarveAttempts, and keep the deepest one.
+func CarveGivensWithSubset(fullGrid []int, seed int64) (map[string][]int, map[string]bool) {
+	return CarveGivensForSpecs(fullGrid, seed, constants.DifficultySpecs())
+}
 
-	// Now we have impossible puzzle and the order cells were removed
+// CarveGivensForSpecs carves one puzzle per difficulty spec with the subset
+// property, hardest tier as the base and easier tiers restored from it. With
+// the default spec list this is byte-for-byte the historical
+// CarveGivensWithSubset behavior; extra tiers only add restorations and never
+// touch the rng, so existing seeds keep their puzzles.
+func CarveGivensForSpecs(fullGrid []int, seed int64, specs []constants.DifficultySpec) (map[string][]int, map[string]bool) {
+	// Technique-predicate tiers (RequireAnyOf) can't be judged by a
+	// given-count carve; they are bank-only and carved by the human
+	// package's technique-driven carve instead. Dropping them here keeps
+	// this carve's rng draws — and so every existing seed's puzzles —
+	// unchanged by their addition to the spec list.
+	classic := specs[:0:0]
+	for _, spec := range specs {
+		if len(spec.RequireAnyOf) == 0 {
+			classic = append(classic, spec)
+		}
+	}
+	specs = classic
+	if len(specs) == 0 {
+		return map[string][]int{}, map[string]bool{}
+	}
+
+	// Specs arrive easiest first (most givens); the last one is the base
+	hardest := specs[len(specs)-1]
+
+	rng := newRNG(seed + 1) // offset seed for carving
+
+	// Carve down to the hardest tier (minimum givens). The first attempt
+	// consumes the rng exactly as older builds did, so seeds that never
+	// needed a retry keep producing the same puzzles.
+	targetRemoved := constants.TotalCells - hardest.TargetGivens
+	puzzle, removalOrder := carveToTarget(fullGrid, rng, targetRemoved)
+
+	// Retry only while the hardest tier would collapse into the next easier
+	// one; getting all the way to its target is rare and not worth extra
+	// carves once the tiers are at least distinct.
+	retryBelow := hardest.TargetGivens
+	if len(specs) > 1 {
+		retryBelow = specs[len(specs)-2].TargetGivens
+	}
+	for attempt := 1; attempt < maxCarveAttempts &&
+		constants.TotalCells-len(removalOrder) >= retryBelow; attempt++ {
+		retryPuzzle, retryOrder := carveToTarget(fullGrid, rng, targetRemoved)
+		if len(retryOrder) > len(removalOrder) {
+			puzzle, removalOrder = retryPuzzle, retryOrder
+		}
+	}
+
+	// Now we have the base puzzle and the order cells were removed.
 	// For easier difficulties, we restore cells in reverse removal order
 
 	result := make(map[string][]int)
 
-	// Impossible is the base (most cells removed)
-	impossiblePuzzle := make([]int, constants.TotalCells)
-	copy(impossiblePuzzle, puzzle)
-	result["impossible"] = impossiblePuzzle
+	// A tier degrades when even the best carve could not get strictly below
+	// the next easier tier's target, so restoration leaves both identical.
+	// The easiest tier degrades only when carving fell short of its target.
+	achievedGivens := constants.TotalCells - len(removalOrder)
+	degraded := make(map[string]bool)
+	for i, spec := range specs {
+		if i == 0 {
+			degraded[spec.Name] = achievedGivens > spec.TargetGivens
+		} else {
+			degraded[spec.Name] = achievedGivens >= specs[i-1].TargetGivens
+		}
+	}
+
+	// The hardest tier is the base (most cells removed)
+	basePuzzle := make([]int, constants.TotalCells)
+	copy(basePuzzle, puzzle)
+	result[hardest.Name] = basePuzzle
 
 	// For each easier difficulty, restore cells to reach target
-	difficulties := []string{"extreme", "hard", "medium", "easy"}
-
-	for _, diff := range difficulties {
-		targetGivens := targets[diff]
+	for _, spec := range specs[:len(specs)-1] {
 		currentGivens := constants.TotalCells - len(removalOrder)
-		cellsToRestore := targetGivens - currentGivens
+		cellsToRestore := spec.TargetGivens - currentGivens
 
 		diffPuzzle := make([]int, constants.TotalCells)
 		copy(diffPuzzle, puzzle)
@@ -406,10 +402,10 @@ func CarveGivensWithSubset(fullGrid []int, seed int64) map[string][]int {
 			restored++
 		}
 
-		result[diff] = diffPuzzle
+		result[spec.Name] = diffPuzzle
 	}
 
-	return result
+	return result, degraded
 }
 
 // PuzzleAnalysis contains the analysis results for a puzzle
@@ -2,6 +2,8 @@ package dp
 
 import (
 	"testing"
+
+	"sudoku-api/pkg/constants"
 )
 
 // Test Data
@@ -609,7 +611,7 @@ func countGivens(grid []int) int {
 
 func TestCarveGivensWithSubset(t *testing.T) {
 	fullGrid := GenerateFullGrid(12345)
-	puzzles := CarveGivensWithSubset(fullGrid, 67890)
+	puzzles, _ := CarveGivensWithSubset(fullGrid, 67890)
 
 	difficulties := []string{"easy", "medium", "hard", "extreme", "impossible"}
 
@@ -756,3 +758,90 @@ func BenchmarkFindConflicts(b *testing.B) {
 		FindConflicts(validPuzzle)
 	}
 }
+
+// TestCarveGivensWithSubset_DegradedTiers covers the degraded flag: a grid
+// whose carves keep bottoming out at the extreme target must report the
+// impossible tier as degraded, while a typical seed carves strictly below it
+func TestCarveGivensWithSubset_DegradedTiers(t *testing.T) {
+	t.Run("stuck carve reports degraded impossible", func(t *testing.T) {
+		// Seed 3's grid resists carving below 24 givens across all retries
+		fullGrid := GenerateFullGrid(3)
+		puzzles, degraded := CarveGivensWithSubset(fullGrid, 3)
+
+		if !degraded["impossible"] {
+			t.Fatal("expected impossible tier to be flagged as degraded")
+		}
+		if imp, ext := countGivens(puzzles["impossible"]), countGivens(puzzles["extreme"]); imp != ext {
+			t.Errorf("degraded impossible should match extreme givens: %d vs %d", imp, ext)
+		}
+	})
+
+	t.Run("typical seed carves impossible below extreme", func(t *testing.T) {
+		fullGrid := GenerateFullGrid(1)
+		puzzles, degraded := CarveGivensWithSubset(fullGrid, 1)
+
+		if degraded["impossible"] {
+			t.Fatal("impossible tier should not be degraded for this seed")
+		}
+		imp, ext := countGivens(puzzles["impossible"]), countGivens(puzzles["extreme"])
+		if imp >= ext {
+			t.Errorf("impossible should have strictly fewer givens than extreme: %d vs %d", imp, ext)
+		}
+	})
+}
+
+// TestCarveGivensForSpecs covers the spec-driven carve: the default list must
+// reproduce CarveGivensWithSubset exactly, and a config-added tier must slot
+// in without disturbing the built-in puzzles for the same seed
+func TestCarveGivensForSpecs(t *testing.T) {
+	fullGrid := GenerateFullGrid(12345)
+	defaults, defaultDegraded := CarveGivensWithSubset(fullGrid, 67890)
+
+	t.Run("default specs match CarveGivensWithSubset", func(t *testing.T) {
+		puzzles, degraded := CarveGivensForSpecs(fullGrid, 67890, constants.DefaultDifficultySpecs())
+		for diff, want := range defaults {
+			got, ok := puzzles[diff]
+			if !ok {
+				t.Fatalf("missing difficulty level: %s", diff)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%s puzzle differs at position %d", diff, i)
+				}
+			}
+			if degraded[diff] != defaultDegraded[diff] {
+				t.Errorf("%s degraded flag differs: %v vs %v", diff, degraded[diff], defaultDegraded[diff])
+			}
+		}
+	})
+
+	t.Run("extra tier carves without disturbing built-ins", func(t *testing.T) {
+		specs := append([]constants.DifficultySpec{
+			{Name: "beginner", Key: "b", TargetGivens: 45, MaxTier: constants.TierSimple},
+		}, constants.DefaultDifficultySpecs()...)
+
+		puzzles, _ := CarveGivensForSpecs(fullGrid, 67890, specs)
+		for diff, want := range defaults {
+			got := puzzles[diff]
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%s puzzle changed when a beginner tier was added (position %d)", diff, i)
+				}
+			}
+		}
+
+		beginner, ok := puzzles["beginner"]
+		if !ok {
+			t.Fatal("missing beginner tier")
+		}
+		if got := countGivens(beginner); got != 45 {
+			t.Errorf("beginner givens = %d, want 45", got)
+		}
+		// Easy must be a subset of beginner (beginner restores more cells)
+		for i, v := range puzzles["easy"] {
+			if v != 0 && beginner[i] != v {
+				t.Errorf("easy given at %d missing from beginner", i)
+			}
+		}
+	})
+}
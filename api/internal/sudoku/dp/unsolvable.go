@@ -0,0 +1,52 @@
+package dp
+
+// Reasons an UnsolvableReport can carry.
+const (
+	// ReasonConflictingGivens means two givens break a row/column/box rule
+	// outright; Conflicts lists the offending pairs.
+	ReasonConflictingGivens = "conflicting-givens"
+	// ReasonOverConstrained means the givens respect the rules individually
+	// but admit no solution together — the signature of a mis-transcribed
+	// given when a board is imported from elsewhere.
+	ReasonOverConstrained = "over-constrained"
+)
+
+// UnsolvableReport classifies why a set of givens admits no solution.
+type UnsolvableReport struct {
+	Reason    string     `json:"reason"`
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+	// RemovableGivens lists given cells whose removal (on its own) restores
+	// solvability — the best single-cell suspects for a transcription error.
+	// Only populated for ReasonOverConstrained.
+	RemovableGivens []int `json:"removable_givens,omitempty"`
+}
+
+// AnalyzeUnsolvable returns nil when the givens admit at least one solution.
+// Otherwise it distinguishes direct conflicts among the givens from an
+// over-constrained grid, and for the latter probes each given's removal in
+// turn — at most one solve per given, so the whole report is bounded by
+// TotalCells+1 solver calls.
+func AnalyzeUnsolvable(givens []int) *UnsolvableReport {
+	if CountSolutions(givens, 1) > 0 {
+		return nil
+	}
+
+	if conflicts := FindConflicts(givens); len(conflicts) > 0 {
+		return &UnsolvableReport{Reason: ReasonConflictingGivens, Conflicts: conflicts}
+	}
+
+	report := &UnsolvableReport{Reason: ReasonOverConstrained}
+	probe := make([]int, len(givens))
+	copy(probe, givens)
+	for i, v := range givens {
+		if v == 0 {
+			continue
+		}
+		probe[i] = 0
+		if CountSolutions(probe, 1) > 0 {
+			report.RemovableGivens = append(report.RemovableGivens, i)
+		}
+		probe[i] = v
+	}
+	return report
+}
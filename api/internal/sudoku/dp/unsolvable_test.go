@@ -0,0 +1,99 @@
+package dp
+
+import (
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// unsolvableTestGivens carves a deterministic solvable puzzle for the fixtures
+func unsolvableTestGivens() []int {
+	full := GenerateFullGridFast(17)
+	return CarveGivens(full, 30, 17)
+}
+
+// misTranscribe returns the givens with one given swapped for a wrong digit
+// that breaks no row/column/box rule but leaves the grid without a solution —
+// the import-typo case — along with the altered cell.
+func misTranscribe(t *testing.T, givens []int) ([]int, int) {
+	t.Helper()
+	bad := make([]int, len(givens))
+	copy(bad, givens)
+	for i, v := range givens {
+		if v == 0 {
+			continue
+		}
+		for d := 1; d <= constants.GridSize; d++ {
+			if d == v {
+				continue
+			}
+			bad[i] = d
+			if len(FindConflicts(bad)) == 0 && CountSolutions(bad, 1) == 0 {
+				return bad, i
+			}
+		}
+		bad[i] = v
+	}
+	t.Fatal("no conflict-free mis-transcription found; pick another fixture seed")
+	return nil, -1
+}
+
+func TestAnalyzeUnsolvableNilForSolvable(t *testing.T) {
+	if report := AnalyzeUnsolvable(unsolvableTestGivens()); report != nil {
+		t.Errorf("expected nil report for a solvable puzzle, got %+v", report)
+	}
+}
+
+func TestAnalyzeUnsolvableConflictingGivens(t *testing.T) {
+	givens := unsolvableTestGivens()
+	// Duplicate the first given into an empty cell of the same row
+	idx := -1
+	for i, v := range givens {
+		if v != 0 {
+			idx = i
+			break
+		}
+	}
+	rowStart := idx / constants.GridSize * constants.GridSize
+	for col := rowStart; col < rowStart+constants.GridSize; col++ {
+		if givens[col] == 0 {
+			givens[col] = givens[idx]
+			break
+		}
+	}
+
+	report := AnalyzeUnsolvable(givens)
+	if report == nil {
+		t.Fatal("expected a report for conflicting givens")
+	}
+	if report.Reason != ReasonConflictingGivens {
+		t.Errorf("expected reason %q, got %q", ReasonConflictingGivens, report.Reason)
+	}
+	if len(report.Conflicts) == 0 {
+		t.Error("expected the conflicting pairs to be listed")
+	}
+	if len(report.RemovableGivens) != 0 {
+		t.Error("removal hints belong to the over-constrained case only")
+	}
+}
+
+func TestAnalyzeUnsolvableOverConstrainedHintsAtBadGiven(t *testing.T) {
+	bad, altered := misTranscribe(t, unsolvableTestGivens())
+
+	report := AnalyzeUnsolvable(bad)
+	if report == nil {
+		t.Fatal("expected a report for an over-constrained puzzle")
+	}
+	if report.Reason != ReasonOverConstrained {
+		t.Errorf("expected reason %q, got %q", ReasonOverConstrained, report.Reason)
+	}
+	found := false
+	for _, cell := range report.RemovableGivens {
+		if cell == altered {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("removal hints %v do not include the mis-transcribed given at %d", report.RemovableGivens, altered)
+	}
+}
@@ -0,0 +1,164 @@
+package human
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human/techniques"
+	"sudoku-api/pkg/constants"
+)
+
+// impossibleBoard carves a board at the impossible difficulty target, the
+// kind of board the ALS family actually runs on
+func impossibleBoard(seed int64) *Board {
+	impossible, _ := constants.DifficultyByName(constants.DifficultyImpossible)
+	givens := dp.CarveGivens(dp.GenerateFullGrid(seed), impossible.TargetGivens, seed)
+	return NewBoard(givens)
+}
+
+func TestALSetsCachedUntilMutation(t *testing.T) {
+	board := impossibleBoard(1)
+
+	first := board.ALSets(4)
+	if len(first) == 0 {
+		t.Fatal("no ALS found on a sparsely filled board")
+	}
+	second := board.ALSets(4)
+	if &first[0] != &second[0] {
+		t.Error("consecutive ALSets calls re-enumerated instead of hitting the cache")
+	}
+
+	// Any candidate removal must drop the cache
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if digits := board.Candidates[idx].ToSlice(); len(digits) > 0 {
+			board.RemoveCandidate(idx, digits[0])
+			break
+		}
+	}
+	third := board.ALSets(4)
+	if len(third) > 0 && &third[0] == &first[0] {
+		t.Error("ALSets served a stale cache after RemoveCandidate")
+	}
+
+	// Placements invalidate too
+	fresh := impossibleBoard(2)
+	before := fresh.ALSets(4)
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if only, ok := fresh.Candidates[idx].Only(); ok {
+			fresh.SetCell(idx, only)
+			break
+		}
+	}
+	after := fresh.ALSets(4)
+	if len(after) > 0 && len(before) > 0 && &after[0] == &before[0] {
+		t.Error("ALSets served a stale cache after SetCell")
+	}
+}
+
+func TestALSetsMatchesEnumeration(t *testing.T) {
+	board := impossibleBoard(3)
+
+	if !reflect.DeepEqual(board.ALSets(4), techniques.EnumerateALS(board, 4)) {
+		t.Error("cached ALSets differ from a fresh enumeration")
+	}
+	// Different sizes are cached independently
+	if !reflect.DeepEqual(board.ALSets(3), techniques.EnumerateALS(board, 3)) {
+		t.Error("cached size-3 ALSets differ from a fresh enumeration")
+	}
+}
+
+// TestFindAllALSUsesBoardCache checks the path the detectors take: FindAllALS
+// on a *Board must serve consecutive calls (one per ALS detector within a
+// FindNextMove pass) from the same enumeration.
+func TestFindAllALSUsesBoardCache(t *testing.T) {
+	board := impossibleBoard(4)
+
+	first := techniques.FindAllALS(board, 4)
+	second := techniques.FindAllALS(board, 4)
+	if len(first) == 0 {
+		t.Fatal("no ALS found")
+	}
+	if &first[0] != &second[0] {
+		t.Error("FindAllALS on a Board did not reuse the cached enumeration")
+	}
+}
+
+// alsSweepSeeds is how many generated boards the ALS contradiction sweep
+// covers; the detectors are heavier than the turbot family, so it is
+// smaller than that sweep
+const alsSweepSeeds = 300
+
+// TestALSFamilySweep checks als-xz and death-blossom eliminations against
+// the known solution, mirroring TestTurbotFamilySweep
+func TestALSFamilySweep(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping ALS family sweep in short mode")
+	}
+
+	detectors := []struct {
+		name   string
+		detect func(techniques.BoardInterface) *core.Move
+	}{
+		{"als-xz", techniques.DetectALSXZ},
+		{"death-blossom", techniques.DetectDeathBlossom},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	detections := map[string]int{}
+
+	for seed := int64(1); seed <= alsSweepSeeds; seed++ {
+		solution := dp.GenerateFullGridFast(seed)
+
+		givens := make([]int, len(solution))
+		copy(givens, solution)
+		for _, idx := range rng.Perm(81)[:20+rng.Intn(36)] {
+			givens[idx] = 0
+		}
+
+		board := NewBoard(givens)
+		board.InitCandidates()
+
+		for _, d := range detectors {
+			move := d.detect(board)
+			if move == nil {
+				continue
+			}
+			detections[d.name]++
+			for _, elim := range move.Eliminations {
+				if solution[elim.Row*9+elim.Col] == elim.Digit {
+					t.Fatalf("seed %d: %s eliminated the true digit %d at R%dC%d\nmove: %+v",
+						seed, d.name, elim.Digit, elim.Row+1, elim.Col+1, move)
+				}
+			}
+		}
+	}
+
+	for _, d := range detectors {
+		if detections[d.name] == 0 {
+			t.Errorf("%s never fired across %d seeds", d.name, alsSweepSeeds)
+		}
+	}
+	t.Logf("ALS family detections over %d seeds: %v", alsSweepSeeds, detections)
+}
+
+var benchALSSink []ALS
+
+func BenchmarkEnumerateALS(b *testing.B) {
+	board := impossibleBoard(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchALSSink = techniques.EnumerateALS(board, 4)
+	}
+}
+
+func BenchmarkALSetsCached(b *testing.B) {
+	board := impossibleBoard(1)
+	board.ALSets(4) // warm the cache
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchALSSink = board.ALSets(4)
+	}
+}
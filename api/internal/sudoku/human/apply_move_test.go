@@ -0,0 +1,208 @@
+package human
+
+import (
+	"math/rand"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// boardSnapshot captures the mutable board state for unchanged-board checks
+type boardSnapshot struct {
+	cells      [constants.TotalCells]int
+	candidates [constants.TotalCells]Candidates
+	eliminated [constants.TotalCells]Candidates
+}
+
+func snapshotBoard(b *Board) boardSnapshot {
+	return boardSnapshot{cells: b.Cells, candidates: b.Candidates, eliminated: b.Eliminated}
+}
+
+func (s boardSnapshot) equals(b *Board) bool {
+	return s.cells == b.Cells && s.candidates == b.Candidates && s.eliminated == b.Eliminated
+}
+
+// applyMoveTestBoard builds a deterministic board with a known filled cell,
+// a known empty cell, and one user-eliminated candidate for the validation
+// cases to poke at.
+func applyMoveTestBoard(t *testing.T) (b *Board, filled, empty int) {
+	t.Helper()
+	givens := dp.CarveGivens(dp.GenerateFullGrid(1), 40, 1)
+	b = NewBoard(givens)
+	filled, empty = -1, -1
+	for i := range b.Cells {
+		if b.Cells[i] != 0 && filled < 0 {
+			filled = i
+		}
+		if b.Cells[i] == 0 && empty < 0 {
+			empty = i
+		}
+	}
+	if filled < 0 || empty < 0 {
+		t.Fatal("test board needs both a filled and an empty cell")
+	}
+	return b, filled, empty
+}
+
+func cellRef(idx int) core.CellRef {
+	return core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize}
+}
+
+// TestApplyMoveRejectsInvalidMoves covers each class of move that doesn't fit
+// the board: the move must error out and leave the board untouched.
+func TestApplyMoveRejectsInvalidMoves(t *testing.T) {
+	solver := NewSolver()
+	board, filled, empty := applyMoveTestBoard(t)
+
+	presentDigit := 0
+	for d := 1; d <= constants.GridSize; d++ {
+		if board.Candidates[empty].Has(d) {
+			presentDigit = d
+			break
+		}
+	}
+	absentDigit := 0
+	for d := 1; d <= constants.GridSize; d++ {
+		if !board.Candidates[empty].Has(d) {
+			absentDigit = d
+			break
+		}
+	}
+	if presentDigit == 0 || absentDigit == 0 {
+		t.Fatal("empty cell needs both a present and an absent candidate")
+	}
+	// Mark the present digit as a user elimination in a second empty cell
+	// for the fill-candidate case
+	eliminatedCell := -1
+	for i := range board.Cells {
+		if i != empty && board.Cells[i] == 0 && board.Candidates[i].Has(presentDigit) {
+			eliminatedCell = i
+			break
+		}
+	}
+	if eliminatedCell < 0 {
+		t.Fatal("no second empty cell holding the test digit")
+	}
+	board.RemoveCandidate(eliminatedCell, presentDigit)
+
+	cases := []struct {
+		name string
+		move *core.Move
+	}{
+		{
+			name: "assign into filled cell",
+			move: &core.Move{Action: constants.ActionAssign, Digit: board.Cells[filled], Targets: []core.CellRef{cellRef(filled)}},
+		},
+		{
+			name: "assign digit that is not a candidate",
+			move: &core.Move{Action: constants.ActionAssign, Digit: absentDigit, Targets: []core.CellRef{cellRef(empty)}},
+		},
+		{
+			name: "eliminate from filled cell",
+			move: &core.Move{Action: constants.ActionEliminate, Eliminations: []core.Candidate{{Row: filled / constants.GridSize, Col: filled % constants.GridSize, Digit: board.Cells[filled]}}},
+		},
+		{
+			name: "eliminate absent candidate",
+			move: &core.Move{Action: constants.ActionEliminate, Eliminations: []core.Candidate{{Row: empty / constants.GridSize, Col: empty % constants.GridSize, Digit: absentDigit}}},
+		},
+		{
+			name: "fill-candidate into filled cell",
+			move: &core.Move{Action: "candidate", Digit: board.Cells[filled], Targets: []core.CellRef{cellRef(filled)}},
+		},
+		{
+			name: "fill-candidate overwrites user elimination",
+			move: &core.Move{Action: "candidate", Digit: presentDigit, Targets: []core.CellRef{cellRef(eliminatedCell)}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := snapshotBoard(board)
+			if _, err := solver.ApplyMove(board, tc.move); err == nil {
+				t.Fatal("expected an error")
+			}
+			if !before.equals(board) {
+				t.Error("board changed despite the error")
+			}
+		})
+	}
+}
+
+// TestApplyMoveAcceptsValidMoves checks that well-formed moves still apply
+// and report their diff
+func TestApplyMoveAcceptsValidMoves(t *testing.T) {
+	solver := NewSolver()
+	board, _, empty := applyMoveTestBoard(t)
+
+	digit := 0
+	for d := 1; d <= constants.GridSize; d++ {
+		if board.Candidates[empty].Has(d) {
+			digit = d
+			break
+		}
+	}
+
+	elim := &core.Move{Action: constants.ActionEliminate, Eliminations: []core.Candidate{{Row: empty / constants.GridSize, Col: empty % constants.GridSize, Digit: digit}}}
+	diff, err := solver.ApplyMove(board, elim)
+	if err != nil {
+		t.Fatalf("valid elimination rejected: %v", err)
+	}
+	if len(diff.Candidates.Removed) == 0 {
+		t.Error("elimination produced an empty diff")
+	}
+	if board.Candidates[empty].Has(digit) {
+		t.Error("elimination was not applied")
+	}
+
+	refill := &core.Move{Action: "candidate", Digit: digit, Targets: []core.CellRef{cellRef(empty)}}
+	if _, err := solver.ApplyMove(board, refill); err == nil {
+		t.Error("refilling an eliminated candidate should be rejected")
+	}
+
+	assignDigit := 0
+	for d := 1; d <= constants.GridSize; d++ {
+		if board.Candidates[empty].Has(d) {
+			assignDigit = d
+			break
+		}
+	}
+	assign := &core.Move{Action: constants.ActionAssign, Digit: assignDigit, Targets: []core.CellRef{cellRef(empty)}}
+	if _, err := solver.ApplyMove(board, assign); err != nil {
+		t.Fatalf("valid assignment rejected: %v", err)
+	}
+	if board.Cells[empty] != assignDigit {
+		t.Errorf("cell %d = %d after assignment, want %d", empty, board.Cells[empty], assignDigit)
+	}
+}
+
+// TestApplyMoveRandomMalformed feeds random (mostly malformed) moves at the
+// board and asserts the invariant the validation exists for: whenever
+// ApplyMove returns an error, the board is unchanged.
+func TestApplyMoveRandomMalformed(t *testing.T) {
+	solver := NewSolver()
+	board, _, _ := applyMoveTestBoard(t)
+	rng := rand.New(rand.NewSource(42))
+	actions := []string{constants.ActionAssign, constants.ActionEliminate, "candidate"}
+
+	for i := 0; i < 2000; i++ {
+		idx := rng.Intn(constants.TotalCells)
+		digit := 1 + rng.Intn(constants.GridSize)
+		move := &core.Move{
+			Action: actions[rng.Intn(len(actions))],
+			Digit:  digit,
+			Targets: []core.CellRef{
+				cellRef(idx),
+			},
+			Eliminations: []core.Candidate{
+				{Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: digit},
+			},
+		}
+
+		before := snapshotBoard(board)
+		if _, err := solver.ApplyMove(board, move); err != nil && !before.equals(board) {
+			t.Fatalf("iteration %d: board changed after rejected move %+v", i, move)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package human
+
+import (
+	"sudoku-api/pkg/constants"
+)
+
+// FindBlockingUserCell analyzes a contradiction and identifies which
+// user-entered cell most likely caused it.
+//
+// For each digit the contradiction cell is missing, the user-entered cells
+// holding that digit in its row, column and box are collected as blockers.
+// Only cells present in the original user board and absent from the givens
+// qualify: given clues and solver placements are never deleted.
+//
+// When the authoritative solution is known, any blocker whose digit disagrees
+// with it is the error and is returned first — ranking by blocked-digit
+// counts regularly deleted a correct entry while the real mistake sat one
+// unit over. Only when every blocker matches the solution (which points at a
+// solver bug, classified upstream) or no solution is available does the
+// ranking fall back to the counting heuristic of deleting the cell that
+// blocks the most digits.
+//
+// Returns the cell index and its digit, or (-1, 0) when no user-entered
+// blocker exists.
+func FindBlockingUserCell(b *Board, contradictionCell int, originalUserBoard, givens, solution []int) (int, int) {
+	row, col := RowOf(contradictionCell), ColOf(contradictionCell)
+	boxRow := (row / constants.BoxSize) * constants.BoxSize
+	boxCol := (col / constants.BoxSize) * constants.BoxSize
+
+	var units [3][]int
+	for i := 0; i < constants.GridSize; i++ {
+		units[0] = append(units[0], IndexOf(row, i))
+		units[1] = append(units[1], IndexOf(i, col))
+	}
+	for r := boxRow; r < boxRow+constants.BoxSize; r++ {
+		for c := boxCol; c < boxCol+constants.BoxSize; c++ {
+			units[2] = append(units[2], IndexOf(r, c))
+		}
+	}
+
+	// Scan the three units for user-entered cells holding each digit, at
+	// most one blocker recorded per unit
+	type blockingCell struct {
+		idx   int
+		digit int
+	}
+	var userBlockers []blockingCell
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		for _, unit := range units {
+			for _, idx := range unit {
+				if b.Cells[idx] == digit {
+					if originalUserBoard[idx] != 0 && givens[idx] == 0 {
+						userBlockers = append(userBlockers, blockingCell{idx, digit})
+					}
+					break
+				}
+			}
+		}
+	}
+
+	if len(userBlockers) == 0 {
+		return -1, 0
+	}
+
+	// Solution-aware ranking: a blocker that disagrees with the solution is
+	// the error, regardless of how many digits it happens to block
+	if len(solution) == constants.TotalCells {
+		for _, blocker := range userBlockers {
+			if solution[blocker.idx] != 0 && blocker.digit != solution[blocker.idx] {
+				return blocker.idx, blocker.digit
+			}
+		}
+	}
+
+	// Fallback: the cell blocking the most digits is most likely wrong
+	cellCount := make(map[int]int)
+	cellDigit := make(map[int]int)
+	for _, blocker := range userBlockers {
+		cellCount[blocker.idx]++
+		cellDigit[blocker.idx] = blocker.digit
+	}
+
+	maxCount, maxCell := 0, -1
+	for _, blocker := range userBlockers {
+		if count := cellCount[blocker.idx]; count > maxCount {
+			maxCount = count
+			maxCell = blocker.idx
+		}
+	}
+
+	if maxCell >= 0 {
+		return maxCell, cellDigit[maxCell]
+	}
+	return -1, 0
+}
@@ -0,0 +1,107 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// blockingTestSolution is the canonical shifted grid used as the
+// authoritative solution in the blocker tests
+func blockingTestSolution() []int {
+	rows := []string{
+		"123456789",
+		"456789123",
+		"789123456",
+		"234567891",
+		"567891234",
+		"891234567",
+		"345678912",
+		"678912345",
+		"912345678",
+	}
+	solution := make([]int, constants.TotalCells)
+	for r, row := range rows {
+		for c := 0; c < constants.GridSize; c++ {
+			solution[r*constants.GridSize+c] = int(row[c] - '0')
+		}
+	}
+	return solution
+}
+
+// TestFindBlockingUserCellPrefersSolutionMismatch sets up the failure mode of
+// the old heuristic: around cell R1C1, a correct entry blocks two digits (row
+// and box) while the actual wrong entry blocks only one (box). Counting picks
+// the correct entry; the solution-aware ranking must pick the wrong one.
+func TestFindBlockingUserCellPrefersSolutionMismatch(t *testing.T) {
+	solution := blockingTestSolution()
+	givens := make([]int, constants.TotalCells)
+
+	userBoard := make([]int, constants.TotalCells)
+	userBoard[1] = 2  // R1C2: correct, blocks 2 via row and box
+	userBoard[36] = 5 // R5C1: correct, blocks 5 via column
+	userBoard[20] = 4 // R3C3: wrong (solution has 9), blocks 4 via box
+
+	board := NewBoard(userBoard)
+	badCell, badDigit := FindBlockingUserCell(board, 0, userBoard, givens, solution)
+	if badCell != 20 || badDigit != 4 {
+		t.Errorf("expected the solution mismatch at cell 20 digit 4, got cell %d digit %d", badCell, badDigit)
+	}
+}
+
+// TestFindBlockingUserCellFallsBackWithoutSolution checks the counting
+// heuristic still runs when no solution is available: the double blocker wins
+// even though it is correct.
+func TestFindBlockingUserCellFallsBackWithoutSolution(t *testing.T) {
+	givens := make([]int, constants.TotalCells)
+
+	userBoard := make([]int, constants.TotalCells)
+	userBoard[1] = 2
+	userBoard[36] = 5
+	userBoard[20] = 4
+
+	board := NewBoard(userBoard)
+	badCell, badDigit := FindBlockingUserCell(board, 0, userBoard, givens, nil)
+	if badCell != 1 || badDigit != 2 {
+		t.Errorf("expected the counting fallback to pick cell 1 digit 2, got cell %d digit %d", badCell, badDigit)
+	}
+}
+
+// TestFindBlockingUserCellAllBlockersCorrect checks that when every blocker
+// agrees with the solution the ranking falls back to counting instead of
+// returning nothing, so upstream solver-bug classification still gets a cell.
+func TestFindBlockingUserCellAllBlockersCorrect(t *testing.T) {
+	solution := blockingTestSolution()
+	givens := make([]int, constants.TotalCells)
+
+	userBoard := make([]int, constants.TotalCells)
+	userBoard[1] = 2
+	userBoard[36] = 5
+
+	board := NewBoard(userBoard)
+	badCell, badDigit := FindBlockingUserCell(board, 0, userBoard, givens, solution)
+	if badCell != 1 || badDigit != 2 {
+		t.Errorf("expected the fallback winner cell 1 digit 2, got cell %d digit %d", badCell, badDigit)
+	}
+}
+
+// TestFindBlockingUserCellIgnoresGivensAndSolverCells checks that neither
+// given clues nor solver placements are ever offered for deletion.
+func TestFindBlockingUserCellIgnoresGivensAndSolverCells(t *testing.T) {
+	solution := blockingTestSolution()
+
+	givens := make([]int, constants.TotalCells)
+	givens[1] = 2 // a clue, not a user entry
+
+	userBoard := make([]int, constants.TotalCells)
+	userBoard[1] = 2
+
+	cells := make([]int, constants.TotalCells)
+	cells[1] = 2
+	cells[36] = 5 // solver placement: absent from the user board
+
+	board := NewBoard(cells)
+	if badCell, _ := FindBlockingUserCell(board, 0, userBoard, givens, solution); badCell != -1 {
+		t.Errorf("expected no deletable blocker, got cell %d", badCell)
+	}
+}
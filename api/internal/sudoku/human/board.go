@@ -1,6 +1,10 @@
 package human
 
-import "sudoku-api/pkg/constants"
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/human/techniques"
+	"sudoku-api/pkg/constants"
+)
 
 // ============================================================================
 // Board - Sudoku Puzzle State
@@ -16,11 +20,58 @@ import "sudoku-api/pkg/constants"
 //
 // ============================================================================
 
+// MarksState records where a cell's pencil marks came from, distinguishing
+// "no marks because nobody penciled yet" from "the user deliberately cleared
+// this cell's marks". Candidate refills (InitCandidates, fill-candidate)
+// must leave user-provided cells alone.
+type MarksState uint8
+
+const (
+	// MarksUnset means no marks have been supplied or computed for the cell
+	MarksUnset MarksState = iota
+	// MarksUserProvided means the user submitted this cell's marks — possibly
+	// an empty set, which stays empty
+	MarksUserProvided
+	// MarksSolverFilled means the solver computed the cell's candidates
+	MarksSolverFilled
+)
+
+// CandidateSource records where the board's candidate sets as a whole came
+// from. Externally supplied sets may be tighter than anything the current
+// technique selection could derive — eliminations from harder techniques, or
+// plain stale marks — which is fine for hinting but poisons difficulty
+// ratings.
+type CandidateSource uint8
+
+const (
+	// CandidatesDerived means every candidate set was computed from the
+	// cells by this solver
+	CandidatesDerived CandidateSource = iota
+	// CandidatesExternal means at least one candidate set was submitted by
+	// the caller and cannot be re-derived from the cells alone
+	CandidatesExternal
+)
+
 // Board represents the Sudoku board state with candidates
 type Board struct {
-	Cells      [constants.TotalCells]int        // 0 for empty, 1-16 for filled
-	Candidates [constants.TotalCells]Candidates // possible values for each cell (bitmask)
-	Eliminated [constants.TotalCells]Candidates // candidates that have been eliminated (don't re-add)
+	Cells       [constants.TotalCells]int        // 0 for empty, 1-16 for filled
+	Candidates  [constants.TotalCells]Candidates // possible values for each cell (bitmask)
+	Eliminated  [constants.TotalCells]Candidates // candidates that have been eliminated (don't re-add)
+	Marks       [constants.TotalCells]MarksState // provenance of each cell's pencil marks
+	Source      CandidateSource                  // provenance of the candidate sets as a whole
+	Constraints core.ConstraintSet               // active variant constraints (zero value = classic)
+
+	// AssumeUnique records whether the puzzle is known to have exactly one
+	// solution. Uniqueness-based techniques (the UR family, BUG) are only
+	// sound under that assumption, so the solver skips them when it's
+	// false. Constructors default it to true; custom puzzles validated as
+	// non-unique clear it.
+	AssumeUnique bool
+
+	// alsCache memoizes ALS enumeration per maxSize (see ALSets). Every
+	// mutation path through the board's methods drops it; writing Cells or
+	// Candidates directly bypasses the invalidation.
+	alsCache map[int][]ALS
 }
 
 // ============================================================================
@@ -29,7 +80,13 @@ type Board struct {
 
 // NewBoard creates a board from givens and initializes candidates
 func NewBoard(givens []int) *Board {
-	b := &Board{}
+	return NewBoardWithConstraints(givens, core.ConstraintSet{})
+}
+
+// NewBoardWithConstraints creates a board from givens under the given variant
+// constraints; candidates are pruned by the variant rules as well
+func NewBoardWithConstraints(givens []int, cs core.ConstraintSet) *Board {
+	b := &Board{Constraints: cs, AssumeUnique: true}
 	for i := 0; i < constants.TotalCells; i++ {
 		b.Cells[i] = givens[i]
 	}
@@ -40,15 +97,43 @@ func NewBoard(givens []int) *Board {
 // NewBoardWithCandidates creates a board with pre-set candidates (for persisting eliminations)
 // Does NOT auto-fill candidates - let FindNextMove handle that one at a time
 func NewBoardWithCandidates(cells []int, candidates [][]int) *Board {
-	b := &Board{}
+	return NewBoardWithCandidatesAndConstraints(cells, candidates, core.ConstraintSet{})
+}
+
+// NewBoardWithCandidatesAndConstraints is NewBoardWithCandidates for variant
+// puzzles: the constraint set affects which absent candidates count as eliminated
+func NewBoardWithCandidatesAndConstraints(cells []int, candidates [][]int, cs core.ConstraintSet) *Board {
+	b := &Board{Constraints: cs, AssumeUnique: true}
+
+	// A grid of empty arrays is how frontends submit a fresh board with no
+	// pencil marks at all; only once at least one cell carries marks does an
+	// empty array mean "the user deliberately cleared this cell"
+	hasMarks := false
+	for i := 0; candidates != nil && i < len(candidates); i++ {
+		if len(candidates[i]) > 0 {
+			hasMarks = true
+			break
+		}
+	}
+
+	if hasMarks {
+		b.Source = CandidatesExternal
+	}
+
 	for i := 0; i < constants.TotalCells; i++ {
 		b.Cells[i] = cells[i]
-		if candidates != nil && i < len(candidates) && candidates[i] != nil {
+		if hasMarks && i < len(candidates) && candidates[i] != nil {
 			b.Candidates[i] = NewCandidates(candidates[i])
+			if cells[i] == 0 {
+				// A non-nil array is the user's marks for the cell, even when
+				// it is empty — a deliberately cleared cell stays cleared
+				b.Marks[i] = MarksUserProvided
+			}
 		}
 		// Mark candidates that could be valid but aren't present as eliminated
-		// This preserves eliminations from previous moves
-		if cells[i] == 0 && candidates != nil && i < len(candidates) && candidates[i] != nil && len(candidates[i]) > 0 {
+		// This preserves eliminations from previous moves, and keeps the
+		// fill-candidate flow from refilling a cell the user blanked
+		if cells[i] == 0 && hasMarks && i < len(candidates) && candidates[i] != nil {
 			for d := 1; d <= constants.GridSize; d++ {
 				if b.canPlace(i, d) && !b.Candidates[i].Has(d) {
 					b.Eliminated[i] = b.Eliminated[i].Set(d)
@@ -63,10 +148,17 @@ func NewBoardWithCandidates(cells []int, candidates [][]int) *Board {
 // Candidate Management
 // ============================================================================
 
-// InitCandidates populates candidates for empty cells based on current board state
+// InitCandidates populates candidates for empty cells based on current board
+// state. Cells whose marks the user provided are left exactly as submitted —
+// recomputing them here is how deliberately cleared cells used to sprout a
+// full candidate set.
 func (b *Board) InitCandidates() {
+	b.invalidateALS()
 	for i := 0; i < constants.TotalCells; i++ {
 		if b.Cells[i] == 0 {
+			if b.Marks[i] == MarksUserProvided {
+				continue
+			}
 			var cands Candidates
 			for d := 1; d <= constants.GridSize; d++ {
 				if b.canPlace(i, d) {
@@ -74,12 +166,39 @@ func (b *Board) InitCandidates() {
 				}
 			}
 			b.Candidates[i] = cands
+			b.Marks[i] = MarksSolverFilled
 		} else {
 			b.Candidates[i] = 0
 		}
 	}
 }
 
+// ValidateCandidatesAgainstPeers flags cells whose candidate set is missing
+// a digit that no filled peer (or variant constraint) excludes. A derived
+// board never trips it; on submitted marks a hit means the set is tighter
+// than the visible board justifies — an earlier advanced elimination, or
+// marks gone stale after an erase — so handlers surface the cells as a
+// warning rather than an error.
+func (b *Board) ValidateCandidatesAgainstPeers() []core.CellRef {
+	var stale []core.CellRef
+	for i := 0; i < constants.TotalCells; i++ {
+		if b.Cells[i] != 0 {
+			continue
+		}
+		// Unmarked cells carry no claim to check; a user-cleared cell does
+		if b.Candidates[i] == 0 && b.Marks[i] != MarksUserProvided {
+			continue
+		}
+		for d := 1; d <= constants.GridSize; d++ {
+			if !b.Candidates[i].Has(d) && b.canPlace(i, d) {
+				stale = append(stale, core.CellRef{Row: i / constants.GridSize, Col: i % constants.GridSize})
+				break
+			}
+		}
+	}
+	return stale
+}
+
 // canPlace checks if a digit can be placed at idx (no conflicts in row/col/box)
 func (b *Board) canPlace(idx, digit int) bool {
 	row, col := idx/constants.GridSize, idx%constants.GridSize
@@ -105,6 +224,63 @@ func (b *Board) canPlace(idx, digit int) bool {
 		}
 	}
 
+	return b.constraintsAllow(row, col, digit)
+}
+
+// knightSteps are the (row, col) offsets of a knight's move, for anti-knight
+var knightSteps = [8][2]int{
+	{-2, -1}, {-2, 1}, {-1, -2}, {-1, 2},
+	{1, -2}, {1, 2}, {2, -1}, {2, 1},
+}
+
+// orthSteps are the (row, col) offsets of orthogonal neighbors, for non-consecutive
+var orthSteps = [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+// constraintsAllow checks the board's variant constraints for placing digit at
+// (row, col). Classic row/col/box conflicts are checked by canPlace
+func (b *Board) constraintsAllow(row, col, digit int) bool {
+	if b.Constraints.AntiKnight {
+		for _, off := range knightSteps {
+			r, c := row+off[0], col+off[1]
+			if r < 0 || r >= constants.GridSize || c < 0 || c >= constants.GridSize {
+				continue
+			}
+			if b.Cells[r*constants.GridSize+c] == digit {
+				return false
+			}
+		}
+	}
+
+	if b.Constraints.NonConsecutive {
+		for _, off := range orthSteps {
+			r, c := row+off[0], col+off[1]
+			if r < 0 || r >= constants.GridSize || c < 0 || c >= constants.GridSize {
+				continue
+			}
+			neighbor := b.Cells[r*constants.GridSize+c]
+			if neighbor != 0 && (neighbor == digit-1 || neighbor == digit+1) {
+				return false
+			}
+		}
+	}
+
+	if b.Constraints.DiagonalsUnique {
+		if row == col {
+			for i := 0; i < constants.GridSize; i++ {
+				if i != row && b.Cells[i*constants.GridSize+i] == digit {
+					return false
+				}
+			}
+		}
+		if row+col == constants.GridSize-1 {
+			for i := 0; i < constants.GridSize; i++ {
+				if i != row && b.Cells[i*constants.GridSize+(constants.GridSize-1-i)] == digit {
+					return false
+				}
+			}
+		}
+	}
+
 	return true
 }
 
@@ -114,9 +290,11 @@ func (b *Board) canPlace(idx, digit int) bool {
 
 // SetCell places a digit and updates candidates in all affected peers
 func (b *Board) SetCell(idx, digit int) {
+	b.invalidateALS()
 	b.Cells[idx] = digit
-	b.Candidates[idx] = 0 // Clear candidates for filled cell
-	b.Eliminated[idx] = 0 // Clear eliminated for filled cell
+	b.Candidates[idx] = 0     // Clear candidates for filled cell
+	b.Eliminated[idx] = 0     // Clear eliminated for filled cell
+	b.Marks[idx] = MarksUnset // A filled cell carries no marks
 
 	row, col := idx/constants.GridSize, idx%constants.GridSize
 
@@ -155,6 +333,7 @@ func (b *Board) ClearCell(idx int) {
 		return
 	}
 
+	b.invalidateALS()
 	b.Cells[idx] = 0
 	b.Eliminated[idx] = 0
 
@@ -165,11 +344,13 @@ func (b *Board) ClearCell(idx int) {
 		}
 	}
 	b.Candidates[idx] = cands
+	b.Marks[idx] = MarksSolverFilled
 }
 
 // RemoveCandidate removes a candidate from a cell and marks it as eliminated
 func (b *Board) RemoveCandidate(idx, digit int) bool {
 	if b.Candidates[idx].Has(digit) {
+		b.invalidateALS()
 		b.Candidates[idx] = b.Candidates[idx].Clear(digit)
 		b.Eliminated[idx] = b.Eliminated[idx].Set(digit)
 		return true
@@ -179,9 +360,40 @@ func (b *Board) RemoveCandidate(idx, digit int) bool {
 
 // AddCandidate adds a candidate to a cell
 func (b *Board) AddCandidate(idx, digit int) {
+	b.invalidateALS()
 	b.Candidates[idx] = b.Candidates[idx].Set(digit)
 }
 
+// ============================================================================
+// ALS Cache
+// ============================================================================
+
+// ALSets returns all Almost Locked Sets up to maxSize, enumerating on first
+// use and serving the same slice until the board mutates. The ALS detector
+// family reaches this through techniques.FindAllALS, so consecutive
+// detectors within one FindNextMove share a single enumeration. Callers
+// must not modify the returned slice.
+func (b *Board) ALSets(maxSize int) []ALS {
+	if maxSize <= 0 {
+		maxSize = 4
+	}
+	if sets, ok := b.alsCache[maxSize]; ok {
+		return sets
+	}
+	sets := techniques.EnumerateALS(b, maxSize)
+	if b.alsCache == nil {
+		b.alsCache = make(map[int][]ALS)
+	}
+	b.alsCache[maxSize] = sets
+	return sets
+}
+
+// invalidateALS drops the cached enumeration. Every mutation that can
+// change cells or candidates must call it before writing.
+func (b *Board) invalidateALS() {
+	b.alsCache = nil
+}
+
 // ============================================================================
 // Board State Queries
 // ============================================================================
@@ -250,10 +462,11 @@ func (b *Board) IsValid() bool {
 
 // Clone creates a deep copy of the board
 func (b *Board) Clone() *Board {
-	nb := &Board{}
+	nb := &Board{Constraints: b.Constraints, AssumeUnique: b.AssumeUnique, Source: b.Source}
 	copy(nb.Cells[:], b.Cells[:])
 	copy(nb.Candidates[:], b.Candidates[:])
 	copy(nb.Eliminated[:], b.Eliminated[:])
+	copy(nb.Marks[:], b.Marks[:])
 	return nb
 }
 
@@ -273,6 +486,16 @@ func (b *Board) GetCandidates() [][]int {
 	return result
 }
 
+// GetMarks returns the per-cell marks provenance as ints (0 = unset,
+// 1 = user-provided, 2 = solver-filled), for API and WASM payloads
+func (b *Board) GetMarks() []int {
+	result := make([]int, constants.TotalCells)
+	for i := 0; i < constants.TotalCells; i++ {
+		result[i] = int(b.Marks[i])
+	}
+	return result
+}
+
 // ============================================================================
 // Query Helpers
 // ============================================================================
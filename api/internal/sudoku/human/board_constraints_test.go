@@ -0,0 +1,88 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+)
+
+func TestNewBoardWithConstraints_AntiKnightPruning(t *testing.T) {
+	givens := make([]int, 81)
+	givens[4*9+4] = 5 // R5C5
+
+	classic := NewBoard(givens)
+	knightIdx := 2*9 + 3 // R3C4, a knight's move from R5C5 (not a classic peer)
+	if !classic.Candidates[knightIdx].Has(5) {
+		t.Fatal("classic board should keep 5 as a candidate at a knight-step cell")
+	}
+
+	b := NewBoardWithConstraints(givens, core.ConstraintSet{AntiKnight: true})
+	if b.Candidates[knightIdx].Has(5) {
+		t.Error("anti-knight board should prune 5 from a knight-step cell")
+	}
+	// A cell outside knight range keeps the candidate
+	farIdx := 8*9 + 8
+	if !b.Candidates[farIdx].Has(5) {
+		t.Error("cell outside knight range should keep 5 as a candidate")
+	}
+}
+
+func TestNewBoardWithConstraints_NonConsecutivePruning(t *testing.T) {
+	givens := make([]int, 81)
+	givens[0] = 5 // R1C1
+
+	b := NewBoardWithConstraints(givens, core.ConstraintSet{NonConsecutive: true})
+	neighborIdx := 1 // R1C2
+	if b.Candidates[neighborIdx].Has(4) || b.Candidates[neighborIdx].Has(6) {
+		t.Error("orthogonal neighbor should not have 4 or 6 as candidates next to a 5")
+	}
+	if !b.Candidates[neighborIdx].Has(7) {
+		t.Error("non-consecutive digits should remain candidates")
+	}
+}
+
+func TestNewBoardWithConstraints_DiagonalPruning(t *testing.T) {
+	givens := make([]int, 81)
+	givens[0] = 5 // R1C1, on the main diagonal
+
+	b := NewBoardWithConstraints(givens, core.ConstraintSet{DiagonalsUnique: true})
+	diagIdx := 4*9 + 4 // R5C5
+	if b.Candidates[diagIdx].Has(5) {
+		t.Error("main-diagonal cell should prune 5")
+	}
+}
+
+func TestClone_PreservesConstraints(t *testing.T) {
+	b := NewBoardWithConstraints(make([]int, 81), core.ConstraintSet{AntiKnight: true})
+	clone := b.Clone()
+	if !clone.Constraints.AntiKnight {
+		t.Error("Clone should copy the constraint set")
+	}
+}
+
+func TestArePeersWithConstraints(t *testing.T) {
+	classic := core.ConstraintSet{}
+	ak := core.ConstraintSet{AntiKnight: true}
+	diag := core.ConstraintSet{DiagonalsUnique: true}
+
+	r1c1 := IndexOf(0, 0)
+	r5c5 := IndexOf(4, 4) // main diagonal with R1C1
+	r3c4 := IndexOf(2, 3) // knight's move from R5C5, not a classic peer
+	r1c5 := IndexOf(0, 4) // same row as R1C1
+
+	if !ArePeersWithConstraints(r1c1, r1c5, classic) {
+		t.Error("classic peers should always be peers")
+	}
+	if ArePeersWithConstraints(r5c5, r3c4, classic) {
+		t.Error("knight-step cells are not classic peers")
+	}
+	if !ArePeersWithConstraints(r5c5, r3c4, ak) {
+		t.Error("knight-step cells should be peers under anti-knight")
+	}
+	if !ArePeersWithConstraints(r1c1, r5c5, diag) {
+		t.Error("main-diagonal cells should be peers under unique diagonals")
+	}
+	if ArePeersWithConstraints(r1c1, r1c1, ak) {
+		t.Error("a cell is never its own peer")
+	}
+}
@@ -0,0 +1,95 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// TestResetPathKeepsUserClearedCellsEmpty reproduces the error-fix reset in
+// solveAll — NewBoardWithCandidates followed by InitCandidates — and checks
+// that a cell whose marks the user deliberately blanked stays blank instead
+// of sprouting a full candidate set.
+func TestResetPathKeepsUserClearedCellsEmpty(t *testing.T) {
+	givens := dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1)
+
+	// True candidates, for picking a realistic penciled subset
+	reference := NewBoard(givens)
+	reference.InitCandidates()
+
+	penciled, cleared := -1, -1
+	for i, v := range givens {
+		if v != 0 {
+			continue
+		}
+		if penciled == -1 {
+			penciled = i
+		} else if cleared == -1 {
+			cleared = i
+			break
+		}
+	}
+
+	candidates := make([][]int, constants.TotalCells)
+	candidates[penciled] = reference.Candidates[penciled].ToSlice()
+	candidates[cleared] = []int{} // deliberately cleared, not merely absent
+
+	board := NewBoardWithCandidates(givens, candidates)
+	board.InitCandidates()
+
+	if board.Marks[cleared] != MarksUserProvided {
+		t.Errorf("cleared cell marks state = %d, want MarksUserProvided", board.Marks[cleared])
+	}
+	if got := board.Candidates[cleared].ToSlice(); len(got) != 0 {
+		t.Errorf("cleared cell regained candidates %v after InitCandidates", got)
+	}
+	// The fill-candidate flow checks Eliminated before re-adding, so every
+	// placeable digit of the cleared cell must be flagged there
+	for d := 1; d <= constants.GridSize; d++ {
+		if reference.Candidates[cleared].Has(d) && !board.Eliminated[cleared].Has(d) {
+			t.Errorf("digit %d of the cleared cell is not eliminated; fill-candidate would refill it", d)
+		}
+	}
+
+	if board.Marks[penciled] != MarksUserProvided {
+		t.Errorf("penciled cell marks state = %d, want MarksUserProvided", board.Marks[penciled])
+	}
+
+	// Cells the user never touched are still filled by the solver
+	for i, v := range givens {
+		if v != 0 || i == penciled || i == cleared {
+			continue
+		}
+		if board.Marks[i] != MarksSolverFilled {
+			t.Errorf("untouched cell %d marks state = %d, want MarksSolverFilled", i, board.Marks[i])
+		}
+		if board.Candidates[i].Count() == 0 {
+			t.Errorf("untouched cell %d has no candidates after InitCandidates", i)
+		}
+	}
+}
+
+// A grid of all-empty arrays is how frontends submit a fresh board; it must
+// keep behaving like "no candidates supplied", not like 81 cleared cells.
+func TestAllEmptyCandidateGridBehavesAsFresh(t *testing.T) {
+	givens := dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1)
+	candidates := make([][]int, constants.TotalCells)
+	for i := range candidates {
+		candidates[i] = []int{}
+	}
+
+	board := NewBoardWithCandidates(givens, candidates)
+	for i := range board.Marks {
+		if board.Marks[i] != MarksUnset {
+			t.Fatalf("cell %d marks state = %d on a fresh board, want MarksUnset", i, board.Marks[i])
+		}
+	}
+
+	board.InitCandidates()
+	for i, v := range givens {
+		if v == 0 && board.Candidates[i].Count() == 0 {
+			t.Errorf("fresh-board cell %d was left without candidates", i)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// tightenedCandidates derives the full candidate grid for givens and then
+// removes one justified candidate from the first cell holding more than one —
+// the shape of a board imported mid-game after an advanced elimination
+func tightenedCandidates(givens []int) [][]int {
+	reference := NewBoard(givens)
+	candidates := make([][]int, constants.TotalCells)
+	tightened := false
+	for i := 0; i < constants.TotalCells; i++ {
+		if givens[i] != 0 {
+			candidates[i] = []int{}
+			continue
+		}
+		digits := reference.Candidates[i].ToSlice()
+		if !tightened && len(digits) > 1 {
+			digits = digits[1:]
+			tightened = true
+		}
+		candidates[i] = digits
+	}
+	return candidates
+}
+
+func TestExternallyTightenedBoardIsUnratable(t *testing.T) {
+	givens := dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1)
+	solver := NewSolver()
+
+	board := NewBoardWithCandidates(givens, tightenedCandidates(givens))
+	if board.Source != CandidatesExternal {
+		t.Fatalf("Source = %v, want CandidatesExternal for submitted marks", board.Source)
+	}
+
+	difficulty, counts, status := solver.AnalyzeBoardDifficulty(board)
+	if status != constants.StatusUnratable {
+		t.Errorf("status = %q, want %q", status, constants.StatusUnratable)
+	}
+	if difficulty != "" || counts != nil {
+		t.Errorf("refused rating still returned difficulty %q, counts %v", difficulty, counts)
+	}
+
+	// The hint path is indifferent to provenance: the same board still
+	// yields a sound next move
+	if move := solver.FindNextMove(board); move == nil {
+		t.Error("FindNextMove returned nil on the external board")
+	}
+
+	// A derived board rates normally through the same entry point
+	derived := NewBoard(givens)
+	if derived.Source != CandidatesDerived {
+		t.Fatalf("Source = %v, want CandidatesDerived for NewBoard", derived.Source)
+	}
+	if _, _, status := solver.AnalyzeBoardDifficulty(derived); status != constants.StatusCompleted {
+		t.Errorf("derived board status = %q, want %q", status, constants.StatusCompleted)
+	}
+}
+
+func TestValidateCandidatesAgainstPeers(t *testing.T) {
+	givens := dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1)
+
+	// Derived candidates are exactly the peer-allowed sets; nothing to flag
+	derived := NewBoard(givens)
+	if stale := derived.ValidateCandidatesAgainstPeers(); len(stale) != 0 {
+		t.Fatalf("derived board flagged %v, want nothing", stale)
+	}
+
+	// Tighten one cell beyond what its peers justify
+	candidates := tightenedCandidates(givens)
+	staleIdx := -1
+	for i, c := range candidates {
+		if givens[i] == 0 && len(c) > 0 {
+			staleIdx = i
+			break
+		}
+	}
+	board := NewBoardWithCandidates(givens, candidates)
+
+	stale := board.ValidateCandidatesAgainstPeers()
+	if len(stale) != 1 {
+		t.Fatalf("flagged %d cells %v, want exactly the tightened one", len(stale), stale)
+	}
+	if got := stale[0].Row*constants.GridSize + stale[0].Col; got != staleIdx {
+		t.Errorf("flagged cell %d, want %d", got, staleIdx)
+	}
+}
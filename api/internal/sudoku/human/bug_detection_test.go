@@ -0,0 +1,77 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/human/techniques"
+)
+
+// bugTestCells returns a fully solved-looking cell array with the given
+// indices blanked out, so only the pattern cells are unsolved
+func bugTestCells(unsolved []int) [81]int {
+	var cells [81]int
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			cells[r*9+c] = (r*3+r/3+c)%9 + 1
+		}
+	}
+	for _, idx := range unsolved {
+		cells[idx] = 0
+	}
+	return cells
+}
+
+// TestDetectBUG_GenuineBUGPlusOne verifies the detector still fires on a true
+// BUG+1: two deadly rectangles where removing the extra digit from the BUG
+// cell leaves every candidate occurring exactly twice in all of its units
+func TestDetectBUG_GenuineBUGPlusOne(t *testing.T) {
+	bugCell := cellIdx(0, 0)
+	unsolved := []int{
+		bugCell, cellIdx(0, 3), cellIdx(1, 0), cellIdx(1, 3), // rectangle on {1,2}
+		cellIdx(0, 4), cellIdx(0, 6), cellIdx(2, 4), cellIdx(2, 6), // rectangle on {3,4}
+	}
+	cm := map[int][]int{
+		bugCell:       {1, 2, 3}, // extra digit 3 appears 3 times in row 1
+		cellIdx(0, 3): {1, 2},
+		cellIdx(1, 0): {1, 2},
+		cellIdx(1, 3): {1, 2},
+		cellIdx(0, 4): {3, 4},
+		cellIdx(0, 6): {3, 4},
+		cellIdx(2, 4): {3, 4},
+		cellIdx(2, 6): {3, 4},
+	}
+
+	board := makeTestBoard(bugTestCells(unsolved), cm)
+	move := techniques.DetectBUG(board)
+
+	if move == nil {
+		t.Fatal("expected BUG+1 move on a genuine BUG+1 board")
+	}
+	if move.Action != "assign" || move.Digit != 3 {
+		t.Errorf("expected assign of 3, got %s of %d", move.Action, move.Digit)
+	}
+	if len(move.Targets) != 1 || move.Targets[0].Row != 0 || move.Targets[0].Col != 0 {
+		t.Errorf("expected target R1C1, got %v", move.Targets)
+	}
+}
+
+// TestDetectBUG_NotABUGState is a regression test: one cell has 3 candidates
+// and a digit occurs 3 times in its row, but the remaining bi-value cells do
+// not form a BUG (their candidates occur 3 times in the row, not twice).
+// The old detector assigned here and the move later surfaced as a
+// contradiction
+func TestDetectBUG_NotABUGState(t *testing.T) {
+	bugCell := cellIdx(0, 0)
+	unsolved := []int{bugCell, cellIdx(0, 1), cellIdx(0, 2)}
+	cm := map[int][]int{
+		bugCell:       {1, 2, 3},
+		cellIdx(0, 1): {1, 2},
+		cellIdx(0, 2): {1, 2},
+	}
+
+	board := makeTestBoard(bugTestCells(unsolved), cm)
+
+	if move := techniques.DetectBUG(board); move != nil {
+		t.Errorf("expected no BUG move on a non-BUG board, got %s of %d at %v", move.Action, move.Digit, move.Targets)
+	}
+}
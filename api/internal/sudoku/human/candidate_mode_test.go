@@ -0,0 +1,97 @@
+package human
+
+import (
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// solveWithMode runs a fresh solver over a fresh board in one candidate mode
+func solveWithMode(givens []int, mode string) ([]core.Move, []int, string) {
+	board := NewBoard(givens)
+	solver := NewSolver()
+	moves, status := solver.SolveWithStepsOpts(board, constants.MaxSolverSteps, SolveOpts{CandidateMode: mode})
+	return moves, board.GetCells(), status
+}
+
+// TestCandidateModesAgree checks that the three candidate modes differ only
+// in bookkeeping: the final boards and the non-fill-candidate technique
+// sequences are identical across 100 seeds.
+func TestCandidateModesAgree(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping candidate mode sweep in short mode")
+	}
+
+	medium, _ := constants.DifficultyByName(constants.DifficultyMedium)
+	for seed := int64(1); seed <= 100; seed++ {
+		givens := dp.CarveGivens(dp.GenerateFullGridFast(seed), medium.TargetGivens, seed)
+
+		implicitMoves, implicitBoard, implicitStatus := solveWithMode(givens, CandidateModeImplicit)
+		noneMoves, noneBoard, noneStatus := solveWithMode(givens, CandidateModeNone)
+		batchedMoves, batchedBoard, batchedStatus := solveWithMode(givens, CandidateModeBatched)
+
+		if implicitStatus != noneStatus || implicitStatus != batchedStatus {
+			t.Fatalf("seed %d: statuses diverge: implicit=%s none=%s batched=%s",
+				seed, implicitStatus, noneStatus, batchedStatus)
+		}
+		if !reflect.DeepEqual(implicitBoard, noneBoard) || !reflect.DeepEqual(implicitBoard, batchedBoard) {
+			t.Fatalf("seed %d: final boards diverge between candidate modes", seed)
+		}
+
+		implicitTechniques := techniqueSequence(implicitMoves)
+		if got := techniqueSequence(noneMoves); !reflect.DeepEqual(implicitTechniques, got) {
+			t.Fatalf("seed %d: technique sequence diverges in mode none:\nimplicit: %v\nnone:     %v",
+				seed, implicitTechniques, got)
+		}
+		if got := techniqueSequence(batchedMoves); !reflect.DeepEqual(implicitTechniques, got) {
+			t.Fatalf("seed %d: technique sequence diverges in mode batched:\nimplicit: %v\nbatched:  %v",
+				seed, implicitTechniques, got)
+		}
+
+		// Mode none must emit no bookkeeping at all; mode batched exactly
+		// one synthetic move, first in the stream, carrying every mark the
+		// implicit stream added one by one
+		implicitFills := 0
+		for _, move := range implicitMoves {
+			if move.Technique == "fill-candidate" {
+				implicitFills++
+			}
+		}
+		for _, move := range noneMoves {
+			if move.Technique == "fill-candidate" {
+				t.Fatalf("seed %d: mode none emitted a fill-candidate move", seed)
+			}
+		}
+		batchedFills := 0
+		for i, move := range batchedMoves {
+			if move.Technique != "fill-candidate" {
+				continue
+			}
+			batchedFills++
+			if i != 0 {
+				t.Fatalf("seed %d: batched fill-candidate move at index %d, want 0", seed, i)
+			}
+			if move.CandidateDiff == nil || len(move.CandidateDiff.Added) != implicitFills {
+				t.Fatalf("seed %d: batched move carries %v adds, implicit emitted %d fills",
+					seed, move.CandidateDiff, implicitFills)
+			}
+		}
+		if implicitFills > 0 && batchedFills != 1 {
+			t.Fatalf("seed %d: expected exactly one batched fill-candidate move, got %d", seed, batchedFills)
+		}
+	}
+}
+
+// techniqueSequence lists the techniques of the non-bookkeeping moves in order
+func techniqueSequence(moves []core.Move) []string {
+	var seq []string
+	for _, move := range moves {
+		if move.Technique != "fill-candidate" {
+			seq = append(seq, move.Technique)
+		}
+	}
+	return seq
+}
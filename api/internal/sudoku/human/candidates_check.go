@@ -0,0 +1,131 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Candidate Checking
+// ============================================================================
+//
+// A soft check of the player's pencil marks: which marks are impossible, and
+// which cells are missing candidates, without handing over the next move.
+// Two strictness levels are supported:
+//
+//   CheckPeers:    only flags marks that conflict with currently filled peer
+//                  cells. Marks the player removed through advanced logic are
+//                  never flagged, and missing marks are only reported for
+//                  cells with no marks at all (a cell the player has penciled
+//                  may have legitimate eliminations we can't distinguish).
+//   CheckSolution: flags every mark that disagrees with the puzzle's unique
+//                  solution, and every empty cell whose marks don't include
+//                  its solution digit.
+
+// Strictness levels for DiffCandidates
+const (
+	CheckPeers    = "peers"
+	CheckSolution = "solution"
+)
+
+// CandidateDiff reports the differences between the player's pencil marks and
+// the true candidate set
+type CandidateDiff struct {
+	WrongMarks      []core.Candidate `json:"wrong_marks"`
+	MissingMarks    []core.Candidate `json:"missing_marks"`
+	CellsWithIssues []int            `json:"cells_with_issues"`
+}
+
+// DiffCandidates compares userCandidates against the true candidate set for
+// the board in cells, at the given strictness level. Filled cells are skipped;
+// givens are only needed for CheckSolution, where they define the puzzle being
+// solved. CellsWithIssues lists each affected cell once, in ascending order.
+func DiffCandidates(cells []int, userCandidates [][]int, givens []int, strictness string) (CandidateDiff, error) {
+	var diff CandidateDiff
+
+	if len(cells) != constants.TotalCells {
+		return diff, fmt.Errorf("cells must have %d entries", constants.TotalCells)
+	}
+	if userCandidates != nil && len(userCandidates) != constants.TotalCells {
+		return diff, fmt.Errorf("candidates must have %d entries", constants.TotalCells)
+	}
+
+	var solution []int
+	switch strictness {
+	case CheckPeers:
+		// handled below
+	case CheckSolution:
+		if len(givens) != constants.TotalCells {
+			return diff, fmt.Errorf("givens must have %d entries", constants.TotalCells)
+		}
+		solution = dp.Solve(givens)
+		if solution == nil {
+			return diff, fmt.Errorf("puzzle has no solution")
+		}
+	default:
+		return diff, fmt.Errorf("invalid strictness '%s'. Must be one of: %s, %s", strictness, CheckPeers, CheckSolution)
+	}
+
+	// Basic candidates from the current board state (filled peers only)
+	board := NewBoard(cells)
+
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if cells[idx] != 0 {
+			continue
+		}
+		row, col := idx/constants.GridSize, idx%constants.GridSize
+
+		var marks []int
+		if userCandidates != nil {
+			marks = userCandidates[idx]
+		}
+
+		hadIssue := false
+		allowed := board.GetCandidatesAt(idx)
+
+		for _, digit := range marks {
+			wrong := digit < 1 || digit > constants.GridSize || !allowed.Has(digit)
+			if solution != nil {
+				wrong = wrong || digit != solution[idx]
+			}
+			if wrong {
+				diff.WrongMarks = append(diff.WrongMarks, core.Candidate{Row: row, Col: col, Digit: digit})
+				hadIssue = true
+			}
+		}
+
+		if solution != nil {
+			// Solution mode: the cell must carry its solution digit
+			if !containsDigit(marks, solution[idx]) {
+				diff.MissingMarks = append(diff.MissingMarks, core.Candidate{Row: row, Col: col, Digit: solution[idx]})
+				hadIssue = true
+			}
+		} else if len(marks) == 0 {
+			// Peers mode: only unmarked cells are safe to report — a marked
+			// cell may have legitimate advanced eliminations
+			for _, digit := range allowed.ToSlice() {
+				diff.MissingMarks = append(diff.MissingMarks, core.Candidate{Row: row, Col: col, Digit: digit})
+				hadIssue = true
+			}
+		}
+
+		if hadIssue {
+			diff.CellsWithIssues = append(diff.CellsWithIssues, idx)
+		}
+	}
+
+	return diff, nil
+}
+
+// containsDigit reports whether digits includes digit
+func containsDigit(digits []int, digit int) bool {
+	for _, d := range digits {
+		if d == digit {
+			return true
+		}
+	}
+	return false
+}
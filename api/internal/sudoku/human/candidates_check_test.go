@@ -0,0 +1,161 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// diffTestPuzzle returns a carved puzzle, its full solution, and the index of
+// an empty cell for DiffCandidates tests
+func diffTestPuzzle(t *testing.T) (givens []int, solution []int, emptyCell int) {
+	t.Helper()
+	solution = dp.GenerateFullGrid(42)
+	givens = dp.CarveGivens(solution, 30, 42)
+	for i, v := range givens {
+		if v == 0 {
+			return givens, solution, i
+		}
+	}
+	t.Fatal("Carved puzzle has no empty cells")
+	return nil, nil, 0
+}
+
+func TestDiffCandidates_PeersMode(t *testing.T) {
+	givens, _, emptyCell := diffTestPuzzle(t)
+	board := NewBoard(givens)
+	allowed := board.GetCandidatesAt(emptyCell).ToSlice()
+
+	// Find a digit excluded by filled peers
+	excluded := 0
+	for d := 1; d <= constants.GridSize; d++ {
+		if !board.GetCandidatesAt(emptyCell).Has(d) {
+			excluded = d
+			break
+		}
+	}
+	if excluded == 0 {
+		t.Fatal("Expected at least one peer-excluded digit")
+	}
+
+	userCandidates := make([][]int, constants.TotalCells)
+	userCandidates[emptyCell] = append(append([]int{}, allowed...), excluded)
+
+	diff, err := DiffCandidates(givens, userCandidates, nil, CheckPeers)
+	if err != nil {
+		t.Fatalf("DiffCandidates failed: %v", err)
+	}
+
+	if len(diff.WrongMarks) != 1 {
+		t.Fatalf("Expected 1 wrong mark, got %d: %v", len(diff.WrongMarks), diff.WrongMarks)
+	}
+	if diff.WrongMarks[0].Digit != excluded {
+		t.Errorf("Expected wrong mark digit %d, got %d", excluded, diff.WrongMarks[0].Digit)
+	}
+
+	// Missing marks are reported only for unmarked cells: every empty cell
+	// except emptyCell should contribute its full basic candidate set
+	for _, m := range diff.MissingMarks {
+		idx := m.Row*constants.GridSize + m.Col
+		if idx == emptyCell {
+			t.Errorf("Expected no missing marks for the marked cell, got digit %d", m.Digit)
+		}
+		if !board.GetCandidatesAt(idx).Has(m.Digit) {
+			t.Errorf("Missing mark %v is not a basic candidate", m)
+		}
+	}
+}
+
+// TestDiffCandidates_PeersModeNoFalsePositives is the guarantee that makes
+// peers mode a soft check: marks removed through advanced logic (down to just
+// the solution digit) must never be flagged
+func TestDiffCandidates_PeersModeNoFalsePositives(t *testing.T) {
+	givens, solution, _ := diffTestPuzzle(t)
+
+	userCandidates := make([][]int, constants.TotalCells)
+	for i, v := range givens {
+		if v == 0 {
+			userCandidates[i] = []int{solution[i]}
+		}
+	}
+
+	diff, err := DiffCandidates(givens, userCandidates, nil, CheckPeers)
+	if err != nil {
+		t.Fatalf("DiffCandidates failed: %v", err)
+	}
+
+	if len(diff.WrongMarks) != 0 {
+		t.Errorf("Expected no wrong marks, got %v", diff.WrongMarks)
+	}
+	if len(diff.MissingMarks) != 0 {
+		t.Errorf("Expected no missing marks for marked cells, got %v", diff.MissingMarks)
+	}
+	if len(diff.CellsWithIssues) != 0 {
+		t.Errorf("Expected no cells with issues, got %v", diff.CellsWithIssues)
+	}
+}
+
+func TestDiffCandidates_SolutionMode(t *testing.T) {
+	givens, solution, emptyCell := diffTestPuzzle(t)
+	board := NewBoard(givens)
+
+	// Find a digit allowed by peers but not the solution digit
+	wrongButAllowed := 0
+	for _, d := range board.GetCandidatesAt(emptyCell).ToSlice() {
+		if d != solution[emptyCell] {
+			wrongButAllowed = d
+			break
+		}
+	}
+	if wrongButAllowed == 0 {
+		t.Fatal("Expected an allowed non-solution digit")
+	}
+
+	userCandidates := make([][]int, constants.TotalCells)
+	for i, v := range givens {
+		if v == 0 {
+			userCandidates[i] = []int{solution[i]}
+		}
+	}
+	// Replace the correct mark with a peer-legal but wrong one
+	userCandidates[emptyCell] = []int{wrongButAllowed}
+
+	// Peers mode accepts it
+	diff, err := DiffCandidates(givens, userCandidates, nil, CheckPeers)
+	if err != nil {
+		t.Fatalf("DiffCandidates failed: %v", err)
+	}
+	if len(diff.WrongMarks) != 0 {
+		t.Errorf("Expected peers mode to accept the mark, got %v", diff.WrongMarks)
+	}
+
+	// Solution mode flags it as wrong and reports the missing solution digit
+	diff, err = DiffCandidates(givens, userCandidates, givens, CheckSolution)
+	if err != nil {
+		t.Fatalf("DiffCandidates failed: %v", err)
+	}
+	if len(diff.WrongMarks) != 1 || diff.WrongMarks[0].Digit != wrongButAllowed {
+		t.Errorf("Expected wrong mark for digit %d, got %v", wrongButAllowed, diff.WrongMarks)
+	}
+	if len(diff.MissingMarks) != 1 || diff.MissingMarks[0].Digit != solution[emptyCell] {
+		t.Errorf("Expected missing mark for digit %d, got %v", solution[emptyCell], diff.MissingMarks)
+	}
+	if len(diff.CellsWithIssues) != 1 || diff.CellsWithIssues[0] != emptyCell {
+		t.Errorf("Expected cell %d flagged, got %v", emptyCell, diff.CellsWithIssues)
+	}
+}
+
+func TestDiffCandidates_Validation(t *testing.T) {
+	givens, _, _ := diffTestPuzzle(t)
+
+	if _, err := DiffCandidates(givens, nil, givens, "strict"); err == nil {
+		t.Error("Expected error for unknown strictness")
+	}
+	if _, err := DiffCandidates([]int{1, 2, 3}, nil, nil, CheckPeers); err == nil {
+		t.Error("Expected error for short board")
+	}
+	if _, err := DiffCandidates(givens, nil, nil, CheckSolution); err == nil {
+		t.Error("Expected error when solution mode lacks givens")
+	}
+}
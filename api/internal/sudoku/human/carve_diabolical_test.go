@@ -0,0 +1,87 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// diabolicalSpec returns the active diabolical spec
+func diabolicalSpec(t *testing.T) constants.DifficultySpec {
+	t.Helper()
+	spec, ok := constants.DifficultyByName(constants.DifficultyDiabolical)
+	if !ok {
+		t.Fatal("no diabolical spec in the active difficulty list")
+	}
+	return spec
+}
+
+// TestCarveDiabolicalRequiresExtremeTechnique carves a diabolical puzzle from
+// a seed known to admit one and checks the tier's guarantees: the givens stay
+// a subset of the impossible tier, the solution stays unique, the solve proof
+// counts at least one technique from the tier's required set, and a solver
+// with that set disabled stalls — the extreme technique is genuinely
+// required, not merely used.
+func TestCarveDiabolicalRequiresExtremeTechnique(t *testing.T) {
+	spec := diabolicalSpec(t)
+	seed := int64(62) // carves to a satisfying diabolical puzzle
+
+	full := dp.GenerateFullGridFast(seed)
+	carved, _ := dp.CarveGivensWithSubset(full, seed)
+	impossible := carved[constants.DifficultyImpossible]
+
+	givens, ok := CarveDiabolical(impossible, seed)
+	if !ok {
+		t.Fatalf("seed %d: no diabolical carve; pick a seed that admits one", seed)
+	}
+
+	// Subset property against the tier it was carved from
+	for pos := range givens {
+		if givens[pos] != 0 && givens[pos] != impossible[pos] {
+			t.Errorf("diabolical given at cell %d missing from the impossible givens", pos)
+		}
+	}
+	if !dp.HasUniqueSolution(givens) {
+		t.Fatal("diabolical givens do not determine the solution uniquely")
+	}
+
+	// The solve proof must include a required (extreme-tier) technique
+	solver := NewSolver()
+	_, counts, status := solver.AnalyzePuzzleDifficulty(givens)
+	if status != constants.StatusCompleted {
+		t.Fatalf("human solver did not complete the diabolical puzzle: %s", status)
+	}
+	used := false
+	for _, slug := range spec.RequireAnyOf {
+		if counts[slug] > 0 {
+			used = true
+			break
+		}
+	}
+	if !used {
+		t.Errorf("solve proof %v contains none of the required techniques %v", counts, spec.RequireAnyOf)
+	}
+
+	// Without the required set, the tier budget cannot finish
+	restricted, err := NewSolverWithConfig(SolverConfig{MaxTier: spec.MaxTier, Disabled: spec.RequireAnyOf})
+	if err != nil {
+		t.Fatalf("NewSolverWithConfig: %v", err)
+	}
+	if _, status := restricted.SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps); status == constants.StatusCompleted {
+		t.Error("puzzle solvable without any required technique; diabolical predicate does not hold")
+	}
+}
+
+// TestCarveDiabolicalReportsMiss checks the honest-failure path: a seed whose
+// impossible carve cannot be deepened to the predicate returns ok=false so
+// cmd/generate omits the tier instead of shipping a mislabeled puzzle.
+func TestCarveDiabolicalReportsMiss(t *testing.T) {
+	seed := int64(1) // known not to admit a diabolical carve
+	full := dp.GenerateFullGridFast(seed)
+	carved, _ := dp.CarveGivensWithSubset(full, seed)
+
+	if givens, ok := CarveDiabolical(carved[constants.DifficultyImpossible], seed); ok {
+		t.Errorf("seed %d unexpectedly carved a diabolical puzzle with %d givens", seed, countGivens(givens))
+	}
+}
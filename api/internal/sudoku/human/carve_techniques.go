@@ -0,0 +1,237 @@
+package human
+
+import (
+	"math/rand"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Technique-Driven Carving
+// ============================================================================
+//
+// The classic carve (dp.CarveGivensWithSubset) defines each difficulty by
+// how many givens remain, which makes "hard" vary wildly between seeds.
+// Technique-driven carving defines each tier by what the solve path demands
+// instead: cells are removed one at a time, the puzzle is re-analyzed with
+// AnalyzePuzzleDifficulty after every removal, and a tier is captured the
+// moment its predicate holds — the solve requires at least one technique
+// from the spec's RequireAnyOf set (the restricted solver stalls without
+// them) while staying inside the spec's MaxTier budget.
+//
+// Tiers are carved in sequence from one shared puzzle, so the subset
+// property (harder givens ⊂ easier givens) holds by construction. When the
+// shared chain cannot reach a tier's predicate the tier is re-carved alone
+// from the full grid; that carve abandons the subset property against the
+// earlier tiers and is flagged SubsetBroken so callers can decide whether
+// to keep it.
+
+// CarveFlags reports how a technique-driven carve deviated from the ideal
+// for one tier.
+type CarveFlags struct {
+	// SubsetBroken means the tier was carved independently of the easier
+	// tiers, so its givens are not a subset of theirs
+	SubsetBroken bool
+	// Unsatisfied means no carve attempt met the tier's predicate; the
+	// returned givens are the deepest best-effort carve
+	Unsatisfied bool
+}
+
+// defaultRequireAnyOf maps each built-in difficulty to the techniques that
+// define it in technique-driven carving. The slugs stay inside the tier
+// budget the difficulty already declares (DifficultySpec.MaxTier).
+var defaultRequireAnyOf = map[string][]string{
+	constants.DifficultyMedium:     {"naked-quad", "hidden-quad", "unique-rectangle", "simple-coloring", "bug"},
+	constants.DifficultyHard:       {"x-wing", "swordfish", "xy-wing"},
+	constants.DifficultyExtreme:    {"jellyfish", "skyscraper", "x-chain", "xy-chain", "w-wing"},
+	constants.DifficultyImpossible: {"finned-x-wing", "finned-swordfish", "aic", "als-xz", "forcing-chain"},
+}
+
+// DefaultTechniqueCarveSpecs returns the active difficulty specs with the
+// built-in RequireAnyOf sets filled in, ready for CarveGivensByTechniques.
+func DefaultTechniqueCarveSpecs() []constants.DifficultySpec {
+	base := constants.DifficultySpecs()
+	specs := make([]constants.DifficultySpec, len(base))
+	copy(specs, base)
+	for i := range specs {
+		// Specs that declare their own set (diabolical) keep it
+		if len(specs[i].RequireAnyOf) == 0 {
+			specs[i].RequireAnyOf = defaultRequireAnyOf[specs[i].Name]
+		}
+	}
+	return specs
+}
+
+// independentCarveAttempts bounds the fresh-grid retries for a tier the
+// shared chain could not satisfy
+const independentCarveAttempts = 3
+
+// CarveGivensByTechniques carves one puzzle per spec, defining each tier by
+// its technique predicate rather than a given count. Specs arrive easiest
+// first, as in dp.CarveGivensForSpecs. The returned flags map records, per
+// tier, whether the subset property was given up or the predicate never
+// held; unknown slugs in a RequireAnyOf set never match and surface as
+// Unsatisfied rather than an error, matching the carve functions' no-error
+// signatures.
+func CarveGivensByTechniques(fullGrid []int, seed int64, specs []constants.DifficultySpec) (map[string][]int, map[string]CarveFlags) {
+	solver := NewSolver()
+	rng := rand.New(rand.NewSource(seed + 1)) // offset seed for carving
+
+	puzzle := make([]int, constants.TotalCells)
+	copy(puzzle, fullGrid)
+
+	result := make(map[string][]int, len(specs))
+	flags := make(map[string]CarveFlags, len(specs))
+
+	for _, spec := range specs {
+		if carveUntilSatisfied(solver, puzzle, spec, rng) {
+			flags[spec.Name] = CarveFlags{}
+		} else {
+			// The shared chain bottomed out; re-carve this tier alone from
+			// the full grid, trading the subset property for the predicate
+			carved := false
+			for attempt := 0; attempt < independentCarveAttempts && !carved; attempt++ {
+				fresh := make([]int, constants.TotalCells)
+				copy(fresh, fullGrid)
+				if carveUntilSatisfied(solver, fresh, spec, rng) {
+					// Later tiers chain from the independent carve so they
+					// at least subset this one
+					puzzle = fresh
+					carved = true
+				}
+			}
+			flags[spec.Name] = CarveFlags{SubsetBroken: carved, Unsatisfied: !carved}
+		}
+
+		tierPuzzle := make([]int, constants.TotalCells)
+		copy(tierPuzzle, puzzle)
+		result[spec.Name] = tierPuzzle
+	}
+
+	return result, flags
+}
+
+// CarveDiabolical continues a finished impossible-tier carve down to the
+// diabolical tier: cells keep coming out while the solution stays unique, the
+// human solver still completes inside the tier budget, and the solve
+// genuinely requires one of the tier's RequireAnyOf techniques. Carving
+// onward from the impossible givens preserves the subset property by
+// construction. Returns the carved givens and whether the predicate was met;
+// the tier is bank-only, so callers (cmd/generate) omit it for seeds where it
+// was not rather than degrade it to a weaker definition.
+func CarveDiabolical(impossible []int, seed int64) ([]int, bool) {
+	spec, ok := constants.DifficultyByName(constants.DifficultyDiabolical)
+	if !ok || len(spec.RequireAnyOf) == 0 {
+		return nil, false
+	}
+
+	solver := NewSolver()
+	puzzle := make([]int, constants.TotalCells)
+	copy(puzzle, impossible)
+
+	// The impossible carve may already require one of the techniques; the
+	// tiers then coincide, which the subset property permits
+	_, counts, status := solver.AnalyzePuzzleDifficulty(puzzle)
+	if status == constants.StatusCompleted && carveSpecSatisfied(puzzle, counts, spec) {
+		return puzzle, true
+	}
+
+	rng := rand.New(rand.NewSource(seed + 2)) // offset past the classic carve
+	if carveUntilSatisfied(solver, puzzle, spec, rng) {
+		return puzzle, true
+	}
+	return nil, false
+}
+
+// carveUntilSatisfied removes cells from puzzle in a rng-shuffled order until
+// the spec's predicate holds, keeping the solution unique and undoing any
+// removal that pushes the solve past the spec's tier budget or stalls the
+// human solver. It reports whether the predicate was met before the
+// positions ran out.
+func carveUntilSatisfied(solver *Solver, puzzle []int, spec constants.DifficultySpec, rng *rand.Rand) bool {
+	for _, pos := range rng.Perm(constants.TotalCells) {
+		if puzzle[pos] == 0 {
+			continue
+		}
+
+		oldVal := puzzle[pos]
+		puzzle[pos] = 0
+
+		if !dp.HasUniqueSolution(puzzle) {
+			puzzle[pos] = oldVal
+			continue
+		}
+
+		_, counts, status := solver.AnalyzePuzzleDifficulty(puzzle)
+		if status != constants.StatusCompleted || exceedsTierBudget(solver, counts, spec.MaxTier) {
+			puzzle[pos] = oldVal
+			continue
+		}
+
+		if carveSpecSatisfied(puzzle, counts, spec) {
+			return true
+		}
+	}
+	return false
+}
+
+// carveSpecSatisfied reports whether the carved puzzle meets the spec's
+// predicate. A spec without RequireAnyOf falls back to its given-count
+// target; otherwise the solve must use one of the listed techniques and
+// genuinely require the set — a solver with all of them disabled stalls.
+func carveSpecSatisfied(puzzle []int, counts map[string]int, spec constants.DifficultySpec) bool {
+	if len(spec.RequireAnyOf) == 0 {
+		return countGivens(puzzle) <= spec.TargetGivens
+	}
+
+	used := false
+	for _, slug := range spec.RequireAnyOf {
+		if counts[slug] > 0 {
+			used = true
+			break
+		}
+	}
+	if !used {
+		return false
+	}
+
+	// "Requires" is judged inside the tier budget: capped to MaxTier with
+	// the required set disabled, the solve must stall. Without the cap a
+	// harder technique (an x-chain subsumes an x-wing, say) would always
+	// substitute and nothing would ever count as required.
+	restricted, err := NewSolverWithConfig(SolverConfig{MaxTier: spec.MaxTier, Disabled: spec.RequireAnyOf})
+	if err != nil {
+		restricted = CreateSolverWithoutTechniques(spec.RequireAnyOf...)
+	}
+	_, status := restricted.SolveWithStepsOpts(NewBoard(puzzle), constants.MaxSolverSteps, SolveOpts{CandidateMode: CandidateModeNone})
+	return status != constants.StatusCompleted
+}
+
+// exceedsTierBudget reports whether any counted technique sits above the
+// tier budget. An empty or unknown budget imposes no cap; bookkeeping moves
+// without a registry entry are ignored.
+func exceedsTierBudget(solver *Solver, counts map[string]int, maxTier string) bool {
+	maxRank, ok := tierRank[maxTier]
+	if !ok {
+		return false
+	}
+	registry := solver.GetRegistry()
+	for slug := range counts {
+		if tech := registry.GetBySlug(slug); tech != nil && tierRank[tech.Tier] > maxRank {
+			return true
+		}
+	}
+	return false
+}
+
+// countGivens counts the filled cells of a puzzle
+func countGivens(puzzle []int) int {
+	givens := 0
+	for _, v := range puzzle {
+		if v != 0 {
+			givens++
+		}
+	}
+	return givens
+}
@@ -0,0 +1,94 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// carveSeedStride separates retry seeds from the base range, mirroring
+// cmd/generate's regeneration loop: not every grid admits a carve meeting a
+// technique predicate, so unsatisfied seeds are retried from distant ones.
+const carveSeedStride = int64(1) << 33
+
+// hardCarveSpec returns the built-in hard spec with its RequireAnyOf set
+func hardCarveSpec(t *testing.T) constants.DifficultySpec {
+	t.Helper()
+	for _, spec := range DefaultTechniqueCarveSpecs() {
+		if spec.Name == constants.DifficultyHard {
+			return spec
+		}
+	}
+	t.Fatal("no hard spec in the default technique carve specs")
+	return constants.DifficultySpec{}
+}
+
+// TestCarveByTechniquesHardRequiresListed generates hard puzzles across 50
+// base seeds (retrying unsatisfied grids the way cmd/generate does) and
+// re-solves each with restricted solvers: capped to the tier budget without
+// the required set the solve must stall, and capped with the full budget it
+// must complete — i.e. every puzzle requires a listed technique and nothing
+// beyond the budget.
+func TestCarveByTechniquesHardRequiresListed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping carve sweep in short mode")
+	}
+
+	spec := hardCarveSpec(t)
+	specs := []constants.DifficultySpec{spec}
+
+	for baseSeed := int64(1); baseSeed <= 50; baseSeed++ {
+		var givens []int
+		for attempt := int64(0); ; attempt++ {
+			if attempt >= 32 {
+				t.Fatalf("base seed %d: no satisfying carve in %d attempts", baseSeed, attempt)
+			}
+			seed := baseSeed + attempt*carveSeedStride
+			puzzles, flags := CarveGivensByTechniques(dp.GenerateFullGrid(seed), seed, specs)
+			if !flags[spec.Name].Unsatisfied {
+				givens = puzzles[spec.Name]
+				break
+			}
+		}
+
+		// Without the required techniques, the tier budget cannot finish
+		restricted, err := NewSolverWithConfig(SolverConfig{MaxTier: spec.MaxTier, Disabled: spec.RequireAnyOf})
+		if err != nil {
+			t.Fatalf("NewSolverWithConfig: %v", err)
+		}
+		if _, status := restricted.SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps); status == constants.StatusCompleted {
+			t.Errorf("base seed %d: puzzle solvable without any of %v", baseSeed, spec.RequireAnyOf)
+		}
+
+		// With the full budget (and nothing harder), it must complete
+		capped := NewSolverUpToTier(spec.MaxTier)
+		if _, status := capped.SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps); status != constants.StatusCompleted {
+			t.Errorf("base seed %d: puzzle not solvable within tier budget %s", baseSeed, spec.MaxTier)
+		}
+	}
+}
+
+// TestCarveByTechniquesSubset checks the subset property on a seed whose
+// chain carves every tier without falling back to an independent carve.
+func TestCarveByTechniquesSubset(t *testing.T) {
+	specs := DefaultTechniqueCarveSpecs()[:3] // easy, medium, hard
+	seed := int64(7)
+
+	puzzles, flags := CarveGivensByTechniques(dp.GenerateFullGrid(seed), seed, specs)
+	for _, spec := range specs {
+		if f := flags[spec.Name]; f.SubsetBroken || f.Unsatisfied {
+			t.Fatalf("seed %d %s tier flagged %+v; pick a seed whose chain carves cleanly", seed, spec.Name, f)
+		}
+	}
+
+	// Harder tiers keep a subset of the easier tiers' givens
+	for i := 1; i < len(specs); i++ {
+		easier, harder := puzzles[specs[i-1].Name], puzzles[specs[i].Name]
+		for pos := range harder {
+			if harder[pos] != 0 && harder[pos] != easier[pos] {
+				t.Fatalf("%s given at cell %d missing from %s", specs[i].Name, pos, specs[i-1].Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// Tier cascade: the auto-pencil-mark assist. After a placement, casual
+// players want the cheap bookkeeping eliminations (locked candidates,
+// subsets) applied for them without the solver filling any cells or
+// reaching for harder logic. The cascade repeatedly runs only the detectors
+// at or below a tier cap, applying each hit, until no detector fires.
+
+// CascadeMaxMoves caps a single cascade. Every applied move removes at
+// least one candidate, so a legitimate cascade is bounded well below this;
+// the cap only exists to terminate on degenerate boards where a detector
+// keeps proposing a move that makes no progress.
+const CascadeMaxMoves = 500
+
+// ApplyTierCascade repeatedly runs the enabled detectors at or below
+// maxTier, applying each move to the board as it goes, until quiescence —
+// no detector at those tiers finds anything. Unless allowAssignments is
+// set, assignment moves (singles) are skipped: an assist should tidy pencil
+// marks, not fill cells. Returns the moves applied, in order; the board is
+// left in the cascaded state. Errors on an unknown tier, on a move that no
+// longer fits the board, or when the cap is hit before quiescence.
+func (s *Solver) ApplyTierCascade(b *Board, maxTier string, allowAssignments bool) ([]core.Move, error) {
+	maxRank, ok := tierRank[maxTier]
+	if !ok {
+		return nil, fmt.Errorf("unknown tier %q", maxTier)
+	}
+
+	var applied []core.Move
+	for {
+		move := s.findCascadeMove(b, maxRank, allowAssignments)
+		if move == nil {
+			return applied, nil
+		}
+		move.StepIndex = len(applied)
+		if _, err := s.ApplyMove(b, move); err != nil {
+			// A detector proposed a move the board rejects; re-scanning
+			// would just propose it again, so stop instead of looping
+			return applied, fmt.Errorf("cascade stopped: %s move does not fit the board: %w", move.Technique, err)
+		}
+		applied = append(applied, *move)
+		if len(applied) >= CascadeMaxMoves {
+			return applied, fmt.Errorf("cascade did not reach quiescence within %d moves", CascadeMaxMoves)
+		}
+	}
+}
+
+// findCascadeMove returns the first detector hit at or below the rank cap,
+// in registry order, skipping assignment moves unless they are allowed
+func (s *Solver) findCascadeMove(b *Board, maxRank int, allowAssignments bool) *core.Move {
+	for _, tier := range tierScanOrder {
+		if tierRank[tier] > maxRank {
+			break
+		}
+		for _, t := range s.registry.GetByTier(tier) {
+			move := s.runDetector(t, b)
+			if move == nil {
+				continue
+			}
+			if move.Action == constants.ActionAssign && !allowAssignments {
+				continue
+			}
+			return decorateMove(move, t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human/techniques"
+	"sudoku-api/pkg/constants"
+)
+
+// cascadeTestGivens carves the seed-1 puzzle, whose simple-tier cascade is
+// known to include pointing-pair and naked-pair eliminations among others
+func cascadeTestGivens() []int {
+	full := dp.GenerateFullGridFast(1)
+	return dp.CarveGivens(full, 30, 1)
+}
+
+func TestApplyTierCascadeChainsEliminations(t *testing.T) {
+	board := NewBoard(cascadeTestGivens())
+	before := board.GetCells()
+
+	moves, err := NewSolver().ApplyTierCascade(board, constants.TierSimple, false)
+	if err != nil {
+		t.Fatalf("ApplyTierCascade failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for i, move := range moves {
+		counts[move.Technique]++
+		if move.Action != constants.ActionEliminate {
+			t.Errorf("move %d (%s): action %q, want eliminations only", i, move.Technique, move.Action)
+		}
+		if move.StepIndex != i {
+			t.Errorf("move %d: StepIndex = %d", i, move.StepIndex)
+		}
+	}
+	// One call picks up both the pointing pair and the naked pair its
+	// eliminations expose
+	if counts["pointing-pair"] == 0 || counts["naked-pair"] == 0 {
+		t.Errorf("cascade counts = %v, want pointing-pair and naked-pair in one call", counts)
+	}
+
+	// Assists tidy pencil marks; no cell value may change
+	after := board.GetCells()
+	for i := range before {
+		if after[i] != before[i] {
+			t.Errorf("cell %d changed %d -> %d with allowAssignments false", i, before[i], after[i])
+		}
+	}
+
+	// The cascade ran to quiescence: an immediate rerun finds nothing
+	again, err := NewSolver().ApplyTierCascade(board, constants.TierSimple, false)
+	if err != nil {
+		t.Fatalf("rerun failed: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("rerun found %d more moves, cascade was not quiescent", len(again))
+	}
+}
+
+func TestApplyTierCascadeAllowAssignments(t *testing.T) {
+	board := NewBoard(cascadeTestGivens())
+	before := board.GetCells()
+
+	moves, err := NewSolver().ApplyTierCascade(board, constants.TierSimple, true)
+	if err != nil {
+		t.Fatalf("ApplyTierCascade failed: %v", err)
+	}
+
+	assigned := 0
+	for _, move := range moves {
+		if move.Action == constants.ActionAssign {
+			assigned++
+		}
+	}
+	if assigned == 0 {
+		t.Fatal("expected assignment moves with allowAssignments true")
+	}
+	filled := 0
+	for i, v := range board.GetCells() {
+		if before[i] == 0 && v != 0 {
+			filled++
+		}
+	}
+	if filled == 0 {
+		t.Error("assignments reported but no cell was filled")
+	}
+}
+
+func TestApplyTierCascadeUnknownTier(t *testing.T) {
+	if _, err := NewSolver().ApplyTierCascade(NewBoard(cascadeTestGivens()), "legendary", false); err == nil {
+		t.Error("expected an error for an unknown tier")
+	}
+}
+
+// TestApplyTierCascadeCapsNonProgressingLoop wires a detector that keeps
+// returning an elimination-free move — applying it changes nothing, so
+// without the cap the cascade would rescan and reapply it forever.
+func TestApplyTierCascadeCapsNonProgressingLoop(t *testing.T) {
+	registry := NewTechniqueRegistry()
+	registry.GetBySlug("x-wing").Detector = func(b techniques.BoardInterface) *core.Move {
+		return &core.Move{Technique: "x-wing", Action: constants.ActionEliminate}
+	}
+
+	// An empty board gives the real simple-tier detectors nothing to find,
+	// leaving only the stubbed non-progressing move
+	board := NewBoard(make([]int, constants.TotalCells))
+	moves, err := NewSolverWithRegistry(registry).ApplyTierCascade(board, constants.TierMedium, false)
+	if err == nil {
+		t.Fatal("expected the loop cap to surface an error")
+	}
+	if len(moves) != CascadeMaxMoves {
+		t.Errorf("applied %d moves before stopping, want the cap %d", len(moves), CascadeMaxMoves)
+	}
+}
@@ -0,0 +1,122 @@
+package human
+
+import (
+	"time"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// maxMaskedDetections caps how many times the single-result detector of a
+// technique without a DetectorAll is re-run with earlier matches masked out,
+// and maskedDetectionBudget bounds the wall time spent doing so: chain and
+// ALS detectors can take seconds on pathological boards, and a cell-targeted
+// hint is best-effort beyond the first few matches.
+const (
+	maxMaskedDetections   = 8
+	maskedDetectionBudget = 250 * time.Millisecond
+)
+
+// FindMoveForCell searches the registry in tier order for the cheapest
+// enabled technique whose move touches the target cell: an assignment into
+// it, or an elimination of one of its candidates. When no technique touches
+// the cell directly, the nearest move whose highlights include the cell or
+// one of its peers is returned instead, flagged Indirect. Returns nil when
+// no enabled technique produces any move relating to the cell.
+func (s *Solver) FindMoveForCell(b *Board, idx int) *core.Move {
+	var nearest *core.Move
+
+	for _, tier := range tierScanOrder {
+		for _, t := range s.registry.GetByTier(tier) {
+			for _, move := range s.movesForTechnique(t, b) {
+				decorateMove(move, t)
+				if moveTouchesCell(move, idx) {
+					return move
+				}
+				if nearest == nil && moveHighlightsCellOrPeer(move, idx) {
+					nearest = move
+				}
+			}
+		}
+	}
+
+	if nearest != nil {
+		indirect := *nearest
+		indirect.Indirect = true
+		return &indirect
+	}
+	return nil
+}
+
+// movesForTechnique returns every match a technique currently has on the
+// board. Techniques with a DetectorAll report all matches directly; for the
+// rest, the single-result detector is looped on a scratch clone with each
+// returned move's eliminations applied, masking it from the next pass. An
+// assignment can't be masked that way without advancing the board past the
+// submitted position, so it ends the loop.
+func (s *Solver) movesForTechnique(t TechniqueDescriptor, b *Board) []*core.Move {
+	if t.RequiresUniqueness && !b.AssumeUnique {
+		return nil
+	}
+	if t.DetectorAll != nil {
+		return t.DetectorAll(b)
+	}
+
+	var moves []*core.Move
+	scratch := b.Clone()
+	deadline := time.Now().Add(maskedDetectionBudget)
+	for i := 0; i < maxMaskedDetections && !time.Now().After(deadline); i++ {
+		move := s.runDetector(t, scratch)
+		if move == nil {
+			break
+		}
+		moves = append(moves, move)
+		if move.Action != constants.ActionEliminate {
+			break
+		}
+		progressed := false
+		for _, elim := range move.Eliminations {
+			if scratch.RemoveCandidate(elim.Row*constants.GridSize+elim.Col, elim.Digit) {
+				progressed = true
+			}
+		}
+		if !progressed {
+			// Masking had no effect, so the detector would just repeat itself
+			break
+		}
+	}
+	return moves
+}
+
+// moveTouchesCell reports whether a move assigns the cell or eliminates one
+// of its candidates
+func moveTouchesCell(move *core.Move, idx int) bool {
+	row, col := idx/constants.GridSize, idx%constants.GridSize
+	if move.Action == constants.ActionAssign {
+		for _, target := range move.Targets {
+			if target.Row == row && target.Col == col {
+				return true
+			}
+		}
+	}
+	for _, elim := range move.Eliminations {
+		if elim.Row == row && elim.Col == col {
+			return true
+		}
+	}
+	return false
+}
+
+// moveHighlightsCellOrPeer reports whether any highlighted cell is the target
+// cell or sees it
+func moveHighlightsCellOrPeer(move *core.Move, idx int) bool {
+	for _, refs := range [][]core.CellRef{move.Highlights.Primary, move.Highlights.Secondary} {
+		for _, ref := range refs {
+			other := ref.Row*constants.GridSize + ref.Col
+			if other == idx || ArePeers(other, idx) {
+				return true
+			}
+		}
+	}
+	return false
+}
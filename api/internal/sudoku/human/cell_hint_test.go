@@ -0,0 +1,82 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/human/techniques"
+	"sudoku-api/pkg/constants"
+)
+
+// cellHintBoard builds a sparse board where cell 20 is a naked single and
+// cell 4 is a hidden single (5 appears nowhere else in row 0). A plain
+// FindNextMove would report the naked single first, so the target-cell
+// search must look past it.
+func cellHintBoard() *Board {
+	cells := make([]int, constants.TotalCells)
+	candidates := make([][]int, constants.TotalCells)
+	candidates[0] = []int{1, 2}
+	candidates[1] = []int{1, 3}
+	candidates[2] = []int{3, 4}
+	candidates[3] = []int{4, 6}
+	candidates[4] = []int{2, 5} // target: hidden single on 5
+	candidates[5] = []int{6, 8}
+	candidates[6] = []int{8, 9}
+	candidates[7] = []int{9, 1}
+	candidates[8] = []int{3, 4}
+	candidates[13] = []int{2, 3}
+	candidates[20] = []int{7} // naked single elsewhere
+	return NewBoardWithCandidates(cells, candidates)
+}
+
+func TestFindMoveForCellPrefersTechniqueTouchingCell(t *testing.T) {
+	solver := NewSolver()
+	board := cellHintBoard()
+
+	// Sanity: the plain registry scan would stop at the naked single in R3C3
+	first := techniques.DetectNakedSingle(board)
+	if first == nil || len(first.Targets) == 0 || first.Targets[0] != (core.CellRef{Row: 2, Col: 2}) {
+		t.Fatalf("expected the naked single at R3C3 to be found first, got %+v", first)
+	}
+
+	move := solver.FindMoveForCell(board, 4)
+	if move == nil {
+		t.Fatal("expected a move for cell 4, got nil")
+	}
+	if move.Technique != "hidden-single" {
+		t.Errorf("expected hidden-single for cell 4, got %q", move.Technique)
+	}
+	if move.Action != constants.ActionAssign {
+		t.Errorf("expected an assign move, got %q", move.Action)
+	}
+	found := false
+	for _, target := range move.Targets {
+		if target.Row == 0 && target.Col == 4 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected move to target R1C5, got targets %v", move.Targets)
+	}
+	if move.Indirect {
+		t.Error("a move touching the cell must not be flagged indirect")
+	}
+}
+
+func TestFindMoveForCellIndirectFallback(t *testing.T) {
+	solver := NewSolver()
+	board := cellHintBoard()
+
+	// Cell 12 has no candidates and nothing assigns or eliminates there, but
+	// the hidden singles in its box highlight its peers
+	move := solver.FindMoveForCell(board, 12)
+	if move == nil {
+		t.Fatal("expected an indirect move for cell 12, got nil")
+	}
+	if !move.Indirect {
+		t.Errorf("expected move to be flagged indirect, got %+v", move)
+	}
+	if moveTouchesCell(move, 12) {
+		t.Error("indirect move must not touch the requested cell")
+	}
+}
@@ -0,0 +1,101 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Binary Board Codec
+// ============================================================================
+//
+// A compact wire format for board state, used by the WASM transferable
+// typed-array interface to avoid per-element JS marshaling:
+//
+//   cells:      one byte per cell (0 = empty, 1-9 = digit), 81 bytes
+//   candidates: one little-endian uint16 bitmask per cell, 162 bytes, where
+//               bit d set means digit d+1 is possible (bit 0 = digit 1)
+//
+// Note the wire bitmask is shifted down one bit from the internal
+// techniques.Candidates layout (which leaves bit 0 unused) so the full mask
+// for 9 digits fits the conventional 0x1FF.
+
+// CandidateMaskBytes is the encoded size of the candidate masks: two bytes
+// per cell, little-endian
+const CandidateMaskBytes = constants.TotalCells * 2
+
+// EncodeBoard packs cell values into one byte per cell
+func EncodeBoard(cells []int) ([]byte, error) {
+	if len(cells) != constants.TotalCells {
+		return nil, fmt.Errorf("cells must have %d elements, got %d", constants.TotalCells, len(cells))
+	}
+	buf := make([]byte, constants.TotalCells)
+	for i, v := range cells {
+		if v < 0 || v > constants.GridSize {
+			return nil, fmt.Errorf("cell %d holds invalid value %d", i, v)
+		}
+		buf[i] = byte(v)
+	}
+	return buf, nil
+}
+
+// DecodeBoard unpacks one byte per cell into cell values
+func DecodeBoard(buf []byte) ([]int, error) {
+	if len(buf) != constants.TotalCells {
+		return nil, fmt.Errorf("board buffer must have %d bytes, got %d", constants.TotalCells, len(buf))
+	}
+	cells := make([]int, constants.TotalCells)
+	for i, b := range buf {
+		if int(b) > constants.GridSize {
+			return nil, fmt.Errorf("cell %d holds invalid value %d", i, b)
+		}
+		cells[i] = int(b)
+	}
+	return cells, nil
+}
+
+// EncodeCandidates packs per-cell candidate lists into little-endian uint16
+// bitmasks (bit d = digit d+1). A nil inner slice encodes as an empty mask.
+func EncodeCandidates(candidates [][]int) ([]byte, error) {
+	if len(candidates) != constants.TotalCells {
+		return nil, fmt.Errorf("candidates must have %d elements, got %d", constants.TotalCells, len(candidates))
+	}
+	buf := make([]byte, CandidateMaskBytes)
+	for i, digits := range candidates {
+		var mask uint16
+		for _, d := range digits {
+			if d < 1 || d > constants.GridSize {
+				return nil, fmt.Errorf("cell %d holds invalid candidate %d", i, d)
+			}
+			mask |= 1 << (d - 1)
+		}
+		buf[2*i] = byte(mask)
+		buf[2*i+1] = byte(mask >> 8)
+	}
+	return buf, nil
+}
+
+// DecodeCandidates unpacks little-endian uint16 bitmasks into per-cell
+// candidate lists. Cells with no candidates decode as empty (non-nil) slices
+// so callers can hand the result straight to NewBoardWithCandidates.
+func DecodeCandidates(buf []byte) ([][]int, error) {
+	if len(buf) != CandidateMaskBytes {
+		return nil, fmt.Errorf("candidate buffer must have %d bytes, got %d", CandidateMaskBytes, len(buf))
+	}
+	candidates := make([][]int, constants.TotalCells)
+	for i := 0; i < constants.TotalCells; i++ {
+		mask := uint16(buf[2*i]) | uint16(buf[2*i+1])<<8
+		if mask>>constants.GridSize != 0 {
+			return nil, fmt.Errorf("cell %d holds invalid candidate mask %#x", i, mask)
+		}
+		digits := []int{}
+		for d := 1; d <= constants.GridSize; d++ {
+			if mask&(1<<(d-1)) != 0 {
+				digits = append(digits, d)
+			}
+		}
+		candidates[i] = digits
+	}
+	return candidates, nil
+}
@@ -0,0 +1,137 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+func TestBoardCodecRoundTrip(t *testing.T) {
+	cells := make([]int, constants.TotalCells)
+	for i := range cells {
+		cells[i] = i % (constants.GridSize + 1) // mix of empties and digits
+	}
+
+	buf, err := EncodeBoard(cells)
+	if err != nil {
+		t.Fatalf("EncodeBoard failed: %v", err)
+	}
+	if len(buf) != constants.TotalCells {
+		t.Fatalf("Expected %d bytes, got %d", constants.TotalCells, len(buf))
+	}
+
+	decoded, err := DecodeBoard(buf)
+	if err != nil {
+		t.Fatalf("DecodeBoard failed: %v", err)
+	}
+	for i, v := range cells {
+		if decoded[i] != v {
+			t.Fatalf("Cell %d: expected %d, got %d", i, v, decoded[i])
+		}
+	}
+}
+
+func TestBoardCodecErrors(t *testing.T) {
+	if _, err := EncodeBoard(make([]int, 10)); err == nil {
+		t.Error("Expected error for short cell slice")
+	}
+	bad := make([]int, constants.TotalCells)
+	bad[3] = constants.GridSize + 1
+	if _, err := EncodeBoard(bad); err == nil {
+		t.Error("Expected error for out-of-range cell value")
+	}
+
+	if _, err := DecodeBoard(make([]byte, 10)); err == nil {
+		t.Error("Expected error for short board buffer")
+	}
+	badBuf := make([]byte, constants.TotalCells)
+	badBuf[7] = constants.GridSize + 1
+	if _, err := DecodeBoard(badBuf); err == nil {
+		t.Error("Expected error for out-of-range byte value")
+	}
+}
+
+func TestCandidateCodecRoundTrip(t *testing.T) {
+	candidates := make([][]int, constants.TotalCells)
+	for i := range candidates {
+		switch i % 3 {
+		case 0:
+			candidates[i] = []int{}
+		case 1:
+			candidates[i] = []int{1, constants.GridSize}
+		default:
+			candidates[i] = []int{2, 4, 7}
+		}
+	}
+
+	buf, err := EncodeCandidates(candidates)
+	if err != nil {
+		t.Fatalf("EncodeCandidates failed: %v", err)
+	}
+	if len(buf) != CandidateMaskBytes {
+		t.Fatalf("Expected %d bytes, got %d", CandidateMaskBytes, len(buf))
+	}
+
+	decoded, err := DecodeCandidates(buf)
+	if err != nil {
+		t.Fatalf("DecodeCandidates failed: %v", err)
+	}
+	for i := range candidates {
+		if len(decoded[i]) != len(candidates[i]) {
+			t.Fatalf("Cell %d: expected %v, got %v", i, candidates[i], decoded[i])
+		}
+		for j, d := range candidates[i] {
+			if decoded[i][j] != d {
+				t.Fatalf("Cell %d: expected %v, got %v", i, candidates[i], decoded[i])
+			}
+		}
+	}
+}
+
+func TestCandidateCodecBitConvention(t *testing.T) {
+	// Wire format: bit d set = digit d+1 possible, so digit 1 lands in bit 0
+	// and all 9 digits fill 0x1FF
+	candidates := make([][]int, constants.TotalCells)
+	for i := range candidates {
+		candidates[i] = []int{}
+	}
+	candidates[0] = []int{1}
+	candidates[1] = []int{constants.GridSize}
+	candidates[2] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	buf, err := EncodeCandidates(candidates)
+	if err != nil {
+		t.Fatalf("EncodeCandidates failed: %v", err)
+	}
+
+	mask := func(i int) uint16 { return uint16(buf[2*i]) | uint16(buf[2*i+1])<<8 }
+	if mask(0) != 0x001 {
+		t.Errorf("Digit 1 should set bit 0, got mask %#x", mask(0))
+	}
+	if mask(1) != 1<<(constants.GridSize-1) {
+		t.Errorf("Digit %d should set bit %d, got mask %#x", constants.GridSize, constants.GridSize-1, mask(1))
+	}
+	if mask(2) != 0x1FF {
+		t.Errorf("Full candidate set should be 0x1FF, got %#x", mask(2))
+	}
+}
+
+func TestCandidateCodecErrors(t *testing.T) {
+	if _, err := EncodeCandidates(make([][]int, 10)); err == nil {
+		t.Error("Expected error for short candidate slice")
+	}
+	bad := make([][]int, constants.TotalCells)
+	bad[5] = []int{0}
+	if _, err := EncodeCandidates(bad); err == nil {
+		t.Error("Expected error for out-of-range candidate digit")
+	}
+
+	if _, err := DecodeCandidates(make([]byte, 10)); err == nil {
+		t.Error("Expected error for short candidate buffer")
+	}
+	badBuf := make([]byte, CandidateMaskBytes)
+	badBuf[1] = 0x02 // bit 9 set = digit 10
+	if _, err := DecodeCandidates(badBuf); err == nil {
+		t.Error("Expected error for mask with bits above digit 9")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !sudokudebug
+
+package human
+
+import "sudoku-api/internal/core"
+
+// guardedDetect runs a technique's detector. Purity checking is compiled in
+// only under the sudokudebug build tag (see detector_guard_debug.go).
+func guardedDetect(t TechniqueDescriptor, b *Board) *core.Move {
+	return t.Detector(b)
+}
+
+// assertMoveFresh checks that an assign move still fits the board when it is
+// about to be applied. Compiled in only under the sudokudebug build tag (see
+// detector_guard_debug.go).
+func assertMoveFresh(b *Board, move *core.Move) {}
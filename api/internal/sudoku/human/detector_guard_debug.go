@@ -0,0 +1,36 @@
+//go:build sudokudebug
+
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// guardedDetect runs a technique's detector and panics if detection mutated
+// the board. Detectors must be pure - only ApplyMove may change board state -
+// and this debug-only check lets the stress CLIs catch regressions when built
+// with -tags sudokudebug.
+func guardedDetect(t TechniqueDescriptor, b *Board) *core.Move {
+	before := *b
+	move := t.Detector(b)
+	if err := diffBoardSnapshot(&before, b); err != nil {
+		panic(fmt.Sprintf("detector %s mutated the board during detection: %v", t.Slug, err))
+	}
+	return move
+}
+
+// assertMoveFresh panics when an assign move the solver is about to apply no
+// longer fits the board - the target must still hold the digit as a
+// candidate. A failure here means a move was computed against stale board
+// state (for example, held across intervening fill-candidate moves).
+func assertMoveFresh(b *Board, move *core.Move) {
+	if move.Action != constants.ActionAssign {
+		return
+	}
+	if err := validateMove(b, move); err != nil {
+		panic(fmt.Sprintf("stale %s move at application time: %v", move.Technique, err))
+	}
+}
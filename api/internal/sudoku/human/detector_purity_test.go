@@ -0,0 +1,65 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// purityTestBoards generates a spread of board states: fresh puzzles at every
+// difficulty plus snapshots taken mid-solve, so detectors see realistic
+// partial eliminations and near-complete grids
+func purityTestBoards(t *testing.T) []*Board {
+	t.Helper()
+	var boards []*Board
+	for seed := int64(1); seed <= 8; seed++ {
+		fullGrid := dp.GenerateFullGrid(seed)
+		puzzles, _ := dp.CarveGivensWithSubset(fullGrid, seed)
+		for _, givens := range puzzles {
+			b := NewBoard(givens)
+			boards = append(boards, b.Clone())
+
+			// Solve the puzzle, snapshotting the board every few moves
+			solver := NewSolver()
+			for step := 0; step < constants.MaxSolverSteps; step++ {
+				move := solver.FindNextMove(b)
+				if move == nil || move.Action == constants.ActionContradiction {
+					break
+				}
+				solver.ApplyMove(b, move)
+				if step%8 == 7 {
+					boards = append(boards, b.Clone())
+				}
+			}
+		}
+	}
+	return boards
+}
+
+// TestDetectorsArePure runs every registered detector against a few hundred
+// generated board states and asserts detection never mutates the board.
+// ApplyMove is the only code allowed to change Cells, Candidates or
+// Eliminated; a detector that fails here must be rewritten to work on local
+// copies (see WrapDetectorWithSnapshot).
+func TestDetectorsArePure(t *testing.T) {
+	boards := purityTestBoards(t)
+	if len(boards) < 200 {
+		t.Fatalf("expected a few hundred board states, got %d", len(boards))
+	}
+
+	registry := NewTechniqueRegistry()
+	for _, tech := range registry.GetAll() {
+		if tech.Detector == nil {
+			continue
+		}
+		wrapped := WrapDetectorWithSnapshot(tech.Detector)
+		t.Run(tech.Slug, func(t *testing.T) {
+			for i, b := range boards {
+				if _, err := wrapped(b); err != nil {
+					t.Fatalf("board %d: %v", i, err)
+				}
+			}
+		})
+	}
+}
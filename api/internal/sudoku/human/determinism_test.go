@@ -0,0 +1,81 @@
+package human
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// =============================================================================
+// DETERMINISM TESTS
+//
+// FindNextMove must return the identical move every time it is asked about
+// the same board: session replay and bug reports depend on it. Go randomizes
+// map iteration order per map, so any detector that picks "the first"
+// combination out of a map will eventually disagree with itself. Repeating
+// the call many times per board is enough to surface such a detector —
+// no special GODEBUG settings are required.
+// =============================================================================
+
+// determinismRunsPerBoard is how often FindNextMove is repeated per board.
+const determinismRunsPerBoard = 50
+
+// determinismBoards is how many carved boards the test covers. Boards are
+// spread across all difficulty tiers so every technique family gets hit.
+const determinismBoards = 200
+
+func TestFindNextMoveDeterministic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping determinism sweep in short mode")
+	}
+
+	specs := constants.DifficultySpecs()
+	solver := NewSolver()
+
+	for i := 0; i < determinismBoards; i++ {
+		seed := int64(9000 + i)
+		spec := specs[i%len(specs)]
+
+		full := dp.GenerateFullGrid(seed)
+		givens := dp.CarveGivens(full, spec.TargetGivens, seed)
+
+		// Advance a board-dependent number of steps first, so the probed
+		// position needs more than singles and the sweep reaches the
+		// pattern and chain detectors too
+		board := NewBoard(givens)
+		board.InitCandidates()
+		solver.Reset()
+		for step := 0; step < i%40; step++ {
+			move := solver.FindNextMove(board)
+			if move == nil {
+				break
+			}
+			solver.ApplyMove(board, move)
+		}
+
+		solver.Reset()
+		reference := solver.FindNextMove(board)
+
+		for run := 1; run < determinismRunsPerBoard; run++ {
+			solver.Reset()
+			move := solver.FindNextMove(board)
+
+			if !reflect.DeepEqual(reference, move) {
+				t.Fatalf("seed %d (%s): run %d returned a different move\nfirst: %s\n now: %s",
+					seed, spec.Name, run, describeMoveForDiff(reference), describeMoveForDiff(move))
+			}
+		}
+	}
+}
+
+// describeMoveForDiff formats a move (or nil) for failure output
+func describeMoveForDiff(m *core.Move) string {
+	if m == nil {
+		return "<no move>"
+	}
+	return fmt.Sprintf("%+v", *m)
+}
@@ -0,0 +1,92 @@
+package human
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Move Diffs
+// ============================================================================
+//
+// A move changes a handful of cells and pencil marks, but the board snapshots
+// returned to clients repeat all 81 cells and their candidates every time.
+// The helpers here compute the compact core.MoveDiff representation instead:
+// ApplyMove diffs the board around each application, and DiffStates diffs two
+// wire-format snapshots for callers that mutate the board outside ApplyMove
+// (error fixes, board resets).
+
+// diffSnapshots compares a pre-move snapshot against the board's current
+// state and returns the cells and candidates that changed
+func diffSnapshots(prevCells [constants.TotalCells]int, prevCands [constants.TotalCells]Candidates, b *Board) core.MoveDiff {
+	var diff core.MoveDiff
+	for i := 0; i < constants.TotalCells; i++ {
+		if b.Cells[i] != prevCells[i] {
+			diff.Cells = append(diff.Cells, core.CellDiff{Index: i, Old: prevCells[i], New: b.Cells[i]})
+		}
+		changed := prevCands[i] ^ b.Candidates[i]
+		if changed == 0 {
+			continue
+		}
+		row, col := i/constants.GridSize, i%constants.GridSize
+		for d := 1; d <= constants.GridSize; d++ {
+			if !changed.Has(d) {
+				continue
+			}
+			if b.Candidates[i].Has(d) {
+				diff.Candidates.Added = append(diff.Candidates.Added, core.Candidate{Row: row, Col: col, Digit: d})
+			} else {
+				diff.Candidates.Removed = append(diff.Candidates.Removed, core.Candidate{Row: row, Col: col, Digit: d})
+			}
+		}
+	}
+	return diff
+}
+
+// DiffStates computes the cell and candidate changes between two board
+// snapshots in the []int / [][]int wire representation. Either candidates
+// slice may be nil or shorter than the board; missing entries count as no
+// candidates.
+func DiffStates(prevCells []int, prevCands [][]int, cells []int, cands [][]int) core.MoveDiff {
+	var diff core.MoveDiff
+	for i := 0; i < constants.TotalCells; i++ {
+		oldVal, newVal := snapshotCell(prevCells, i), snapshotCell(cells, i)
+		if oldVal != newVal {
+			diff.Cells = append(diff.Cells, core.CellDiff{Index: i, Old: oldVal, New: newVal})
+		}
+		oldCands, newCands := snapshotCandidates(prevCands, i), snapshotCandidates(cands, i)
+		changed := oldCands ^ newCands
+		if changed == 0 {
+			continue
+		}
+		row, col := i/constants.GridSize, i%constants.GridSize
+		for d := 1; d <= constants.GridSize; d++ {
+			if !changed.Has(d) {
+				continue
+			}
+			if newCands.Has(d) {
+				diff.Candidates.Added = append(diff.Candidates.Added, core.Candidate{Row: row, Col: col, Digit: d})
+			} else {
+				diff.Candidates.Removed = append(diff.Candidates.Removed, core.Candidate{Row: row, Col: col, Digit: d})
+			}
+		}
+	}
+	return diff
+}
+
+// snapshotCell reads a cell from a possibly short snapshot slice
+func snapshotCell(cells []int, idx int) int {
+	if idx >= len(cells) {
+		return 0
+	}
+	return cells[idx]
+}
+
+// snapshotCandidates reads a cell's candidates from a possibly sparse
+// snapshot as a bitmask
+func snapshotCandidates(cands [][]int, idx int) Candidates {
+	if cands == nil || idx >= len(cands) || cands[idx] == nil {
+		return 0
+	}
+	return NewCandidates(cands[idx])
+}
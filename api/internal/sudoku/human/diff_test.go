@@ -0,0 +1,95 @@
+package human
+
+import (
+	"encoding/json"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// TestApplyDiffsReconstructsFinalBoard solves many puzzles while collecting
+// the per-move diffs returned by ApplyMove, then checks that replaying the
+// diffs on the initial board via core.ApplyDiffs reproduces the solver's
+// final board exactly. It also compares the JSON size of the diff stream
+// against full per-move board/candidate snapshots, since the delta format
+// exists to shrink the /solve/all payload.
+func TestApplyDiffsReconstructsFinalBoard(t *testing.T) {
+	const (
+		seeds    = 100
+		maxMoves = 1000
+	)
+
+	// The move description itself is identical in both formats; the state
+	// portion (full board+candidates snapshot vs diff) is what the delta
+	// format replaces, so that is what gets measured
+	type fullSnapshot struct {
+		Board      []int   `json:"board"`
+		Candidates [][]int `json:"candidates"`
+	}
+
+	solver := NewSolver()
+	var fullBytes, deltaBytes, moveBytes int
+
+	for seed := int64(1); seed <= seeds; seed++ {
+		fullGrid := dp.GenerateFullGrid(seed)
+		givens := dp.CarveGivens(fullGrid, 24, seed)
+		// Start without candidates, as autosolve clients do: the solver then
+		// fills pencil marks one move at a time, which is what makes full
+		// per-move snapshots so repetitive on long solves
+		board := NewBoardWithCandidates(givens, nil)
+		solver.Reset()
+
+		initial := board.GetCells()
+		var diffs []core.MoveDiff
+		var fullMoves []fullSnapshot
+		var moves []*core.Move
+		for i := 0; i < maxMoves && !board.IsSolved(); i++ {
+			move := solver.FindNextMove(board)
+			if move == nil || move.Action == constants.ActionContradiction {
+				break
+			}
+			diff, err := solver.ApplyMove(board, move)
+			if err != nil {
+				t.Fatalf("ApplyMove rejected a solver-found move: %v", err)
+			}
+			diffs = append(diffs, diff)
+			fullMoves = append(fullMoves, fullSnapshot{board.GetCells(), board.GetCandidates()})
+			moves = append(moves, move)
+		}
+
+		reconstructed := core.ApplyDiffs(initial, diffs)
+		final := board.GetCells()
+		for i := range final {
+			if reconstructed[i] != final[i] {
+				t.Fatalf("seed %d: cell %d reconstructed as %d, solver has %d",
+					seed, i, reconstructed[i], final[i])
+			}
+		}
+
+		fullJSON, err := json.Marshal(fullMoves)
+		if err != nil {
+			t.Fatalf("seed %d: marshal full snapshots: %v", seed, err)
+		}
+		deltaJSON, err := json.Marshal(diffs)
+		if err != nil {
+			t.Fatalf("seed %d: marshal diffs: %v", seed, err)
+		}
+		movesJSON, err := json.Marshal(moves)
+		if err != nil {
+			t.Fatalf("seed %d: marshal moves: %v", seed, err)
+		}
+		fullBytes += len(fullJSON)
+		deltaBytes += len(deltaJSON)
+		moveBytes += len(movesJSON)
+	}
+
+	stateReduction := 100 * (1 - float64(deltaBytes)/float64(fullBytes))
+	totalReduction := 100 * (1 - float64(deltaBytes+moveBytes)/float64(fullBytes+moveBytes))
+	t.Logf("state payload across %d solves: full %d bytes, delta %d bytes (%.1f%% smaller; %.1f%% including move descriptions)",
+		seeds, fullBytes, deltaBytes, stateReduction, totalReduction)
+	if stateReduction < 80 {
+		t.Errorf("expected the delta format to shrink state payloads by >80%%, got %.1f%%", stateReduction)
+	}
+}
@@ -0,0 +1,179 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Answer kinds returned by ExplainCell
+const (
+	ExplainPlacedPeer   = "placed-peer"
+	ExplainEliminatedBy = "eliminated-by"
+	ExplainRequired     = "required"
+	ExplainPossible     = "possible"
+)
+
+// CellExplanation answers "why is digit D impossible or required at this
+// cell". Kind selects which detail field is meaningful: PeerCell for
+// placed-peer, Technique for eliminated-by, Unit for required.
+type CellExplanation struct {
+	Kind        string `json:"kind"`
+	Cell        int    `json:"cell"`
+	Digit       int    `json:"digit"`
+	PeerCell    int    `json:"peer_cell"` // placed-peer: the cell holding the conflicting digit, -1 otherwise
+	Technique   string `json:"technique,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Explanation string `json:"explanation"`
+}
+
+// ExplainCell explains why digit is impossible or required at the given cell.
+// The answer is one of:
+//
+//   - placed-peer: a cell that may not share the digit already contains it
+//   - eliminated-by: a technique removed the candidate (found by replaying
+//     the solver when the board carries no provenance)
+//   - required: the digit is the cell's only candidate, or a hidden single
+//     in one of its units
+//   - possible: no reason either way
+func ExplainCell(b *Board, idx, digit int) (*CellExplanation, error) {
+	if idx < 0 || idx >= constants.TotalCells {
+		return nil, fmt.Errorf("cell index %d out of range", idx)
+	}
+	if digit < 1 || digit > constants.GridSize {
+		return nil, fmt.Errorf("digit %d out of range", digit)
+	}
+	if b.Cells[idx] != 0 {
+		return nil, fmt.Errorf("cell %d is already filled", idx)
+	}
+
+	row, col := RowOf(idx), ColOf(idx)
+	answer := &CellExplanation{Kind: ExplainPossible, Cell: idx, Digit: digit, PeerCell: -1}
+
+	// A filled cell that may not share the digit rules it out directly
+	if peer := conflictingPeer(b, idx, digit); peer >= 0 {
+		answer.Kind = ExplainPlacedPeer
+		answer.PeerCell = peer
+		answer.Explanation = fmt.Sprintf("%d cannot go in R%dC%d: R%dC%d already contains %d.",
+			digit, row+1, col+1, RowOf(peer)+1, ColOf(peer)+1, b.Cells[peer])
+		return answer, nil
+	}
+
+	if b.Candidates[idx].Has(digit) {
+		// Sole remaining candidate in the cell
+		if only, ok := b.Candidates[idx].Only(); ok && only == digit {
+			answer.Kind = ExplainRequired
+			answer.Unit = "cell"
+			answer.Explanation = fmt.Sprintf("%d is the only candidate left in R%dC%d.", digit, row+1, col+1)
+			return answer, nil
+		}
+		// Hidden single in one of the cell's units
+		if unit, ok := hiddenSingleUnit(b, idx, digit); ok {
+			answer.Kind = ExplainRequired
+			answer.Unit = unit
+			answer.Explanation = fmt.Sprintf("R%dC%d is the only cell in %s that can hold %d.", row+1, col+1, unit, digit)
+			return answer, nil
+		}
+		answer.Explanation = fmt.Sprintf("%d is still possible in R%dC%d.", digit, row+1, col+1)
+		return answer, nil
+	}
+
+	// The candidate is absent without a direct conflict, so it was eliminated.
+	// The board carries no provenance, so replay the solver from the cells
+	// until some technique removes it.
+	if technique := findEliminationTechnique(b, idx, digit); technique != "" {
+		answer.Kind = ExplainEliminatedBy
+		answer.Technique = technique
+		answer.Explanation = fmt.Sprintf("%d was eliminated from R%dC%d by %s.", digit, row+1, col+1, technique)
+		return answer, nil
+	}
+
+	// No technique justifies the missing pencil mark; report it as possible
+	answer.Explanation = fmt.Sprintf("%d is still possible in R%dC%d.", digit, row+1, col+1)
+	return answer, nil
+}
+
+// conflictingPeer returns the index of a filled cell that makes digit
+// impossible at idx, or -1. Covers classic peers, variant same-digit peers,
+// and non-consecutive neighbors.
+func conflictingPeer(b *Board, idx, digit int) int {
+	for _, peer := range Peers[idx] {
+		if b.Cells[peer] == digit {
+			return peer
+		}
+	}
+	if b.Constraints.IsClassic() {
+		return -1
+	}
+	for j := 0; j < constants.TotalCells; j++ {
+		if b.Cells[j] == digit && ArePeersWithConstraints(idx, j, b.Constraints) {
+			return j
+		}
+	}
+	if b.Constraints.NonConsecutive {
+		row, col := RowOf(idx), ColOf(idx)
+		for _, off := range [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			r, c := row+off[0], col+off[1]
+			if r < 0 || r >= constants.GridSize || c < 0 || c >= constants.GridSize {
+				continue
+			}
+			neighbor := IndexOf(r, c)
+			if b.Cells[neighbor] == digit-1 || b.Cells[neighbor] == digit+1 {
+				return neighbor
+			}
+		}
+	}
+	return -1
+}
+
+// hiddenSingleUnit reports the first unit of idx where digit can go nowhere
+// else, as a display name like "row 3"
+func hiddenSingleUnit(b *Board, idx, digit int) (string, bool) {
+	units := []struct {
+		name  string
+		cells []int
+	}{
+		{fmt.Sprintf("row %d", RowOf(idx)+1), RowIndices[RowOf(idx)]},
+		{fmt.Sprintf("column %d", ColOf(idx)+1), ColIndices[ColOf(idx)]},
+		{fmt.Sprintf("box %d", BoxOf(idx)+1), BoxIndices[BoxOf(idx)]},
+	}
+	for _, unit := range units {
+		alone := true
+		for _, cell := range unit.cells {
+			if cell != idx && b.Cells[cell] == 0 && b.Candidates[cell].Has(digit) {
+				alone = false
+				break
+			}
+		}
+		if alone {
+			return unit.name, true
+		}
+	}
+	return "", false
+}
+
+// findEliminationTechnique rebuilds the board from its cells and replays the
+// solver until a move removes digit from idx, returning that move's
+// technique. Returns "" when no technique eliminates it within the step
+// budget (or the cell gets filled first).
+func findEliminationTechnique(b *Board, idx, digit int) string {
+	replay := NewBoardWithConstraints(b.GetCells(), b.Constraints)
+	if !replay.Candidates[idx].Has(digit) {
+		return ""
+	}
+	solver := NewSolver()
+	for step := 0; step < constants.MaxSolverSteps; step++ {
+		move := solver.FindNextMove(replay)
+		if move == nil || move.Action == constants.ActionContradiction {
+			return ""
+		}
+		solver.ApplyMoveUnchecked(replay, move)
+		if replay.Cells[idx] != 0 {
+			return ""
+		}
+		if !replay.Candidates[idx].Has(digit) {
+			return move.Technique
+		}
+	}
+	return ""
+}
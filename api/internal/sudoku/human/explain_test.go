@@ -0,0 +1,141 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+func TestExplainCell_PlacedPeer(t *testing.T) {
+	// Row 0 holds a 5 at C1; asking about 5 anywhere else in that row must
+	// point back at it
+	cells := make([]int, constants.TotalCells)
+	cells[0] = 5
+	b := NewBoard(cells)
+
+	answer, err := ExplainCell(b, 4, 5)
+	if err != nil {
+		t.Fatalf("ExplainCell: %v", err)
+	}
+	if answer.Kind != ExplainPlacedPeer {
+		t.Fatalf("Kind = %s, want %s", answer.Kind, ExplainPlacedPeer)
+	}
+	if answer.PeerCell != 0 {
+		t.Errorf("PeerCell = %d, want 0", answer.PeerCell)
+	}
+}
+
+func TestExplainCell_Required(t *testing.T) {
+	t.Run("sole candidate in the cell", func(t *testing.T) {
+		cells := make([]int, constants.TotalCells)
+		candidates := make([][]int, constants.TotalCells)
+		for i := range candidates {
+			candidates[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+		}
+		candidates[40] = []int{7}
+		b := NewBoardWithCandidates(cells, candidates)
+
+		answer, err := ExplainCell(b, 40, 7)
+		if err != nil {
+			t.Fatalf("ExplainCell: %v", err)
+		}
+		if answer.Kind != ExplainRequired {
+			t.Fatalf("Kind = %s, want %s", answer.Kind, ExplainRequired)
+		}
+		if answer.Unit != "cell" {
+			t.Errorf("Unit = %q, want \"cell\"", answer.Unit)
+		}
+	})
+
+	t.Run("hidden single in a row", func(t *testing.T) {
+		cells := make([]int, constants.TotalCells)
+		candidates := make([][]int, constants.TotalCells)
+		for i := range candidates {
+			candidates[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+		}
+		// 7 can only go in C5 of row 0
+		for col := 0; col < constants.GridSize; col++ {
+			if col != 4 {
+				candidates[col] = []int{1, 2, 3, 4, 5, 6, 8, 9}
+			}
+		}
+		b := NewBoardWithCandidates(cells, candidates)
+
+		answer, err := ExplainCell(b, 4, 7)
+		if err != nil {
+			t.Fatalf("ExplainCell: %v", err)
+		}
+		if answer.Kind != ExplainRequired {
+			t.Fatalf("Kind = %s, want %s", answer.Kind, ExplainRequired)
+		}
+		if answer.Unit != "row 1" {
+			t.Errorf("Unit = %q, want \"row 1\"", answer.Unit)
+		}
+	})
+}
+
+func TestExplainCell_Possible(t *testing.T) {
+	cells := make([]int, constants.TotalCells)
+	b := NewBoard(cells)
+
+	answer, err := ExplainCell(b, 40, 7)
+	if err != nil {
+		t.Fatalf("ExplainCell: %v", err)
+	}
+	if answer.Kind != ExplainPossible {
+		t.Fatalf("Kind = %s, want %s", answer.Kind, ExplainPossible)
+	}
+}
+
+func TestExplainCell_EliminatedBy(t *testing.T) {
+	// Solve a real puzzle until a technique eliminates a candidate, then ask
+	// about that candidate on the resulting board: the replay fallback must
+	// name a technique
+	fullGrid := dp.GenerateFullGrid(7)
+	puzzles, _ := dp.CarveGivensWithSubset(fullGrid, 7)
+	b := NewBoard(puzzles["impossible"])
+	solver := NewSolver()
+
+	for step := 0; step < constants.MaxSolverSteps; step++ {
+		move := solver.FindNextMove(b)
+		if move == nil || move.Action == constants.ActionContradiction {
+			t.Fatal("puzzle produced no technique elimination")
+		}
+		if move.Action == constants.ActionEliminate && len(move.Eliminations) > 0 {
+			elim := move.Eliminations[0]
+			idx := IndexOf(elim.Row, elim.Col)
+			solver.ApplyMove(b, move)
+
+			answer, err := ExplainCell(b, idx, elim.Digit)
+			if err != nil {
+				t.Fatalf("ExplainCell: %v", err)
+			}
+			if answer.Kind != ExplainEliminatedBy {
+				t.Fatalf("Kind = %s, want %s", answer.Kind, ExplainEliminatedBy)
+			}
+			if answer.Technique == "" {
+				t.Error("expected a technique name for the elimination")
+			}
+			return
+		}
+		solver.ApplyMove(b, move)
+	}
+	t.Fatal("solver never produced an elimination move")
+}
+
+func TestExplainCell_Errors(t *testing.T) {
+	cells := make([]int, constants.TotalCells)
+	cells[0] = 5
+	b := NewBoard(cells)
+
+	if _, err := ExplainCell(b, 0, 5); err == nil {
+		t.Error("expected error for a filled cell")
+	}
+	if _, err := ExplainCell(b, -1, 5); err == nil {
+		t.Error("expected error for an out-of-range cell")
+	}
+	if _, err := ExplainCell(b, 4, 10); err == nil {
+		t.Error("expected error for an out-of-range digit")
+	}
+}
@@ -0,0 +1,89 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// explanationLangs are the languages every move must render in
+var explanationLangs = []string{"en", "es"}
+
+// assertMoveExplains checks a move carries a message key whose parameters
+// satisfy the templates in every bundled language
+func assertMoveExplains(t *testing.T, context string, move *core.Move) {
+	t.Helper()
+
+	if move.ExplanationKey == "" {
+		t.Errorf("%s: move (%s) has no explanation key", context, move.Technique)
+		return
+	}
+	if move.Explanation == "" {
+		t.Errorf("%s: move (%s) has no rendered explanation", context, move.Technique)
+	}
+	for _, lang := range explanationLangs {
+		if _, err := core.RenderExplanation(move.ExplanationKey, move.ExplanationParams, lang); err != nil {
+			t.Errorf("%s: move (%s) does not render in %s: %v", context, move.Technique, lang, err)
+		}
+	}
+}
+
+// TestSolverMovesCarryExplanationKeys solves generated puzzles at every
+// difficulty and asserts each move the solver emits renders cleanly in all
+// bundled languages.
+func TestSolverMovesCarryExplanationKeys(t *testing.T) {
+	for seed := int64(1); seed <= 4; seed++ {
+		fullGrid := dp.GenerateFullGrid(seed)
+		puzzles, _ := dp.CarveGivensWithSubset(fullGrid, seed)
+		for difficulty, givens := range puzzles {
+			board := NewBoard(givens)
+			solver := NewSolver()
+			for step := 0; step < constants.MaxSolverSteps; step++ {
+				move := solver.FindNextMove(board)
+				if move == nil {
+					break
+				}
+				assertMoveExplains(t, string(difficulty), move)
+				if move.Action == constants.ActionContradiction {
+					break
+				}
+				solver.ApplyMove(board, move)
+			}
+		}
+	}
+}
+
+// TestDetectorMovesCarryExplanationKeys runs every registered detector over
+// the purity board sweep; any move a detector returns must carry a
+// renderable message key, regardless of whether the registry would have
+// picked it.
+func TestDetectorMovesCarryExplanationKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping detector sweep in short mode")
+	}
+
+	boards := purityTestBoards(t)
+	registry := NewTechniqueRegistry()
+	for _, tech := range registry.GetAll() {
+		if tech.Detector == nil {
+			continue
+		}
+		t.Run(tech.Slug, func(t *testing.T) {
+			found := false
+			for _, b := range boards {
+				move := tech.Detector(b)
+				if move == nil {
+					continue
+				}
+				found = true
+				assertMoveExplains(t, tech.Slug, move)
+				break // one hit per detector is enough
+			}
+			if !found {
+				t.Logf("%s never fired on the sweep boards", tech.Slug)
+			}
+		})
+	}
+}
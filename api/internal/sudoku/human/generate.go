@@ -0,0 +1,73 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Technique-Targeted Generation
+// ============================================================================
+//
+// Bridges the dp generator and the human solver: keep generating and carving
+// puzzles until one's solve path exercises a requested technique. Used to
+// backfill techniques that the static puzzle pool doesn't cover (see
+// cmd/generate_practice -technique).
+
+// generationGivensCycle lists the carve sizes tried in rotation while
+// searching. Harder techniques generally only appear on sparser grids, so the
+// cycle leans toward lower given counts.
+var generationGivensCycle = []int{30, 26, 24, 22, 20}
+
+// GenerateForTechnique generates and carves puzzles, seeded from seed and
+// incrementing per attempt, until one's human solve path uses the technique
+// identified by slug. It returns the givens of the first match along with the
+// number of attempts consumed, or an error once maxAttempts is exhausted.
+func GenerateForTechnique(slug string, seed int64, maxAttempts int) ([]int, int, error) {
+	return generateForTechnique(slug, seed, maxAttempts, false)
+}
+
+// GenerateRequiringTechnique is the strict variant of GenerateForTechnique:
+// the returned puzzle not only uses the technique, it also stalls when the
+// technique is disabled, so the solve genuinely requires it.
+func GenerateRequiringTechnique(slug string, seed int64, maxAttempts int) ([]int, int, error) {
+	return generateForTechnique(slug, seed, maxAttempts, true)
+}
+
+func generateForTechnique(slug string, seed int64, maxAttempts int, strict bool) ([]int, int, error) {
+	solver := NewSolver()
+	if solver.GetRegistry().GetBySlug(slug) == nil {
+		return nil, 0, fmt.Errorf("unknown technique: %s", slug)
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		attemptSeed := seed + int64(attempt)
+		fullGrid := dp.GenerateFullGrid(attemptSeed)
+		target := generationGivensCycle[attempt%len(generationGivensCycle)]
+		givens := dp.CarveGivens(fullGrid, target, attemptSeed)
+
+		_, techniqueCounts, status := solver.AnalyzePuzzleDifficulty(givens)
+		if status != constants.StatusCompleted || techniqueCounts[slug] == 0 {
+			continue
+		}
+
+		if strict && !puzzleRequiresTechnique(givens, slug) {
+			continue
+		}
+
+		return givens, attempt + 1, nil
+	}
+
+	return nil, maxAttempts, fmt.Errorf("no puzzle using %s found in %d attempts", slug, maxAttempts)
+}
+
+// puzzleRequiresTechnique reports whether the puzzle stalls once the technique
+// is disabled, i.e. the rest of the registry cannot finish without it.
+func puzzleRequiresTechnique(givens []int, slug string) bool {
+	solver := CreateSolverWithoutTechniques(slug)
+	b := NewBoard(givens)
+	_, status := solver.SolveWithSteps(b, constants.MaxSolverSteps)
+	return status != constants.StatusCompleted
+}
@@ -0,0 +1,64 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Seed 1 with a 200-attempt budget is known to cover both techniques (x-wing
+// appears within ~15 attempts, skyscraper within ~50), so the budget gives
+// headroom without letting a regression run unbounded.
+func TestGenerateForTechnique(t *testing.T) {
+	tests := []struct {
+		slug        string
+		maxAttempts int
+	}{
+		{"x-wing", 200},
+		{"skyscraper", 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			givens, attempts, err := GenerateForTechnique(tt.slug, 1, tt.maxAttempts)
+			if err != nil {
+				t.Fatalf("GenerateForTechnique(%s) failed: %v", tt.slug, err)
+			}
+			if attempts < 1 || attempts > tt.maxAttempts {
+				t.Errorf("Attempts %d outside budget 1..%d", attempts, tt.maxAttempts)
+			}
+			if len(givens) != constants.TotalCells {
+				t.Fatalf("Expected %d givens cells, got %d", constants.TotalCells, len(givens))
+			}
+
+			// The returned puzzle must actually use the technique when solved
+			solver := NewSolver()
+			_, techniqueCounts, status := solver.AnalyzePuzzleDifficulty(givens)
+			if status != constants.StatusCompleted {
+				t.Fatalf("Returned puzzle did not solve: %s", status)
+			}
+			if techniqueCounts[tt.slug] == 0 {
+				t.Errorf("Solve path does not use %s", tt.slug)
+			}
+		})
+	}
+}
+
+func TestGenerateForTechnique_UnknownSlug(t *testing.T) {
+	_, _, err := GenerateForTechnique("not-a-technique", 1, 10)
+	if err == nil {
+		t.Error("Expected error for unknown technique slug")
+	}
+}
+
+func TestGenerateForTechnique_ExhaustsAttempts(t *testing.T) {
+	// One attempt is essentially never enough for a hard fish pattern, so the
+	// budget error path should trigger and report the attempts consumed.
+	_, attempts, err := GenerateForTechnique("jellyfish", 1, 1)
+	if err == nil {
+		t.Error("Expected error when attempt budget is exhausted")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt consumed, got %d", attempts)
+	}
+}
@@ -0,0 +1,206 @@
+package human
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// The golden solve corpus pins the solver's complete move sequence for a
+// spread of puzzles, so a detector change that shifts any step — a new find,
+// a lost find, or just a different tie-break — fails a fast test instead of
+// waiting for the stress CLIs. Only technique slugs and target cells are
+// compared; explanations and highlights can change freely.
+//
+// Regenerate after an intentional behavior change with
+//
+//	go test ./internal/sudoku/human -run TestGoldenSolves -update
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden solve corpus from the current solver")
+
+const goldenSolvesPath = "testdata/golden/solves.json"
+
+// goldenSolve is one corpus entry: the board (givens string, or a state-codec
+// string when the bug only reproduces with specific pencil marks) and the
+// expected sequence, one "slug R1C1,R2C4" line per move.
+type goldenSolve struct {
+	Name   string   `json:"name"`
+	Givens string   `json:"givens,omitempty"`
+	State  string   `json:"state,omitempty"`
+	Status string   `json:"status"`
+	Moves  []string `json:"moves"`
+}
+
+// Hand-picked regression boards; the generated seeds below cover breadth,
+// these cover specific bugs that once shipped.
+const (
+	// Found by GenerateForTechnique("swordfish", 1, ...): the solve needs a
+	// Swordfish among coloring and chains — the shape that stalled when
+	// X-Wing and Swordfish disagreed over degenerate fish ordering
+	goldenSwordfishGivens = "000005000705041003490000070006070510803000009000602004000400000000030000160020040"
+
+	// SudokuWiki's Unique Rectangle Type 2 example (see cmd/test_ur_puzzles)
+	goldenURType2Givens = "020000000060000794809060200700003000900102003000500008004020507682000030000000010"
+
+	// Seed 3's medium puzzle with one user entry duplicating a digit in its
+	// row: the contradiction must blame the wrong entry, not an arbitrary
+	// cell of the conflicting pair
+	goldenWrongEntryState = "AXicNMjRDUQhCACwgqC4_8IXc3n9rFLacxnWHhnrRbwfyZlOnxh9hfrfyY2lTNB-AQAA__8aYwCj"
+
+	// The same puzzle untouched, but with the true digit stripped from one
+	// cell's pencil marks: every entry is correct, so the eventual
+	// contradiction is the solver-bug class, found mid-solve
+	goldenStrippedCandidateState = "AXicPI6xDcIwFETfOXFidxmBAiIhBWYIUgqLihK6iIoiQ9CyB4OAWIRR0IfgK-yvp_v3T1DiMUUIUFQBp8KAjAcc1ME7_lLAR0T5Y7WrgIKSIPCMrIBRcNZOT710ABoSsOc0hyzm_6KjkpJsXmpSL5gESV0-2GmjtaK2tNljm_begWH2vXnQcM09o26C1pK_YJC16vUJAAD__3ToDyI"
+)
+
+// goldenBoard reconstructs a corpus entry's starting board
+func goldenBoard(entry goldenSolve) (*Board, error) {
+	if entry.State != "" {
+		cells, candidates, err := core.DecodeState(entry.State)
+		if err != nil {
+			return nil, fmt.Errorf("bad state: %w", err)
+		}
+		if candidates != nil {
+			return NewBoardWithCandidates(cells, candidates), nil
+		}
+		return NewBoard(cells), nil
+	}
+	if len(entry.Givens) != constants.TotalCells {
+		return nil, fmt.Errorf("givens must be %d digits, got %d", constants.TotalCells, len(entry.Givens))
+	}
+	cells := make([]int, constants.TotalCells)
+	for i, c := range entry.Givens {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("bad digit %q at cell %d", c, i)
+		}
+		cells[i] = int(c - '0')
+	}
+	return NewBoard(cells), nil
+}
+
+// goldenMoveLine flattens a move to the compared form: slug plus target
+// cells, nothing presentation-layer
+func goldenMoveLine(m core.Move) string {
+	refs := make([]string, len(m.Targets))
+	for i, t := range m.Targets {
+		refs[i] = fmt.Sprintf("R%dC%d", t.Row+1, t.Col+1)
+	}
+	return m.Technique + " " + strings.Join(refs, ",")
+}
+
+func goldenSolveEntry(entry goldenSolve) (goldenSolve, error) {
+	board, err := goldenBoard(entry)
+	if err != nil {
+		return entry, err
+	}
+	moves, status := NewSolver().SolveWithStepsOpts(board, constants.MaxSolverSteps, SolveOpts{CandidateMode: CandidateModeNone})
+	entry.Status = status
+	entry.Moves = make([]string, len(moves))
+	for i, m := range moves {
+		entry.Moves[i] = goldenMoveLine(m)
+	}
+	return entry, nil
+}
+
+// buildGoldenCorpus records the current solver's sequences for the corpus
+// boards: nine carved seeds across every classic difficulty, plus the
+// regression boards above
+func buildGoldenCorpus(t *testing.T) []goldenSolve {
+	t.Helper()
+
+	boards := []goldenSolve{
+		{Name: "swordfish-regression", Givens: goldenSwordfishGivens},
+		{Name: "sudokuwiki-ur-type-2", Givens: goldenURType2Givens},
+		{Name: "contradiction-wrong-entry", State: goldenWrongEntryState},
+		{Name: "contradiction-stripped-candidate", State: goldenStrippedCandidateState},
+	}
+	for seed := int64(1); seed <= 9; seed++ {
+		full := dp.GenerateFullGrid(seed)
+		puzzles, _ := dp.CarveGivensWithSubset(full, seed)
+		for _, name := range constants.DifficultyNames() {
+			givens, ok := puzzles[name]
+			if !ok {
+				// Technique-predicate tiers are bank-only and never carved
+				continue
+			}
+			digits := make([]byte, len(givens))
+			for i, v := range givens {
+				digits[i] = byte('0' + v)
+			}
+			boards = append(boards, goldenSolve{
+				Name:   fmt.Sprintf("seed-%d-%s", seed, name),
+				Givens: string(digits),
+			})
+		}
+	}
+
+	entries := make([]goldenSolve, len(boards))
+	for i, board := range boards {
+		entry, err := goldenSolveEntry(board)
+		if err != nil {
+			t.Fatalf("%s: %v", board.Name, err)
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+func TestGoldenSolves(t *testing.T) {
+	if *updateGolden {
+		entries := buildGoldenCorpus(t)
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			t.Fatalf("marshal corpus: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(goldenSolvesPath), 0o755); err != nil {
+			t.Fatalf("create testdata/golden: %v", err)
+		}
+		if err := os.WriteFile(goldenSolvesPath, append(data, '\n'), 0o644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		t.Logf("rewrote %s with %d entries", goldenSolvesPath, len(entries))
+		return
+	}
+
+	data, err := os.ReadFile(goldenSolvesPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file (regenerate with -update): %v", err)
+	}
+	var entries []goldenSolve
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("failed to parse golden file: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("golden file holds no entries")
+	}
+
+	for _, entry := range entries {
+		entry := entry
+		t.Run(entry.Name, func(t *testing.T) {
+			got, err := goldenSolveEntry(goldenSolve{Name: entry.Name, Givens: entry.Givens, State: entry.State})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != entry.Status {
+				t.Errorf("status diverged: got %s, want %s", got.Status, entry.Status)
+			}
+			for i := 0; i < len(got.Moves) && i < len(entry.Moves); i++ {
+				if got.Moves[i] != entry.Moves[i] {
+					t.Fatalf("step %d diverged:\ngot  %s\nwant %s", i, got.Moves[i], entry.Moves[i])
+				}
+			}
+			if len(got.Moves) != len(entry.Moves) {
+				t.Fatalf("move count diverged after %d matching steps: got %d, want %d",
+					min(len(got.Moves), len(entry.Moves)), len(got.Moves), len(entry.Moves))
+			}
+		})
+	}
+}
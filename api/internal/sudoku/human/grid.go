@@ -40,6 +40,9 @@ type Unit = techniques.Unit
 // BoardInterface is an alias for techniques.BoardInterface
 type BoardInterface = techniques.BoardInterface
 
+// ALS is an alias for techniques.ALS - an Almost Locked Set
+type ALS = techniques.ALS
+
 // UnitType constants from techniques package
 const (
 	UnitRow = techniques.UnitRow
@@ -60,110 +63,56 @@ func AllCandidates() Candidates {
 // ============================================================================
 // Precomputed Peer Data
 // ============================================================================
+//
+// The tables themselves live in core/grid (one source of truth, shared with
+// the dp solver and the WASM build); these vars re-export the views the
+// techniques package builds over them.
 
 var (
 	// Peers contains all peer indices for each cell (row + col + box peers, excluding self)
-	Peers [constants.TotalCells][]int
+	Peers = techniques.Peers
 
 	// RowPeers contains peer indices within the same row for each cell
-	RowPeers [constants.TotalCells][]int
+	RowPeers = techniques.RowPeers
 
 	// ColPeers contains peer indices within the same column for each cell
-	ColPeers [constants.TotalCells][]int
+	ColPeers = techniques.ColPeers
 
 	// BoxPeers contains peer indices within the same box for each cell
-	BoxPeers [constants.TotalCells][]int
+	BoxPeers = techniques.BoxPeers
 
 	// RowIndices maps row number to all cell indices in that row
-	RowIndices [constants.GridSize][]int
+	RowIndices = techniques.RowIndices
 
 	// ColIndices maps column number to all cell indices in that column
-	ColIndices [constants.GridSize][]int
+	ColIndices = techniques.ColIndices
 
 	// BoxIndices maps box number to all cell indices in that box
-	BoxIndices [constants.GridSize][]int
+	BoxIndices = techniques.BoxIndices
 )
 
-func init() {
-	initializePeers()
-}
-
-// initializePeers precomputes all peer relationships
-func initializePeers() {
-	// Initialize row/col/box indices first
-	for r := 0; r < constants.GridSize; r++ {
-		for c := 0; c < constants.GridSize; c++ {
-			idx := r*constants.GridSize + c
-			RowIndices[r] = append(RowIndices[r], idx)
-			ColIndices[c] = append(ColIndices[c], idx)
-
-			boxNum := (r/constants.BoxSize)*constants.BoxSize + c/constants.BoxSize
-			BoxIndices[boxNum] = append(BoxIndices[boxNum], idx)
-		}
-	}
-
-	// For each cell, compute its peers
-	for i := 0; i < constants.TotalCells; i++ {
-		row, col := i/constants.GridSize, i%constants.GridSize
-		boxNum := (row/constants.BoxSize)*constants.BoxSize + col/constants.BoxSize
-
-		// Collect unique peers (avoiding duplicates)
-		peerSet := make(map[int]bool)
-
-		// Row peers
-		for _, idx := range RowIndices[row] {
-			if idx != i {
-				RowPeers[i] = append(RowPeers[i], idx)
-				peerSet[idx] = true
-			}
-		}
-
-		// Column peers
-		for _, idx := range ColIndices[col] {
-			if idx != i {
-				ColPeers[i] = append(ColPeers[i], idx)
-				peerSet[idx] = true
-			}
-		}
-
-		// Box peers
-		for _, idx := range BoxIndices[boxNum] {
-			if idx != i {
-				BoxPeers[i] = append(BoxPeers[i], idx)
-				peerSet[idx] = true
-			}
-		}
-
-		// All unique peers
-		for peerIdx := range peerSet {
-			Peers[i] = append(Peers[i], peerIdx)
-		}
-	}
-}
-
 // ============================================================================
 // Coordinate Helpers
 // ============================================================================
 
-// RowOf returns the row number (0-15) for a cell index
+// RowOf returns the row number for a cell index
 func RowOf(idx int) int {
-	return idx / constants.GridSize
+	return techniques.RowOf(idx)
 }
 
-// ColOf returns the column number (0-15) for a cell index
+// ColOf returns the column number for a cell index
 func ColOf(idx int) int {
-	return idx % constants.GridSize
+	return techniques.ColOf(idx)
 }
 
-// BoxOf returns the box number (0-15) for a cell index
+// BoxOf returns the box number for a cell index
 func BoxOf(idx int) int {
-	row, col := idx/constants.GridSize, idx%constants.GridSize
-	return (row/constants.BoxSize)*constants.BoxSize + col/constants.BoxSize
+	return techniques.BoxOf(idx)
 }
 
 // IndexOf returns the cell index for given row and column
 func IndexOf(row, col int) int {
-	return row*constants.GridSize + col
+	return techniques.IndexOf(row, col)
 }
 
 // ToCellRef converts a cell index to a CellRef
@@ -207,10 +156,44 @@ func AreBoxPeers(idx1, idx2 int) bool {
 // ArePeers returns true if two cells can see each other (same row, col, or box)
 // and are not the same cell
 func ArePeers(idx1, idx2 int) bool {
+	return techniques.ArePeers(idx1, idx2)
+}
+
+// ArePeersWithConstraints returns true if two cells may not contain the same
+// digit under the given variant constraints: classic peers, knight-step peers
+// when anti-knight is active, and shared-diagonal cells when diagonals are
+// unique. Non-consecutive is not a same-digit relation so it adds no peers
+func ArePeersWithConstraints(idx1, idx2 int, cs core.ConstraintSet) bool {
+	if ArePeers(idx1, idx2) {
+		return true
+	}
 	if idx1 == idx2 {
 		return false
 	}
-	return AreRowPeers(idx1, idx2) || AreColPeers(idx1, idx2) || AreBoxPeers(idx1, idx2)
+
+	if cs.AntiKnight {
+		dr, dc := RowOf(idx1)-RowOf(idx2), ColOf(idx1)-ColOf(idx2)
+		if dr < 0 {
+			dr = -dr
+		}
+		if dc < 0 {
+			dc = -dc
+		}
+		if (dr == 1 && dc == 2) || (dr == 2 && dc == 1) {
+			return true
+		}
+	}
+
+	if cs.DiagonalsUnique {
+		if RowOf(idx1) == ColOf(idx1) && RowOf(idx2) == ColOf(idx2) {
+			return true
+		}
+		if RowOf(idx1)+ColOf(idx1) == constants.GridSize-1 && RowOf(idx2)+ColOf(idx2) == constants.GridSize-1 {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ============================================================================
@@ -0,0 +1,161 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Board history with undo/redo. Frontends that keep their own move history
+// drift from the Go board logic (peer pruning, eliminated tracking), so
+// History owns the board and records the exact cell states each operation
+// overwrites. Undo restores those states verbatim instead of recomputing
+// candidates — a peer candidate that was already absent for an independent
+// reason stays absent after undoing the placement that would also have
+// pruned it.
+
+// Operation kinds accepted by History.Do.
+const (
+	OpSetDigit   = "set-digit"
+	OpClearDigit = "clear-digit"
+	OpAddMark    = "add-mark"
+	OpRemoveMark = "remove-mark"
+)
+
+// HistoryOp is one user action against the board. Digit is ignored for
+// clear-digit.
+type HistoryOp struct {
+	Kind  string `json:"kind"`
+	Cell  int    `json:"cell"`
+	Digit int    `json:"digit"`
+}
+
+// cellState is the full saved state of one cell: its digit, pencil marks,
+// and eliminated set.
+type cellState struct {
+	idx        int
+	digit      int
+	candidates Candidates
+	eliminated Candidates
+}
+
+// historyEntry pairs an applied op with the states it overwrote.
+type historyEntry struct {
+	op       HistoryOp
+	previous []cellState
+}
+
+// History wraps a Board and tracks operations for undo/redo.
+type History struct {
+	board     *Board
+	undoStack []historyEntry
+	redoStack []historyEntry
+}
+
+// NewHistory wraps an existing board. The board is owned by the history from
+// here on; mutating it directly desynchronizes the stacks.
+func NewHistory(b *Board) *History {
+	return &History{board: b}
+}
+
+// Board returns the wrapped board for read access.
+func (h *History) Board() *Board {
+	return h.board
+}
+
+// Do validates and applies one operation, recording the cell states it
+// overwrites. Any new operation clears the redo stack.
+func (h *History) Do(op HistoryOp) error {
+	if op.Cell < 0 || op.Cell >= constants.TotalCells {
+		return fmt.Errorf("cell index %d out of range", op.Cell)
+	}
+	switch op.Kind {
+	case OpSetDigit, OpAddMark, OpRemoveMark:
+		if op.Digit < 1 || op.Digit > constants.GridSize {
+			return fmt.Errorf("digit %d out of range", op.Digit)
+		}
+	case OpClearDigit:
+	default:
+		return fmt.Errorf("unknown operation kind %q", op.Kind)
+	}
+
+	entry := historyEntry{op: op, previous: h.snapshotCells(op)}
+	h.apply(op)
+	h.undoStack = append(h.undoStack, entry)
+	h.redoStack = nil
+	return nil
+}
+
+// Undo reverts the most recent operation by restoring the recorded cell
+// states. Returns false when there is nothing to undo.
+func (h *History) Undo() bool {
+	if len(h.undoStack) == 0 {
+		return false
+	}
+	entry := h.undoStack[len(h.undoStack)-1]
+	h.undoStack = h.undoStack[:len(h.undoStack)-1]
+
+	// Restores write board state directly, so drop the ALS cache by hand
+	h.board.invalidateALS()
+	for _, st := range entry.previous {
+		h.board.Cells[st.idx] = st.digit
+		h.board.Candidates[st.idx] = st.candidates
+		h.board.Eliminated[st.idx] = st.eliminated
+	}
+	h.redoStack = append(h.redoStack, entry)
+	return true
+}
+
+// Redo reapplies the most recently undone operation. The board is in the
+// exact state it was in when the op first ran, so reapplying reproduces the
+// original deltas. Returns false when there is nothing to redo.
+func (h *History) Redo() bool {
+	if len(h.redoStack) == 0 {
+		return false
+	}
+	entry := h.redoStack[len(h.redoStack)-1]
+	h.redoStack = h.redoStack[:len(h.redoStack)-1]
+
+	h.apply(entry.op)
+	h.undoStack = append(h.undoStack, entry)
+	return true
+}
+
+// Snapshot returns the current cells and pencil marks in the same shape the
+// HTTP and WASM layers use.
+func (h *History) Snapshot() ([]int, [][]int) {
+	return h.board.GetCells(), h.board.GetCandidates()
+}
+
+// snapshotCells records the state of every cell the op can touch: the cell
+// itself, plus its peers for a placement (which prunes their candidates).
+func (h *History) snapshotCells(op HistoryOp) []cellState {
+	cells := []int{op.Cell}
+	if op.Kind == OpSetDigit {
+		cells = append(cells, Peers[op.Cell]...)
+	}
+	states := make([]cellState, len(cells))
+	for i, idx := range cells {
+		states[i] = cellState{
+			idx:        idx,
+			digit:      h.board.Cells[idx],
+			candidates: h.board.Candidates[idx],
+			eliminated: h.board.Eliminated[idx],
+		}
+	}
+	return states
+}
+
+// apply performs the board mutation for one validated op.
+func (h *History) apply(op HistoryOp) {
+	switch op.Kind {
+	case OpSetDigit:
+		h.board.SetCell(op.Cell, op.Digit)
+	case OpClearDigit:
+		h.board.ClearCell(op.Cell)
+	case OpAddMark:
+		h.board.AddCandidate(op.Cell, op.Digit)
+	case OpRemoveMark:
+		h.board.RemoveCandidate(op.Cell, op.Digit)
+	}
+}
@@ -0,0 +1,143 @@
+package human
+
+import (
+	"math/rand"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+func historyTestBoard() *Board {
+	full := dp.GenerateFullGrid(3)
+	medium, _ := constants.DifficultyByName(constants.DifficultyMedium)
+	givens := dp.CarveGivens(full, medium.TargetGivens, 3)
+	b := NewBoard(givens)
+	b.InitCandidates()
+	return b
+}
+
+// randomOp produces a valid op; the op may still be a no-op on the current
+// board (clearing an empty cell, removing an absent mark), which undo must
+// handle just as precisely.
+func randomOp(rng *rand.Rand) HistoryOp {
+	kinds := []string{OpSetDigit, OpClearDigit, OpAddMark, OpRemoveMark}
+	return HistoryOp{
+		Kind:  kinds[rng.Intn(len(kinds))],
+		Cell:  rng.Intn(constants.TotalCells),
+		Digit: 1 + rng.Intn(constants.GridSize),
+	}
+}
+
+func TestHistoryUndoRestoresInitialState(t *testing.T) {
+	board := historyTestBoard()
+	initial := *board
+
+	h := NewHistory(board)
+	rng := rand.New(rand.NewSource(99))
+	ops := 0
+	for i := 0; i < 200; i++ {
+		if err := h.Do(randomOp(rng)); err != nil {
+			t.Fatalf("op %d failed: %v", i, err)
+		}
+		ops++
+	}
+
+	for i := 0; i < ops; i++ {
+		if !h.Undo() {
+			t.Fatalf("undo %d returned false with ops remaining", i)
+		}
+	}
+	if h.Undo() {
+		t.Error("undo past the initial state should return false")
+	}
+
+	if err := diffBoardSnapshot(&initial, board); err != nil {
+		t.Errorf("board differs from initial state after full undo: %v", err)
+	}
+}
+
+func TestHistoryRedoReplaysExactly(t *testing.T) {
+	board := historyTestBoard()
+	h := NewHistory(board)
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		if err := h.Do(randomOp(rng)); err != nil {
+			t.Fatalf("op %d failed: %v", i, err)
+		}
+	}
+	final := *board
+
+	for h.Undo() {
+	}
+	for h.Redo() {
+	}
+	if h.Redo() {
+		t.Error("redo past the final state should return false")
+	}
+
+	if err := diffBoardSnapshot(&final, board); err != nil {
+		t.Errorf("board differs from final state after undo/redo round trip: %v", err)
+	}
+}
+
+// TestHistoryUndoDoesNotResurrectCandidates covers the case deltas exist
+// for: a peer candidate that was removed independently before a placement
+// must stay absent when that placement is undone.
+func TestHistoryUndoDoesNotResurrectCandidates(t *testing.T) {
+	board := NewBoard(make([]int, constants.TotalCells))
+	board.InitCandidates()
+	h := NewHistory(board)
+
+	// R1C2 loses 5 for an independent reason (user removed the mark), then a
+	// 5 is placed at R1C1, which would also have pruned it
+	if err := h.Do(HistoryOp{Kind: OpRemoveMark, Cell: 1, Digit: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Do(HistoryOp{Kind: OpSetDigit, Cell: 0, Digit: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !h.Undo() {
+		t.Fatal("undo failed")
+	}
+	if board.GetCandidatesAt(1).Has(5) {
+		t.Error("undoing the placement resurrected a candidate removed for an independent reason")
+	}
+	// A peer pruned only by the placement does get its candidate back
+	if !board.GetCandidatesAt(2).Has(5) {
+		t.Error("undoing the placement should restore the candidate it pruned")
+	}
+}
+
+func TestHistoryDoValidation(t *testing.T) {
+	h := NewHistory(historyTestBoard())
+	if err := h.Do(HistoryOp{Kind: OpSetDigit, Cell: -1, Digit: 5}); err == nil {
+		t.Error("expected error for out-of-range cell")
+	}
+	if err := h.Do(HistoryOp{Kind: OpSetDigit, Cell: 0, Digit: 10}); err == nil {
+		t.Error("expected error for out-of-range digit")
+	}
+	if err := h.Do(HistoryOp{Kind: "swap-cells", Cell: 0, Digit: 1}); err == nil {
+		t.Error("expected error for unknown op kind")
+	}
+	if h.Undo() {
+		t.Error("rejected ops must not land on the undo stack")
+	}
+}
+
+func TestHistoryDoClearsRedo(t *testing.T) {
+	h := NewHistory(historyTestBoard())
+	if err := h.Do(HistoryOp{Kind: OpAddMark, Cell: 0, Digit: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if !h.Undo() {
+		t.Fatal("undo failed")
+	}
+	if err := h.Do(HistoryOp{Kind: OpAddMark, Cell: 0, Digit: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if h.Redo() {
+		t.Error("a new op should clear the redo stack")
+	}
+}
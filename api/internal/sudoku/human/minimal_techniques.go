@@ -0,0 +1,115 @@
+package human
+
+import "sudoku-api/pkg/constants"
+
+// Minimal technique analysis answers "could I have finished this without the
+// X-Wing?". AnalyzePuzzleDifficulty reports the techniques the default solve
+// happened to use, which overstates what a puzzle demands: the solver often
+// fires a technique on an elimination an alternative could have made.
+// MinimalTechniqueAnalysis greedily disables the used techniques one at a
+// time, keeping each removal that still lets the solve complete, and reports
+// the locally-minimal set that survives along with which of its members are
+// essential - the ones whose removal alone breaks the solve.
+
+// MinimalTechnique is one member of the locally-minimal technique set.
+type MinimalTechnique struct {
+	Slug string `json:"slug"`
+	// Essential means disabling just this technique leaves the rest of the
+	// registry unable to finish. A non-essential member still earned its
+	// place in the set - removing it on top of the removals already made
+	// broke the solve - but an alternative route exists (a puzzle solvable
+	// via either x-wing or coloring keeps one of them, essential neither).
+	Essential bool `json:"essential"`
+}
+
+// minimalAnalysisMaxRuns bounds the solver runs one analysis may spend. The
+// used technique set is small, so the bound is generous; if it is ever hit,
+// the remaining candidates are simply kept and unprobed members report as
+// non-essential.
+const minimalAnalysisMaxRuns = 40
+
+// MinimalTechniqueAnalysis solves the puzzle, then greedily re-solves with
+// each used technique above the simple tier disabled (hardest first, so the
+// surviving set leans toward easier techniques), keeping removals that still
+// complete. It returns the technique set of the final reduced solve in
+// pedagogical order, with Essential probed per member. Simple-tier techniques
+// are the baseline every solve keeps: they are never candidates for removal
+// and are reported without the essential probe. The status is
+// StatusCompleted, or the failing status of the initial solve.
+func (s *Solver) MinimalTechniqueAnalysis(givens []int) ([]MinimalTechnique, string) {
+	counts, status := minimalSolveCounts(s, givens)
+	if status != constants.StatusCompleted {
+		return nil, status
+	}
+	runs := 1
+
+	// Candidate removals: the used above-simple techniques, hardest first
+	advanced := s.registry.AdvancedTechniqueSlugs()
+	var candidates []string
+	for i := len(advanced) - 1; i >= 0; i-- {
+		if counts[advanced[i]] > 0 {
+			candidates = append(candidates, advanced[i])
+		}
+	}
+
+	var removed []string
+	for _, slug := range candidates {
+		if runs >= minimalAnalysisMaxRuns {
+			break
+		}
+		trial := append(append([]string(nil), removed...), slug)
+		trialCounts, trialStatus := minimalSolveCounts(s.solverWithoutSlugs(trial), givens)
+		runs++
+		if trialStatus != constants.StatusCompleted {
+			continue
+		}
+		removed = trial
+		// The reduced solve may substitute techniques the original never
+		// used; the minimal set is read off the final reduced solve
+		counts = trialCounts
+	}
+
+	var result []MinimalTechnique
+	for _, info := range s.registry.GetTechniqueInfos() {
+		if counts[info.Slug] == 0 {
+			continue
+		}
+		member := MinimalTechnique{Slug: info.Slug}
+		if info.Tier != constants.TierSimple && runs < minimalAnalysisMaxRuns {
+			_, aloneStatus := minimalSolveCounts(s.solverWithoutSlugs([]string{info.Slug}), givens)
+			runs++
+			member.Essential = aloneStatus != constants.StatusCompleted
+		}
+		result = append(result, member)
+	}
+	return result, constants.StatusCompleted
+}
+
+// solverWithoutSlugs builds a fresh solver with s's disabled techniques plus
+// the given slugs disabled, leaving s untouched for concurrent callers
+func (s *Solver) solverWithoutSlugs(slugs []string) *Solver {
+	registry := NewTechniqueRegistry()
+	for _, tech := range s.registry.GetAll() {
+		if !tech.Enabled {
+			registry.SetEnabled(tech.Slug, false)
+		}
+	}
+	for _, slug := range slugs {
+		registry.SetEnabled(slug, false)
+	}
+	return NewSolverWithRegistry(registry)
+}
+
+// minimalSolveCounts runs one candidate-mode-free solve and tallies the
+// technique multiset, or returns the non-completed status
+func minimalSolveCounts(solver *Solver, givens []int) (map[string]int, string) {
+	moves, status := solver.SolveWithStepsOpts(NewBoard(givens), constants.MaxSolverSteps, SolveOpts{CandidateMode: CandidateModeNone})
+	if status != constants.StatusCompleted {
+		return nil, status
+	}
+	counts := make(map[string]int)
+	for _, move := range moves {
+		counts[move.Technique]++
+	}
+	return counts, status
+}
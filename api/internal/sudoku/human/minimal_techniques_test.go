@@ -0,0 +1,99 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// findMinimal returns the set member for slug, if present
+func findMinimal(set []MinimalTechnique, slug string) (MinimalTechnique, bool) {
+	for _, member := range set {
+		if member.Slug == slug {
+			return member, true
+		}
+	}
+	return MinimalTechnique{}, false
+}
+
+// Seed 1's first x-wing puzzle (found within ~15 attempts, matching
+// TestGenerateForTechnique's budget note) is solvable without the x-wing:
+// coloring covers the same eliminations. The analysis must drop or keep the
+// interchangeable techniques without calling either essential, while at
+// least one of them survives in the minimal set.
+func TestMinimalTechniqueAnalysis_InterchangeableTechniques(t *testing.T) {
+	givens, _, err := GenerateForTechnique("x-wing", 1, 50)
+	if err != nil {
+		t.Fatalf("GenerateForTechnique failed: %v", err)
+	}
+
+	solver := NewSolver()
+	_, counts, status := solver.AnalyzePuzzleDifficulty(givens)
+	if status != constants.StatusCompleted || counts["x-wing"] == 0 {
+		t.Fatalf("fixture premise broken: status=%s x-wing count=%d", status, counts["x-wing"])
+	}
+	if puzzleRequiresTechnique(givens, "x-wing") {
+		t.Fatal("fixture premise broken: puzzle must be solvable without x-wing")
+	}
+
+	set, status := solver.MinimalTechniqueAnalysis(givens)
+	if status != constants.StatusCompleted {
+		t.Fatalf("expected completed analysis, got %s", status)
+	}
+
+	inSet := 0
+	for _, slug := range []string{"x-wing", "simple-coloring"} {
+		member, ok := findMinimal(set, slug)
+		if !ok {
+			continue
+		}
+		inSet++
+		if member.Essential {
+			t.Errorf("%s has an alternative and must not be essential", slug)
+		}
+	}
+	if inSet == 0 {
+		t.Errorf("expected x-wing or simple-coloring in the minimal set, got %v", set)
+	}
+}
+
+// Within the medium tier budget, seed 11's x-wing cannot be substituted: the
+// capped registry stalls without it. The analysis on a tier-capped solver
+// must flag exactly that dependence.
+func TestMinimalTechniqueAnalysis_EssentialTechnique(t *testing.T) {
+	givens, _, err := GenerateForTechnique("x-wing", 11, 1)
+	if err != nil {
+		t.Fatalf("GenerateForTechnique failed: %v", err)
+	}
+
+	capped := NewSolverUpToTier(constants.TierMedium)
+	set, status := capped.MinimalTechniqueAnalysis(givens)
+	if status != constants.StatusCompleted {
+		t.Fatalf("expected completed analysis, got %s", status)
+	}
+
+	member, ok := findMinimal(set, "x-wing")
+	if !ok {
+		t.Fatalf("expected x-wing in the minimal set, got %v", set)
+	}
+	if !member.Essential {
+		t.Error("expected x-wing to be essential within the medium tier")
+	}
+	for _, member := range set {
+		if member.Slug != "x-wing" && member.Essential {
+			t.Errorf("expected only x-wing essential, got %s too", member.Slug)
+		}
+	}
+}
+
+func TestMinimalTechniqueAnalysis_UnsolvableReportsStatus(t *testing.T) {
+	// An empty board stalls the simple-tier-only solver immediately
+	capped := NewSolverUpToTier(constants.TierSimple)
+	set, status := capped.MinimalTechniqueAnalysis(make([]int, constants.TotalCells))
+	if status == constants.StatusCompleted {
+		t.Fatal("expected a non-completed status for an unsolvable analysis")
+	}
+	if set != nil {
+		t.Errorf("expected no set for an unsolvable analysis, got %v", set)
+	}
+}
@@ -0,0 +1,171 @@
+package human
+
+import (
+	"math/rand"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human/techniques"
+)
+
+// niceLoopOpenChain is a remote-pair style chain of four bivalue cells
+// (1,2)-(2,3)-(3,4)-(4,1) whose ends R1C1 and R5C2 do not see each other, so
+// it concludes as a discontinuous loop: the victim R5C1 sees one end through
+// column 1 and the other through row 5 and loses the dangling 1. The satellite
+// 1s keep the victim out of any conjugate pair, so no shorter chain reaches it
+var niceLoopOpenChain = map[int][]int{
+	cellIdx(0, 0): {1, 2}, // chain start
+	cellIdx(0, 4): {2, 3},
+	cellIdx(4, 4): {3, 4},
+	cellIdx(4, 1): {4, 1}, // chain end
+	cellIdx(4, 0): {1, 6}, // sees both ends
+	cellIdx(4, 8): {1, 7}, // breaks the row 5 conjugate on 1
+	cellIdx(5, 2): {1, 9}, // breaks the box 4 conjugate on 1
+	cellIdx(7, 0): {1, 5}, // breaks the column 1 conjugate on 1
+}
+
+// niceLoopClosedChain is the same remote-pair chain with its last cell moved
+// to R5C1, so the ends R1C1 and R5C1 see each other through column 1 and the
+// chain closes into a continuous loop. R8C1 sits on the closure link and
+// loses the 1
+var niceLoopClosedChain = map[int][]int{
+	cellIdx(0, 0): {1, 2},
+	cellIdx(0, 4): {2, 3},
+	cellIdx(4, 4): {3, 4},
+	cellIdx(4, 0): {4, 1},
+	cellIdx(7, 0): {1, 5}, // covered by the closure link
+}
+
+// TestDetectNiceLoop_Discontinuous verifies the open-chain conclusion:
+// one end of the chain is always true, so the candidate seeing both dies
+func TestDetectNiceLoop_Discontinuous(t *testing.T) {
+	board := makeTestBoard([81]int{}, niceLoopOpenChain)
+	move := techniques.DetectNiceLoop(board)
+
+	if move == nil {
+		t.Fatal("expected Nice Loop move")
+	}
+	if move.ExplanationKey != "technique.nice-loop" {
+		t.Errorf("expected discontinuous key, got %q", move.ExplanationKey)
+	}
+	if move.Action != "eliminate" || move.Digit != 1 {
+		t.Errorf("expected eliminate of 1, got %s of %d", move.Action, move.Digit)
+	}
+	if len(move.Eliminations) != 1 || move.Eliminations[0].Row != 4 || move.Eliminations[0].Col != 0 || move.Eliminations[0].Digit != 1 {
+		t.Errorf("expected elimination of 1 at R5C1, got %v", move.Eliminations)
+	}
+	if len(move.Targets) != 4 {
+		t.Errorf("expected 4 chain cells, got %d", len(move.Targets))
+	}
+}
+
+// TestDetectNiceLoop_ContinuousLoop verifies the closed-chain conclusion:
+// the ends see each other, so every weak link around the loop eliminates.
+// R8C1 is covered by the closure link, not by the chain ends alone
+func TestDetectNiceLoop_ContinuousLoop(t *testing.T) {
+	board := makeTestBoard([81]int{}, niceLoopClosedChain)
+	move := techniques.DetectNiceLoop(board)
+
+	if move == nil {
+		t.Fatal("expected Nice Loop move")
+	}
+	if move.ExplanationKey != "technique.nice-loop.loop" {
+		t.Errorf("expected continuous loop key, got %q", move.ExplanationKey)
+	}
+	if len(move.Eliminations) != 1 || move.Eliminations[0].Row != 7 || move.Eliminations[0].Col != 0 || move.Eliminations[0].Digit != 1 {
+		t.Errorf("expected elimination of 1 at R8C1, got %v", move.Eliminations)
+	}
+	if len(move.ChainLinks) != 6 {
+		t.Errorf("expected 6 chain link refs (3 pairs), got %d", len(move.ChainLinks))
+	}
+}
+
+// TestDetectNiceLoop_Narration pins the step-by-step narration rendered from
+// the chain structure, in English and through the Spanish vocabulary
+func TestDetectNiceLoop_Narration(t *testing.T) {
+	board := makeTestBoard([81]int{}, niceLoopClosedChain)
+	move := techniques.DetectNiceLoop(board)
+	if move == nil {
+		t.Fatal("expected Nice Loop move")
+	}
+
+	want := "Nice Loop closing back on R1C1: if R1C1 is not 1, then R1C1 is 2, " +
+		"so R1C5 is not 2, then R1C5 is 3, so R5C5 is not 3, then R5C5 is 4, " +
+		"so R5C1 is not 4, then R5C1 is 1; every weak link in the loop " +
+		"eliminates the candidates it covers."
+	if move.Explanation != want {
+		t.Errorf("narration diverged:\n got  %s\n want %s", move.Explanation, want)
+	}
+
+	move.LocalizeExplanation("es")
+	wantES := "Nice Loop que se cierra sobre R1C1: si R1C1 no es 1, entonces R1C1 es 2, " +
+		"así que R1C5 no es 2, entonces R1C5 es 3, así que R5C5 no es 3, entonces R5C5 es 4, " +
+		"así que R5C1 no es 4, entonces R5C1 es 1; cada enlace débil del bucle " +
+		"elimina los candidatos que cubre."
+	if move.Explanation != wantES {
+		t.Errorf("spanish narration diverged:\n got  %s\n want %s", move.Explanation, wantES)
+	}
+}
+
+// TestDetectNiceLoop_MaxNodes verifies the configurable node budget is honored
+func TestDetectNiceLoop_MaxNodes(t *testing.T) {
+	board := makeTestBoard([81]int{}, niceLoopClosedChain)
+
+	old := techniques.NiceLoopMaxNodes
+	defer func() { techniques.NiceLoopMaxNodes = old }()
+
+	techniques.NiceLoopMaxNodes = 6
+	if move := techniques.DetectNiceLoop(board); move != nil {
+		t.Errorf("expected no move with a 6-node budget, got %v", move.Targets)
+	}
+
+	techniques.NiceLoopMaxNodes = 8
+	if move := techniques.DetectNiceLoop(board); move == nil {
+		t.Error("expected move with an 8-node budget")
+	}
+}
+
+// niceLoopSweepSeeds matches the fish and turbot sweeps: every nice loop
+// elimination on a generated board is checked against the known solution.
+const niceLoopSweepSeeds = 10000
+
+func TestNiceLoopSweep(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping nice loop sweep in short mode")
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	detections := map[string]int{}
+
+	for seed := int64(1); seed <= niceLoopSweepSeeds; seed++ {
+		solution := dp.GenerateFullGridFast(seed)
+
+		givens := make([]int, len(solution))
+		copy(givens, solution)
+		for _, idx := range rng.Perm(81)[:20+rng.Intn(36)] {
+			givens[idx] = 0
+		}
+
+		board := NewBoard(givens)
+		board.InitCandidates()
+
+		move := techniques.DetectNiceLoop(board)
+		if move == nil {
+			continue
+		}
+		detections[move.ExplanationKey]++
+		for _, elim := range move.Eliminations {
+			if solution[elim.Row*9+elim.Col] == elim.Digit {
+				t.Fatalf("seed %d: nice loop eliminated the true digit %d at R%dC%d\nmove: %+v",
+					seed, elim.Digit, elim.Row+1, elim.Col+1, move)
+			}
+		}
+	}
+
+	for _, key := range []string{"technique.nice-loop", "technique.nice-loop.loop"} {
+		if detections[key] == 0 {
+			t.Errorf("%s never fired across %d seeds", key, niceLoopSweepSeeds)
+		}
+	}
+	t.Logf("nice loop detections over %d seeds: %v", niceLoopSweepSeeds, detections)
+}
@@ -0,0 +1,55 @@
+package human
+
+import "sort"
+
+// Puzzle diffing between two carves of the same solution.
+//
+// Difficulties are carved as subsets of one full grid, so the easier carve of
+// a seed holds a superset of the harder carve's givens. DiffPuzzles reports
+// what dropping down a difficulty buys the player: the extra givens, and
+// which techniques the change adds to or removes from the solving path.
+
+// GivenDelta is one given present in the easier carve but not the harder one
+type GivenDelta struct {
+	Index int `json:"index"`
+	Digit int `json:"digit"`
+}
+
+// PuzzleDiff summarizes what separates a harder carve from an easier one
+type PuzzleDiff struct {
+	AddedGivens       []GivenDelta `json:"addedGivens"`
+	RemovedTechniques []string     `json:"removedTechniques"`
+	AddedTechniques   []string     `json:"addedTechniques"`
+}
+
+// DiffPuzzles compares the harder carve ("from") against the easier carve
+// ("to") of the same solution. AddedGivens lists the givens the easier carve
+// grants on top of the harder one; the technique lists diff the
+// AnalyzePuzzleDifficulty counts of the two puzzles, so RemovedTechniques are
+// needed for the harder carve but not the easier one and AddedTechniques the
+// reverse. Both lists are sorted for deterministic output.
+func (s *Solver) DiffPuzzles(from, to []int) PuzzleDiff {
+	var diff PuzzleDiff
+	for i := range to {
+		if to[i] != 0 && (i >= len(from) || from[i] == 0) {
+			diff.AddedGivens = append(diff.AddedGivens, GivenDelta{Index: i, Digit: to[i]})
+		}
+	}
+
+	_, fromCounts, _ := s.AnalyzePuzzleDifficulty(from)
+	_, toCounts, _ := s.AnalyzePuzzleDifficulty(to)
+
+	for slug, n := range fromCounts {
+		if n > 0 && toCounts[slug] == 0 {
+			diff.RemovedTechniques = append(diff.RemovedTechniques, slug)
+		}
+	}
+	for slug, n := range toCounts {
+		if n > 0 && fromCounts[slug] == 0 {
+			diff.AddedTechniques = append(diff.AddedTechniques, slug)
+		}
+	}
+	sort.Strings(diff.RemovedTechniques)
+	sort.Strings(diff.AddedTechniques)
+	return diff
+}
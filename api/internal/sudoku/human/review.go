@@ -0,0 +1,232 @@
+package human
+
+import (
+	"fmt"
+	"time"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// Solve review: replay a finished (or abandoned) game's move history and
+// grade each placement against what the solver could derive at that point.
+// A placement is "derivable" when an enabled technique assigns that digit to
+// that cell on the replayed board, a "guess" when it matches the solution
+// but no technique justifies it yet, and a "mistake" when it contradicts
+// the solution.
+
+// MaxReviewHistory caps how many history entries a review will replay
+const MaxReviewHistory = 500
+
+// reviewTimeBudget bounds the whole analysis; entries past the budget are
+// replayed but not graded
+const reviewTimeBudget = 3 * time.Second
+
+// History entry types accepted by ReviewHistory.
+const (
+	ReviewActionSet   = "set"
+	ReviewActionErase = "erase"
+	ReviewActionMark  = "mark"
+)
+
+// Verdicts attached to replayed placements.
+const (
+	VerdictDerivable   = "derivable"
+	VerdictGuess       = "guess"
+	VerdictMistake     = "mistake"
+	VerdictNotAnalyzed = "not-analyzed"
+)
+
+// ReviewAction is one entry of the user's move history. Timestamp is unix
+// milliseconds and optional (0 when the client didn't record it); Digit is
+// ignored for erase.
+type ReviewAction struct {
+	Type      string `json:"type"`
+	Index     int    `json:"index"`
+	Digit     int    `json:"digit"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ReviewAnnotation grades one history entry. Verdict and Technique are only
+// set for placements; MissedSingle names a cell where a naked or hidden
+// single was available while the user guessed elsewhere.
+type ReviewAnnotation struct {
+	Move         int    `json:"move"`
+	Type         string `json:"type"`
+	Index        int    `json:"index"`
+	Digit        int    `json:"digit,omitempty"`
+	Verdict      string `json:"verdict,omitempty"`
+	Technique    string `json:"technique,omitempty"`
+	MissedSingle string `json:"missedSingle,omitempty"`
+}
+
+// ReviewSummary aggregates the per-placement verdicts
+type ReviewSummary struct {
+	Placements     int     `json:"placements"`
+	Derivable      int     `json:"derivable"`
+	Guesses        int     `json:"guesses"`
+	Mistakes       int     `json:"mistakes"`
+	MissedSingles  int     `json:"missedSingles"`
+	AvgMoveSeconds float64 `json:"avgMoveSeconds,omitempty"`
+}
+
+// SolveReview is the full analysis of one history
+type SolveReview struct {
+	Annotations []ReviewAnnotation `json:"annotations"`
+	Summary     ReviewSummary      `json:"summary"`
+}
+
+// ReviewHistory replays history against the given puzzle and grades every
+// placement. The board is mutated exactly as the user played - including
+// mistakes - so later verdicts reflect the state the user actually saw.
+func (s *Solver) ReviewHistory(givens []int, history []ReviewAction) (*SolveReview, error) {
+	if len(givens) != constants.TotalCells {
+		return nil, fmt.Errorf("givens must have %d cells", constants.TotalCells)
+	}
+	if len(history) > MaxReviewHistory {
+		return nil, fmt.Errorf("history exceeds %d entries", MaxReviewHistory)
+	}
+
+	solution := dp.Solve(givens)
+	if solution == nil {
+		return nil, fmt.Errorf("puzzle has no solution")
+	}
+
+	board := NewBoard(givens)
+	review := &SolveReview{Annotations: make([]ReviewAnnotation, 0, len(history))}
+	deadline := time.Now().Add(reviewTimeBudget)
+
+	for i, action := range history {
+		if action.Index < 0 || action.Index >= constants.TotalCells {
+			return nil, fmt.Errorf("history entry %d: cell index %d out of range", i, action.Index)
+		}
+		annotation := ReviewAnnotation{Move: i, Type: action.Type, Index: action.Index, Digit: action.Digit}
+
+		switch action.Type {
+		case ReviewActionSet:
+			if action.Digit < 1 || action.Digit > constants.GridSize {
+				return nil, fmt.Errorf("history entry %d: digit %d out of range", i, action.Digit)
+			}
+			if givens[action.Index] != 0 {
+				return nil, fmt.Errorf("history entry %d: cell %d is a given", i, action.Index)
+			}
+			s.gradePlacement(board, solution, &annotation, deadline)
+			review.tally(annotation)
+			board.SetCell(action.Index, action.Digit)
+		case ReviewActionErase:
+			if givens[action.Index] != 0 {
+				return nil, fmt.Errorf("history entry %d: cell %d is a given", i, action.Index)
+			}
+			annotation.Digit = 0
+			board.ClearCell(action.Index)
+		case ReviewActionMark:
+			if action.Digit < 1 || action.Digit > constants.GridSize {
+				return nil, fmt.Errorf("history entry %d: digit %d out of range", i, action.Digit)
+			}
+			// Pencil marks don't advance the replayed board: it tracks true
+			// candidates, and verdicts grade placements against those
+		default:
+			return nil, fmt.Errorf("history entry %d: unknown action type %q", i, action.Type)
+		}
+
+		review.Annotations = append(review.Annotations, annotation)
+	}
+
+	review.Summary.AvgMoveSeconds = averageGapSeconds(history)
+	return review, nil
+}
+
+// gradePlacement fills in the verdict fields for one set action
+func (s *Solver) gradePlacement(board *Board, solution []int, annotation *ReviewAnnotation, deadline time.Time) {
+	if time.Now().After(deadline) {
+		annotation.Verdict = VerdictNotAnalyzed
+		return
+	}
+	if annotation.Digit != solution[annotation.Index] {
+		annotation.Verdict = VerdictMistake
+		return
+	}
+	if slug := s.findDerivation(board, annotation.Index, annotation.Digit); slug != "" {
+		annotation.Verdict = VerdictDerivable
+		annotation.Technique = slug
+		return
+	}
+	annotation.Verdict = VerdictGuess
+	if idx := s.availableSingle(board); idx >= 0 {
+		annotation.MissedSingle = fmt.Sprintf("R%dC%d", idx/constants.GridSize+1, idx%constants.GridSize+1)
+	}
+}
+
+// findDerivation scans the registry in tier order for an enabled technique
+// that assigns digit to the cell on the current board, mirroring the
+// cell-targeted detection used by FindMoveForCell
+func (s *Solver) findDerivation(b *Board, idx, digit int) string {
+	row, col := idx/constants.GridSize, idx%constants.GridSize
+	for _, tier := range tierScanOrder {
+		for _, t := range s.registry.GetByTier(tier) {
+			for _, move := range s.movesForTechnique(t, b) {
+				if move.Action != constants.ActionAssign || move.Digit != digit {
+					continue
+				}
+				for _, target := range move.Targets {
+					if target.Row == row && target.Col == col {
+						return t.Slug
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// availableSingle returns the cell of a naked or hidden single currently on
+// the board, or -1; a guess made while one was available is worth flagging
+func (s *Solver) availableSingle(b *Board) int {
+	for _, slug := range []string{"naked-single", "hidden-single"} {
+		t := s.registry.GetBySlug(slug)
+		if t == nil || !t.Enabled {
+			continue
+		}
+		if move := s.runDetector(*t, b); move != nil && len(move.Targets) > 0 {
+			return move.Targets[0].Row*constants.GridSize + move.Targets[0].Col
+		}
+	}
+	return -1
+}
+
+// tally folds one graded placement into the summary counts
+func (r *SolveReview) tally(annotation ReviewAnnotation) {
+	r.Summary.Placements++
+	switch annotation.Verdict {
+	case VerdictDerivable:
+		r.Summary.Derivable++
+	case VerdictGuess:
+		r.Summary.Guesses++
+	case VerdictMistake:
+		r.Summary.Mistakes++
+	}
+	if annotation.MissedSingle != "" {
+		r.Summary.MissedSingles++
+	}
+}
+
+// averageGapSeconds is the mean time between consecutive timestamped
+// entries, or 0 when fewer than two entries carry timestamps
+func averageGapSeconds(history []ReviewAction) float64 {
+	var total, gaps int64
+	prev := int64(0)
+	for _, action := range history {
+		if action.Timestamp == 0 {
+			continue
+		}
+		if prev != 0 && action.Timestamp > prev {
+			total += action.Timestamp - prev
+			gaps++
+		}
+		prev = action.Timestamp
+	}
+	if gaps == 0 {
+		return 0
+	}
+	return float64(total) / float64(gaps) / 1000
+}
@@ -0,0 +1,160 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// replayAssignments runs the solver over a puzzle and converts every
+// assignment it makes into a set history entry, stopping when the solver
+// stalls or the puzzle is solved. Returns the history and whether it solved.
+func replayAssignments(t *testing.T, givens []int) ([]ReviewAction, *Board) {
+	t.Helper()
+	board := NewBoard(givens)
+	solver := NewSolver()
+	var history []ReviewAction
+	for step := 0; step < constants.MaxSolverSteps; step++ {
+		if board.IsSolved() {
+			break
+		}
+		move := solver.FindNextMove(board)
+		if move == nil || move.Action == constants.ActionContradiction {
+			break
+		}
+		if move.Action == constants.ActionAssign && len(move.Targets) > 0 {
+			idx := move.Targets[0].Row*constants.GridSize + move.Targets[0].Col
+			history = append(history, ReviewAction{Type: ReviewActionSet, Index: idx, Digit: move.Digit})
+		}
+		solver.ApplyMove(board, move)
+	}
+	return history, board
+}
+
+// TestReviewHistoryCleanSolve grades a history of solver-derived placements:
+// every one must come back derivable with a technique attached
+func TestReviewHistoryCleanSolve(t *testing.T) {
+	fullGrid := dp.GenerateFullGrid(1)
+	puzzles, _ := dp.CarveGivensWithSubset(fullGrid, 1)
+	givens := puzzles[constants.DifficultyEasy]
+
+	history, board := replayAssignments(t, givens)
+	if !board.IsSolved() {
+		t.Fatalf("solver did not finish the easy puzzle (%d placements)", len(history))
+	}
+
+	// Interleave a pencil mark and timestamps to cover the non-placement path
+	history[0].Timestamp = 10_000
+	history[1].Timestamp = 20_000
+	history[2].Timestamp = 40_000
+	marked := append([]ReviewAction{{Type: ReviewActionMark, Index: history[0].Index, Digit: history[0].Digit}}, history...)
+
+	review, err := NewSolver().ReviewHistory(givens, marked)
+	if err != nil {
+		t.Fatalf("ReviewHistory: %v", err)
+	}
+
+	if review.Summary.Placements != len(history) {
+		t.Errorf("Placements = %d, want %d", review.Summary.Placements, len(history))
+	}
+	if review.Summary.Guesses != 0 || review.Summary.Mistakes != 0 {
+		t.Errorf("clean history graded with %d guesses, %d mistakes", review.Summary.Guesses, review.Summary.Mistakes)
+	}
+	if review.Summary.Derivable != len(history) {
+		t.Errorf("Derivable = %d, want %d", review.Summary.Derivable, len(history))
+	}
+	for _, annotation := range review.Annotations {
+		if annotation.Type != ReviewActionSet {
+			continue
+		}
+		if annotation.Verdict != VerdictDerivable {
+			t.Fatalf("move %d (R%dC%d=%d) graded %q", annotation.Move,
+				annotation.Index/constants.GridSize+1, annotation.Index%constants.GridSize+1,
+				annotation.Digit, annotation.Verdict)
+		}
+		if annotation.Technique == "" {
+			t.Errorf("move %d is derivable but names no technique", annotation.Move)
+		}
+	}
+	if review.Summary.AvgMoveSeconds != 15 {
+		t.Errorf("AvgMoveSeconds = %v, want 15", review.Summary.AvgMoveSeconds)
+	}
+}
+
+// TestReviewHistoryGradesGuessAndMistake scripts a history on a nearly
+// empty board, where no technique can justify a placement: one entry
+// matches the analyzer's solution (a lucky guess) and one contradicts it
+func TestReviewHistoryGradesGuessAndMistake(t *testing.T) {
+	fullGrid := dp.GenerateFullGrid(1)
+
+	// Two givens constrain nothing: no single, pair or chain fires, so any
+	// placement is unjustified
+	givens := make([]int, constants.TotalCells)
+	givens[0] = fullGrid[0]
+	givens[40] = fullGrid[40]
+
+	solution := dp.Solve(givens)
+	if solution == nil {
+		t.Fatal("two-given board has no solution")
+	}
+	wrong := solution[2]%constants.GridSize + 1 // never equals solution[2]
+
+	scripted := []ReviewAction{
+		{Type: ReviewActionSet, Index: 1, Digit: solution[1]},
+		{Type: ReviewActionSet, Index: 2, Digit: wrong},
+	}
+
+	review, err := NewSolver().ReviewHistory(givens, scripted)
+	if err != nil {
+		t.Fatalf("ReviewHistory: %v", err)
+	}
+
+	if review.Summary.Guesses != 1 {
+		t.Errorf("Guesses = %d, want 1", review.Summary.Guesses)
+	}
+	if review.Summary.Mistakes != 1 {
+		t.Errorf("Mistakes = %d, want 1", review.Summary.Mistakes)
+	}
+	if review.Annotations[0].Verdict != VerdictGuess {
+		t.Errorf("guess placement graded %q", review.Annotations[0].Verdict)
+	}
+	if review.Annotations[1].Verdict != VerdictMistake {
+		t.Errorf("mistake placement graded %q", review.Annotations[1].Verdict)
+	}
+}
+
+// TestReviewHistoryRejectsBadEntries covers the validation paths
+func TestReviewHistoryRejectsBadEntries(t *testing.T) {
+	fullGrid := dp.GenerateFullGrid(1)
+	puzzles, _ := dp.CarveGivensWithSubset(fullGrid, 1)
+	givens := puzzles[constants.DifficultyEasy]
+	solver := NewSolver()
+
+	givenIdx := -1
+	for i, v := range givens {
+		if v != 0 {
+			givenIdx = i
+			break
+		}
+	}
+
+	cases := []struct {
+		name    string
+		history []ReviewAction
+	}{
+		{"unknown type", []ReviewAction{{Type: "swap", Index: 0, Digit: 1}}},
+		{"digit out of range", []ReviewAction{{Type: ReviewActionSet, Index: 0, Digit: 10}}},
+		{"index out of range", []ReviewAction{{Type: ReviewActionSet, Index: 81, Digit: 1}}},
+		{"overwriting a given", []ReviewAction{{Type: ReviewActionSet, Index: givenIdx, Digit: 1}}},
+	}
+	for _, tc := range cases {
+		if _, err := solver.ReviewHistory(givens, tc.history); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+
+	if _, err := solver.ReviewHistory(givens, make([]ReviewAction, MaxReviewHistory+1)); err == nil {
+		t.Error("expected error for oversized history")
+	}
+}
@@ -0,0 +1,79 @@
+package human
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// ============================================================================
+// Calibrated Score Weights
+// ============================================================================
+//
+// The Score values in the technique manifest are expert guesses. cmd/calibrate
+// fits replacements against anonymized human solve-time data and writes them
+// as a weights file; loading that file here overrides the manifest Scores for
+// every registry built afterwards, so the numeric difficulty rating tracks
+// measured effort instead of intuition. Load at startup, before any solver is
+// created — registries snapshot the overrides when they register techniques.
+
+// ScoreWeightsFile is the on-disk weights format produced by cmd/calibrate.
+// MinScore/MaxScore record the bounds the fit was clamped to; the loader
+// re-checks every weight against them so a hand-edited file cannot smuggle in
+// a zero or runaway score.
+type ScoreWeightsFile struct {
+	MinScore int                `json:"min_score"`
+	MaxScore int                `json:"max_score"`
+	Weights  map[string]float64 `json:"weights"`
+}
+
+// scoreOverrides maps technique slugs to calibrated scores. Nil means the
+// built-in manifest scores apply. Set once at startup; not guarded by a lock.
+var scoreOverrides map[string]int
+
+// SetScoreOverrides validates and installs calibrated score overrides.
+// Every slug must name a registered technique and every weight must sit
+// within the file's own positive bounds. Slugs absent from the file keep
+// their built-in scores.
+func SetScoreOverrides(file ScoreWeightsFile) error {
+	if file.MinScore <= 0 || file.MaxScore < file.MinScore {
+		return fmt.Errorf("invalid score bounds %d-%d: min must be positive and at most max", file.MinScore, file.MaxScore)
+	}
+	known := make(map[string]bool)
+	for _, desc := range techniqueManifest() {
+		known[desc.Slug] = true
+	}
+	overrides := make(map[string]int, len(file.Weights))
+	for slug, weight := range file.Weights {
+		if !known[canonicalSlug(slug)] {
+			return fmt.Errorf("unknown technique %q in weights file", slug)
+		}
+		if weight < float64(file.MinScore) || weight > float64(file.MaxScore) {
+			return fmt.Errorf("technique %q: weight %.2f outside bounds %d-%d", slug, weight, file.MinScore, file.MaxScore)
+		}
+		overrides[canonicalSlug(slug)] = int(math.Round(weight))
+	}
+	scoreOverrides = overrides
+	return nil
+}
+
+// ClearScoreOverrides restores the built-in manifest scores
+func ClearScoreOverrides() {
+	scoreOverrides = nil
+}
+
+// LoadScoreWeights reads a cmd/calibrate weights file and installs its
+// overrides. A missing or invalid file is an error; callers decide whether
+// that is fatal.
+func LoadScoreWeights(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read weights file: %w", err)
+	}
+	var file ScoreWeightsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse weights file: %w", err)
+	}
+	return SetScoreOverrides(file)
+}
@@ -0,0 +1,121 @@
+package human
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// writeWeightsFile marshals a weights file into a temp dir and returns its path
+func writeWeightsFile(t *testing.T, file ScoreWeightsFile) string {
+	t.Helper()
+	data, err := json.Marshal(file)
+	if err != nil {
+		t.Fatalf("marshal weights: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "weights.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("write weights: %v", err)
+	}
+	return path
+}
+
+// TestLoadScoreWeightsChangesSolveScore solves the same puzzle before and
+// after loading a weights file and checks the summary score shifts by exactly
+// the overridden per-move deltas — same moves, recalibrated rating.
+func TestLoadScoreWeightsChangesSolveScore(t *testing.T) {
+	t.Cleanup(ClearScoreOverrides)
+
+	data, ok := GetTechniquePuzzle("x-wing")
+	if !ok {
+		t.Fatal("no puzzle data for x-wing")
+	}
+	givens, _ := loadTestPuzzle(t, data)
+
+	baseline := NewSolver()
+	baseScores := map[string]int{
+		"naked-single":  baseline.GetRegistry().GetBySlug("naked-single").Score,
+		"hidden-single": baseline.GetRegistry().GetBySlug("hidden-single").Score,
+	}
+	baseMoves, status := baseline.SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps)
+	if status != constants.StatusCompleted {
+		t.Fatalf("baseline solve did not complete: %s", status)
+	}
+	baseScore := baseline.Summarize(baseMoves).Score
+
+	overrides := map[string]float64{
+		"naked-single":  float64(baseScores["naked-single"]) + 7,
+		"hidden-single": float64(baseScores["hidden-single"]) + 11,
+	}
+	path := writeWeightsFile(t, ScoreWeightsFile{MinScore: 5, MaxScore: 200, Weights: overrides})
+	if err := LoadScoreWeights(path); err != nil {
+		t.Fatalf("LoadScoreWeights failed: %v", err)
+	}
+
+	calibrated := NewSolver()
+	moves, status := calibrated.SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps)
+	if status != constants.StatusCompleted {
+		t.Fatalf("calibrated solve did not complete: %s", status)
+	}
+	if len(moves) != len(baseMoves) {
+		t.Fatalf("weights changed the move stream: %d moves vs %d", len(moves), len(baseMoves))
+	}
+
+	// The expected shift follows from the baseline technique counts
+	wantScore := baseScore
+	for _, move := range baseMoves {
+		if move.Tier == "" {
+			continue
+		}
+		if weight, ok := overrides[move.Technique]; ok {
+			wantScore += weight - float64(move.Score)
+		}
+	}
+	gotScore := calibrated.Summarize(moves).Score
+	if gotScore != wantScore {
+		t.Errorf("calibrated score = %v, want %v (baseline %v)", gotScore, wantScore, baseScore)
+	}
+
+	// Loading the same file again is a no-op: the rating is deterministic
+	if err := LoadScoreWeights(path); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	repeat, _ := NewSolver().SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps)
+	if again := calibrated.Summarize(repeat).Score; again != gotScore {
+		t.Errorf("score not deterministic across reloads: %v vs %v", again, gotScore)
+	}
+}
+
+func TestSetScoreOverridesValidation(t *testing.T) {
+	t.Cleanup(ClearScoreOverrides)
+
+	cases := []struct {
+		name string
+		file ScoreWeightsFile
+	}{
+		{"non-positive min", ScoreWeightsFile{MinScore: 0, MaxScore: 100, Weights: map[string]float64{"x-wing": 50}}},
+		{"max below min", ScoreWeightsFile{MinScore: 50, MaxScore: 10, Weights: map[string]float64{"x-wing": 50}}},
+		{"unknown slug", ScoreWeightsFile{MinScore: 5, MaxScore: 200, Weights: map[string]float64{"not-a-technique": 50}}},
+		{"weight below bounds", ScoreWeightsFile{MinScore: 5, MaxScore: 200, Weights: map[string]float64{"x-wing": 2}}},
+		{"weight above bounds", ScoreWeightsFile{MinScore: 5, MaxScore: 200, Weights: map[string]float64{"x-wing": 500}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := SetScoreOverrides(tc.file); err == nil {
+				t.Errorf("expected %s to be rejected", tc.name)
+			}
+		})
+	}
+
+	// A valid file resolves aliases onto the canonical technique
+	valid := ScoreWeightsFile{MinScore: 5, MaxScore: 200, Weights: map[string]float64{"claiming": 33}}
+	if err := SetScoreOverrides(valid); err != nil {
+		t.Fatalf("valid weights rejected: %v", err)
+	}
+	if got := NewTechniqueRegistry().GetBySlug("box-line-reduction").Score; got != 33 {
+		t.Errorf("aliased override not applied: box-line-reduction score = %d, want 33", got)
+	}
+}
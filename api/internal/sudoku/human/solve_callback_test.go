@@ -0,0 +1,70 @@
+package human
+
+import (
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// callbackTestGivens carves one medium board for the callback tests
+func callbackTestGivens() []int {
+	medium, _ := constants.DifficultyByName(constants.DifficultyMedium)
+	return dp.CarveGivens(dp.GenerateFullGridFast(3), medium.TargetGivens, 3)
+}
+
+// TestSolveWithStepsFuncDeliversEveryMove checks that the callback sees
+// exactly the returned move stream, in order, with the board snapshot
+// reflecting each move as applied.
+func TestSolveWithStepsFuncDeliversEveryMove(t *testing.T) {
+	givens := callbackTestGivens()
+
+	// Reference run without a callback
+	wantMoves, wantStatus := NewSolver().SolveWithSteps(NewBoard(givens), constants.MaxSolverSteps)
+
+	var seen []core.Move
+	var lastCells []int
+	moves, status := NewSolver().SolveWithStepsFunc(NewBoard(givens), constants.MaxSolverSteps, func(move core.Move, cells []int) bool {
+		seen = append(seen, move)
+		lastCells = cells
+		return true
+	})
+
+	if status != wantStatus {
+		t.Fatalf("status diverged from SolveWithSteps: got %s, want %s", status, wantStatus)
+	}
+	if !reflect.DeepEqual(moves, wantMoves) {
+		t.Fatalf("move list diverged from SolveWithSteps: got %d moves, want %d", len(moves), len(wantMoves))
+	}
+	if !reflect.DeepEqual(seen, moves) {
+		t.Fatalf("callback saw %d moves, return value carries %d", len(seen), len(moves))
+	}
+	if status == constants.StatusCompleted && !dp.IsValid(lastCells) {
+		t.Error("final callback snapshot is not the solved grid")
+	}
+}
+
+// TestSolveWithStepsFuncAborts checks that a false return stops the solve at
+// that move and reports StatusAborted.
+func TestSolveWithStepsFuncAborts(t *testing.T) {
+	givens := callbackTestGivens()
+
+	const stopAfter = 5
+	calls := 0
+	moves, status := NewSolver().SolveWithStepsFunc(NewBoard(givens), constants.MaxSolverSteps, func(core.Move, []int) bool {
+		calls++
+		return calls < stopAfter
+	})
+
+	if status != constants.StatusAborted {
+		t.Fatalf("expected status %s, got %s", constants.StatusAborted, status)
+	}
+	if calls != stopAfter {
+		t.Errorf("callback ran %d times after aborting at %d", calls, stopAfter)
+	}
+	if len(moves) != stopAfter {
+		t.Errorf("expected %d moves up to the abort, got %d", stopAfter, len(moves))
+	}
+}
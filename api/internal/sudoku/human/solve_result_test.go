@@ -0,0 +1,88 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human/techniques"
+	"sudoku-api/pkg/constants"
+)
+
+// A solve cut off by a tight step cap reports how far it got and how to
+// finish: CanContinue, the true empty-cell count, and a step suggestion
+// that actually completes the puzzle when used for the continuation.
+func TestSolveResultContinuationAfterStepCap(t *testing.T) {
+	givens := dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1)
+	board := NewBoard(givens)
+
+	alarmsBefore := MaxStepsAlarmCount()
+	result := NewSolver().SolveWithStepsResult(board, 3)
+	if result.Status != constants.StatusMaxStepsReached {
+		t.Fatalf("status = %q, want %q", result.Status, constants.StatusMaxStepsReached)
+	}
+	if !result.CanContinue {
+		t.Error("CanContinue = false after hitting the step cap")
+	}
+	if MaxStepsAlarmCount() != alarmsBefore+1 {
+		t.Error("step-cap alarm counter did not increment")
+	}
+
+	empty := 0
+	for _, cell := range board.GetCells() {
+		if cell == 0 {
+			empty++
+		}
+	}
+	if result.Remaining != empty {
+		t.Errorf("Remaining = %d, want %d empty cells", result.Remaining, empty)
+	}
+	if result.Remaining == 0 {
+		t.Fatal("3 steps solved a 30-given puzzle; cap test is vacuous")
+	}
+	if result.SuggestedMaxSteps <= 3 {
+		t.Fatalf("SuggestedMaxSteps = %d, want > the exhausted cap", result.SuggestedMaxSteps)
+	}
+
+	// Continuing from the partial board with the suggested cap finishes
+	resumed := NewSolver().SolveWithStepsResult(board, result.SuggestedMaxSteps)
+	if resumed.Status != constants.StatusCompleted {
+		t.Fatalf("continuation status = %q, want %q", resumed.Status, constants.StatusCompleted)
+	}
+	if resumed.Remaining != 0 || resumed.CanContinue || resumed.SuggestedMaxSteps != 0 {
+		t.Errorf("completed solve carries continuation fields: %+v", resumed)
+	}
+}
+
+// A detector that keeps re-emitting the same elimination-free move would
+// spin until the step cap without cycle detection; the solve must abort as
+// cycle_detected and name the offending move instead.
+func TestSolveResultDetectsCyclingDetector(t *testing.T) {
+	registry := NewTechniqueRegistry()
+	registry.GetBySlug("x-wing").Detector = func(b techniques.BoardInterface) *core.Move {
+		return &core.Move{Technique: "x-wing", Action: constants.ActionEliminate, Digit: 4}
+	}
+
+	// An empty board gives the real detectors nothing to find, leaving only
+	// the stubbed non-progressing move
+	board := NewBoard(make([]int, constants.TotalCells))
+	alarmsBefore := CycleAlarmCount()
+	result := NewSolverWithRegistry(registry).SolveWithStepsResult(board, constants.MaxSolverSteps)
+	if result.Status != constants.StatusCycleDetected {
+		t.Fatalf("status = %q, want %q", result.Status, constants.StatusCycleDetected)
+	}
+	if result.CycleMove == nil || result.CycleMove.Technique != "x-wing" {
+		t.Fatalf("CycleMove = %+v, want the stubbed x-wing move", result.CycleMove)
+	}
+	if result.CanContinue {
+		t.Error("CanContinue = true for a cycling solve; rerunning cannot help")
+	}
+	if CycleAlarmCount() != alarmsBefore+1 {
+		t.Error("cycle alarm counter did not increment")
+	}
+	// The first emission is kept — it looked legitimate at the time — but
+	// the repeat is not
+	if len(result.Moves) != 1 {
+		t.Errorf("got %d moves, want the single first emission", len(result.Moves))
+	}
+}
@@ -0,0 +1,90 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// TestSummarizeXWingSolve runs the x-wing fixture with only singles and the
+// target enabled, then checks the summary singles out the x-wing move.
+func TestSummarizeXWingSolve(t *testing.T) {
+	data, ok := GetTechniquePuzzle("x-wing")
+	if !ok {
+		t.Fatal("no puzzle data for x-wing")
+	}
+	givens, _ := loadTestPuzzle(t, data)
+
+	registry := NewTechniqueRegistry()
+	targetTech := registry.GetBySlug("x-wing")
+	if targetTech == nil {
+		t.Fatal("x-wing not in registry")
+	}
+	applyIsolationStrategy(registry, "x-wing", targetTech, DisableAllExceptTargetAndBasics)
+	solver := NewSolverWithRegistry(registry)
+
+	board := NewBoard(givens)
+	moves, _ := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	summary := solver.Summarize(moves)
+
+	if summary.ToughestMove < 0 || summary.ToughestMove >= len(moves) {
+		t.Fatalf("ToughestMove = %d with %d moves", summary.ToughestMove, len(moves))
+	}
+	if tech := moves[summary.ToughestMove].Technique; tech != "x-wing" {
+		t.Errorf("toughest move is %q, want x-wing", tech)
+	}
+
+	// Recount tiers from the stamped moves; ungraded moves must not appear
+	wantCounts := make(map[string]int)
+	var wantScore float64
+	for _, move := range moves {
+		if move.Tier == "" {
+			continue
+		}
+		wantCounts[move.Tier]++
+		wantScore += float64(move.Score)
+	}
+	if len(summary.TierCounts) != len(wantCounts) {
+		t.Errorf("TierCounts = %v, want %v", summary.TierCounts, wantCounts)
+	}
+	for tier, n := range wantCounts {
+		if summary.TierCounts[tier] != n {
+			t.Errorf("TierCounts[%s] = %d, want %d", tier, summary.TierCounts[tier], n)
+		}
+	}
+	if summary.TierCounts[constants.TierMedium] == 0 {
+		t.Error("expected at least one medium-tier move (the x-wing)")
+	}
+	if summary.Score != wantScore {
+		t.Errorf("Score = %v, want %v", summary.Score, wantScore)
+	}
+}
+
+// TestSummarizeTieBreaksLater verifies equal-score ties pick the later move
+// and that ungraded moves are skipped entirely.
+func TestSummarizeTieBreaksLater(t *testing.T) {
+	s := NewSolver()
+	moves := []core.Move{
+		{Technique: "fill-candidate"}, // ungraded, skipped
+		{Technique: "naked-single", Tier: constants.TierSimple, Score: 10},
+		{Technique: "x-wing", Tier: constants.TierMedium, Score: 55},
+		{Technique: "hidden-single", Tier: constants.TierSimple, Score: 15},
+		{Technique: "x-wing", Tier: constants.TierMedium, Score: 55},
+	}
+
+	summary := s.Summarize(moves)
+	if summary.ToughestMove != 4 {
+		t.Errorf("ToughestMove = %d, want 4 (ties break toward the later move)", summary.ToughestMove)
+	}
+	if summary.TierCounts[constants.TierSimple] != 2 || summary.TierCounts[constants.TierMedium] != 2 {
+		t.Errorf("TierCounts = %v, want 2 simple and 2 medium", summary.TierCounts)
+	}
+	if summary.Score != 135 {
+		t.Errorf("Score = %v, want 135", summary.Score)
+	}
+
+	if empty := s.Summarize(nil); empty.ToughestMove != -1 || empty.Score != 0 {
+		t.Errorf("empty summary = %+v, want ToughestMove -1 and zero score", empty)
+	}
+}
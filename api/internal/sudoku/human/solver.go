@@ -1,7 +1,13 @@
 package human
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
@@ -48,6 +54,39 @@ var DifficultyAllowedTiers = map[core.Difficulty][]string{
 	core.DifficultyImpossible: {constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme},
 }
 
+// ============================================================================
+// Solver Strategy
+// ============================================================================
+
+// SolverStrategy controls how the solver picks among technique hits when
+// several detectors could fire on the same board state
+type SolverStrategy string
+
+const (
+	// StrategyFirst returns the first detector hit in registry order (default)
+	StrategyFirst SolverStrategy = "first"
+	// StrategyPreferAssign scans all enabled tiers for an assign move before
+	// falling back to the first eliminate found
+	StrategyPreferAssign SolverStrategy = "prefer-assign"
+	// StrategyMaxImpact picks, within the first tier that yields any move,
+	// an assignment or else the move with the most eliminations
+	StrategyMaxImpact SolverStrategy = "max-impact"
+)
+
+// ParseStrategy validates a strategy string. An empty string selects the
+// default StrategyFirst. Returns false for unknown strategies.
+func ParseStrategy(s string) (SolverStrategy, bool) {
+	switch SolverStrategy(s) {
+	case "", StrategyFirst:
+		return StrategyFirst, true
+	case StrategyPreferAssign:
+		return StrategyPreferAssign, true
+	case StrategyMaxImpact:
+		return StrategyMaxImpact, true
+	}
+	return StrategyFirst, false
+}
+
 // ============================================================================
 // Solver Struct
 // ============================================================================
@@ -55,6 +94,9 @@ var DifficultyAllowedTiers = map[core.Difficulty][]string{
 // Solver holds the technique registry and orchestrates solving
 type Solver struct {
 	registry *TechniqueRegistry
+	// strategy controls move ordering when multiple techniques could fire.
+	// It is configuration, not per-session state, so Reset leaves it alone.
+	strategy SolverStrategy
 	// generationState tracks the candidate-generation / technique-application phase.
 	// It is required because the autosolver runs in two phases: generate all
 	// candidates (digit-first) then apply techniques. Persisting the state
@@ -64,12 +106,22 @@ type Solver struct {
 	// This prevents infinite loops by ensuring we don't return the same candidate
 	// move repeatedly across multiple FindNextMove calls.
 	candidateIndex int
+	// stats collects per-detector instrumentation when EnableStats has been
+	// called. Nil means disabled: detector calls then go through untimed, so
+	// the hot path pays nothing for the feature.
+	stats *SolverStats
+	// logger, when set, receives one entry per completed solve (status, step
+	// count, wall time) — solve boundaries only, never per move. Nil means
+	// disabled: the solve loop then makes no logging or clock calls, same as
+	// the stats field above.
+	logger *slog.Logger
 }
 
 // NewSolver creates a solver with the technique registry
 func NewSolver() *Solver {
 	return &Solver{
 		registry:        NewTechniqueRegistry(),
+		strategy:        StrategyFirst,
 		generationState: StateNotStarted,
 		candidateIndex:  0,
 	}
@@ -79,11 +131,17 @@ func NewSolver() *Solver {
 func NewSolverWithRegistry(registry *TechniqueRegistry) *Solver {
 	return &Solver{
 		registry:        registry,
+		strategy:        StrategyFirst,
 		generationState: StateNotStarted,
 		candidateIndex:  0,
 	}
 }
 
+// SetStrategy sets the move-ordering strategy used by FindNextMove
+func (s *Solver) SetStrategy(strategy SolverStrategy) {
+	s.strategy = strategy
+}
+
 // Reset clears the solver's stateful fields to ensure each solving session
 // starts fresh. This prevents state from one hint request affecting another.
 func (s *Solver) Reset() {
@@ -91,6 +149,58 @@ func (s *Solver) Reset() {
 	s.candidateIndex = 0
 }
 
+// SolverStats aggregates detector measurements across FindNextMove calls,
+// keyed by technique slug. It exists for difficulty research: which detectors
+// run, how often they fire, and where solving time actually goes.
+type SolverStats struct {
+	DetectorCalls map[string]int           // times each detector was invoked
+	DetectorTime  map[string]time.Duration // cumulative wall time per detector
+	Hits          map[string]int           // times each detector returned a move
+}
+
+// EnableStats turns on per-detector instrumentation. Stats accumulate across
+// solves and survive Reset (they are measurement output, not session state);
+// call EnableStats again to start a fresh collection.
+func (s *Solver) EnableStats() {
+	s.stats = &SolverStats{
+		DetectorCalls: make(map[string]int),
+		DetectorTime:  make(map[string]time.Duration),
+		Hits:          make(map[string]int),
+	}
+}
+
+// Stats returns the collected detector stats, or nil when stats are disabled
+func (s *Solver) Stats() *SolverStats {
+	return s.stats
+}
+
+// SetLogger installs a boundary logger: SolveWithStepsOpts then emits one
+// debug entry when a solve finishes. A nil logger (the default) disables it.
+func (s *Solver) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// runDetector invokes a technique's detector, timing it only when stats are
+// enabled so the disabled path makes no clock calls. Uniqueness-based
+// techniques are skipped outright on boards that don't assume a unique
+// solution — their eliminations would be unsound there.
+func (s *Solver) runDetector(t TechniqueDescriptor, b *Board) *core.Move {
+	if t.RequiresUniqueness && !b.AssumeUnique {
+		return nil
+	}
+	if s.stats == nil {
+		return guardedDetect(t, b)
+	}
+	start := time.Now()
+	move := guardedDetect(t, b)
+	s.stats.DetectorTime[t.Slug] += time.Since(start)
+	s.stats.DetectorCalls[t.Slug]++
+	if move != nil {
+		s.stats.Hits[t.Slug]++
+	}
+	return move
+}
+
 // GenerationState represents the solver's candidate-generation lifecycle
 type GenerationState int
 
@@ -135,19 +245,19 @@ func (s *Solver) createDuplicateViolationMove(digit int, idx1, idx2 int, unitTyp
 	switch unitType {
 	case UnitRow:
 		technique = "constraint-violation-duplicate-row"
-		unitName = fmt.Sprintf("row %d", unitIndex+1)
+		unitName = "row"
 		secondary = getRowCellRefs(unitIndex)
 	case UnitCol:
 		technique = "constraint-violation-duplicate-col"
-		unitName = fmt.Sprintf("column %d", unitIndex+1)
+		unitName = "column"
 		secondary = getColCellRefs(unitIndex)
 	case UnitBox:
 		technique = "constraint-violation-duplicate-box"
-		unitName = fmt.Sprintf("box %d", unitIndex+1)
+		unitName = "box"
 		secondary = getBoxCellRefs(unitIndex)
 	}
 
-	return &core.Move{
+	move := &core.Move{
 		Technique: technique,
 		Action:    "contradiction",
 		Digit:     digit,
@@ -155,8 +265,6 @@ func (s *Solver) createDuplicateViolationMove(digit int, idx1, idx2 int, unitTyp
 			{Row: row1, Col: col1},
 			{Row: row2, Col: col2},
 		},
-		Explanation: fmt.Sprintf("Constraint violation: %d appears twice in %s at R%dC%d and R%dC%d",
-			digit, unitName, row1+1, col1+1, row2+1, col2+1),
 		Highlights: core.Highlights{
 			Primary:   []core.CellRef{{Row: row1, Col: col1}, {Row: row2, Col: col2}},
 			Secondary: secondary,
@@ -167,6 +275,14 @@ func (s *Solver) createDuplicateViolationMove(digit int, idx1, idx2 int, unitTyp
 			URL:   "",
 		},
 	}
+	move.SetExplanation("solver.constraint-violation", map[string]interface{}{
+		"digit":     digit,
+		"unitType":  unitName,
+		"unitIndex": unitIndex + 1,
+		"cell1":     fmt.Sprintf("R%dC%d", row1+1, col1+1),
+		"cell2":     fmt.Sprintf("R%dC%d", row2+1, col2+1),
+	})
+	return move
 }
 
 // checkConstraintViolations detects logical constraint violations in the board
@@ -220,15 +336,18 @@ func (s *Solver) checkConstraintViolations(b *Board) *core.Move {
 
 			// If no digit can be placed AND candidates are empty, it's a real contradiction
 			if !anyValidPlacement {
-				return &core.Move{
-					Technique:   "contradiction",
-					Action:      "contradiction",
-					Digit:       0,
-					Targets:     []core.CellRef{{Row: row, Col: col}},
-					Explanation: fmt.Sprintf("No candidates available for R%dC%d: contradiction detected", row+1, col+1),
-					Highlights:  core.Highlights{Primary: []core.CellRef{{Row: row, Col: col}}},
-					Refs:        core.TechniqueRef{Title: "Contradiction", Slug: "contradiction"},
+				move := &core.Move{
+					Technique:  "contradiction",
+					Action:     "contradiction",
+					Digit:      0,
+					Targets:    []core.CellRef{{Row: row, Col: col}},
+					Highlights: core.Highlights{Primary: []core.CellRef{{Row: row, Col: col}}},
+					Refs:       core.TechniqueRef{Title: "Contradiction", Slug: "contradiction"},
 				}
+				move.SetExplanation("solver.no-candidates", map[string]interface{}{
+					"cell": fmt.Sprintf("R%dC%d", row+1, col+1),
+				})
+				return move
 			}
 			// Otherwise, candidates just haven't been filled yet - this is normal
 		}
@@ -270,14 +389,12 @@ func (s *Solver) checkConstraintViolations(b *Board) *core.Move {
 					}
 				}
 
-				return &core.Move{
+				move := &core.Move{
 					Technique:    "constraint-violation-invalid-candidate",
 					Action:       "eliminate",
 					Digit:        d,
 					Targets:      []core.CellRef{{Row: row, Col: col}},
 					Eliminations: []core.Candidate{{Row: row, Col: col, Digit: d}},
-					Explanation: fmt.Sprintf("Invalid candidate: R%dC%d has candidate %d, but %d already exists in this cell's row, column, or box",
-						row+1, col+1, d, d),
 					Highlights: core.Highlights{
 						Primary:   []core.CellRef{{Row: row, Col: col}},
 						Secondary: conflictCells,
@@ -288,6 +405,11 @@ func (s *Solver) checkConstraintViolations(b *Board) *core.Move {
 						URL:   "",
 					},
 				}
+				move.SetExplanation("solver.invalid-candidate", map[string]interface{}{
+					"cell":  fmt.Sprintf("R%dC%d", row+1, col+1),
+					"digit": d,
+				})
+				return move
 			}
 		}
 	}
@@ -423,12 +545,11 @@ func (s *Solver) fillCandidatesForUnit(b *Board, unitType UnitType, unitIndex, d
 		row, col := i/constants.GridSize, i%constants.GridSize
 
 		if !digitExistsInCells(b, row, col, d) && !b.Candidates[i].Has(d) && !b.Eliminated[i].Has(d) {
-			return &core.Move{
-				Technique:   "fill-candidate",
-				Action:      "candidate",
-				Digit:       d,
-				Targets:     []core.CellRef{{Row: row, Col: col}},
-				Explanation: fmt.Sprintf("Added %d as a candidate to R%dC%d", d, row+1, col+1),
+			move := &core.Move{
+				Technique: "fill-candidate",
+				Action:    "candidate",
+				Digit:     d,
+				Targets:   []core.CellRef{{Row: row, Col: col}},
 				Highlights: core.Highlights{
 					Primary: []core.CellRef{{Row: row, Col: col}},
 				},
@@ -438,6 +559,11 @@ func (s *Solver) fillCandidatesForUnit(b *Board, unitType UnitType, unitIndex, d
 					URL:   "/technique/fill-candidate",
 				},
 			}
+			move.SetExplanation("solver.add-candidate", map[string]interface{}{
+				"digit": d,
+				"cell":  fmt.Sprintf("R%dC%d", row+1, col+1),
+			})
+			return move
 		}
 	}
 	return nil
@@ -494,12 +620,12 @@ func (s *Solver) checkHiddenSingleInUnit(b *Board, unitType UnitType, unitIndex,
 		case UnitBox:
 			unitName = "box"
 		}
-		return &core.Move{
-			Technique:   "hidden-single",
-			Action:      constants.ActionAssign,
-			Digit:       d,
-			Targets:     []core.CellRef{cell},
-			Explanation: fmt.Sprintf("R%dC%d must be %d: only cell in %s %d that can contain %d", cell.Row+1, cell.Col+1, d, unitName, unitIndex+1, d),
+		move := &core.Move{
+			Technique:        "hidden-single",
+			Action:           constants.ActionAssign,
+			Digit:            d,
+			Targets:          []core.CellRef{cell},
+			TargetCandidates: [][]int{b.Candidates[cell.Row*constants.GridSize+cell.Col].ToSlice()},
 			Highlights: core.Highlights{
 				Primary:   []core.CellRef{cell},
 				Secondary: getUnitCellRefs(unitType, unitIndex),
@@ -510,6 +636,17 @@ func (s *Solver) checkHiddenSingleInUnit(b *Board, unitType UnitType, unitIndex,
 				URL:   "/technique/hidden-single",
 			},
 		}
+		if tech := s.registry.GetBySlug("hidden-single"); tech != nil {
+			move.Tier = tech.Tier
+			move.Score = tech.Score
+		}
+		move.SetExplanation("solver.forced-single", map[string]interface{}{
+			"cell":      fmt.Sprintf("R%dC%d", cell.Row+1, cell.Col+1),
+			"digit":     d,
+			"unitType":  unitName,
+			"unitIndex": unitIndex + 1,
+		})
+		return move
 	}
 
 	return nil
@@ -530,21 +667,48 @@ func (s *Solver) checkHiddenSingleInBox(b *Board, box, d int) *core.Move {
 	return s.checkHiddenSingleInUnit(b, UnitBox, box, d)
 }
 
-// checkForSingles performs single detection AFTER all candidates are filled
+// tierScanOrder is the order tiers are scanned when looking for moves
+var tierScanOrder = []string{constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme}
+
+// decorateMove stamps a detector move with the technique's slug and refs. A
+// detector may pre-set a variant slug (e.g. sashimi-x-wing) which is kept so
+// stats can separate the variants
+func decorateMove(move *core.Move, t TechniqueDescriptor) *core.Move {
+	if move.Technique == "" {
+		move.Technique = t.Slug
+	}
+	move.Refs = core.TechniqueRef{
+		Title: t.Name,
+		Slug:  t.Slug,
+		URL:   fmt.Sprintf("/technique/%s", t.Slug),
+	}
+	move.Tier = t.Tier
+	move.Score = t.Score
+	return move
+}
+
+// checkForSingles performs technique detection AFTER all candidates are filled,
+// dispatching on the configured move-ordering strategy
 func (s *Solver) checkForSingles(b *Board) *core.Move {
+	switch s.strategy {
+	case StrategyPreferAssign:
+		return s.findMovePreferAssign(b)
+	case StrategyMaxImpact:
+		return s.findMoveMaxImpact(b)
+	default:
+		return s.findMoveFirst(b)
+	}
+}
+
+// findMoveFirst returns the first detector hit in registry order
+func (s *Solver) findMoveFirst(b *Board) *core.Move {
 	// Use existing technique library to find singles with complete candidate information
 	// Try techniques by tier (this will find naked singles, hidden singles, etc.)
 
-	for _, tier := range []string{constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme} {
+	for _, tier := range tierScanOrder {
 		for _, t := range s.registry.GetByTier(tier) {
-			if move := t.Detector(b); move != nil {
-				move.Technique = t.Slug
-				move.Refs = core.TechniqueRef{
-					Title: t.Name,
-					Slug:  t.Slug,
-					URL:   fmt.Sprintf("/technique/%s", t.Slug),
-				}
-				return move
+			if move := s.runDetector(t, b); move != nil {
+				return decorateMove(move, t)
 			}
 		}
 	}
@@ -552,6 +716,57 @@ func (s *Solver) checkForSingles(b *Board) *core.Move {
 	return nil // No singles found
 }
 
+// findMovePreferAssign scans all enabled tiers for an assign move before
+// settling for the first eliminate found. This avoids long runs of
+// single-candidate eliminations when a placement is available deeper in
+// the registry. Deterministic: scan order is always registry order.
+func (s *Solver) findMovePreferAssign(b *Board) *core.Move {
+	var firstMove *core.Move
+
+	for _, tier := range tierScanOrder {
+		for _, t := range s.registry.GetByTier(tier) {
+			move := s.runDetector(t, b)
+			if move == nil {
+				continue
+			}
+			decorateMove(move, t)
+			if move.Action == constants.ActionAssign {
+				return move
+			}
+			if firstMove == nil {
+				firstMove = move
+			}
+		}
+	}
+	return firstMove
+}
+
+// findMoveMaxImpact picks, within the first tier that yields any move, an
+// assignment if one exists, otherwise the move with the most eliminations.
+// Ties break to registry order, keeping the result deterministic.
+func (s *Solver) findMoveMaxImpact(b *Board) *core.Move {
+	for _, tier := range tierScanOrder {
+		var best *core.Move
+		for _, t := range s.registry.GetByTier(tier) {
+			move := s.runDetector(t, b)
+			if move == nil {
+				continue
+			}
+			decorateMove(move, t)
+			if move.Action == constants.ActionAssign {
+				return move
+			}
+			if best == nil || len(move.Eliminations) > len(best.Eliminations) {
+				best = move
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
 // checkHiddenSingleForDigitImmediate checks if digit d at cell idx is a hidden single
 // by looking at all POTENTIAL placements (not just current candidates)
 // Note: checkHiddenSingleForDigitImmediate was removed as it was unused.
@@ -628,8 +843,80 @@ func digitExistsInCells(b *Board, row, col, digit int) bool {
 // Move Application
 // ============================================================================
 
-// ApplyMove applies a move to the board
-func (s *Solver) ApplyMove(b *Board, move *core.Move) {
+// ApplyMove validates a move against the current board state, applies it,
+// and returns the cell and candidate changes it caused, including knock-on
+// candidate removals from placements. A move that doesn't fit the board — an
+// assignment into a filled cell or of a digit that isn't a candidate, an
+// elimination referencing a filled cell or an absent candidate, or a
+// fill-candidate that would overwrite a user elimination — returns an error
+// and leaves the board untouched.
+func (s *Solver) ApplyMove(b *Board, move *core.Move) (core.MoveDiff, error) {
+	if err := validateMove(b, move); err != nil {
+		return core.MoveDiff{}, err
+	}
+	return s.ApplyMoveUnchecked(b, move), nil
+}
+
+// validateMove checks that a move makes sense on the board as it stands, so
+// a stale or malformed move errors out instead of silently driving the board
+// into an inconsistent state that surfaces later as a mystery contradiction
+func validateMove(b *Board, move *core.Move) error {
+	switch move.Action {
+	case constants.ActionAssign:
+		for _, target := range move.Targets {
+			idx := target.Row*constants.GridSize + target.Col
+			if b.Cells[idx] != 0 {
+				return fmt.Errorf("assign targets filled cell R%dC%d", target.Row+1, target.Col+1)
+			}
+			if !b.Candidates[idx].Has(move.Digit) {
+				return fmt.Errorf("digit %d is not a candidate in R%dC%d", move.Digit, target.Row+1, target.Col+1)
+			}
+		}
+	case constants.ActionEliminate:
+		for _, elim := range move.Eliminations {
+			idx := elim.Row*constants.GridSize + elim.Col
+			if b.Cells[idx] != 0 {
+				return fmt.Errorf("elimination references filled cell R%dC%d", elim.Row+1, elim.Col+1)
+			}
+			if !b.Candidates[idx].Has(elim.Digit) {
+				return fmt.Errorf("candidate %d is not present in R%dC%d", elim.Digit, elim.Row+1, elim.Col+1)
+			}
+		}
+	case "candidate":
+		for _, target := range move.Targets {
+			idx := target.Row*constants.GridSize + target.Col
+			if b.Cells[idx] != 0 {
+				return fmt.Errorf("fill-candidate targets filled cell R%dC%d", target.Row+1, target.Col+1)
+			}
+			if b.Eliminated[idx].Has(move.Digit) {
+				return fmt.Errorf("digit %d was eliminated from R%dC%d and may not be refilled", move.Digit, target.Row+1, target.Col+1)
+			}
+		}
+		if move.CandidateDiff != nil {
+			for _, add := range move.CandidateDiff.Added {
+				idx := add.Row*constants.GridSize + add.Col
+				if b.Cells[idx] != 0 {
+					return fmt.Errorf("fill-candidate targets filled cell R%dC%d", add.Row+1, add.Col+1)
+				}
+				if b.Eliminated[idx].Has(add.Digit) {
+					return fmt.Errorf("digit %d was eliminated from R%dC%d and may not be refilled", add.Digit, add.Row+1, add.Col+1)
+				}
+			}
+		}
+	}
+	// "contradiction" and other non-mutating actions need no validation
+	return nil
+}
+
+// ApplyMoveUnchecked applies a move without validating it against the board
+// and returns the resulting diff.
+//
+// Deprecated: use ApplyMove, which rejects moves that don't fit the board
+// instead of silently corrupting its state. The solver's own loops still
+// apply their freshly detected moves through this path.
+func (s *Solver) ApplyMoveUnchecked(b *Board, move *core.Move) core.MoveDiff {
+	prevCells := b.Cells
+	prevCands := b.Candidates
 	switch move.Action {
 	case constants.ActionAssign:
 		for _, target := range move.Targets {
@@ -644,31 +931,220 @@ func (s *Solver) ApplyMove(b *Board, move *core.Move) {
 			idx := target.Row*constants.GridSize + target.Col
 			b.AddCandidate(idx, move.Digit)
 		}
+		if move.CandidateDiff != nil {
+			for _, add := range move.CandidateDiff.Added {
+				b.AddCandidate(add.Row*constants.GridSize+add.Col, add.Digit)
+			}
+		}
 	}
 	// "contradiction" action doesn't change the board
+	return diffSnapshots(prevCells, prevCands, b)
 }
 
 // ============================================================================
 // Solving
 // ============================================================================
 
+// Candidate emission modes for SolveOpts.CandidateMode. The solver fills
+// candidates the same way in every mode; only how that bookkeeping reaches
+// the returned move stream differs.
+const (
+	// CandidateModeImplicit emits one fill-candidate move per added mark
+	// (the historical behavior)
+	CandidateModeImplicit = "implicit"
+	// CandidateModeNone emits no fill-candidate moves at all, for callers
+	// that maintain their own marks or only care about solving techniques
+	CandidateModeNone = "none"
+	// CandidateModeBatched folds every added mark into a single synthetic
+	// fill-candidate move at the start of the stream, carrying the full list
+	// as one CandidateDiff
+	CandidateModeBatched = "batched"
+)
+
+// SolveOpts adjusts how SolveWithStepsOpts reports a solve
+type SolveOpts struct {
+	// CandidateMode is one of the CandidateMode constants; empty means
+	// CandidateModeImplicit
+	CandidateMode string
+}
+
+// ParseCandidateMode validates a candidate-mode name, mapping empty to the
+// implicit default
+func ParseCandidateMode(mode string) (string, bool) {
+	switch mode {
+	case "":
+		return CandidateModeImplicit, true
+	case CandidateModeImplicit, CandidateModeNone, CandidateModeBatched:
+		return mode, true
+	}
+	return CandidateModeImplicit, false
+}
+
+// SolveResult is a finished solve with enough metadata to act on a partial
+// one: when the step cap cuts a solve short the board may be nearly done,
+// and the caller can offer to continue instead of showing a bare status.
+type SolveResult struct {
+	Moves  []core.Move `json:"moves"`
+	Status string      `json:"status"`
+	// Remaining is how many cells are still empty when the solve stopped
+	Remaining int `json:"remaining"`
+	// CanContinue reports the solve hit the step cap while still making
+	// progress; re-running from the final board with a higher limit picks
+	// up where this one stopped
+	CanContinue bool `json:"can_continue"`
+	// SuggestedMaxSteps is the limit a continuation should use; zero when
+	// CanContinue is false
+	SuggestedMaxSteps int `json:"suggested_max_steps,omitempty"`
+	// CycleMove is the repeating move that aborted a cycle-detected solve
+	CycleMove *core.Move `json:"cycle_move,omitempty"`
+}
+
+// cycleWindow is how many recent solving moves the cycle detector compares
+// against. An identical (technique, digit, targets, eliminations) move
+// within the window cannot be progress — its effects were already applied —
+// so the solve is cycling.
+const cycleWindow = 12
+
+// maxStepsAlarms and cycleAlarms count solves cut off by the step cap or by
+// cycle detection. Both usually mean a detector is emitting no-op moves;
+// health reporting reads them through the accessors below.
+var (
+	maxStepsAlarms atomic.Int64
+	cycleAlarms    atomic.Int64
+)
+
+// MaxStepsAlarmCount returns how many solves hit the step cap since startup
+func MaxStepsAlarmCount() int64 { return maxStepsAlarms.Load() }
+
+// CycleAlarmCount returns how many solves were aborted by cycle detection
+// since startup
+func CycleAlarmCount() int64 { return cycleAlarms.Load() }
+
+// moveSignature keys a move for cycle detection. Eliminations are included:
+// a move may legitimately recur with the same targets as long as it removes
+// something new, but an identical elimination set cannot apply twice.
+func moveSignature(m *core.Move) string {
+	var sb strings.Builder
+	sb.WriteString(m.Technique)
+	sb.WriteByte('|')
+	sb.WriteString(strconv.Itoa(m.Digit))
+	for _, t := range m.Targets {
+		fmt.Fprintf(&sb, "|t%d,%d", t.Row, t.Col)
+	}
+	for _, e := range m.Eliminations {
+		fmt.Fprintf(&sb, "|e%d,%d,%d", e.Row, e.Col, e.Digit)
+	}
+	return sb.String()
+}
+
 // SolveWithSteps attempts to solve using human techniques, returning all moves
 func (s *Solver) SolveWithSteps(b *Board, maxSteps int) ([]core.Move, string) {
+	return s.SolveWithStepsOpts(b, maxSteps, SolveOpts{})
+}
+
+// SolveWithStepsResult is SolveWithSteps returning the full SolveResult, for
+// callers that surface partial solves instead of a bare status
+func (s *Solver) SolveWithStepsResult(b *Board, maxSteps int) SolveResult {
+	return s.solveWithSteps(b, maxSteps, SolveOpts{}, nil)
+}
+
+// SolveWithStepsResultOpts is SolveWithStepsOpts returning the full
+// SolveResult
+func (s *Solver) SolveWithStepsResultOpts(b *Board, maxSteps int, opts SolveOpts) SolveResult {
+	return s.solveWithSteps(b, maxSteps, opts, nil)
+}
+
+// SolveWithStepsFunc is SolveWithSteps with a per-move callback for callers
+// that want the move stream live rather than all at once (progress bars,
+// streaming endpoints). onMove runs after each emitted move has been applied,
+// with the move and a snapshot of the cells it produced; returning false
+// aborts the solve and the returned status is StatusAborted. The moves
+// delivered to the callback are exactly the moves returned.
+func (s *Solver) SolveWithStepsFunc(b *Board, maxSteps int, onMove func(core.Move, []int) bool) ([]core.Move, string) {
+	result := s.solveWithSteps(b, maxSteps, SolveOpts{}, onMove)
+	return result.Moves, result.Status
+}
+
+// SolveWithStepsOpts is SolveWithSteps with control over the emitted move
+// stream. The solving loop is identical in every candidate mode — candidates
+// are filled and techniques fire in the same order — so the non-bookkeeping
+// moves never depend on the mode chosen.
+func (s *Solver) SolveWithStepsOpts(b *Board, maxSteps int, opts SolveOpts) ([]core.Move, string) {
+	result := s.solveWithSteps(b, maxSteps, opts, nil)
+	return result.Moves, result.Status
+}
+
+func (s *Solver) solveWithSteps(b *Board, maxSteps int, opts SolveOpts, onMove func(core.Move, []int) bool) SolveResult {
+	mode := opts.CandidateMode
+	if mode == "" {
+		mode = CandidateModeImplicit
+	}
+
 	var moves []core.Move
+	var batched []core.Candidate
+	var cycleMove *core.Move
+	status := ""
 	step := 0
 
+	// Recent solving-move signatures for cycle detection, oldest first
+	var recent []string
+
+	// Only touch the clock when someone is listening
+	var solveStart time.Time
+	if s.logger != nil {
+		solveStart = time.Now()
+	}
+
 	for step < maxSteps && !b.IsSolved() {
 		move := s.FindNextMove(b)
 		if move == nil {
-			return moves, constants.StatusStalled
+			status = constants.StatusStalled
+			break
+		}
+
+		if move.Technique != "fill-candidate" {
+			sig := moveSignature(move)
+			cycling := false
+			for _, seen := range recent {
+				if seen == sig {
+					cycling = true
+					break
+				}
+			}
+			if cycling {
+				// Applying the move again would loop forever; stop before
+				// it goes back on the board and report the offender
+				status = constants.StatusCycleDetected
+				cycleMove = move
+				break
+			}
+			recent = append(recent, sig)
+			if len(recent) > cycleWindow {
+				recent = recent[1:]
+			}
 		}
 
 		move.StepIndex = step
-		s.ApplyMove(b, move)
-		moves = append(moves, *move)
+		assertMoveFresh(b, move)
+		s.ApplyMoveUnchecked(b, move)
+
+		if move.Technique == "fill-candidate" && mode != CandidateModeImplicit {
+			if mode == CandidateModeBatched {
+				for _, target := range move.Targets {
+					batched = append(batched, core.Candidate{Row: target.Row, Col: target.Col, Digit: move.Digit})
+				}
+			}
+		} else {
+			moves = append(moves, *move)
+			if onMove != nil && !onMove(*move, b.GetCells()) {
+				status = constants.StatusAborted
+				break
+			}
+		}
 
 		if move.Technique == "contradiction" {
-			return moves, constants.StatusStalled
+			status = constants.StatusStalled
+			break
 		}
 
 		// Only count actual solving moves as steps, not candidate-filling
@@ -677,10 +1153,121 @@ func (s *Solver) SolveWithSteps(b *Board, maxSteps int) ([]core.Move, string) {
 		}
 	}
 
-	if b.IsSolved() {
-		return moves, constants.StatusCompleted
+	if status == "" {
+		if b.IsSolved() {
+			status = constants.StatusCompleted
+		} else {
+			status = constants.StatusMaxStepsReached
+		}
+	}
+
+	if len(batched) > 0 {
+		moves = append([]core.Move{batchedCandidateMove(batched)}, moves...)
+	}
+
+	remaining := 0
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if b.GetCell(idx) == 0 {
+			remaining++
+		}
 	}
-	return moves, constants.StatusMaxStepsReached
+
+	result := SolveResult{
+		Moves:     moves,
+		Status:    status,
+		Remaining: remaining,
+		CycleMove: cycleMove,
+	}
+	if status == constants.StatusMaxStepsReached {
+		result.CanContinue = true
+		// A cell rarely needs more than a few moves once the solve has come
+		// this far; pad generously so one continuation usually finishes
+		result.SuggestedMaxSteps = maxSteps + 4*result.Remaining
+	}
+
+	switch status {
+	case constants.StatusMaxStepsReached:
+		maxStepsAlarms.Add(1)
+		if s.logger != nil {
+			s.logger.LogAttrs(context.Background(), slog.LevelWarn, "solver hit step cap",
+				slog.Int("max_steps", maxSteps),
+				slog.Int("remaining", result.Remaining),
+			)
+		}
+	case constants.StatusCycleDetected:
+		cycleAlarms.Add(1)
+		if s.logger != nil {
+			s.logger.LogAttrs(context.Background(), slog.LevelWarn, "solver cycle detected",
+				slog.String("technique", cycleMove.Technique),
+				slog.Int("digit", cycleMove.Digit),
+				slog.Int("step", step),
+			)
+		}
+	}
+
+	if s.logger != nil {
+		s.logger.LogAttrs(context.Background(), slog.LevelDebug, "solve finished",
+			slog.String("status", status),
+			slog.Int("steps", step),
+			slog.Duration("duration", time.Since(solveStart)),
+		)
+	}
+	return result
+}
+
+// batchedCandidateMove wraps every pencil mark a solve filled in into one
+// synthetic bookkeeping move. Prepending the batch is replay-safe: technique
+// detectors only fire once candidate generation is complete, so no recorded
+// move depends on a mark that is missing at its point in the stream.
+func batchedCandidateMove(added []core.Candidate) core.Move {
+	move := core.Move{
+		Technique:     "fill-candidate",
+		Action:        "candidate",
+		CandidateDiff: &core.CandidateDiff{Added: added},
+		Refs: core.TechniqueRef{
+			Title: "Fill Candidate",
+			Slug:  "fill-candidate",
+			URL:   "/technique/fill-candidate",
+		},
+	}
+	move.SetExplanation("solver.batch-candidates", map[string]interface{}{
+		"count": len(added),
+	})
+	return move
+}
+
+// SolveSummary aggregates the graded moves of a solve into a per-step
+// difficulty overview for clients.
+type SolveSummary struct {
+	// ToughestMove indexes the highest-scoring move in the move slice. Ties
+	// break toward the later move: a hard step deep into the solve feels
+	// harder than the same step early on. -1 when no move carries a grade.
+	ToughestMove int            `json:"toughest_move"`
+	TierCounts   map[string]int `json:"tier_counts"`
+	Score        float64        `json:"score"`
+}
+
+// Summarize builds a SolveSummary from a move list produced by
+// SolveWithSteps. Ungraded bookkeeping moves (fill-candidate,
+// contradiction) are skipped.
+func (s *Solver) Summarize(moves []core.Move) SolveSummary {
+	summary := SolveSummary{
+		ToughestMove: -1,
+		TierCounts:   make(map[string]int),
+	}
+	toughest := -1
+	for i, move := range moves {
+		if move.Tier == "" {
+			continue
+		}
+		summary.TierCounts[move.Tier]++
+		summary.Score += float64(move.Score)
+		if move.Score >= toughest {
+			toughest = move.Score
+			summary.ToughestMove = i
+		}
+	}
+	return summary
 }
 
 // ============================================================================
@@ -711,11 +1298,40 @@ func (s *Solver) SetTechniqueEnabled(slug string, enabled bool) bool {
 
 // AnalyzePuzzleDifficulty solves the puzzle and returns the required difficulty level
 func (s *Solver) AnalyzePuzzleDifficulty(givens []int) (core.Difficulty, map[string]int, string) {
+	difficulty, counts, _, status := s.AnalyzePuzzleDifficultyReport(givens)
+	return difficulty, counts, status
+}
+
+// AnalyzePuzzleDifficultyReport is AnalyzePuzzleDifficulty with an extra
+// flag reporting whether the rating leaned on uniqueness-based techniques.
+// Such a rating is only trustworthy for puzzles with a unique solution.
+func (s *Solver) AnalyzePuzzleDifficultyReport(givens []int) (core.Difficulty, map[string]int, bool, string) {
+	_, difficulty, counts, usedUniqueness, status := s.AnalyzePuzzleSolve(givens)
+	return difficulty, counts, usedUniqueness, status
+}
+
+// AnalyzeBoardDifficulty is AnalyzePuzzleDifficulty for a mid-game board.
+// Boards whose candidates were supplied externally are refused with
+// StatusUnratable: sets tighter than the enabled techniques could derive
+// would launder the harder techniques' eliminations into an easier rating.
+// Hinting against such boards stays fine — a hint only has to be sound for
+// this position, not representative of the whole puzzle.
+func (s *Solver) AnalyzeBoardDifficulty(b *Board) (core.Difficulty, map[string]int, string) {
+	if b.Source == CandidatesExternal {
+		return "", nil, constants.StatusUnratable
+	}
+	return s.AnalyzePuzzleDifficulty(b.GetCells())
+}
+
+// AnalyzePuzzleSolve is AnalyzePuzzleDifficultyReport returning the full
+// move list as well, for callers that also want to summarize the solve
+// without running it a second time.
+func (s *Solver) AnalyzePuzzleSolve(givens []int) ([]core.Move, core.Difficulty, map[string]int, bool, string) {
 	b := NewBoard(givens)
 	moves, status := s.SolveWithSteps(b, constants.MaxSolverSteps)
 
 	if status != constants.StatusCompleted {
-		return "", nil, status
+		return moves, "", nil, false, status
 	}
 
 	techniqueCounts := make(map[string]int)
@@ -728,25 +1344,28 @@ func (s *Solver) AnalyzePuzzleDifficulty(givens []int) (core.Difficulty, map[str
 		constants.TierExtreme: 3,
 	}
 
+	usedUniqueness := false
 	for _, move := range moves {
 		techniqueCounts[move.Technique]++
 		tier := s.GetTechniqueTier(move.Technique)
 		if tierOrder[tier] > tierOrder[highestTier] {
 			highestTier = tier
 		}
+		if tech := s.registry.GetBySlug(move.Technique); tech != nil && tech.RequiresUniqueness {
+			usedUniqueness = true
+		}
 	}
 
+	// The easiest difficulty whose technique budget covers the highest tier
+	// used. With the default specs this maps simple/medium/hard/extreme to
+	// easy/medium/extreme/impossible.
 	var requiredDifficulty core.Difficulty
-	switch highestTier {
-	case constants.TierSimple:
-		requiredDifficulty = core.DifficultyEasy
-	case constants.TierMedium:
-		requiredDifficulty = core.DifficultyMedium
-	case constants.TierHard:
-		requiredDifficulty = core.DifficultyExtreme
-	case constants.TierExtreme:
-		requiredDifficulty = core.DifficultyImpossible
-	}
-
-	return requiredDifficulty, techniqueCounts, constants.StatusCompleted
+	for _, spec := range constants.DifficultySpecs() {
+		if tierOrder[spec.MaxTier] >= tierOrder[highestTier] {
+			requiredDifficulty = core.Difficulty(spec.Name)
+			break
+		}
+	}
+
+	return moves, requiredDifficulty, techniqueCounts, usedUniqueness, constants.StatusCompleted
 }
@@ -0,0 +1,119 @@
+package human
+
+import (
+	"fmt"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Construction-time solver configuration: tier caps for difficulty-matched
+// hints and execution-order overrides for teaching curricula that introduce
+// techniques in a different sequence than the default pedagogical order.
+
+// tierRank orders the tiers for capping; techniques whose tier ranks above
+// the cap are disabled
+var tierRank = map[string]int{
+	constants.TierSimple:  0,
+	constants.TierMedium:  1,
+	constants.TierHard:    2,
+	constants.TierExtreme: 3,
+}
+
+// SolverConfig bundles the construction-time options for NewSolverWithConfig.
+// MaxTier caps the registry at a tier ("" = no cap), Disabled turns off
+// individual techniques, and Order overrides execution order. They apply in
+// that sequence, so Order only has to cover what is still enabled.
+type SolverConfig struct {
+	MaxTier  string
+	Order    []string
+	Disabled []string
+}
+
+// NewSolverWithConfig builds a solver from a configuration, rejecting
+// unknown tiers, slugs, or an order that doesn't cover the enabled set
+func NewSolverWithConfig(config SolverConfig) (*Solver, error) {
+	registry := NewTechniqueRegistry()
+	if config.MaxTier != "" && !registry.CapTier(config.MaxTier) {
+		return nil, fmt.Errorf("unknown tier %q", config.MaxTier)
+	}
+	for _, slug := range config.Disabled {
+		if !registry.SetEnabled(slug, false) {
+			return nil, fmt.Errorf("unknown technique %q", slug)
+		}
+	}
+	if len(config.Order) > 0 {
+		if err := registry.SetOrder(config.Order); err != nil {
+			return nil, err
+		}
+	}
+	return NewSolverWithRegistry(registry), nil
+}
+
+// NewSolverUpToTier creates a solver that uses techniques up to and
+// including maxTier; higher tiers are disabled. Falls back to the full
+// solver for an unknown tier.
+func NewSolverUpToTier(maxTier string) *Solver {
+	registry := NewTechniqueRegistry()
+	if !registry.CapTier(maxTier) {
+		return NewSolver()
+	}
+	return NewSolverWithRegistry(registry)
+}
+
+// CapTier disables every technique whose tier ranks above maxTier. Returns
+// false, changing nothing, for an unknown tier.
+func (r *TechniqueRegistry) CapTier(maxTier string) bool {
+	maxRank, ok := tierRank[maxTier]
+	if !ok {
+		return false
+	}
+	for _, tech := range r.techniques {
+		if tierRank[tech.Tier] > maxRank {
+			tech.Enabled = false
+		}
+	}
+	return true
+}
+
+// SetOrder overrides technique execution order with the given slug
+// sequence. The sequence must cover every enabled technique exactly once -
+// a partial order would silently drop techniques from the scan - and
+// unknown or duplicate slugs are rejected. Disabled techniques keep their
+// relative registration order after the listed ones, so re-enabling one
+// later still slots it somewhere deterministic.
+func (r *TechniqueRegistry) SetOrder(slugs []string) error {
+	listed := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		if r.techniques[slug] == nil {
+			return fmt.Errorf("unknown technique %q", slug)
+		}
+		if listed[slug] {
+			return fmt.Errorf("technique %q listed twice", slug)
+		}
+		listed[slug] = true
+	}
+	for slug, tech := range r.techniques {
+		if tech.Enabled && !listed[slug] {
+			return fmt.Errorf("enabled technique %q missing from order", slug)
+		}
+	}
+
+	newTierOrder := make(map[string][]string, len(r.tierOrder))
+	for i, slug := range slugs {
+		tech := r.techniques[slug]
+		tech.Order = i + 1
+		newTierOrder[tech.Tier] = append(newTierOrder[tech.Tier], slug)
+	}
+	next := len(slugs) + 1
+	for _, tier := range tierScanOrder {
+		for _, slug := range r.tierOrder[tier] {
+			if !listed[slug] {
+				r.techniques[slug].Order = next
+				next++
+				newTierOrder[tier] = append(newTierOrder[tier], slug)
+			}
+		}
+	}
+	r.tierOrder = newTierOrder
+	return nil
+}
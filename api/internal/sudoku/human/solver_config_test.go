@@ -0,0 +1,228 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// defaultTechniqueOrder freezes the registry's pedagogical scan order.
+// Reordering techniques changes which move the solver returns on boards
+// where several fire at once, so any deliberate change must update this
+// list (and be meant).
+var defaultTechniqueOrder = []string{
+	"naked-single",
+	"hidden-single",
+	"locked-pair",
+	"naked-pair",
+	"hidden-pair",
+	"pointing-pair",
+	"pointing-triple",
+	"box-line-reduction",
+	"locked-triple",
+	"naked-triple",
+	"hidden-triple",
+	"naked-quad",
+	"hidden-quad",
+	"x-wing",
+	"swordfish",
+	"xy-wing",
+	"xyz-wing",
+	"simple-coloring",
+	"bug",
+	"unique-rectangle",
+	"jellyfish",
+	"skyscraper",
+	"2-string-kite",
+	"turbot-fish",
+	"x-chain",
+	"xy-chain",
+	"nice-loop",
+	"w-wing",
+	"wxyz-wing",
+	"empty-rectangle",
+	"medusa-3d",
+	"unique-rectangle-type-2",
+	"unique-rectangle-type-3",
+	"unique-rectangle-type-4",
+	"unique-rectangle-type-5",
+	"unique-rectangle-type-6",
+	"hidden-rectangle",
+	"finned-x-wing",
+	"finned-swordfish",
+	"grouped-x-cycles",
+	"aic",
+	"als-xz",
+	"als-xy-wing",
+	"als-xy-chain",
+	"sue-de-coq",
+	"death-blossom",
+	"digit-forcing-chain",
+	"forcing-chain",
+}
+
+// scanOrder flattens a registry's enabled techniques in tier scan order
+func scanOrder(r *TechniqueRegistry) []string {
+	var slugs []string
+	for _, tier := range tierScanOrder {
+		for _, tech := range r.GetByTier(tier) {
+			slugs = append(slugs, tech.Slug)
+		}
+	}
+	return slugs
+}
+
+// TestDefaultTechniqueOrderFrozen catches accidental reordering of the
+// default registry
+func TestDefaultTechniqueOrderFrozen(t *testing.T) {
+	got := scanOrder(NewTechniqueRegistry())
+	if len(got) != len(defaultTechniqueOrder) {
+		t.Fatalf("registry scans %d techniques, frozen order has %d", len(got), len(defaultTechniqueOrder))
+	}
+	for i, slug := range defaultTechniqueOrder {
+		if got[i] != slug {
+			t.Fatalf("scan position %d: got %q, want %q", i, got[i], slug)
+		}
+	}
+}
+
+// TestSetOrderChangesMoveSelection drives a simple-capped solver to a
+// stall, where two same-tier techniques fire simultaneously, then checks
+// that promoting the later one changes which move FindNextMove returns.
+// Stall states are used because the solver's candidate-sweep phase returns
+// singles before the registry scan, so singles can't be reordered.
+func TestSetOrderChangesMoveSelection(t *testing.T) {
+	registry := NewTechniqueRegistry()
+	probe := NewSolver()
+
+	// firingInTier lists the enabled techniques of one tier that currently
+	// produce a move
+	firingInTier := func(b *Board, tier string) []string {
+		var slugs []string
+		for _, tech := range registry.GetByTier(tier) {
+			if probe.runDetector(tech, b) != nil {
+				slugs = append(slugs, tech.Slug)
+			}
+		}
+		return slugs
+	}
+
+	var state *Board
+	var firing []string
+	for seed := int64(1); seed <= 32 && state == nil; seed++ {
+		fullGrid := dp.GenerateFullGrid(seed)
+		puzzles, _ := dp.CarveGivensWithSubset(fullGrid, seed)
+		for _, difficulty := range []string{constants.DifficultyMedium, constants.DifficultyHard, constants.DifficultyExtreme} {
+			board := NewBoard(puzzles[difficulty])
+			capped := NewSolverUpToTier(constants.TierSimple)
+			for step := 0; step < constants.MaxSolverSteps && !board.IsSolved(); step++ {
+				move := capped.FindNextMove(board)
+				if move == nil {
+					break
+				}
+				if move.Action == constants.ActionContradiction {
+					board = nil
+					break
+				}
+				capped.ApplyMove(board, move)
+			}
+			if board == nil || board.IsSolved() {
+				continue
+			}
+			// Only the first tier with any hit is usable: an earlier
+			// tier's lone technique would preempt anything promoted below
+			for _, tier := range tierScanOrder[1:] {
+				slugs := firingInTier(board, tier)
+				if len(slugs) == 0 {
+					continue
+				}
+				if len(slugs) >= 2 {
+					state = board
+					firing = slugs
+				}
+				break
+			}
+			if state != nil {
+				break
+			}
+		}
+	}
+	if state == nil {
+		t.Fatal("no stall state with two same-tier techniques available")
+	}
+
+	// Compare registry slugs via Refs: variant detectors (e.g. sashimi
+	// fish) pre-set a variant Technique name
+	first, promoted := firing[0], firing[1]
+	if move := NewSolver().FindNextMove(state.Clone()); move.Refs.Slug != first {
+		t.Fatalf("default order returned %q, want %q", move.Refs.Slug, first)
+	}
+
+	order := []string{promoted}
+	for _, slug := range defaultTechniqueOrder {
+		if slug != promoted {
+			order = append(order, slug)
+		}
+	}
+	solver, err := NewSolverWithConfig(SolverConfig{Order: order})
+	if err != nil {
+		t.Fatalf("NewSolverWithConfig: %v", err)
+	}
+	if move := solver.FindNextMove(state.Clone()); move.Refs.Slug != promoted {
+		t.Fatalf("promoted order returned %q, want %q", move.Refs.Slug, promoted)
+	}
+}
+
+// TestSetOrderValidation covers the rejection paths
+func TestSetOrderValidation(t *testing.T) {
+	if err := NewTechniqueRegistry().SetOrder(defaultTechniqueOrder[1:]); err == nil {
+		t.Error("expected error for order missing an enabled technique")
+	}
+	if err := NewTechniqueRegistry().SetOrder(append([]string{"no-such-technique"}, defaultTechniqueOrder...)); err == nil {
+		t.Error("expected error for unknown slug")
+	}
+	if err := NewTechniqueRegistry().SetOrder(append([]string{"naked-single"}, defaultTechniqueOrder...)); err == nil {
+		t.Error("expected error for duplicate slug")
+	}
+
+	// A disabled technique doesn't have to be listed
+	registry := NewTechniqueRegistry()
+	registry.SetEnabled("forcing-chain", false)
+	if err := registry.SetOrder(defaultTechniqueOrder[:len(defaultTechniqueOrder)-1]); err != nil {
+		t.Errorf("SetOrder without the disabled slug: %v", err)
+	}
+}
+
+// TestNewSolverWithConfigRejectsUnknowns covers config validation
+func TestNewSolverWithConfigRejectsUnknowns(t *testing.T) {
+	if _, err := NewSolverWithConfig(SolverConfig{MaxTier: "legendary"}); err == nil {
+		t.Error("expected error for unknown tier")
+	}
+	if _, err := NewSolverWithConfig(SolverConfig{Disabled: []string{"no-such-technique"}}); err == nil {
+		t.Error("expected error for unknown disabled slug")
+	}
+}
+
+// TestMaxTierSimpleNeverExceedsTier sweeps the generated board states and
+// asserts a simple-capped solver never returns a move from a higher tier
+func TestMaxTierSimpleNeverExceedsTier(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping board sweep in short mode")
+	}
+
+	registry := NewTechniqueRegistry()
+	for _, board := range purityTestBoards(t) {
+		move := NewSolverUpToTier(constants.TierSimple).FindNextMove(board.Clone())
+		if move == nil {
+			continue
+		}
+		tech := registry.GetBySlug(move.Technique)
+		if tech == nil {
+			continue // bookkeeping or violation moves carry no registered slug
+		}
+		if tech.Tier != constants.TierSimple {
+			t.Fatalf("simple-capped solver returned %s-tier move %q", tech.Tier, move.Technique)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+//go:build sudokudebug
+
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// TestAssertMoveFreshPanicsOnStaleMove injects an assign move whose digit is
+// no longer a candidate of its target and checks that the sudokudebug
+// assertion catches it. Run with: go test -tags sudokudebug
+func TestAssertMoveFreshPanicsOnStaleMove(t *testing.T) {
+	givens := dp.CarveGivens(dp.GenerateFullGrid(1), 40, 1)
+	board := NewBoard(givens)
+
+	empty, staleDigit := -1, 0
+	for i := range board.Cells {
+		if board.Cells[i] != 0 {
+			continue
+		}
+		for d := 1; d <= constants.GridSize; d++ {
+			if !board.Candidates[i].Has(d) {
+				empty, staleDigit = i, d
+				break
+			}
+		}
+		if empty >= 0 {
+			break
+		}
+	}
+	if empty < 0 {
+		t.Fatal("no empty cell with a missing candidate")
+	}
+
+	stale := &core.Move{
+		Technique: "hidden-single",
+		Action:    constants.ActionAssign,
+		Digit:     staleDigit,
+		Targets:   []core.CellRef{{Row: empty / constants.GridSize, Col: empty % constants.GridSize}},
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("assertMoveFresh did not panic on a stale assign move")
+		}
+	}()
+	assertMoveFresh(board, stale)
+}
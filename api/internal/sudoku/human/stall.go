@@ -0,0 +1,121 @@
+package human
+
+import (
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// Stall diagnostics. When SolveWithSteps returns stalled, the status string
+// alone says nothing about why; DiagnoseStall captures enough board and
+// technique state to triage a stalled seed without re-running the solver by
+// hand.
+
+// NearMiss records a technique whose preconditions were almost met on the
+// stalled board, with a human-readable reason from its Diagnose hook.
+type NearMiss struct {
+	Slug   string `json:"slug"`
+	Reason string `json:"reason"`
+}
+
+// StallReport summarizes a board the solver could make no progress on.
+type StallReport struct {
+	EmptyCells int `json:"empty_cells"`
+	// CandidateHistogram maps candidates-per-cell to the number of empty
+	// cells with that count
+	CandidateHistogram map[int]int `json:"candidate_histogram"`
+	BivalueCells       int         `json:"bivalue_cells"`
+	// NearMisses lists enabled techniques that came close to firing, for
+	// detectors that provide a Diagnose hook
+	NearMisses []NearMiss `json:"near_misses"`
+	// ProbePlacements is the number of dp-solver-verified placements needed
+	// before any technique fires again, or -1 if the board has no solution
+	ProbePlacements int `json:"probe_placements"`
+}
+
+// DiagnoseStall inspects a stalled board and reports why no enabled technique
+// fired. The board is not modified; probing runs on clones.
+func (s *Solver) DiagnoseStall(b *Board) *StallReport {
+	report := &StallReport{CandidateHistogram: make(map[int]int)}
+
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if b.GetCell(idx) != 0 {
+			continue
+		}
+		report.EmptyCells++
+		n := b.GetCandidatesAt(idx).Count()
+		report.CandidateHistogram[n]++
+		if n == 2 {
+			report.BivalueCells++
+		}
+	}
+
+	for _, t := range s.registry.GetAll() {
+		if !t.Enabled || t.Diagnose == nil {
+			continue
+		}
+		if reason := t.Diagnose(b); reason != "" {
+			report.NearMisses = append(report.NearMisses, NearMiss{Slug: t.Slug, Reason: reason})
+		}
+	}
+
+	report.ProbePlacements = s.probePlacements(b)
+	return report
+}
+
+// probePlacements finds how many solution digits must be placed before any
+// enabled technique fires. Each level tries every single verified placement;
+// when none unlocks a move, one placement is committed and the search deepens.
+func (s *Solver) probePlacements(b *Board) int {
+	solution := dp.Solve(b.GetCells())
+	if solution == nil {
+		return -1
+	}
+
+	work := b.Clone()
+	for placements := 1; placements <= constants.TotalCells; placements++ {
+		committed := -1
+		for idx := 0; idx < constants.TotalCells; idx++ {
+			if work.GetCell(idx) != 0 {
+				continue
+			}
+			trial := work.Clone()
+			trial.SetCell(idx, solution[idx])
+			if s.techniqueFires(trial) {
+				return placements
+			}
+			if committed == -1 {
+				committed = idx
+			}
+		}
+		if committed == -1 {
+			break // board is full
+		}
+		work.SetCell(committed, solution[committed])
+	}
+	return -1
+}
+
+// techniqueFires reports whether a fresh solver with this solver's technique
+// enablement finds any non-candidate move on the board.
+func (s *Solver) techniqueFires(b *Board) bool {
+	probe := NewSolver()
+	for _, t := range s.registry.GetAll() {
+		probe.SetTechniqueEnabled(t.Slug, t.Enabled)
+	}
+
+	// Bounded by the number of pencil marks a fill phase can add
+	for step := 0; step < constants.TotalCells*constants.GridSize; step++ {
+		move := probe.FindNextMove(b)
+		if move == nil {
+			return false
+		}
+		if move.Technique == "contradiction" {
+			return false
+		}
+		if move.Technique != "fill-candidate" {
+			return true
+		}
+		probe.ApplyMoveUnchecked(b, move)
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// stalledBoardForTest deterministically produces a stalled board: an
+// impossible-tier puzzle solved with only singles, subsets, and X-Wing
+// enabled stalls well before completion.
+func stalledBoardForTest(t *testing.T) (*Solver, *Board) {
+	t.Helper()
+
+	keep := map[string]bool{
+		"naked-single": true, "hidden-single": true,
+		"naked-pair": true, "hidden-pair": true,
+		"naked-triple": true, "hidden-triple": true,
+		"naked-quad": true, "hidden-quad": true,
+		"x-wing": true,
+	}
+
+	full := dp.GenerateFullGrid(1)
+	impossible, _ := constants.DifficultyByName(constants.DifficultyImpossible)
+	givens := dp.CarveGivens(full, impossible.TargetGivens, 1)
+
+	solver := NewSolver()
+	for _, desc := range solver.GetRegistry().GetAll() {
+		if !keep[desc.Slug] {
+			solver.SetTechniqueEnabled(desc.Slug, false)
+		}
+	}
+
+	board := NewBoard(givens)
+	_, status := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	if status != constants.StatusStalled {
+		t.Fatalf("expected stalled status, got %s", status)
+	}
+	return solver, board
+}
+
+func TestDiagnoseStall(t *testing.T) {
+	solver, board := stalledBoardForTest(t)
+	report := solver.DiagnoseStall(board)
+
+	emptyCells := 0
+	for i := 0; i < constants.TotalCells; i++ {
+		if board.GetCell(i) == 0 {
+			emptyCells++
+		}
+	}
+	if report.EmptyCells != emptyCells {
+		t.Errorf("EmptyCells = %d, want %d", report.EmptyCells, emptyCells)
+	}
+
+	histTotal := 0
+	for _, count := range report.CandidateHistogram {
+		histTotal += count
+	}
+	if histTotal != emptyCells {
+		t.Errorf("candidate histogram covers %d cells, want %d", histTotal, emptyCells)
+	}
+	if report.BivalueCells != report.CandidateHistogram[2] {
+		t.Errorf("BivalueCells = %d, want histogram[2] = %d", report.BivalueCells, report.CandidateHistogram[2])
+	}
+
+	if len(report.NearMisses) == 0 {
+		t.Error("expected a non-empty near-miss list on a stalled board")
+	}
+	for _, nm := range report.NearMisses {
+		if nm.Slug == "" || nm.Reason == "" {
+			t.Errorf("near miss has empty slug or reason: %+v", nm)
+		}
+	}
+
+	if report.ProbePlacements < 1 {
+		t.Errorf("ProbePlacements = %d, want >= 1", report.ProbePlacements)
+	}
+}
+
+func TestDiagnoseStallLeavesBoardUntouched(t *testing.T) {
+	solver, board := stalledBoardForTest(t)
+	before := *board
+	solver.DiagnoseStall(board)
+	if err := diffBoardSnapshot(&before, board); err != nil {
+		t.Errorf("DiagnoseStall mutated the board: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// TestReplayEncodedContradiction covers the bug-report flow the replay_state
+// tool relies on: a contradictory board round-trips through the state codec
+// and the solver reports the contradiction on the decoded state.
+func TestReplayEncodedContradiction(t *testing.T) {
+	cells := make([]int, constants.TotalCells)
+	cells[0] = 5
+	cells[1] = 5 // duplicate in row 1
+
+	encoded, err := core.EncodeState(cells, nil)
+	if err != nil {
+		t.Fatalf("EncodeState: %v", err)
+	}
+	decoded, candidates, err := core.DecodeState(encoded)
+	if err != nil {
+		t.Fatalf("DecodeState: %v", err)
+	}
+	if candidates != nil {
+		t.Fatal("expected nil candidates for a cells-only state")
+	}
+
+	move := NewSolver().FindNextMove(NewBoard(decoded))
+	if move == nil {
+		t.Fatal("expected a move on the decoded board")
+	}
+	if move.Action != constants.ActionContradiction {
+		t.Fatalf("expected a contradiction move, got %q (%s)", move.Technique, move.Action)
+	}
+}
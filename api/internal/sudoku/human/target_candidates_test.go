@@ -0,0 +1,55 @@
+package human
+
+import (
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// TestAssignMovesCarryTargetCandidates runs scripted solves and checks that
+// every assign move is annotated with the candidate set of its target cells
+// exactly as the board held them at detection time. Clients rely on this to
+// render what the solver saw rather than their own (possibly stale) pencil
+// marks.
+func TestAssignMovesCarryTargetCandidates(t *testing.T) {
+	solver := NewSolver()
+	assigns := 0
+
+	for seed := int64(1); seed <= 5; seed++ {
+		givens := dp.CarveGivens(dp.GenerateFullGrid(seed), 28, seed)
+		board := NewBoard(givens)
+		solver.Reset()
+
+		for step := 0; step < constants.MaxSolverSteps && !board.IsSolved(); step++ {
+			move := solver.FindNextMove(board)
+			if move == nil || move.Action == constants.ActionContradiction {
+				break
+			}
+			// Detectors are pure, so the board still shows detection-time state
+			if move.Action == constants.ActionAssign {
+				assigns++
+				if len(move.TargetCandidates) != len(move.Targets) {
+					t.Fatalf("seed %d: %s move has %d candidate sets for %d targets",
+						seed, move.Technique, len(move.TargetCandidates), len(move.Targets))
+				}
+				for i, target := range move.Targets {
+					idx := IndexOf(target.Row, target.Col)
+					want := board.Candidates[idx].ToSlice()
+					if !reflect.DeepEqual(move.TargetCandidates[i], want) {
+						t.Fatalf("seed %d: %s move reports candidates %v for R%dC%d, board has %v",
+							seed, move.Technique, move.TargetCandidates[i], target.Row+1, target.Col+1, want)
+					}
+				}
+			}
+			if _, err := solver.ApplyMove(board, move); err != nil {
+				t.Fatalf("seed %d: ApplyMove rejected a solver-found move: %v", seed, err)
+			}
+		}
+	}
+
+	if assigns == 0 {
+		t.Fatal("scripted solves produced no assign moves")
+	}
+}
@@ -0,0 +1,155 @@
+package human
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// registryGoldenOrder freezes the registry's pedagogical order. The solver's
+// move streams depend on which technique claims a pattern first, so reordering
+// the manifest silently changes every generated puzzle's solve path. Adding a
+// technique means appending (or deliberately splicing) its slug here in the
+// same change that adds its manifest entry.
+var registryGoldenOrder = []string{
+	// Simple tier
+	"naked-single",
+	"hidden-single",
+	"naked-pair",
+	"locked-pair",
+	"hidden-pair",
+	"pointing-pair",
+	"pointing-triple",
+	"box-line-reduction",
+	"naked-triple",
+	"locked-triple",
+	"hidden-triple",
+	// Medium tier
+	"naked-quad",
+	"hidden-quad",
+	"x-wing",
+	"swordfish",
+	"xy-wing",
+	"xyz-wing",
+	"simple-coloring",
+	"bug",
+	"unique-rectangle",
+	// Hard tier
+	"jellyfish",
+	"skyscraper",
+	"2-string-kite",
+	"turbot-fish",
+	"x-chain",
+	"xy-chain",
+	"nice-loop",
+	"w-wing",
+	"wxyz-wing",
+	"empty-rectangle",
+	"medusa-3d",
+	"unique-rectangle-type-2",
+	"unique-rectangle-type-3",
+	"unique-rectangle-type-4",
+	"unique-rectangle-type-5",
+	"unique-rectangle-type-6",
+	"hidden-rectangle",
+	// Extreme tier
+	"finned-x-wing",
+	"finned-swordfish",
+	"grouped-x-cycles",
+	"aic",
+	"als-xz",
+	"als-xy-wing",
+	"als-xy-chain",
+	"sue-de-coq",
+	"death-blossom",
+	"digit-forcing-chain",
+	"forcing-chain",
+}
+
+// TestRegistryOrderFrozen diffs the registry's pedagogical order against the
+// golden list so reorderings are a deliberate, reviewed change.
+func TestRegistryOrderFrozen(t *testing.T) {
+	registry := NewTechniqueRegistry()
+	var slugs []string
+	for _, info := range registry.GetTechniqueInfos() {
+		slugs = append(slugs, info.Slug)
+	}
+	if !reflect.DeepEqual(slugs, registryGoldenOrder) {
+		t.Errorf("registry order diverged from the golden list:\n got  %v\n want %v", slugs, registryGoldenOrder)
+	}
+}
+
+// TestStressTechniqueListsPartitionRegistry checks the lists cmd/test_techniques
+// derives from the registry: every slug resolves, nothing appears twice, and
+// the simple baseline plus the advanced set cover the registry exactly — so
+// every registered technique gets a stress run.
+func TestStressTechniqueListsPartitionRegistry(t *testing.T) {
+	registry := NewTechniqueRegistry()
+	simple := registry.SimpleTechniqueSlugs()
+	advanced := registry.AdvancedTechniqueSlugs()
+
+	seen := make(map[string]bool)
+	for _, slug := range append(append([]string{}, simple...), advanced...) {
+		if registry.GetBySlug(slug) == nil {
+			t.Errorf("list slug %q is not in the registry", slug)
+		}
+		if seen[slug] {
+			t.Errorf("slug %q appears in both lists", slug)
+		}
+		seen[slug] = true
+	}
+
+	for _, tech := range registry.GetAll() {
+		if !seen[tech.Slug] {
+			t.Errorf("registered technique %q is in neither stress list", tech.Slug)
+		}
+	}
+}
+
+// TestEveryTechniqueHasDetectorAndCoverage checks two conventions for each
+// manifest entry: the detector resolves to a named function in the techniques
+// package (not nil, not an ad-hoc closure), and the slug appears quoted in at
+// least one test file in this package — the convention by which isolated and
+// full-solve tests bind their cases to a technique.
+func TestEveryTechniqueHasDetectorAndCoverage(t *testing.T) {
+	const conformanceFile = "technique_conformance_test.go"
+
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatalf("read package dir: %v", err)
+	}
+	var testSources strings.Builder
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, "_test.go") || name == conformanceFile {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Clean(name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		testSources.Write(data)
+	}
+	sources := testSources.String()
+
+	for _, desc := range techniqueManifest() {
+		if desc.Detector == nil {
+			t.Errorf("%s: manifest entry has no detector", desc.Slug)
+			continue
+		}
+		fn := runtime.FuncForPC(reflect.ValueOf(desc.Detector).Pointer())
+		if fn == nil || !strings.Contains(fn.Name(), "/techniques.Detect") {
+			name := "<unknown>"
+			if fn != nil {
+				name = fn.Name()
+			}
+			t.Errorf("%s: detector %s is not a named Detect function in the techniques package", desc.Slug, name)
+		}
+		if !strings.Contains(sources, "\""+desc.Slug+"\"") {
+			t.Errorf("%s: no test file in this package references the slug; add an isolated or full-solve case", desc.Slug)
+		}
+	}
+}
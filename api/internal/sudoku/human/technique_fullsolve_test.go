@@ -6,6 +6,7 @@ import (
 	"sudoku-api/internal/core"
 	"sudoku-api/internal/puzzles"
 	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human/techniques"
 	"sudoku-api/pkg/constants"
 )
 
@@ -395,6 +396,69 @@ func BenchmarkFullSolve_Impossible(b *testing.B) {
 	}
 }
 
+// BenchmarkSolveImpossible benchmarks full solves of generated impossible
+// puzzles; self-contained via the DP generator so it runs without puzzles.json
+func BenchmarkSolveImpossible(b *testing.B) {
+	fullGrid := dp.GenerateFullGrid(1)
+	givens := dp.CarveGivens(fullGrid, 20, 1)
+	solver := NewSolver()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board := NewBoard(givens)
+		solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	}
+}
+
+// BenchmarkFindNextMove benchmarks a single detection pass over a board with
+// candidates already filled, mirroring the per-request solver endpoint cost
+func BenchmarkFindNextMove(b *testing.B) {
+	fullGrid := dp.GenerateFullGrid(1)
+	givens := dp.CarveGivens(fullGrid, 20, 1)
+	board := NewBoard(givens)
+	board.InitCandidates()
+	solver := NewSolver()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		solver.Reset()
+		solver.FindNextMove(board)
+	}
+}
+
+// BenchmarkDetectForcingChain measures the forcing-chain detector alone on an
+// impossible board, where every empty cell is a potential branch point. The
+// detector runs last in the progression, so its worst case bounds
+// FindNextMove's worst case; it should stay well under 50ms.
+func BenchmarkDetectForcingChain(b *testing.B) {
+	fullGrid := dp.GenerateFullGrid(1)
+	givens := dp.CarveGivens(fullGrid, 20, 1)
+	board := NewBoard(givens)
+	board.InitCandidates()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		techniques.DetectForcingChain(board)
+	}
+}
+
+// BenchmarkDetectDigitForcingChain is the digit-scoped counterpart
+func BenchmarkDetectDigitForcingChain(b *testing.B) {
+	fullGrid := dp.GenerateFullGrid(1)
+	givens := dp.CarveGivens(fullGrid, 20, 1)
+	board := NewBoard(givens)
+	board.InitCandidates()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		techniques.DetectDigitForcingChain(board)
+	}
+}
+
 // =============================================================================
 // HELPERS
 // =============================================================================
@@ -173,6 +173,9 @@ func TestPointingPairHighlights(t *testing.T) {
 			candidateMap[idx] = []int{1, 3, 4, 5, 6, 7, 8, 9}
 		}
 	}
+	// Drop 2 from the third row-0 cell too, so exactly two box cells carry
+	// it (three would be a pointing triple)
+	candidateMap[2] = []int{1, 3, 4, 5, 6, 7, 8, 9}
 
 	board := makeTestBoard(cells, candidateMap)
 	move := techniques.DetectPointingPair(board)
@@ -740,17 +743,18 @@ func TestJellyfishHighlights(t *testing.T) {
 }
 
 func TestSkyscraperHighlights(t *testing.T) {
+	// Conjugate pairs on 1 in rows 2 and 5 sharing column 1 as the base,
+	// with cells seeing both roofs still holding the 1
 	cells := [81]int{}
-	cm := map[int][]int{}
-	for i := 0; i < 81; i++ {
-		cm[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	cm := map[int][]int{
+		9:  {1, 2},
+		14: {1, 3},
+		36: {1, 5},
+		39: {1, 6},
+		3:  {1, 4},
+		21: {1, 9},
 	}
 
-	cm[0] = []int{1}
-	cm[4] = []int{1}
-	cm[27] = []int{1}
-	cm[31] = []int{1}
-
 	board := makeTestBoard(cells, cm)
 	move := techniques.DetectSkyscraper(board)
 
@@ -849,8 +853,11 @@ func TestWXYZWingHighlights(t *testing.T) {
 	if move == nil {
 		t.Skip("WXYZ-Wing not detected")
 	}
-	if move != nil && len(move.Highlights.Primary) < 4 {
-		t.Errorf("Expected at least 4 primary, got %d", len(move.Highlights.Primary))
+	if move != nil && len(move.Highlights.Primary) != 1 {
+		t.Errorf("Expected 1 primary highlight for the pivot, got %d", len(move.Highlights.Primary))
+	}
+	if move != nil && len(move.Highlights.Secondary) != 3 {
+		t.Errorf("Expected 3 secondary highlights for the wings, got %d", len(move.Highlights.Secondary))
 	}
 }
 
@@ -1236,14 +1243,187 @@ func TestTechniqueCount(t *testing.T) {
 		"jellyfish", "skyscraper", "x-chain", "xy-chain", "w-wing", "wxyz-wing",
 		"empty-rectangle", "medusa-3d",
 		"unique-rectangle-type-2", "unique-rectangle-type-3", "unique-rectangle-type-4",
+		"unique-rectangle-type-5", "unique-rectangle-type-6", "hidden-rectangle",
 		"finned-x-wing", "finned-swordfish", "grouped-x-cycles", "aic",
 		"als-xz", "als-xy-wing", "als-xy-chain", "sue-de-coq",
 		"digit-forcing-chain", "forcing-chain", "death-blossom",
 	}
 
-	if len(techniques) != 39 {
-		t.Errorf("Expected 39 techniques, got %d", len(techniques))
+	if len(techniques) != 42 {
+		t.Errorf("Expected 42 techniques, got %d", len(techniques))
 	}
 
 	t.Logf("Total techniques defined: %d", len(techniques))
 }
+
+// candidateRefIn reports whether refs contains the given cell/digit pair
+func candidateRefIn(ref core.CandidateRef, refs []core.CandidateRef) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// TestHiddenSingleJustifyingRoles places blocking digits so the typed roles
+// have real evidence to point at: the Justifying list must carry only the
+// digit-bearing peers, never the target cell's own candidate set (the UI bug
+// the roles exist to fix).
+func TestHiddenSingleJustifyingRoles(t *testing.T) {
+	cells := [81]int{}
+	// Placed 7s that bar every row-1 cell except R1C2 from taking 7:
+	// R4C1 covers column 1, R5C3 column 3, R2C4 box 2, R3C7 box 3
+	blockers := []int{cellIdx(3, 0), cellIdx(4, 2), cellIdx(1, 3), cellIdx(2, 6)}
+	for _, idx := range blockers {
+		cells[idx] = 7
+	}
+
+	candidateMap := map[int][]int{}
+	for i := 0; i < 81; i++ {
+		if cells[i] == 0 {
+			candidateMap[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+		}
+	}
+	for col := 0; col < 9; col++ {
+		candidateMap[col] = []int{1, 2, 3, 5} // no 7 anywhere else in row 1
+	}
+	candidateMap[1] = []int{5, 7}
+
+	board := makeTestBoard(cells, candidateMap)
+	move := techniques.DetectHiddenSingle(board)
+	if move == nil {
+		t.Fatal("Expected Hidden Single to be detected")
+	}
+	if move.Digit != 7 {
+		t.Fatalf("Expected digit 7, got %d", move.Digit)
+	}
+
+	target := core.CellRef{Row: 0, Col: 1}
+	if len(move.Highlights.Target) != 1 || !cellRefIn(target, move.Highlights.Target) {
+		t.Errorf("Expected Target [R1C2], got %v", move.Highlights.Target)
+	}
+	if len(move.Highlights.Defining) != constants.GridSize {
+		t.Errorf("Expected the full unit as Defining, got %v", move.Highlights.Defining)
+	}
+
+	justifying := move.Highlights.Justifying
+	if len(justifying) != len(blockers) {
+		t.Fatalf("Expected %d justifying candidates, got %v", len(blockers), justifying)
+	}
+	for _, j := range justifying {
+		if j.Digit != 7 {
+			t.Errorf("Justifying entry %v carries digit %d, want only digit-bearing peers", j, j.Digit)
+		}
+		if j.Row == target.Row && j.Col == target.Col {
+			t.Errorf("Justifying includes the target cell %v; its own candidates must not be listed", j)
+		}
+	}
+	for _, idx := range blockers {
+		want := core.CandidateRef{Row: idx / 9, Col: idx % 9, Digit: 7}
+		if !candidateRefIn(want, justifying) {
+			t.Errorf("Expected justifying peer %v, got %v", want, justifying)
+		}
+	}
+
+	// The 5 stripped from the target mirrors into Eliminated
+	if len(move.Highlights.Eliminated) != 1 || move.Highlights.Eliminated[0] != (core.CandidateRef{Row: 0, Col: 1, Digit: 5}) {
+		t.Errorf("Expected Eliminated [{R1C2 5}], got %v", move.Highlights.Eliminated)
+	}
+}
+
+func TestNakedPairTypedRoles(t *testing.T) {
+	cells := [81]int{}
+	candidateMap := map[int][]int{}
+	for i := 0; i < 81; i++ {
+		candidateMap[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	}
+	candidateMap[0] = []int{1, 2}
+	candidateMap[1] = []int{1, 2}
+
+	board := makeTestBoard(cells, candidateMap)
+	move := techniques.DetectNakedPair(board)
+	if move == nil {
+		t.Fatal("Expected Naked Pair to be detected")
+	}
+
+	if len(move.Highlights.Defining) != 2 {
+		t.Errorf("Expected the pair cells as Defining, got %v", move.Highlights.Defining)
+	}
+	// Candidate-level: both pair digits in both pair cells, nothing else
+	if len(move.Highlights.Justifying) != 4 {
+		t.Fatalf("Expected 4 justifying candidates, got %v", move.Highlights.Justifying)
+	}
+	for _, cell := range []int{0, 1} {
+		for _, d := range []int{1, 2} {
+			want := core.CandidateRef{Row: 0, Col: cell, Digit: d}
+			if !candidateRefIn(want, move.Highlights.Justifying) {
+				t.Errorf("Expected justifying candidate %v, got %v", want, move.Highlights.Justifying)
+			}
+		}
+	}
+
+	if len(move.Highlights.Eliminated) != len(move.Eliminations) {
+		t.Errorf("Eliminated (%d) does not mirror the move's eliminations (%d)",
+			len(move.Highlights.Eliminated), len(move.Eliminations))
+	}
+	// Target lists the cells losing candidates, which excludes the pair
+	if len(move.Highlights.Target) == 0 {
+		t.Fatal("Expected Target cells for the eliminations")
+	}
+	for _, ref := range move.Highlights.Target {
+		if cellRefIn(ref, move.Highlights.Defining) {
+			t.Errorf("Target %v overlaps the defining pair", ref)
+		}
+	}
+}
+
+func TestXWingTypedRoles(t *testing.T) {
+	cells := [81]int{}
+	candidateMap := map[int][]int{}
+	for i := 0; i < 81; i++ {
+		candidateMap[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	}
+	for _, row := range []int{0, 7} {
+		for col := 0; col < 9; col++ {
+			if col != 0 && col != 6 {
+				candidateMap[row*9+col] = []int{2, 3, 4, 5, 6, 7, 8, 9}
+			} else {
+				candidateMap[row*9+col] = []int{1}
+			}
+		}
+	}
+
+	board := makeTestBoard(cells, candidateMap)
+	move := techniques.DetectXWing(board)
+	if move == nil {
+		t.Fatal("Expected X-Wing to be detected")
+	}
+
+	if len(move.Highlights.Defining) != 4 {
+		t.Errorf("Expected 4 corner cells as Defining, got %v", move.Highlights.Defining)
+	}
+	if len(move.Highlights.Justifying) != 4 {
+		t.Fatalf("Expected 4 justifying candidates, got %v", move.Highlights.Justifying)
+	}
+	for _, row := range []int{0, 7} {
+		for _, col := range []int{0, 6} {
+			want := core.CandidateRef{Row: row, Col: col, Digit: 1}
+			if !candidateRefIn(want, move.Highlights.Justifying) {
+				t.Errorf("Expected corner candidate %v, got %v", want, move.Highlights.Justifying)
+			}
+		}
+	}
+	if len(move.Highlights.Eliminated) != len(move.Eliminations) {
+		t.Errorf("Eliminated (%d) does not mirror the move's eliminations (%d)",
+			len(move.Highlights.Eliminated), len(move.Eliminations))
+	}
+	for _, ref := range move.Highlights.Target {
+		if ref.Col != 0 && ref.Col != 6 {
+			t.Errorf("Target %v is outside the cover columns", ref)
+		}
+		if cellRefIn(ref, move.Highlights.Defining) {
+			t.Errorf("Target %v overlaps the defining corners", ref)
+		}
+	}
+}
@@ -28,23 +28,43 @@ import (
 // This ensures rare/advanced techniques can fire without being preempted by
 // more common techniques that solve the puzzle first.
 var techniqueIsolationConfig = map[string][]string{
-	"bug":       {"xy-wing"},
-	"jellyfish": {"medusa-3d"},
+	// Locked variants run before their naked counterparts, so those
+	// counterparts only surface with the locked detectors off
+	"naked-pair":   {"locked-pair"},
+	"naked-triple": {"locked-triple"},
+	"bug":          {"xy-wing"},
+	"jellyfish":    {"medusa-3d"},
+	// The umbrella turbot fish only surfaces with its specific shapes off,
+	// mirroring the locked/naked split above
+	"turbot-fish": {"skyscraper", "2-string-kite"},
+	"x-chain":     {"skyscraper", "2-string-kite", "turbot-fish"},
+	// Nice loops cover the bivalue/bilocation subset of the chain family, so
+	// the narrower chain detectors ahead of it in the order must sit out
+	"nice-loop": {"skyscraper", "2-string-kite", "turbot-fish", "x-chain", "xy-chain"},
+	// Nice loops run early in the hard tier and pick off the eliminations
+	// these techniques' puzzles were chosen for, so they sit out below
+	"w-wing":           {"nice-loop"},
+	"medusa-3d":        {"xy-chain", "w-wing", "nice-loop"},
+	"empty-rectangle":  {"x-chain", "xy-chain", "nice-loop"},
+	"grouped-x-cycles": {"aic", "x-chain", "xy-chain", "nice-loop", "als-xz", "als-xy-chain", "digit-forcing-chain", "finned-x-wing"},
 	"unique-rectangle-type-2": {
-		"aic", "medusa-3d", "x-chain", "xy-chain", "grouped-x-cycles", "simple-coloring",
+		"aic", "medusa-3d", "x-chain", "xy-chain", "nice-loop", "grouped-x-cycles", "simple-coloring",
 		"w-wing", "wxyz-wing", "skyscraper", "empty-rectangle",
 	},
 	"unique-rectangle-type-3": {
-		"aic", "medusa-3d", "x-chain", "xy-chain", "grouped-x-cycles", "simple-coloring",
+		"aic", "medusa-3d", "x-chain", "xy-chain", "nice-loop", "grouped-x-cycles", "simple-coloring",
 		"skyscraper", "empty-rectangle", "w-wing", "wxyz-wing", "finned-x-wing", "finned-swordfish",
 		"jellyfish",
 	},
-	"unique-rectangle-type-4": {"medusa-3d"},
-	"als-xz":                  {"aic"},
-	"als-xy-wing":             {"aic"},
-	"als-xy-chain":            {"aic", "medusa-3d"},
+	"unique-rectangle-type-4": {"medusa-3d", "nice-loop"},
+	"finned-x-wing":           {"nice-loop"},
+	"finned-swordfish":        {"nice-loop"},
+	"aic":                     {"nice-loop"},
+	"als-xz":                  {"aic", "nice-loop"},
+	"als-xy-wing":             {"aic", "nice-loop"},
+	"als-xy-chain":            {"aic", "medusa-3d", "nice-loop"},
 	"sue-de-coq":              {"aic", "als-xz", "als-xy-wing", "als-xy-chain", "digit-forcing-chain", "forcing-chain"},
-	"digit-forcing-chain":     {"aic", "als-xz", "als-xy-wing", "als-xy-chain", "sue-de-coq", "death-blossom"},
+	"digit-forcing-chain":     {"aic", "nice-loop", "als-xz", "als-xy-wing", "als-xy-chain", "sue-de-coq", "death-blossom"},
 	"forcing-chain":           {"aic", "als-xz", "als-xy-wing", "als-xy-chain", "sue-de-coq", "death-blossom", "digit-forcing-chain"},
 	"death-blossom":           {"aic", "als-xz", "als-xy-wing", "als-xy-chain", "digit-forcing-chain", "forcing-chain", "medusa-3d"},
 }
@@ -391,7 +411,7 @@ func runEarlyStopWithDisabledTechniques(t *testing.T, slug string, disabledTechn
 }
 
 // =============================================================================
-// SIMPLE TIER TESTS (8 techniques)
+// SIMPLE TIER TESTS (10 techniques)
 // =============================================================================
 
 func TestTechniqueIsolated_HiddenSingle(t *testing.T) {
@@ -403,7 +423,11 @@ func TestTechniqueIsolated_NakedSingle(t *testing.T) {
 }
 
 func TestTechniqueIsolated_NakedPair(t *testing.T) {
-	runIsolatedTechniqueTest(t, "naked-pair")
+	runEarlyStopWithDisabledTechniques(t, "naked-pair", techniqueIsolationConfig["naked-pair"])
+}
+
+func TestTechniqueIsolated_LockedPair(t *testing.T) {
+	runEarlyStopWithDisabledTechniques(t, "locked-pair", techniqueIsolationConfig["locked-pair"])
 }
 
 func TestTechniqueIsolated_HiddenPair(t *testing.T) {
@@ -419,7 +443,11 @@ func TestTechniqueIsolated_BoxLineReduction(t *testing.T) {
 }
 
 func TestTechniqueIsolated_NakedTriple(t *testing.T) {
-	runIsolatedTechniqueTest(t, "naked-triple")
+	runEarlyStopWithDisabledTechniques(t, "naked-triple", techniqueIsolationConfig["naked-triple"])
+}
+
+func TestTechniqueIsolated_LockedTriple(t *testing.T) {
+	runEarlyStopWithDisabledTechniques(t, "locked-triple", techniqueIsolationConfig["locked-triple"])
 }
 
 func TestTechniqueIsolated_HiddenTriple(t *testing.T) {
@@ -474,16 +502,28 @@ func TestTechniqueIsolated_Skyscraper(t *testing.T) {
 	runIsolatedTechniqueTest(t, "skyscraper")
 }
 
+func TestTechniqueIsolated_TwoStringKite(t *testing.T) {
+	runIsolatedTechniqueTest(t, "2-string-kite")
+}
+
+func TestTechniqueIsolated_TurbotFish(t *testing.T) {
+	runEarlyStopWithDisabledTechniques(t, "turbot-fish", techniqueIsolationConfig["turbot-fish"])
+}
+
 func TestTechniqueIsolated_XChain(t *testing.T) {
-	runIsolatedTechniqueTest(t, "x-chain")
+	runEarlyStopWithDisabledTechniques(t, "x-chain", techniqueIsolationConfig["x-chain"])
 }
 
 func TestTechniqueIsolated_XYChain(t *testing.T) {
 	runIsolatedTechniqueTest(t, "xy-chain")
 }
 
+func TestTechniqueIsolated_NiceLoop(t *testing.T) {
+	runEarlyStopWithDisabledTechniques(t, "nice-loop", techniqueIsolationConfig["nice-loop"])
+}
+
 func TestTechniqueIsolated_Medusa3D(t *testing.T) {
-	runIsolatedTechniqueTest(t, "medusa-3d")
+	runEarlyStopWithDisabledTechniques(t, "medusa-3d", techniqueIsolationConfig["medusa-3d"])
 }
 
 func TestTechniqueIsolated_Jellyfish(t *testing.T) {
@@ -508,11 +548,11 @@ func TestTechniqueIsolated_WXYZWing(t *testing.T) {
 }
 
 func TestTechniqueIsolated_WWing(t *testing.T) {
-	runIsolatedTechniqueTest(t, "w-wing")
+	runEarlyStopWithDisabledTechniques(t, "w-wing", techniqueIsolationConfig["w-wing"])
 }
 
 func TestTechniqueIsolated_EmptyRectangle(t *testing.T) {
-	runIsolatedTechniqueTest(t, "empty-rectangle")
+	runEarlyStopWithDisabledTechniques(t, "empty-rectangle", techniqueIsolationConfig["empty-rectangle"])
 }
 
 // =============================================================================
@@ -520,24 +560,22 @@ func TestTechniqueIsolated_EmptyRectangle(t *testing.T) {
 // =============================================================================
 
 func TestTechniqueIsolated_GroupedXCycles(t *testing.T) {
-	// Uses early stop for faster execution - full solver until technique fires
-	runEarlyStopTechniqueTest(t, "grouped-x-cycles")
+	runEarlyStopWithDisabledTechniques(t, "grouped-x-cycles", techniqueIsolationConfig["grouped-x-cycles"])
 }
 
 func TestTechniqueIsolated_FinnedXWing(t *testing.T) {
-	// Uses early stop - full solver until technique fires
-	runEarlyStopTechniqueTest(t, "finned-x-wing")
+	runEarlyStopWithDisabledTechniques(t, "finned-x-wing", techniqueIsolationConfig["finned-x-wing"])
 }
 
 func TestTechniqueIsolated_FinnedSwordfish(t *testing.T) {
-	runIsolatedTechniqueTest(t, "finned-swordfish")
+	runEarlyStopWithDisabledTechniques(t, "finned-swordfish", techniqueIsolationConfig["finned-swordfish"])
 }
 
 func TestTechniqueIsolated_AIC(t *testing.T) {
 	// AIC uses Type 2 elimination: when both endpoints have the same digit,
 	// both are ON, and they see each other, we eliminate the start candidate
 	// (since Start=ON leads to End=ON but they can't both be ON - contradiction).
-	runEarlyStopTechniqueTest(t, "aic")
+	runEarlyStopWithDisabledTechniques(t, "aic", techniqueIsolationConfig["aic"])
 }
 
 func TestTechniqueIsolated_ALSXZ(t *testing.T) {
@@ -3,19 +3,44 @@
 package human
 
 import (
+	"sort"
+
 	"sudoku-api/internal/core"
 	"sudoku-api/internal/sudoku/human/techniques"
+	"sudoku-api/pkg/constants"
 )
 
 // TechniqueDescriptor holds metadata about a solving technique
 type TechniqueDescriptor struct {
-	Name        string                                       // Display name
-	Slug        string                                       // URL-friendly identifier
-	Tier        string                                       // Difficulty tier (constants.TierSimple, etc.)
-	Description string                                       // Brief description
-	Detector    func(b techniques.BoardInterface) *core.Move // Detection function
-	Enabled     bool                                         // Whether technique is enabled
-	Order       int                                          // Execution order within tier
+	Name        string                                         // Display name
+	Slug        string                                         // URL-friendly identifier
+	Tier        string                                         // Difficulty tier (constants.TierSimple, etc.)
+	Description string                                         // Brief description
+	Score       int                                            // Difficulty score (higher = harder)
+	DocURL      string                                         // Documentation link (defaults to /technique/<slug>)
+	Detector    func(b techniques.BoardInterface) *core.Move   // Detection function
+	DetectorAll func(b techniques.BoardInterface) []*core.Move // Optional all-matches variant for cell-targeted hints
+	Diagnose    func(b techniques.BoardInterface) string       // Optional near-miss reporter for stall diagnostics
+	Enabled     bool                                           // Whether technique is enabled
+	Order       int                                            // Execution order within tier
+
+	// RequiresUniqueness marks techniques that are only sound when the
+	// puzzle has exactly one solution (the UR family, BUG). The solver
+	// skips them on boards that don't assume uniqueness.
+	RequiresUniqueness bool
+}
+
+// TechniqueInfo is the JSON-serializable metadata for one registered
+// technique, consumed by the /api/techniques endpoint and the WASM
+// getTechniques export so clients don't have to hardcode display names.
+type TechniqueInfo struct {
+	Slug        string `json:"slug"`
+	DisplayName string `json:"display_name"`
+	Tier        string `json:"tier"`
+	Description string `json:"description"`
+	Score       int    `json:"score"`
+	DocURL      string `json:"doc_url"`
+	Enabled     bool   `json:"enabled"`
 }
 
 // TechniqueRegistry holds all available techniques organized by tier
@@ -34,7 +59,11 @@ func NewTechniqueRegistry() *TechniqueRegistry {
 	return registry
 }
 
-// registerTechniques defines all available techniques with metadata
+// techniqueManifest is the registration manifest: one entry per technique,
+// pairing its slug with its tier, score, and detector. The registry is built
+// from this list and the conformance tests freeze it, so adding a technique
+// means adding exactly one entry here (plus its detector file).
+//
 // Order is based on PEDAGOGICAL LEARNING PROGRESSION - the natural order
 // a student would learn techniques, from most intuitive to most advanced.
 //
@@ -52,446 +81,641 @@ func NewTechniqueRegistry() *TechniqueRegistry {
 // - Medium (Tough): Quads, Fish, Wings, Coloring, BUG, UR Type 1
 // - Hard (Diabolical): Advanced Fish, Chains, Medusa, Advanced URs
 // - Extreme: Finned Fish, AICs, ALS techniques, Forcing Chains
-func (r *TechniqueRegistry) registerTechniques() {
-	// ==========================================================================
-	// SIMPLE TIER (Basic) - Singles, Pairs, Intersection Removal, Triples
-	// Learning progression: Start with the most intuitive techniques
-	// ==========================================================================
-
-	// Naked Single first - most intuitive: "only one number can go here!"
-	r.register(TechniqueDescriptor{
-		Name:        "Naked Single",
-		Slug:        "naked-single",
-		Tier:        "simple",
-		Description: "A cell with only one possible candidate",
-		Detector:    techniques.DetectNakedSingle,
-		Enabled:     true,
-		Order:       1,
-	})
-
-	// Hidden Single second - "only one place for this number!"
-	r.register(TechniqueDescriptor{
-		Name:        "Hidden Single",
-		Slug:        "hidden-single",
-		Tier:        "simple",
-		Description: "A digit that can only go in one cell in a row, column, or box",
-		Detector:    techniques.DetectHiddenSingle,
-		Enabled:     true,
-		Order:       2,
-	})
-
-	// Pairs - first subset technique, easy to visualize
-	r.register(TechniqueDescriptor{
-		Name:        "Naked Pair",
-		Slug:        "naked-pair",
-		Tier:        "simple",
-		Description: "Two cells with the same two candidates eliminate those digits from their peers",
-		Detector:    techniques.DetectNakedPair,
-		Enabled:     true,
-		Order:       3,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Hidden Pair",
-		Slug:        "hidden-pair",
-		Tier:        "simple",
-		Description: "Two digits that can only be in two cells eliminate other candidates from those cells",
-		Detector:    techniques.DetectHiddenPair,
-		Enabled:     true,
-		Order:       4,
-	})
-
-	// Intersection techniques - still visual, box/line relationships
-	r.register(TechniqueDescriptor{
-		Name:        "Pointing Pair",
-		Slug:        "pointing-pair",
-		Tier:        "simple",
-		Description: "If a digit in a box can only be in one row/column, eliminate it from the rest of that row/column",
-		Detector:    techniques.DetectPointingPair,
-		Enabled:     true,
-		Order:       5,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Box-Line Reduction",
-		Slug:        "box-line-reduction",
-		Tier:        "simple",
-		Description: "If a digit in a row/column can only be in one box, eliminate it from the rest of that box",
-		Detector:    techniques.DetectBoxLineReduction,
-		Enabled:     true,
-		Order:       6,
-	})
-
-	// Triples - natural extension of pairs
-	r.register(TechniqueDescriptor{
-		Name:        "Naked Triple",
-		Slug:        "naked-triple",
-		Tier:        "simple",
-		Description: "Three cells with the same three candidates eliminate those digits from their peers",
-		Detector:    techniques.DetectNakedTriple,
-		Enabled:     true,
-		Order:       7,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Hidden Triple",
-		Slug:        "hidden-triple",
-		Tier:        "simple",
-		Description: "Three digits that can only be in three cells eliminate other candidates from those cells",
-		Detector:    techniques.DetectHiddenTriple,
-		Enabled:     true,
-		Order:       8,
-	})
-
-	// ==========================================================================
-	// MEDIUM TIER (Tough) - Quads, Fish, Wings, Coloring, BUG, UR Type 1
-	// Learning progression: Complete subsets, then introduce fish and wings
-	// ==========================================================================
-
-	// Quads first - complete the subset progression (pairs → triples → quads)
-	r.register(TechniqueDescriptor{
-		Name:        "Naked Quad",
-		Slug:        "naked-quad",
-		Tier:        "medium",
-		Description: "Four cells with the same four candidates eliminate those digits from their peers",
-		Detector:    techniques.DetectNakedQuad,
-		Enabled:     true,
-		Order:       9,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Hidden Quad",
-		Slug:        "hidden-quad",
-		Tier:        "medium",
-		Description: "Four digits that can only be in four cells eliminate other candidates from those cells",
-		Detector:    techniques.DetectHiddenQuad,
-		Enabled:     true,
-		Order:       10,
-	})
-
-	// Fish techniques - visual pattern recognition
-	r.register(TechniqueDescriptor{
-		Name:        "X-Wing",
-		Slug:        "x-wing",
-		Tier:        "medium",
-		Description: "A digit forming a rectangle pattern allows eliminations",
-		Detector:    techniques.DetectXWing,
-		Enabled:     true,
-		Order:       11,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Swordfish",
-		Slug:        "swordfish",
-		Tier:        "medium",
-		Description: "A 3x3 fish pattern for eliminations",
-		Detector:    techniques.DetectSwordfish,
-		Enabled:     true,
-		Order:       12,
-	})
-
-	// Wing techniques - introduce chained logic
-	r.register(TechniqueDescriptor{
-		Name:        "XY-Wing",
-		Slug:        "xy-wing",
-		Tier:        "medium",
-		Description: "A hinge cell and two pincers eliminate candidates",
-		Detector:    techniques.DetectXYWing,
-		Enabled:     true,
-		Order:       13,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "XYZ-Wing",
-		Slug:        "xyz-wing",
-		Tier:        "medium",
-		Description: "A trivalue hinge with bivalue pincers",
-		Detector:    techniques.DetectXYZWing,
-		Enabled:     true,
-		Order:       14,
-	})
-
-	// Simple Coloring - introduces color chain concepts
-	r.register(TechniqueDescriptor{
-		Name:        "Simple Coloring",
-		Slug:        "simple-coloring",
-		Tier:        "medium",
-		Description: "Color chains of strong links to find eliminations",
-		Detector:    techniques.DetectSimpleColoring,
-		Enabled:     true,
-		Order:       15,
-	})
-
-	// BUG and Unique Rectangle - require understanding of uniqueness
-	r.register(TechniqueDescriptor{
-		Name:        "BUG",
-		Slug:        "bug",
-		Tier:        "medium",
-		Description: "Bivalue Universal Grave - avoid patterns with multiple solutions",
-		Detector:    techniques.DetectBUG,
-		Enabled:     true,
-		Order:       16,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Unique Rectangle",
-		Slug:        "unique-rectangle",
-		Tier:        "medium",
-		Description: "Avoid deadly rectangles that would make puzzle have multiple solutions",
-		Detector:    techniques.DetectUniqueRectangle,
-		Enabled:     true,
-		Order:       17,
-	})
-
-	// ==========================================================================
-	// HARD TIER (Diabolical) - Advanced Fish, Chains, Medusa, Advanced URs
-	// Learning progression: Complete fish family, then chains, then advanced patterns
-	// ==========================================================================
-
-	// Jellyfish - complete the fish family (X-Wing → Swordfish → Jellyfish)
-	r.register(TechniqueDescriptor{
-		Name:        "Jellyfish",
-		Slug:        "jellyfish",
-		Tier:        "hard",
-		Description: "A 4x4 fish pattern for eliminations",
-		Detector:    techniques.DetectJellyfish,
-		Enabled:     true,
-		Order:       18,
-	})
-
-	// Skyscraper - simple single-digit chain, intro to chain concepts
-	r.register(TechniqueDescriptor{
-		Name:        "Skyscraper",
-		Slug:        "skyscraper",
-		Tier:        "hard",
-		Description: "A turbot fish variant for eliminations",
-		Detector:    techniques.DetectSkyscraper,
-		Enabled:     true,
-		Order:       19,
-	})
-
-	// X-Chain - general single-digit chains
-	r.register(TechniqueDescriptor{
-		Name:        "X-Chain",
-		Slug:        "x-chain",
-		Tier:        "hard",
-		Description: "Chain of alternating strong/weak links for a single digit",
-		Detector:    techniques.DetectXChain,
-		Enabled:     true,
-		Order:       20,
-	})
-
-	// XY-Chain - multi-digit chains through bivalue cells
-	r.register(TechniqueDescriptor{
-		Name:        "XY-Chain",
-		Slug:        "xy-chain",
-		Tier:        "hard",
-		Description: "Chain through bivalue cells",
-		Detector:    techniques.DetectXYChain,
-		Enabled:     true,
-		Order:       21,
-	})
-
-	// W-Wing - connected bivalue cells
-	r.register(TechniqueDescriptor{
-		Name:        "W-Wing",
-		Slug:        "w-wing",
-		Tier:        "hard",
-		Description: "Two bivalue cells connected by strong link",
-		Detector:    techniques.DetectWWing,
-		Enabled:     true,
-		Order:       22,
-	})
-
-	// WXYZ-Wing - complete the wing family
-	r.register(TechniqueDescriptor{
-		Name:        "WXYZ-Wing",
-		Slug:        "wxyz-wing",
-		Tier:        "hard",
-		Description: "A four-candidate wing pattern",
-		Detector:    techniques.DetectWXYZWing,
-		Enabled:     true,
-		Order:       23,
-	})
-
-	// Empty Rectangle - box-based chain technique
-	r.register(TechniqueDescriptor{
-		Name:        "Empty Rectangle",
-		Slug:        "empty-rectangle",
-		Tier:        "hard",
-		Description: "Use empty rectangles to create eliminations",
-		Detector:    techniques.DetectEmptyRectangle,
-		Enabled:     true,
-		Order:       24,
-	})
-
-	// 3D Medusa - advanced multi-digit coloring
-	r.register(TechniqueDescriptor{
-		Name:        "3D Medusa",
-		Slug:        "medusa-3d",
-		Tier:        "hard",
-		Description: "Multi-digit coloring with strong/weak link chains",
-		Detector:    techniques.DetectMedusa3D,
-		Enabled:     true,
-		Order:       25,
-	})
-
-	// Advanced Unique Rectangles - after basic UR is understood
-	r.register(TechniqueDescriptor{
-		Name:        "Unique Rectangle Type 2",
-		Slug:        "unique-rectangle-type-2",
-		Tier:        "hard",
-		Description: "Unique rectangle with extra candidates in one corner",
-		Detector:    techniques.DetectUniqueRectangleType2,
-		Enabled:     true,
-		Order:       26,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Unique Rectangle Type 3",
-		Slug:        "unique-rectangle-type-3",
-		Tier:        "hard",
-		Description: "Unique rectangle with naked pair/triple",
-		Detector:    techniques.DetectUniqueRectangleType3,
-		Enabled:     true,
-		Order:       27,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Unique Rectangle Type 4",
-		Slug:        "unique-rectangle-type-4",
-		Tier:        "hard",
-		Description: "Unique rectangle with hidden pair",
-		Detector:    techniques.DetectUniqueRectangleType4,
-		Enabled:     true,
-		Order:       28,
-	})
-
-	// ==========================================================================
-	// EXTREME TIER - Finned Fish, AICs, ALS, Forcing Chains
-	// Learning progression: Finned fish extend basic fish, then AICs, then ALS
-	// ==========================================================================
-
-	// Finned Fish - extensions of basic fish patterns
-	r.register(TechniqueDescriptor{
-		Name:        "Finned X-Wing",
-		Slug:        "finned-x-wing",
-		Tier:        "extreme",
-		Description: "An X-Wing with extra candidates (fins)",
-		Detector:    techniques.DetectFinnedXWing,
-		Enabled:     true,
-		Order:       29,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "Finned Swordfish",
-		Slug:        "finned-swordfish",
-		Tier:        "extreme",
-		Description: "A Swordfish with extra candidates (fins)",
-		Detector:    techniques.DetectFinnedSwordfish,
-		Enabled:     true,
-		Order:       30,
-	})
-
-	// Grouped X-Cycles - advanced single-digit cycles
-	r.register(TechniqueDescriptor{
-		Name:        "Grouped X-Cycles",
-		Slug:        "grouped-x-cycles",
-		Tier:        "extreme",
-		Description: "X-Cycles using group strong links",
-		Detector:    techniques.DetectGroupedXCycles,
-		Enabled:     true,
-		Order:       31,
-	})
-
-	// AIC - general alternating inference chains
-	r.register(TechniqueDescriptor{
-		Name:        "AIC",
-		Slug:        "aic",
-		Tier:        "extreme",
-		Description: "Alternating Inference Chains",
-		Detector:    techniques.DetectAIC,
-		Enabled:     true,
-		Order:       32,
-	})
-
-	// ALS techniques - Almost Locked Sets family
-	r.register(TechniqueDescriptor{
-		Name:        "ALS-XZ",
-		Slug:        "als-xz",
-		Tier:        "extreme",
-		Description: "Almost Locked Set with XZ rule",
-		Detector:    techniques.DetectALSXZ,
-		Enabled:     true,
-		Order:       33,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "ALS-XY-Wing",
-		Slug:        "als-xy-wing",
-		Tier:        "extreme",
-		Description: "Almost Locked Set XY-Wing pattern",
-		Detector:    techniques.DetectALSXYWing,
-		Enabled:     true,
-		Order:       34,
-	})
-
-	r.register(TechniqueDescriptor{
-		Name:        "ALS-XY-Chain",
-		Slug:        "als-xy-chain",
-		Tier:        "extreme",
-		Description: "Chain of Almost Locked Sets",
-		Detector:    techniques.DetectALSXYChain,
-		Enabled:     true,
-		Order:       35,
-	})
-
-	// Sue de Coq - intersecting ALS
-	r.register(TechniqueDescriptor{
-		Name:        "Sue de Coq",
-		Slug:        "sue-de-coq",
-		Tier:        "extreme",
-		Description: "Two intersecting almost locked sets",
-		Detector:    techniques.DetectSueDeCoq,
-		Enabled:     true,
-		Order:       36,
-	})
-
-	// Death Blossom - advanced ALS pattern
-	r.register(TechniqueDescriptor{
-		Name:        "Death Blossom",
-		Slug:        "death-blossom",
-		Tier:        "extreme",
-		Description: "Advanced ALS pattern with stem and petals",
-		Detector:    techniques.DetectDeathBlossom,
-		Enabled:     true,
-		Order:       37,
-	})
-
-	// Forcing Chains - most general forcing techniques (last resort)
-	r.register(TechniqueDescriptor{
-		Name:        "Digit Forcing Chain",
-		Slug:        "digit-forcing-chain",
-		Tier:        "extreme",
-		Description: "Forcing chain focused on single digit",
-		Detector:    techniques.DetectDigitForcingChain,
-		Enabled:     true,
-		Order:       38,
-	})
+func techniqueManifest() []TechniqueDescriptor {
+	return []TechniqueDescriptor{
+		// ==========================================================================
+		// SIMPLE TIER (Basic) - Singles, Pairs, Intersection Removal, Triples
+		// Learning progression: Start with the most intuitive techniques
+		// ==========================================================================
+
+		// Naked Single first - most intuitive: "only one number can go here!"
+		{
+			Name:        "Naked Single",
+			Slug:        "naked-single",
+			Tier:        "simple",
+			Description: "A cell with only one possible candidate",
+			Score:       10,
+			Detector:    techniques.DetectNakedSingle,
+			DetectorAll: techniques.DetectNakedSingleAll,
+			Enabled:     true,
+			Order:       1,
+		},
+
+		// Hidden Single second - "only one place for this number!"
+		{
+			Name:        "Hidden Single",
+			Slug:        "hidden-single",
+			Tier:        "simple",
+			Description: "A digit that can only go in one cell in a row, column, or box",
+			Score:       15,
+			Detector:    techniques.DetectHiddenSingle,
+			DetectorAll: techniques.DetectHiddenSingleAll,
+			Enabled:     true,
+			Order:       2,
+		},
+
+		// Locked Pair runs before Naked Pair so the box+line variant claims the
+		// pattern and eliminates from both houses in a single move
+		{
+			Name:        "Locked Pair",
+			Slug:        "locked-pair",
+			Tier:        "simple",
+			Description: "A naked pair confined to one box and one line eliminates its digits from both houses",
+			Score:       22,
+			Detector:    techniques.DetectLockedPair,
+			Enabled:     true,
+			Order:       4,
+		},
+
+		// Pairs - first subset technique, easy to visualize
+		{
+			Name:        "Naked Pair",
+			Slug:        "naked-pair",
+			Tier:        "simple",
+			Description: "Two cells with the same two candidates eliminate those digits from their peers",
+			Score:       20,
+			Detector:    techniques.DetectNakedPair,
+			DetectorAll: techniques.DetectNakedPairAll,
+			Diagnose:    func(b techniques.BoardInterface) string { return techniques.DiagnoseNakedSubset(b, 2) },
+			Enabled:     true,
+			Order:       3,
+		},
+
+		{
+			Name:        "Hidden Pair",
+			Slug:        "hidden-pair",
+			Tier:        "simple",
+			Description: "Two digits that can only be in two cells eliminate other candidates from those cells",
+			Score:       25,
+			Detector:    techniques.DetectHiddenPair,
+			DetectorAll: techniques.DetectHiddenPairAll,
+			Diagnose:    func(b techniques.BoardInterface) string { return techniques.DiagnoseHiddenSubset(b, 2) },
+			Enabled:     true,
+			Order:       5,
+		},
+
+		// Intersection techniques - still visual, box/line relationships
+		{
+			Name:        "Pointing Pair",
+			Slug:        "pointing-pair",
+			Tier:        "simple",
+			Description: "If a digit in a box can only be in one row/column, eliminate it from the rest of that row/column",
+			Score:       30,
+			Detector:    techniques.DetectPointingPair,
+			DetectorAll: techniques.DetectPointingPairAll,
+			Enabled:     true,
+			Order:       6,
+		},
+
+		{
+			Name:        "Pointing Triple",
+			Slug:        "pointing-triple",
+			Tier:        "simple",
+			Description: "If a digit in a box can only be in three cells of one row/column, eliminate it from the rest of that row/column",
+			Score:       32,
+			Detector:    techniques.DetectPointingTriple,
+			DetectorAll: techniques.DetectPointingTripleAll,
+			Enabled:     true,
+			Order:       7,
+		},
+
+		// Box-Line Reduction is also known as claiming; GetBySlug resolves both
+		{
+			Name:        "Box-Line Reduction",
+			Slug:        "box-line-reduction",
+			Tier:        "simple",
+			Description: "If a digit in a row/column can only be in one box, eliminate it from the rest of that box",
+			Score:       30,
+			Detector:    techniques.DetectBoxLineReduction,
+			DetectorAll: techniques.DetectBoxLineReductionAll,
+			Enabled:     true,
+			Order:       8,
+		},
+
+		// Locked Triple runs before Naked Triple for the same reason as Locked Pair
+		{
+			Name:        "Locked Triple",
+			Slug:        "locked-triple",
+			Tier:        "simple",
+			Description: "A naked triple confined to one box and one line eliminates its digits from both houses",
+			Score:       38,
+			Detector:    techniques.DetectLockedTriple,
+			Enabled:     true,
+			Order:       10,
+		},
+
+		// Triples - natural extension of pairs
+		{
+			Name:        "Naked Triple",
+			Slug:        "naked-triple",
+			Tier:        "simple",
+			Description: "Three cells with the same three candidates eliminate those digits from their peers",
+			Score:       35,
+			Detector:    techniques.DetectNakedTriple,
+			DetectorAll: techniques.DetectNakedTripleAll,
+			Diagnose:    func(b techniques.BoardInterface) string { return techniques.DiagnoseNakedSubset(b, 3) },
+			Enabled:     true,
+			Order:       9,
+		},
+
+		{
+			Name:        "Hidden Triple",
+			Slug:        "hidden-triple",
+			Tier:        "simple",
+			Description: "Three digits that can only be in three cells eliminate other candidates from those cells",
+			Score:       40,
+			Detector:    techniques.DetectHiddenTriple,
+			DetectorAll: techniques.DetectHiddenTripleAll,
+			Diagnose:    func(b techniques.BoardInterface) string { return techniques.DiagnoseHiddenSubset(b, 3) },
+			Enabled:     true,
+			Order:       11,
+		},
+
+		// ==========================================================================
+		// MEDIUM TIER (Tough) - Quads, Fish, Wings, Coloring, BUG, UR Type 1
+		// Learning progression: Complete subsets, then introduce fish and wings
+		// ==========================================================================
+
+		// Quads first - complete the subset progression (pairs → triples → quads)
+		{
+			Name:        "Naked Quad",
+			Slug:        "naked-quad",
+			Tier:        "medium",
+			Description: "Four cells with the same four candidates eliminate those digits from their peers",
+			Score:       45,
+			Detector:    techniques.DetectNakedQuad,
+			DetectorAll: techniques.DetectNakedQuadAll,
+			Diagnose:    func(b techniques.BoardInterface) string { return techniques.DiagnoseNakedSubset(b, 4) },
+			Enabled:     true,
+			Order:       12,
+		},
+
+		{
+			Name:        "Hidden Quad",
+			Slug:        "hidden-quad",
+			Tier:        "medium",
+			Description: "Four digits that can only be in four cells eliminate other candidates from those cells",
+			Score:       50,
+			Detector:    techniques.DetectHiddenQuad,
+			DetectorAll: techniques.DetectHiddenQuadAll,
+			Diagnose:    func(b techniques.BoardInterface) string { return techniques.DiagnoseHiddenSubset(b, 4) },
+			Enabled:     true,
+			Order:       13,
+		},
+
+		// Fish techniques - visual pattern recognition
+		{
+			Name:        "X-Wing",
+			Slug:        "x-wing",
+			Tier:        "medium",
+			Description: "A digit forming a rectangle pattern allows eliminations",
+			Score:       55,
+			Detector:    techniques.DetectXWing,
+			DetectorAll: techniques.DetectXWingAll,
+			Diagnose:    techniques.DiagnoseXWing,
+			Enabled:     true,
+			Order:       14,
+		},
+
+		{
+			Name:        "Swordfish",
+			Slug:        "swordfish",
+			Tier:        "medium",
+			Description: "A 3x3 fish pattern for eliminations",
+			Score:       60,
+			Detector:    techniques.DetectSwordfish,
+			DetectorAll: techniques.DetectSwordfishAll,
+			Enabled:     true,
+			Order:       15,
+		},
+
+		// Wing techniques - introduce chained logic
+		{
+			Name:        "XY-Wing",
+			Slug:        "xy-wing",
+			Tier:        "medium",
+			Description: "A hinge cell and two pincers eliminate candidates",
+			Score:       60,
+			Detector:    techniques.DetectXYWing,
+			Enabled:     true,
+			Order:       16,
+		},
+
+		{
+			Name:        "XYZ-Wing",
+			Slug:        "xyz-wing",
+			Tier:        "medium",
+			Description: "A trivalue hinge with bivalue pincers",
+			Score:       65,
+			Detector:    techniques.DetectXYZWing,
+			Enabled:     true,
+			Order:       17,
+		},
+
+		// Simple Coloring - introduces color chain concepts
+		{
+			Name:        "Simple Coloring",
+			Slug:        "simple-coloring",
+			Tier:        "medium",
+			Description: "Color chains of strong links to find eliminations",
+			Score:       70,
+			Detector:    techniques.DetectSimpleColoring,
+			Enabled:     true,
+			Order:       18,
+		},
+
+		// BUG and Unique Rectangle - require understanding of uniqueness
+		{
+			Name:               "BUG",
+			Slug:               "bug",
+			Tier:               "medium",
+			Description:        "Bivalue Universal Grave - avoid patterns with multiple solutions",
+			Score:              70,
+			Detector:           techniques.DetectBUG,
+			Enabled:            true,
+			Order:              19,
+			RequiresUniqueness: true,
+		},
+
+		{
+			Name:               "Unique Rectangle",
+			Slug:               "unique-rectangle",
+			Tier:               "medium",
+			Description:        "Avoid deadly rectangles that would make puzzle have multiple solutions",
+			Score:              70,
+			Detector:           techniques.DetectUniqueRectangle,
+			Enabled:            true,
+			Order:              20,
+			RequiresUniqueness: true,
+		},
+
+		// ==========================================================================
+		// HARD TIER (Diabolical) - Advanced Fish, Chains, Medusa, Advanced URs
+		// Learning progression: Complete fish family, then chains, then advanced patterns
+		// ==========================================================================
+
+		// Jellyfish - complete the fish family (X-Wing → Swordfish → Jellyfish)
+		{
+			Name:        "Jellyfish",
+			Slug:        "jellyfish",
+			Tier:        "hard",
+			Description: "A 4x4 fish pattern for eliminations",
+			Score:       75,
+			Detector:    techniques.DetectJellyfish,
+			Enabled:     true,
+			Order:       21,
+		},
+
+		// Turbot fish family - single-digit chains of two strong links, the
+		// intro to chain concepts. The specific shapes register first so they
+		// claim their patterns before the generic case.
+		{
+			Name:        "Skyscraper",
+			Slug:        "skyscraper",
+			Tier:        "hard",
+			Description: "Two parallel strong links sharing a base line",
+			Score:       75,
+			Detector:    techniques.DetectSkyscraper,
+			DetectorAll: techniques.DetectSkyscraperAll,
+			Enabled:     true,
+			Order:       22,
+		},
+
+		{
+			Name:        "2-String Kite",
+			Slug:        "2-string-kite",
+			Tier:        "hard",
+			Description: "A row and a column strong link connected through a box",
+			Score:       75,
+			Detector:    techniques.DetectTwoStringKite,
+			DetectorAll: techniques.DetectTwoStringKiteAll,
+			Enabled:     true,
+			Order:       23,
+		},
+
+		{
+			Name:        "Turbot Fish",
+			Slug:        "turbot-fish",
+			Tier:        "hard",
+			Description: "Two strong links joined by a weak link, in any shape",
+			Score:       78,
+			Detector:    techniques.DetectTurbotFish,
+			DetectorAll: techniques.DetectTurbotFishAll,
+			Enabled:     true,
+			Order:       24,
+		},
+
+		// X-Chain - general single-digit chains
+		{
+			Name:        "X-Chain",
+			Slug:        "x-chain",
+			Tier:        "hard",
+			Description: "Chain of alternating strong/weak links for a single digit",
+			Score:       80,
+			Detector:    techniques.DetectXChain,
+			Enabled:     true,
+			Order:       25,
+		},
+
+		// XY-Chain - multi-digit chains through bivalue cells
+		{
+			Name:        "XY-Chain",
+			Slug:        "xy-chain",
+			Tier:        "hard",
+			Description: "Chain through bivalue cells",
+			Score:       85,
+			Detector:    techniques.DetectXYChain,
+			Enabled:     true,
+			Order:       26,
+		},
+
+		// Nice Loop - AIC restricted to bivalue/bilocation nodes, narrated
+		{
+			Name:        "Nice Loop",
+			Slug:        "nice-loop",
+			Tier:        "hard",
+			Description: "Alternating loop through bivalue cells and conjugate pairs",
+			Score:       86,
+			Detector:    techniques.DetectNiceLoop,
+			Enabled:     true,
+			Order:       27,
+		},
+
+		// W-Wing - connected bivalue cells
+		{
+			Name:        "W-Wing",
+			Slug:        "w-wing",
+			Tier:        "hard",
+			Description: "Two bivalue cells connected by strong link",
+			Score:       80,
+			Detector:    techniques.DetectWWing,
+			Enabled:     true,
+			Order:       28,
+		},
+
+		// WXYZ-Wing - complete the wing family
+		{
+			Name:        "WXYZ-Wing",
+			Slug:        "wxyz-wing",
+			Tier:        "hard",
+			Description: "A four-candidate wing pattern",
+			Score:       85,
+			Detector:    techniques.DetectWXYZWing,
+			Enabled:     true,
+			Order:       29,
+		},
+
+		// Empty Rectangle - box-based chain technique
+		{
+			Name:        "Empty Rectangle",
+			Slug:        "empty-rectangle",
+			Tier:        "hard",
+			Description: "Use empty rectangles to create eliminations",
+			Score:       80,
+			Detector:    techniques.DetectEmptyRectangle,
+			Enabled:     true,
+			Order:       30,
+		},
+
+		// 3D Medusa - advanced multi-digit coloring
+		{
+			Name:        "3D Medusa",
+			Slug:        "medusa-3d",
+			Tier:        "hard",
+			Description: "Multi-digit coloring with strong/weak link chains",
+			Score:       90,
+			Detector:    techniques.DetectMedusa3D,
+			Enabled:     true,
+			Order:       31,
+		},
+
+		// Advanced Unique Rectangles - after basic UR is understood
+		{
+			Name:               "Unique Rectangle Type 2",
+			Slug:               "unique-rectangle-type-2",
+			Tier:               "hard",
+			Description:        "Unique rectangle with extra candidates in one corner",
+			Score:              85,
+			Detector:           techniques.DetectUniqueRectangleType2,
+			Enabled:            true,
+			Order:              32,
+			RequiresUniqueness: true,
+		},
+
+		{
+			Name:               "Unique Rectangle Type 3",
+			Slug:               "unique-rectangle-type-3",
+			Tier:               "hard",
+			Description:        "Unique rectangle with naked pair/triple",
+			Score:              90,
+			Detector:           techniques.DetectUniqueRectangleType3,
+			Enabled:            true,
+			Order:              33,
+			RequiresUniqueness: true,
+		},
+
+		{
+			Name:               "Unique Rectangle Type 4",
+			Slug:               "unique-rectangle-type-4",
+			Tier:               "hard",
+			Description:        "Unique rectangle with hidden pair",
+			Score:              90,
+			Detector:           techniques.DetectUniqueRectangleType4,
+			Enabled:            true,
+			Order:              34,
+			RequiresUniqueness: true,
+		},
+
+		{
+			Name:               "Unique Rectangle Type 5",
+			Slug:               "unique-rectangle-type-5",
+			Tier:               "hard",
+			Description:        "Unique rectangle with the same extra candidate on diagonal corners",
+			Score:              85,
+			Detector:           techniques.DetectUniqueRectangleType5,
+			Enabled:            true,
+			Order:              35,
+			RequiresUniqueness: true,
+		},
+
+		{
+			Name:               "Unique Rectangle Type 6",
+			Slug:               "unique-rectangle-type-6",
+			Tier:               "hard",
+			Description:        "Unique rectangle with an X-Wing on one of its digits",
+			Score:              90,
+			Detector:           techniques.DetectUniqueRectangleType6,
+			Enabled:            true,
+			Order:              36,
+			RequiresUniqueness: true,
+		},
+
+		{
+			Name:               "Hidden Rectangle",
+			Slug:               "hidden-rectangle",
+			Tier:               "hard",
+			Description:        "Unique rectangle buried under extra candidates",
+			Score:              92,
+			Detector:           techniques.DetectHiddenRectangle,
+			Enabled:            true,
+			Order:              37,
+			RequiresUniqueness: true,
+		},
+
+		// ==========================================================================
+		// EXTREME TIER - Finned Fish, AICs, ALS, Forcing Chains
+		// Learning progression: Finned fish extend basic fish, then AICs, then ALS
+		// ==========================================================================
+
+		// Finned Fish - extensions of basic fish patterns
+		{
+			Name:        "Finned X-Wing",
+			Slug:        "finned-x-wing",
+			Tier:        "extreme",
+			Description: "An X-Wing with extra candidates (fins), including sashimi variants",
+			Score:       95,
+			Detector:    techniques.DetectFinnedXWing,
+			Enabled:     true,
+			Order:       38,
+		},
+
+		{
+			Name:        "Finned Swordfish",
+			Slug:        "finned-swordfish",
+			Tier:        "extreme",
+			Description: "A Swordfish with extra candidates (fins), including sashimi variants",
+			Score:       100,
+			Detector:    techniques.DetectFinnedSwordfish,
+			Enabled:     true,
+			Order:       39,
+		},
+
+		// Grouped X-Cycles - advanced single-digit cycles
+		{
+			Name:        "Grouped X-Cycles",
+			Slug:        "grouped-x-cycles",
+			Tier:        "extreme",
+			Description: "X-Cycles using group strong links",
+			Score:       105,
+			Detector:    techniques.DetectGroupedXCycles,
+			Enabled:     true,
+			Order:       40,
+		},
+
+		// AIC - general alternating inference chains
+		{
+			Name:        "AIC",
+			Slug:        "aic",
+			Tier:        "extreme",
+			Description: "Alternating Inference Chains",
+			Score:       110,
+			Detector:    techniques.DetectAIC,
+			Enabled:     true,
+			Order:       41,
+		},
+
+		// ALS techniques - Almost Locked Sets family
+		{
+			Name:        "ALS-XZ",
+			Slug:        "als-xz",
+			Tier:        "extreme",
+			Description: "Almost Locked Set with XZ rule",
+			Score:       110,
+			Detector:    techniques.DetectALSXZ,
+			Enabled:     true,
+			Order:       42,
+		},
+
+		{
+			Name:        "ALS-XY-Wing",
+			Slug:        "als-xy-wing",
+			Tier:        "extreme",
+			Description: "Almost Locked Set XY-Wing pattern",
+			Score:       115,
+			Detector:    techniques.DetectALSXYWing,
+			Enabled:     true,
+			Order:       43,
+		},
+
+		{
+			Name:        "ALS-XY-Chain",
+			Slug:        "als-xy-chain",
+			Tier:        "extreme",
+			Description: "Chain of Almost Locked Sets",
+			Score:       120,
+			Detector:    techniques.DetectALSXYChain,
+			Enabled:     true,
+			Order:       44,
+		},
+
+		// Sue de Coq - intersecting ALS
+		{
+			Name:        "Sue de Coq",
+			Slug:        "sue-de-coq",
+			Tier:        "extreme",
+			Description: "Two intersecting almost locked sets",
+			Score:       115,
+			Detector:    techniques.DetectSueDeCoq,
+			Enabled:     true,
+			Order:       45,
+		},
+
+		// Death Blossom - advanced ALS pattern
+		{
+			Name:        "Death Blossom",
+			Slug:        "death-blossom",
+			Tier:        "extreme",
+			Description: "Advanced ALS pattern with stem and petals",
+			Score:       125,
+			Detector:    techniques.DetectDeathBlossom,
+			Enabled:     true,
+			Order:       46,
+		},
+
+		// Forcing Chains - most general forcing techniques (last resort)
+		{
+			Name:        "Digit Forcing Chain",
+			Slug:        "digit-forcing-chain",
+			Tier:        "extreme",
+			Description: "Forcing chain focused on single digit",
+			Score:       130,
+			Detector:    techniques.DetectDigitForcingChain,
+			Enabled:     true,
+			Order:       47,
+		},
+
+		{
+			Name:        "Forcing Chain",
+			Slug:        "forcing-chain",
+			Tier:        "extreme",
+			Description: "Chain of implications from candidate assumptions",
+			Score:       135,
+			Detector:    techniques.DetectForcingChain,
+			Enabled:     true,
+			Order:       48,
+		},
+	}
+}
 
-	r.register(TechniqueDescriptor{
-		Name:        "Forcing Chain",
-		Slug:        "forcing-chain",
-		Tier:        "extreme",
-		Description: "Chain of implications from candidate assumptions",
-		Detector:    techniques.DetectForcingChain,
-		Enabled:     true,
-		Order:       39,
-	})
+// registerTechniques installs every manifest entry into the registry
+func (r *TechniqueRegistry) registerTechniques() {
+	for _, desc := range techniqueManifest() {
+		r.register(desc)
+	}
 }
 
 // register adds a technique to the registry
 func (r *TechniqueRegistry) register(desc TechniqueDescriptor) {
+	// Default the documentation link to the technique's detail page
+	if desc.DocURL == "" {
+		desc.DocURL = "/technique/" + desc.Slug
+	}
+
+	// Calibrated weights, when loaded, replace the manifest's guessed score
+	if override, ok := scoreOverrides[desc.Slug]; ok {
+		desc.Score = override
+	}
+
 	// Store a copy of the descriptor in the map
 	r.techniques[desc.Slug] = &desc
 
@@ -510,9 +734,23 @@ func (r *TechniqueRegistry) GetByTier(tier string) []TechniqueDescriptor {
 	return result
 }
 
-// GetBySlug returns a technique by its slug
+// slugAliases maps alternate community names onto the canonical slug, so
+// clients can use either without the registry double-counting the technique
+var slugAliases = map[string]string{
+	"claiming": "box-line-reduction",
+}
+
+// canonicalSlug resolves a possibly-aliased slug to its canonical form
+func canonicalSlug(slug string) string {
+	if canonical, ok := slugAliases[slug]; ok {
+		return canonical
+	}
+	return slug
+}
+
+// GetBySlug returns a technique by its slug, resolving aliases
 func (r *TechniqueRegistry) GetBySlug(slug string) *TechniqueDescriptor {
-	return r.techniques[slug]
+	return r.techniques[canonicalSlug(slug)]
 }
 
 // GetAll returns all techniques
@@ -524,15 +762,67 @@ func (r *TechniqueRegistry) GetAll() []TechniqueDescriptor {
 	return result
 }
 
-// SetEnabled enables or disables a technique by slug
+// SetEnabled enables or disables a technique by slug, resolving aliases
 func (r *TechniqueRegistry) SetEnabled(slug string, enabled bool) bool {
-	if tech := r.techniques[slug]; tech != nil {
+	if tech := r.techniques[canonicalSlug(slug)]; tech != nil {
 		tech.Enabled = enabled
 		return true
 	}
 	return false
 }
 
+// GetTechniqueInfos returns serializable metadata for all registered
+// techniques, sorted by pedagogical order
+func (r *TechniqueRegistry) GetTechniqueInfos() []TechniqueInfo {
+	all := r.GetAll()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Order < all[j].Order
+	})
+
+	infos := make([]TechniqueInfo, len(all))
+	for i, tech := range all {
+		infos[i] = TechniqueInfo{
+			Slug:        tech.Slug,
+			DisplayName: tech.Name,
+			Tier:        tech.Tier,
+			Description: tech.Description,
+			Score:       tech.Score,
+			DocURL:      tech.DocURL,
+			Enabled:     tech.Enabled,
+		}
+	}
+	return infos
+}
+
+// SimpleTechniqueSlugs returns the simple-tier slugs in pedagogical order.
+// They are the baseline set that per-technique stress runs (cmd/test_techniques)
+// keep enabled alongside the technique under test.
+func (r *TechniqueRegistry) SimpleTechniqueSlugs() []string {
+	return r.slugsInOrder(func(t TechniqueDescriptor) bool { return t.Tier == constants.TierSimple })
+}
+
+// AdvancedTechniqueSlugs returns every slug above the simple tier in
+// pedagogical order — the set cmd/test_techniques exercises one at a time.
+func (r *TechniqueRegistry) AdvancedTechniqueSlugs() []string {
+	return r.slugsInOrder(func(t TechniqueDescriptor) bool { return t.Tier != constants.TierSimple })
+}
+
+// slugsInOrder returns the slugs of all registered techniques matching keep,
+// sorted by pedagogical order
+func (r *TechniqueRegistry) slugsInOrder(keep func(TechniqueDescriptor) bool) []string {
+	all := r.GetAll()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Order < all[j].Order
+	})
+	var slugs []string
+	for _, tech := range all {
+		if keep(tech) {
+			slugs = append(slugs, tech.Slug)
+		}
+	}
+	return slugs
+}
+
 // GetEnabledTechniques returns all enabled techniques organized by tier
 func (r *TechniqueRegistry) GetEnabledTechniques() map[string][]TechniqueDescriptor {
 	result := make(map[string][]TechniqueDescriptor)
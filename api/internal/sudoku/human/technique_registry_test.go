@@ -54,6 +54,21 @@ func TestTechniqueRegistry_GetBySlug(t *testing.T) {
 	if unknown != nil {
 		t.Error("Should not find non-existent technique")
 	}
+
+	// Test alias resolution: claiming is box-line reduction
+	claiming := registry.GetBySlug("claiming")
+	if claiming == nil {
+		t.Fatal("Should resolve claiming alias")
+	}
+	if claiming.Slug != "box-line-reduction" {
+		t.Errorf("Expected claiming to resolve to box-line-reduction, got %s", claiming.Slug)
+	}
+	if !registry.SetEnabled("claiming", false) {
+		t.Error("SetEnabled should resolve the claiming alias")
+	}
+	if registry.GetBySlug("box-line-reduction").Enabled {
+		t.Error("Disabling via the alias should disable box-line-reduction")
+	}
 }
 
 func TestTechniqueRegistry_EnableDisable(t *testing.T) {
@@ -301,3 +316,76 @@ func TestGetTechniqueSlugsForTier(t *testing.T) {
 		}
 	}
 }
+
+func TestTechniqueRegistry_Metadata(t *testing.T) {
+	registry := NewTechniqueRegistry()
+
+	// Every registered technique must have complete display metadata
+	for _, tech := range registry.GetAll() {
+		if tech.Name == "" {
+			t.Errorf("Technique %s has empty display name", tech.Slug)
+		}
+		if tech.Tier == "" {
+			t.Errorf("Technique %s has empty tier", tech.Slug)
+		}
+		if tech.Score <= 0 {
+			t.Errorf("Technique %s has no difficulty score", tech.Slug)
+		}
+		if tech.DocURL != "/technique/"+tech.Slug {
+			t.Errorf("Technique %s has unexpected doc URL %s", tech.Slug, tech.DocURL)
+		}
+	}
+
+	// The slug set must match the techniques exercised by cmd/test_techniques
+	expectedSlugs := []string{
+		// Simple tier
+		"naked-single", "hidden-single", "naked-pair", "locked-pair", "hidden-pair",
+		"pointing-pair", "pointing-triple", "box-line-reduction", "naked-triple", "locked-triple", "hidden-triple",
+		// Medium tier
+		"naked-quad", "hidden-quad", "x-wing", "swordfish", "xy-wing",
+		"xyz-wing", "simple-coloring", "bug", "unique-rectangle",
+		// Hard tier
+		"jellyfish", "skyscraper", "2-string-kite", "turbot-fish", "x-chain", "xy-chain", "nice-loop",
+		"w-wing", "wxyz-wing", "empty-rectangle", "medusa-3d",
+		"unique-rectangle-type-2", "unique-rectangle-type-3", "unique-rectangle-type-4",
+		"unique-rectangle-type-5", "unique-rectangle-type-6", "hidden-rectangle",
+		// Extreme tier
+		"finned-x-wing", "finned-swordfish", "grouped-x-cycles", "aic",
+		"als-xz", "als-xy-wing", "als-xy-chain", "sue-de-coq",
+		"death-blossom", "digit-forcing-chain", "forcing-chain",
+	}
+
+	registered := make(map[string]bool)
+	for _, tech := range registry.GetAll() {
+		registered[tech.Slug] = true
+	}
+
+	for _, slug := range expectedSlugs {
+		if !registered[slug] {
+			t.Errorf("Expected technique %s is not registered", slug)
+		}
+	}
+	if len(registered) != len(expectedSlugs) {
+		t.Errorf("Registry has %d techniques, expected %d", len(registered), len(expectedSlugs))
+	}
+}
+
+func TestTechniqueRegistry_GetTechniqueInfos(t *testing.T) {
+	registry := NewTechniqueRegistry()
+	infos := registry.GetTechniqueInfos()
+
+	if len(infos) != len(registry.GetAll()) {
+		t.Fatalf("Expected info for all techniques, got %d", len(infos))
+	}
+
+	// Infos should be sorted by pedagogical order: naked-single first
+	if infos[0].Slug != "naked-single" {
+		t.Errorf("Expected naked-single first, got %s", infos[0].Slug)
+	}
+
+	for _, info := range infos {
+		if info.DisplayName == "" || info.Tier == "" || info.DocURL == "" {
+			t.Errorf("Technique %s has incomplete info: %+v", info.Slug, info)
+		}
+	}
+}
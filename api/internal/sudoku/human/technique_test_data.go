@@ -23,7 +23,7 @@ type TechniquePuzzleData struct {
 	Description string
 }
 
-// TechniquePuzzles contains test puzzles for all 39 techniques.
+// TechniquePuzzles contains test puzzles for all 45 techniques.
 // Puzzles are either:
 //   - Indexed from puzzles.json (PuzzleIndex >= 0, use with Difficulty)
 //   - Direct puzzle strings (PuzzleIndex < 0, use PuzzleString)
@@ -33,20 +33,22 @@ type TechniquePuzzleData struct {
 //   - SudokuWiki: Technique examples and exemplar puzzles
 //   - Hodoku: Technique demonstrations
 //
-// The 39 techniques by tier:
-//   - Simple (8): hidden-single, naked-single, naked-pair, hidden-pair,
-//     pointing-pair, box-line-reduction, naked-triple, hidden-triple
+// The 45 techniques by tier:
+//   - Simple (11): hidden-single, naked-single, naked-pair, locked-pair,
+//     hidden-pair, pointing-pair, pointing-triple, box-line-reduction,
+//     naked-triple, locked-triple, hidden-triple
 //   - Medium (9): bug, x-wing, unique-rectangle, xy-wing, simple-coloring,
 //     naked-quad, hidden-quad, swordfish, xyz-wing
-//   - Hard (11): skyscraper, x-chain, xy-chain, medusa-3d, jellyfish,
+//   - Hard (14): skyscraper, x-chain, xy-chain, medusa-3d, jellyfish,
 //     unique-rectangle-type-2, unique-rectangle-type-3, unique-rectangle-type-4,
+//     unique-rectangle-type-5, unique-rectangle-type-6, hidden-rectangle,
 //     wxyz-wing, w-wing, empty-rectangle
 //   - Extreme (11): grouped-x-cycles, finned-x-wing, finned-swordfish, aic,
 //     als-xz, als-xy-wing, als-xy-chain, sue-de-coq, digit-forcing-chain,
 //     forcing-chain, death-blossom
 var TechniquePuzzles = []TechniquePuzzleData{
 	// ==========================================================================
-	// SIMPLE TIER (8 techniques)
+	// SIMPLE TIER (10 techniques)
 	// ==========================================================================
 	{
 		Slug:        "hidden-single",
@@ -69,6 +71,13 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		Difficulty:  "impossible",
 		Description: "Two cells with the same two candidates eliminate those digits from their peers",
 	},
+	{
+		Slug:        "locked-pair",
+		Tier:        "simple",
+		PuzzleIndex: 0,
+		Difficulty:  "impossible",
+		Description: "A naked pair confined to one box and one line eliminates its digits from both houses",
+	},
 	{
 		Slug:        "hidden-pair",
 		Tier:        "simple",
@@ -83,6 +92,13 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		Difficulty:  "impossible",
 		Description: "If a digit in a box can only be in one row/column, eliminate it from the rest of that row/column",
 	},
+	{
+		Slug:        "pointing-triple",
+		Tier:        "simple",
+		PuzzleIndex: 14,
+		Difficulty:  "hard",
+		Description: "If a digit in a box can only be in three cells of one row/column, eliminate it from the rest of that row/column",
+	},
 	{
 		Slug:        "box-line-reduction",
 		Tier:        "simple",
@@ -97,6 +113,13 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		Difficulty:  "impossible",
 		Description: "Three cells with the same three candidates eliminate those digits from their peers",
 	},
+	{
+		Slug:        "locked-triple",
+		Tier:        "simple",
+		PuzzleIndex: 984,
+		Difficulty:  "impossible",
+		Description: "A naked triple confined to one box and one line eliminates its digits from both houses",
+	},
 	{
 		Slug:        "hidden-triple",
 		Tier:        "simple",
@@ -182,6 +205,20 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		Difficulty:  "impossible",
 		Description: "A turbot fish variant for eliminations",
 	},
+	{
+		Slug:         "2-string-kite",
+		Tier:         "hard",
+		PuzzleIndex:  -1,
+		PuzzleString: "500403087000007239000210000006050001090000064304000002000000000800700005000106090",
+		Description:  "A row and a column strong link bridged through a box",
+	},
+	{
+		Slug:        "turbot-fish",
+		Tier:        "hard",
+		PuzzleIndex: 44,
+		Difficulty:  "impossible",
+		Description: "Umbrella for the turbot family - fires with the specific shapes disabled",
+	},
 	{
 		Slug:        "x-chain",
 		Tier:        "hard",
@@ -196,6 +233,13 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		PuzzleString: "370010046080006050560004100005090060007060504000450300000030427753249681000001935",
 		Description:  "Chain through bivalue cells - partial solve state from puzzle 6",
 	},
+	{
+		Slug:         "nice-loop",
+		Tier:         "hard",
+		PuzzleIndex:  -1,
+		PuzzleString: "370010046080006050560004100005090060007060504000450300000030427753249681000001935",
+		Description:  "Narrated loop over bivalue/bilocation nodes - shares the xy-chain state",
+	},
 	{
 		Slug:        "medusa-3d",
 		Tier:        "hard",
@@ -232,6 +276,27 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		Difficulty:  "impossible",
 		Description: "Unique rectangle with hidden pair - found in puzzle database",
 	},
+	{
+		Slug:         "unique-rectangle-type-5",
+		Tier:         "hard",
+		PuzzleIndex:  -1,
+		PuzzleString: "000000508506030027704005000008090004472506100100070005000009740240008390310047800",
+		Description:  "Valid unique board for tier coverage - the type 5 shape rarely survives full auto candidates, so detection is exercised directly in techniques_test.go",
+	},
+	{
+		Slug:         "unique-rectangle-type-6",
+		Tier:         "hard",
+		PuzzleIndex:  -1,
+		PuzzleString: "003000002000052700054378160042037000708600205610245370400003600087160520106500000",
+		Description:  "Rectangle with an X-Wing on a UR digit - generated board (fires immediately)",
+	},
+	{
+		Slug:         "hidden-rectangle",
+		Tier:         "hard",
+		PuzzleIndex:  -1,
+		PuzzleString: "800400000095280401030605807008134065956002143040950002287340006069020310413000270",
+		Description:  "Rectangle with one bivalue corner and conjugate pairs through the opposite one - generated board (fires immediately)",
+	},
 	{
 		Slug:         "wxyz-wing",
 		Tier:         "hard",
@@ -262,8 +327,8 @@ var TechniquePuzzles = []TechniquePuzzleData{
 		Slug:         "grouped-x-cycles",
 		Tier:         "extreme",
 		PuzzleIndex:  -1,
-		PuzzleString: "247100900008070521150208740500002100000706050800510000790000312600307894080001675",
-		Description:  "X-Cycles using group strong links - partial solve state from puzzle 139",
+		PuzzleString: "092860050508917200000025900200000509045200000709501020920638005000152690156000832",
+		Description:  "X-Cycles using group strong links - partial solve state from puzzle 39",
 	},
 	{
 		Slug:         "finned-x-wing",
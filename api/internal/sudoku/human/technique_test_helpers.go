@@ -1,6 +1,8 @@
 package human
 
 import (
+	"fmt"
+
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -272,28 +274,7 @@ func CreateSolverWithTierOnly(tier string) *Solver {
 //	solver := CreateSolverUpToTier("medium") // simple + medium techniques only
 //	moves, status := solver.SolveWithSteps(board, 200)
 func CreateSolverUpToTier(maxTier string) *Solver {
-	registry := NewTechniqueRegistry()
-
-	tierOrder := map[string]int{
-		constants.TierSimple:  0,
-		constants.TierMedium:  1,
-		constants.TierHard:    2,
-		constants.TierExtreme: 3,
-	}
-
-	maxTierOrder, ok := tierOrder[maxTier]
-	if !ok {
-		return NewSolver() // fallback if invalid tier
-	}
-
-	for _, tech := range registry.GetAll() {
-		techTierOrder := tierOrder[tech.Tier]
-		if techTierOrder > maxTierOrder {
-			registry.SetEnabled(tech.Slug, false)
-		}
-	}
-
-	return NewSolverWithRegistry(registry)
+	return NewSolverUpToTier(maxTier)
 }
 
 // CreateSolverWithoutTechniques creates a solver with specific techniques disabled.
@@ -367,3 +348,33 @@ func GetTechniqueSlugsForTier(tier string) []string {
 	}
 	return slugs
 }
+
+// WrapDetectorWithSnapshot wraps a detector so every call verifies that
+// detection left the board untouched. Detectors must be pure - ApplyMove is
+// the only code allowed to mutate a board - so any diff reported here is a
+// bug in the detector. Returns the detected move plus an error naming the
+// first mutated cell.
+func WrapDetectorWithSnapshot(detector func(BoardInterface) *core.Move) func(*Board) (*core.Move, error) {
+	return func(b *Board) (*core.Move, error) {
+		before := *b
+		move := detector(b)
+		return move, diffBoardSnapshot(&before, b)
+	}
+}
+
+// diffBoardSnapshot reports the first difference between a board snapshot
+// and the board's current state, or nil when they are bit-identical
+func diffBoardSnapshot(before, after *Board) error {
+	for i := 0; i < constants.TotalCells; i++ {
+		if before.Cells[i] != after.Cells[i] {
+			return fmt.Errorf("cell %d changed from %d to %d", i, before.Cells[i], after.Cells[i])
+		}
+		if before.Candidates[i] != after.Candidates[i] {
+			return fmt.Errorf("candidates at cell %d changed from %s to %s", i, before.Candidates[i], after.Candidates[i])
+		}
+		if before.Eliminated[i] != after.Eliminated[i] {
+			return fmt.Errorf("eliminations at cell %d changed from %s to %s", i, before.Eliminated[i], after.Eliminated[i])
+		}
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
 package techniques
 
 import (
-	"fmt"
+	"sort"
 
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
@@ -92,19 +92,22 @@ func DetectALSXYWing(b BoardInterface) *core.Move {
 
 							if len(eliminations) > 0 {
 								targets := buildTargets(alsA.Cells, alsB.Cells, alsC.Cells)
-								return &core.Move{
+								return explained(&core.Move{
 									Action:       "eliminate",
 									Digit:        z,
 									Targets:      targets,
 									Eliminations: eliminations,
-									Explanation: fmt.Sprintf(
-										"ALS-XY-Wing: A=%s, B=%s, C=%s; RC(A-B)=%d, RC(A-C)=%d; eliminate %d",
-										FormatCells(alsA.Cells), FormatCells(alsB.Cells), FormatCells(alsC.Cells),
-										x, y, z),
 									Highlights: core.Highlights{
 										Primary: targets,
 									},
-								}
+								}, "technique.als-xy-wing", map[string]interface{}{
+									"alsA": FormatCells(alsA.Cells),
+									"alsB": FormatCells(alsB.Cells),
+									"alsC": FormatCells(alsC.Cells),
+									"x":    x,
+									"y":    y,
+									"z":    z,
+								})
 							}
 						}
 					}
@@ -195,11 +198,18 @@ func searchALSChain(b BoardInterface, allALS []ALS, adjRC map[int]map[int][]int,
 			continue
 		}
 
-		// Extend the chain
-		for nextIdx, rcs := range adjRC[currALSIdx] {
-			if curr.visited[nextIdx] {
-				continue
+		// Extend the chain. Neighbors are sorted because map iteration
+		// order is random and the first conclusive chain wins
+		var nextIdxs []int
+		for nextIdx := range adjRC[currALSIdx] {
+			if !curr.visited[nextIdx] {
+				nextIdxs = append(nextIdxs, nextIdx)
 			}
+		}
+		sort.Ints(nextIdxs)
+
+		for _, nextIdx := range nextIdxs {
+			rcs := adjRC[currALSIdx][nextIdx]
 
 			// The RC digit must be different from the last used RC (if any)
 			for _, rc := range rcs {
@@ -291,18 +301,19 @@ func checkChainElimination(b BoardInterface, allALS []ALS, path []int, rcUsed []
 				targets = append(targets, core.CellRef{Row: cell / constants.GridSize, Col: cell % constants.GridSize})
 			}
 
-			return &core.Move{
+			return explained(&core.Move{
 				Action:       "eliminate",
 				Digit:        z,
 				Targets:      targets,
 				Eliminations: eliminations,
-				Explanation: fmt.Sprintf(
-					"ALS-XY-Chain: %d ALS linked by RCs %v; eliminate %d",
-					len(path), rcUsed, z),
 				Highlights: core.Highlights{
 					Primary: targets,
 				},
-			}
+			}, "technique.als-xy-chain", map[string]interface{}{
+				"length": len(path),
+				"rcs":    rcUsed,
+				"z":      z,
+			})
 		}
 	}
 
@@ -362,3 +373,83 @@ func buildTargets(cellGroups ...[]int) []core.CellRef {
 	}
 	return CellRefsFromIndices(allCells...)
 }
+
+// DetectALSXZ finds ALS-XZ pattern:
+// - Two ALS (A and B) that share a "restricted common" digit X
+// - X appears in both ALS, and all cells containing X in A see all cells containing X in B
+// - Both ALS share another digit Z
+// - Eliminate Z from cells that see all Z-cells in both ALS
+func DetectALSXZ(b BoardInterface) *core.Move {
+	allALS := FindAllALS(b, 4)
+
+	// Try all pairs of ALS
+	for i := 0; i < len(allALS); i++ {
+		for j := i + 1; j < len(allALS); j++ {
+			alsA := allALS[i]
+			alsB := allALS[j]
+
+			// ALS must not share any cells
+			if ALSShareCells(alsA, alsB) {
+				continue
+			}
+
+			// Find common digits between the two ALS
+			commonDigits := IntersectInts(alsA.Digits, alsB.Digits)
+			if len(commonDigits) < 2 {
+				continue // Need at least X (restricted common) and Z (elimination digit)
+			}
+
+			// Try each pair of common digits as (X, Z)
+			for _, x := range commonDigits {
+				// Check if X is a restricted common:
+				// All cells with X in A must see all cells with X in B
+				xCellsA := alsA.ByDigit[x]
+				xCellsB := alsB.ByDigit[x]
+
+				if !AllSeeAll(xCellsA, xCellsB) {
+					continue
+				}
+
+				// For each other common digit Z, try to find eliminations
+				for _, z := range commonDigits {
+					if z == x {
+						continue
+					}
+
+					zCellsA := alsA.ByDigit[z]
+					zCellsB := alsB.ByDigit[z]
+
+					// Build exclusion set (all cells in either ALS)
+					exclude := append(alsA.Cells, alsB.Cells...)
+					// Combine Z cells from both ALS
+					allZCells := append(zCellsA, zCellsB...)
+
+					// Find cells that see ALL Z-cells in both ALS
+					eliminations := FindEliminationsSeeing(b, z, exclude, allZCells...)
+
+					if len(eliminations) > 0 {
+						// Build targets from both ALS cells
+						targets := CellRefsFromIndices(append(alsA.Cells, alsB.Cells...)...)
+
+						return explained(&core.Move{
+							Action:       "eliminate",
+							Digit:        z,
+							Targets:      targets,
+							Eliminations: eliminations,
+							Highlights: core.Highlights{
+								Primary: targets,
+							},
+						}, "technique.als-xz", map[string]interface{}{
+							"alsA": FormatCells(alsA.Cells),
+							"alsB": FormatCells(alsB.Cells),
+							"x":    x,
+							"z":    z,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -276,22 +274,21 @@ func findBlossomEliminations(b BoardInterface, stem int, petals []ALS, z int, st
 	secondary := CellRefsFromIndices(petalCells...)
 	targets = append(targets, secondary...)
 
-	// Build explanation
-	stemRow, stemCol := stem/constants.GridSize, stem%constants.GridSize
-	explanation := fmt.Sprintf("Death Blossom: stem R%dC%d {%v} with %d petals; eliminate %d",
-		stemRow+1, stemCol+1, FormatDigits(stemCands), len(petals), z)
-
-	return &core.Move{
+	return explained(&core.Move{
 		Action:       "eliminate",
 		Digit:        z,
 		Targets:      targets,
 		Eliminations: eliminations,
-		Explanation:  explanation,
 		Highlights: core.Highlights{
 			Primary:   primary,
 			Secondary: secondary,
 		},
-	}
+	}, "technique.death-blossom", map[string]interface{}{
+		"stem":       FormatCell(stem),
+		"stemDigits": stemCands,
+		"petals":     len(petals),
+		"z":          z,
+	})
 }
 
 // DEBUG: Add tracing to understand petal selection
@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -81,17 +79,87 @@ func DetectBUG(b BoardInterface) *core.Move {
 
 		// If this digit appears 3 times in row, col, or box, it's the BUG digit
 		if rowCount == 3 || colCount == 3 || boxCount == 3 {
-			return &core.Move{
-				Action:      "assign",
-				Digit:       digit,
-				Targets:     []core.CellRef{{Row: row, Col: col}},
-				Explanation: fmt.Sprintf("BUG+1: All other cells are bi-value; R%dC%d must be %d to avoid multiple solutions", row+1, col+1, digit),
+			// Verify the full BUG condition before assigning: with the extra
+			// digit removed from the BUG+1 cell, every remaining candidate
+			// must occur exactly twice in each of its units. Otherwise the
+			// board only looks locally like a BUG and the assignment is not
+			// forced.
+			if !isBUGWithoutExtra(b, bugCell, digit) {
+				continue
+			}
+			return withTargetCandidates(b, explained(&core.Move{
+				Action:  "assign",
+				Digit:   digit,
+				Targets: []core.CellRef{{Row: row, Col: col}},
 				Highlights: core.Highlights{
 					Primary: []core.CellRef{{Row: row, Col: col}},
 				},
-			}
+			}, "technique.bug", map[string]interface{}{
+				"cell":  FormatCell(bugCell),
+				"digit": digit,
+			}))
 		}
 	}
 
 	return nil
 }
+
+// isBUGWithoutExtra checks whether removing extraDigit from the BUG+1 cell
+// leaves a true BUG state: every candidate occurs exactly zero or two times
+// in every row, column, and box. All unsolved cells are already known to be
+// bi-value at this point (the BUG cell becomes bi-value once reduced).
+func isBUGWithoutExtra(b BoardInterface, bugCell, extraDigit int) bool {
+	hasCand := func(idx, digit int) bool {
+		if idx == bugCell && digit == extraDigit {
+			return false
+		}
+		return b.GetCandidatesAt(idx).Has(digit)
+	}
+
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		// Rows
+		for r := 0; r < constants.GridSize; r++ {
+			count := 0
+			for c := 0; c < constants.GridSize; c++ {
+				if hasCand(r*constants.GridSize+c, digit) {
+					count++
+				}
+			}
+			if count != 0 && count != 2 {
+				return false
+			}
+		}
+
+		// Columns
+		for c := 0; c < constants.GridSize; c++ {
+			count := 0
+			for r := 0; r < constants.GridSize; r++ {
+				if hasCand(r*constants.GridSize+c, digit) {
+					count++
+				}
+			}
+			if count != 0 && count != 2 {
+				return false
+			}
+		}
+
+		// Boxes
+		for boxRow := 0; boxRow < constants.GridSize; boxRow += constants.BoxSize {
+			for boxCol := 0; boxCol < constants.GridSize; boxCol += constants.BoxSize {
+				count := 0
+				for r := boxRow; r < boxRow+constants.BoxSize; r++ {
+					for c := boxCol; c < boxCol+constants.BoxSize; c++ {
+						if hasCand(r*constants.GridSize+c, digit) {
+							count++
+						}
+					}
+				}
+				if count != 0 && count != 2 {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,359 @@
+package techniques
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// AIC Chain Engine
+// ============================================================================
+//
+// A single Alternating Inference Chain engine shared by the chain-family
+// detectors. It builds a link graph over candidate nodes (cell, digit):
+//
+//   strong links: conjugate pairs (only two places for a digit in a unit)
+//                 and bivalue cells (only two digits in a cell)
+//   weak links:   same digit in peer cells, or two digits in the same cell
+//
+// and searches for chains that alternate strong, weak, strong, ... ending on
+// a strong link. A completed chain proves "start is true OR end is true", so
+// any candidate weakly linked to BOTH endpoints can be eliminated
+// (discontinuous nice loop). When the end also links weakly back to the
+// start, the chain closes into a continuous nice loop and every weak link in
+// the loop eliminates the candidates it covers.
+//
+// X-Chain, XY-Chain, and AIC are thin wrappers that restrict the node set
+// (single digit, bivalue cells only, or unrestricted). Every elimination is
+// re-verified against the endpoints before it is emitted, so the wrappers
+// cannot produce contradictions the links don't support.
+
+// AICMaxNodes bounds the chain search depth in candidate nodes. Longer chains
+// exist but are rarely human-findable and the search cost grows quickly.
+var AICMaxNodes = 12
+
+// chainEngine holds the candidate link graph for one board state
+type chainEngine struct {
+	b      BoardInterface
+	nodes  []candidateNode
+	member map[candidateNode]bool
+	strong map[candidateNode][]candidateNode
+	weak   map[candidateNode][]candidateNode
+}
+
+// chainResult is a completed chain with its verified eliminations
+type chainResult struct {
+	nodes []candidateNode
+	elims []core.Candidate
+	loop  bool // true when the chain closes into a continuous nice loop
+}
+
+// newChainEngine builds the link graph over all candidate nodes accepted by
+// include (nil accepts every candidate). Construction order is deterministic:
+// cells ascending, digits ascending
+func newChainEngine(b BoardInterface, include func(cell, digit int) bool) *chainEngine {
+	e := &chainEngine{
+		b:      b,
+		member: make(map[candidateNode]bool),
+		strong: make(map[candidateNode][]candidateNode),
+		weak:   make(map[candidateNode][]candidateNode),
+	}
+
+	for cell := 0; cell < constants.TotalCells; cell++ {
+		if b.GetCell(cell) != 0 {
+			continue
+		}
+		for _, digit := range b.GetCandidatesAt(cell).ToSlice() {
+			if include != nil && !include(cell, digit) {
+				continue
+			}
+			n := candidateNode{cell: cell, digit: digit}
+			e.nodes = append(e.nodes, n)
+			e.member[n] = true
+		}
+	}
+
+	// Strong links from conjugate pairs (only 2 places for digit in a unit)
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		for _, unit := range AllUnits() {
+			cells := b.CellsWithDigitInUnit(unit, digit)
+			if len(cells) != 2 {
+				continue
+			}
+			n1 := candidateNode{cell: cells[0], digit: digit}
+			n2 := candidateNode{cell: cells[1], digit: digit}
+			if e.member[n1] && e.member[n2] {
+				e.addStrong(n1, n2)
+			}
+		}
+	}
+
+	// Strong links from bivalue cells (only 2 digits in a cell)
+	for cell := 0; cell < constants.TotalCells; cell++ {
+		if b.GetCell(cell) != 0 {
+			continue
+		}
+		cands := b.GetCandidatesAt(cell).ToSlice()
+		if len(cands) != 2 {
+			continue
+		}
+		n1 := candidateNode{cell: cell, digit: cands[0]}
+		n2 := candidateNode{cell: cell, digit: cands[1]}
+		if e.member[n1] && e.member[n2] {
+			e.addStrong(n1, n2)
+		}
+	}
+
+	// Weak links: same digit in peer cells. Built per node pair so strong
+	// links are also walkable as weak, as AIC logic requires
+	for i := 0; i < len(e.nodes); i++ {
+		for j := i + 1; j < len(e.nodes); j++ {
+			n1, n2 := e.nodes[i], e.nodes[j]
+			if weakLinked(n1, n2) {
+				e.weak[n1] = append(e.weak[n1], n2)
+				e.weak[n2] = append(e.weak[n2], n1)
+			}
+		}
+	}
+
+	return e
+}
+
+// addStrong records a strong link in both directions, skipping duplicates
+// (a conjugate pair can appear in more than one unit)
+func (e *chainEngine) addStrong(n1, n2 candidateNode) {
+	if containsNode(e.strong[n1], n2) {
+		return
+	}
+	e.strong[n1] = append(e.strong[n1], n2)
+	e.strong[n2] = append(e.strong[n2], n1)
+}
+
+// weakLinked reports whether two candidate nodes cannot both be true: the
+// same digit in peer cells, or different digits in the same cell
+func weakLinked(n1, n2 candidateNode) bool {
+	if n1.cell == n2.cell {
+		return n1.digit != n2.digit
+	}
+	return n1.digit == n2.digit && ArePeers(n1.cell, n2.cell)
+}
+
+// chainSearch configures one engine search
+type chainSearch struct {
+	maxNodes      int  // node budget per chain
+	sameDigitEnds bool // require both endpoints to carry the same digit
+	allowLoops    bool // also report continuous nice loops
+	// loopsFirst concludes a closed chain as a continuous loop before trying
+	// the discontinuous reading. The loop eliminations of a closed chain are a
+	// superset of its two ends' (the closure link is one of its weak links),
+	// so detectors that want loops reported as loops set this; the default
+	// keeps the discontinuous reading AIC historically reported
+	loopsFirst bool
+}
+
+// findBestChain searches from every node and returns the shortest chain with
+// at least one verified elimination, or nil
+func (e *chainEngine) findBestChain(cfg chainSearch) *chainResult {
+	var best *chainResult
+	for _, start := range e.nodes {
+		result := e.searchFrom(start, cfg)
+		if result == nil {
+			continue
+		}
+		if best == nil || len(result.nodes) < len(best.nodes) {
+			best = result
+		}
+	}
+	return best
+}
+
+// searchFrom runs a breadth-first alternating search from start, so the first
+// chain found with an elimination is also the shortest from that node. Chains
+// begin and end on strong links; parity tracks which link type extends next
+func (e *chainEngine) searchFrom(start candidateNode, cfg chainSearch) *chainResult {
+	type state struct {
+		node       candidateNode
+		strongNext bool
+	}
+	type item struct {
+		node  candidateNode
+		chain []candidateNode
+		// strongNext is true when the next link must be strong
+		strongNext bool
+	}
+
+	visited := map[state]bool{{start, true}: true}
+	queue := []item{{node: start, chain: []candidateNode{start}, strongNext: true}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		// A chain whose last link was strong (so a weak link would extend it
+		// next) has an odd link count and can be scored
+		if len(cur.chain) >= 4 && !cur.strongNext {
+			if result := e.concludeChain(cur.chain, cfg); result != nil {
+				return result
+			}
+		}
+
+		if len(cur.chain) >= cfg.maxNodes {
+			continue
+		}
+
+		links := e.weak[cur.node]
+		if cur.strongNext {
+			links = e.strong[cur.node]
+		}
+		for _, next := range links {
+			st := state{next, !cur.strongNext}
+			if visited[st] || containsNode(cur.chain, next) {
+				continue
+			}
+			visited[st] = true
+
+			chain := make([]candidateNode, len(cur.chain)+1)
+			copy(chain, cur.chain)
+			chain[len(cur.chain)] = next
+			queue = append(queue, item{node: next, chain: chain, strongNext: !cur.strongNext})
+		}
+	}
+
+	return nil
+}
+
+// concludeChain verifies a completed chain and collects its eliminations
+func (e *chainEngine) concludeChain(chain []candidateNode, cfg chainSearch) *chainResult {
+	head, tail := chain[0], chain[len(chain)-1]
+	if cfg.sameDigitEnds && head.digit != tail.digit {
+		return nil
+	}
+
+	if cfg.loopsFirst {
+		if result := e.concludeLoop(chain, cfg); result != nil {
+			return result
+		}
+	}
+
+	// Discontinuous: anything weakly linked to both endpoints is false
+	elims := e.eliminationsSeeing(chain, head, tail)
+	if len(elims) > 0 {
+		return &chainResult{nodes: chain, elims: elims}
+	}
+
+	if !cfg.loopsFirst {
+		if result := e.concludeLoop(chain, cfg); result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// concludeLoop reports a chain whose end links weakly back to its start as a
+// continuous nice loop: exactly one endpoint of every weak link around the
+// loop is true, so each weak link eliminates the candidates it covers
+func (e *chainEngine) concludeLoop(chain []candidateNode, cfg chainSearch) *chainResult {
+	head, tail := chain[0], chain[len(chain)-1]
+	if !cfg.allowLoops || !weakLinked(head, tail) {
+		return nil
+	}
+
+	var loopElims []core.Candidate
+	for i := 1; i+1 < len(chain); i += 2 {
+		loopElims = append(loopElims, e.eliminationsSeeing(chain, chain[i], chain[i+1])...)
+	}
+	loopElims = append(loopElims, e.eliminationsSeeing(chain, tail, head)...)
+	if len(loopElims) > 0 {
+		return &chainResult{nodes: chain, elims: dedupeCandidates(loopElims), loop: true}
+	}
+	return nil
+}
+
+// eliminationsSeeing returns every candidate outside the chain that is weakly
+// linked to both n1 and n2. This is the verification step: each elimination
+// is re-checked against the required endpoints before being emitted
+func (e *chainEngine) eliminationsSeeing(chain []candidateNode, n1, n2 candidateNode) []core.Candidate {
+	var elims []core.Candidate
+	for cell := 0; cell < constants.TotalCells; cell++ {
+		if e.b.GetCell(cell) != 0 {
+			continue
+		}
+		for _, digit := range e.b.GetCandidatesAt(cell).ToSlice() {
+			n := candidateNode{cell: cell, digit: digit}
+			if containsNode(chain, n) {
+				continue
+			}
+			if weakLinked(n, n1) && weakLinked(n, n2) {
+				elims = append(elims, core.Candidate{
+					Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit,
+				})
+			}
+		}
+	}
+	return elims
+}
+
+// dedupeCandidates removes duplicate eliminations while preserving order
+func dedupeCandidates(cands []core.Candidate) []core.Candidate {
+	seen := make(map[core.Candidate]bool, len(cands))
+	result := cands[:0]
+	for _, c := range cands {
+		if !seen[c] {
+			seen[c] = true
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// chainCells collapses a node chain to its cell path, merging consecutive
+// nodes in the same cell (in-cell strong links)
+func chainCells(chain []candidateNode) []int {
+	var cells []int
+	for _, n := range chain {
+		if len(cells) == 0 || cells[len(cells)-1] != n.cell {
+			cells = append(cells, n.cell)
+		}
+	}
+	return cells
+}
+
+// chainEliminationMove assembles the Move for a completed chain: targets and
+// primary highlights are the chain cells, secondary highlights the
+// elimination cells, and ChainLinks the consecutive cell pairs for the UI
+func chainEliminationMove(result *chainResult, key string, params map[string]interface{}) *core.Move {
+	cells := chainCells(result.nodes)
+
+	targets := make([]core.CellRef, len(cells))
+	for i, c := range cells {
+		targets[i] = core.CellRef{Row: c / constants.GridSize, Col: c % constants.GridSize}
+	}
+
+	links := make([]core.CellRef, 0, 2*(len(cells)-1))
+	for i := 0; i+1 < len(cells); i++ {
+		links = append(links, targets[i], targets[i+1])
+	}
+
+	seen := make(map[int]bool)
+	var secondary []core.CellRef
+	for _, elim := range result.elims {
+		idx := elim.Row*constants.GridSize + elim.Col
+		if !seen[idx] {
+			seen[idx] = true
+			secondary = append(secondary, core.CellRef{Row: elim.Row, Col: elim.Col})
+		}
+	}
+
+	return explained(&core.Move{
+		Action:       "eliminate",
+		Digit:        result.elims[0].Digit,
+		Targets:      targets,
+		Eliminations: result.elims,
+		Highlights: core.Highlights{
+			Primary:   targets,
+			Secondary: secondary,
+		},
+		ChainLinks: links,
+	}, key, params)
+}
@@ -1,452 +1,74 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
 
-// DetectJellyfish finds Jellyfish pattern: 4 rows where a digit appears in 2-4 positions,
-// and those positions share exactly 4 columns (or vice versa).
-func DetectJellyfish(b BoardInterface) *core.Move {
-	for digit := 1; digit <= constants.GridSize; digit++ {
-		if move := detectJellyfishInDirection(b, digit, UnitRow); move != nil {
-			return move
-		}
-		if move := detectJellyfishInDirection(b, digit, UnitCol); move != nil {
-			return move
-		}
-	}
-	return nil
-}
-
-// detectJellyfishInDirection finds Jellyfish in the specified direction (rows or columns)
-func detectJellyfishInDirection(b BoardInterface, digit int, dir UnitType) *core.Move {
-	// Build map of primary unit -> secondary positions where digit appears
-	unitPositions := make(map[int][]int)
-	for primary := 0; primary < constants.GridSize; primary++ {
-		var secondaries []int
-		for secondary := 0; secondary < constants.GridSize; secondary++ {
-			idx := cellIndexForDirection(dir, primary, secondary)
-			if b.GetCandidatesAt(idx).Has(digit) {
-				secondaries = append(secondaries, secondary)
-			}
-		}
-		if len(secondaries) >= 2 && len(secondaries) <= 4 {
-			unitPositions[primary] = secondaries
-		}
-	}
-
-	var units []int
-	for unit := range unitPositions {
-		units = append(units, unit)
-	}
-
-	if len(units) < 4 {
-		return nil
-	}
-
-	// Try all combinations of 4 units
-	for i := 0; i < len(units); i++ {
-		for j := i + 1; j < len(units); j++ {
-			for k := j + 1; k < len(units); k++ {
-				for l := k + 1; l < len(units); l++ {
-					u1, u2, u3, u4 := units[i], units[j], units[k], units[l]
-
-					// Collect all secondary positions from these 4 units
-					secondarySet := make(map[int]bool)
-					for _, s := range unitPositions[u1] {
-						secondarySet[s] = true
-					}
-					for _, s := range unitPositions[u2] {
-						secondarySet[s] = true
-					}
-					for _, s := range unitPositions[u3] {
-						secondarySet[s] = true
-					}
-					for _, s := range unitPositions[u4] {
-						secondarySet[s] = true
-					}
-
-					if len(secondarySet) != 4 {
-						continue
-					}
-
-					var secondaries []int
-					for s := range secondarySet {
-						secondaries = append(secondaries, s)
-					}
-
-					// Find eliminations in secondary lines outside the 4 primary units
-					var eliminations []core.Candidate
-					for _, sec := range secondaries {
-						for pri := 0; pri < constants.GridSize; pri++ {
-							if pri == u1 || pri == u2 || pri == u3 || pri == u4 {
-								continue
-							}
-							idx := cellIndexForDirection(dir, pri, sec)
-							if b.GetCandidatesAt(idx).Has(digit) {
-								row, col := cellCoordsForDirection(dir, pri, sec)
-								eliminations = append(eliminations, core.Candidate{
-									Row: row, Col: col, Digit: digit,
-								})
-							}
-						}
-					}
-
-					if len(eliminations) > 0 {
-						var targets []core.CellRef
-						for _, pri := range []int{u1, u2, u3, u4} {
-							for _, sec := range unitPositions[pri] {
-								row, col := cellCoordsForDirection(dir, pri, sec)
-								targets = append(targets, core.CellRef{Row: row, Col: col})
-							}
-						}
-
-						return &core.Move{
-							Action:       "eliminate",
-							Digit:        digit,
-							Targets:      targets,
-							Eliminations: eliminations,
-							Explanation:  fmt.Sprintf("Jellyfish: %d in %s %d,%d,%d,%d", digit, directionNamePlural(dir), u1+1, u2+1, u3+1, u4+1),
-							Highlights: core.Highlights{
-								Primary: targets,
-							},
-						}
-					}
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-// cellIndexForDirection returns the cell index given primary and secondary coordinates
-// For UnitRow: primary=row, secondary=col -> row*GridSize+col
-// For UnitCol: primary=col, secondary=row -> row*GridSize+col (note: secondary is row)
-func cellIndexForDirection(dir UnitType, primary, secondary int) int {
-	if dir == UnitRow {
-		return primary*constants.GridSize + secondary
-	}
-	return secondary*constants.GridSize + primary
-}
-
-// cellCoordsForDirection returns (row, col) given primary and secondary coordinates
-func cellCoordsForDirection(dir UnitType, primary, secondary int) (int, int) {
-	if dir == UnitRow {
-		return primary, secondary
-	}
-	return secondary, primary
-}
+// XChainMaxLinks bounds the X-Chain search depth in links. Longer chains
+// exist but are rarely human-findable, and grouped-x-cycles picks up the
+// heavier single-digit patterns later in the progression.
+var XChainMaxLinks = 6
 
-// directionNamePlural returns "rows" or "columns" for explanation strings
-func directionNamePlural(dir UnitType) string {
-	if dir == UnitRow {
-		return "rows"
-	}
-	return "columns"
-}
-
-// DetectXChain finds X-Chain pattern: a chain of conjugate pairs for a single digit
+// DetectXChain finds X-Chain pattern: an alternating chain for a single
+// digit, where strong links are conjugate pairs (no grouped nodes - those
+// belong to grouped-x-cycles). Implemented as a wrapper over the shared
+// chain engine with the node set restricted to one digit, which returns the
+// shortest qualifying chain within XChainMaxLinks links
 func DetectXChain(b BoardInterface) *core.Move {
 	for digit := 1; digit <= constants.GridSize; digit++ {
-		// Build conjugate pair graph
-		conjugates := buildConjugateGraph(b, digit)
-		if len(conjugates) == 0 {
+		d := digit
+		engine := newChainEngine(b, func(cell, dig int) bool { return dig == d })
+		result := engine.findBestChain(chainSearch{
+			maxNodes:      XChainMaxLinks + 1,
+			sameDigitEnds: true,
+		})
+		if result == nil {
 			continue
 		}
 
-		// Find chains of length 4+ (even length required for elimination)
-		for start := range conjugates {
-			if move := findXChainFrom(b, digit, start, conjugates); move != nil {
-				return move
-			}
-		}
+		cells := chainCells(result.nodes)
+		head, tail := cells[0], cells[len(cells)-1]
+		return chainEliminationMove(result, "technique.x-chain", map[string]interface{}{
+			"digit": digit,
+			"head":  FormatCell(head),
+			"tail":  FormatCell(tail),
+		})
 	}
 	return nil
 }
 
-func buildConjugateGraph(b BoardInterface, digit int) map[int][]int {
-	conjugates := make(map[int][]int)
-
-	// Check rows and columns using UnitType abstraction
-	for _, dir := range []UnitType{UnitRow, UnitCol} {
-		for primary := 0; primary < constants.GridSize; primary++ {
-			var cells []int
-			for secondary := 0; secondary < constants.GridSize; secondary++ {
-				idx := cellIndexForDirection(dir, primary, secondary)
-				if b.GetCandidatesAt(idx).Has(digit) {
-					cells = append(cells, idx)
-				}
-			}
-			if len(cells) == 2 {
-				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
-				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
-			}
-		}
-	}
-
-	// Check boxes
-	for box := 0; box < constants.GridSize; box++ {
-		var cells []int
-		boxRow, boxCol := (box/constants.BoxSize)*constants.BoxSize, (box%constants.BoxSize)*constants.BoxSize
-		for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-			for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-				if b.GetCandidatesAt(r*constants.GridSize + c).Has(digit) {
-					cells = append(cells, r*constants.GridSize+c)
-				}
-			}
-		}
-		if len(cells) == 2 {
-			conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
-			conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
-		}
-	}
-
-	return conjugates
-}
-
-func findXChainFrom(b BoardInterface, digit int, start int, conjugates map[int][]int) *core.Move {
-	// BFS to find chains
-	type chainNode struct {
-		cell  int
-		path  []int
-		color int // 0 = ON, 1 = OFF
-	}
-
-	visited := make(map[int]bool)
-	queue := []chainNode{{start, []int{start}, 0}}
-
-	for len(queue) > 0 {
-		node := queue[0]
-		queue = queue[1:]
-
-		if visited[node.cell] {
-			continue
-		}
-		visited[node.cell] = true
-
-		// Look for eliminations: cells that see both ends of an even-length chain
-		if len(node.path) >= 4 && len(node.path)%2 == 0 {
-			chainStart := node.path[0]
-			chainEnd := node.cell
-
-			for i := 0; i < constants.TotalCells; i++ {
-				if !b.GetCandidatesAt(i).Has(digit) {
-					continue
-				}
-				// Skip cells in the chain
-				inChain := false
-				for _, c := range node.path {
-					if i == c {
-						inChain = true
-						break
-					}
-				}
-				if inChain {
-					continue
-				}
-
-				// Must see both ends
-				if ArePeers(i, chainStart) && ArePeers(i, chainEnd) {
-					var targets []core.CellRef
-					for _, c := range node.path {
-						targets = append(targets, core.CellRef{Row: c / constants.GridSize, Col: c % constants.GridSize})
-					}
-
-					return &core.Move{
-						Action:  "eliminate",
-						Digit:   digit,
-						Targets: targets,
-						Eliminations: []core.Candidate{
-							{Row: i / constants.GridSize, Col: i % constants.GridSize, Digit: digit},
-						},
-						Explanation: fmt.Sprintf("X-Chain: sees both ends of chain: eliminate %d from R%dC%d.", digit, i/constants.GridSize+1, i%constants.GridSize+1),
-						Highlights: core.Highlights{
-							Primary:   targets,
-							Secondary: []core.CellRef{{Row: i / constants.GridSize, Col: i % constants.GridSize}},
-						},
-					}
-				}
-			}
-		}
-
-		// Continue building chain
-		for _, next := range conjugates[node.cell] {
-			if !visited[next] {
-				newPath := make([]int, len(node.path)+1)
-				copy(newPath, node.path)
-				newPath[len(node.path)] = next
-				queue = append(queue, chainNode{next, newPath, 1 - node.color})
-			}
-		}
-	}
-
-	return nil
-}
-
-// DetectXYChain finds XY-Chain pattern: a chain of bivalue cells
+// XYChainMaxLength bounds the XY-Chain search depth (number of chain cells).
+// Longer chains exist but are rarely human-findable and the search cost grows
+// quickly, so the detector stops extending chains at this length.
+var XYChainMaxLength = 8
+
+// DetectXYChain finds XY-Chain patterns: an alternating chain restricted to
+// bivalue cells, where in-cell strong links flip between each cell's two
+// candidates and the dangling digit at both ends matches. That digit can be
+// eliminated from any cell seeing both chain ends. Implemented as a wrapper
+// over the shared chain engine with the node set restricted to bivalue
+// cells, bounded by XYChainMaxLength chain cells
 func DetectXYChain(b BoardInterface) *core.Move {
-	// Find all bivalue cells
-	var bivalue []int
-	for i := 0; i < constants.TotalCells; i++ {
-		if b.GetCandidatesAt(i).Count() == 2 {
-			bivalue = append(bivalue, i)
-		}
-	}
-
-	if len(bivalue) < 3 {
-		return nil
-	}
-
-	// Build adjacency: two bivalue cells are connected if they share a unit and a candidate
-	adj := make(map[int][]struct {
-		cell       int
-		sharedCand int
+	engine := newChainEngine(b, func(cell, digit int) bool {
+		return b.GetCandidatesAt(cell).Count() == 2
 	})
-
-	for _, c1 := range bivalue {
-		for _, c2 := range bivalue {
-			if c1 >= c2 || !ArePeers(c1, c2) {
-				continue
-			}
-			// Find shared candidate
-			for _, d := range b.GetCandidatesAt(c1).ToSlice() {
-				if b.GetCandidatesAt(c2).Has(d) {
-					adj[c1] = append(adj[c1], struct {
-						cell       int
-						sharedCand int
-					}{c2, d})
-					adj[c2] = append(adj[c2], struct {
-						cell       int
-						sharedCand int
-					}{c1, d})
-					break
-				}
-			}
-		}
-	}
-
-	// DFS to find chains
-	for _, start := range bivalue {
-		if move := findXYChainFrom(b, start, adj); move != nil {
-			return move
-		}
-	}
-
-	return nil
-}
-
-func findXYChainFrom(b BoardInterface, start int, adj map[int][]struct {
-	cell       int
-	sharedCand int
-}) *core.Move {
-	cands := b.GetCandidatesAt(start).ToSlice()
-	if len(cands) != 2 {
+	result := engine.findBestChain(chainSearch{
+		maxNodes:      2 * XYChainMaxLength,
+		sameDigitEnds: true,
+	})
+	if result == nil {
 		return nil
 	}
 
-	// Try chains starting with each candidate
-	for _, startCand := range cands {
-		type node struct {
-			cell    int
-			path    []int
-			endCand int // the "dangling" candidate at the end
-		}
-
-		visited := make(map[int]bool)
-		// Start with the other candidate as the "dangling" one
-		otherCand := cands[0]
-		if startCand == cands[0] {
-			otherCand = cands[1]
-		}
-
-		queue := []node{{start, []int{start}, otherCand}}
-
-		for len(queue) > 0 {
-			n := queue[0]
-			queue = queue[1:]
-
-			if visited[n.cell] {
-				continue
-			}
-			visited[n.cell] = true
-
-			// Check for eliminations: if start's startCand == end's endCand,
-			// cells seeing both can eliminate that digit
-			if len(n.path) >= 3 && startCand == n.endCand {
-				chainStart := n.path[0]
-				chainEnd := n.cell
-
-				for i := 0; i < constants.TotalCells; i++ {
-					if !b.GetCandidatesAt(i).Has(startCand) {
-						continue
-					}
-					inChain := false
-					for _, c := range n.path {
-						if i == c {
-							inChain = true
-							break
-						}
-					}
-					if inChain {
-						continue
-					}
-
-					if ArePeers(i, chainStart) && ArePeers(i, chainEnd) {
-						var targets []core.CellRef
-						for _, c := range n.path {
-							targets = append(targets, core.CellRef{Row: c / constants.GridSize, Col: c % constants.GridSize})
-						}
-
-						return &core.Move{
-							Action:  "eliminate",
-							Digit:   startCand,
-							Targets: targets,
-							Eliminations: []core.Candidate{
-								{Row: i / constants.GridSize, Col: i % constants.GridSize, Digit: startCand},
-							},
-							Explanation: fmt.Sprintf("XY-Chain: eliminate %d from R%dC%d.", startCand, i/constants.GridSize+1, i%constants.GridSize+1),
-							Highlights: core.Highlights{
-								Primary:   targets,
-								Secondary: []core.CellRef{{Row: i / constants.GridSize, Col: i % constants.GridSize}},
-							},
-						}
-					}
-				}
-			}
-
-			// Extend chain
-			for _, neighbor := range adj[n.cell] {
-				if visited[neighbor.cell] {
-					continue
-				}
-				// The shared candidate must be the current endCand
-				if neighbor.sharedCand != n.endCand {
-					continue
-				}
-
-				// New end candidate is the other candidate of the neighbor cell
-				neighborCands := b.GetCandidatesAt(neighbor.cell).ToSlice()
-				if len(neighborCands) != 2 {
-					continue
-				}
-				newEndCand := neighborCands[0]
-				if neighborCands[0] == neighbor.sharedCand {
-					newEndCand = neighborCands[1]
-				}
-
-				newPath := make([]int, len(n.path)+1)
-				copy(newPath, n.path)
-				newPath[len(n.path)] = neighbor.cell
-				queue = append(queue, node{neighbor.cell, newPath, newEndCand})
-			}
-		}
-	}
-
-	return nil
+	cells := chainCells(result.nodes)
+	head, tail := cells[0], cells[len(cells)-1]
+	return chainEliminationMove(result, "technique.xy-chain", map[string]interface{}{
+		"length": len(cells),
+		"head":   FormatCell(head),
+		"tail":   FormatCell(tail),
+		"digit":  result.elims[0].Digit,
+	})
 }
 
 // DetectWWing finds W-Wing pattern: two bivalue cells with same candidates,
@@ -528,7 +150,7 @@ func DetectWWing(b BoardInterface) *core.Move {
 							}
 
 							if len(eliminations) > 0 {
-								return &core.Move{
+								return explained(&core.Move{
 									Action: "eliminate",
 									Digit:  elimDigit,
 									Targets: []core.CellRef{
@@ -538,7 +160,6 @@ func DetectWWing(b BoardInterface) *core.Move {
 										{Row: link2 / constants.GridSize, Col: link2 % constants.GridSize},
 									},
 									Eliminations: eliminations,
-									Explanation:  fmt.Sprintf("W-Wing: {%d,%d} cells connected by strong link on %d", d1, d2, linkDigit),
 									Highlights: core.Highlights{
 										Primary: []core.CellRef{
 											{Row: bv1.idx / constants.GridSize, Col: bv1.idx % constants.GridSize},
@@ -549,7 +170,10 @@ func DetectWWing(b BoardInterface) *core.Move {
 											{Row: link2 / constants.GridSize, Col: link2 % constants.GridSize},
 										},
 									},
-								}
+								}, "technique.w-wing", map[string]interface{}{
+									"digits":    []int{d1, d2},
+									"linkDigit": linkDigit,
+								})
 							}
 						}
 					}
@@ -681,20 +305,23 @@ func DetectEmptyRectangle(b BoardInterface) *core.Move {
 								targets = append(targets, core.CellRef{Row: p / constants.GridSize, Col: p % constants.GridSize})
 							}
 
-							return &core.Move{
+							return explained(&core.Move{
 								Action:  "eliminate",
 								Digit:   digit,
 								Targets: targets,
 								Eliminations: []core.Candidate{
 									{Row: linkRow, Col: erCol, Digit: digit},
 								},
-								Explanation: fmt.Sprintf("Empty Rectangle: %d in box %d with conjugate pair in C%d: eliminate from R%dC%d.",
-									digit, box+1, linkCol+1, linkRow+1, erCol+1),
 								Highlights: core.Highlights{
 									Primary:   targets,
 									Secondary: []core.CellRef{{Row: linkRow, Col: erCol}},
 								},
-							}
+							}, "technique.empty-rectangle.col", map[string]interface{}{
+								"digit": digit,
+								"box":   box + 1,
+								"col":   linkCol + 1,
+								"cell":  FormatCell(targetIdx),
+							})
 						}
 					}
 
@@ -750,20 +377,23 @@ func DetectEmptyRectangle(b BoardInterface) *core.Move {
 								targets = append(targets, core.CellRef{Row: p / constants.GridSize, Col: p % constants.GridSize})
 							}
 
-							return &core.Move{
+							return explained(&core.Move{
 								Action:  "eliminate",
 								Digit:   digit,
 								Targets: targets,
 								Eliminations: []core.Candidate{
 									{Row: erRow, Col: linkCol, Digit: digit},
 								},
-								Explanation: fmt.Sprintf("Empty Rectangle: %d in box %d with conjugate pair in R%d: eliminate from R%dC%d.",
-									digit, box+1, linkRow+1, erRow+1, linkCol+1),
 								Highlights: core.Highlights{
 									Primary:   targets,
 									Secondary: []core.CellRef{{Row: erRow, Col: linkCol}},
 								},
-							}
+							}, "technique.empty-rectangle.row", map[string]interface{}{
+								"digit": digit,
+								"box":   box + 1,
+								"row":   linkRow + 1,
+								"cell":  FormatCell(targetIdx),
+							})
 						}
 					}
 				}
@@ -0,0 +1,155 @@
+package techniques
+
+import (
+	"sort"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// DetectSimpleColoring uses single-digit coloring to find eliminations
+func DetectSimpleColoring(b BoardInterface) *core.Move {
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		// Find conjugate pairs (cells where digit appears exactly twice in a unit)
+		conjugates := make(map[int][]int) // cell -> connected cells
+
+		// Check rows
+		for row := 0; row < constants.GridSize; row++ {
+			var cells []int
+			for col := 0; col < constants.GridSize; col++ {
+				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
+					cells = append(cells, row*constants.GridSize+col)
+				}
+			}
+			if len(cells) == 2 {
+				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
+				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
+			}
+		}
+
+		// Check columns
+		for col := 0; col < constants.GridSize; col++ {
+			var cells []int
+			for row := 0; row < constants.GridSize; row++ {
+				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
+					cells = append(cells, row*constants.GridSize+col)
+				}
+			}
+			if len(cells) == 2 {
+				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
+				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
+			}
+		}
+
+		// Check boxes
+		for box := 0; box < constants.GridSize; box++ {
+			var cells []int
+			boxRow, boxCol := (box/constants.BoxSize)*constants.BoxSize, (box%constants.BoxSize)*constants.BoxSize
+			for r := boxRow; r < boxRow+constants.BoxSize; r++ {
+				for c := boxCol; c < boxCol+constants.BoxSize; c++ {
+					if b.GetCandidatesAt(r*constants.GridSize + c).Has(digit) {
+						cells = append(cells, r*constants.GridSize+c)
+					}
+				}
+			}
+			if len(cells) == 2 {
+				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
+				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
+			}
+		}
+
+		if len(conjugates) == 0 {
+			continue
+		}
+
+		// Get sorted list of starting cells for deterministic iteration
+		var startCells []int
+		for cell := range conjugates {
+			startCells = append(startCells, cell)
+		}
+		sort.Ints(startCells)
+
+		// Color each connected component separately
+		colors := make(map[int]int) // cell -> color (1 or 2)
+
+		for _, start := range startCells {
+			if colors[start] != 0 {
+				continue
+			}
+
+			// BFS to color THIS connected component only
+			var color1, color2 []int // Reset for each component!
+			queue := []int{start}
+			colors[start] = 1
+			color1 = append(color1, start)
+
+			for len(queue) > 0 {
+				cell := queue[0]
+				queue = queue[1:]
+				currentColor := colors[cell]
+				nextColor := 3 - currentColor
+
+				for _, neighbor := range conjugates[cell] {
+					if colors[neighbor] == 0 {
+						colors[neighbor] = nextColor
+						if nextColor == 1 {
+							color1 = append(color1, neighbor)
+						} else {
+							color2 = append(color2, neighbor)
+						}
+						queue = append(queue, neighbor)
+					}
+				}
+			}
+
+			// Need at least one cell of each color for a valid chain
+			if len(color1) == 0 || len(color2) == 0 {
+				continue
+			}
+
+			// Check for eliminations: cells that see both colors OF THIS COMPONENT
+			for i := 0; i < constants.TotalCells; i++ {
+				if !b.GetCandidatesAt(i).Has(digit) || colors[i] != 0 {
+					continue
+				}
+
+				seesColor1 := false
+				seesColor2 := false
+				for _, c1 := range color1 {
+					if ArePeers(i, c1) {
+						seesColor1 = true
+						break
+					}
+				}
+				for _, c2 := range color2 {
+					if ArePeers(i, c2) {
+						seesColor2 = true
+						break
+					}
+				}
+
+				if seesColor1 && seesColor2 {
+					return explained(&core.Move{
+						Action: "eliminate",
+						Digit:  digit,
+						Targets: []core.CellRef{
+							{Row: i / constants.GridSize, Col: i % constants.GridSize},
+						},
+						Eliminations: []core.Candidate{
+							{Row: i / constants.GridSize, Col: i % constants.GridSize, Digit: digit},
+						},
+						Highlights: core.Highlights{
+							Primary:   []core.CellRef{{Row: i / constants.GridSize, Col: i % constants.GridSize}},
+							Secondary: CellRefsFromIndices(append(color1, color2...)...),
+						},
+					}, "technique.simple-coloring", map[string]interface{}{
+						"cell":  FormatCell(i),
+						"digit": digit,
+					})
+				}
+			}
+		}
+	}
+
+	return nil
+}
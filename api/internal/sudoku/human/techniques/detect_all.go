@@ -0,0 +1,33 @@
+package techniques
+
+import "sudoku-api/internal/core"
+
+// Detectors return the first match in board scan order, which is what the
+// solver wants when advancing a solve. Cell-targeted hints need every match
+// instead: the requested cell is rarely part of the first pattern found. The
+// *All detector variants share the underlying scans with their single-result
+// counterparts through an emit callback, so both always agree on ordering.
+
+// moveScan walks the board in a fixed order and passes each match to emit,
+// stopping early once emit returns true
+type moveScan func(b BoardInterface, emit func(*core.Move) bool)
+
+// firstMove adapts an emit-based scan to the single-result detector shape
+func firstMove(scan moveScan, b BoardInterface) *core.Move {
+	var move *core.Move
+	scan(b, func(m *core.Move) bool {
+		move = m
+		return true
+	})
+	return move
+}
+
+// allMoves runs a scan to completion and collects every match in scan order
+func allMoves(scan moveScan, b BoardInterface) []*core.Move {
+	var moves []*core.Move
+	scan(b, func(m *core.Move) bool {
+		moves = append(moves, m)
+		return false
+	})
+	return moves
+}
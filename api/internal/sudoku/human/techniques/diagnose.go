@@ -0,0 +1,153 @@
+package techniques
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"sudoku-api/pkg/constants"
+)
+
+// Near-miss reporters for stall diagnostics. Each returns a short reason
+// string when a technique's preconditions were almost met on the given board,
+// or "" when nothing came close. They never mutate the board and are only
+// called when the solver has already stalled, so clarity wins over speed.
+
+// DiagnoseNakedSubset reports the closest a unit came to containing a naked
+// subset of size n.
+func DiagnoseNakedSubset(b BoardInterface, n int) string {
+	name := strings.ToLower(subsetNames[n])
+	for _, unit := range AllUnits() {
+		var cells []int
+		unsolved := 0
+		for _, idx := range unit.Cells {
+			if b.GetCell(idx) != 0 {
+				continue
+			}
+			unsolved++
+			if c := b.GetCandidatesAt(idx).Count(); c >= 2 && c <= n {
+				cells = append(cells, idx)
+			}
+		}
+		if len(cells) < n {
+			continue
+		}
+		minUnion := 0
+		forEachCombination(len(cells), n, func(combo []int) bool {
+			var union Candidates
+			for _, ci := range combo {
+				union = union.Union(b.GetCandidatesAt(cells[ci]))
+			}
+			if c := union.Count(); minUnion == 0 || c < minUnion {
+				minUnion = c
+			}
+			return false
+		})
+		switch {
+		case minUnion == n && unsolved <= n:
+			return fmt.Sprintf("naked %s in %s %d covers the whole remaining unit", name, unit.Type.String(), unit.Index+1)
+		case minUnion == n:
+			return fmt.Sprintf("naked %s in %s %d yields no eliminations", name, unit.Type.String(), unit.Index+1)
+		case minUnion == n+1:
+			return fmt.Sprintf("%d cells in %s %d reduce to %d digits, one more than a naked %s needs", n, unit.Type.String(), unit.Index+1, minUnion, name)
+		}
+	}
+	return ""
+}
+
+// DiagnoseHiddenSubset reports the closest a unit came to confining n digits
+// to n cells.
+func DiagnoseHiddenSubset(b BoardInterface, n int) string {
+	name := strings.ToLower(subsetNames[n])
+	for _, unit := range AllUnits() {
+		var positions [constants.GridSize + 1]uint16
+		for slot, idx := range unit.Cells {
+			if b.GetCell(idx) != 0 {
+				continue
+			}
+			for _, d := range b.GetCandidatesAt(idx).ToSlice() {
+				positions[d] |= 1 << slot
+			}
+		}
+		// Allow one cell of slack so near misses are visible
+		var digits []int
+		for d := 1; d <= constants.GridSize; d++ {
+			if c := bits.OnesCount16(positions[d]); c >= 2 && c <= n+1 {
+				digits = append(digits, d)
+			}
+		}
+		if len(digits) < n {
+			continue
+		}
+		minUnion := 0
+		forEachCombination(len(digits), n, func(combo []int) bool {
+			var posUnion uint16
+			for _, di := range combo {
+				posUnion |= positions[digits[di]]
+			}
+			if c := bits.OnesCount16(posUnion); minUnion == 0 || c < minUnion {
+				minUnion = c
+			}
+			return false
+		})
+		switch minUnion {
+		case n:
+			return fmt.Sprintf("hidden %s in %s %d yields no eliminations", name, unit.Type.String(), unit.Index+1)
+		case n + 1:
+			return fmt.Sprintf("%d digits in %s %d span %d cells, one more than a hidden %s needs", n, unit.Type.String(), unit.Index+1, minUnion, name)
+		}
+	}
+	return ""
+}
+
+// DiagnoseXWing reports digits that have the right row counts for an X-Wing
+// but whose columns never align (and vice versa).
+func DiagnoseXWing(b BoardInterface) string {
+	if reason := diagnoseXWingLines(b, true); reason != "" {
+		return reason
+	}
+	return diagnoseXWingLines(b, false)
+}
+
+func diagnoseXWingLines(b BoardInterface, byRow bool) string {
+	axis, cross := "rows", "column"
+	if !byRow {
+		axis, cross = "columns", "row"
+	}
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		var masks []uint16
+		for line := 0; line < constants.GridSize; line++ {
+			var mask uint16
+			count := 0
+			for other := 0; other < constants.GridSize; other++ {
+				idx := line*constants.GridSize + other
+				if !byRow {
+					idx = other*constants.GridSize + line
+				}
+				if b.GetCandidatesAt(idx).Has(digit) {
+					mask |= 1 << other
+					count++
+				}
+			}
+			if count == 2 {
+				masks = append(masks, mask)
+			}
+		}
+		if len(masks) < 2 {
+			continue
+		}
+		aligned := false
+		for i := 0; i < len(masks) && !aligned; i++ {
+			for j := i + 1; j < len(masks); j++ {
+				if masks[i] == masks[j] {
+					aligned = true
+					break
+				}
+			}
+		}
+		if !aligned {
+			return fmt.Sprintf("digit %d has %d %s with exactly two positions but no aligned %s pair", digit, len(masks), axis, cross)
+		}
+	}
+	return ""
+}
@@ -1,7 +1,7 @@
 package techniques
 
 import (
-	"fmt"
+	"sort"
 
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
@@ -319,8 +319,17 @@ func findCommonPlacement(b BoardInterface, digit int, positions []int, results [
 		return nil
 	}
 
-	// Use first result as base, check if placements exist in all others
-	for idx, placedDigit := range results[0].placements {
+	// Use first result as base, check if placements exist in all others.
+	// Cells are visited in sorted order because map iteration order is
+	// random and the first common conclusion wins
+	cells := make([]int, 0, len(results[0].placements))
+	for idx := range results[0].placements {
+		cells = append(cells, idx)
+	}
+	sort.Ints(cells)
+
+	for _, idx := range cells {
+		placedDigit := results[0].placements[idx]
 		// Skip the starting positions themselves (they're different in each branch)
 		isStartPos := false
 		for _, pos := range positions {
@@ -347,7 +356,7 @@ func findCommonPlacement(b BoardInterface, digit int, positions []int, results [
 			}
 		}
 
-		if common {
+		if common && forcingAssignmentSane(b, idx, placedDigit) {
 			row, col := idx/constants.GridSize, idx%constants.GridSize
 
 			// Build targets (the starting positions we're choosing between)
@@ -356,20 +365,22 @@ func findCommonPlacement(b BoardInterface, digit int, positions []int, results [
 				targets = append(targets, core.CellRef{Row: pos / constants.GridSize, Col: pos % constants.GridSize})
 			}
 
-			return &core.Move{
+			return withTargetCandidates(b, explained(&core.Move{
 				Action:  "assign",
 				Digit:   placedDigit,
 				Targets: []core.CellRef{{Row: row, Col: col}},
-				Explanation: fmt.Sprintf(
-					"Digit Forcing Chain: %d in %s %d can only go in %d positions; "+
-						"trying each leads to R%dC%d=%d",
-					digit, unitType, unitIdx+1, len(positions), row+1, col+1, placedDigit,
-				),
 				Highlights: core.Highlights{
 					Primary:   []core.CellRef{{Row: row, Col: col}},
 					Secondary: targets,
 				},
-			}
+			}, "technique.digit-forcing-chain.assign", map[string]interface{}{
+				"digit":     digit,
+				"unitType":  unitType,
+				"unitIndex": unitIdx + 1,
+				"count":     len(positions),
+				"cell":      FormatCell(idx),
+				"value":     placedDigit,
+			}))
 		}
 	}
 
@@ -382,8 +393,16 @@ func findCommonElimination(b BoardInterface, digit int, positions []int, results
 		return nil
 	}
 
-	// Collect all eliminations from the first result
-	for idx, digits := range results[0].eliminations {
+	// Collect all eliminations from the first result, visiting cells and
+	// digits in sorted order so the chosen elimination is reproducible
+	cells := make([]int, 0, len(results[0].eliminations))
+	for idx := range results[0].eliminations {
+		cells = append(cells, idx)
+	}
+	sort.Ints(cells)
+
+	for _, idx := range cells {
+		digits := results[0].eliminations[idx]
 		// Skip the starting positions
 		isStartPos := false
 		for _, pos := range positions {
@@ -396,7 +415,13 @@ func findCommonElimination(b BoardInterface, digit int, positions []int, results
 			continue
 		}
 
-		for elimDigit := range digits {
+		elimDigits := make([]int, 0, len(digits))
+		for d := range digits {
+			elimDigits = append(elimDigits, d)
+		}
+		sort.Ints(elimDigits)
+
+		for _, elimDigit := range elimDigits {
 			// Skip if candidate doesn't exist on original board
 			if !b.GetCandidatesAt(idx).Has(elimDigit) {
 				continue
@@ -414,7 +439,7 @@ func findCommonElimination(b BoardInterface, digit int, positions []int, results
 				}
 			}
 
-			if common {
+			if common && forcingEliminationSane(b, idx, elimDigit) {
 				row, col := idx/constants.GridSize, idx%constants.GridSize
 
 				// Build targets (the starting positions we're choosing between)
@@ -423,23 +448,25 @@ func findCommonElimination(b BoardInterface, digit int, positions []int, results
 					targets = append(targets, core.CellRef{Row: pos / constants.GridSize, Col: pos % constants.GridSize})
 				}
 
-				return &core.Move{
+				return explained(&core.Move{
 					Action:  "eliminate",
 					Digit:   elimDigit,
 					Targets: targets,
 					Eliminations: []core.Candidate{
 						{Row: row, Col: col, Digit: elimDigit},
 					},
-					Explanation: fmt.Sprintf(
-						"Digit Forcing Chain: %d in %s %d can only go in %d positions; "+
-							"trying each eliminates %d from R%dC%d",
-						digit, unitType, unitIdx+1, len(positions), elimDigit, row+1, col+1,
-					),
 					Highlights: core.Highlights{
 						Primary:   []core.CellRef{{Row: row, Col: col}},
 						Secondary: targets,
 					},
-				}
+				}, "technique.digit-forcing-chain.eliminate", map[string]interface{}{
+					"digit":     digit,
+					"unitType":  unitType,
+					"unitIndex": unitIdx + 1,
+					"count":     len(positions),
+					"elim":      elimDigit,
+					"cell":      FormatCell(idx),
+				})
 			}
 		}
 	}
@@ -1,408 +1,320 @@
 package techniques
 
 import (
-	"fmt"
 	"sort"
 
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
 
+// ============================================================================
+// Generalized Basic Fish
+// ============================================================================
+//
+// X-Wing and Swordfish are the same pattern at different sizes: n base lines
+// where a digit's candidates fall into at most n positions, whose union
+// covers exactly n cross lines. The digit must then occupy one cell per base
+// line, claiming all n cover lines, so it can be eliminated from the rest of
+// those lines. scanBasicFish implements the pattern once with the base-set
+// size as a parameter; the per-technique entry points pick the size, which
+// also selects the explanation key.
+
+// fishNames maps base-set size to the technique name used in explanation keys
+var fishNames = map[int]string{2: "x-wing", 3: "swordfish"}
+
 // DetectXWing finds X-Wing pattern: a digit in exactly 2 positions in 2 rows,
 // and those positions share the same columns
-func DetectXWing(b BoardInterface) *core.Move {
-	for digit := 1; digit <= constants.GridSize; digit++ {
-		// Find rows where digit appears in exactly 2 columns
-		rowPositions := make(map[int][]int)
-		for row := 0; row < constants.GridSize; row++ {
-			var cols []int
-			for col := 0; col < constants.GridSize; col++ {
-				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-					cols = append(cols, col)
-				}
-			}
-			if len(cols) == 2 {
-				rowPositions[row] = cols
-			}
-		}
-
-		// Look for matching row pairs
-		var rows []int
-		for row := range rowPositions {
-			rows = append(rows, row)
-		}
+func DetectXWing(b BoardInterface) *core.Move { return firstMove(scanXWings, b) }
 
-		for i := 0; i < len(rows); i++ {
-			for j := i + 1; j < len(rows); j++ {
-				r1, r2 := rows[i], rows[j]
-				cols1, cols2 := rowPositions[r1], rowPositions[r2]
+// DetectXWingAll returns every X-Wing on the board in scan order
+func DetectXWingAll(b BoardInterface) []*core.Move { return allMoves(scanXWings, b) }
 
-				if cols1[0] == cols2[0] && cols1[1] == cols2[1] {
-					c1, c2 := cols1[0], cols1[1]
+func scanXWings(b BoardInterface, emit func(*core.Move) bool) { scanBasicFish(b, 2, emit) }
 
-					// Find eliminations in the columns
-					var eliminations []core.Candidate
-					for row := 0; row < constants.GridSize; row++ {
-						if row == r1 || row == r2 {
-							continue
-						}
-						if b.GetCandidatesAt(row*constants.GridSize + c1).Has(digit) {
-							eliminations = append(eliminations, core.Candidate{Row: row, Col: c1, Digit: digit})
-						}
-						if b.GetCandidatesAt(row*constants.GridSize + c2).Has(digit) {
-							eliminations = append(eliminations, core.Candidate{Row: row, Col: c2, Digit: digit})
-						}
-					}
-
-					if len(eliminations) > 0 {
-						// Build secondary highlights: all cells in the two rows forming the X-Wing
-						var secondaryCells []int
-						for col := 0; col < constants.GridSize; col++ {
-							secondaryCells = append(secondaryCells, r1*constants.GridSize+col)
-							secondaryCells = append(secondaryCells, r2*constants.GridSize+col)
-						}
-						return &core.Move{
-							Action: "eliminate",
-							Digit:  digit,
-							Targets: []core.CellRef{
-								{Row: r1, Col: c1}, {Row: r1, Col: c2},
-								{Row: r2, Col: c1}, {Row: r2, Col: c2},
-							},
-							Eliminations: eliminations,
-							Explanation:  fmt.Sprintf("X-Wing: %d in rows %d,%d columns %d,%d", digit, r1+1, r2+1, c1+1, c2+1),
-							Highlights: core.Highlights{
-								Primary: []core.CellRef{
-									{Row: r1, Col: c1}, {Row: r1, Col: c2},
-									{Row: r2, Col: c1}, {Row: r2, Col: c2},
-								},
-								Secondary: ToCellRefs(secondaryCells),
-							},
-						}
-					}
-				}
-			}
-		}
-
-		// Check columns for X-Wing
-		colPositions := make(map[int][]int)
-		for col := 0; col < constants.GridSize; col++ {
-			var rows []int
-			for row := 0; row < constants.GridSize; row++ {
-				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-					rows = append(rows, row)
-				}
-			}
-			if len(rows) == 2 {
-				colPositions[col] = rows
-			}
+func scanBasicFish(b BoardInterface, size int, emit func(*core.Move) bool) {
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		if scanFishOrientation(b, digit, size, false, emit) {
+			return
 		}
-
-		var cols []int
-		for col := range colPositions {
-			cols = append(cols, col)
+		if scanFishOrientation(b, digit, size, true, emit) {
+			return
 		}
+	}
+}
 
-		for i := 0; i < len(cols); i++ {
-			for j := i + 1; j < len(cols); j++ {
-				c1, c2 := cols[i], cols[j]
-				rows1, rows2 := colPositions[c1], colPositions[c2]
-
-				if rows1[0] == rows2[0] && rows1[1] == rows2[1] {
-					r1, r2 := rows1[0], rows1[1]
-
-					var eliminations []core.Candidate
-					for col := 0; col < constants.GridSize; col++ {
-						if col == c1 || col == c2 {
-							continue
-						}
-						if b.GetCandidatesAt(r1*constants.GridSize + col).Has(digit) {
-							eliminations = append(eliminations, core.Candidate{Row: r1, Col: col, Digit: digit})
-						}
-						if b.GetCandidatesAt(r2*constants.GridSize + col).Has(digit) {
-							eliminations = append(eliminations, core.Candidate{Row: r2, Col: col, Digit: digit})
-						}
-					}
+// fishCell maps a (base line, cross line) pair to a cell index. Base lines
+// are rows unless colBased, in which case the roles swap.
+func fishCell(line, cross int, colBased bool) int {
+	if colBased {
+		return cross*constants.GridSize + line
+	}
+	return line*constants.GridSize + cross
+}
 
-					if len(eliminations) > 0 {
-						// Build secondary highlights: all cells in the two columns forming the X-Wing
-						var secondaryCells []int
-						for row := 0; row < constants.GridSize; row++ {
-							secondaryCells = append(secondaryCells, row*constants.GridSize+c1)
-							secondaryCells = append(secondaryCells, row*constants.GridSize+c2)
-						}
-						return &core.Move{
-							Action: "eliminate",
-							Digit:  digit,
-							Targets: []core.CellRef{
-								{Row: r1, Col: c1}, {Row: r1, Col: c2},
-								{Row: r2, Col: c1}, {Row: r2, Col: c2},
-							},
-							Eliminations: eliminations,
-							Explanation:  fmt.Sprintf("X-Wing: %d in columns %d,%d rows %d,%d", digit, c1+1, c2+1, r1+1, r2+1),
-							Highlights: core.Highlights{
-								Primary: []core.CellRef{
-									{Row: r1, Col: c1}, {Row: r1, Col: c2},
-									{Row: r2, Col: c1}, {Row: r2, Col: c2},
-								},
-								Secondary: ToCellRefs(secondaryCells),
-							},
-						}
-					}
-				}
+// scanFishOrientation scans one digit and orientation for fish of the given
+// base-set size. Returns true when emit asked to stop.
+func scanFishOrientation(b BoardInterface, digit, size int, colBased bool, emit func(*core.Move) bool) bool {
+	// Find base lines where the digit appears in 2..size positions. Two
+	// positions are enough in any base line: three 2-candidate rows whose
+	// union is 3 columns form a valid 2-2-2 Swordfish.
+	positions := make(map[int][]int)
+	for line := 0; line < constants.GridSize; line++ {
+		var cross []int
+		for x := 0; x < constants.GridSize; x++ {
+			if b.GetCandidatesAt(fishCell(line, x, colBased)).Has(digit) {
+				cross = append(cross, x)
 			}
 		}
+		if len(cross) >= 2 && len(cross) <= size {
+			positions[line] = cross
+		}
 	}
 
-	return nil
-}
+	var lines []int
+	for line := range positions {
+		lines = append(lines, line)
+	}
+	// Map iteration order is random; sort so the same board always yields
+	// the same fish
+	sort.Ints(lines)
+	if len(lines) < size {
+		return false
+	}
 
-// DetectXYWing finds XY-Wing pattern: pivot cell with candidates XY,
-// two wings with candidates XZ and YZ, eliminate Z from cells seeing both wings
-func DetectXYWing(b BoardInterface) *core.Move {
-	// Find cells with exactly 2 candidates (potential pivots or wings)
-	var bivalues []int
-	for i := 0; i < constants.TotalCells; i++ {
-		if b.GetCandidatesAt(i).Count() == 2 {
-			bivalues = append(bivalues, i)
+	stopped := false
+	forEachCombination(len(lines), size, func(idxs []int) bool {
+		base := make([]int, size)
+		inBase := make(map[int]bool, size)
+		for i, idx := range idxs {
+			base[i] = lines[idx]
+			inBase[lines[idx]] = true
 		}
-	}
 
-	for _, pivot := range bivalues {
-		pivotCands := b.GetCandidatesAt(pivot).ToSlice()
-		if len(pivotCands) != 2 {
-			continue
+		coverSet := make(map[int]bool)
+		for _, line := range base {
+			for _, x := range positions[line] {
+				coverSet[x] = true
+			}
+		}
+		if len(coverSet) != size {
+			return false
+		}
+		// A base subset already confined to two cover lines is an X-Wing;
+		// the cheaper detector runs first and claims it, and the rest of
+		// the larger fish falls out as singles afterwards
+		if size > 2 && hasXWingSubset(positions, base) {
+			return false
 		}
-		x, y := pivotCands[0], pivotCands[1]
 
-		// Find wings that see the pivot
-		var xzWings, yzWings []int
+		var cover []int
+		for x := range coverSet {
+			cover = append(cover, x)
+		}
+		sort.Ints(cover)
 
-		for _, wing := range bivalues {
-			if wing == pivot {
+		var eliminations []core.Candidate
+		for line := 0; line < constants.GridSize; line++ {
+			if inBase[line] {
 				continue
 			}
-			if !ArePeers(pivot, wing) {
-				continue
+			for _, x := range cover {
+				cell := fishCell(line, x, colBased)
+				if b.GetCandidatesAt(cell).Has(digit) {
+					eliminations = append(eliminations, core.Candidate{
+						Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit,
+					})
+				}
 			}
+		}
+		if len(eliminations) == 0 {
+			return false
+		}
 
-			wingCands := b.GetCandidatesAt(wing).ToSlice()
-			if len(wingCands) != 2 {
-				continue
+		// Targets are the pattern cells; secondary highlights the full base
+		// lines for orientation
+		var targets []core.CellRef
+		var secondaryCells []int
+		baseLabels := make([]int, 0, size)
+		coverLabels := make([]int, 0, size)
+		for _, line := range base {
+			baseLabels = append(baseLabels, line+1)
+			for _, x := range positions[line] {
+				cell := fishCell(line, x, colBased)
+				targets = append(targets, core.CellRef{Row: cell / constants.GridSize, Col: cell % constants.GridSize})
 			}
-
-			hasX := wingCands[0] == x || wingCands[1] == x
-			hasY := wingCands[0] == y || wingCands[1] == y
-
-			if hasX && !hasY {
-				xzWings = append(xzWings, wing)
-			} else if hasY && !hasX {
-				yzWings = append(yzWings, wing)
+			for x := 0; x < constants.GridSize; x++ {
+				secondaryCells = append(secondaryCells, fishCell(line, x, colBased))
 			}
 		}
+		for _, x := range cover {
+			coverLabels = append(coverLabels, x+1)
+		}
 
-		// Try all XZ-YZ pairs
-		for _, xzWing := range xzWings {
-			xzCands := b.GetCandidatesAt(xzWing).ToSlice()
-			var z1 int
-			if xzCands[0] == x {
-				z1 = xzCands[1]
-			} else {
-				z1 = xzCands[0]
-			}
-
-			for _, yzWing := range yzWings {
-				yzCands := b.GetCandidatesAt(yzWing).ToSlice()
-				var z2 int
-				if yzCands[0] == y {
-					z2 = yzCands[1]
-				} else {
-					z2 = yzCands[0]
-				}
-
-				if z1 != z2 {
-					continue
-				}
-				z := z1
-
-				// Find cells that see both wings and have z as candidate
-				var eliminations []core.Candidate
-				for i := 0; i < constants.TotalCells; i++ {
-					if i == pivot || i == xzWing || i == yzWing {
-						continue
-					}
-					if !b.GetCandidatesAt(i).Has(z) {
-						continue
-					}
-					if ArePeers(i, xzWing) && ArePeers(i, yzWing) {
-						eliminations = append(eliminations, core.Candidate{
-							Row: i / constants.GridSize, Col: i % constants.GridSize, Digit: z,
-						})
-					}
-				}
+		key := "technique." + fishNames[size] + ".rows"
+		params := map[string]interface{}{
+			"digit": digit,
+			"rows":  baseLabels,
+			"cols":  coverLabels,
+		}
+		if colBased {
+			key = "technique." + fishNames[size] + ".cols"
+			params["rows"], params["cols"] = coverLabels, baseLabels
+		}
+		move := &core.Move{
+			Action:       "eliminate",
+			Digit:        digit,
+			Targets:      targets,
+			Eliminations: eliminations,
+			Highlights: core.Highlights{
+				Primary:    targets,
+				Secondary:  ToCellRefs(secondaryCells),
+				Target:     EliminationCells(eliminations),
+				Defining:   targets,
+				Justifying: DigitRefs(targets, digit),
+				Eliminated: eliminations,
+			},
+		}
+		move.SetExplanation(key, params)
+		stopped = emit(move)
+		return stopped
+	})
+	return stopped
+}
 
-				if len(eliminations) > 0 {
-					return &core.Move{
-						Action: "eliminate",
-						Digit:  z,
-						Targets: []core.CellRef{
-							{Row: pivot / constants.GridSize, Col: pivot % constants.GridSize},
-							{Row: xzWing / constants.GridSize, Col: xzWing % constants.GridSize},
-							{Row: yzWing / constants.GridSize, Col: yzWing % constants.GridSize},
-						},
-						Eliminations: eliminations,
-						Explanation:  fmt.Sprintf("XY-Wing: pivot at R%dC%d with wings: eliminate %d.", pivot/constants.GridSize+1, pivot%constants.GridSize+1, z),
-						Highlights: core.Highlights{
-							Primary: []core.CellRef{
-								{Row: pivot / constants.GridSize, Col: pivot % constants.GridSize},
-								{Row: xzWing / constants.GridSize, Col: xzWing % constants.GridSize},
-								{Row: yzWing / constants.GridSize, Col: yzWing % constants.GridSize},
-							},
-						},
-					}
-				}
+// hasXWingSubset reports whether two of the base lines confine the digit to
+// two shared cross lines between them
+func hasXWingSubset(positions map[int][]int, base []int) bool {
+	for i := 0; i < len(base); i++ {
+		for j := i + 1; j < len(base); j++ {
+			union := make(map[int]bool)
+			for _, x := range positions[base[i]] {
+				union[x] = true
+			}
+			for _, x := range positions[base[j]] {
+				union[x] = true
+			}
+			if len(union) <= 2 {
+				return true
 			}
 		}
 	}
-
-	return nil
+	return false
 }
 
-// DetectSimpleColoring uses single-digit coloring to find eliminations
-func DetectSimpleColoring(b BoardInterface) *core.Move {
+// DetectJellyfish finds Jellyfish pattern: 4 rows where a digit appears in 2-4 positions,
+// and those positions share exactly 4 columns (or vice versa).
+func DetectJellyfish(b BoardInterface) *core.Move {
 	for digit := 1; digit <= constants.GridSize; digit++ {
-		// Find conjugate pairs (cells where digit appears exactly twice in a unit)
-		conjugates := make(map[int][]int) // cell -> connected cells
-
-		// Check rows
-		for row := 0; row < constants.GridSize; row++ {
-			var cells []int
-			for col := 0; col < constants.GridSize; col++ {
-				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-					cells = append(cells, row*constants.GridSize+col)
-				}
-			}
-			if len(cells) == 2 {
-				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
-				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
-			}
+		if move := detectJellyfishInDirection(b, digit, UnitRow); move != nil {
+			return move
 		}
-
-		// Check columns
-		for col := 0; col < constants.GridSize; col++ {
-			var cells []int
-			for row := 0; row < constants.GridSize; row++ {
-				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-					cells = append(cells, row*constants.GridSize+col)
-				}
-			}
-			if len(cells) == 2 {
-				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
-				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
-			}
+		if move := detectJellyfishInDirection(b, digit, UnitCol); move != nil {
+			return move
 		}
+	}
+	return nil
+}
 
-		// Check boxes
-		for box := 0; box < constants.GridSize; box++ {
-			var cells []int
-			boxRow, boxCol := (box/constants.BoxSize)*constants.BoxSize, (box%constants.BoxSize)*constants.BoxSize
-			for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-				for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-					if b.GetCandidatesAt(r*constants.GridSize + c).Has(digit) {
-						cells = append(cells, r*constants.GridSize+c)
-					}
-				}
-			}
-			if len(cells) == 2 {
-				conjugates[cells[0]] = append(conjugates[cells[0]], cells[1])
-				conjugates[cells[1]] = append(conjugates[cells[1]], cells[0])
+// detectJellyfishInDirection finds Jellyfish in the specified direction (rows or columns)
+func detectJellyfishInDirection(b BoardInterface, digit int, dir UnitType) *core.Move {
+	// Build map of primary unit -> secondary positions where digit appears
+	unitPositions := make(map[int][]int)
+	for primary := 0; primary < constants.GridSize; primary++ {
+		var secondaries []int
+		for secondary := 0; secondary < constants.GridSize; secondary++ {
+			idx := cellIndexForDirection(dir, primary, secondary)
+			if b.GetCandidatesAt(idx).Has(digit) {
+				secondaries = append(secondaries, secondary)
 			}
 		}
-
-		if len(conjugates) == 0 {
-			continue
+		if len(secondaries) >= 2 && len(secondaries) <= 4 {
+			unitPositions[primary] = secondaries
 		}
+	}
 
-		// Get sorted list of starting cells for deterministic iteration
-		var startCells []int
-		for cell := range conjugates {
-			startCells = append(startCells, cell)
-		}
-		sort.Ints(startCells)
-
-		// Color each connected component separately
-		colors := make(map[int]int) // cell -> color (1 or 2)
+	var units []int
+	for unit := range unitPositions {
+		units = append(units, unit)
+	}
+	// Map iteration order is random; sort so the same board always yields
+	// the same fish
+	sort.Ints(units)
 
-		for _, start := range startCells {
-			if colors[start] != 0 {
-				continue
-			}
+	if len(units) < 4 {
+		return nil
+	}
 
-			// BFS to color THIS connected component only
-			var color1, color2 []int // Reset for each component!
-			queue := []int{start}
-			colors[start] = 1
-			color1 = append(color1, start)
-
-			for len(queue) > 0 {
-				cell := queue[0]
-				queue = queue[1:]
-				currentColor := colors[cell]
-				nextColor := 3 - currentColor
-
-				for _, neighbor := range conjugates[cell] {
-					if colors[neighbor] == 0 {
-						colors[neighbor] = nextColor
-						if nextColor == 1 {
-							color1 = append(color1, neighbor)
-						} else {
-							color2 = append(color2, neighbor)
-						}
-						queue = append(queue, neighbor)
+	// Try all combinations of 4 units
+	for i := 0; i < len(units); i++ {
+		for j := i + 1; j < len(units); j++ {
+			for k := j + 1; k < len(units); k++ {
+				for l := k + 1; l < len(units); l++ {
+					u1, u2, u3, u4 := units[i], units[j], units[k], units[l]
+
+					// Collect all secondary positions from these 4 units
+					secondarySet := make(map[int]bool)
+					for _, s := range unitPositions[u1] {
+						secondarySet[s] = true
+					}
+					for _, s := range unitPositions[u2] {
+						secondarySet[s] = true
+					}
+					for _, s := range unitPositions[u3] {
+						secondarySet[s] = true
+					}
+					for _, s := range unitPositions[u4] {
+						secondarySet[s] = true
 					}
-				}
-			}
-
-			// Need at least one cell of each color for a valid chain
-			if len(color1) == 0 || len(color2) == 0 {
-				continue
-			}
 
-			// Check for eliminations: cells that see both colors OF THIS COMPONENT
-			for i := 0; i < constants.TotalCells; i++ {
-				if !b.GetCandidatesAt(i).Has(digit) || colors[i] != 0 {
-					continue
-				}
+					if len(secondarySet) != 4 {
+						continue
+					}
 
-				seesColor1 := false
-				seesColor2 := false
-				for _, c1 := range color1 {
-					if ArePeers(i, c1) {
-						seesColor1 = true
-						break
+					var secondaries []int
+					for s := range secondarySet {
+						secondaries = append(secondaries, s)
 					}
-				}
-				for _, c2 := range color2 {
-					if ArePeers(i, c2) {
-						seesColor2 = true
-						break
+					sort.Ints(secondaries)
+
+					// Find eliminations in secondary lines outside the 4 primary units
+					var eliminations []core.Candidate
+					for _, sec := range secondaries {
+						for pri := 0; pri < constants.GridSize; pri++ {
+							if pri == u1 || pri == u2 || pri == u3 || pri == u4 {
+								continue
+							}
+							idx := cellIndexForDirection(dir, pri, sec)
+							if b.GetCandidatesAt(idx).Has(digit) {
+								row, col := cellCoordsForDirection(dir, pri, sec)
+								eliminations = append(eliminations, core.Candidate{
+									Row: row, Col: col, Digit: digit,
+								})
+							}
+						}
 					}
-				}
 
-				if seesColor1 && seesColor2 {
-					return &core.Move{
-						Action: "eliminate",
-						Digit:  digit,
-						Targets: []core.CellRef{
-							{Row: i / constants.GridSize, Col: i % constants.GridSize},
-						},
-						Eliminations: []core.Candidate{
-							{Row: i / constants.GridSize, Col: i % constants.GridSize, Digit: digit},
-						},
-						Explanation: fmt.Sprintf("Simple Coloring: cell R%dC%d sees both colors for %d", i/constants.GridSize+1, i%constants.GridSize+1, digit),
-						Highlights: core.Highlights{
-							Primary:   []core.CellRef{{Row: i / constants.GridSize, Col: i % constants.GridSize}},
-							Secondary: CellRefsFromIndices(append(color1, color2...)...),
-						},
+					if len(eliminations) > 0 {
+						var targets []core.CellRef
+						for _, pri := range []int{u1, u2, u3, u4} {
+							for _, sec := range unitPositions[pri] {
+								row, col := cellCoordsForDirection(dir, pri, sec)
+								targets = append(targets, core.CellRef{Row: row, Col: col})
+							}
+						}
+
+						return explained(&core.Move{
+							Action:       "eliminate",
+							Digit:        digit,
+							Targets:      targets,
+							Eliminations: eliminations,
+							Highlights: core.Highlights{
+								Primary: targets,
+							},
+						}, "technique.jellyfish", map[string]interface{}{
+							"digit":          digit,
+							"unitTypePlural": directionNamePlural(dir),
+							"units":          []int{u1 + 1, u2 + 1, u3 + 1, u4 + 1},
+						})
 					}
 				}
 			}
@@ -411,3 +323,29 @@ func DetectSimpleColoring(b BoardInterface) *core.Move {
 
 	return nil
 }
+
+// cellIndexForDirection returns the cell index given primary and secondary coordinates
+// For UnitRow: primary=row, secondary=col -> row*GridSize+col
+// For UnitCol: primary=col, secondary=row -> row*GridSize+col (note: secondary is row)
+func cellIndexForDirection(dir UnitType, primary, secondary int) int {
+	if dir == UnitRow {
+		return primary*constants.GridSize + secondary
+	}
+	return secondary*constants.GridSize + primary
+}
+
+// cellCoordsForDirection returns (row, col) given primary and secondary coordinates
+func cellCoordsForDirection(dir UnitType, primary, secondary int) (int, int) {
+	if dir == UnitRow {
+		return primary, secondary
+	}
+	return secondary, primary
+}
+
+// directionNamePlural returns "rows" or "columns" for explanation strings
+func directionNamePlural(dir UnitType) string {
+	if dir == UnitRow {
+		return "rows"
+	}
+	return "columns"
+}
@@ -10,6 +10,11 @@ import (
 // Maximum propagation depth to prevent excessive computation
 const maxPropagationDepth = 12
 
+// ForcingChainMaxCellCandidates bounds how wide a cell forcing chain may
+// branch: 2 restricts the search to bivalue cells, 3 (the default) also
+// tries trivalue cells.
+var ForcingChainMaxCellCandidates = 3
+
 // propagationResult tracks what happens when we assume a value
 type propagationResult struct {
 	placements   map[int]int          // cell index -> digit placed
@@ -131,6 +136,53 @@ func recordEliminationsForPeers(b BoardInterface, cellIdx, digit int, eliminatio
 	}
 }
 
+// forcingAssignmentSane double-checks a common placement against the real
+// board before it is emitted: the cell must still be empty, the digit must
+// still be a candidate, and no peer may already hold it. Propagation results
+// are derived from a simulated board, so conclusions are re-verified rather
+// than trusted.
+func forcingAssignmentSane(b BoardInterface, cell, digit int) bool {
+	if b.GetCell(cell) != 0 || !b.GetCandidatesAt(cell).Has(digit) {
+		return false
+	}
+	for _, peer := range Peers[cell] {
+		if b.GetCell(peer) == digit {
+			return false
+		}
+	}
+	return true
+}
+
+// forcingEliminationSane double-checks a common elimination against the real
+// board: the cell must be empty, the digit must still be a candidate, and
+// removing it must not strip the cell's last candidate.
+func forcingEliminationSane(b BoardInterface, cell, digit int) bool {
+	if b.GetCell(cell) != 0 {
+		return false
+	}
+	cands := b.GetCandidatesAt(cell)
+	return cands.Has(digit) && cands.Count() > 1
+}
+
+// candidateListString renders a candidate list for explanations: "2 or 7",
+// "2, 5 or 7"
+func candidateListString(cands []int) string {
+	switch len(cands) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%d", cands[0])
+	}
+	s := ""
+	for _, d := range cands[:len(cands)-1] {
+		if s != "" {
+			s += ", "
+		}
+		s += fmt.Sprintf("%d", d)
+	}
+	return fmt.Sprintf("%s or %d", s, cands[len(cands)-1])
+}
+
 // getUnitsForCell returns the three units (row, col, box) that contain the given cell
 func getUnitsForCell(cellIdx int) []Unit {
 	row, col := cellIdx/constants.GridSize, cellIdx%constants.GridSize
@@ -159,7 +211,7 @@ func DetectForcingChain(b BoardInterface) *core.Move {
 // For each candidate, propagate singles and find common conclusions
 func detectCellForcingChain(b BoardInterface) *core.Move {
 	// Find bivalue cells first (most likely to yield results), then trivalue
-	for numCands := 2; numCands <= 3; numCands++ {
+	for numCands := 2; numCands <= ForcingChainMaxCellCandidates; numCands++ {
 		for cell := 0; cell < constants.TotalCells; cell++ {
 			if b.GetCell(cell) != 0 || b.GetCandidatesAt(cell).Count() != numCands {
 				continue
@@ -183,17 +235,22 @@ func detectCellForcingChain(b BoardInterface) *core.Move {
 					if !res.valid {
 						// The other candidate must be correct
 						otherDigit := cands[1-i]
+						if !forcingAssignmentSane(b, cell, otherDigit) {
+							continue
+						}
 						row, col := cell/constants.GridSize, cell%constants.GridSize
-						return &core.Move{
+						return withTargetCandidates(b, explained(&core.Move{
 							Action:  "assign",
 							Digit:   otherDigit,
 							Targets: []core.CellRef{{Row: row, Col: col}},
-							Explanation: fmt.Sprintf("Cell Forcing Chain: If R%dC%d=%d, contradiction follows. Therefore R%dC%d=%d",
-								row+1, col+1, cands[i], row+1, col+1, otherDigit),
 							Highlights: core.Highlights{
 								Primary: []core.CellRef{{Row: row, Col: col}},
 							},
-						}
+						}, "technique.forcing-chain.contradiction", map[string]interface{}{
+							"cell":  FormatCell(cell),
+							"digit": cands[i],
+							"value": otherDigit,
+						}))
 					}
 				}
 			}
@@ -231,20 +288,23 @@ func detectCellForcingChain(b BoardInterface) *core.Move {
 					}
 				}
 
-				if commonDigit > 0 {
+				if commonDigit > 0 && forcingAssignmentSane(b, targetCell, commonDigit) {
 					row, col := cell/constants.GridSize, cell%constants.GridSize
 					targetRow, targetCol := targetCell/constants.GridSize, targetCell%constants.GridSize
-					return &core.Move{
+					return withTargetCandidates(b, explained(&core.Move{
 						Action:  "assign",
 						Digit:   commonDigit,
 						Targets: []core.CellRef{{Row: targetRow, Col: targetCol}},
-						Explanation: fmt.Sprintf("Cell Forcing Chain: All candidates in R%dC%d lead to R%dC%d=%d",
-							row+1, col+1, targetRow+1, targetCol+1, commonDigit),
 						Highlights: core.Highlights{
 							Primary:   []core.CellRef{{Row: targetRow, Col: targetCol}},
 							Secondary: []core.CellRef{{Row: row, Col: col}},
 						},
-					}
+					}, "technique.forcing-chain.assign", map[string]interface{}{
+						"cell":       FormatCell(cell),
+						"candidates": candidateListString(cands),
+						"target":     FormatCell(targetCell),
+						"digit":      commonDigit,
+					}))
 				}
 			}
 
@@ -272,23 +332,26 @@ func detectCellForcingChain(b BoardInterface) *core.Move {
 						}
 					}
 
-					if allEliminate {
+					if allEliminate && forcingEliminationSane(b, targetCell, digit) {
 						row, col := cell/constants.GridSize, cell%constants.GridSize
 						targetRow, targetCol := targetCell/constants.GridSize, targetCell%constants.GridSize
-						return &core.Move{
+						return explained(&core.Move{
 							Action:  "eliminate",
 							Digit:   digit,
 							Targets: []core.CellRef{{Row: row, Col: col}},
 							Eliminations: []core.Candidate{
 								{Row: targetRow, Col: targetCol, Digit: digit},
 							},
-							Explanation: fmt.Sprintf("Cell Forcing Chain: All candidates in R%dC%d lead to eliminating %d from R%dC%d",
-								row+1, col+1, digit, targetRow+1, targetCol+1),
 							Highlights: core.Highlights{
 								Primary:   []core.CellRef{{Row: row, Col: col}},
 								Secondary: []core.CellRef{{Row: targetRow, Col: targetCol}},
 							},
-						}
+						}, "technique.forcing-chain.eliminate", map[string]interface{}{
+							"cell":       FormatCell(cell),
+							"candidates": candidateListString(cands),
+							"target":     FormatCell(targetCell),
+							"digit":      digit,
+						})
 					}
 				}
 			}
@@ -312,8 +375,7 @@ func detectUnitForcingChain(b BoardInterface) *core.Move {
 			}
 
 			if len(positions) >= 2 && len(positions) <= 3 {
-				unitDesc := fmt.Sprintf("%s %d", unit.Type.String(), unit.Index+1)
-				if move := tryUnitForcingChain(b, digit, positions, unitDesc); move != nil {
+				if move := tryUnitForcingChain(b, digit, positions, unit.Type.String(), unit.Index); move != nil {
 					return move
 				}
 			}
@@ -324,7 +386,7 @@ func detectUnitForcingChain(b BoardInterface) *core.Move {
 }
 
 // tryUnitForcingChain tries forcing chains for a digit in specific positions within a unit
-func tryUnitForcingChain(b BoardInterface, digit int, positions []int, unitDesc string) *core.Move {
+func tryUnitForcingChain(b BoardInterface, digit int, positions []int, unitType string, unitIdx int) *core.Move {
 	results := make([]*propagationResult, len(positions))
 
 	// Propagate for each possible position
@@ -345,17 +407,23 @@ func tryUnitForcingChain(b BoardInterface, digit int, positions []int, unitDesc
 		for i, res := range results {
 			if res.valid {
 				cell := positions[i]
+				if !forcingAssignmentSane(b, cell, digit) {
+					continue
+				}
 				row, col := cell/constants.GridSize, cell%constants.GridSize
-				return &core.Move{
+				return withTargetCandidates(b, explained(&core.Move{
 					Action:  "assign",
 					Digit:   digit,
 					Targets: []core.CellRef{{Row: row, Col: col}},
-					Explanation: fmt.Sprintf("Unit Forcing Chain: In %s, %d at other positions leads to contradiction. R%dC%d=%d",
-						unitDesc, digit, row+1, col+1, digit),
 					Highlights: core.Highlights{
 						Primary: []core.CellRef{{Row: row, Col: col}},
 					},
-				}
+				}, "technique.forcing-chain.unit-contradiction", map[string]interface{}{
+					"unitType":  unitType,
+					"unitIndex": unitIdx + 1,
+					"digit":     digit,
+					"cell":      FormatCell(cell),
+				}))
 			}
 		}
 	}
@@ -398,23 +466,27 @@ func tryUnitForcingChain(b BoardInterface, digit int, positions []int, unitDesc
 			}
 		}
 
-		if commonDigit > 0 {
+		if commonDigit > 0 && forcingAssignmentSane(b, targetCell, commonDigit) {
 			targetRow, targetCol := targetCell/constants.GridSize, targetCell%constants.GridSize
 			var highlights []core.CellRef
 			for _, pos := range positions {
 				highlights = append(highlights, core.CellRef{Row: pos / constants.GridSize, Col: pos % constants.GridSize})
 			}
-			return &core.Move{
+			return withTargetCandidates(b, explained(&core.Move{
 				Action:  "assign",
 				Digit:   commonDigit,
 				Targets: []core.CellRef{{Row: targetRow, Col: targetCol}},
-				Explanation: fmt.Sprintf("Unit Forcing Chain: Wherever %d goes in %s, R%dC%d=%d",
-					digit, unitDesc, targetRow+1, targetCol+1, commonDigit),
 				Highlights: core.Highlights{
 					Primary:   []core.CellRef{{Row: targetRow, Col: targetCol}},
 					Secondary: highlights,
 				},
-			}
+			}, "technique.forcing-chain.unit-assign", map[string]interface{}{
+				"digit":     digit,
+				"unitType":  unitType,
+				"unitIndex": unitIdx + 1,
+				"cell":      FormatCell(targetCell),
+				"value":     commonDigit,
+			}))
 		}
 	}
 
@@ -455,26 +527,30 @@ func tryUnitForcingChain(b BoardInterface, digit int, positions []int, unitDesc
 				}
 			}
 
-			if allEliminate {
+			if allEliminate && forcingEliminationSane(b, targetCell, elimDigit) {
 				targetRow, targetCol := targetCell/constants.GridSize, targetCell%constants.GridSize
 				var highlights []core.CellRef
 				for _, pos := range positions {
 					highlights = append(highlights, core.CellRef{Row: pos / constants.GridSize, Col: pos % constants.GridSize})
 				}
-				return &core.Move{
+				return explained(&core.Move{
 					Action:  "eliminate",
 					Digit:   elimDigit,
 					Targets: highlights,
 					Eliminations: []core.Candidate{
 						{Row: targetRow, Col: targetCol, Digit: elimDigit},
 					},
-					Explanation: fmt.Sprintf("Unit Forcing Chain: Wherever %d goes in %s: eliminate %d from R%dC%d.",
-						digit, unitDesc, elimDigit, targetRow+1, targetCol+1),
 					Highlights: core.Highlights{
 						Primary:   highlights,
 						Secondary: []core.CellRef{{Row: targetRow, Col: targetCol}},
 					},
-				}
+				}, "technique.forcing-chain.unit-eliminate", map[string]interface{}{
+					"digit":     digit,
+					"unitType":  unitType,
+					"unitIndex": unitIdx + 1,
+					"elim":      elimDigit,
+					"cell":      FormatCell(targetCell),
+				})
 			}
 		}
 	}
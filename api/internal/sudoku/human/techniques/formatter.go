@@ -11,6 +11,25 @@ import (
 // Formatting Utilities
 // ============================================================================
 
+// explained attaches a structured explanation to a move and returns it, so
+// emit-style scans can set the message key inline
+func explained(move *core.Move, key string, params map[string]interface{}) *core.Move {
+	move.SetExplanation(key, params)
+	return move
+}
+
+// withTargetCandidates records the candidate set of each target cell at
+// detection time on an assign move (see core.Move.TargetCandidates). Every
+// assign-producing detector wraps its move in this so clients can render
+// exactly what the solver saw.
+func withTargetCandidates(b BoardInterface, move *core.Move) *core.Move {
+	move.TargetCandidates = make([][]int, len(move.Targets))
+	for i, target := range move.Targets {
+		move.TargetCandidates[i] = b.GetCandidatesAt(FromCellRef(target)).ToSlice()
+	}
+	return move
+}
+
 // FormatCell formats a cell index as "R{row}C{col}" (1-indexed for display)
 func FormatCell(cell int) string {
 	return fmt.Sprintf("R%dC%d", RowOf(cell)+1, ColOf(cell)+1)
@@ -5,11 +5,12 @@ import (
 	"sort"
 
 	"sudoku-api/internal/core"
+	"sudoku-api/internal/core/grid"
 	"sudoku-api/pkg/constants"
 )
 
 // ============================================================================
-// Precomputed Peer Data - Dynamic sizing based on constants
+// Precomputed Peer Data - views into the shared tables in core/grid
 // ============================================================================
 
 var (
@@ -36,59 +37,18 @@ var (
 )
 
 func init() {
-	initializePeers()
-}
-
-// initializePeers precomputes all peer relationships
-func initializePeers() {
-	// Initialize row/col/box indices first
-	for r := 0; r < constants.GridSize; r++ {
-		for c := 0; c < constants.GridSize; c++ {
-			idx := r*constants.GridSize + c
-			RowIndices[r] = append(RowIndices[r], idx)
-			ColIndices[c] = append(ColIndices[c], idx)
-
-			boxNum := (r/constants.BoxSize)*constants.BoxSize + c/constants.BoxSize
-			BoxIndices[boxNum] = append(BoxIndices[boxNum], idx)
-		}
-	}
-
-	// For each cell, compute its peers
+	// The tables themselves live in core/grid; these vars are slice views
+	// into them, kept for the many call sites that index them directly
 	for i := 0; i < constants.TotalCells; i++ {
-		row, col := i/constants.GridSize, i%constants.GridSize
-		boxNum := (row/constants.BoxSize)*constants.BoxSize + col/constants.BoxSize
-
-		// Collect unique peers (avoiding duplicates)
-		peerSet := make(map[int]bool)
-
-		// Row peers
-		for _, idx := range RowIndices[row] {
-			if idx != i {
-				RowPeers[i] = append(RowPeers[i], idx)
-				peerSet[idx] = true
-			}
-		}
-
-		// Column peers
-		for _, idx := range ColIndices[col] {
-			if idx != i {
-				ColPeers[i] = append(ColPeers[i], idx)
-				peerSet[idx] = true
-			}
-		}
-
-		// Box peers
-		for _, idx := range BoxIndices[boxNum] {
-			if idx != i {
-				BoxPeers[i] = append(BoxPeers[i], idx)
-				peerSet[idx] = true
-			}
-		}
-
-		// All unique peers
-		for peerIdx := range peerSet {
-			Peers[i] = append(Peers[i], peerIdx)
-		}
+		Peers[i] = grid.PeersOf(i)
+		RowPeers[i] = grid.RowPeersOf(i)
+		ColPeers[i] = grid.ColPeersOf(i)
+		BoxPeers[i] = grid.BoxPeersOf(i)
+	}
+	for u := 0; u < constants.GridSize; u++ {
+		RowIndices[u] = grid.Rows[u][:]
+		ColIndices[u] = grid.Cols[u][:]
+		BoxIndices[u] = grid.Boxes[u][:]
 	}
 }
 
@@ -96,25 +56,24 @@ func initializePeers() {
 // Coordinate Helpers
 // ============================================================================
 
-// RowOf returns the row number (0-15) for a cell index
+// RowOf returns the row number for a cell index
 func RowOf(idx int) int {
-	return idx / constants.GridSize
+	return grid.RowOf(idx)
 }
 
-// ColOf returns the column number (0-15) for a cell index
+// ColOf returns the column number for a cell index
 func ColOf(idx int) int {
-	return idx % constants.GridSize
+	return grid.ColOf(idx)
 }
 
-// BoxOf returns the box number (0-15) for a cell index
+// BoxOf returns the box number for a cell index
 func BoxOf(idx int) int {
-	row, col := idx/constants.GridSize, idx%constants.GridSize
-	return (row/constants.BoxSize)*constants.BoxSize + col/constants.BoxSize
+	return grid.BoxOf(idx)
 }
 
 // IndexOf returns the cell index for given row and column
 func IndexOf(row, col int) int {
-	return row*constants.GridSize + col
+	return grid.IndexOf(row, col)
 }
 
 // ToCellRef converts a cell index to a CellRef
@@ -158,10 +117,7 @@ func AreBoxPeers(idx1, idx2 int) bool {
 // ArePeers returns true if two cells can see each other (same row, col, or box)
 // and are not the same cell
 func ArePeers(idx1, idx2 int) bool {
-	if idx1 == idx2 {
-		return false
-	}
-	return AreRowPeers(idx1, idx2) || AreColPeers(idx1, idx2) || AreBoxPeers(idx1, idx2)
+	return grid.ArePeers(idx1, idx2)
 }
 
 // ============================================================================
@@ -289,6 +245,31 @@ func DedupeEliminations(elims []core.Candidate) []core.Candidate {
 	return result
 }
 
+// EliminationCells returns the distinct cells an elimination list touches,
+// in first-seen order, for the Target highlight role of eliminate moves
+func EliminationCells(elims []core.Candidate) []core.CellRef {
+	seen := make(map[core.CellRef]bool, len(elims))
+	var cells []core.CellRef
+	for _, e := range elims {
+		ref := core.CellRef{Row: e.Row, Col: e.Col}
+		if !seen[ref] {
+			seen[ref] = true
+			cells = append(cells, ref)
+		}
+	}
+	return cells
+}
+
+// DigitRefs pins one digit in each of the given cells, for the Justifying
+// highlight role of single-digit patterns (intersections, fish)
+func DigitRefs(cells []core.CellRef, digit int) []core.CandidateRef {
+	refs := make([]core.CandidateRef, len(cells))
+	for i, c := range cells {
+		refs[i] = core.CandidateRef{Row: c.Row, Col: c.Col, Digit: digit}
+	}
+	return refs
+}
+
 // AllSeeAll returns true if every cell in cellsA sees every cell in cellsB
 func AllSeeAll(cellsA, cellsB []int) bool {
 	for _, a := range cellsA {
@@ -369,13 +350,35 @@ type ALS struct {
 	ByDigit map[int][]int // For each digit, which cells contain it
 }
 
+// ALSCache is implemented by boards that memoize ALS enumeration across
+// detector calls (human.Board). FindAllALS consults it so the whole ALS
+// family (ALS-XZ, ALS-XY-Wing, ALS-XY-Chain, Death Blossom) shares one
+// enumeration per board state instead of recomputing it per detector.
+type ALSCache interface {
+	ALSets(maxSize int) []ALS
+}
+
 // FindAllALS finds all Almost Locked Sets in all units.
 // An ALS is a set of N cells containing exactly N+1 different candidates.
 // maxSize limits the ALS size (default 4 if <= 0).
+// Boards implementing ALSCache serve the result from their cache.
 func FindAllALS(b BoardInterface, maxSize int) []ALS {
 	if maxSize <= 0 {
 		maxSize = 4
 	}
+	if cache, ok := b.(ALSCache); ok {
+		return cache.ALSets(maxSize)
+	}
+	return EnumerateALS(b, maxSize)
+}
+
+// EnumerateALS performs the actual enumeration, bypassing any board cache.
+// Cache implementations call this to fill themselves; everyone else should
+// go through FindAllALS.
+func EnumerateALS(b BoardInterface, maxSize int) []ALS {
+	if maxSize <= 0 {
+		maxSize = 4
+	}
 
 	var allALS []ALS
 
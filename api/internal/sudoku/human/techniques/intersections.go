@@ -0,0 +1,281 @@
+package techniques
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// DetectPointingPair finds a digit in a box confined to two cells of one
+// row/column
+func DetectPointingPair(b BoardInterface) *core.Move {
+	return firstMove(scanPointingPairs, b)
+}
+
+// DetectPointingPairAll finds every pointing pair on the board, in scan order
+func DetectPointingPairAll(b BoardInterface) []*core.Move {
+	return allMoves(scanPointingPairs, b)
+}
+
+// DetectPointingTriple finds a digit in a box confined to three cells of one
+// row/column
+func DetectPointingTriple(b BoardInterface) *core.Move {
+	return firstMove(scanPointingTriples, b)
+}
+
+// DetectPointingTripleAll finds every pointing triple on the board, in scan
+// order
+func DetectPointingTripleAll(b BoardInterface) []*core.Move {
+	return allMoves(scanPointingTriples, b)
+}
+
+func scanPointingPairs(b BoardInterface, emit func(*core.Move) bool) {
+	scanPointingLocked(b, 2, "technique.pointing-pair", emit)
+}
+
+func scanPointingTriples(b BoardInterface, emit func(*core.Move) bool) {
+	scanPointingLocked(b, 3, "technique.pointing-triple", emit)
+}
+
+// scanPointingLocked is the shared locked-candidates scan for pointing
+// patterns: a digit whose box candidates all sit on one line, with the
+// pattern size (two or three cells) selecting which technique claims it
+func scanPointingLocked(b BoardInterface, size int, keyPrefix string, emit func(*core.Move) bool) {
+	for box := 0; box < constants.GridSize; box++ {
+		boxRow, boxCol := (box/3)*3, (box%3)*3
+
+		for digit := 1; digit <= constants.GridSize; digit++ {
+			var positions []core.CellRef
+			for r := boxRow; r < boxRow+3; r++ {
+				for c := boxCol; c < boxCol+3; c++ {
+					if b.GetCandidatesAt(r*constants.GridSize + c).Has(digit) {
+						positions = append(positions, core.CellRef{Row: r, Col: c})
+					}
+				}
+			}
+
+			if len(positions) != size {
+				continue
+			}
+
+			// Check if all in same row
+			sameRow := true
+			row := positions[0].Row
+			for _, p := range positions[1:] {
+				if p.Row != row {
+					sameRow = false
+					break
+				}
+			}
+
+			if sameRow {
+				var eliminations []core.Candidate
+				for c := 0; c < constants.GridSize; c++ {
+					if c >= boxCol && c < boxCol+3 {
+						continue
+					}
+					if b.GetCandidatesAt(row*constants.GridSize + c).Has(digit) {
+						eliminations = append(eliminations, core.Candidate{Row: row, Col: c, Digit: digit})
+					}
+				}
+				if len(eliminations) > 0 && emit(explained(&core.Move{
+					Action:       "eliminate",
+					Digit:        digit,
+					Targets:      positions,
+					Eliminations: eliminations,
+					Highlights: core.Highlights{
+						Primary:    positions,
+						Secondary:  ToCellRefs(RowIndices[row]),
+						Target:     EliminationCells(eliminations),
+						Defining:   positions,
+						Justifying: DigitRefs(positions, digit),
+						Eliminated: eliminations,
+					},
+				}, keyPrefix+".row", map[string]interface{}{
+					"box":   box + 1,
+					"digit": digit,
+					"row":   row + 1,
+				})) {
+					return
+				}
+			}
+
+			// Check if all in same column
+			sameCol := true
+			col := positions[0].Col
+			for _, p := range positions[1:] {
+				if p.Col != col {
+					sameCol = false
+					break
+				}
+			}
+
+			if sameCol {
+				var eliminations []core.Candidate
+				for r := 0; r < constants.GridSize; r++ {
+					if r >= boxRow && r < boxRow+3 {
+						continue
+					}
+					if b.GetCandidatesAt(r*constants.GridSize + col).Has(digit) {
+						eliminations = append(eliminations, core.Candidate{Row: r, Col: col, Digit: digit})
+					}
+				}
+				if len(eliminations) > 0 && emit(explained(&core.Move{
+					Action:       "eliminate",
+					Digit:        digit,
+					Targets:      positions,
+					Eliminations: eliminations,
+					Highlights: core.Highlights{
+						Primary:    positions,
+						Secondary:  ToCellRefs(ColIndices[col]),
+						Target:     EliminationCells(eliminations),
+						Defining:   positions,
+						Justifying: DigitRefs(positions, digit),
+						Eliminated: eliminations,
+					},
+				}, keyPrefix+".col", map[string]interface{}{
+					"box":   box + 1,
+					"digit": digit,
+					"col":   col + 1,
+				})) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DetectBoxLineReduction finds candidates in a row/column confined to one
+// box. The pattern is also known as claiming, and the registry exposes it
+// under both slugs.
+func DetectBoxLineReduction(b BoardInterface) *core.Move {
+	return firstMove(scanClaiming, b)
+}
+
+// DetectBoxLineReductionAll finds every box/line reduction on the board, in
+// scan order
+func DetectBoxLineReductionAll(b BoardInterface) []*core.Move {
+	return allMoves(scanClaiming, b)
+}
+
+func scanClaiming(b BoardInterface, emit func(*core.Move) bool) {
+	for row := 0; row < constants.GridSize; row++ {
+		for digit := 1; digit <= constants.GridSize; digit++ {
+			var positions []core.CellRef
+			for col := 0; col < constants.GridSize; col++ {
+				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
+					positions = append(positions, core.CellRef{Row: row, Col: col})
+				}
+			}
+
+			if len(positions) < 2 || len(positions) > 3 {
+				continue
+			}
+
+			// Check if all in same box
+			boxCol := (positions[0].Col / 3) * 3
+			sameBox := true
+			for _, p := range positions[1:] {
+				if (p.Col/3)*3 != boxCol {
+					sameBox = false
+					break
+				}
+			}
+
+			if sameBox {
+				boxRow := (row / 3) * 3
+				var eliminations []core.Candidate
+				for r := boxRow; r < boxRow+3; r++ {
+					if r == row {
+						continue
+					}
+					for c := boxCol; c < boxCol+3; c++ {
+						if b.GetCandidatesAt(r*constants.GridSize + c).Has(digit) {
+							eliminations = append(eliminations, core.Candidate{Row: r, Col: c, Digit: digit})
+						}
+					}
+				}
+				if len(eliminations) > 0 && emit(explained(&core.Move{
+					Action:       "eliminate",
+					Digit:        digit,
+					Targets:      positions,
+					Eliminations: eliminations,
+					Highlights: core.Highlights{
+						Primary:    positions,
+						Secondary:  ToCellRefs(BoxIndices[(row/3)*3+boxCol/3]),
+						Target:     EliminationCells(eliminations),
+						Defining:   positions,
+						Justifying: DigitRefs(positions, digit),
+						Eliminated: eliminations,
+					},
+				}, "technique.box-line-reduction.row", map[string]interface{}{
+					"row":   row + 1,
+					"box":   (row/3)*3 + boxCol/3 + 1,
+					"digit": digit,
+				})) {
+					return
+				}
+			}
+		}
+	}
+
+	// Check columns
+	for col := 0; col < constants.GridSize; col++ {
+		for digit := 1; digit <= constants.GridSize; digit++ {
+			var positions []core.CellRef
+			for row := 0; row < constants.GridSize; row++ {
+				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
+					positions = append(positions, core.CellRef{Row: row, Col: col})
+				}
+			}
+
+			if len(positions) < 2 || len(positions) > 3 {
+				continue
+			}
+
+			// Check if all in same box
+			boxRow := (positions[0].Row / 3) * 3
+			sameBox := true
+			for _, p := range positions[1:] {
+				if (p.Row/3)*3 != boxRow {
+					sameBox = false
+					break
+				}
+			}
+
+			if sameBox {
+				boxCol := (col / 3) * 3
+				var eliminations []core.Candidate
+				for r := boxRow; r < boxRow+3; r++ {
+					for c := boxCol; c < boxCol+3; c++ {
+						if c == col {
+							continue
+						}
+						if b.GetCandidatesAt(r*constants.GridSize + c).Has(digit) {
+							eliminations = append(eliminations, core.Candidate{Row: r, Col: c, Digit: digit})
+						}
+					}
+				}
+				if len(eliminations) > 0 && emit(explained(&core.Move{
+					Action:       "eliminate",
+					Digit:        digit,
+					Targets:      positions,
+					Eliminations: eliminations,
+					Highlights: core.Highlights{
+						Primary:    positions,
+						Secondary:  ToCellRefs(BoxIndices[boxRow/3*3+col/3]),
+						Target:     EliminationCells(eliminations),
+						Defining:   positions,
+						Justifying: DigitRefs(positions, digit),
+						Eliminated: eliminations,
+					},
+				}, "technique.box-line-reduction.col", map[string]interface{}{
+					"col":   col + 1,
+					"box":   (boxRow/3)*3 + col/3 + 1,
+					"digit": digit,
+				})) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package techniques
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// Locked subsets are naked pairs/triples confined to a single box that also
+// share a row or column. The subset then eliminates its digits from BOTH the
+// box remainder and the line remainder in one move, which the plain naked
+// pair/triple detectors would need two separate moves (one per unit) to find.
+
+// DetectLockedPair finds a naked pair confined to one box and one line
+func DetectLockedPair(b BoardInterface) *core.Move {
+	for boxIdx := 0; boxIdx < constants.GridSize; boxIdx++ {
+		var pairs []int
+		for _, idx := range BoxIndices[boxIdx] {
+			if b.GetCandidatesAt(idx).Count() == 2 {
+				pairs = append(pairs, idx)
+			}
+		}
+		for i := 0; i < len(pairs); i++ {
+			for j := i + 1; j < len(pairs); j++ {
+				idx1, idx2 := pairs[i], pairs[j]
+				if b.GetCandidatesAt(idx1) != b.GetCandidatesAt(idx2) {
+					continue
+				}
+				digits := b.GetCandidatesAt(idx1).ToSlice()
+				if move := buildLockedSubsetMove(b, boxIdx, []int{idx1, idx2}, digits, "technique.locked-pair"); move != nil {
+					return move
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// DetectLockedTriple finds a naked triple confined to one box and one line
+func DetectLockedTriple(b BoardInterface) *core.Move {
+	for boxIdx := 0; boxIdx < constants.GridSize; boxIdx++ {
+		var candidates []int
+		for _, idx := range BoxIndices[boxIdx] {
+			n := b.GetCandidatesAt(idx).Count()
+			if n >= 2 && n <= 3 {
+				candidates = append(candidates, idx)
+			}
+		}
+		for i := 0; i < len(candidates); i++ {
+			for j := i + 1; j < len(candidates); j++ {
+				for k := j + 1; k < len(candidates); k++ {
+					idx1, idx2, idx3 := candidates[i], candidates[j], candidates[k]
+					union := b.GetCandidatesAt(idx1).Union(b.GetCandidatesAt(idx2)).Union(b.GetCandidatesAt(idx3))
+					if union.Count() != 3 {
+						continue
+					}
+					if move := buildLockedSubsetMove(b, boxIdx, []int{idx1, idx2, idx3}, union.ToSlice(), "technique.locked-triple"); move != nil {
+						return move
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// buildLockedSubsetMove checks whether the subset cells (already confined to
+// box boxIdx) also share a row or column, and if so collects eliminations
+// from the box remainder and the line remainder. Returns nil when the subset
+// is not locked or eliminates nothing.
+func buildLockedSubsetMove(b BoardInterface, boxIdx int, subset []int, digits []int, key string) *core.Move {
+	// The subset must share a row or a column as well as the box
+	sameRow, sameCol := true, true
+	for _, idx := range subset[1:] {
+		if idx/constants.GridSize != subset[0]/constants.GridSize {
+			sameRow = false
+		}
+		if idx%constants.GridSize != subset[0]%constants.GridSize {
+			sameCol = false
+		}
+	}
+
+	var lineType string
+	var lineIdx int
+	var lineCells []int
+	switch {
+	case sameRow:
+		lineType = "row"
+		lineIdx = subset[0] / constants.GridSize
+		lineCells = RowIndices[lineIdx]
+	case sameCol:
+		lineType = "column"
+		lineIdx = subset[0] % constants.GridSize
+		lineCells = ColIndices[lineIdx]
+	default:
+		return nil
+	}
+
+	inSubset := make(map[int]bool, len(subset))
+	for _, idx := range subset {
+		inSubset[idx] = true
+	}
+	inBox := make(map[int]bool, constants.GridSize)
+	for _, idx := range BoxIndices[boxIdx] {
+		inBox[idx] = true
+	}
+
+	// Eliminate the subset digits from the box remainder, then from the part
+	// of the line outside the box
+	var eliminations []core.Candidate
+	for _, idx := range BoxIndices[boxIdx] {
+		if inSubset[idx] {
+			continue
+		}
+		for _, d := range digits {
+			if b.GetCandidatesAt(idx).Has(d) {
+				eliminations = append(eliminations, core.Candidate{
+					Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: d,
+				})
+			}
+		}
+	}
+	for _, idx := range lineCells {
+		if inSubset[idx] || inBox[idx] {
+			continue
+		}
+		for _, d := range digits {
+			if b.GetCandidatesAt(idx).Has(d) {
+				eliminations = append(eliminations, core.Candidate{
+					Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: d,
+				})
+			}
+		}
+	}
+
+	if len(eliminations) == 0 {
+		return nil
+	}
+
+	targets := ToCellRefs(subset)
+
+	// Secondary highlights cover both houses the subset locks
+	secondary := ToCellRefs(BoxIndices[boxIdx])
+	for _, idx := range lineCells {
+		if !inBox[idx] {
+			secondary = append(secondary, core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize})
+		}
+	}
+
+	return explained(&core.Move{
+		Action:       "eliminate",
+		Digit:        0,
+		Targets:      targets,
+		Eliminations: eliminations,
+		Unit:         &core.UnitRef{Type: "box", Index: boxIdx},
+		Highlights: core.Highlights{
+			Primary:   targets,
+			Secondary: secondary,
+		},
+	}, key, map[string]interface{}{
+		"digits":    digits,
+		"box":       boxIdx + 1,
+		"unitType":  lineType,
+		"unitIndex": lineIdx + 1,
+		"cells":     FormatCells(subset),
+	})
+}
@@ -1,7 +1,6 @@
 package techniques
 
 import (
-	"fmt"
 	"sort"
 
 	"sudoku-api/internal/core"
@@ -74,9 +73,10 @@ func DetectMedusa3D(b BoardInterface) *core.Move {
 		}
 	}
 
-	// Add strong link connections: same digit, conjugate pair in unit
-	for digit, pairs := range conjugatePairs {
-		for _, pair := range pairs {
+	// Add strong link connections: same digit, conjugate pair in unit.
+	// Iterate digits in order so adjacency lists are built deterministically
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		for _, pair := range conjugatePairs[digit] {
 			p1 := candidatePair{pair[0], digit}
 			p2 := candidatePair{pair[1], digit}
 			adj[p1.key()] = append(adj[p1.key()], p2)
@@ -243,18 +243,19 @@ func checkSameCellContradiction(b BoardInterface, colorToCheck, otherColor []can
 			if len(eliminations) > 0 {
 				// Collect all colored cells for highlighting
 				allPairs := append(colorToCheck, otherColor...)
-				return &core.Move{
+				return explained(&core.Move{
 					Action:       "eliminate",
 					Digit:        0,
 					Targets:      pairsToTargets(allPairs),
 					Eliminations: eliminations,
-					Explanation: fmt.Sprintf("3D Medusa: Color %d has two candidates in R%dC%d: eliminate all color %d.",
-						colorNum, cell/constants.GridSize+1, cell%constants.GridSize+1, colorNum),
 					Highlights: core.Highlights{
 						Primary:   pairsToTargets(colorToCheck),
 						Secondary: pairsToTargets(otherColor),
 					},
-				}
+				}, "technique.medusa-3d.twice-in-cell", map[string]interface{}{
+					"color": colorNum,
+					"cell":  FormatCell(cell),
+				})
 			}
 		}
 	}
@@ -287,19 +288,21 @@ func checkSameUnitContradiction(b BoardInterface, colorToCheck, otherColor []can
 
 					if len(eliminations) > 0 {
 						allPairs := append(colorToCheck, otherColor...)
-						return &core.Move{
+						return explained(&core.Move{
 							Action:       "eliminate",
 							Digit:        0,
 							Targets:      pairsToTargets(allPairs),
 							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("3D Medusa: Color %d has %d twice in same unit (R%dC%d, R%dC%d): eliminate all color %d.",
-								colorNum, digit, pairs[i].cell/constants.GridSize+1, pairs[i].cell%constants.GridSize+1,
-								pairs[j].cell/constants.GridSize+1, pairs[j].cell%constants.GridSize+1, colorNum),
 							Highlights: core.Highlights{
 								Primary:   pairsToTargets(colorToCheck),
 								Secondary: pairsToTargets(otherColor),
 							},
-						}
+						}, "technique.medusa-3d.twice-in-unit", map[string]interface{}{
+							"color": colorNum,
+							"digit": digit,
+							"cell1": FormatCell(pairs[i].cell),
+							"cell2": FormatCell(pairs[j].cell),
+						})
 					}
 				}
 			}
@@ -375,7 +378,7 @@ func checkUncoloredSeesBothColors(b BoardInterface, color1, color2 []candidatePa
 
 			if seesColor1 && seesColor2 {
 				allPairs := append(color1, color2...)
-				return &core.Move{
+				return explained(&core.Move{
 					Action: "eliminate",
 					Digit:  digit,
 					Targets: []core.CellRef{
@@ -384,13 +387,14 @@ func checkUncoloredSeesBothColors(b BoardInterface, color1, color2 []candidatePa
 					Eliminations: []core.Candidate{
 						{Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit},
 					},
-					Explanation: fmt.Sprintf("3D Medusa: R%dC%d sees %d in both colors: eliminate %d.",
-						cell/constants.GridSize+1, cell%constants.GridSize+1, digit, digit),
 					Highlights: core.Highlights{
 						Primary:   []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
 						Secondary: pairsToTargets(allPairs),
 					},
-				}
+				}, "technique.medusa-3d.sees-both", map[string]interface{}{
+					"cell":  FormatCell(cell),
+					"digit": digit,
+				})
 			}
 		}
 	}
@@ -454,7 +458,7 @@ func checkUncoloredInBicoloredCell(b BoardInterface, color1, color2 []candidateP
 			if !color1Set[cp.key()] && !color2Set[cp.key()] {
 				// Found an uncolored candidate in a bicolored cell - eliminate it
 				allPairs := append(color1, color2...)
-				return &core.Move{
+				return explained(&core.Move{
 					Action: "eliminate",
 					Digit:  digit,
 					Targets: []core.CellRef{
@@ -463,13 +467,14 @@ func checkUncoloredInBicoloredCell(b BoardInterface, color1, color2 []candidateP
 					Eliminations: []core.Candidate{
 						{Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit},
 					},
-					Explanation: fmt.Sprintf("3D Medusa: R%dC%d has candidates in both colors: eliminate uncolored %d.",
-						cell/constants.GridSize+1, cell%constants.GridSize+1, digit),
 					Highlights: core.Highlights{
 						Primary:   []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
 						Secondary: pairsToTargets(allPairs),
 					},
-				}
+				}, "technique.medusa-3d.both-in-cell", map[string]interface{}{
+					"cell":  FormatCell(cell),
+					"digit": digit,
+				})
 			}
 		}
 	}
@@ -531,7 +536,7 @@ func checkUncoloredSeesColorAndOppositeInCell(b BoardInterface, color1, color2 [
 					if ArePeers(cell, c1) {
 						// Elimination: sees Color1 in unit, has Color2 in cell
 						allPairs := append(color1, color2...)
-						return &core.Move{
+						return explained(&core.Move{
 							Action: "eliminate",
 							Digit:  digit,
 							Targets: []core.CellRef{
@@ -540,13 +545,16 @@ func checkUncoloredSeesColorAndOppositeInCell(b BoardInterface, color1, color2 [
 							Eliminations: []core.Candidate{
 								{Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit},
 							},
-							Explanation: fmt.Sprintf("3D Medusa: R%dC%d has color 2 and sees %d in color 1: eliminate %d.",
-								cell/constants.GridSize+1, cell%constants.GridSize+1, digit, digit),
 							Highlights: core.Highlights{
 								Primary:   []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
 								Secondary: pairsToTargets(allPairs),
 							},
-						}
+						}, "technique.medusa-3d.sees-color", map[string]interface{}{
+							"cell":       FormatCell(cell),
+							"cellColor":  2,
+							"digit":      digit,
+							"otherColor": 1,
+						})
 					}
 				}
 			}
@@ -558,7 +566,7 @@ func checkUncoloredSeesColorAndOppositeInCell(b BoardInterface, color1, color2 [
 					if ArePeers(cell, c2) {
 						// Elimination: sees Color2 in unit, has Color1 in cell
 						allPairs := append(color1, color2...)
-						return &core.Move{
+						return explained(&core.Move{
 							Action: "eliminate",
 							Digit:  digit,
 							Targets: []core.CellRef{
@@ -567,13 +575,16 @@ func checkUncoloredSeesColorAndOppositeInCell(b BoardInterface, color1, color2 [
 							Eliminations: []core.Candidate{
 								{Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit},
 							},
-							Explanation: fmt.Sprintf("3D Medusa: R%dC%d has color 1 and sees %d in color 2: eliminate %d.",
-								cell/constants.GridSize+1, cell%constants.GridSize+1, digit, digit),
 							Highlights: core.Highlights{
 								Primary:   []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
 								Secondary: pairsToTargets(allPairs),
 							},
-						}
+						}, "technique.medusa-3d.sees-color", map[string]interface{}{
+							"cell":       FormatCell(cell),
+							"cellColor":  1,
+							"digit":      digit,
+							"otherColor": 2,
+						})
 					}
 				}
 			}
@@ -630,18 +641,19 @@ func checkAllCandidatesSameColor(b BoardInterface, colorToCheck, otherColor []ca
 
 			if len(eliminations) > 0 {
 				allPairs := append(colorToCheck, otherColor...)
-				return &core.Move{
+				return explained(&core.Move{
 					Action:       "eliminate",
 					Digit:        0,
 					Targets:      pairsToTargets(allPairs),
 					Eliminations: eliminations,
-					Explanation: fmt.Sprintf("3D Medusa: R%dC%d has all candidates in color %d: eliminate all color %d.",
-						cell/constants.GridSize+1, cell%constants.GridSize+1, colorNum, colorNum),
 					Highlights: core.Highlights{
 						Primary:   []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
 						Secondary: pairsToTargets(allPairs),
 					},
-				}
+				}, "technique.medusa-3d.all-in-color", map[string]interface{}{
+					"cell":  FormatCell(cell),
+					"color": colorNum,
+				})
 			}
 		}
 	}
@@ -0,0 +1,85 @@
+package techniques
+
+import (
+	"sudoku-api/internal/core"
+)
+
+// NiceLoopMaxNodes bounds the Nice Loop search depth in candidate nodes. Ten
+// nodes keeps the loops at a length a solver following the narration can
+// still verify step by step.
+var NiceLoopMaxNodes = 10
+
+// DetectNiceLoop finds Nice Loops: alternating chains restricted to the
+// AIC-lite node set of bivalue cells and bilocation candidates (a digit with
+// only two places left in one of the cell's units), so every step reads as
+// "the other digit" or "the other place". Both loop kinds are reported: a
+// discontinuous loop eliminates the candidates seeing both chain ends, and a
+// continuous loop eliminates the off-chain candidates covered by its weak
+// links. Unlike the other chain wrappers, the explanation carries a
+// step-by-step narration built from the chain structure. Implemented over
+// the shared engine in chain_engine.go
+func DetectNiceLoop(b BoardInterface) *core.Move {
+	engine := newChainEngine(b, func(cell, digit int) bool {
+		return isBivalueCell(b, cell) || isBilocation(b, cell, digit)
+	})
+	result := engine.findBestChain(chainSearch{
+		maxNodes:   NiceLoopMaxNodes,
+		allowLoops: true,
+		loopsFirst: true,
+	})
+	if result == nil {
+		return nil
+	}
+
+	head, tail := result.nodes[0], result.nodes[len(result.nodes)-1]
+	if result.loop {
+		return chainEliminationMove(result, "technique.nice-loop.loop", map[string]interface{}{
+			"head":      FormatCell(head.cell),
+			"narration": narrateChain(result.nodes),
+		})
+	}
+	return chainEliminationMove(result, "technique.nice-loop", map[string]interface{}{
+		"head":      FormatCell(head.cell),
+		"tail":      FormatCell(tail.cell),
+		"narration": narrateChain(result.nodes),
+	})
+}
+
+// isBivalueCell reports whether a cell holds exactly two candidates
+func isBivalueCell(b BoardInterface, cell int) bool {
+	return b.GetCandidatesAt(cell).Count() == 2
+}
+
+// isBilocation reports whether the digit has exactly two places left in one
+// of the cell's units, making this candidate half of a conjugate pair
+func isBilocation(b BoardInterface, cell, digit int) bool {
+	units := []Unit{
+		{Type: UnitRow, Index: RowOf(cell), Cells: RowIndices[RowOf(cell)]},
+		{Type: UnitCol, Index: ColOf(cell), Cells: ColIndices[ColOf(cell)]},
+		{Type: UnitBox, Index: BoxOf(cell), Cells: BoxIndices[BoxOf(cell)]},
+	}
+	for _, unit := range units {
+		if len(b.CellsWithDigitInUnit(unit, digit)) == 2 {
+			return true
+		}
+	}
+	return false
+}
+
+// narrateChain converts a node chain into the explanation narration, reading
+// the chain under the assumption that the head candidate is false — the
+// premise both loop kinds share. Each strong link then forces the next node
+// true and each weak link forces it false, and since the chain alternates
+// starting strong, a node's parity determines both its truth and its link
+func narrateChain(nodes []candidateNode) core.ChainNarration {
+	narration := make(core.ChainNarration, len(nodes))
+	for i, n := range nodes {
+		narration[i] = core.ChainStep{
+			Cell:   FormatCell(n.cell),
+			Digit:  n.digit,
+			True:   i%2 == 1,
+			Strong: i%2 == 1,
+		}
+	}
+	return narration
+}
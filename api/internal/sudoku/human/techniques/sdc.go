@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -10,13 +8,18 @@ import (
 // DetectSueDeCoq finds Sue de Coq (Two-Sector Disjoint Subset) patterns.
 //
 // A Sue de Coq occurs at the intersection of a box and a line (row/column):
-//   - The intersection has 2-3 cells with N candidates total
-//   - Find an Almost Locked Set (ALS) in the REST of the box (not in the line)
-//     that shares some candidates with the intersection
-//   - Find an ALS in the REST of the line (not in the box) that shares other candidates
-//   - If the two ALS together cover all N candidates with no overlap, eliminations can be made:
-//   - Eliminate ALS-A candidates from rest of box
-//   - Eliminate ALS-B candidates from rest of line
+//   - The intersection has N cells (2 or 3) with N+2 candidates total
+//   - An ALS in the REST of the line (a bivalue cell, or k cells with k+1
+//     candidates) and an ALS in the REST of the box have disjoint candidate
+//     sets
+//   - The distinct digits across intersection and both ALS equal the total
+//     cell count, so every digit is locked: line-ALS digits are confined to
+//     the intersection plus the line ALS, box-ALS digits to the intersection
+//     plus the box ALS, and leftover intersection digits to the intersection
+//
+// Eliminations: line-ALS digits and intersection-only digits go from the rest
+// of the line; box-ALS digits and intersection-only digits go from the rest
+// of the box.
 func DetectSueDeCoq(b BoardInterface) *core.Move {
 	// Try each box
 	for box := 0; box < constants.GridSize; box++ {
@@ -47,19 +50,9 @@ func detectSueDeCoqIntersection(b BoardInterface, box int, lineIdx int, isRow bo
 
 	// Get intersection cells (cells that are in both box and line)
 	var intersectionCells []int
-	if isRow {
-		for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-			idx := lineIdx*constants.GridSize + c
-			if b.GetCell(idx) == 0 && b.GetCandidatesAt(idx).Count() > 0 {
-				intersectionCells = append(intersectionCells, idx)
-			}
-		}
-	} else {
-		for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-			idx := r*constants.GridSize + lineIdx
-			if b.GetCell(idx) == 0 && b.GetCandidatesAt(idx).Count() > 0 {
-				intersectionCells = append(intersectionCells, idx)
-			}
+	for _, idx := range intersectionIndices(box, lineIdx, isRow) {
+		if b.GetCell(idx) == 0 && b.GetCandidatesAt(idx).Count() > 0 {
+			intersectionCells = append(intersectionCells, idx)
 		}
 	}
 
@@ -68,376 +61,211 @@ func detectSueDeCoqIntersection(b BoardInterface, box int, lineIdx int, isRow bo
 		return nil
 	}
 
-	// Get combined candidates of intersection cells
+	// Get combined candidates of intersection cells: N cells must hold
+	// exactly N+2 candidates so the two disjoint ALS can absorb the surplus
 	var intersectionCands Candidates
 	for _, idx := range intersectionCells {
 		intersectionCands = intersectionCands.Union(b.GetCandidatesAt(idx))
 	}
-	intersectionDigits := intersectionCands.ToSlice()
-
-	// For a valid Sue de Coq with N intersection cells:
-	// We need at least N+2 candidates (to cover with 2 ALS)
-	// The simpler case: 2 cells with 4 candidates, each ALS covers 2
-	if len(intersectionDigits) < len(intersectionCells)+2 {
+	if intersectionCands.Count() != len(intersectionCells)+2 {
 		return nil
 	}
 
-	// Get box remainder cells (in box but not in intersection)
+	inIntersection := func(idx int) bool {
+		for _, iCell := range intersectionCells {
+			if idx == iCell {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Get box remainder cells (in box but not in the line)
 	var boxRemainderCells []int
 	for r := boxRow; r < boxRow+constants.BoxSize; r++ {
 		for c := boxCol; c < boxCol+constants.BoxSize; c++ {
 			idx := r*constants.GridSize + c
-			if b.GetCell(idx) != 0 || b.GetCandidatesAt(idx).Count() == 0 {
+			if b.GetCell(idx) != 0 || b.GetCandidatesAt(idx).Count() == 0 || inIntersection(idx) {
 				continue
 			}
-			// Check if in intersection
-			inIntersection := false
-			for _, iCell := range intersectionCells {
-				if idx == iCell {
-					inIntersection = true
-					break
-				}
-			}
-			if !inIntersection {
-				boxRemainderCells = append(boxRemainderCells, idx)
+			if isRow && r == lineIdx || !isRow && c == lineIdx {
+				continue
 			}
+			boxRemainderCells = append(boxRemainderCells, idx)
 		}
 	}
 
-	// Get line remainder cells (in line but not in intersection)
+	// Get line remainder cells (in line but not in the box)
 	var lineRemainderCells []int
-	if isRow {
-		for c := 0; c < constants.GridSize; c++ {
-			// Skip cells in the box
-			if c >= boxCol && c < boxCol+constants.BoxSize {
+	for i := 0; i < constants.GridSize; i++ {
+		var idx int
+		if isRow {
+			if i >= boxCol && i < boxCol+constants.BoxSize {
 				continue
 			}
-			idx := lineIdx*constants.GridSize + c
-			if b.GetCell(idx) == 0 && b.GetCandidatesAt(idx).Count() > 0 {
-				lineRemainderCells = append(lineRemainderCells, idx)
-			}
-		}
-	} else {
-		for r := 0; r < constants.GridSize; r++ {
-			// Skip cells in the box
-			if r >= boxRow && r < boxRow+constants.BoxSize {
+			idx = lineIdx*constants.GridSize + i
+		} else {
+			if i >= boxRow && i < boxRow+constants.BoxSize {
 				continue
 			}
-			idx := r*constants.GridSize + lineIdx
-			if b.GetCell(idx) == 0 && b.GetCandidatesAt(idx).Count() > 0 {
-				lineRemainderCells = append(lineRemainderCells, idx)
-			}
+			idx = i*constants.GridSize + lineIdx
+		}
+		if b.GetCell(idx) == 0 && b.GetCandidatesAt(idx).Count() > 0 {
+			lineRemainderCells = append(lineRemainderCells, idx)
 		}
 	}
 
-	// Find ALS candidates in box remainder that share candidates with intersection
-	boxALSList := findALSInCells(b, boxRemainderCells, intersectionDigits)
+	boxALSList := findALSInCells(b, boxRemainderCells, intersectionCands)
+	lineALSList := findALSInCells(b, lineRemainderCells, intersectionCands)
 
-	// Find ALS candidates in line remainder that share candidates with intersection
-	lineALSList := findALSInCells(b, lineRemainderCells, intersectionDigits)
+	// Try all combinations of line-ALS and box-ALS
+	for _, lineALS := range lineALSList {
+		lineDigits := NewCandidates(lineALS.Digits)
+		for _, boxALS := range boxALSList {
+			boxDigits := NewCandidates(boxALS.Digits)
 
-	// Try all combinations of box-ALS and line-ALS
-	for _, boxALS := range boxALSList {
-		for _, lineALS := range lineALSList {
-			// The ALS candidates must not overlap
-			if digitsOverlap(boxALS.Digits, lineALS.Digits) {
+			// The two ALS candidate sets must be disjoint
+			if !lineDigits.Intersect(boxDigits).IsEmpty() {
 				continue
 			}
 
-			// Combined ALS candidates must cover all intersection candidates exactly
-			combinedALS := NewCandidates(boxALS.Digits).Union(NewCandidates(lineALS.Digits))
-
-			// Check if combined covers exactly the intersection candidates
-			if combinedALS != intersectionCands {
+			// The counting argument needs exactly as many distinct digits as
+			// cells across intersection + both ALS; only then is every digit
+			// locked to its sector
+			allDigits := intersectionCands.Union(lineDigits).Union(boxDigits)
+			if allDigits.Count() != len(intersectionCells)+len(lineALS.Cells)+len(boxALS.Cells) {
 				continue
 			}
 
-			// Found a valid Sue de Coq pattern! Now find eliminations.
-			var eliminations []core.Candidate
+			// Intersection-only digits are confined to the intersection
+			// itself, so they leave both remainders
+			ioDigits := intersectionCands.Subtract(lineDigits).Subtract(boxDigits)
+			lineElim := lineDigits.Union(ioDigits)
+			boxElim := boxDigits.Union(ioDigits)
 
-			// Eliminate boxALS digits from rest of box (excluding intersection and boxALS cells)
-			for r := boxRow; r < boxRow+3; r++ {
-				for c := boxCol; c < boxCol+3; c++ {
-					idx := r*constants.GridSize + c
-					if b.GetCell(idx) != 0 {
-						continue
-					}
-
-					// Skip intersection cells
-					inIntersection := false
-					for _, iCell := range intersectionCells {
-						if idx == iCell {
-							inIntersection = true
-							break
-						}
-					}
-					if inIntersection {
-						continue
-					}
-
-					// Skip boxALS cells
-					inBoxALS := false
-					for _, aCell := range boxALS.Cells {
-						if idx == aCell {
-							inBoxALS = true
-							break
-						}
-					}
-					if inBoxALS {
-						continue
-					}
+			var eliminations []core.Candidate
 
-					// Eliminate boxALS digits
-					for _, d := range boxALS.Digits {
-						if b.GetCandidatesAt(idx).Has(d) {
-							eliminations = append(eliminations, core.Candidate{
-								Row: r, Col: c, Digit: d,
-							})
-						}
+			// Eliminate from rest of line (excluding lineALS cells)
+			for _, idx := range lineRemainderCells {
+				if containsCell(lineALS.Cells, idx) {
+					continue
+				}
+				for _, d := range lineElim.ToSlice() {
+					if b.GetCandidatesAt(idx).Has(d) {
+						eliminations = append(eliminations, core.Candidate{
+							Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: d,
+						})
 					}
 				}
 			}
 
-			// Eliminate lineALS digits from rest of line (excluding intersection and lineALS cells)
-			if isRow {
-				for c := 0; c < constants.GridSize; c++ {
-					idx := lineIdx*constants.GridSize + c
-
-					// Skip cells in box
-					if c >= boxCol && c < boxCol+3 {
-						continue
-					}
-
-					if b.GetCell(idx) != 0 {
-						continue
-					}
-
-					// Skip lineALS cells
-					inLineALS := false
-					for _, aCell := range lineALS.Cells {
-						if idx == aCell {
-							inLineALS = true
-							break
-						}
-					}
-					if inLineALS {
-						continue
-					}
-
-					// Eliminate lineALS digits
-					for _, d := range lineALS.Digits {
-						if b.GetCandidatesAt(idx).Has(d) {
-							eliminations = append(eliminations, core.Candidate{
-								Row: lineIdx, Col: c, Digit: d,
-							})
-						}
-					}
+			// Eliminate from rest of box (excluding boxALS cells)
+			for _, idx := range boxRemainderCells {
+				if containsCell(boxALS.Cells, idx) {
+					continue
 				}
-			} else {
-				for r := 0; r < constants.GridSize; r++ {
-					idx := r*constants.GridSize + lineIdx
-
-					// Skip cells in box
-					if r >= boxRow && r < boxRow+3 {
-						continue
-					}
-
-					if b.GetCell(idx) != 0 {
-						continue
-					}
-
-					// Skip lineALS cells
-					inLineALS := false
-					for _, aCell := range lineALS.Cells {
-						if idx == aCell {
-							inLineALS = true
-							break
-						}
-					}
-					if inLineALS {
-						continue
-					}
-
-					// Eliminate lineALS digits
-					for _, d := range lineALS.Digits {
-						if b.GetCandidatesAt(idx).Has(d) {
-							eliminations = append(eliminations, core.Candidate{
-								Row: r, Col: lineIdx, Digit: d,
-							})
-						}
+				for _, d := range boxElim.ToSlice() {
+					if b.GetCandidatesAt(idx).Has(d) {
+						eliminations = append(eliminations, core.Candidate{
+							Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: d,
+						})
 					}
 				}
 			}
 
-			if len(eliminations) > 0 {
-				// Build targets: intersection cells + both ALS cells
-				var targets []core.CellRef
-				var primary []core.CellRef
-				var secondary []core.CellRef
-
-				for _, idx := range intersectionCells {
-					ref := core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize}
-					targets = append(targets, ref)
-					primary = append(primary, ref)
-				}
-				for _, idx := range boxALS.Cells {
-					ref := core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize}
-					targets = append(targets, ref)
-					secondary = append(secondary, ref)
-				}
-				for _, idx := range lineALS.Cells {
-					ref := core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize}
-					targets = append(targets, ref)
-					secondary = append(secondary, ref)
-				}
+			if len(eliminations) == 0 {
+				continue
+			}
 
-				lineType := "row"
-				lineNum := lineIdx + 1
-				if !isRow {
-					lineType = "column"
-				}
+			// Build targets: intersection cells + both ALS cells. Highlights
+			// keep the three roles apart for rendering.
+			var targets []core.CellRef
+			for _, idx := range intersectionCells {
+				targets = append(targets, core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize})
+			}
+			primary := append([]core.CellRef(nil), targets...)
+			for _, idx := range lineALS.Cells {
+				targets = append(targets, core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize})
+			}
+			for _, idx := range boxALS.Cells {
+				targets = append(targets, core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize})
+			}
 
-				return &core.Move{
-					Action:       "eliminate",
-					Digit:        0,
-					Targets:      targets,
-					Eliminations: eliminations,
-					Explanation: fmt.Sprintf("Sue de Coq: intersection of box %d and %s %d with candidates {%s}; "+
-						"box ALS {%s} covers {%s}, %s ALS {%s} covers {%s}",
-						box+1, lineType, lineNum,
-						FormatDigits(intersectionDigits),
-						FormatCells(boxALS.Cells), FormatDigits(boxALS.Digits),
-						lineType, FormatCells(lineALS.Cells), FormatDigits(lineALS.Digits)),
-					Highlights: core.Highlights{
-						Primary:   primary,
-						Secondary: secondary,
-					},
-				}
+			lineType := "row"
+			if !isRow {
+				lineType = "column"
 			}
+
+			return explained(&core.Move{
+				Action:       "eliminate",
+				Digit:        0,
+				Targets:      targets,
+				Eliminations: eliminations,
+				Highlights: core.Highlights{
+					Primary:   primary,
+					Secondary: ToCellRefs(lineALS.Cells),
+					Tertiary:  ToCellRefs(boxALS.Cells),
+				},
+			}, "technique.sue-de-coq", map[string]interface{}{
+				"box":        box + 1,
+				"unitType":   lineType,
+				"unitIndex":  lineIdx + 1,
+				"digits":     intersectionCands.ToSlice(),
+				"lineCells":  FormatCells(lineALS.Cells),
+				"lineDigits": lineALS.Digits,
+				"boxCells":   FormatCells(boxALS.Cells),
+				"boxDigits":  boxALS.Digits,
+			})
 		}
 	}
 
 	return nil
 }
 
-// findALSInCells finds Almost Locked Sets within the given cells
-// that share at least one digit with the intersection digits.
-// The ALS may contain extra digits - we filter by overlap, not exact match.
-func findALSInCells(b BoardInterface, cells []int, intersectionDigits []int) []ALS {
-	var result []ALS
-
-	intersectionSet := NewCandidates(intersectionDigits)
-
-	// Helper to check if ALS shares at least one digit with intersection
-	sharesDigitWithIntersection := func(digits Candidates) bool {
-		return digits.Intersect(intersectionSet) != 0
-	}
-
-	// Helper to get only intersection-overlapping digits from ALS
-	getOverlappingDigits := func(digits Candidates) []int {
-		return digits.Intersect(intersectionSet).ToSlice()
+// intersectionIndices returns the three cell indices shared by a box and a line
+func intersectionIndices(box, lineIdx int, isRow bool) []int {
+	boxRow, boxCol := (box/constants.BoxSize)*constants.BoxSize, (box%constants.BoxSize)*constants.BoxSize
+	indices := make([]int, 0, constants.BoxSize)
+	for i := 0; i < constants.BoxSize; i++ {
+		if isRow {
+			indices = append(indices, lineIdx*constants.GridSize+boxCol+i)
+		} else {
+			indices = append(indices, (boxRow+i)*constants.GridSize+lineIdx)
+		}
 	}
+	return indices
+}
 
-	// Try ALS of size 1 (bivalue cell - 1 cell with 2 candidates)
-	for _, cell := range cells {
-		cands := b.GetCandidatesAt(cell)
+// findALSInCells finds Almost Locked Sets (k cells with k+1 candidates, sizes
+// 1-3) within the given cells that share at least one digit with the
+// intersection. Digits holds the full candidate set of the ALS - Sue de Coq's
+// counting needs every digit the ALS cells can take, not just the overlap.
+func findALSInCells(b BoardInterface, cells []int, intersectionCands Candidates) []ALS {
+	var result []ALS
 
-		// For ALS: N cells need N+1 candidates
-		// 1 cell needs 2 candidates
-		if cands.Count() != 2 {
-			continue
+	addIfALS := func(subset []int) {
+		var combined Candidates
+		for _, cell := range subset {
+			combined = combined.Union(b.GetCandidatesAt(cell))
 		}
-
-		// Must share at least one digit with intersection
-		if !sharesDigitWithIntersection(cands) {
-			continue
+		if combined.Count() != len(subset)+1 {
+			return
 		}
-
-		// Store only the overlapping digits for matching purposes
-		overlapDigits := getOverlappingDigits(cands)
-
-		byDigit := make(map[int][]int)
-		for _, d := range cands.ToSlice() {
-			byDigit[d] = []int{cell}
+		if combined.Intersect(intersectionCands).IsEmpty() {
+			return
 		}
 		result = append(result, ALS{
-			Cells:   []int{cell},
-			Digits:  overlapDigits, // Only intersection-overlapping digits
-			ByDigit: byDigit,
+			Cells:  append([]int(nil), subset...),
+			Digits: combined.ToSlice(),
 		})
 	}
 
-	// Try ALS of size 2 (2 cells with 3 candidates total)
-	for i := 0; i < len(cells); i++ {
-		for j := i + 1; j < len(cells); j++ {
-			combined := b.GetCandidatesAt(cells[i]).Union(b.GetCandidatesAt(cells[j]))
-
-			// For ALS: 2 cells need 3 candidates
-			if combined.Count() != 3 {
-				continue
-			}
-
-			// Must share at least one digit with intersection
-			if !sharesDigitWithIntersection(combined) {
-				continue
-			}
-
-			overlapDigits := getOverlappingDigits(combined)
-			digits := combined.ToSlice()
-
-			byDigit := make(map[int][]int)
-			for _, d := range digits {
-				if b.GetCandidatesAt(cells[i]).Has(d) {
-					byDigit[d] = append(byDigit[d], cells[i])
-				}
-				if b.GetCandidatesAt(cells[j]).Has(d) {
-					byDigit[d] = append(byDigit[d], cells[j])
-				}
-			}
-			result = append(result, ALS{
-				Cells:   []int{cells[i], cells[j]},
-				Digits:  overlapDigits, // Only intersection-overlapping digits
-				ByDigit: byDigit,
-			})
-		}
-	}
-
-	// Try ALS of size 3 (3 cells with 4 candidates total) - less common but possible
 	for i := 0; i < len(cells); i++ {
+		addIfALS([]int{cells[i]})
 		for j := i + 1; j < len(cells); j++ {
+			addIfALS([]int{cells[i], cells[j]})
 			for k := j + 1; k < len(cells); k++ {
-				combined := b.GetCandidatesAt(cells[i]).Union(b.GetCandidatesAt(cells[j])).Union(b.GetCandidatesAt(cells[k]))
-
-				// For ALS: 3 cells need 4 candidates
-				if combined.Count() != 4 {
-					continue
-				}
-
-				// Must share at least one digit with intersection
-				if !sharesDigitWithIntersection(combined) {
-					continue
-				}
-
-				overlapDigits := getOverlappingDigits(combined)
-				digits := combined.ToSlice()
-
-				byDigit := make(map[int][]int)
-				for _, d := range digits {
-					if b.GetCandidatesAt(cells[i]).Has(d) {
-						byDigit[d] = append(byDigit[d], cells[i])
-					}
-					if b.GetCandidatesAt(cells[j]).Has(d) {
-						byDigit[d] = append(byDigit[d], cells[j])
-					}
-					if b.GetCandidatesAt(cells[k]).Has(d) {
-						byDigit[d] = append(byDigit[d], cells[k])
-					}
-				}
-				result = append(result, ALS{
-					Cells:   []int{cells[i], cells[j], cells[k]},
-					Digits:  overlapDigits, // Only intersection-overlapping digits
-					ByDigit: byDigit,
-				})
+				addIfALS([]int{cells[i], cells[j], cells[k]})
 			}
 		}
 	}
@@ -445,7 +273,12 @@ func findALSInCells(b BoardInterface, cells []int, intersectionDigits []int) []A
 	return result
 }
 
-// digitsOverlap returns true if the two digit slices share any common digit
-func digitsOverlap(a, b []int) bool {
-	return NewCandidates(a).Intersect(NewCandidates(b)) != 0
+// containsCell reports whether idx is one of cells
+func containsCell(cells []int, idx int) bool {
+	for _, c := range cells {
+		if c == idx {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,230 @@
+package techniques
+
+import (
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// DetectNakedSingle finds a cell with only one candidate
+func DetectNakedSingle(b BoardInterface) *core.Move {
+	return firstMove(scanNakedSingles, b)
+}
+
+// DetectNakedSingleAll finds every naked single on the board, in scan order
+func DetectNakedSingleAll(b BoardInterface) []*core.Move {
+	return allMoves(scanNakedSingles, b)
+}
+
+func scanNakedSingles(b BoardInterface, emit func(*core.Move) bool) {
+	for i := 0; i < constants.GridSize*constants.GridSize; i++ {
+		if b.GetCell(i) == 0 && b.GetCandidatesAt(i).Count() == 1 {
+			digit, _ := b.GetCandidatesAt(i).Only()
+			row, col := i/constants.GridSize, i%constants.GridSize
+
+			if emit(withTargetCandidates(b, explained(&core.Move{
+				Action:  "assign",
+				Digit:   digit,
+				Targets: []core.CellRef{{Row: row, Col: col}},
+				Highlights: core.Highlights{
+					Primary:    []core.CellRef{{Row: row, Col: col}},
+					Target:     []core.CellRef{{Row: row, Col: col}},
+					Defining:   []core.CellRef{{Row: row, Col: col}},
+					Justifying: []core.CandidateRef{{Row: row, Col: col, Digit: digit}},
+				},
+			}, "technique.naked-single", map[string]interface{}{
+				"cell":  FormatCell(i),
+				"digit": digit,
+			}))) {
+				return
+			}
+		}
+	}
+}
+
+// DetectHiddenSingle finds a digit that can only go in one cell within a unit
+func DetectHiddenSingle(b BoardInterface) *core.Move {
+	return firstMove(scanHiddenSingles, b)
+}
+
+// DetectHiddenSingleAll finds every hidden single on the board. A cell that
+// is the digit's only home in several units is reported once.
+func DetectHiddenSingleAll(b BoardInterface) []*core.Move {
+	return allMoves(scanHiddenSingles, b)
+}
+
+func scanHiddenSingles(b BoardInterface, emit func(*core.Move) bool) {
+	// The same single can surface in the row, column, and box scans; dedupe
+	// by cell so callers collecting all matches see it once
+	var seen [constants.TotalCells]bool
+	emitOnce := func(idx, digit int) bool {
+		if seen[idx] {
+			return false
+		}
+		seen[idx] = true
+		return emit(hiddenSingleMove(b, idx, digit))
+	}
+
+	for row := 0; row < constants.GridSize; row++ {
+		for digit := 1; digit <= constants.GridSize; digit++ {
+			var positions []int
+			for col := 0; col < constants.GridSize; col++ {
+				idx := row*constants.GridSize + col
+				if b.GetCell(idx) == digit {
+					positions = nil
+					break
+				}
+				if b.GetCandidatesAt(idx).Has(digit) {
+					positions = append(positions, col)
+				}
+			}
+			if len(positions) == 1 {
+				idx := row*constants.GridSize + positions[0]
+				if b.GetCandidatesAt(idx).Count() > 1 && emitOnce(idx, digit) {
+					return
+				}
+			}
+		}
+	}
+
+	// Check columns
+	for col := 0; col < constants.GridSize; col++ {
+		for digit := 1; digit <= constants.GridSize; digit++ {
+			var positions []int
+			for row := 0; row < constants.GridSize; row++ {
+				idx := row*constants.GridSize + col
+				if b.GetCell(idx) == digit {
+					positions = nil
+					break
+				}
+				if b.GetCandidatesAt(idx).Has(digit) {
+					positions = append(positions, row)
+				}
+			}
+			if len(positions) == 1 {
+				idx := positions[0]*constants.GridSize + col
+				if b.GetCandidatesAt(idx).Count() > 1 && emitOnce(idx, digit) {
+					return
+				}
+			}
+		}
+	}
+
+	for box := 0; box < constants.GridSize; box++ {
+		for digit := 1; digit <= constants.GridSize; digit++ {
+			var positions []int
+			found := false
+			for _, idx := range BoxIndices[box] {
+				if b.GetCell(idx) == digit {
+					found = true
+					break
+				}
+				if b.GetCandidatesAt(idx).Has(digit) {
+					positions = append(positions, idx)
+				}
+			}
+			if !found && len(positions) == 1 {
+				idx := positions[0]
+				if b.GetCandidatesAt(idx).Count() > 1 && emitOnce(idx, digit) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// hiddenSingleMove builds the move for a hidden single at idx. The cell may
+// be the only home for the digit in several units at once; the explanation
+// and highlights use the pedagogically simplest one (box, then row, then
+// column), regardless of which scan found the single.
+func hiddenSingleMove(b BoardInterface, idx, digit int) *core.Move {
+	row, col := idx/constants.GridSize, idx%constants.GridSize
+
+	unit := Unit{Type: UnitBox, Index: BoxOf(idx), Cells: BoxIndices[BoxOf(idx)]}
+	if !unitJustifiesHiddenSingle(b, unit.Cells, idx, digit) {
+		unit = Unit{Type: UnitRow, Index: row, Cells: RowIndices[row]}
+		if !unitJustifiesHiddenSingle(b, unit.Cells, idx, digit) {
+			unit = Unit{Type: UnitCol, Index: col, Cells: ColIndices[col]}
+		}
+	}
+
+	// Build eliminations for all other candidates in this cell
+	cellCandidates := b.GetCandidatesAt(idx)
+	var eliminations []core.Candidate
+	for d := 1; d <= constants.GridSize; d++ {
+		if d != digit && cellCandidates.Has(d) {
+			eliminations = append(eliminations, core.Candidate{Row: row, Col: col, Digit: d})
+		}
+	}
+
+	// The other cells of the unit all exclude the digit (filled, or missing
+	// the candidate) - they are what makes this cell the digit's only home
+	var secondary []core.CellRef
+	for _, cell := range unit.Cells {
+		if cell != idx {
+			secondary = append(secondary, core.CellRef{Row: cell / constants.GridSize, Col: cell % constants.GridSize})
+		}
+	}
+
+	return withTargetCandidates(b, explained(&core.Move{
+		Action:       "assign",
+		Digit:        digit,
+		Targets:      []core.CellRef{{Row: row, Col: col}},
+		Eliminations: eliminations,
+		Highlights: core.Highlights{
+			Primary:   []core.CellRef{{Row: row, Col: col}},
+			Secondary: secondary,
+			Target:    []core.CellRef{{Row: row, Col: col}},
+			Defining:  ToCellRefs(unit.Cells),
+			// Only the digit-bearing evidence, never the target cell's own
+			// candidate set — highlighting all of those is the UI bug this
+			// role exists to fix
+			Justifying: hiddenSingleJustification(b, unit.Cells, idx, digit),
+			Eliminated: eliminations,
+		},
+		Unit: &core.UnitRef{Type: unit.Type.String(), Index: unit.Index},
+	}, "technique.hidden-single", map[string]interface{}{
+		"unitType":  unit.Type.String(),
+		"unitIndex": unit.Index + 1,
+		"digit":     digit,
+		"cell":      FormatCell(idx),
+	}))
+}
+
+// hiddenSingleJustification collects the placed digits that bar the unit's
+// other empty cells from taking the digit: for each such cell missing the
+// candidate, the filled peers holding the digit. Exclusions that stem from
+// earlier candidate eliminations have no placed digit to point at and
+// contribute nothing.
+func hiddenSingleJustification(b BoardInterface, unitCells []int, idx, digit int) []core.CandidateRef {
+	seen := make(map[int]bool)
+	var refs []core.CandidateRef
+	for _, cell := range unitCells {
+		if cell == idx || b.GetCell(cell) != 0 {
+			continue
+		}
+		for _, peer := range Peers[cell] {
+			if b.GetCell(peer) == digit && !seen[peer] {
+				seen[peer] = true
+				refs = append(refs, core.CandidateRef{Row: RowOf(peer), Col: ColOf(peer), Digit: digit})
+			}
+		}
+	}
+	return refs
+}
+
+// unitJustifiesHiddenSingle reports whether idx is the only cell in the unit
+// that can still take the digit.
+func unitJustifiesHiddenSingle(b BoardInterface, cells []int, idx, digit int) bool {
+	for _, cell := range cells {
+		if cell == idx {
+			continue
+		}
+		if b.GetCell(cell) == digit {
+			return false
+		}
+		if b.GetCell(cell) == 0 && b.GetCandidatesAt(cell).Has(digit) {
+			return false
+		}
+	}
+	return true
+}
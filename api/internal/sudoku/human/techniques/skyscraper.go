@@ -8,120 +8,220 @@ import (
 )
 
 // ============================================================================
-// Skyscraper Detection
+// Turbot Fish Family (Skyscraper, 2-String Kite, Turbot Fish)
 // ============================================================================
 //
-// A Skyscraper is a pattern involving two rows (or columns) where a digit
-// appears exactly twice in each. One end of each pair shares a column (or row),
-// forming the "base" of the skyscraper. The other ends (the "tops") are in
-// different boxes. Any cell that sees both tops can have the digit eliminated.
+// All three patterns are single-digit chains of two strong links joined by a
+// weak link. A strong link is a conjugate pair: the only two cells in a row
+// or column that still allow the digit. When the connected ends (the bridge)
+// see each other, at least one of the two free ends must hold the digit, so
+// it can be eliminated from every cell that sees both free ends.
+//
+// The patterns differ only in how the bridge is shaped:
+//   - Skyscraper: both strong links in rows (or both in columns) with bridge
+//     ends sharing the crossing line, free ends in different boxes
+//   - 2-String Kite: one row link and one column link, bridged through a box
+//   - Turbot Fish: any two strong links joined by a weak link
+//
+// The detectors below share one scan. Turbot fish is the umbrella: its
+// detector accepts every bridge shape, while the specific names register
+// first and claim their shapes, so the generic slug only surfaces for the
+// leftover shapes - or when the specific detectors are disabled.
 
-// DetectSkyscraper finds Skyscraper pattern: two conjugate pairs sharing one end
-func DetectSkyscraper(b BoardInterface) *core.Move {
-	for digit := 1; digit <= constants.GridSize; digit++ {
-		// Find rows with exactly 2 candidates for this digit
-		var rowPairs []struct {
-			row  int
-			cols [2]int
-		}
+// turbotLink is a conjugate pair for one digit in a single row or column
+type turbotLink struct {
+	isRow bool
+	unit  int
+	ends  [2]int // cell indices, in scan order
+}
 
-		for row := 0; row < constants.GridSize; row++ {
-			var cols []int
-			for col := 0; col < constants.GridSize; col++ {
-				if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-					cols = append(cols, col)
-				}
+// turbotKind classifies the bridge shape of a candidate pattern
+type turbotKind int
+
+const (
+	turbotSkyscraper turbotKind = iota
+	turbotKite
+	turbotGeneric
+)
+
+// turbotStrongLinks collects the conjugate pairs for a digit, rows first
+func turbotStrongLinks(b BoardInterface, digit int) []turbotLink {
+	var links []turbotLink
+	for row := 0; row < constants.GridSize; row++ {
+		var ends []int
+		for col := 0; col < constants.GridSize; col++ {
+			if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
+				ends = append(ends, row*constants.GridSize+col)
 			}
-			if len(cols) == 2 {
-				rowPairs = append(rowPairs, struct {
-					row  int
-					cols [2]int
-				}{row, [2]int{cols[0], cols[1]}})
+		}
+		if len(ends) == 2 {
+			links = append(links, turbotLink{isRow: true, unit: row, ends: [2]int{ends[0], ends[1]}})
+		}
+	}
+	for col := 0; col < constants.GridSize; col++ {
+		var ends []int
+		for row := 0; row < constants.GridSize; row++ {
+			if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
+				ends = append(ends, row*constants.GridSize+col)
 			}
 		}
+		if len(ends) == 2 {
+			links = append(links, turbotLink{isRow: false, unit: col, ends: [2]int{ends[0], ends[1]}})
+		}
+	}
+	return links
+}
 
-		// Look for skyscraper patterns
-		for i := 0; i < len(rowPairs); i++ {
-			for j := i + 1; j < len(rowPairs); j++ {
-				r1 := rowPairs[i]
-				r2 := rowPairs[j]
-
-				// Check if they share exactly one column
-				shared := -1
-				unshared1, unshared2 := -1, -1
-
-				if r1.cols[0] == r2.cols[0] {
-					shared = r1.cols[0]
-					unshared1, unshared2 = r1.cols[1], r2.cols[1]
-				} else if r1.cols[0] == r2.cols[1] {
-					shared = r1.cols[0]
-					unshared1, unshared2 = r1.cols[1], r2.cols[0]
-				} else if r1.cols[1] == r2.cols[0] {
-					shared = r1.cols[1]
-					unshared1, unshared2 = r1.cols[0], r2.cols[1]
-				} else if r1.cols[1] == r2.cols[1] {
-					shared = r1.cols[1]
-					unshared1, unshared2 = r1.cols[0], r2.cols[0]
-				}
-
-				if shared == -1 || unshared1 == unshared2 {
-					continue
-				}
+// classifyTurbot names the bridge shape between two strong links, given the
+// bridge ends b1 (from l1) and b2 (from l2) and the free ends f1 and f2
+func classifyTurbot(l1, l2 turbotLink, b1, b2, f1, f2 int) turbotKind {
+	if l1.isRow == l2.isRow {
+		sameCrossLine := (l1.isRow && ColOf(b1) == ColOf(b2)) || (!l1.isRow && RowOf(b1) == RowOf(b2))
+		if sameCrossLine && BoxOf(f1) != BoxOf(f2) {
+			return turbotSkyscraper
+		}
+		return turbotGeneric
+	}
+	if BoxOf(b1) == BoxOf(b2) {
+		return turbotKite
+	}
+	return turbotGeneric
+}
 
-				// The unshared ends must be in different boxes for a proper skyscraper
-				box1 := (r1.row/constants.BoxSize)*constants.BoxSize + unshared1/constants.BoxSize
-				box2 := (r2.row/constants.BoxSize)*constants.BoxSize + unshared2/constants.BoxSize
-				if box1 == box2 {
+// scanTurbotFamily finds every two-strong-link pattern of the given kind and
+// passes the resulting moves to emit in scan order. turbotGeneric acts as the
+// umbrella and matches every bridge shape.
+func scanTurbotFamily(b BoardInterface, kind turbotKind, emit func(*core.Move) bool) {
+	for digit := 1; digit <= constants.GridSize; digit++ {
+		links := turbotStrongLinks(b, digit)
+		for i := 0; i < len(links); i++ {
+			for j := i + 1; j < len(links); j++ {
+				l1, l2 := links[i], links[j]
+				if sharesCell(l1, l2) {
 					continue
 				}
-
-				// Find eliminations: cells that see both unshared ends
-				var eliminations []core.Candidate
-				for idx := 0; idx < constants.TotalCells; idx++ {
-					if !b.GetCandidatesAt(idx).Has(digit) {
-						continue
-					}
-					row, col := idx/constants.GridSize, idx%constants.GridSize
-					if (row == r1.row && col == unshared1) || (row == r2.row && col == unshared2) {
-						continue
-					}
-
-					// Check if sees both unshared ends
-					seesEnd1 := ArePeers(idx, r1.row*constants.GridSize+unshared1)
-					seesEnd2 := ArePeers(idx, r2.row*constants.GridSize+unshared2)
-
-					if seesEnd1 && seesEnd2 {
-						eliminations = append(eliminations, core.Candidate{
-							Row: row, Col: col, Digit: digit,
-						})
+				for _, e1 := range [2]int{0, 1} {
+					for _, e2 := range [2]int{0, 1} {
+						b1, f1 := l1.ends[e1], l1.ends[1-e1]
+						b2, f2 := l2.ends[e2], l2.ends[1-e2]
+						if !ArePeers(b1, b2) {
+							continue
+						}
+						if kind != turbotGeneric && classifyTurbot(l1, l2, b1, b2, f1, f2) != kind {
+							continue
+						}
+						move := turbotMove(b, kind, digit, [4]int{b1, f1, b2, f2})
+						if move != nil && emit(move) {
+							return
+						}
 					}
 				}
+			}
+		}
+	}
+}
 
-				if len(eliminations) > 0 {
-					return &core.Move{
-						Action: "eliminate",
-						Digit:  digit,
-						Targets: []core.CellRef{
-							{Row: r1.row, Col: r1.cols[0]},
-							{Row: r1.row, Col: r1.cols[1]},
-							{Row: r2.row, Col: r2.cols[0]},
-							{Row: r2.row, Col: r2.cols[1]},
-						},
-						Eliminations: eliminations,
-						Explanation:  fmt.Sprintf("Skyscraper: %d with base in column %d", digit, shared+1),
-						Highlights: core.Highlights{
-							Primary: []core.CellRef{
-								{Row: r1.row, Col: r1.cols[0]},
-								{Row: r1.row, Col: r1.cols[1]},
-								{Row: r2.row, Col: r2.cols[0]},
-								{Row: r2.row, Col: r2.cols[1]},
-							},
-						},
-					}
-				}
+// sharesCell reports whether two links have a cell in common
+func sharesCell(l1, l2 turbotLink) bool {
+	for _, a := range l1.ends {
+		for _, b := range l2.ends {
+			if a == b {
+				return true
 			}
 		}
 	}
+	return false
+}
+
+// turbotMove builds the elimination move for a confirmed pattern, or nil when
+// no cell sees both free ends. cells is [bridge1, free1, bridge2, free2].
+func turbotMove(b BoardInterface, kind turbotKind, digit int, cells [4]int) *core.Move {
+	bridge1, free1, bridge2, free2 := cells[0], cells[1], cells[2], cells[3]
+
+	var eliminations []core.Candidate
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if !b.GetCandidatesAt(idx).Has(digit) {
+			continue
+		}
+		if idx == bridge1 || idx == free1 || idx == bridge2 || idx == free2 {
+			continue
+		}
+		if ArePeers(idx, free1) && ArePeers(idx, free2) {
+			eliminations = append(eliminations, core.Candidate{
+				Row: RowOf(idx), Col: ColOf(idx), Digit: digit,
+			})
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+
+	var key string
+	var params map[string]interface{}
+	switch kind {
+	case turbotSkyscraper:
+		key = "technique.skyscraper"
+		params = map[string]interface{}{"digit": digit, "base1": cellName(bridge1), "base2": cellName(bridge2)}
+	case turbotKite:
+		key = "technique.2-string-kite"
+		params = map[string]interface{}{"digit": digit, "box": BoxOf(bridge1) + 1}
+	default:
+		key = "technique.turbot-fish"
+		params = map[string]interface{}{"digit": digit, "end1": cellName(free1), "end2": cellName(free2)}
+	}
+
+	refs := []core.CellRef{
+		{Row: RowOf(bridge1), Col: ColOf(bridge1)},
+		{Row: RowOf(free1), Col: ColOf(free1)},
+		{Row: RowOf(bridge2), Col: ColOf(bridge2)},
+		{Row: RowOf(free2), Col: ColOf(free2)},
+	}
+	return explained(&core.Move{
+		Action:       "eliminate",
+		Digit:        digit,
+		Targets:      refs,
+		Eliminations: eliminations,
+		Highlights: core.Highlights{
+			Primary: refs,
+		},
+	}, key, params)
+}
+
+// cellName formats a cell index as R#C# for explanations
+func cellName(idx int) string {
+	return fmt.Sprintf("R%dC%d", RowOf(idx)+1, ColOf(idx)+1)
+}
+
+// DetectSkyscraper finds two parallel strong links whose bridge ends share
+// the crossing line
+func DetectSkyscraper(b BoardInterface) *core.Move { return firstMove(scanSkyscrapers, b) }
+
+// DetectSkyscraperAll returns every skyscraper on the board in scan order
+func DetectSkyscraperAll(b BoardInterface) []*core.Move { return allMoves(scanSkyscrapers, b) }
+
+func scanSkyscrapers(b BoardInterface, emit func(*core.Move) bool) {
+	scanTurbotFamily(b, turbotSkyscraper, emit)
+}
+
+// DetectTwoStringKite finds a row strong link and a column strong link
+// bridged through a box
+func DetectTwoStringKite(b BoardInterface) *core.Move { return firstMove(scanTwoStringKites, b) }
+
+// DetectTwoStringKiteAll returns every 2-string kite on the board in scan order
+func DetectTwoStringKiteAll(b BoardInterface) []*core.Move { return allMoves(scanTwoStringKites, b) }
+
+func scanTwoStringKites(b BoardInterface, emit func(*core.Move) bool) {
+	scanTurbotFamily(b, turbotKite, emit)
+}
+
+// DetectTurbotFish finds any two strong links joined by a weak link. The
+// skyscraper and kite detectors register first, so under the full registry
+// this slug only surfaces for the shapes neither of them claims.
+func DetectTurbotFish(b BoardInterface) *core.Move { return firstMove(scanTurbotFish, b) }
+
+// DetectTurbotFishAll returns every turbot fish on the board in scan order
+func DetectTurbotFishAll(b BoardInterface) []*core.Move { return allMoves(scanTurbotFish, b) }
 
-	return nil
+func scanTurbotFish(b BoardInterface, emit func(*core.Move) bool) {
+	scanTurbotFamily(b, turbotGeneric, emit)
 }
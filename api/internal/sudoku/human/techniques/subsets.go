@@ -0,0 +1,287 @@
+package techniques
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+// Generic naked/hidden subset detection for N = 2 (pair), 3 (triple) and
+// 4 (quad). The six registered detectors below are thin wrappers around
+// detectNakedSubset and detectHiddenSubset, which do the combination search
+// once over the candidate bitmask instead of repeating hand-rolled loops per
+// size.
+
+// subsetNames maps subset size to the display name used in explanations.
+var subsetNames = map[int]string{2: "Pair", 3: "Triple", 4: "Quad"}
+
+// DetectNakedPair finds two cells in a unit with the same two candidates
+func DetectNakedPair(b BoardInterface) *core.Move {
+	return detectNakedSubset(b, 2)
+}
+
+// DetectNakedTriple finds three cells in a unit with candidates that are a subset of three digits
+func DetectNakedTriple(b BoardInterface) *core.Move {
+	return detectNakedSubset(b, 3)
+}
+
+// DetectNakedQuad finds four cells with candidates that are a subset of four digits
+func DetectNakedQuad(b BoardInterface) *core.Move {
+	return detectNakedSubset(b, 4)
+}
+
+// DetectHiddenPair finds two digits that only appear in two cells within a unit
+func DetectHiddenPair(b BoardInterface) *core.Move {
+	return detectHiddenSubset(b, 2)
+}
+
+// DetectHiddenTriple finds three digits that only appear in three cells within a unit
+func DetectHiddenTriple(b BoardInterface) *core.Move {
+	return detectHiddenSubset(b, 3)
+}
+
+// DetectHiddenQuad finds four digits that only appear in four cells
+func DetectHiddenQuad(b BoardInterface) *core.Move {
+	return detectHiddenSubset(b, 4)
+}
+
+// DetectNakedPairAll, and the other *All variants below, find every subset
+// match on the board in scan order; the solver uses them for cell-targeted
+// hints.
+func DetectNakedPairAll(b BoardInterface) []*core.Move   { return detectNakedSubsetAll(b, 2) }
+func DetectNakedTripleAll(b BoardInterface) []*core.Move { return detectNakedSubsetAll(b, 3) }
+func DetectNakedQuadAll(b BoardInterface) []*core.Move   { return detectNakedSubsetAll(b, 4) }
+func DetectHiddenPairAll(b BoardInterface) []*core.Move  { return detectHiddenSubsetAll(b, 2) }
+func DetectHiddenTripleAll(b BoardInterface) []*core.Move {
+	return detectHiddenSubsetAll(b, 3)
+}
+func DetectHiddenQuadAll(b BoardInterface) []*core.Move { return detectHiddenSubsetAll(b, 4) }
+
+func detectNakedSubset(b BoardInterface, n int) *core.Move {
+	var move *core.Move
+	scanNakedSubsets(b, n, func(m *core.Move) bool {
+		move = m
+		return true
+	})
+	return move
+}
+
+func detectNakedSubsetAll(b BoardInterface, n int) []*core.Move {
+	var moves []*core.Move
+	scanNakedSubsets(b, n, func(m *core.Move) bool {
+		moves = append(moves, m)
+		return false
+	})
+	return moves
+}
+
+func detectHiddenSubset(b BoardInterface, n int) *core.Move {
+	var move *core.Move
+	scanHiddenSubsets(b, n, func(m *core.Move) bool {
+		move = m
+		return true
+	})
+	return move
+}
+
+func detectHiddenSubsetAll(b BoardInterface, n int) []*core.Move {
+	var moves []*core.Move
+	scanHiddenSubsets(b, n, func(m *core.Move) bool {
+		moves = append(moves, m)
+		return false
+	})
+	return moves
+}
+
+// scanNakedSubsets finds n unsolved cells in a unit whose combined candidates
+// are exactly n digits. Those digits are locked into the subset, so they can
+// be eliminated from every other unsolved cell in the unit. Matches are
+// passed to emit in scan order until it returns true.
+func scanNakedSubsets(b BoardInterface, n int, emit func(*core.Move) bool) {
+	for _, unit := range AllUnits() {
+		// Only unsolved cells with 2..n candidates can be part of the subset.
+		var cells []int
+		unsolved := 0
+		for _, idx := range unit.Cells {
+			if b.GetCell(idx) != 0 {
+				continue
+			}
+			unsolved++
+			if c := b.GetCandidatesAt(idx).Count(); c >= 2 && c <= n {
+				cells = append(cells, idx)
+			}
+		}
+		// A subset spanning the entire remaining unit is degenerate: there is
+		// no other cell left to eliminate from.
+		if len(cells) < n || unsolved <= n {
+			continue
+		}
+
+		stop := false
+		forEachCombination(len(cells), n, func(combo []int) bool {
+			var union Candidates
+			subset := make([]int, n)
+			for i, ci := range combo {
+				subset[i] = cells[ci]
+				union = union.Union(b.GetCandidatesAt(cells[ci]))
+			}
+			if union.Count() != n {
+				return false
+			}
+
+			var eliminations []core.Candidate
+			for _, idx := range unit.Cells {
+				if b.GetCell(idx) != 0 || containsCell(subset, idx) {
+					continue
+				}
+				for _, d := range b.GetCandidatesAt(idx).Intersect(union).ToSlice() {
+					eliminations = append(eliminations, core.Candidate{
+						Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: d,
+					})
+				}
+			}
+			if len(eliminations) == 0 {
+				return false
+			}
+			stop = emit(subsetMove(b, "Naked", n, union, subset, eliminations, unit))
+			return stop
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// scanHiddenSubsets finds n digits confined to n cells within a unit. All
+// other candidates can be eliminated from those cells, since the n digits must
+// fill them. Matches are passed to emit in scan order until it returns true.
+func scanHiddenSubsets(b BoardInterface, n int, emit func(*core.Move) bool) {
+	for _, unit := range AllUnits() {
+		// Position bitmask (over unit slots 0-8) for each digit.
+		var positions [constants.GridSize + 1]uint16
+		var unitDigits Candidates
+		for slot, idx := range unit.Cells {
+			if b.GetCell(idx) != 0 {
+				continue
+			}
+			for _, d := range b.GetCandidatesAt(idx).ToSlice() {
+				positions[d] |= 1 << slot
+				unitDigits = unitDigits.Set(d)
+			}
+		}
+
+		// Digits confined to 2..n cells are the only viable subset members
+		// (a digit in one cell is a hidden single, not part of a subset).
+		var digits []int
+		for d := 1; d <= constants.GridSize; d++ {
+			if c := bits.OnesCount16(positions[d]); c >= 2 && c <= n {
+				digits = append(digits, d)
+			}
+		}
+		// With only n digits left in the unit the "subset" is the entire
+		// remaining unit and carries no non-subset candidates to strip.
+		if len(digits) < n || unitDigits.Count() <= n {
+			continue
+		}
+
+		stop := false
+		forEachCombination(len(digits), n, func(combo []int) bool {
+			var posUnion uint16
+			var subsetDigits Candidates
+			for _, di := range combo {
+				posUnion |= positions[digits[di]]
+				subsetDigits = subsetDigits.Set(digits[di])
+			}
+			if bits.OnesCount16(posUnion) != n {
+				return false
+			}
+
+			var subset []int
+			for slot, idx := range unit.Cells {
+				if posUnion&(1<<slot) != 0 {
+					subset = append(subset, idx)
+				}
+			}
+
+			// Only non-subset digits come off the subset cells themselves.
+			var eliminations []core.Candidate
+			for _, idx := range subset {
+				for _, d := range b.GetCandidatesAt(idx).Subtract(subsetDigits).ToSlice() {
+					eliminations = append(eliminations, core.Candidate{
+						Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: d,
+					})
+				}
+			}
+			if len(eliminations) == 0 {
+				return false
+			}
+			stop = emit(subsetMove(b, "Hidden", n, subsetDigits, subset, eliminations, unit))
+			return stop
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// subsetMove builds the eliminate move shared by all subset detectors.
+func subsetMove(b BoardInterface, kind string, n int, digits Candidates, subset []int, eliminations []core.Candidate, unit Unit) *core.Move {
+	refs := ToCellRefs(subset)
+	key := fmt.Sprintf("technique.%s-%s", strings.ToLower(kind), strings.ToLower(subsetNames[n]))
+
+	// The logic lives in the subset digits each subset cell actually holds;
+	// a cell's other candidates (hidden subsets) are what gets eliminated
+	var justifying []core.CandidateRef
+	for _, idx := range subset {
+		for _, d := range b.GetCandidatesAt(idx).Intersect(digits).ToSlice() {
+			justifying = append(justifying, core.CandidateRef{Row: RowOf(idx), Col: ColOf(idx), Digit: d})
+		}
+	}
+
+	return explained(&core.Move{
+		Action:       "eliminate",
+		Targets:      refs,
+		Eliminations: eliminations,
+		Unit:         &core.UnitRef{Type: unit.Type.String(), Index: unit.Index},
+		Highlights: core.Highlights{
+			Primary:    refs,
+			Secondary:  ToCellRefs(unit.Cells),
+			Target:     EliminationCells(eliminations),
+			Defining:   refs,
+			Justifying: justifying,
+			Eliminated: eliminations,
+		},
+	}, key, map[string]interface{}{
+		"digits":    digits.ToSlice(),
+		"unitType":  unit.Type.String(),
+		"unitIndex": unit.Index + 1,
+	})
+}
+
+// forEachCombination invokes fn with every k-element index combination of
+// 0..m-1 in lexicographic order, stopping early once fn returns true.
+func forEachCombination(m, k int, fn func(combo []int) bool) {
+	combo := make([]int, k)
+	for i := range combo {
+		combo[i] = i
+	}
+	for {
+		if fn(combo) {
+			return
+		}
+		i := k - 1
+		for i >= 0 && combo[i] == m-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		combo[i]++
+		for j := i + 1; j < k; j++ {
+			combo[j] = combo[j-1] + 1
+		}
+	}
+}
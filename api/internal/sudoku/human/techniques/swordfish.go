@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -15,203 +13,18 @@ import (
 // If a digit appears 2-3 times in each of 3 rows, and those positions
 // align to exactly 3 columns, the digit can be eliminated from other
 // cells in those columns (and vice versa for column-based Swordfish).
+// Detection runs through the generalized fish finder in fish.go with a
+// base-set size of 3, which covers the 2-2-2 and 2-2-3 configurations and
+// leaves degenerate combinations (two base rows confined to two shared
+// columns) to the cheaper X-Wing detector.
 
 // DetectSwordfish finds Swordfish patterns
-func DetectSwordfish(b BoardInterface) *core.Move {
-	for digit := 1; digit <= constants.GridSize; digit++ {
-		if move := detectSwordfishInRows(b, digit); move != nil {
-			return move
-		}
-		if move := detectSwordfishInCols(b, digit); move != nil {
-			return move
-		}
-	}
-	return nil
-}
-
-func detectSwordfishInRows(b BoardInterface, digit int) *core.Move {
-	// Find rows where digit appears in 2-3 columns
-	rowPositions := make(map[int][]int)
-	for row := 0; row < constants.GridSize; row++ {
-		var cols []int
-		for col := 0; col < constants.GridSize; col++ {
-			if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-				cols = append(cols, col)
-			}
-		}
-		if len(cols) >= 2 && len(cols) <= 3 {
-			rowPositions[row] = cols
-		}
-	}
-
-	var rows []int
-	for row := range rowPositions {
-		rows = append(rows, row)
-	}
-
-	if len(rows) < 3 {
-		return nil
-	}
-
-	// Try all combinations of 3 rows
-	for i := 0; i < len(rows); i++ {
-		for j := i + 1; j < len(rows); j++ {
-			for k := j + 1; k < len(rows); k++ {
-				r1, r2, r3 := rows[i], rows[j], rows[k]
-
-				// Union of columns
-				colSet := make(map[int]bool)
-				for _, c := range rowPositions[r1] {
-					colSet[c] = true
-				}
-				for _, c := range rowPositions[r2] {
-					colSet[c] = true
-				}
-				for _, c := range rowPositions[r3] {
-					colSet[c] = true
-				}
-
-				if len(colSet) != 3 {
-					continue
-				}
-
-				var cols []int
-				for c := range colSet {
-					cols = append(cols, c)
-				}
-
-				// Find eliminations in these columns
-				var eliminations []core.Candidate
-				for _, col := range cols {
-					for row := 0; row < constants.GridSize; row++ {
-						if row == r1 || row == r2 || row == r3 {
-							continue
-						}
-						if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-							eliminations = append(eliminations, core.Candidate{
-								Row: row, Col: col, Digit: digit,
-							})
-						}
-					}
-				}
-
-				if len(eliminations) > 0 {
-					var targets []core.CellRef
-					for _, row := range []int{r1, r2, r3} {
-						for _, col := range rowPositions[row] {
-							targets = append(targets, core.CellRef{Row: row, Col: col})
-						}
-					}
-
-					return &core.Move{
-						Action:       "eliminate",
-						Digit:        digit,
-						Targets:      targets,
-						Eliminations: eliminations,
-						Explanation:  fmt.Sprintf("Swordfish: %d in rows %d,%d,%d columns %d,%d,%d", digit, r1+1, r2+1, r3+1, cols[0]+1, cols[1]+1, cols[2]+1),
-						Highlights: core.Highlights{
-							Primary: targets,
-						},
-					}
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-func detectSwordfishInCols(b BoardInterface, digit int) *core.Move {
-	// Find columns where digit appears in 2-3 rows
-	colPositions := make(map[int][]int)
-	for col := 0; col < constants.GridSize; col++ {
-		var rows []int
-		for row := 0; row < constants.GridSize; row++ {
-			if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-				rows = append(rows, row)
-			}
-		}
-		if len(rows) >= 2 && len(rows) <= 3 {
-			colPositions[col] = rows
-		}
-	}
-
-	var cols []int
-	for col := range colPositions {
-		cols = append(cols, col)
-	}
-
-	if len(cols) < 3 {
-		return nil
-	}
-
-	// Try all combinations of 3 columns
-	for i := 0; i < len(cols); i++ {
-		for j := i + 1; j < len(cols); j++ {
-			for k := j + 1; k < len(cols); k++ {
-				c1, c2, c3 := cols[i], cols[j], cols[k]
-
-				// Union of rows
-				rowSet := make(map[int]bool)
-				for _, r := range colPositions[c1] {
-					rowSet[r] = true
-				}
-				for _, r := range colPositions[c2] {
-					rowSet[r] = true
-				}
-				for _, r := range colPositions[c3] {
-					rowSet[r] = true
-				}
+func DetectSwordfish(b BoardInterface) *core.Move { return firstMove(scanSwordfish, b) }
 
-				if len(rowSet) != 3 {
-					continue
-				}
-
-				var rows []int
-				for r := range rowSet {
-					rows = append(rows, r)
-				}
-
-				// Find eliminations in these rows
-				var eliminations []core.Candidate
-				for _, row := range rows {
-					for col := 0; col < constants.GridSize; col++ {
-						if col == c1 || col == c2 || col == c3 {
-							continue
-						}
-						if b.GetCandidatesAt(row*constants.GridSize + col).Has(digit) {
-							eliminations = append(eliminations, core.Candidate{
-								Row: row, Col: col, Digit: digit,
-							})
-						}
-					}
-				}
-
-				if len(eliminations) > 0 {
-					var targets []core.CellRef
-					for _, col := range []int{c1, c2, c3} {
-						for _, row := range colPositions[col] {
-							targets = append(targets, core.CellRef{Row: row, Col: col})
-						}
-					}
-
-					return &core.Move{
-						Action:       "eliminate",
-						Digit:        digit,
-						Targets:      targets,
-						Eliminations: eliminations,
-						Explanation:  fmt.Sprintf("Swordfish: %d in columns %d,%d,%d rows %d,%d,%d", digit, c1+1, c2+1, c3+1, rows[0]+1, rows[1]+1, rows[2]+1),
-						Highlights: core.Highlights{
-							Primary: targets,
-						},
-					}
-				}
-			}
-		}
-	}
+// DetectSwordfishAll returns every Swordfish on the board in scan order
+func DetectSwordfishAll(b BoardInterface) []*core.Move { return allMoves(scanSwordfish, b) }
 
-	return nil
-}
+func scanSwordfish(b BoardInterface, emit func(*core.Move) bool) { scanBasicFish(b, 3, emit) }
 
 // ============================================================================
 // Finned Swordfish Detection
@@ -222,6 +35,11 @@ func detectSwordfishInCols(b BoardInterface, digit int) *core.Move {
 // - Positions align to exactly 3 columns (or vice versa)
 // - One row has extra positions (the "fin") not in the main columns
 // - Eliminate the digit from cells that are in one of the 3 columns AND see the fin cell
+//
+// The Sashimi variant allows the finned row to cover only one of the main
+// columns: the fin stands in for the missing position, and the eliminations
+// are unchanged (main-column cells inside the fin's box). Sashimi moves are
+// labeled "sashimi-swordfish" so stats separate the variants.
 
 func DetectFinnedSwordfish(b BoardInterface) *core.Move {
 	for digit := 1; digit <= constants.GridSize; digit++ {
@@ -305,10 +123,12 @@ func detectFinnedSwordfishInRows(b BoardInterface, digit int) *core.Move {
 						}
 					}
 
-					// Finned row must have at least 2 main columns and at least 1 fin
-					if len(mainCols) < 2 || len(finCols) == 0 {
+					// Finned row must cover at least 1 main column and have at
+					// least 1 fin. Covering only 1 is the sashimi variant
+					if len(mainCols) < 1 || len(finCols) == 0 {
 						continue
 					}
+					sashimi := len(mainCols) == 1
 
 					// All fins must be in the same box
 					if len(finCols) > 1 {
@@ -325,14 +145,14 @@ func detectFinnedSwordfishInRows(b BoardInterface, digit int) *core.Move {
 						}
 					}
 
-					// The fin(s) must share a box with at least one main column position in the finned row
+					// The fin(s) must share a box with at least one of the 3
+					// main columns (covered in the finned row or not)
 					finRowInBox := finnedRow.row / constants.BoxSize
 
-					// Find which main columns are in the same box as the fin
 					var targetCols []int
-					for _, mc := range mainCols {
-						if mc/constants.BoxSize == finCols[0]/constants.BoxSize {
-							targetCols = append(targetCols, mc)
+					for c := 0; c < constants.GridSize; c++ {
+						if baseColSet[c] && c/constants.BoxSize == finCols[0]/constants.BoxSize {
+							targetCols = append(targetCols, c)
 						}
 					}
 
@@ -340,26 +160,31 @@ func detectFinnedSwordfishInRows(b BoardInterface, digit int) *core.Move {
 						continue
 					}
 
+					// Every pattern cell (base rows, finned row, and fins) is
+					// excluded from elimination explicitly
+					pattern := make(map[int]bool)
+					for _, c := range baseRow1.cols {
+						pattern[baseRow1.row*constants.GridSize+c] = true
+					}
+					for _, c := range baseRow2.cols {
+						pattern[baseRow2.row*constants.GridSize+c] = true
+					}
+					for _, c := range finnedRow.cols {
+						pattern[finnedRow.row*constants.GridSize+c] = true
+					}
+
 					// Find eliminations: cells that are:
 					// 1. In one of the 3 base columns (would be eliminated by regular swordfish)
 					// 2. AND see the fin cell (in same box as fin)
 					// 3. AND not part of the swordfish pattern
 					var eliminations []core.Candidate
-					swordfishRows := map[int]bool{
-						finnedRow.row: true,
-						baseRow1.row:  true,
-						baseRow2.row:  true,
-					}
-
-					// Eliminations can only occur in the target columns (columns in same box as fin)
-					// and only in rows within the fin's box (but not the swordfish rows)
 					boxRowStart := finRowInBox * constants.BoxSize
 					for _, tc := range targetCols {
 						for row := boxRowStart; row < boxRowStart+constants.BoxSize; row++ {
-							if swordfishRows[row] {
+							idx := row*constants.GridSize + tc
+							if pattern[idx] {
 								continue
 							}
-							idx := row*constants.GridSize + tc
 							if b.GetCandidatesAt(idx).Has(digit) {
 								// Verify this cell sees the fin (it will if in same box)
 								seesAllFins := true
@@ -400,19 +225,26 @@ func detectFinnedSwordfishInRows(b BoardInterface, digit int) *core.Move {
 
 						rowIndices := []int{baseRow1.row, baseRow2.row, finnedRow.row}
 
-						return &core.Move{
+						technique, key := "finned-swordfish", "technique.finned-swordfish.rows"
+						if sashimi {
+							technique, key = "sashimi-swordfish", "technique.sashimi-swordfish.rows"
+						}
+
+						return explained(&core.Move{
+							Technique:    technique,
 							Action:       "eliminate",
 							Digit:        digit,
 							Targets:      targets,
 							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("Finned Swordfish: %d in rows %d,%d,%d with fin at R%dC%d",
-								digit, rowIndices[0]+1, rowIndices[1]+1, rowIndices[2]+1,
-								finnedRow.row+1, finCols[0]+1),
 							Highlights: core.Highlights{
 								Primary:   targets,
 								Secondary: finCells,
 							},
-						}
+						}, key, map[string]interface{}{
+							"digit": digit,
+							"rows":  []int{rowIndices[0] + 1, rowIndices[1] + 1, rowIndices[2] + 1},
+							"fin":   FormatCell(finnedRow.row*constants.GridSize + finCols[0]),
+						})
 					}
 				}
 			}
@@ -492,10 +324,12 @@ func detectFinnedSwordfishInCols(b BoardInterface, digit int) *core.Move {
 						}
 					}
 
-					// Finned column must have at least 2 main rows and at least 1 fin
-					if len(mainRows) < 2 || len(finRows) == 0 {
+					// Finned column must cover at least 1 main row and have at
+					// least 1 fin. Covering only 1 is the sashimi variant
+					if len(mainRows) < 1 || len(finRows) == 0 {
 						continue
 					}
+					sashimi := len(mainRows) == 1
 
 					// All fins must be in the same box
 					if len(finRows) > 1 {
@@ -512,14 +346,14 @@ func detectFinnedSwordfishInCols(b BoardInterface, digit int) *core.Move {
 						}
 					}
 
-					// The fin(s) must share a box with at least one main row position in the finned column
+					// The fin(s) must share a box with at least one of the 3
+					// main rows (covered in the finned column or not)
 					finColInBox := finnedCol.col / constants.BoxSize
 
-					// Find which main rows are in the same box as the fin
 					var targetRows []int
-					for _, mr := range mainRows {
-						if mr/constants.BoxSize == finRows[0]/constants.BoxSize {
-							targetRows = append(targetRows, mr)
+					for r := 0; r < constants.GridSize; r++ {
+						if baseRowSet[r] && r/constants.BoxSize == finRows[0]/constants.BoxSize {
+							targetRows = append(targetRows, r)
 						}
 					}
 
@@ -527,26 +361,31 @@ func detectFinnedSwordfishInCols(b BoardInterface, digit int) *core.Move {
 						continue
 					}
 
+					// Every pattern cell (base columns, finned column, and
+					// fins) is excluded from elimination explicitly
+					pattern := make(map[int]bool)
+					for _, r := range baseCol1.rows {
+						pattern[r*constants.GridSize+baseCol1.col] = true
+					}
+					for _, r := range baseCol2.rows {
+						pattern[r*constants.GridSize+baseCol2.col] = true
+					}
+					for _, r := range finnedCol.rows {
+						pattern[r*constants.GridSize+finnedCol.col] = true
+					}
+
 					// Find eliminations: cells that are:
 					// 1. In one of the 3 base rows (would be eliminated by regular swordfish)
 					// 2. AND see the fin cell (in same box as fin)
 					// 3. AND not part of the swordfish pattern
 					var eliminations []core.Candidate
-					swordfishCols := map[int]bool{
-						finnedCol.col: true,
-						baseCol1.col:  true,
-						baseCol2.col:  true,
-					}
-
-					// Eliminations can only occur in the target rows (rows in same box as fin)
-					// and only in columns within the fin's box (but not the swordfish columns)
 					boxColStart := finColInBox * constants.BoxSize
 					for _, tr := range targetRows {
 						for col := boxColStart; col < boxColStart+constants.BoxSize; col++ {
-							if swordfishCols[col] {
+							idx := tr*constants.GridSize + col
+							if pattern[idx] {
 								continue
 							}
-							idx := tr*constants.GridSize + col
 							if b.GetCandidatesAt(idx).Has(digit) {
 								// Verify this cell sees the fin (it will if in same box)
 								seesAllFins := true
@@ -587,19 +426,26 @@ func detectFinnedSwordfishInCols(b BoardInterface, digit int) *core.Move {
 
 						colIndices := []int{baseCol1.col, baseCol2.col, finnedCol.col}
 
-						return &core.Move{
+						technique, key := "finned-swordfish", "technique.finned-swordfish.cols"
+						if sashimi {
+							technique, key = "sashimi-swordfish", "technique.sashimi-swordfish.cols"
+						}
+
+						return explained(&core.Move{
+							Technique:    technique,
 							Action:       "eliminate",
 							Digit:        digit,
 							Targets:      targets,
 							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("Finned Swordfish: %d in columns %d,%d,%d with fin at R%dC%d",
-								digit, colIndices[0]+1, colIndices[1]+1, colIndices[2]+1,
-								finRows[0]+1, finnedCol.col+1),
 							Highlights: core.Highlights{
 								Primary:   targets,
 								Secondary: finCells,
 							},
-						}
+						}, key, map[string]interface{}{
+							"digit": digit,
+							"cols":  []int{colIndices[0] + 1, colIndices[1] + 1, colIndices[2] + 1},
+							"fin":   FormatCell(finRows[0]*constants.GridSize + finnedCol.col),
+						})
 					}
 				}
 			}
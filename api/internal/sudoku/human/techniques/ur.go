@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -18,6 +16,14 @@ import (
 // All UR types share the same rectangle-finding logic: 4 cells forming a
 // rectangle across exactly 2 boxes, where all 4 cells contain candidates
 // for the same 2 digits.
+//
+// The deadly-pattern argument only holds when none of the rectangle cells
+// is clued: a clue pins its cell and the "swap the two digits" second
+// solution no longer exists. The board API does not distinguish clues from
+// placements, so the guard is conservative: every corner must be unsolved,
+// and neither of the rectangle's two boxes may hold either UR digit as a
+// placed value. The latter also protects against user-managed pencil marks
+// that went stale after a placement.
 
 // urRectangle represents a potential Unique Rectangle configuration
 type urRectangle struct {
@@ -45,10 +51,11 @@ var urFloorRoofPairs = [][2][2]int{
 // findURRectangles finds all valid Unique Rectangle configurations for digits d1, d2.
 // A UR rectangle has 4 cells forming a rectangle across exactly 2 boxes.
 func findURRectangles(b BoardInterface, d1, d2 int) []urRectangle {
-	// Find all cells that have both d1 and d2 as candidates
+	// Find all unsolved cells that have both d1 and d2 as candidates. The
+	// solved-cell check matters when candidates are user-managed and stale.
 	var cells []int
 	for i := 0; i < constants.TotalCells; i++ {
-		if b.GetCandidatesAt(i).Has(d1) && b.GetCandidatesAt(i).Has(d2) {
+		if b.GetCell(i) == 0 && b.GetCandidatesAt(i).Has(d1) && b.GetCandidatesAt(i).Has(d2) {
 			cells = append(cells, i)
 		}
 	}
@@ -119,6 +126,12 @@ func findURRectangles(b BoardInterface, d1, d2 int) []urRectangle {
 						corners[3] = cells[k] // (r3, c2)
 					}
 
+					// A placed UR digit in either box breaks the
+					// deadly-pattern argument
+					if !urBoxesFreeOfDigits(b, corners, d1, d2) {
+						continue
+					}
+
 					rectangles = append(rectangles, urRectangle{
 						d1:      d1,
 						d2:      d2,
@@ -132,6 +145,25 @@ func findURRectangles(b BoardInterface, d1, d2 int) []urRectangle {
 	return rectangles
 }
 
+// urBoxesFreeOfDigits reports whether the two boxes spanned by the rectangle
+// contain no placed instance of either UR digit.
+func urBoxesFreeOfDigits(b BoardInterface, corners [4]int, d1, d2 int) bool {
+	checked := make(map[int]bool, 2)
+	for _, corner := range corners {
+		box := BoxOf(corner)
+		if checked[box] {
+			continue
+		}
+		checked[box] = true
+		for _, idx := range BoxIndices[box] {
+			if v := b.GetCell(idx); v == d1 || v == d2 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // DetectUniqueRectangle finds Unique Rectangle Type 1 patterns
 // A UR occurs when 4 cells form a rectangle across EXACTLY 2 boxes, and 3 corners
 // are bivalue with the same 2 digits. The 4th corner must have extra candidates
@@ -161,17 +193,20 @@ func DetectUniqueRectangle(b BoardInterface) *core.Move {
 
 					targets := CellRefsFromIndices(rect.corners[:]...)
 
-					return &core.Move{
+					return explained(&core.Move{
 						Action:       "eliminate",
 						Digit:        0,
 						Targets:      targets,
 						Eliminations: eliminations,
-						Explanation:  fmt.Sprintf("Unique Rectangle Type 1: %d/%d would form deadly pattern: eliminate from R%dC%d.", d1, d2, row+1, col+1),
 						Highlights: core.Highlights{
 							Primary:   targets[:3], // The 3 bivalue corners
 							Secondary: []core.CellRef{{Row: row, Col: col}},
 						},
-					}
+					}, "technique.unique-rectangle", map[string]interface{}{
+						"d1":   d1,
+						"d2":   d2,
+						"cell": FormatCell(nonBivalueIdx),
+					})
 				}
 			}
 		}
@@ -238,18 +273,22 @@ func DetectUniqueRectangleType2(b BoardInterface) *core.Move {
 					if len(eliminations) > 0 {
 						targets := CellRefsFromIndices(corners[:]...)
 
-						return &core.Move{
+						return explained(&core.Move{
 							Action:       "eliminate",
 							Digit:        extraDigit,
 							Targets:      targets,
 							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("Unique Rectangle Type 2: %d/%d with extra %d: eliminate %d from cells seeing both R%dC%d and R%dC%d.",
-								d1, d2, extraDigit, extraDigit, roofCorner0/constants.GridSize+1, roofCorner0%constants.GridSize+1, roofCorner1/constants.GridSize+1, roofCorner1%constants.GridSize+1),
 							Highlights: core.Highlights{
 								Primary:   CellRefsFromIndices(corners[floorPair[0]], corners[floorPair[1]]),
 								Secondary: CellRefsFromIndices(roofCorner0, roofCorner1),
 							},
-						}
+						}, "technique.unique-rectangle-type-2", map[string]interface{}{
+							"d1":    d1,
+							"d2":    d2,
+							"digit": extraDigit,
+							"roof1": FormatCell(roofCorner0),
+							"roof2": FormatCell(roofCorner1),
+						})
 					}
 				}
 			}
@@ -362,18 +401,22 @@ func DetectUniqueRectangleType3(b BoardInterface) *core.Move {
 									if len(eliminations) > 0 {
 										targets := CellRefsFromIndices(corners[:]...)
 
-										return &core.Move{
+										return explained(&core.Move{
 											Action:       "eliminate",
 											Digit:        0,
 											Targets:      targets,
 											Eliminations: eliminations,
-											Explanation: fmt.Sprintf("Unique Rectangle Type 3: %d/%d: pseudo-cell with %v forms naked pair with R%dC%d in %s.",
-												d1, d2, extraSlice, idx/constants.GridSize+1, idx%constants.GridSize+1, unit.unitType),
 											Highlights: core.Highlights{
 												Primary:   CellRefsFromIndices(corners[floorPair[0]], corners[floorPair[1]]),
 												Secondary: CellRefsFromIndices(roofCorner0, roofCorner1, idx),
 											},
-										}
+										}, "technique.unique-rectangle-type-3.pair", map[string]interface{}{
+											"d1":       d1,
+											"d2":       d2,
+											"digits":   extraSlice,
+											"cell":     FormatCell(idx),
+											"unitType": unit.unitType,
+										})
 									}
 								}
 							}
@@ -443,18 +486,22 @@ func DetectUniqueRectangleType3(b BoardInterface) *core.Move {
 										if len(eliminations) > 0 {
 											targets := CellRefsFromIndices(corners[:]...)
 
-											return &core.Move{
+											return explained(&core.Move{
 												Action:       "eliminate",
 												Digit:        0,
 												Targets:      targets,
 												Eliminations: eliminations,
-												Explanation: fmt.Sprintf("Unique Rectangle Type 3: %d/%d: pseudo-cell forms naked triple with R%dC%d and R%dC%d in %s.",
-													d1, d2, idx1/constants.GridSize+1, idx1%constants.GridSize+1, idx2/constants.GridSize+1, idx2%constants.GridSize+1, unit.unitType),
 												Highlights: core.Highlights{
 													Primary:   CellRefsFromIndices(corners[floorPair[0]], corners[floorPair[1]]),
 													Secondary: CellRefsFromIndices(roofCorner0, roofCorner1, idx1, idx2),
 												},
-											}
+											}, "technique.unique-rectangle-type-3.triple", map[string]interface{}{
+												"d1":       d1,
+												"d2":       d2,
+												"cell1":    FormatCell(idx1),
+												"cell2":    FormatCell(idx2),
+												"unitType": unit.unitType,
+											})
 										}
 									}
 								}
@@ -533,18 +580,19 @@ func DetectUniqueRectangleType4(b BoardInterface) *core.Move {
 							if len(eliminations) > 0 {
 								targets := CellRefsFromIndices(corners[:]...)
 
-								return &core.Move{
+								return explained(&core.Move{
 									Action:       "eliminate",
 									Digit:        d2,
 									Targets:      targets,
 									Eliminations: eliminations,
-									Explanation: fmt.Sprintf("Unique Rectangle Type 4: %d/%d: %d confined to UR in row %d: eliminate %d.",
-										d1, d2, d1, row+1, d2),
 									Highlights: core.Highlights{
 										Primary:   CellRefsFromIndices(bv0, bv1),
 										Secondary: CellRefsFromIndices(ex0, ex1),
 									},
-								}
+								}, "technique.unique-rectangle-type-4", map[string]interface{}{
+									"d1": d1, "d2": d2, "keep": d1,
+									"unitType": "row", "unitIndex": row + 1, "remove": d2,
+								})
 							}
 						}
 
@@ -561,18 +609,19 @@ func DetectUniqueRectangleType4(b BoardInterface) *core.Move {
 							if len(eliminations) > 0 {
 								targets := CellRefsFromIndices(corners[:]...)
 
-								return &core.Move{
+								return explained(&core.Move{
 									Action:       "eliminate",
 									Digit:        d1,
 									Targets:      targets,
 									Eliminations: eliminations,
-									Explanation: fmt.Sprintf("Unique Rectangle Type 4: %d/%d: %d confined to UR in row %d: eliminate %d.",
-										d1, d2, d2, row+1, d1),
 									Highlights: core.Highlights{
 										Primary:   CellRefsFromIndices(bv0, bv1),
 										Secondary: CellRefsFromIndices(ex0, ex1),
 									},
-								}
+								}, "technique.unique-rectangle-type-4", map[string]interface{}{
+									"d1": d1, "d2": d2, "keep": d2,
+									"unitType": "row", "unitIndex": row + 1, "remove": d1,
+								})
 							}
 						}
 					}
@@ -609,18 +658,19 @@ func DetectUniqueRectangleType4(b BoardInterface) *core.Move {
 							if len(eliminations) > 0 {
 								targets := CellRefsFromIndices(corners[:]...)
 
-								return &core.Move{
+								return explained(&core.Move{
 									Action:       "eliminate",
 									Digit:        d2,
 									Targets:      targets,
 									Eliminations: eliminations,
-									Explanation: fmt.Sprintf("Unique Rectangle Type 4: %d/%d: %d confined to UR in column %d: eliminate %d.",
-										d1, d2, d1, col+1, d2),
 									Highlights: core.Highlights{
 										Primary:   CellRefsFromIndices(bv0, bv1),
 										Secondary: CellRefsFromIndices(ex0, ex1),
 									},
-								}
+								}, "technique.unique-rectangle-type-4", map[string]interface{}{
+									"d1": d1, "d2": d2, "keep": d1,
+									"unitType": "column", "unitIndex": col + 1, "remove": d2,
+								})
 							}
 						}
 
@@ -637,18 +687,19 @@ func DetectUniqueRectangleType4(b BoardInterface) *core.Move {
 							if len(eliminations) > 0 {
 								targets := CellRefsFromIndices(corners[:]...)
 
-								return &core.Move{
+								return explained(&core.Move{
 									Action:       "eliminate",
 									Digit:        d1,
 									Targets:      targets,
 									Eliminations: eliminations,
-									Explanation: fmt.Sprintf("Unique Rectangle Type 4: %d/%d: %d confined to UR in column %d: eliminate %d.",
-										d1, d2, d2, col+1, d1),
 									Highlights: core.Highlights{
 										Primary:   CellRefsFromIndices(bv0, bv1),
 										Secondary: CellRefsFromIndices(ex0, ex1),
 									},
-								}
+								}, "technique.unique-rectangle-type-4", map[string]interface{}{
+									"d1": d1, "d2": d2, "keep": d2,
+									"unitType": "column", "unitIndex": col + 1, "remove": d1,
+								})
 							}
 						}
 					}
@@ -659,3 +710,251 @@ func DetectUniqueRectangleType4(b BoardInterface) *core.Move {
 
 	return nil
 }
+
+// urDiagonalPairs lists the two diagonals of the corner ordering: corners
+// [0] and [3] are opposite, as are [1] and [2]
+var urDiagonalPairs = [2][2]int{{0, 3}, {1, 2}}
+
+// urDigitConfinedToRect reports whether digit's candidates in the given unit
+// (a full row or column index list) are confined to the two rectangle cells
+// rectA and rectB.
+func urDigitConfinedToRect(b BoardInterface, unit []int, digit, rectA, rectB int) bool {
+	for _, idx := range unit {
+		if idx == rectA || idx == rectB {
+			continue
+		}
+		if b.GetCandidatesAt(idx).Has(digit) {
+			return false
+		}
+	}
+	return true
+}
+
+// DetectUniqueRectangleType5 finds UR Type 5 patterns
+// Like Type 2, the corners beyond the bivalue ones carry the same single
+// extra candidate X - but here the X corners sit diagonally (or on three
+// corners) instead of sharing a line. One of them must hold X in any valid
+// solution, so X falls out of every cell that sees all of them.
+func DetectUniqueRectangleType5(b BoardInterface) *core.Move {
+	for d1 := 1; d1 <= constants.GridSize-1; d1++ {
+		for d2 := d1 + 1; d2 <= constants.GridSize; d2++ {
+			for _, rect := range findURRectangles(b, d1, d2) {
+				urDigits := NewCandidates([]int{d1, d2})
+
+				// Every corner must be {d1,d2} or {d1,d2}+X for one shared X
+				extraDigit := 0
+				var extraCorners, bivalueCorners []int
+				valid := true
+				for _, corner := range rect.corners {
+					extras := b.GetCandidatesAt(corner).Subtract(urDigits)
+					switch extras.Count() {
+					case 0:
+						bivalueCorners = append(bivalueCorners, corner)
+					case 1:
+						d := extras.ToSlice()[0]
+						if extraDigit == 0 {
+							extraDigit = d
+						} else if extraDigit != d {
+							valid = false
+						}
+						extraCorners = append(extraCorners, corner)
+					default:
+						valid = false
+					}
+					if !valid {
+						break
+					}
+				}
+				if !valid || extraDigit == 0 {
+					continue
+				}
+
+				// Two X corners sharing a row or column belong to Type 2;
+				// Type 5 claims the diagonal and three-corner shapes
+				if len(extraCorners) == 2 {
+					r0, c0 := extraCorners[0]/constants.GridSize, extraCorners[0]%constants.GridSize
+					r1, c1 := extraCorners[1]/constants.GridSize, extraCorners[1]%constants.GridSize
+					if r0 == r1 || c0 == c1 {
+						continue
+					}
+				} else if len(extraCorners) != 3 {
+					continue
+				}
+
+				eliminations := FindEliminationsSeeing(b, extraDigit, extraCorners, extraCorners...)
+				if len(eliminations) == 0 {
+					continue
+				}
+
+				targets := CellRefsFromIndices(rect.corners[:]...)
+				return explained(&core.Move{
+					Action:       "eliminate",
+					Digit:        extraDigit,
+					Targets:      targets,
+					Eliminations: eliminations,
+					Highlights: core.Highlights{
+						Primary:   CellRefsFromIndices(bivalueCorners...),
+						Secondary: CellRefsFromIndices(extraCorners...),
+					},
+				}, "technique.unique-rectangle-type-5", map[string]interface{}{
+					"d1":    d1,
+					"d2":    d2,
+					"digit": extraDigit,
+					"cells": FormatCells(extraCorners),
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// DetectUniqueRectangleType6 finds UR Type 6 patterns
+// Two diagonal corners are bivalue with {A,B}; the other diagonal carries
+// extras. If one UR digit forms conjugate pairs in both rectangle rows (or
+// both columns) - an X-Wing on that digit - placing it in an extra corner
+// forces the deadly rectangle, so it is eliminated from both extra corners.
+func DetectUniqueRectangleType6(b BoardInterface) *core.Move {
+	for d1 := 1; d1 <= constants.GridSize-1; d1++ {
+		for d2 := d1 + 1; d2 <= constants.GridSize; d2++ {
+			for _, rect := range findURRectangles(b, d1, d2) {
+				corners := rect.corners
+				rowTop := corners[0] / constants.GridSize
+				rowBottom := corners[3] / constants.GridSize
+				colLeft := corners[0] % constants.GridSize
+				colRight := corners[3] % constants.GridSize
+
+				for i, diag := range urDiagonalPairs {
+					other := urDiagonalPairs[1-i]
+					bv0, bv1 := corners[diag[0]], corners[diag[1]]
+					ex0, ex1 := corners[other[0]], corners[other[1]]
+
+					if b.GetCandidatesAt(bv0).Count() != 2 || b.GetCandidatesAt(bv1).Count() != 2 {
+						continue
+					}
+					// A rectangle of four bare bivalue corners is already a
+					// deadly pattern, not a Type 6; require a real roof
+					if b.GetCandidatesAt(ex0).Count() <= 2 && b.GetCandidatesAt(ex1).Count() <= 2 {
+						continue
+					}
+
+					for _, d := range []int{d1, d2} {
+						// The UR digit must be conjugate-paired with the
+						// rectangle cells in both rows or in both columns
+						rowsConfined := urDigitConfinedToRect(b, RowIndices[rowTop], d, corners[0], corners[1]) &&
+							urDigitConfinedToRect(b, RowIndices[rowBottom], d, corners[2], corners[3])
+						colsConfined := urDigitConfinedToRect(b, ColIndices[colLeft], d, corners[0], corners[2]) &&
+							urDigitConfinedToRect(b, ColIndices[colRight], d, corners[1], corners[3])
+						if !rowsConfined && !colsConfined {
+							continue
+						}
+
+						var eliminations []core.Candidate
+						for _, ex := range []int{ex0, ex1} {
+							if b.GetCandidatesAt(ex).Has(d) {
+								eliminations = append(eliminations, core.Candidate{
+									Row: ex / constants.GridSize, Col: ex % constants.GridSize, Digit: d,
+								})
+							}
+						}
+						if len(eliminations) == 0 {
+							continue
+						}
+
+						unitType := "rows"
+						if !rowsConfined {
+							unitType = "columns"
+						}
+						targets := CellRefsFromIndices(corners[:]...)
+						return explained(&core.Move{
+							Action:       "eliminate",
+							Digit:        d,
+							Targets:      targets,
+							Eliminations: eliminations,
+							Highlights: core.Highlights{
+								Primary:   CellRefsFromIndices(bv0, bv1),
+								Secondary: CellRefsFromIndices(ex0, ex1),
+							},
+						}, "technique.unique-rectangle-type-6", map[string]interface{}{
+							"d1":       d1,
+							"d2":       d2,
+							"digit":    d,
+							"unitType": unitType,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// DetectHiddenRectangle finds Hidden Rectangle patterns
+// Only one corner needs to be bivalue with {A,B}; the others may bury the UR
+// digits under extras. If one UR digit is conjugate-paired with the rectangle
+// in both lines through the corner opposite the bivalue one, placing the
+// other UR digit there would force the deadly rectangle, so it is eliminated
+// from that corner.
+func DetectHiddenRectangle(b BoardInterface) *core.Move {
+	for d1 := 1; d1 <= constants.GridSize-1; d1++ {
+		for d2 := d1 + 1; d2 <= constants.GridSize; d2++ {
+			for _, rect := range findURRectangles(b, d1, d2) {
+				corners := rect.corners
+
+				for _, diag := range urDiagonalPairs {
+					for swap := 0; swap < 2; swap++ {
+						floor := corners[diag[swap]]
+						opp := corners[diag[1-swap]]
+
+						if b.GetCandidatesAt(floor).Count() != 2 {
+							continue
+						}
+
+						oppRow := opp / constants.GridSize
+						oppCol := opp % constants.GridSize
+						// The rectangle cells sharing a line with opp sit in
+						// floor's column and floor's row respectively
+						rowMate := oppRow*constants.GridSize + floor%constants.GridSize
+						colMate := (floor/constants.GridSize)*constants.GridSize + oppCol
+
+						for _, pair := range [2][2]int{{d1, d2}, {d2, d1}} {
+							strong, elim := pair[0], pair[1]
+							if !b.GetCandidatesAt(opp).Has(elim) {
+								continue
+							}
+							if !urDigitConfinedToRect(b, RowIndices[oppRow], strong, opp, rowMate) {
+								continue
+							}
+							if !urDigitConfinedToRect(b, ColIndices[oppCol], strong, opp, colMate) {
+								continue
+							}
+
+							targets := CellRefsFromIndices(corners[:]...)
+							return explained(&core.Move{
+								Action:  "eliminate",
+								Digit:   elim,
+								Targets: targets,
+								Eliminations: []core.Candidate{
+									{Row: oppRow, Col: oppCol, Digit: elim},
+								},
+								Highlights: core.Highlights{
+									Primary:   CellRefsFromIndices(floor),
+									Secondary: CellRefsFromIndices(opp),
+								},
+							}, "technique.hidden-rectangle", map[string]interface{}{
+								"d1":     d1,
+								"d2":     d2,
+								"digit":  elim,
+								"cell":   FormatCell(opp),
+								"strong": strong,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -106,7 +104,7 @@ func DetectXYZWing(b BoardInterface) *core.Move {
 						xr, xc := xzWing/constants.GridSize, xzWing%constants.GridSize
 						yr, yc := yzWing/constants.GridSize, yzWing%constants.GridSize
 
-						return &core.Move{
+						return explained(&core.Move{
 							Action: "eliminate",
 							Digit:  zDigit,
 							Targets: []core.CellRef{
@@ -115,11 +113,6 @@ func DetectXYZWing(b BoardInterface) *core.Move {
 								{Row: yr, Col: yc},
 							},
 							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("XYZ-Wing: pivot R%dC%d {%d,%d,%d} with wings R%dC%d {%d,%d} and R%dC%d {%d,%d}: eliminate %d.",
-								pr+1, pc+1, xDigit, yDigit, zDigit,
-								xr+1, xc+1, xDigit, zDigit,
-								yr+1, yc+1, yDigit, zDigit,
-								zDigit),
 							Highlights: core.Highlights{
 								Primary: []core.CellRef{
 									{Row: pr, Col: pc},
@@ -127,7 +120,12 @@ func DetectXYZWing(b BoardInterface) *core.Move {
 									{Row: yr, Col: yc},
 								},
 							},
-						}
+						}, "technique.xyz-wing", map[string]interface{}{
+							"pivot": FormatCell(pivot),
+							"wing1": FormatCell(xzWing),
+							"wing2": FormatCell(yzWing),
+							"digit": zDigit,
+						})
 					}
 				}
 			}
@@ -211,6 +209,13 @@ func DetectWXYZWing(b BoardInterface) *core.Move {
 						continue
 					}
 
+					// Degenerate case: the quad is an XYZ-Wing plus a
+					// spectator cell. Skip and let the cheaper detector
+					// claim the same eliminations.
+					if reducesToXYZWing(b, quad, z) {
+						continue
+					}
+
 					// Eliminate Z from cells that see ALL Z-containing cells in the quad
 					eliminations := FindEliminationsSeeing(b, z, quad[:], zCells...)
 
@@ -226,30 +231,30 @@ func DetectWXYZWing(b BoardInterface) *core.Move {
 							}
 						}
 
-						// Primary = cells with Z (wing cells), Secondary = hinge
+						// Primary = pivot (hinge), Secondary = wing cells
 						var primary, secondary []core.CellRef
 						for _, cell := range quad {
-							if b.GetCandidatesAt(cell).Has(z) {
+							if cell == hingeIdx {
 								primary = append(primary, core.CellRef{Row: cell / constants.GridSize, Col: cell % constants.GridSize})
 							} else {
 								secondary = append(secondary, core.CellRef{Row: cell / constants.GridSize, Col: cell % constants.GridSize})
 							}
 						}
 
-						return &core.Move{
+						return explained(&core.Move{
 							Action:       "eliminate",
 							Digit:        z,
 							Targets:      targets,
 							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("WXYZ-Wing: cells {R%dC%d,R%dC%d,R%dC%d,R%dC%d} contain %v: eliminate non-restricted %d.",
-								quad[0]/constants.GridSize+1, quad[0]%constants.GridSize+1, quad[1]/constants.GridSize+1, quad[1]%constants.GridSize+1,
-								quad[2]/constants.GridSize+1, quad[2]%constants.GridSize+1, quad[3]/constants.GridSize+1, quad[3]%constants.GridSize+1,
-								digits, z),
 							Highlights: core.Highlights{
 								Primary:   primary,
 								Secondary: secondary,
 							},
-						}
+						}, "technique.wxyz-wing", map[string]interface{}{
+							"cells":  FormatCells(quad[:]),
+							"digits": digits,
+							"digit":  z,
+						})
 					}
 				}
 			}
@@ -277,6 +282,29 @@ func isConnectedQuad(quad [4]int) bool {
 	return true
 }
 
+// reducesToXYZWing reports whether dropping one non-Z cell leaves three cells
+// with a union of exactly three digits — i.e. the quad is an XYZ-Wing (or
+// XY-Wing) carrying every Z candidate, plus a spectator cell. Dropping a cell
+// that holds Z would change the eliminations, so only Z-free cells qualify.
+func reducesToXYZWing(b BoardInterface, quad [4]int, z int) bool {
+	for drop := 0; drop < 4; drop++ {
+		if b.GetCandidatesAt(quad[drop]).Has(z) {
+			continue
+		}
+		var union Candidates
+		for i, cell := range quad {
+			if i == drop {
+				continue
+			}
+			union = union.Union(b.GetCandidatesAt(cell))
+		}
+		if union.Count() == 3 {
+			return true
+		}
+	}
+	return false
+}
+
 // isDigitRestricted checks if all instances of a digit in the quad can see each other
 func isDigitRestricted(b BoardInterface, quad [4]int, digit int) bool {
 	// Find all cells in quad containing this digit
@@ -304,75 +332,111 @@ func isDigitRestricted(b BoardInterface, quad [4]int, digit int) bool {
 	return true
 }
 
-// DetectALSXZ finds ALS-XZ pattern:
-// - Two ALS (A and B) that share a "restricted common" digit X
-// - X appears in both ALS, and all cells containing X in A see all cells containing X in B
-// - Both ALS share another digit Z
-// - Eliminate Z from cells that see all Z-cells in both ALS
-func DetectALSXZ(b BoardInterface) *core.Move {
-	allALS := FindAllALS(b, 4)
-
-	// Try all pairs of ALS
-	for i := 0; i < len(allALS); i++ {
-		for j := i + 1; j < len(allALS); j++ {
-			alsA := allALS[i]
-			alsB := allALS[j]
-
-			// ALS must not share any cells
-			if ALSShareCells(alsA, alsB) {
+// DetectXYWing finds XY-Wing pattern: pivot cell with candidates XY,
+// two wings with candidates XZ and YZ, eliminate Z from cells seeing both wings
+func DetectXYWing(b BoardInterface) *core.Move {
+	// Find cells with exactly 2 candidates (potential pivots or wings)
+	var bivalues []int
+	for i := 0; i < constants.TotalCells; i++ {
+		if b.GetCandidatesAt(i).Count() == 2 {
+			bivalues = append(bivalues, i)
+		}
+	}
+
+	for _, pivot := range bivalues {
+		pivotCands := b.GetCandidatesAt(pivot).ToSlice()
+		if len(pivotCands) != 2 {
+			continue
+		}
+		x, y := pivotCands[0], pivotCands[1]
+
+		// Find wings that see the pivot
+		var xzWings, yzWings []int
+
+		for _, wing := range bivalues {
+			if wing == pivot {
+				continue
+			}
+			if !ArePeers(pivot, wing) {
 				continue
 			}
 
-			// Find common digits between the two ALS
-			commonDigits := IntersectInts(alsA.Digits, alsB.Digits)
-			if len(commonDigits) < 2 {
-				continue // Need at least X (restricted common) and Z (elimination digit)
+			wingCands := b.GetCandidatesAt(wing).ToSlice()
+			if len(wingCands) != 2 {
+				continue
 			}
 
-			// Try each pair of common digits as (X, Z)
-			for _, x := range commonDigits {
-				// Check if X is a restricted common:
-				// All cells with X in A must see all cells with X in B
-				xCellsA := alsA.ByDigit[x]
-				xCellsB := alsB.ByDigit[x]
+			hasX := wingCands[0] == x || wingCands[1] == x
+			hasY := wingCands[0] == y || wingCands[1] == y
+
+			if hasX && !hasY {
+				xzWings = append(xzWings, wing)
+			} else if hasY && !hasX {
+				yzWings = append(yzWings, wing)
+			}
+		}
+
+		// Try all XZ-YZ pairs
+		for _, xzWing := range xzWings {
+			xzCands := b.GetCandidatesAt(xzWing).ToSlice()
+			var z1 int
+			if xzCands[0] == x {
+				z1 = xzCands[1]
+			} else {
+				z1 = xzCands[0]
+			}
 
-				if !AllSeeAll(xCellsA, xCellsB) {
+			for _, yzWing := range yzWings {
+				yzCands := b.GetCandidatesAt(yzWing).ToSlice()
+				var z2 int
+				if yzCands[0] == y {
+					z2 = yzCands[1]
+				} else {
+					z2 = yzCands[0]
+				}
+
+				if z1 != z2 {
 					continue
 				}
+				z := z1
 
-				// For each other common digit Z, try to find eliminations
-				for _, z := range commonDigits {
-					if z == x {
+				// Find cells that see both wings and have z as candidate
+				var eliminations []core.Candidate
+				for i := 0; i < constants.TotalCells; i++ {
+					if i == pivot || i == xzWing || i == yzWing {
 						continue
 					}
+					if !b.GetCandidatesAt(i).Has(z) {
+						continue
+					}
+					if ArePeers(i, xzWing) && ArePeers(i, yzWing) {
+						eliminations = append(eliminations, core.Candidate{
+							Row: i / constants.GridSize, Col: i % constants.GridSize, Digit: z,
+						})
+					}
+				}
 
-					zCellsA := alsA.ByDigit[z]
-					zCellsB := alsB.ByDigit[z]
-
-					// Build exclusion set (all cells in either ALS)
-					exclude := append(alsA.Cells, alsB.Cells...)
-					// Combine Z cells from both ALS
-					allZCells := append(zCellsA, zCellsB...)
-
-					// Find cells that see ALL Z-cells in both ALS
-					eliminations := FindEliminationsSeeing(b, z, exclude, allZCells...)
-
-					if len(eliminations) > 0 {
-						// Build targets from both ALS cells
-						targets := CellRefsFromIndices(append(alsA.Cells, alsB.Cells...)...)
-
-						return &core.Move{
-							Action:       "eliminate",
-							Digit:        z,
-							Targets:      targets,
-							Eliminations: eliminations,
-							Explanation: fmt.Sprintf("ALS-XZ: ALS A {%v} and ALS B {%v} with restricted common %d: eliminate %d.",
-								FormatCells(alsA.Cells), FormatCells(alsB.Cells), x, z),
-							Highlights: core.Highlights{
-								Primary: targets,
+				if len(eliminations) > 0 {
+					return explained(&core.Move{
+						Action: "eliminate",
+						Digit:  z,
+						Targets: []core.CellRef{
+							{Row: pivot / constants.GridSize, Col: pivot % constants.GridSize},
+							{Row: xzWing / constants.GridSize, Col: xzWing % constants.GridSize},
+							{Row: yzWing / constants.GridSize, Col: yzWing % constants.GridSize},
+						},
+						Eliminations: eliminations,
+						Highlights: core.Highlights{
+							Primary: []core.CellRef{
+								{Row: pivot / constants.GridSize, Col: pivot % constants.GridSize},
+								{Row: xzWing / constants.GridSize, Col: xzWing % constants.GridSize},
+								{Row: yzWing / constants.GridSize, Col: yzWing % constants.GridSize},
 							},
-						}
-					}
+						},
+					}, "technique.xy-wing", map[string]interface{}{
+						"pivot": FormatCell(pivot),
+						"digit": z,
+					})
 				}
 			}
 		}
@@ -2,360 +2,476 @@ package techniques
 
 import (
 	"fmt"
+	"sort"
 
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
 
-// DetectGroupedXCycles finds Grouped X-Cycles eliminations or assignments.
+// ============================================================================
+// Grouped X-Cycles
+// ============================================================================
 //
-// X-Cycles are chains of strong and weak links for a single digit that form a loop.
-// Strong link: exactly 2 candidates in a unit (if one is false, other must be true)
-// Weak link: candidates see each other (if one is true, other must be false)
+// X-Cycles are closed loops of strong and weak links for a single digit.
+// Grouped X-Cycles extend the node set: all candidates of the digit inside a
+// box-row or box-column intersection act as one node, so a strong link can
+// connect a cell to a group ("the digit is in this cell or somewhere in that
+// segment").
 //
-// Types of X-Cycles:
-//   - Nice Loop (continuous): alternates strong-weak-strong-weak..., even length
-//     -> Cells seeing both ends of any weak link can eliminate the digit
-//   - Type 1 (discontinuous): two strong links meet at a node
-//     -> That node must be ON (the digit goes there)
-//   - Type 2 (discontinuous): two weak links meet at a node
-//     -> That node must be OFF (eliminate the digit)
+//   strong link: the digit has exactly two placements in a unit, where each
+//                side may be a single cell or a box-line segment
+//   weak link:   two disjoint nodes whose cells all share a unit (at most one
+//                side can be true)
+//
+// A closed loop is classified by its alternation parity:
+//   - Continuous (nice loop): strict strong/weak alternation all the way
+//     around -> the digit leaves every weak link's unit except the two ends
+//   - Discontinuity at a strong link: two strong links meet at one node
+//     -> that node is ON (assigned when it is a single cell)
+//   - Discontinuity at a weak link: two weak links meet at one node
+//     -> that node is OFF (the digit leaves all its cells)
+// Loops with more than one alternation break prove nothing and are rejected
+// by classifyXCycleLoop, so an even/odd mix can never produce a move.
+
+// GroupedXCyclesMaxLinks bounds the loop search in links. Longer loops exist
+// but are rarely human-findable and the search cost grows quickly.
+var GroupedXCyclesMaxLinks = 8
+
+// DetectGroupedXCycles finds the shortest grouped X-Cycle with a conclusion,
+// scanning digits ascending
 func DetectGroupedXCycles(b BoardInterface) *core.Move {
 	for digit := 1; digit <= constants.GridSize; digit++ {
-		if move := findGroupedXCycleForDigit(b, digit); move != nil {
+		g := newXCGraph(b, digit)
+		if move := g.findBestLoop(); move != nil {
 			return move
 		}
 	}
 	return nil
 }
 
-func findGroupedXCycleForDigit(b BoardInterface, digit int) *core.Move {
-	// Build list of cells with this candidate
-	var cells []int
-	for idx := 0; idx < constants.TotalCells; idx++ {
-		if b.GetCandidatesAt(idx).Has(digit) {
-			cells = append(cells, idx)
+// xcGraph is the single-digit link graph. Nodes are identified by id; node 0
+// onward map to sorted cell slices (length 1 for plain cells, 2-3 for
+// box-line segments)
+type xcGraph struct {
+	b      BoardInterface
+	digit  int
+	nodes  [][]int
+	strong [][]int
+	ids    map[int]int // encoded cell set -> node id
+}
+
+func newXCGraph(b BoardInterface, digit int) *xcGraph {
+	g := &xcGraph{b: b, digit: digit, ids: make(map[int]int)}
+
+	// Strong links in rows and columns: with the candidates in exactly two
+	// boxes, one side or the other must hold the digit
+	for _, dir := range []UnitType{UnitRow, UnitCol} {
+		for primary := 0; primary < constants.GridSize; primary++ {
+			var cells []int
+			for secondary := 0; secondary < constants.GridSize; secondary++ {
+				idx := cellIndexForDirection(dir, primary, secondary)
+				if b.GetCandidatesAt(idx).Has(digit) {
+					cells = append(cells, idx)
+				}
+			}
+			g.addLineLinks(cells, BoxOf)
 		}
 	}
 
-	if len(cells) < 4 {
-		return nil
+	// Strong links in boxes: the same split along row and column segments
+	for box := 0; box < constants.GridSize; box++ {
+		var cells []int
+		for _, idx := range BoxIndices[box] {
+			if b.GetCandidatesAt(idx).Has(digit) {
+				cells = append(cells, idx)
+			}
+		}
+		g.addLineLinks(cells, func(idx int) int { return idx / constants.GridSize })
+		g.addLineLinks(cells, func(idx int) int { return idx % constants.GridSize })
 	}
 
-	// Build strong link pairs - cells that are the only two with digit in their unit
-	strongLinks := buildStrongLinksXC(b, digit, cells)
+	// Every candidate cell is a node even without a strong link: such a cell
+	// can still be the weak-discontinuity a loop closes on
+	for idx := 0; idx < constants.TotalCells; idx++ {
+		if b.GetCandidatesAt(idx).Has(digit) {
+			g.nodeID([]int{idx})
+		}
+	}
 
-	// Try to find cycles using DFS with alternating links
-	return findXCyclesDFS(b, digit, cells, strongLinks)
+	return g
 }
 
-// strongLinkXC represents a bidirectional strong link between two cells
-type strongLinkXC struct {
-	cell1, cell2 int
-	unit         string // "row", "col", or "box"
+// addLineLinks splits a unit's candidate cells into segments by the given
+// partition and records a strong link when exactly two segments remain. A
+// two-cell unit also links directly as a plain conjugate pair
+func (g *xcGraph) addLineLinks(cells []int, segmentOf func(int) int) {
+	if len(cells) < 2 {
+		return
+	}
+	if len(cells) == 2 {
+		g.addStrong(g.nodeID([]int{cells[0]}), g.nodeID([]int{cells[1]}))
+		return
+	}
+
+	segments := make(map[int][]int)
+	for _, idx := range cells {
+		seg := segmentOf(idx)
+		segments[seg] = append(segments[seg], idx)
+	}
+	if len(segments) != 2 {
+		return
+	}
+	var sides [][]int
+	for _, side := range segments {
+		sides = append(sides, side)
+	}
+	g.addStrong(g.nodeID(sides[0]), g.nodeID(sides[1]))
 }
 
-func buildStrongLinksXC(b BoardInterface, digit int, cells []int) []strongLinkXC {
-	var links []strongLinkXC
+// nodeID interns a cell set and returns its node id
+func (g *xcGraph) nodeID(cells []int) int {
+	sorted := make([]int, len(cells))
+	copy(sorted, cells)
+	sort.Ints(sorted)
 
-	// Check rows
-	for row := 0; row < constants.GridSize; row++ {
-		var rowCells []int
-		for _, idx := range cells {
-			if idx/constants.GridSize == row {
-				rowCells = append(rowCells, idx)
-			}
-		}
-		if len(rowCells) == 2 {
-			links = append(links, strongLinkXC{rowCells[0], rowCells[1], "row"})
+	key := 0
+	for _, c := range sorted {
+		key = key*(constants.TotalCells+1) + c + 1
+	}
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+	id := len(g.nodes)
+	g.ids[key] = id
+	g.nodes = append(g.nodes, sorted)
+	g.strong = append(g.strong, nil)
+	return id
+}
+
+// addStrong records a strong link in both directions, skipping duplicates
+// (the same pair can qualify through more than one unit)
+func (g *xcGraph) addStrong(a, b int) {
+	if a == b {
+		return
+	}
+	for _, existing := range g.strong[a] {
+		if existing == b {
+			return
 		}
 	}
+	g.strong[a] = append(g.strong[a], b)
+	g.strong[b] = append(g.strong[b], a)
+}
 
-	// Check columns
-	for col := 0; col < constants.GridSize; col++ {
-		var colCells []int
-		for _, idx := range cells {
-			if idx%constants.GridSize == col {
-				colCells = append(colCells, idx)
+// weakLinkedXC reports whether two nodes cannot both hold the digit: their
+// cells are disjoint and all of them share a row, column, or box
+func (g *xcGraph) weakLinkedXC(a, b int) bool {
+	for _, ca := range g.nodes[a] {
+		for _, cb := range g.nodes[b] {
+			if ca == cb {
+				return false
 			}
 		}
-		if len(colCells) == 2 {
-			links = append(links, strongLinkXC{colCells[0], colCells[1], "col"})
-		}
 	}
+	combined := make([]int, 0, len(g.nodes[a])+len(g.nodes[b]))
+	combined = append(combined, g.nodes[a]...)
+	combined = append(combined, g.nodes[b]...)
+	return cellsShareUnit(combined)
+}
 
-	// Check boxes
-	for box := 0; box < constants.GridSize; box++ {
-		boxRowStart := (box / constants.BoxSize) * constants.BoxSize
-		boxColStart := (box % constants.BoxSize) * constants.BoxSize
-		var boxCells []int
-		for _, idx := range cells {
-			r, c := idx/constants.GridSize, idx%constants.GridSize
-			if r >= boxRowStart && r < boxRowStart+constants.BoxSize && c >= boxColStart && c < boxColStart+constants.BoxSize {
-				boxCells = append(boxCells, idx)
-			}
+// cellsShareUnit reports whether all cells lie in one row, column, or box
+func cellsShareUnit(cells []int) bool {
+	sameRow, sameCol, sameBox := true, true, true
+	for _, idx := range cells[1:] {
+		if idx/constants.GridSize != cells[0]/constants.GridSize {
+			sameRow = false
 		}
-		if len(boxCells) == 2 {
-			links = append(links, strongLinkXC{boxCells[0], boxCells[1], "box"})
+		if idx%constants.GridSize != cells[0]%constants.GridSize {
+			sameCol = false
+		}
+		if BoxOf(idx) != BoxOf(cells[0]) {
+			sameBox = false
 		}
 	}
-
-	return links
+	return sameRow || sameCol || sameBox
 }
 
-// hasStrongLinkXC checks if there's a strong link between two cells
-func hasStrongLinkXC(strongLinks []strongLinkXC, cell1, cell2 int) bool {
-	for _, link := range strongLinks {
-		if (link.cell1 == cell1 && link.cell2 == cell2) ||
-			(link.cell1 == cell2 && link.cell2 == cell1) {
-			return true
+// overlapsPath reports whether a node shares a cell with any node on the path
+func (g *xcGraph) overlapsPath(path []int, node int) bool {
+	for _, p := range path {
+		for _, cp := range g.nodes[p] {
+			for _, cn := range g.nodes[node] {
+				if cp == cn {
+					return true
+				}
+			}
 		}
 	}
 	return false
 }
 
-// hasWeakLinkXC checks if there's a weak link (cells see each other)
-func hasWeakLinkXC(cell1, cell2 int) bool {
-	return cell1 != cell2 && ArePeers(cell1, cell2)
+// Loop classifications from classifyXCycleLoop
+const (
+	loopInvalid = iota
+	loopContinuous
+	loopStrongDiscontinuity
+	loopWeakDiscontinuity
+)
+
+// classifyXCycleLoop checks the alternation parity of a closed loop.
+// links[i] is the type of the link leaving node i (true = strong). Exactly
+// zero alternation breaks means a continuous loop; exactly one identifies
+// the discontinuity node; anything else proves nothing and is rejected
+func classifyXCycleLoop(links []bool) (kind, at int) {
+	n := len(links)
+	breaks := 0
+	at = -1
+	for i := 0; i < n; i++ {
+		if links[(i-1+n)%n] == links[i] {
+			breaks++
+			at = i
+		}
+	}
+	switch {
+	case breaks == 0:
+		return loopContinuous, -1
+	case breaks == 1 && links[at]:
+		return loopStrongDiscontinuity, at
+	case breaks == 1:
+		return loopWeakDiscontinuity, at
+	default:
+		return loopInvalid, -1
+	}
 }
 
-// findXCyclesDFS searches for X-Cycles using DFS
-func findXCyclesDFS(b BoardInterface, digit int, cells []int, strongLinks []strongLinkXC) *core.Move {
-	// Try starting from each cell
-	for _, startCell := range cells {
-		// Try both: starting with strong link out, or weak link out
-		for _, startWithStrong := range []bool{true, false} {
-			if move := searchCycle(b, digit, cells, strongLinks, startCell, startWithStrong); move != nil {
-				return move
+// findBestLoop searches loops from every node with both starting parities,
+// breadth-first, and returns the move from the shortest loop that concludes
+// anything
+func (g *xcGraph) findBestLoop() *core.Move {
+	var best *core.Move
+	bestLen := GroupedXCyclesMaxLinks + 1
+
+	for start := range g.nodes {
+		for _, startStrong := range []bool{true, false} {
+			move, length := g.searchLoopsFrom(start, startStrong, bestLen)
+			if move != nil && length < bestLen {
+				best, bestLen = move, length
 			}
 		}
 	}
-	return nil
+	return best
 }
 
-func searchCycle(b BoardInterface, digit int, cells []int, strongLinks []strongLinkXC, startCell int, startWithStrong bool) *core.Move {
-	// DFS with proper alternation tracking
-	// path[i] connected to path[i+1] via linkStrong[i]
-	type dfsState struct {
-		cell       int
+// searchLoopsFrom runs a breadth-first alternating walk from start, closing
+// back to it whenever the required link type allows. BFS order means the
+// first conclusive loop is also the shortest from this start
+func (g *xcGraph) searchLoopsFrom(start int, startStrong bool, maxLinks int) (*core.Move, int) {
+	type item struct {
+		node       int
 		path       []int
-		linkStrong []bool // linkStrong[i] = type of link from path[i] to path[i+1]
+		links      []bool // links[i] joins path[i] to path[i+1]
+		strongNext bool
 	}
-
-	stack := []dfsState{{
-		cell:       startCell,
-		path:       []int{startCell},
-		linkStrong: []bool{},
-	}}
-
-	for len(stack) > 0 {
-		state := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		// Limit path length
-		if len(state.path) > 10 {
-			continue
-		}
-
-		currentCell := state.cell
-		pathLen := len(state.path)
-
-		// Determine what kind of link we need next
-		// We want to alternate: strong, weak, strong, weak, ...
-		// First link type is determined by startWithStrong
-		var needStrong bool
-		if pathLen == 1 {
-			needStrong = startWithStrong
-		} else {
-			// Alternate from previous
-			needStrong = !state.linkStrong[len(state.linkStrong)-1]
-		}
-
-		// Check for cycle back to start (need at least 4 nodes for a valid cycle)
-		if pathLen >= 4 {
-			if needStrong && hasStrongLinkXC(strongLinks, currentCell, startCell) {
-				// Cycle closes with strong link
-				fullPath := state.path
-				fullLinks := append(state.linkStrong, true) // closing link is strong
-
-				if move := analyzeCycleFixed(b, digit, fullPath, fullLinks); move != nil {
-					return move
+	queue := []item{{node: start, path: []int{start}, strongNext: startStrong}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		// Try closing back to the start with the required link type
+		if len(cur.path) >= 3 {
+			closes := false
+			if cur.strongNext {
+				for _, next := range g.strong[cur.node] {
+					if next == start {
+						closes = true
+					}
 				}
+			} else {
+				closes = g.weakLinkedXC(cur.node, start)
 			}
-			if !needStrong && hasWeakLinkXC(currentCell, startCell) {
-				// Cycle closes with weak link
-				fullPath := state.path
-				fullLinks := append(state.linkStrong, false) // closing link is weak
-
-				if move := analyzeCycleFixed(b, digit, fullPath, fullLinks); move != nil {
-					return move
+			if closes {
+				links := append(append([]bool{}, cur.links...), cur.strongNext)
+				if move := g.concludeLoop(cur.path, links); move != nil {
+					return move, len(links)
 				}
 			}
 		}
 
-		// Explore neighbors with the required link type
-		for _, nextCell := range cells {
-			// Don't revisit cells in path (except for closing cycle, handled above)
-			inPath := false
-			for _, p := range state.path {
-				if p == nextCell {
-					inPath = true
-					break
+		if len(cur.links)+1 >= maxLinks {
+			continue
+		}
+
+		var neighbors []int
+		if cur.strongNext {
+			neighbors = g.strong[cur.node]
+		} else {
+			for next := range g.nodes {
+				if g.weakLinkedXC(cur.node, next) {
+					neighbors = append(neighbors, next)
 				}
 			}
-			if inPath {
+		}
+		for _, next := range neighbors {
+			if g.overlapsPath(cur.path, next) {
 				continue
 			}
+			path := make([]int, len(cur.path)+1)
+			copy(path, cur.path)
+			path[len(cur.path)] = next
+			links := make([]bool, len(cur.links)+1)
+			copy(links, cur.links)
+			links[len(cur.links)] = cur.strongNext
+			queue = append(queue, item{node: next, path: path, links: links, strongNext: !cur.strongNext})
+		}
+	}
 
-			// Check if we have the required link type
-			hasLink := false
-			if needStrong {
-				hasLink = hasStrongLinkXC(strongLinks, currentCell, nextCell)
-			} else {
-				hasLink = hasWeakLinkXC(currentCell, nextCell)
-			}
+	return nil, 0
+}
 
-			if !hasLink {
-				continue
+// concludeLoop classifies a closed loop and builds the move for its rule, or
+// nil when the loop proves nothing on this board
+func (g *xcGraph) concludeLoop(path []int, links []bool) *core.Move {
+	kind, at := classifyXCycleLoop(links)
+
+	switch kind {
+	case loopContinuous:
+		return g.niceLoopMove(path, links)
+
+	case loopStrongDiscontinuity:
+		// The node is ON. Only a single cell can be assigned; a group
+		// discontinuity confines the digit without placing it, which is not
+		// worth a move of its own
+		cell := -1
+		if len(g.nodes[path[at]]) == 1 {
+			cell = g.nodes[path[at]][0]
+		}
+		if cell < 0 || !g.b.GetCandidatesAt(cell).Has(g.digit) {
+			return nil
+		}
+		return withTargetCandidates(g.b, explained(&core.Move{
+			Action:  "assign",
+			Digit:   g.digit,
+			Targets: []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
+			Highlights: core.Highlights{
+				Primary: g.pathCellRefs(path),
+			},
+		}, "technique.grouped-x-cycles.assign", map[string]interface{}{
+			"digit": g.digit,
+			"cell":  FormatCell(cell),
+		}))
+
+	case loopWeakDiscontinuity:
+		// The node is OFF: the digit leaves all its cells
+		var elims []core.Candidate
+		for _, cell := range g.nodes[path[at]] {
+			if g.b.GetCandidatesAt(cell).Has(g.digit) {
+				elims = append(elims, core.Candidate{
+					Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: g.digit,
+				})
 			}
-
-			newPath := make([]int, len(state.path)+1)
-			copy(newPath, state.path)
-			newPath[len(state.path)] = nextCell
-
-			newLinks := make([]bool, len(state.linkStrong)+1)
-			copy(newLinks, state.linkStrong)
-			newLinks[len(state.linkStrong)] = needStrong
-
-			stack = append(stack, dfsState{
-				cell:       nextCell,
-				path:       newPath,
-				linkStrong: newLinks,
-			})
 		}
+		if len(elims) == 0 {
+			return nil
+		}
+		return explained(&core.Move{
+			Action:       "eliminate",
+			Digit:        g.digit,
+			Eliminations: elims,
+			Highlights: core.Highlights{
+				Primary:   g.pathCellRefs(path),
+				Secondary: g.nodeCellRefs(path[at]),
+			},
+		}, "technique.grouped-x-cycles.weak", map[string]interface{}{
+			"digit": g.digit,
+			"node":  g.nodeName(path[at]),
+		})
 	}
 
 	return nil
 }
 
-// analyzeCycleFixed checks for discontinuities in the cycle
-// linkStrong[i] = type of link from path[i] to path[(i+1) % len(path)]
-func analyzeCycleFixed(b BoardInterface, digit int, path []int, linkStrong []bool) *core.Move {
+// niceLoopMove collects eliminations for a continuous loop: any candidate
+// outside the loop that sees every cell of both ends of a weak link
+func (g *xcGraph) niceLoopMove(path []int, links []bool) *core.Move {
 	n := len(path)
-	if n < 4 || len(linkStrong) != n {
-		return nil
+	inLoop := make(map[int]bool)
+	for _, node := range path {
+		for _, cell := range g.nodes[node] {
+			inLoop[cell] = true
+		}
 	}
 
-	// Check each node for discontinuity
-	// At node i:
-	//   - Link coming IN is linkStrong[(i-1+n)%n] (from path[i-1] to path[i])
-	//   - Link going OUT is linkStrong[i] (from path[i] to path[i+1])
+	var elims []core.Candidate
 	for i := 0; i < n; i++ {
-		linkIn := linkStrong[(i-1+n)%n]
-		linkOut := linkStrong[i]
-
-		cell := path[i]
-
-		// Type 1: Two strong links meet -> cell must be ON
-		if linkIn && linkOut {
-			return &core.Move{
-				Action:  "assign",
-				Digit:   digit,
-				Targets: []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
-				Explanation: fmt.Sprintf("X-Cycle Type 1: two strong links meet at R%dC%d, so it must be %d",
-					cell/constants.GridSize+1, cell%constants.GridSize+1, digit),
-				Highlights: core.Highlights{
-					Primary: pathToCellRefsSimple(path),
-				},
-			}
+		if links[i] {
+			continue
 		}
-
-		// Type 2: Two weak links meet -> cell must be OFF
-		if !linkIn && !linkOut {
-			if b.GetCandidatesAt(cell).Has(digit) {
-				return &core.Move{
-					Action: "eliminate",
-					Digit:  digit,
-					Eliminations: []core.Candidate{
-						{Row: cell / constants.GridSize, Col: cell % constants.GridSize, Digit: digit},
-					},
-					Explanation: fmt.Sprintf("X-Cycle Type 2: two weak links meet at R%dC%d, eliminating %d",
-						cell/constants.GridSize+1, cell%constants.GridSize+1, digit),
-					Highlights: core.Highlights{
-						Primary:   pathToCellRefsSimple(path),
-						Secondary: []core.CellRef{{Row: cell / constants.GridSize, Col: cell % constants.GridSize}},
-					},
-				}
+		end1, end2 := path[i], path[(i+1)%n]
+		for idx := 0; idx < constants.TotalCells; idx++ {
+			if inLoop[idx] || !g.b.GetCandidatesAt(idx).Has(g.digit) {
+				continue
+			}
+			if g.seesAllCells(idx, end1) && g.seesAllCells(idx, end2) {
+				elims = append(elims, core.Candidate{
+					Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: g.digit,
+				})
 			}
 		}
 	}
+	if len(elims) == 0 {
+		return nil
+	}
 
-	// No discontinuity found - this is a nice loop (continuous, alternating)
-	// For nice loops, we can eliminate from cells that see both ends of any weak link
-	return findNiceLoopEliminationsFixed(b, digit, path, linkStrong)
+	return explained(&core.Move{
+		Action:       "eliminate",
+		Digit:        g.digit,
+		Eliminations: DedupeEliminations(elims),
+		Highlights: core.Highlights{
+			Primary: g.pathCellRefs(path),
+		},
+	}, "technique.grouped-x-cycles.loop", map[string]interface{}{
+		"digit": g.digit,
+	})
 }
 
-func findNiceLoopEliminationsFixed(b BoardInterface, digit int, path []int, linkStrong []bool) *core.Move {
-	n := len(path)
-
-	var eliminations []core.Candidate
-
-	// Find weak links and look for eliminations
-	for i := 0; i < n; i++ {
-		if !linkStrong[i] { // This is a weak link
-			cell1 := path[i]
-			cell2 := path[(i+1)%n]
-
-			// Cells that see BOTH ends of this weak link can eliminate the digit
-			for idx := 0; idx < constants.TotalCells; idx++ {
-				if !b.GetCandidatesAt(idx).Has(digit) {
-					continue
-				}
-
-				// Skip cells in the cycle
-				inPath := false
-				for _, p := range path {
-					if p == idx {
-						inPath = true
-						break
-					}
-				}
-				if inPath {
-					continue
-				}
-
-				if ArePeers(idx, cell1) && ArePeers(idx, cell2) {
-					eliminations = append(eliminations, core.Candidate{
-						Row: idx / constants.GridSize, Col: idx % constants.GridSize, Digit: digit,
-					})
-				}
-			}
+// seesAllCells reports whether idx is a peer of every cell in the node
+func (g *xcGraph) seesAllCells(idx, node int) bool {
+	for _, cell := range g.nodes[node] {
+		if !ArePeers(idx, cell) {
+			return false
 		}
 	}
+	return true
+}
 
-	if len(eliminations) > 0 {
-		// Deduplicate
-		eliminations = DedupeEliminations(eliminations)
-
-		return &core.Move{
-			Action:       "eliminate",
-			Digit:        digit,
-			Eliminations: eliminations,
-			Explanation: fmt.Sprintf("X-Cycle Nice Loop: eliminate %d from cells seeing both ends of weak links.",
-				digit),
-			Highlights: core.Highlights{
-				Primary: pathToCellRefsSimple(path),
-			},
-		}
+// pathCellRefs flattens the loop nodes to cell references for highlighting
+func (g *xcGraph) pathCellRefs(path []int) []core.CellRef {
+	var refs []core.CellRef
+	for _, node := range path {
+		refs = append(refs, g.nodeCellRefs(node)...)
 	}
-
-	return nil
+	return refs
 }
 
-func pathToCellRefsSimple(path []int) []core.CellRef {
-	refs := make([]core.CellRef, len(path))
-	for i, cell := range path {
+func (g *xcGraph) nodeCellRefs(node int) []core.CellRef {
+	refs := make([]core.CellRef, len(g.nodes[node]))
+	for i, cell := range g.nodes[node] {
 		refs[i] = core.CellRef{Row: cell / constants.GridSize, Col: cell % constants.GridSize}
 	}
 	return refs
 }
+
+// nodeName formats a node for explanations: a cell reference, or the cell
+// list of a group
+func (g *xcGraph) nodeName(node int) string {
+	cells := g.nodes[node]
+	if len(cells) == 1 {
+		return fmt.Sprintf("R%dC%d", cells[0]/constants.GridSize+1, cells[0]%constants.GridSize+1)
+	}
+	name := "group"
+	for _, cell := range cells {
+		name += fmt.Sprintf(" R%dC%d", cell/constants.GridSize+1, cell%constants.GridSize+1)
+	}
+	return name
+}
@@ -1,8 +1,6 @@
 package techniques
 
 import (
-	"fmt"
-
 	"sudoku-api/internal/core"
 	"sudoku-api/pkg/constants"
 )
@@ -14,8 +12,14 @@ import (
 // A Finned X-Wing is like a regular X-Wing but with one extra candidate (the "fin")
 // in one of the rows/columns. The fin restricts where eliminations can occur:
 // only cells that see both the X-Wing corner AND the fin can be eliminated.
+//
+// The Sashimi variant drops one base corner entirely: the fin row covers only
+// one of the two base columns, and the fin stands in for the missing corner
+// (it must share a box with it). Eliminations work the same way — cells in the
+// target column inside the fin's box. Sashimi moves are labeled
+// "sashimi-x-wing" so stats separate the variants.
 
-// DetectFinnedXWing finds Finned X-Wing patterns
+// DetectFinnedXWing finds Finned and Sashimi X-Wing patterns
 func DetectFinnedXWing(b BoardInterface) *core.Move {
 	for digit := 1; digit <= constants.GridSize; digit++ {
 		// Check row-based finned X-wing
@@ -50,94 +54,134 @@ func detectFinnedXWingInRows(b BoardInterface, digit int) *core.Move {
 		}
 	}
 
-	// Try pairs of rows
+	// Try pairs of rows; either row can serve as the base (exactly 2
+	// candidates), the other carries the fin(s)
 	for i := 0; i < len(rows); i++ {
 		for j := i + 1; j < len(rows); j++ {
-			r1, r2 := rows[i], rows[j]
+			for _, pair := range [2][2]rowInfo{{rows[i], rows[j]}, {rows[j], rows[i]}} {
+				baseRow, finRow := pair[0], pair[1]
+				if len(baseRow.cols) != 2 {
+					continue
+				}
 
-			// One row should have exactly 2, the other 2 or 3
-			// The row with 3 has the fin
-			var baseRow, finRow rowInfo
-			if len(r1.cols) == 2 && len(r2.cols) == 3 {
-				baseRow, finRow = r1, r2
-			} else if len(r1.cols) == 3 && len(r2.cols) == 2 {
-				baseRow, finRow = r2, r1
-			} else if len(r1.cols) == 2 && len(r2.cols) == 2 {
-				// Both have 2 - not a finned X-wing
-				continue
-			} else {
-				continue
-			}
+				// Split the fin row into covered base corners and fin columns
+				c1, c2 := baseRow.cols[0], baseRow.cols[1]
+				hasC1, hasC2 := false, false
+				var finCols []int
+				for _, c := range finRow.cols {
+					switch c {
+					case c1:
+						hasC1 = true
+					case c2:
+						hasC2 = true
+					default:
+						finCols = append(finCols, c)
+					}
+				}
+				if len(finCols) == 0 || (!hasC1 && !hasC2) {
+					continue
+				}
 
-			// Find common columns and fin column
-			c1, c2 := baseRow.cols[0], baseRow.cols[1]
-			finCol := -1
-			hasC1, hasC2 := false, false
+				// All fins must be in the same box
+				finBox := finCols[0] / constants.BoxSize
+				sameBox := true
+				for _, fc := range finCols[1:] {
+					if fc/constants.BoxSize != finBox {
+						sameBox = false
+						break
+					}
+				}
+				if !sameBox {
+					continue
+				}
 
-			for _, c := range finRow.cols {
-				switch c {
-				case c1:
-					hasC1 = true
-				case c2:
-					hasC2 = true
-				default:
-					finCol = c
+				// Standard finned: both corners covered, fin shares a box with
+				// one of them. Sashimi: one corner missing, the fin must share
+				// a box with the missing corner
+				sashimi := false
+				targetCol := -1
+				switch {
+				case hasC1 && hasC2:
+					if c1/constants.BoxSize == finBox {
+						targetCol = c1
+					} else if c2/constants.BoxSize == finBox {
+						targetCol = c2
+					}
+				case hasC1:
+					sashimi = true
+					if c2/constants.BoxSize == finBox {
+						targetCol = c2
+					}
+				default: // hasC2
+					sashimi = true
+					if c1/constants.BoxSize == finBox {
+						targetCol = c1
+					}
+				}
+				if targetCol == -1 {
+					continue
 				}
-			}
 
-			if !hasC1 || !hasC2 || finCol == -1 {
-				continue
-			}
+				// Every pattern cell is excluded from elimination explicitly
+				pattern := map[int]bool{
+					baseRow.row*constants.GridSize + c1: true,
+					baseRow.row*constants.GridSize + c2: true,
+				}
+				for _, c := range finRow.cols {
+					pattern[finRow.row*constants.GridSize+c] = true
+				}
 
-			// The fin must be in the same box as one of the base columns in the fin row
-			finRowBox := finRow.row / constants.BoxSize
-			finColBox := finCol / constants.BoxSize
+				// Eliminations: cells in targetCol inside the fin's box
+				var eliminations []core.Candidate
+				boxRowStart := (finRow.row / constants.BoxSize) * constants.BoxSize
+				for r := boxRowStart; r < boxRowStart+constants.BoxSize; r++ {
+					idx := r*constants.GridSize + targetCol
+					if pattern[idx] {
+						continue
+					}
+					if b.GetCandidatesAt(idx).Has(digit) {
+						eliminations = append(eliminations, core.Candidate{Row: r, Col: targetCol, Digit: digit})
+					}
+				}
 
-			// Find which base column the fin shares a box with
-			targetCol := -1
-			if c1/constants.BoxSize == finColBox {
-				targetCol = c1
-			} else if c2/constants.BoxSize == finColBox {
-				targetCol = c2
-			}
+				if len(eliminations) == 0 {
+					continue
+				}
 
-			if targetCol == -1 {
-				continue
-			}
+				technique, key := "finned-x-wing", "technique.finned-x-wing.rows"
+				if sashimi {
+					technique, key = "sashimi-x-wing", "technique.sashimi-x-wing.rows"
+				}
 
-			// Eliminations: cells in targetCol that are in the same box-row as the fin
-			// and see the base row's targetCol cell
-			var eliminations []core.Candidate
-			boxRowStart := finRowBox * constants.BoxSize
-			for r := boxRowStart; r < boxRowStart+constants.BoxSize; r++ {
-				if r == finRow.row || r == baseRow.row {
-					continue
+				corners := []core.CellRef{
+					{Row: baseRow.row, Col: c1}, {Row: baseRow.row, Col: c2},
 				}
-				idx := r*constants.GridSize + targetCol
-				if b.GetCandidatesAt(idx).Has(digit) {
-					eliminations = append(eliminations, core.Candidate{Row: r, Col: targetCol, Digit: digit})
+				if hasC1 {
+					corners = append(corners, core.CellRef{Row: finRow.row, Col: c1})
+				}
+				if hasC2 {
+					corners = append(corners, core.CellRef{Row: finRow.row, Col: c2})
+				}
+				var finCells []core.CellRef
+				for _, fc := range finCols {
+					finCells = append(finCells, core.CellRef{Row: finRow.row, Col: fc})
 				}
-			}
 
-			if len(eliminations) > 0 {
-				return &core.Move{
-					Action: "eliminate",
-					Digit:  digit,
-					Targets: []core.CellRef{
-						{Row: baseRow.row, Col: c1}, {Row: baseRow.row, Col: c2},
-						{Row: finRow.row, Col: c1}, {Row: finRow.row, Col: c2},
-						{Row: finRow.row, Col: finCol},
-					},
+				return explained(&core.Move{
+					Technique:    technique,
+					Action:       "eliminate",
+					Digit:        digit,
+					Targets:      append(append([]core.CellRef{}, corners...), finCells...),
 					Eliminations: eliminations,
-					Explanation:  fmt.Sprintf("Finned X-Wing: %d in rows %d,%d with fin at R%dC%d", digit, baseRow.row+1, finRow.row+1, finRow.row+1, finCol+1),
 					Highlights: core.Highlights{
-						Primary: []core.CellRef{
-							{Row: baseRow.row, Col: c1}, {Row: baseRow.row, Col: c2},
-							{Row: finRow.row, Col: c1}, {Row: finRow.row, Col: c2},
-						},
-						Secondary: []core.CellRef{{Row: finRow.row, Col: finCol}},
+						Primary:   corners,
+						Secondary: finCells,
 					},
-				}
+				}, key, map[string]interface{}{
+					"digit": digit,
+					"rows":  []int{baseRow.row + 1, finRow.row + 1},
+					"fin":   FormatCell(finRow.row*constants.GridSize + finCols[0]),
+				})
 			}
 		}
 	}
@@ -165,91 +209,134 @@ func detectFinnedXWingInCols(b BoardInterface, digit int) *core.Move {
 		}
 	}
 
-	// Try pairs of columns
+	// Try pairs of columns; either column can serve as the base (exactly 2
+	// candidates), the other carries the fin(s)
 	for i := 0; i < len(cols); i++ {
 		for j := i + 1; j < len(cols); j++ {
-			c1, c2 := cols[i], cols[j]
+			for _, pair := range [2][2]colInfo{{cols[i], cols[j]}, {cols[j], cols[i]}} {
+				baseCol, finCol := pair[0], pair[1]
+				if len(baseCol.rows) != 2 {
+					continue
+				}
 
-			// One column should have exactly 2 rows, the other 2 or 3
-			var baseCol, finCol colInfo
-			if len(c1.rows) == 2 && len(c2.rows) == 3 {
-				baseCol, finCol = c1, c2
-			} else if len(c1.rows) == 3 && len(c2.rows) == 2 {
-				baseCol, finCol = c2, c1
-			} else if len(c1.rows) == 2 && len(c2.rows) == 2 {
-				continue
-			} else {
-				continue
-			}
+				// Split the fin column into covered base corners and fin rows
+				r1, r2 := baseCol.rows[0], baseCol.rows[1]
+				hasR1, hasR2 := false, false
+				var finRows []int
+				for _, r := range finCol.rows {
+					switch r {
+					case r1:
+						hasR1 = true
+					case r2:
+						hasR2 = true
+					default:
+						finRows = append(finRows, r)
+					}
+				}
+				if len(finRows) == 0 || (!hasR1 && !hasR2) {
+					continue
+				}
 
-			// Find common rows and fin row
-			r1, r2 := baseCol.rows[0], baseCol.rows[1]
-			finRow := -1
-			hasR1, hasR2 := false, false
+				// All fins must be in the same box
+				finBox := finRows[0] / constants.BoxSize
+				sameBox := true
+				for _, fr := range finRows[1:] {
+					if fr/constants.BoxSize != finBox {
+						sameBox = false
+						break
+					}
+				}
+				if !sameBox {
+					continue
+				}
 
-			for _, r := range finCol.rows {
-				switch r {
-				case r1:
-					hasR1 = true
-				case r2:
-					hasR2 = true
-				default:
-					finRow = r
+				// Standard finned: both corners covered, fin shares a box with
+				// one of them. Sashimi: one corner missing, the fin must share
+				// a box with the missing corner
+				sashimi := false
+				targetRow := -1
+				switch {
+				case hasR1 && hasR2:
+					if r1/constants.BoxSize == finBox {
+						targetRow = r1
+					} else if r2/constants.BoxSize == finBox {
+						targetRow = r2
+					}
+				case hasR1:
+					sashimi = true
+					if r2/constants.BoxSize == finBox {
+						targetRow = r2
+					}
+				default: // hasR2
+					sashimi = true
+					if r1/constants.BoxSize == finBox {
+						targetRow = r1
+					}
+				}
+				if targetRow == -1 {
+					continue
 				}
-			}
 
-			if !hasR1 || !hasR2 || finRow == -1 {
-				continue
-			}
+				// Every pattern cell is excluded from elimination explicitly
+				pattern := map[int]bool{
+					r1*constants.GridSize + baseCol.col: true,
+					r2*constants.GridSize + baseCol.col: true,
+				}
+				for _, r := range finCol.rows {
+					pattern[r*constants.GridSize+finCol.col] = true
+				}
 
-			// The fin must be in the same box as one of the base rows in the fin column
-			finColBox := finCol.col / constants.BoxSize
-			finRowBox := finRow / constants.BoxSize
+				// Eliminations: cells in targetRow inside the fin's box
+				var eliminations []core.Candidate
+				boxColStart := (finCol.col / constants.BoxSize) * constants.BoxSize
+				for c := boxColStart; c < boxColStart+constants.BoxSize; c++ {
+					idx := targetRow*constants.GridSize + c
+					if pattern[idx] {
+						continue
+					}
+					if b.GetCandidatesAt(idx).Has(digit) {
+						eliminations = append(eliminations, core.Candidate{Row: targetRow, Col: c, Digit: digit})
+					}
+				}
 
-			// Find which base row the fin shares a box with
-			targetRow := -1
-			if r1/constants.BoxSize == finRowBox {
-				targetRow = r1
-			} else if r2/constants.BoxSize == finRowBox {
-				targetRow = r2
-			}
+				if len(eliminations) == 0 {
+					continue
+				}
 
-			if targetRow == -1 {
-				continue
-			}
+				technique, key := "finned-x-wing", "technique.finned-x-wing.cols"
+				if sashimi {
+					technique, key = "sashimi-x-wing", "technique.sashimi-x-wing.cols"
+				}
 
-			// Eliminations: cells in targetRow that are in the same box-column as the fin
-			var eliminations []core.Candidate
-			boxColStart := finColBox * constants.BoxSize
-			for c := boxColStart; c < boxColStart+constants.BoxSize; c++ {
-				if c == finCol.col || c == baseCol.col {
-					continue
+				corners := []core.CellRef{
+					{Row: r1, Col: baseCol.col}, {Row: r2, Col: baseCol.col},
 				}
-				idx := targetRow*constants.GridSize + c
-				if b.GetCandidatesAt(idx).Has(digit) {
-					eliminations = append(eliminations, core.Candidate{Row: targetRow, Col: c, Digit: digit})
+				if hasR1 {
+					corners = append(corners, core.CellRef{Row: r1, Col: finCol.col})
+				}
+				if hasR2 {
+					corners = append(corners, core.CellRef{Row: r2, Col: finCol.col})
+				}
+				var finCells []core.CellRef
+				for _, fr := range finRows {
+					finCells = append(finCells, core.CellRef{Row: fr, Col: finCol.col})
 				}
-			}
 
-			if len(eliminations) > 0 {
-				return &core.Move{
-					Action: "eliminate",
-					Digit:  digit,
-					Targets: []core.CellRef{
-						{Row: r1, Col: baseCol.col}, {Row: r2, Col: baseCol.col},
-						{Row: r1, Col: finCol.col}, {Row: r2, Col: finCol.col},
-						{Row: finRow, Col: finCol.col},
-					},
+				return explained(&core.Move{
+					Technique:    technique,
+					Action:       "eliminate",
+					Digit:        digit,
+					Targets:      append(append([]core.CellRef{}, corners...), finCells...),
 					Eliminations: eliminations,
-					Explanation:  fmt.Sprintf("Finned X-Wing: %d in columns %d,%d with fin at R%dC%d", digit, baseCol.col+1, finCol.col+1, finRow+1, finCol.col+1),
 					Highlights: core.Highlights{
-						Primary: []core.CellRef{
-							{Row: r1, Col: baseCol.col}, {Row: r2, Col: baseCol.col},
-							{Row: r1, Col: finCol.col}, {Row: r2, Col: finCol.col},
-						},
-						Secondary: []core.CellRef{{Row: finRow, Col: finCol.col}},
+						Primary:   corners,
+						Secondary: finCells,
 					},
-				}
+				}, key, map[string]interface{}{
+					"digit": digit,
+					"cols":  []int{baseCol.col + 1, finCol.col + 1},
+					"fin":   FormatCell(finRows[0]*constants.GridSize + finCol.col),
+				})
 			}
 		}
 	}
@@ -0,0 +1,116 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// urType1Board builds a minimal Unique Rectangle type-1 position: three
+// corners hold the bare pair {1,2} and the fourth carries an extra candidate,
+// so the UR detector eliminates 1 and 2 from it — but only if the puzzle is
+// known to have a single solution.
+func urType1Board() *Board {
+	cells := [81]int{}
+	cm := map[int][]int{}
+	for i := 0; i < 81; i++ {
+		cm[i] = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	}
+	// Corners r0c0/r0c3/r2c0/r2c3 span exactly two boxes
+	cm[0] = []int{1, 2}
+	cm[3] = []int{1, 2}
+	cm[18] = []int{1, 2}
+	cm[21] = []int{1, 2, 3}
+	return makeTestBoard(cells, cm)
+}
+
+// TestUniquenessTechniquesRespectAssumeUnique checks the central runDetector
+// gate: a technique tagged RequiresUniqueness fires on a board that assumes a
+// unique solution and is silently skipped on one that does not.
+func TestUniquenessTechniquesRespectAssumeUnique(t *testing.T) {
+	s := NewSolver()
+	ur := s.registry.GetBySlug("unique-rectangle")
+	if ur == nil {
+		t.Fatal("unique-rectangle not registered")
+	}
+	if !ur.RequiresUniqueness {
+		t.Fatal("unique-rectangle should be tagged RequiresUniqueness")
+	}
+
+	board := urType1Board()
+	if move := s.runDetector(*ur, board); move == nil {
+		t.Fatal("expected a Unique Rectangle move when uniqueness is assumed")
+	}
+
+	board.AssumeUnique = false
+	if move := s.runDetector(*ur, board); move != nil {
+		t.Errorf("Unique Rectangle fired on a non-unique board: %s", move.Technique)
+	}
+	if moves := s.movesForTechnique(*ur, board); len(moves) != 0 {
+		t.Errorf("movesForTechnique returned %d uniqueness moves on a non-unique board", len(moves))
+	}
+
+	// The flag survives cloning: hint paths solve on scratch copies
+	if move := s.runDetector(*ur, board.Clone()); move != nil {
+		t.Error("Unique Rectangle fired on a clone of a non-unique board")
+	}
+}
+
+// TestSolveSkipsUniquenessOnTwoSolutionPuzzle blanks a deadly rectangle out
+// of a solved grid — a puzzle with exactly two solutions — and solves it with
+// the full registry and AssumeUnique off. No move may come from a
+// uniqueness-based technique, and the solve cannot complete: either
+// resolution of the rectangle would be a guess.
+func TestSolveSkipsUniquenessOnTwoSolutionPuzzle(t *testing.T) {
+	full := dp.GenerateFullGrid(3)
+	givens := blankDeadlyRectangle(t, full)
+
+	board := NewBoard(givens)
+	board.AssumeUnique = false
+
+	s := NewSolver()
+	moves, status := s.SolveWithSteps(board, constants.MaxSolverSteps)
+	if status == constants.StatusCompleted {
+		t.Error("a two-solution puzzle should not solve to completion")
+	}
+	for _, move := range moves {
+		if tech := s.registry.GetBySlug(move.Refs.Slug); tech != nil && tech.RequiresUniqueness {
+			t.Errorf("non-unique board received uniqueness move %s", move.Refs.Slug)
+		}
+	}
+}
+
+// blankDeadlyRectangle removes a rectangle of four cells spanning exactly two
+// boxes whose corners hold a/b and b/a, which leaves the grid with precisely
+// two solutions (the corners can be swapped).
+func blankDeadlyRectangle(t *testing.T, full []int) []int {
+	t.Helper()
+	for r1 := 0; r1 < 9; r1++ {
+		for r2 := r1 + 1; r2 < 9; r2++ {
+			for c1 := 0; c1 < 9; c1++ {
+				for c2 := c1 + 1; c2 < 9; c2++ {
+					a, b := full[r1*9+c1], full[r1*9+c2]
+					if full[r2*9+c1] != b || full[r2*9+c2] != a {
+						continue
+					}
+					boxes := map[int]bool{}
+					for _, idx := range []int{r1*9 + c1, r1*9 + c2, r2*9 + c1, r2*9 + c2} {
+						boxes[(idx/9/3)*3+(idx%9)/3] = true
+					}
+					if len(boxes) != 2 {
+						continue
+					}
+					givens := make([]int, len(full))
+					copy(givens, full)
+					for _, idx := range []int{r1*9 + c1, r1*9 + c2, r2*9 + c1, r2*9 + c2} {
+						givens[idx] = 0
+					}
+					return givens
+				}
+			}
+		}
+	}
+	t.Fatal("grid contains no deadly rectangle; pick another seed")
+	return nil
+}
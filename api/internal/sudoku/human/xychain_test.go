@@ -0,0 +1,90 @@
+package human
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/human/techniques"
+)
+
+// TestDetectXYChain_FourCellChain verifies detection of a constructed 4-cell
+// chain: (1,2)-(2,3)-(3,4)-(4,1). Both ends leave a dangling 1, so 1 is
+// eliminated from the cell that sees both ends
+func TestDetectXYChain_FourCellChain(t *testing.T) {
+	cells := [81]int{}
+	cm := map[int][]int{
+		cellIdx(0, 0): {1, 2}, // chain start
+		cellIdx(0, 4): {2, 3},
+		cellIdx(4, 4): {3, 4},
+		cellIdx(4, 0): {4, 1}, // chain end
+		cellIdx(7, 0): {1, 5}, // sees both ends via column 1
+	}
+
+	board := makeTestBoard(cells, cm)
+	move := techniques.DetectXYChain(board)
+
+	if move == nil {
+		t.Fatal("expected XY-Chain move")
+	}
+	if move.Action != "eliminate" || move.Digit != 1 {
+		t.Errorf("expected eliminate of 1, got %s of %d", move.Action, move.Digit)
+	}
+	if len(move.Targets) != 4 {
+		t.Errorf("expected 4 chain cells, got %d", len(move.Targets))
+	}
+	if len(move.Eliminations) != 1 || move.Eliminations[0].Row != 7 || move.Eliminations[0].Col != 0 || move.Eliminations[0].Digit != 1 {
+		t.Errorf("expected elimination of 1 at R8C1, got %v", move.Eliminations)
+	}
+	// 3 links between 4 cells, stored as from/to pairs
+	if len(move.ChainLinks) != 6 {
+		t.Errorf("expected 6 chain link refs (3 pairs), got %d", len(move.ChainLinks))
+	}
+	if len(move.Highlights.Primary) != 4 {
+		t.Errorf("expected all chain cells highlighted, got %d", len(move.Highlights.Primary))
+	}
+}
+
+// TestDetectXYChain_EndDigitsDiffer verifies no move is emitted when the
+// chain's dangling end digits don't match: (1,2)-(2,3)-(3,4) dangles 1 at one
+// end and 4 at the other
+func TestDetectXYChain_EndDigitsDiffer(t *testing.T) {
+	cells := [81]int{}
+	cm := map[int][]int{
+		cellIdx(0, 0): {1, 2},
+		cellIdx(0, 4): {2, 3},
+		cellIdx(4, 4): {3, 4},
+		cellIdx(4, 0): {1, 9}, // sees both ends, but no valid chain exists
+	}
+
+	board := makeTestBoard(cells, cm)
+
+	if move := techniques.DetectXYChain(board); move != nil {
+		t.Errorf("expected no XY-Chain move, got %s of %d at %v", move.Action, move.Digit, move.Targets)
+	}
+}
+
+// TestDetectXYChain_MaxLength verifies the configurable length cap is honored
+func TestDetectXYChain_MaxLength(t *testing.T) {
+	cells := [81]int{}
+	cm := map[int][]int{
+		cellIdx(0, 0): {1, 2},
+		cellIdx(0, 4): {2, 3},
+		cellIdx(4, 4): {3, 4},
+		cellIdx(4, 0): {4, 1},
+		cellIdx(7, 0): {1, 5},
+	}
+
+	board := makeTestBoard(cells, cm)
+
+	old := techniques.XYChainMaxLength
+	defer func() { techniques.XYChainMaxLength = old }()
+
+	techniques.XYChainMaxLength = 3
+	if move := techniques.DetectXYChain(board); move != nil {
+		t.Errorf("expected no move with max length 3, got %v", move.Targets)
+	}
+
+	techniques.XYChainMaxLength = 4
+	if move := techniques.DetectXYChain(board); move == nil {
+		t.Error("expected move with max length 4")
+	}
+}
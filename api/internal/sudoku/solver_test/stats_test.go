@@ -0,0 +1,76 @@
+package solver_test
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/internal/sudoku/human"
+)
+
+func sumHits(stats *human.SolverStats) int {
+	total := 0
+	for _, n := range stats.Hits {
+		total += n
+	}
+	return total
+}
+
+// TestSolverStats_CountersMatchMoves drives a solve move by move and checks
+// that every hit recorded in the stats corresponds to exactly one returned
+// detector move. Moves from the candidate-generation phase (fill-candidate
+// and its inline hidden singles) bypass the detectors and must not count.
+func TestSolverStats_CountersMatchMoves(t *testing.T) {
+	fullGrid := dp.GenerateFullGrid(42)
+	givens := dp.CarveGivens(fullGrid, 24, 42)
+
+	solver := human.NewSolver()
+	solver.EnableStats()
+	b := human.NewBoard(givens)
+
+	detectorMoves := 0
+	for i := 0; i < 5000; i++ {
+		prevHits := sumHits(solver.Stats())
+		move := solver.FindNextMove(b)
+		if move == nil {
+			break
+		}
+		switch delta := sumHits(solver.Stats()) - prevHits; delta {
+		case 0:
+			// generation-phase move, no detector involved
+		case 1:
+			detectorMoves++
+			// Refs carry the registry slug even when the move's own technique
+			// is a variant label (e.g. sashimi-x-wing)
+			if solver.GetRegistry().GetBySlug(move.Refs.Slug) == nil {
+				t.Fatalf("detector hit recorded for non-registry move %q", move.Technique)
+			}
+		default:
+			t.Fatalf("move %q recorded %d hits, want 0 or 1", move.Technique, delta)
+		}
+		solver.ApplyMove(b, move)
+	}
+
+	stats := solver.Stats()
+	if detectorMoves == 0 {
+		t.Fatal("expected at least one detector move in the solve")
+	}
+	if got := sumHits(stats); got != detectorMoves {
+		t.Errorf("total hits = %d, want %d detector moves", got, detectorMoves)
+	}
+	for slug, hits := range stats.Hits {
+		if stats.DetectorCalls[slug] < hits {
+			t.Errorf("%s: %d hits but only %d calls", slug, hits, stats.DetectorCalls[slug])
+		}
+	}
+	if len(stats.DetectorTime) == 0 {
+		t.Error("expected detector timings to be recorded")
+	}
+}
+
+// TestSolverStats_DisabledByDefault verifies stats are opt-in
+func TestSolverStats_DisabledByDefault(t *testing.T) {
+	solver := human.NewSolver()
+	if solver.Stats() != nil {
+		t.Error("Stats should be nil before EnableStats is called")
+	}
+}
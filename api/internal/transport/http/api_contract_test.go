@@ -0,0 +1,190 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// decodeErrorEnvelope asserts the structured envelope shape
+// {"error": {"code", "message"}} and returns the envelope
+func decodeErrorEnvelope(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	envelope, ok := response["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected error envelope object, got %v", response["error"])
+	}
+	if code, ok := envelope["code"].(string); !ok || code == "" {
+		t.Errorf("envelope missing code: %v", envelope)
+	}
+	if msg, ok := envelope["message"].(string); !ok || msg == "" {
+		t.Errorf("envelope missing message: %v", envelope)
+	}
+	return envelope
+}
+
+// TestErrorEnvelope covers the shared error envelope across handler families
+func TestErrorEnvelope(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       map[string]interface{}
+		rawBody    string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "puzzle rejects unknown difficulty",
+			method:     "GET",
+			path:       "/api/puzzle/some-seed?d=nightmare",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidDifficulty,
+		},
+		{
+			name:       "session start rejects malformed JSON",
+			method:     "POST",
+			path:       "/api/session/start",
+			rawBody:    "{not json",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidRequest,
+		},
+		{
+			name:   "solve rejects a bad token",
+			method: "POST",
+			path:   "/api/solve/next",
+			body: map[string]interface{}{
+				"token": "garbage",
+				"board": make([]int, 81),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   ErrCodeInvalidToken,
+		},
+		{
+			name:   "solve rejects a short board",
+			method: "POST",
+			path:   "/api/solve/next",
+			body: map[string]interface{}{
+				"token": token,
+				"board": []int{1, 2, 3},
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidBoardSize,
+		},
+		{
+			name:       "session restore requires a token",
+			method:     "GET",
+			path:       "/api/session/restore",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeTokenRequired,
+		},
+		{
+			name:   "custom validate rejects short givens",
+			method: "POST",
+			path:   "/api/custom/validate",
+			body: map[string]interface{}{
+				"givens":    []int{1, 2, 3},
+				"device_id": "test-device",
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidBoardSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body *bytes.Buffer
+			switch {
+			case tt.rawBody != "":
+				body = bytes.NewBufferString(tt.rawBody)
+			case tt.body != nil:
+				raw, _ := json.Marshal(tt.body)
+				body = bytes.NewBuffer(raw)
+			default:
+				body = &bytes.Buffer{}
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, body)
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			envelope := decodeErrorEnvelope(t, w)
+			if envelope["code"] != tt.wantCode {
+				t.Errorf("expected code %q, got %v", tt.wantCode, envelope["code"])
+			}
+		})
+	}
+}
+
+// TestAPIV1Prefix covers the canonical /api/v1 prefix and its unprefixed
+// alias serving the same routes
+func TestAPIV1Prefix(t *testing.T) {
+	router := setupRouter()
+
+	for _, prefix := range []string{"/api/v1", "/api"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", prefix+"/version", nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s/version: expected 200, got %d", prefix, w.Code)
+		}
+	}
+}
+
+// TestCORS covers the configurable CORS middleware
+func TestCORS(t *testing.T) {
+	router := setupRouter()
+
+	t.Run("preflight succeeds for an allowed origin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("OPTIONS", "/api/v1/solve/next", nil)
+		req.Header.Set("Origin", "https://app.example.test")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", w.Code)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.test" {
+			t.Errorf("expected origin echoed, got %q", got)
+		}
+		if w.Header().Get("Access-Control-Allow-Methods") == "" {
+			t.Error("expected Access-Control-Allow-Methods header")
+		}
+	})
+
+	t.Run("simple request carries CORS headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/version", nil)
+		req.Header.Set("Origin", "https://app.example.test")
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.test" {
+			t.Errorf("expected origin echoed, got %q", got)
+		}
+	})
+
+	t.Run("disallowed origin gets no CORS headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/version", nil)
+		req.Header.Set("Origin", "https://evil.example.test")
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("expected no CORS headers, got %q", got)
+		}
+	})
+}
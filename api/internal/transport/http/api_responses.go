@@ -0,0 +1,90 @@
+package http
+
+import (
+	"sudoku-api/internal/state"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+// Concrete wire shapes for the handlers whose responses used to be assembled
+// from gin.H maps. These structs are the contract the OpenAPI generator
+// (openapi.go) reflects over, so renaming or retyping a field changes the
+// published spec in the same commit instead of drifting past it. Handlers
+// with dynamic payloads - the solve family mixes typed core.Move values with
+// the legacy map-shaped fix moves - keep their map assembly and are
+// documented in openapi.go's route table instead.
+
+// APIErrorBody is the inner object of the shared error envelope
+type APIErrorBody struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// APIErrorResponse is the envelope every error response uses:
+//
+//	{"error": {"code": "...", "message": "..."}}
+type APIErrorResponse struct {
+	Error APIErrorBody `json:"error"`
+}
+
+// VersionResponse reports the API and solver versions
+type VersionResponse struct {
+	APIVersion    string `json:"api_version"`
+	SolverVersion string `json:"solver_version"`
+}
+
+// SessionStartResponse carries the signed session token plus its expiry
+// metadata, so clients can schedule a refresh instead of discovering the
+// lapse through a 401 mid-game. Timestamps are RFC 3339.
+type SessionStartResponse struct {
+	Token            string `json:"token"`
+	PuzzleID         string `json:"puzzle_id"`
+	StartedAt        string `json:"started_at"`
+	ExpiresAt        string `json:"expires_at"`
+	RefreshableUntil string `json:"refreshable_until"`
+}
+
+// SessionRefreshResponse is SessionStartResponse for a reissued token; the
+// original start time is not restated
+type SessionRefreshResponse struct {
+	Token            string `json:"token"`
+	PuzzleID         string `json:"puzzle_id"`
+	ExpiresAt        string `json:"expires_at"`
+	RefreshableUntil string `json:"refreshable_until"`
+}
+
+// SessionSaveResponse acknowledges a server-side save
+type SessionSaveResponse struct {
+	SavedAt string `json:"saved_at"`
+}
+
+// SessionRestoreResponse returns the last state saved for a session
+type SessionRestoreResponse struct {
+	PuzzleID   string          `json:"puzzle_id"`
+	Difficulty string          `json:"difficulty"`
+	State      state.SavedGame `json:"state"`
+}
+
+// SessionCompleteResponse reports the server-computed score for a finished
+// game and the player's rank among completions of the same puzzle
+type SessionCompleteResponse struct {
+	Score     int                      `json:"score"`
+	Breakdown constants.ScoreBreakdown `json:"breakdown"`
+	Rank      int                      `json:"rank"`
+}
+
+// ValidateWinnableResponse deliberately carries nothing but the verdict; see
+// validateWinnableHandler for why the oracle stays terse
+type ValidateWinnableResponse struct {
+	Winnable bool `json:"winnable"`
+}
+
+// CandidatesCheckResponse reports the pencil-mark diff under the strictness
+// that was actually applied (the default is filled in server-side). The diff
+// fields are promoted from human.CandidateDiff, whose json tags are the wire
+// contract.
+type CandidatesCheckResponse struct {
+	Strictness string `json:"strictness"`
+	human.CandidateDiff
+}
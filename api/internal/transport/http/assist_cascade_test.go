@@ -0,0 +1,88 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+)
+
+// postAssistCascade submits a cascade request and returns the recorder
+func postAssistCascade(router http.Handler, body map[string]interface{}) *httptest.ResponseRecorder {
+	encoded, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/assist/cascade", bytes.NewBuffer(encoded))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAssistCascadeAppliesEliminationsOnly(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	// Seed 1 at 30 givens has a rich simple-tier cascade (locked candidates,
+	// pairs) before any harder technique is needed
+	board := dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1)
+
+	w := postAssistCascade(router, map[string]interface{}{
+		"token": token,
+		"board": board,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Moves []struct {
+			Action    string `json:"action"`
+			Technique string `json:"technique"`
+		} `json:"moves"`
+		Board      []int   `json:"board"`
+		Candidates [][]int `json:"candidates"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(resp.Moves) == 0 {
+		t.Fatal("expected the cascade to apply at least one elimination")
+	}
+	for i, move := range resp.Moves {
+		if move.Action != "eliminate" {
+			t.Errorf("move %d (%s): action %q, want eliminate", i, move.Technique, move.Action)
+		}
+	}
+
+	// Without allow_assignments the returned board matches the submitted one
+	if len(resp.Board) != len(board) {
+		t.Fatalf("board size %d, want %d", len(resp.Board), len(board))
+	}
+	for i := range board {
+		if resp.Board[i] != board[i] {
+			t.Errorf("cell %d changed %d -> %d", i, board[i], resp.Board[i])
+		}
+	}
+	if len(resp.Candidates) == 0 {
+		t.Error("expected the cascaded candidates in the response")
+	}
+}
+
+func TestAssistCascadeRejectsUnknownTier(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	w := postAssistCascade(router, map[string]interface{}{
+		"token":    token,
+		"board":    dp.CarveGivens(dp.GenerateFullGridFast(1), 30, 1),
+		"max_tier": "legendary",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if code := errorCode(w); code != ErrCodeInvalidTier {
+		t.Errorf("expected error code %q, got %q", ErrCodeInvalidTier, code)
+	}
+}
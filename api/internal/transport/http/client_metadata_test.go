@@ -0,0 +1,216 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sudoku-api/pkg/config"
+	"sudoku-api/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupClientRouter builds a router with client-identity settings under test
+func setupClientRouter(mutate func(*config.Config)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	cfg := &config.Config{
+		JWTSecret: "test-secret-key",
+	}
+	if mutate != nil {
+		mutate(cfg)
+	}
+	RegisterRoutes(r, cfg)
+	return r
+}
+
+// startSessionFrom posts /api/session/start with control over the transport
+// details the metadata capture reads
+func startSessionFrom(router *gin.Engine, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  "metadata-device",
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// sessionFromResponse decodes the token out of a session/start response
+func sessionFromResponse(t *testing.T, w *httptest.ResponseRecorder) *SessionToken {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	token, _ := resp["token"].(string)
+	session, err := verifyToken("test-secret-key", token)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	return session
+}
+
+func TestSessionTokenCarriesClientMetadata(t *testing.T) {
+	router := setupClientRouter(nil)
+
+	w := startSessionFrom(router, "203.0.113.7:4321", map[string]string{
+		"User-Agent":    "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/117.0",
+		"X-App-Version": "1.4.2",
+	})
+	session := sessionFromResponse(t, w)
+
+	if session.IPHash != hashClientIP("203.0.113.7") {
+		t.Errorf("Expected ip_hash of 203.0.113.7, got %q", session.IPHash)
+	}
+	if session.UAFamily != "firefox" {
+		t.Errorf("Expected ua_family 'firefox', got %q", session.UAFamily)
+	}
+	if session.AppVersion != "1.4.2" {
+		t.Errorf("Expected app_version '1.4.2', got %q", session.AppVersion)
+	}
+}
+
+func TestClientIPHonorsTrustedProxiesOnly(t *testing.T) {
+	headers := map[string]string{
+		// Forged or real proxy chain: real client, then an internal hop
+		"X-Forwarded-For": "203.0.113.9, 10.0.0.2",
+	}
+
+	// With the proxy range configured, the forwarded chain resolves to the
+	// first address outside it
+	trusted := setupClientRouter(func(c *config.Config) {
+		c.TrustedProxies = []string{"10.0.0.0/8"}
+	})
+	session := sessionFromResponse(t, startSessionFrom(trusted, "10.0.0.1:9999", headers))
+	if session.IPHash != hashClientIP("203.0.113.9") {
+		t.Errorf("Expected forwarded client 203.0.113.9 behind trusted proxy, got hash %q", session.IPHash)
+	}
+
+	// Without it, the header is ignored and the remote address is the client
+	untrusted := setupClientRouter(nil)
+	session = sessionFromResponse(t, startSessionFrom(untrusted, "10.0.0.1:9999", headers))
+	if session.IPHash != hashClientIP("10.0.0.1") {
+		t.Errorf("Expected remote address 10.0.0.1 without trusted proxies, got hash %q", session.IPHash)
+	}
+}
+
+// errorCode pulls the machine-readable code out of an error envelope
+func errorCode(w *httptest.ResponseRecorder) string {
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return resp.Error.Code
+}
+
+func TestBlockedDeviceRejected(t *testing.T) {
+	router := setupClientRouter(func(c *config.Config) {
+		c.BlockedDevices = []string{"abusive-device"}
+	})
+
+	// At session start
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  "abusive-device",
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403 at session start, got %d", w.Code)
+	}
+	if errorCode(w) != ErrCodeClientBlocked {
+		t.Errorf("Expected error code %q, got %q", ErrCodeClientBlocked, errorCode(w))
+	}
+
+	// At solve time, with a token minted before the device was blocked
+	now := time.Now()
+	token, err := createToken("test-secret-key", SessionToken{
+		DeviceID:   "abusive-device",
+		PuzzleID:   "test-seed" + constants.PuzzleIDDl + "medium",
+		Seed:       "test-seed",
+		Difficulty: "medium",
+		StartedAt:  now,
+		ExpiresAt:  now.Add(constants.SessionTokenExpiry),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	w = postSolveNext(router, token, testSessionGivens(t))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403 at solve time, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if errorCode(w) != ErrCodeClientBlocked {
+		t.Errorf("Expected error code %q, got %q", ErrCodeClientBlocked, errorCode(w))
+	}
+
+	// Other devices are unaffected
+	if token = startSession(t, router, "innocent-device"); token == "" {
+		t.Fatal("Expected an unblocked device to start a session")
+	}
+}
+
+func TestBlockedIPPrefixRejected(t *testing.T) {
+	router := setupClientRouter(func(c *config.Config) {
+		c.BlockedIPPrefixes = []string{hashClientIP("198.51.100.7")[:6]}
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/version", nil)
+	req.RemoteAddr = "198.51.100.7:1234"
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403 for blocked IP, got %d", w.Code)
+	}
+	if errorCode(w) != ErrCodeClientBlocked {
+		t.Errorf("Expected error code %q, got %q", ErrCodeClientBlocked, errorCode(w))
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/version", nil)
+	req.RemoteAddr = "198.51.100.8:1234"
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for an unblocked IP, got %d", w.Code)
+	}
+}
+
+func TestSolveAcceptsTokenWithoutClientClaims(t *testing.T) {
+	// Tokens from before the metadata claims existed must keep working
+	router := setupClientRouter(nil)
+
+	now := time.Now()
+	token, err := createToken("test-secret-key", SessionToken{
+		DeviceID:   "old-client-device",
+		PuzzleID:   "test-seed" + constants.PuzzleIDDl + "medium",
+		Seed:       "test-seed",
+		Difficulty: "medium",
+		StartedAt:  now,
+		ExpiresAt:  now.Add(constants.SessionTokenExpiry),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	w := postSolveNext(router, token, testSessionGivens(t))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for a token without client claims, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
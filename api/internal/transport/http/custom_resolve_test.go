@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/ingest"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// postCustomResolve submits OCR readings to /api/custom/resolve and decodes
+// the response
+func postCustomResolve(t *testing.T, router http.Handler, cells []ingest.CellReading) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"cells": cells})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/custom/resolve", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return response
+}
+
+func TestCustomResolve(t *testing.T) {
+	router := setupRouter()
+
+	fullGrid := dp.GenerateFullGrid(2)
+	puzzles, _ := dp.CarveGivensWithSubset(fullGrid, 2)
+	givens := puzzles[constants.DifficultyEasy]
+
+	readings := func() []ingest.CellReading {
+		cells := make([]ingest.CellReading, len(givens))
+		for i, digit := range givens {
+			cells[i] = ingest.CellReading{Digit: digit, Confidence: 0.95}
+		}
+		return cells
+	}
+
+	t.Run("ambiguous cell resolves to the alternative", func(t *testing.T) {
+		cells := readings()
+
+		// Misread one given as a digit its row already holds; the true
+		// digit survives only as the alternative
+		idx := -1
+		for i, digit := range givens {
+			if digit != 0 {
+				idx = i
+				break
+			}
+		}
+		row := idx / constants.GridSize
+		clash := 0
+		for c := 0; c < constants.GridSize; c++ {
+			if other := row*constants.GridSize + c; other != idx && givens[other] != 0 {
+				clash = givens[other]
+				break
+			}
+		}
+		cells[idx] = ingest.CellReading{
+			Digit:      clash,
+			Confidence: 0.5,
+			Alternatives: []ingest.Alternative{
+				{Digit: givens[idx], Confidence: 0.4},
+			},
+		}
+
+		response := postCustomResolve(t, router, cells)
+		if response["resolved"] != true {
+			t.Fatalf("expected resolved, got %v", response)
+		}
+		resolved := response["givens"].([]interface{})
+		if int(resolved[idx].(float64)) != givens[idx] {
+			t.Errorf("cell %d resolved to %v, want %d", idx, resolved[idx], givens[idx])
+		}
+		corrections := response["corrections"].([]interface{})
+		if len(corrections) != 1 {
+			t.Fatalf("expected 1 correction, got %v", corrections)
+		}
+	})
+
+	t.Run("unresolvable readings report problem cells", func(t *testing.T) {
+		cells := readings()
+
+		idx := -1
+		for i, digit := range givens {
+			if digit != 0 {
+				idx = i
+				break
+			}
+		}
+		row := idx / constants.GridSize
+		clash := 0
+		for c := 0; c < constants.GridSize; c++ {
+			if other := row*constants.GridSize + c; other != idx && givens[other] != 0 {
+				clash = givens[other]
+				break
+			}
+		}
+		cells[idx] = ingest.CellReading{
+			Digit:      clash,
+			Confidence: 0.5,
+			Alternatives: []ingest.Alternative{
+				{Digit: clash, Confidence: 0.4},
+			},
+		}
+
+		response := postCustomResolve(t, router, cells)
+		if response["resolved"] != false {
+			t.Fatalf("expected unresolved, got %v", response)
+		}
+		problem := response["problem_cells"].([]interface{})
+		if len(problem) != 1 || int(problem[0].(float64)) != idx {
+			t.Errorf("problem_cells = %v, want [%d]", problem, idx)
+		}
+	})
+
+	t.Run("wrong cell count is rejected", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"cells": make([]ingest.CellReading, 10),
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/custom/resolve", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,324 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// postCustomValidate submits givens to /api/custom/validate and decodes the
+// response
+func postCustomValidate(t *testing.T, router http.Handler, givens []int) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"givens":    givens,
+		"device_id": "test-device",
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/custom/validate", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return response
+}
+
+// TestCustomValidateSolutionCount covers the solution-space sampling in the
+// custom validation response
+func TestCustomValidateSolutionCount(t *testing.T) {
+	router := setupRouter()
+
+	// A full grid matching testPuzzles[0], as ints
+	solution := make([]int, 81)
+	for i, ch := range testPuzzles[0].S {
+		solution[i] = int(ch - '0')
+	}
+
+	t.Run("two-solution puzzle reports differing cells", func(t *testing.T) {
+		// Blank a deadly rectangle: cells (0,5), (0,8), (1,5) and (1,8) hold
+		// 4/8 and 8/4 across two boxes, so swapping them is the only other
+		// solution
+		givens := make([]int, 81)
+		copy(givens, solution)
+		rectangle := []int{5, 8, 14, 17}
+		for _, idx := range rectangle {
+			givens[idx] = 0
+		}
+
+		response := postCustomValidate(t, router, givens)
+		if response["unique"] != false {
+			t.Fatalf("expected unique=false, got %v", response["unique"])
+		}
+		if count, ok := response["solution_count"].(float64); !ok || count != 2 {
+			t.Fatalf("expected solution_count 2, got %v", response["solution_count"])
+		}
+		cells, ok := response["differing_cells"].([]interface{})
+		if !ok || len(cells) != len(rectangle) {
+			t.Fatalf("expected differing_cells %v, got %v", rectangle, response["differing_cells"])
+		}
+		for i, idx := range rectangle {
+			if int(cells[i].(float64)) != idx {
+				t.Fatalf("expected differing_cells %v, got %v", rectangle, cells)
+			}
+		}
+	})
+
+	t.Run("structurally broken puzzle reports capped count", func(t *testing.T) {
+		// Keep only the first two rows: enough givens to pass the minimum
+		// check, but the solution space is huge
+		givens := make([]int, 81)
+		copy(givens[:18], solution[:18])
+
+		response := postCustomValidate(t, router, givens)
+		if response["unique"] != false {
+			t.Fatalf("expected unique=false, got %v", response["unique"])
+		}
+		count, ok := response["solution_count"].(string)
+		if !ok || count != ">=1000" {
+			t.Fatalf("expected solution_count \">=1000\", got %v", response["solution_count"])
+		}
+		if _, present := response["differing_cells"]; present {
+			t.Error("capped counts should not report differing cells")
+		}
+	})
+}
+
+// TestCustomValidateDailyEquivalence covers the warning emitted when a custom
+// submission is a symmetry transform of a recent daily puzzle
+func TestCustomValidateDailyEquivalence(t *testing.T) {
+	router := setupRouter()
+
+	daily, _, _, err := puzzles.Global().GetDailyPuzzle(time.Now(), "medium")
+	if err != nil {
+		t.Fatalf("failed to resolve today's daily: %v", err)
+	}
+	wantSeed := constants.DailyPuzzlePrefix + TodayUTC()
+
+	t.Run("transposed and relabeled daily is flagged", func(t *testing.T) {
+		relabel := [10]int{0, 4, 7, 1, 9, 2, 8, 3, 6, 5}
+		disguised := make([]int, constants.TotalCells)
+		for r := 0; r < constants.GridSize; r++ {
+			for c := 0; c < constants.GridSize; c++ {
+				disguised[c*constants.GridSize+r] = relabel[daily[r*constants.GridSize+c]]
+			}
+		}
+
+		response := postCustomValidate(t, router, disguised)
+		if response["valid"] != true || response["unique"] != true {
+			t.Fatalf("expected a valid unique puzzle, got %v", response)
+		}
+		if response["equivalent_to_daily"] != wantSeed {
+			t.Errorf("expected equivalent_to_daily %q, got %v", wantSeed, response["equivalent_to_daily"])
+		}
+	})
+
+	t.Run("unrelated puzzle is not flagged", func(t *testing.T) {
+		full := dp.GenerateFullGrid(11)
+		response := postCustomValidate(t, router, dp.CarveGivens(full, 30, 11))
+		if response["valid"] != true {
+			t.Fatalf("expected a valid puzzle, got %v", response)
+		}
+		if _, present := response["equivalent_to_daily"]; present {
+			t.Errorf("unexpected equivalent_to_daily: %v", response["equivalent_to_daily"])
+		}
+	})
+}
+
+// TestCustomValidateMinimal covers the minimal:true option, which reports
+// whether every given is necessary for uniqueness
+func TestCustomValidateMinimal(t *testing.T) {
+	router := setupRouter()
+
+	postMinimal := func(t *testing.T, givens []int) map[string]interface{} {
+		t.Helper()
+		body, _ := json.Marshal(map[string]interface{}{
+			"givens":    givens,
+			"device_id": "test-device",
+			"minimal":   true,
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/custom/validate", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		return response
+	}
+
+	full := dp.GenerateFullGrid(5)
+	minimized := dp.MinimizeGivens(dp.CarveGivens(full, 30, 5), 5)
+
+	t.Run("minimal puzzle reports minimal", func(t *testing.T) {
+		response := postMinimal(t, minimized)
+		if response["unique"] != true {
+			t.Fatalf("expected unique=true, got %v", response["unique"])
+		}
+		if response["minimal"] != true {
+			t.Errorf("expected minimal=true, got %v", response["minimal"])
+		}
+		if redundant, ok := response["redundant_givens"].([]interface{}); ok && len(redundant) != 0 {
+			t.Errorf("expected no redundant givens, got %v", redundant)
+		}
+	})
+
+	t.Run("extra given is flagged redundant", func(t *testing.T) {
+		extra := -1
+		for i, v := range minimized {
+			if v == 0 {
+				extra = i
+				break
+			}
+		}
+		padded := make([]int, len(minimized))
+		copy(padded, minimized)
+		padded[extra] = full[extra]
+
+		response := postMinimal(t, padded)
+		if response["minimal"] != false {
+			t.Errorf("expected minimal=false, got %v", response["minimal"])
+		}
+		redundant, ok := response["redundant_givens"].([]interface{})
+		if !ok || len(redundant) == 0 {
+			t.Fatalf("expected redundant givens, got %v", response["redundant_givens"])
+		}
+		found := false
+		for _, idx := range redundant {
+			if int(idx.(float64)) == extra {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected given %d in redundant list, got %v", extra, redundant)
+		}
+	})
+
+	t.Run("omitted flag leaves response unchanged", func(t *testing.T) {
+		response := postCustomValidate(t, router, minimized)
+		if _, present := response["minimal"]; present {
+			t.Error("minimal field should only appear when requested")
+		}
+	})
+}
+
+// TestCustomValidateSessionTokens covers the session tokens issued alongside
+// validation: unique puzzles get a token with the solution sealed in, and
+// multi-solution puzzles get one marked non-unique so the solve endpoints
+// keep uniqueness-based techniques off for them.
+func TestCustomValidateSessionTokens(t *testing.T) {
+	router := setupRouter()
+
+	solution := make([]int, 81)
+	for i, ch := range testPuzzles[0].S {
+		solution[i] = int(ch - '0')
+	}
+
+	t.Run("unique puzzle token seals the solution", func(t *testing.T) {
+		givens := make([]int, 81)
+		copy(givens, solution)
+		givens[5] = 0 // removing one cell keeps the puzzle unique
+
+		response := postCustomValidate(t, router, givens)
+		if response["unique"] != true {
+			t.Fatalf("expected unique=true, got %v", response["unique"])
+		}
+		tokenStr, ok := response["token"].(string)
+		if !ok || tokenStr == "" {
+			t.Fatalf("expected a session token, got %v", response["token"])
+		}
+		session, err := verifyToken("test-secret-key", tokenStr)
+		if err != nil {
+			t.Fatalf("issued token does not verify: %v", err)
+		}
+		if session.NonUnique {
+			t.Error("unique puzzle token should not be marked non-unique")
+		}
+		if session.Difficulty != constants.DifficultyCustom {
+			t.Errorf("expected difficulty %q, got %q", constants.DifficultyCustom, session.Difficulty)
+		}
+		sealed := openSolution("test-secret-key", session.PuzzleID, session.Solution)
+		if len(sealed) != constants.TotalCells {
+			t.Fatalf("expected a sealed solution, got %v", sealed)
+		}
+		for i := range solution {
+			if sealed[i] != solution[i] {
+				t.Fatalf("sealed solution differs at cell %d: %d != %d", i, sealed[i], solution[i])
+			}
+		}
+	})
+
+	t.Run("multi-solution puzzle token is marked non-unique", func(t *testing.T) {
+		// Blank the deadly rectangle from TestCustomValidateSolutionCount:
+		// the puzzle has exactly two solutions
+		givens := make([]int, 81)
+		copy(givens, solution)
+		for _, idx := range []int{5, 8, 14, 17} {
+			givens[idx] = 0
+		}
+
+		response := postCustomValidate(t, router, givens)
+		if response["unique"] != false {
+			t.Fatalf("expected unique=false, got %v", response["unique"])
+		}
+		tokenStr, ok := response["token"].(string)
+		if !ok || tokenStr == "" {
+			t.Fatalf("expected a session token, got %v", response["token"])
+		}
+		session, err := verifyToken("test-secret-key", tokenStr)
+		if err != nil {
+			t.Fatalf("issued token does not verify: %v", err)
+		}
+		if !session.NonUnique {
+			t.Error("multi-solution puzzle token should be marked non-unique")
+		}
+		if session.Solution != "" {
+			t.Error("multi-solution puzzle token should not carry a solution")
+		}
+
+		// The solve endpoint accepts the token but must not suggest a move:
+		// the four open cells form a deadly rectangle, and either resolution
+		// would be a guess once uniqueness techniques are off. Candidates are
+		// supplied so the solver doesn't spend the request filling them in.
+		candidates := make([][]int, constants.TotalCells)
+		for _, idx := range []int{5, 8, 14, 17} {
+			candidates[idx] = []int{solution[5], solution[8]}
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"token":      tokenStr,
+			"board":      givens,
+			"givens":     givens,
+			"candidates": candidates,
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var solveResp map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &solveResp); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		if mv, present := solveResp["move"]; !present || mv != nil {
+			t.Errorf("expected a nil move on the deadly rectangle, got %v", mv)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/pkg/constants"
+)
+
+// dailyEquivalenceWindowDays is how far back custom-puzzle validation looks
+// for a daily puzzle the submission is a transformation of
+const dailyEquivalenceWindowDays = 7
+
+// dailyCanonicalCache memoizes canonical forms of daily puzzles, keyed by
+// bank index and difficulty. Computing a form runs the full symmetry search
+// (tens of milliseconds), and the same bank entries come up on every request
+// within the window.
+var dailyCanonicalCache = struct {
+	sync.Mutex
+	forms map[string]string
+}{forms: make(map[string]string)}
+
+// equivalentRecentDaily returns the seed of a recent daily puzzle the given
+// grid is a symmetry transform of (relabeled digits, transposed, or with
+// permuted bands, stacks, rows, or columns), or "" when it matches none. Every
+// difficulty tier of each daily in the window is checked, since a submitter
+// may have copied the daily at any tier.
+func equivalentRecentDaily(givens []int) string {
+	loader := puzzles.Global()
+	if loader == nil {
+		return ""
+	}
+
+	form, _ := core.CanonicalForm(givens)
+	now := time.Now().UTC()
+	for day := 0; day < dailyEquivalenceWindowDays; day++ {
+		date := now.AddDate(0, 0, -day)
+		for _, difficulty := range constants.DifficultyNames() {
+			dailyGivens, _, puzzleIndex, err := loader.GetDailyPuzzle(date, difficulty)
+			if err != nil {
+				continue
+			}
+
+			key := fmt.Sprintf("%d%s%s", puzzleIndex, constants.PuzzleIDDl, difficulty)
+			dailyCanonicalCache.Lock()
+			dailyForm, cached := dailyCanonicalCache.forms[key]
+			dailyCanonicalCache.Unlock()
+			if !cached {
+				dailyForm, _ = core.CanonicalForm(dailyGivens)
+				dailyCanonicalCache.Lock()
+				dailyCanonicalCache.forms[key] = dailyForm
+				dailyCanonicalCache.Unlock()
+			}
+
+			if dailyForm == form {
+				return constants.DailyPuzzlePrefix + date.Format(constants.DailyDateFormat)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDiabolicalNotServedOnDemand checks the documented contract for
+// bank-only tiers: without a pre-generated bank entry backing the seed, a
+// diabolical request is a 404 rather than a silently weaker on-demand carve.
+func TestDiabolicalNotServedOnDemand(t *testing.T) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/some-seed?d=diabolical", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+	if code := errorCode(w); code != ErrCodeNoPuzzleFound {
+		t.Errorf("Expected error code %q, got %q", ErrCodeNoPuzzleFound, code)
+	}
+}
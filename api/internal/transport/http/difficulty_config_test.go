@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+// installBeginnerTier adds a config-style sixth difficulty for the duration of
+// a test, the same way config.Load installs EXTRA_DIFFICULTIES at startup
+func installBeginnerTier(t *testing.T) {
+	t.Helper()
+	specs := append(constants.DefaultDifficultySpecs(), constants.DifficultySpec{
+		Name: "beginner", Key: "b", TargetGivens: 45, MaxTier: constants.TierSimple,
+	})
+	if err := constants.SetDifficultySpecs(specs); err != nil {
+		t.Fatalf("SetDifficultySpecs: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := constants.SetDifficultySpecs(constants.DefaultDifficultySpecs()); err != nil {
+			t.Fatalf("failed to restore default specs: %v", err)
+		}
+	})
+}
+
+// TestConfigDefinedDifficulty verifies that a tier added through config shows
+// up in puzzle validation and carving without any handler changes
+func TestConfigDefinedDifficulty(t *testing.T) {
+	router := setupRouter()
+
+	t.Run("unknown difficulty rejected before install", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/puzzle/some-seed?d=beginner", nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+	})
+
+	installBeginnerTier(t)
+
+	t.Run("config tier is served via carving", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/puzzle/some-seed?d=beginner", nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		givens, ok := response["givens"].([]interface{})
+		if !ok || len(givens) != 81 {
+			t.Fatalf("expected 81-cell givens array, got %v", response["givens"])
+		}
+		count := 0
+		for _, v := range givens {
+			if v.(float64) != 0 {
+				count++
+			}
+		}
+		if count != 45 {
+			t.Errorf("beginner puzzle has %d givens, want 45", count)
+		}
+		// The loader has no beginner tier, so this must come from generation
+		if idx, ok := response["puzzle_index"].(float64); !ok || idx != -1 {
+			t.Errorf("expected generated puzzle (index -1), got %v", response["puzzle_index"])
+		}
+	})
+
+	t.Run("config tier accepted by session start", func(t *testing.T) {
+		body := `{"seed":"some-seed","difficulty":"beginner","device_id":"test-device-123"}`
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/session/start", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("rejection message lists the config tier", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/puzzle/some-seed?d=nightmare", nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		envelope, _ := response["error"].(map[string]interface{})
+		errMsg, _ := envelope["message"].(string)
+		if !strings.Contains(errMsg, "beginner") {
+			t.Errorf("error message should list the config tier: %s", errMsg)
+		}
+	})
+}
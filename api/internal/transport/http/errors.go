@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Machine-readable error codes shared by every handler. Clients branch on
+// these instead of parsing messages.
+const (
+	ErrCodeInvalidRequest           = "invalid_request"
+	ErrCodeInvalidDifficulty        = "invalid_difficulty"
+	ErrCodeInvalidStrategy          = "invalid_strategy"
+	ErrCodeInvalidAnalysis          = "invalid_analysis"
+	ErrCodeInvalidTier              = "invalid_tier"
+	ErrCodeInvalidBoardSize         = "invalid_board_size"
+	ErrCodeInvalidCellValue         = "invalid_cell_value"
+	ErrCodeInvalidCell              = "invalid_cell"
+	ErrCodeInvalidCandidates        = "invalid_candidates"
+	ErrCodeInvalidAnnotations       = "invalid_annotations"
+	ErrCodeInvalidCount             = "invalid_count"
+	ErrCodeInvalidFormat            = "invalid_format"
+	ErrCodeInvalidSeed              = "invalid_seed"
+	ErrCodeInvalidTransform         = "invalid_transform"
+	ErrCodeInvalidCursor            = "invalid_cursor"
+	ErrCodeInvalidMove              = "invalid_move"
+	ErrCodeInvalidMoveLog           = "invalid_move_log"
+	ErrCodeInvalidToken             = "invalid_token"
+	ErrCodeTokenExpired             = "token_expired"
+	ErrCodeTokenRequired            = "token_required"
+	ErrCodeGivensModified           = "givens_modified"
+	ErrCodeHistoryTooLarge          = "history_too_large"
+	ErrCodeNoSavedState             = "no_saved_state"
+	ErrCodeBoardNotSolved           = "board_not_solved"
+	ErrCodeAlreadyCompleted         = "already_completed"
+	ErrCodeNoSolution               = "no_solution"
+	ErrCodeUnsolvablePuzzle         = "unsolvable_puzzle"
+	ErrCodeNoPuzzleFound            = "no_puzzle_found"
+	ErrCodeInsufficientData         = "insufficient_data"
+	ErrCodeTutorialNotFound         = "tutorial_not_found"
+	ErrCodeTechniqueRequired        = "technique_required"
+	ErrCodeTechniqueDataUnavailable = "technique_data_unavailable"
+	ErrCodePuzzlesNotLoaded         = "puzzles_not_loaded"
+	ErrCodeTimeBudgetExceeded       = "time_budget_exceeded"
+	ErrCodeBodyTooLarge             = "body_too_large"
+	ErrCodeRateLimited              = "rate_limited"
+	ErrCodeClientBlocked            = "client_blocked"
+	ErrCodeInternal                 = "internal_error"
+)
+
+// apiTokenError maps a verifyToken failure to its error code: an expired
+// token gets token_expired so clients know to call /session/refresh instead
+// of restarting the game, everything else is invalid_token
+func apiTokenError(c *gin.Context, err error) {
+	if errors.Is(err, errTokenExpired) {
+		apiError(c, http.StatusUnauthorized, ErrCodeTokenExpired, "token expired: refresh it via /session/refresh")
+		return
+	}
+	apiError(c, http.StatusUnauthorized, ErrCodeInvalidToken, "invalid token: "+err.Error())
+}
+
+// apiError writes the structured error envelope every handler shares:
+//
+//	{"error": {"code": "...", "message": "..."}}
+func apiError(c *gin.Context, status int, code, message string) {
+	apiErrorDetails(c, status, code, message, nil)
+}
+
+// apiErrorDetails is apiError with a details object for errors that carry
+// extra machine-readable context
+func apiErrorDetails(c *gin.Context, status int, code, message string, details gin.H) {
+	c.JSON(status, APIErrorResponse{Error: APIErrorBody{
+		Code:    code,
+		Message: message,
+		Details: map[string]interface{}(details),
+	}})
+}
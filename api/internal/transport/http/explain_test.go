@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+func TestExplainEndpoint(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	if token == "" {
+		t.Fatal("failed to get session token")
+	}
+
+	post := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/explain", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("placed peer explanation", func(t *testing.T) {
+		board := make([]int, constants.TotalCells)
+		board[0] = 5
+		w := post(map[string]interface{}{
+			"token": token,
+			"board": board,
+			"row":   0,
+			"col":   4,
+			"digit": 5,
+		})
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		if response["kind"] != "placed-peer" {
+			t.Errorf("kind = %v, want placed-peer", response["kind"])
+		}
+		if response["peer_cell"].(float64) != 0 {
+			t.Errorf("peer_cell = %v, want 0", response["peer_cell"])
+		}
+	})
+
+	t.Run("invalid token rejected", func(t *testing.T) {
+		board := make([]int, constants.TotalCells)
+		w := post(map[string]interface{}{
+			"token": "not-a-token",
+			"board": board,
+			"row":   0,
+			"col":   0,
+			"digit": 5,
+		})
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("filled cell rejected", func(t *testing.T) {
+		board := make([]int, constants.TotalCells)
+		board[0] = 5
+		w := post(map[string]interface{}{
+			"token": token,
+			"board": board,
+			"row":   0,
+			"col":   0,
+			"digit": 5,
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
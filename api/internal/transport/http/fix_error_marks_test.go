@@ -0,0 +1,119 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
+)
+
+// TestSolveAllFixErrorPreservesUserMarks drives the fix-error reset in
+// solveAll with a wrong user entry and checks the rebuilt board keeps the
+// user's penciled subset instead of refilling every cell with computed
+// candidates — the "candidates appeared when deleting digits" regression.
+func TestSolveAllFixErrorPreservesUserMarks(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// Enter a placeable-but-wrong digit in the first empty cell that has one
+	reference := human.NewBoardWithConstraints(givens, core.ConstraintSet{}).GetCandidates()
+	wrongCell, wrongDigit := -1, 0
+	for i, v := range givens {
+		if v != 0 {
+			continue
+		}
+		for _, d := range reference[i] {
+			if d != solution[i] {
+				wrongCell, wrongDigit = i, d
+				break
+			}
+		}
+		if wrongCell >= 0 {
+			break
+		}
+	}
+	if wrongCell < 0 {
+		t.Fatal("No cell available for a wrong entry")
+	}
+	board := make([]int, len(givens))
+	copy(board, givens)
+	board[wrongCell] = wrongDigit
+
+	// The candidate grid a frontend would display for that board, with one
+	// cell narrowed by hand to a proper subset
+	candidates := human.NewBoardWithConstraints(board, core.ConstraintSet{}).GetCandidates()
+	penciled := -1
+	var subset []int
+	for i, v := range board {
+		if v != 0 || i == wrongCell || len(candidates[i]) < 3 {
+			continue
+		}
+		for _, d := range candidates[i] {
+			if d != solution[i] {
+				subset = nil
+				for _, keep := range candidates[i] {
+					if keep != d {
+						subset = append(subset, keep)
+					}
+				}
+				break
+			}
+		}
+		if subset != nil {
+			candidates[i] = subset
+			penciled = i
+			break
+		}
+	}
+	if penciled < 0 {
+		t.Fatal("No cell available for a penciled subset")
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":      token,
+		"board":      board,
+		"candidates": candidates,
+		"givens":     givens,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/all", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Moves []struct {
+			Candidates [][]int                `json:"candidates"`
+			Move       map[string]interface{} `json:"move"`
+		} `json:"moves"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	var fixCandidates [][]int
+	for _, mr := range resp.Moves {
+		if technique, _ := mr.Move["technique"].(string); technique == "fix-error" {
+			fixCandidates = mr.Candidates
+			break
+		}
+	}
+	if fixCandidates == nil {
+		t.Fatal("Expected a fix-error move for the wrong entry")
+	}
+	if !reflect.DeepEqual(fixCandidates[penciled], subset) {
+		t.Errorf("Penciled cell %d holds %v after the fix-error reset, want the user's subset %v",
+			penciled, fixCandidates[penciled], subset)
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Liveness and readiness probes. /healthz (and its /health alias) only
+// proves the process answers HTTP; /readyz verifies the components a pod
+// needs before it should receive traffic: a usable puzzle bank, a solver
+// that still returns moves, and a sane config.
+
+// healthHandler answers liveness probes: the process is up and serving
+func healthHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"version": constants.APIVersion,
+	})
+}
+
+// readyCanaryBudget bounds the canary solve; a healthy solver answers a
+// near-complete board in microseconds, so blowing this budget means the
+// solver is wedged, not slow
+const readyCanaryBudget = 5 * time.Millisecond
+
+// canaryGivens is a full valid grid with one cell blanked: the cheapest
+// board that still drives FindNextMove through its real code path
+var canaryGivens = func() []int {
+	cells := make([]int, constants.TotalCells)
+	for i, ch := range "057924638362158974498736512531279486926483157784615293273561849619847325845392761" {
+		cells[i] = int(ch - '0')
+	}
+	return cells
+}()
+
+// canarySolve runs the readiness canary. It is a package variable so tests
+// can force failure modes without wedging a real solver.
+var canarySolve = runCanarySolve
+
+func runCanarySolve() error {
+	board := human.NewBoard(canaryGivens)
+	board.InitCandidates()
+	solver := human.NewSolver()
+
+	done := make(chan *core.Move, 1)
+	go func() { done <- solver.FindNextMove(board) }()
+
+	select {
+	case move := <-done:
+		if move == nil {
+			return errors.New("canary solve returned no move")
+		}
+		return nil
+	case <-time.After(readyCanaryBudget):
+		return fmt.Errorf("canary solve exceeded the %s budget", readyCanaryBudget)
+	}
+}
+
+// readyzHandler answers readiness probes with per-component statuses and a
+// 503 when any critical component fails. A missing or empty puzzle bank is
+// "degraded" - the server still works through on-demand generation, which
+// is slow - and counts as not ready unless READY_WHEN_DEGRADED opts in.
+func readyzHandler(c *gin.Context) {
+	components := gin.H{}
+	ready := true
+	degraded := false
+
+	if loader := puzzles.Global(); loader != nil && loader.Count() > 0 {
+		components["puzzles"] = gin.H{"status": "ok", "loaded": loader.Count()}
+	} else {
+		components["puzzles"] = gin.H{
+			"status": "degraded",
+			"detail": "no puzzle bank loaded; serving on-demand generation only",
+		}
+		degraded = true
+		if cfg == nil || !cfg.ReadyWhenDegraded {
+			ready = false
+		}
+	}
+
+	if err := canarySolve(); err != nil {
+		components["solver"] = gin.H{"status": "error", "detail": err.Error()}
+		ready = false
+	} else {
+		components["solver"] = gin.H{"status": "ok"}
+	}
+
+	if cfg == nil || cfg.JWTSecret == "" {
+		components["config"] = gin.H{"status": "error", "detail": "no JWT secret configured"}
+		ready = false
+	} else {
+		components["config"] = gin.H{"status": "ok"}
+	}
+
+	status := "ok"
+	code := http.StatusOK
+	switch {
+	case !ready:
+		status = "unavailable"
+		code = http.StatusServiceUnavailable
+	case degraded:
+		status = "degraded"
+	}
+
+	c.JSON(code, gin.H{
+		"status":     status,
+		"version":    constants.APIVersion,
+		"components": components,
+	})
+}
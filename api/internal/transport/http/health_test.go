@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getReadyz(t *testing.T, router http.Handler) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return w.Code, response
+}
+
+func componentStatus(t *testing.T, response map[string]interface{}, name string) string {
+	t.Helper()
+	components, ok := response["components"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected components in response, got: %v", response)
+	}
+	component, ok := components[name].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected component %q, got: %v", name, components)
+	}
+	status, _ := component["status"].(string)
+	return status
+}
+
+func TestReadyzHealthy(t *testing.T) {
+	router := setupRouter()
+
+	code, response := getReadyz(t, router)
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %v", code, response)
+	}
+	if response["status"] != "ok" {
+		t.Errorf("Expected overall status ok, got %v", response["status"])
+	}
+	for _, name := range []string{"puzzles", "solver", "config"} {
+		if got := componentStatus(t, response, name); got != "ok" {
+			t.Errorf("Expected component %s ok, got %s", name, got)
+		}
+	}
+}
+
+func TestReadyzEmptyLoader(t *testing.T) {
+	original := puzzles.Global()
+	puzzles.SetGlobal(puzzles.NewLoaderFromPuzzles(nil))
+	defer puzzles.SetGlobal(original)
+
+	router := setupRouter()
+	code, response := getReadyz(t, router)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503 with an empty loader, got %d: %v", code, response)
+	}
+	if response["status"] != "unavailable" {
+		t.Errorf("Expected overall status unavailable, got %v", response["status"])
+	}
+	if got := componentStatus(t, response, "puzzles"); got != "degraded" {
+		t.Errorf("Expected puzzles degraded, got %s", got)
+	}
+	// The solver itself is still fine
+	if got := componentStatus(t, response, "solver"); got != "ok" {
+		t.Errorf("Expected solver ok, got %s", got)
+	}
+
+	// With the opt-in knob, degraded generation-only mode still counts as ready
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, &config.Config{
+		JWTSecret:         "test-secret-key",
+		ReadyWhenDegraded: true,
+	})
+	code, response = getReadyz(t, r)
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200 with READY_WHEN_DEGRADED, got %d: %v", code, response)
+	}
+	if response["status"] != "degraded" {
+		t.Errorf("Expected overall status degraded, got %v", response["status"])
+	}
+}
+
+func TestReadyzCanaryFailure(t *testing.T) {
+	original := canarySolve
+	canarySolve = func() error { return errors.New("canary solve exceeded the 5ms budget") }
+	defer func() { canarySolve = original }()
+
+	router := setupRouter()
+	code, response := getReadyz(t, router)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503 with a wedged solver, got %d: %v", code, response)
+	}
+	if got := componentStatus(t, response, "solver"); got != "error" {
+		t.Errorf("Expected solver error, got %s", got)
+	}
+}
+
+func TestHealthzAlias(t *testing.T) {
+	router := setupRouter()
+	for _, path := range []string{"/health", "/healthz"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s: expected 200, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudoku-api/pkg/config"
+)
+
+// captureRequestLog swaps the base logger for a JSON sink and returns the
+// buffer entries land in plus the restore function
+func captureRequestLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	saved := baseLogger
+	buf := &bytes.Buffer{}
+	baseLogger = slog.New(slog.NewJSONHandler(buf, nil))
+	t.Cleanup(func() { baseLogger = saved })
+	return buf
+}
+
+// TestSolveNextRequestLogging pins the one-entry-per-request contract: a
+// solve/next call produces exactly one structured entry, and that entry
+// carries the session seed, the returned technique, and the request ID echoed
+// to the client.
+func TestSolveNextRequestLogging(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	givens := testSessionGivens(t)
+
+	buf := captureRequestLog(t)
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"token": token,
+		"board": givens,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	move, ok := resp["move"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a move in the response, got %v", resp["move"])
+	}
+	technique, _ := move["technique"].(string)
+	if technique == "" {
+		t.Fatal("Expected the move to name its technique")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 || lines[0] == "" {
+		t.Fatalf("Expected exactly one log entry, got %d:\n%s", len(lines), buf.String())
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Log entry is not valid JSON: %v\n%s", err, lines[0])
+	}
+	if entry["seed"] != "test-seed" {
+		t.Errorf("Expected seed 'test-seed' in the log entry, got %v", entry["seed"])
+	}
+	if entry["technique"] != technique {
+		t.Errorf("Expected technique %q in the log entry, got %v", technique, entry["technique"])
+	}
+	if entry["status"] != float64(http.StatusOK) {
+		t.Errorf("Expected status 200 in the log entry, got %v", entry["status"])
+	}
+	requestID, _ := entry["request_id"].(string)
+	if requestID == "" {
+		t.Error("Expected a request_id in the log entry")
+	}
+	if header := w.Header().Get("X-Request-ID"); header != requestID {
+		t.Errorf("X-Request-ID header %q does not match logged request_id %q", header, requestID)
+	}
+}
+
+// TestRequestLogErrorStatus verifies a failing request still yields one entry
+// with its HTTP status, so error-rate queries can run off the request log
+func TestRequestLogErrorStatus(t *testing.T) {
+	router := setupRouter()
+	buf := captureRequestLog(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 || lines[0] == "" {
+		t.Fatalf("Expected exactly one log entry, got %d:\n%s", len(lines), buf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Log entry is not valid JSON: %v\n%s", err, lines[0])
+	}
+	if entry["status"] != float64(http.StatusBadRequest) {
+		t.Errorf("Expected status 400 in the log entry, got %v", entry["status"])
+	}
+}
+
+// TestNewLoggerLevel checks LOG_LEVEL parsing, including the fallback for
+// unknown values
+func TestNewLoggerLevel(t *testing.T) {
+	ctx := context.Background()
+
+	logger := newLogger(&config.Config{LogLevel: "error", LogFormat: "json"})
+	if logger.Enabled(ctx, slog.LevelWarn) {
+		t.Error("Expected level 'error' to suppress warn entries")
+	}
+	if !logger.Enabled(ctx, slog.LevelError) {
+		t.Error("Expected level 'error' to pass error entries")
+	}
+
+	logger = newLogger(&config.Config{LogLevel: "nonsense"})
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		t.Error("Expected an unknown level to fall back to info")
+	}
+	if !logger.Enabled(ctx, slog.LevelInfo) {
+		t.Error("Expected the fallback level to pass info entries")
+	}
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+)
+
+// TestSolveMaxTierParam covers the ?maxTier= cap on the solve endpoints
+func TestSolveMaxTierParam(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("failed to resolve test puzzle: %v", err)
+	}
+
+	postSolveNext := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, _ := json.Marshal(map[string]interface{}{
+			"token":  token,
+			"board":  givens,
+			"givens": givens,
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/solve/next"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("capped solve succeeds", func(t *testing.T) {
+		w := postSolveNext(t, "?maxTier=simple")
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("unknown tier is rejected", func(t *testing.T) {
+		w := postSolveNext(t, "?maxTier=legendary")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ============================================================================
+// Metrics
+// ============================================================================
+//
+// A minimal hand-rolled Prometheus text-format exporter. The API's only
+// dependency is gin, so rather than pulling in the prometheus client we track
+// a handful of counters, one latency histogram, and one gauge behind a mutex
+// and render the scrape output ourselves. Enabled via METRICS_ENABLED; when
+// disabled the package-level registry stays nil and every record call is a
+// nil-receiver no-op.
+
+// solveNextBuckets are the histogram upper bounds (seconds) for
+// /api/solve/next latency. Solve requests are usually a few milliseconds but
+// can reach the solver time budget, so the buckets span ms to seconds.
+var solveNextBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics holds the API's instrumentation counters. All methods are safe for
+// concurrent use and safe to call on a nil receiver, so handlers can record
+// unconditionally whether or not metrics are enabled.
+type Metrics struct {
+	mu sync.Mutex
+
+	puzzlesServed  map[string]uint64 // difficulty -> count
+	solverOutcomes map[string]uint64 // outcome (completed/stalled/contradiction) -> count
+	fixErrorMoves  uint64
+	loadedPuzzles  int
+
+	// solve/next latency histogram: cumulative-style bucket counts are
+	// computed at render time, observations are bucketed on record
+	solveNextBucketCounts []uint64
+	solveNextSum          float64
+	solveNextCount        uint64
+}
+
+// metricsRegistry is the process-wide registry, set by RegisterRoutes when
+// METRICS_ENABLED is on. Nil means metrics are disabled.
+var metricsRegistry *Metrics
+
+// NewMetrics creates an empty metrics registry
+func NewMetrics() *Metrics {
+	return &Metrics{
+		puzzlesServed:         make(map[string]uint64),
+		solverOutcomes:        make(map[string]uint64),
+		solveNextBucketCounts: make([]uint64, len(solveNextBuckets)),
+	}
+}
+
+// IncPuzzleServed counts one puzzle response for the given difficulty
+func (m *Metrics) IncPuzzleServed(difficulty string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.puzzlesServed[difficulty]++
+	m.mu.Unlock()
+}
+
+// IncSolverOutcome counts one terminal solver outcome
+// (completed, stalled, or contradiction)
+func (m *Metrics) IncSolverOutcome(outcome string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.solverOutcomes[outcome]++
+	m.mu.Unlock()
+}
+
+// IncFixErrorMove counts one fix-error move emitted by a solve handler
+func (m *Metrics) IncFixErrorMove() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.fixErrorMoves++
+	m.mu.Unlock()
+}
+
+// ObserveSolveNextDuration records one /api/solve/next request duration in
+// the latency histogram
+func (m *Metrics) ObserveSolveNextDuration(d time.Duration) {
+	if m == nil {
+		return
+	}
+	secs := d.Seconds()
+	m.mu.Lock()
+	for i, le := range solveNextBuckets {
+		if secs <= le {
+			m.solveNextBucketCounts[i]++
+			break
+		}
+	}
+	m.solveNextSum += secs
+	m.solveNextCount++
+	m.mu.Unlock()
+}
+
+// SetLoadedPuzzles records how many pre-generated puzzles are loaded
+func (m *Metrics) SetLoadedPuzzles(n int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.loadedPuzzles = n
+	m.mu.Unlock()
+}
+
+// Render writes the registry in the Prometheus text exposition format.
+// Label values are emitted in sorted order so scrapes are deterministic.
+func (m *Metrics) Render() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP sudoku_puzzles_served_total Puzzles served by difficulty.\n")
+	b.WriteString("# TYPE sudoku_puzzles_served_total counter\n")
+	for _, difficulty := range sortedKeys(m.puzzlesServed) {
+		fmt.Fprintf(&b, "sudoku_puzzles_served_total{difficulty=%q} %d\n", difficulty, m.puzzlesServed[difficulty])
+	}
+
+	b.WriteString("# HELP sudoku_solver_outcomes_total Terminal solver outcomes from the solve handlers.\n")
+	b.WriteString("# TYPE sudoku_solver_outcomes_total counter\n")
+	for _, outcome := range sortedKeys(m.solverOutcomes) {
+		fmt.Fprintf(&b, "sudoku_solver_outcomes_total{outcome=%q} %d\n", outcome, m.solverOutcomes[outcome])
+	}
+
+	b.WriteString("# HELP sudoku_fix_error_moves_total Fix-error moves emitted by the solve handlers.\n")
+	b.WriteString("# TYPE sudoku_fix_error_moves_total counter\n")
+	fmt.Fprintf(&b, "sudoku_fix_error_moves_total %d\n", m.fixErrorMoves)
+
+	b.WriteString("# HELP sudoku_solve_next_duration_seconds Latency of /api/solve/next requests.\n")
+	b.WriteString("# TYPE sudoku_solve_next_duration_seconds histogram\n")
+	cumulative := uint64(0)
+	for i, le := range solveNextBuckets {
+		cumulative += m.solveNextBucketCounts[i]
+		fmt.Fprintf(&b, "sudoku_solve_next_duration_seconds_bucket{le=%q} %d\n", formatBucketBound(le), cumulative)
+	}
+	fmt.Fprintf(&b, "sudoku_solve_next_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.solveNextCount)
+	fmt.Fprintf(&b, "sudoku_solve_next_duration_seconds_sum %g\n", m.solveNextSum)
+	fmt.Fprintf(&b, "sudoku_solve_next_duration_seconds_count %d\n", m.solveNextCount)
+
+	b.WriteString("# HELP sudoku_loaded_puzzles Number of pre-generated puzzles loaded.\n")
+	b.WriteString("# TYPE sudoku_loaded_puzzles gauge\n")
+	fmt.Fprintf(&b, "sudoku_loaded_puzzles %d\n", m.loadedPuzzles)
+
+	return b.String()
+}
+
+// sortedKeys returns the map's keys in sorted order for stable output
+func sortedKeys(m map[string]uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatBucketBound renders a histogram bound the way Prometheus expects
+// (shortest decimal representation, e.g. "0.005", "1", "10")
+func formatBucketBound(le float64) string {
+	return strconv.FormatFloat(le, 'g', -1, 64)
+}
+
+// metricsHandler serves the Prometheus scrape endpoint
+func metricsHandler(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.String(http.StatusOK, metricsRegistry.Render())
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudoku-api/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupMetricsRouter builds a router with METRICS_ENABLED behavior on
+func setupMetricsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	cfg := &config.Config{
+		JWTSecret:      "test-secret-key",
+		MetricsEnabled: true,
+	}
+	RegisterRoutes(r, cfg)
+	return r
+}
+
+func TestMetricsDisabledByDefault(t *testing.T) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when metrics are disabled, got %d", w.Code)
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	router := setupMetricsRouter()
+	token := getValidToken(router)
+	if token == "" {
+		t.Fatal("Failed to get valid token")
+	}
+
+	// Serve a couple of puzzles at different difficulties
+	for _, path := range []string{"/api/puzzle/metrics-seed?d=easy", "/api/puzzle/metrics-seed?d=medium"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s returned %d", path, w.Code)
+		}
+	}
+
+	// One solve/next request so the latency histogram has an observation
+	givens := make([]int, 81)
+	board := make([]int, 81)
+	puzzle := testPuzzles[0]
+	for _, idx := range puzzle.G["m"] {
+		givens[idx] = int(puzzle.S[idx] - '0')
+		board[idx] = givens[idx]
+	}
+	body := map[string]interface{}{
+		"token":  token,
+		"board":  board,
+		"givens": givens,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("solve/next returned %d: %s", w.Code, w.Body.String())
+	}
+
+	// Scrape and check the expected metric names and values
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/metrics", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /metrics returned %d", w.Code)
+	}
+
+	scrape := w.Body.String()
+
+	for _, want := range []string{
+		`sudoku_puzzles_served_total{difficulty="easy"} 1`,
+		`sudoku_puzzles_served_total{difficulty="medium"} 1`,
+		"sudoku_solve_next_duration_seconds_count 1",
+		"sudoku_fix_error_moves_total 0",
+	} {
+		if !strings.Contains(scrape, want) {
+			t.Errorf("Expected scrape to contain %q, got:\n%s", want, scrape)
+		}
+	}
+
+	// Two test puzzles are loaded by the package-level init
+	if !strings.Contains(scrape, "sudoku_loaded_puzzles 2") {
+		t.Errorf("Expected sudoku_loaded_puzzles 2 in scrape, got:\n%s", scrape)
+	}
+
+	// Histogram sum should be present (value is timing-dependent)
+	if !strings.Contains(scrape, "sudoku_solve_next_duration_seconds_sum") {
+		t.Errorf("Expected latency histogram sum in scrape, got:\n%s", scrape)
+	}
+}
+
+func TestMetricsSolverOutcomes(t *testing.T) {
+	router := setupMetricsRouter()
+	token := getValidToken(router)
+	if token == "" {
+		t.Fatal("Failed to get valid token")
+	}
+
+	// A full solve from the givens should record a completed outcome
+	givens := make([]int, 81)
+	puzzle := testPuzzles[0]
+	for _, idx := range puzzle.G["e"] {
+		givens[idx] = int(puzzle.S[idx] - '0')
+	}
+	board := make([]int, 81)
+	copy(board, givens)
+
+	body := map[string]interface{}{
+		"token":  token,
+		"board":  board,
+		"givens": givens,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/all", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("solve/all returned %d: %s", w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if solved, _ := response["solved"].(bool); !solved {
+		t.Fatal("Expected the easy puzzle to be solved")
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/metrics", nil)
+	router.ServeHTTP(w, req)
+
+	scrape := w.Body.String()
+	if !strings.Contains(scrape, `sudoku_solver_outcomes_total{outcome="completed"} 1`) {
+		t.Errorf("Expected completed outcome counter in scrape, got:\n%s", scrape)
+	}
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ============================================================================
+// Client Identity and Blocklist
+// ============================================================================
+//
+// The rate limiter keys on device_id, which clients can rotate freely. For
+// abuse investigations, session tokens additionally carry client metadata
+// captured at session start: a hashed IP, the user-agent family, and the
+// X-App-Version header. The blocklist below rejects known-bad clients by
+// device_id or IP-hash prefix, driven entirely by config - no database.
+
+// hashClientIP reduces a client IP to a short stable hash. Truncating to
+// eight bytes keeps token claims and log entries compact while leaving
+// enough hash for prefix matching in BLOCKED_IP_PREFIXES; raw addresses
+// never enter tokens or logs.
+func hashClientIP(ip string) string {
+	sum := sha256.Sum256([]byte(ip))
+	return hex.EncodeToString(sum[:8])
+}
+
+// uaFamily reduces a User-Agent header to a coarse product family. Order
+// matters: Chrome-based browsers also advertise Safari, and Edge and Opera
+// also advertise Chrome.
+func uaFamily(ua string) string {
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "Edg/"):
+		return "edge"
+	case strings.Contains(ua, "OPR/"):
+		return "opera"
+	case strings.Contains(ua, "Chrome/"):
+		return "chrome"
+	case strings.Contains(ua, "Firefox/"):
+		return "firefox"
+	case strings.Contains(ua, "Safari/"):
+		return "safari"
+	}
+	// Non-browser clients: keep the first product token
+	if i := strings.IndexAny(ua, "/ "); i > 0 {
+		return strings.ToLower(ua[:i])
+	}
+	return strings.ToLower(ua)
+}
+
+// deviceBlocked reports whether the device_id is on the configured blocklist
+func deviceBlocked(deviceID string) bool {
+	if deviceID == "" {
+		return false
+	}
+	for _, d := range cfg.BlockedDevices {
+		if d == deviceID {
+			return true
+		}
+	}
+	return false
+}
+
+// ipHashBlocked reports whether the hashed client IP matches a configured
+// blocklist prefix
+func ipHashBlocked(ipHash string) bool {
+	for _, p := range cfg.BlockedIPPrefixes {
+		if p != "" && strings.HasPrefix(ipHash, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// abortBlocked rejects a blocklisted client with the shared error envelope
+func abortBlocked(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": gin.H{
+			"code":    ErrCodeClientBlocked,
+			"message": "client is blocked",
+		},
+	})
+}
+
+// blocklistMiddleware rejects requests whose hashed client IP matches a
+// configured prefix. Device-level blocks are enforced where a device_id is
+// actually present: at session start and in the solve guards' token probe.
+func blocklistMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ipHashBlocked(hashClientIP(c.ClientIP())) {
+			abortBlocked(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+// ============================================================================
+// Solve Endpoint Guards
+// ============================================================================
+//
+// The solve endpoints run up to thousands of solver iterations per request,
+// so they get extra protection: a request body size cap and a per-client
+// token-bucket rate limiter keyed by the session's device_id (falling back
+// to the client IP when no valid token is present).
+
+// Clock abstracts time for the rate limiter so tests can advance it manually
+// instead of sleeping
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the production Clock backed by time.Now
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// tokenBucket tracks remaining tokens for one client
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter is a token-bucket limiter keyed by client identity. A full
+// bucket holds one minute's quota, refilled continuously.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens added per second
+	burst   float64 // bucket capacity
+	clock   Clock
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiter creates a limiter allowing ratePerMinute requests per key.
+// Pass a nil clock to use the system clock.
+func NewRateLimiter(ratePerMinute int, clock Clock) *RateLimiter {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &RateLimiter{
+		rate:    float64(ratePerMinute) / 60.0,
+		burst:   float64(ratePerMinute),
+		clock:   clock,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow reports whether a request from key may proceed, consuming a token
+// when it does
+func (rl *RateLimiter) Allow(key string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.clock.Now()
+	bucket := rl.buckets[key]
+	if bucket == nil {
+		bucket = &tokenBucket{tokens: rl.burst, last: now}
+		rl.buckets[key] = bucket
+	}
+
+	bucket.tokens += now.Sub(bucket.last).Seconds() * rl.rate
+	if bucket.tokens > rl.burst {
+		bucket.tokens = rl.burst
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// solveGuards returns middleware that enforces the request body size cap and
+// the per-client rate limit before a solve handler runs. A nil limiter
+// disables rate limiting (body limits still apply).
+func solveGuards(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// GET requests on guarded routes carry no body to cap or probe
+		var body []byte
+		if c.Request.Body != nil {
+			if cfg.MaxRequestBytes > 0 {
+				c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, cfg.MaxRequestBytes)
+			}
+
+			// Read the body up front: MaxBytesReader surfaces oversized bodies
+			// here, and we need the token claim for the rate limit key anyway
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"error": gin.H{
+						"code":    ErrCodeBodyTooLarge,
+						"message": fmt.Sprintf("request body exceeds %d bytes", cfg.MaxRequestBytes),
+					},
+				})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		// Decode the token claim once: it keys the rate limit and carries
+		// the device_id the blocklist checks
+		var session *SessionToken
+		var probe struct {
+			Token string `json:"token"`
+		}
+		if json.Unmarshal(body, &probe) == nil && probe.Token != "" {
+			session, _ = verifyToken(cfg.JWTSecret, probe.Token)
+		}
+
+		if session != nil && deviceBlocked(session.DeviceID) {
+			abortBlocked(c)
+			return
+		}
+
+		if limiter != nil {
+			// Key by the device_id claim when the request carries a valid
+			// session token, otherwise by client IP
+			key := c.ClientIP()
+			if session != nil && session.DeviceID != "" {
+				key = session.DeviceID
+			}
+
+			if !limiter.Allow(key) {
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+					"error": gin.H{
+						"code":    ErrCodeRateLimited,
+						"message": "rate limit exceeded, retry later",
+					},
+				})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// ============================================================================
+// CORS
+// ============================================================================
+
+// corsMiddleware reflects the request origin when it is on the configured
+// allow list (or the list contains "*"), and answers OPTIONS preflights with
+// 204. Requests from other origins pass through without CORS headers, so
+// browsers block them while same-origin callers are unaffected.
+func corsMiddleware(allowedOrigins []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && originAllowed(allowedOrigins, origin) {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+			c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			c.Header("Access-Control-Max-Age", "86400")
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// originAllowed reports whether the origin is on the allow list
+func originAllowed(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		if a == "*" || a == origin {
+			return true
+		}
+	}
+	return false
+}
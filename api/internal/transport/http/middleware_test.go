@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sudoku-api/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeClock is a manually advanced Clock for limiter tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+func (f *fakeClock) advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	limiter := NewRateLimiter(60, clock)
+
+	for i := 0; i < 60; i++ {
+		if !limiter.Allow("device-a") {
+			t.Fatalf("request %d should be allowed within the burst", i+1)
+		}
+	}
+	if limiter.Allow("device-a") {
+		t.Error("request beyond the burst should be rejected")
+	}
+	if !limiter.Allow("device-b") {
+		t.Error("a different key should have its own bucket")
+	}
+
+	// 60/min refills one token per second
+	clock.advance(time.Second)
+	if !limiter.Allow("device-a") {
+		t.Error("one token should be available after refill")
+	}
+	if limiter.Allow("device-a") {
+		t.Error("only one token should have refilled")
+	}
+}
+
+// setupGuardedRouter builds a router with tight solve guards for testing
+func setupGuardedRouter(ratePerMinute int, maxBytes int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	cfg := &config.Config{
+		JWTSecret:          "test-secret-key",
+		SolveRatePerMinute: ratePerMinute,
+		MaxRequestBytes:    maxBytes,
+	}
+	RegisterRoutes(r, cfg)
+	return r
+}
+
+func startSession(t *testing.T, router *gin.Engine, deviceID string) string {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  deviceID,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	token, _ := response["token"].(string)
+	if token == "" {
+		t.Fatalf("failed to start session for %s: %s", deviceID, w.Body.String())
+	}
+	return token
+}
+
+func postSolveNext(router *gin.Engine, token string, board []int) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{
+		"token": token,
+		"board": board,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSolveRateLimit_PerDevice(t *testing.T) {
+	const limit = 5
+	router := setupGuardedRouter(limit, 0)
+
+	tokenA := startSession(t, router, "rate-device-a")
+	tokenB := startSession(t, router, "rate-device-b")
+
+	board := make([]int, 81)
+
+	allowed, throttled := 0, 0
+	for i := 0; i < 20; i++ {
+		w := postSolveNext(router, tokenA, board)
+		switch w.Code {
+		case http.StatusOK:
+			allowed++
+		case http.StatusTooManyRequests:
+			throttled++
+		default:
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	if allowed != limit {
+		t.Errorf("expected %d allowed requests, got %d", limit, allowed)
+	}
+	if throttled != 20-limit {
+		t.Errorf("expected %d throttled requests, got %d", 20-limit, throttled)
+	}
+
+	// A second device has its own bucket and is unaffected
+	if w := postSolveNext(router, tokenB, board); w.Code != http.StatusOK {
+		t.Errorf("second device should not be throttled, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSolveBodyTooLarge(t *testing.T) {
+	router := setupGuardedRouter(0, 512)
+
+	padding := strings.Repeat("x", 10_000)
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":   "irrelevant",
+		"board":   make([]int, 81),
+		"padding": padding,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d: %s", w.Code, w.Body.String())
+	}
+}
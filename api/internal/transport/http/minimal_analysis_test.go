@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPuzzleMinimalAnalysis(t *testing.T) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/test-seed?d=medium&analysis=minimal", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	set, ok := response["minimal_techniques"].([]interface{})
+	if !ok || len(set) == 0 {
+		t.Fatalf("expected non-empty minimal_techniques, got %v", response["minimal_techniques"])
+	}
+	for _, entry := range set {
+		member, ok := entry.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected object entries, got %v", entry)
+		}
+		if _, ok := member["slug"].(string); !ok {
+			t.Errorf("entry missing slug: %v", member)
+		}
+		if _, ok := member["essential"].(bool); !ok {
+			t.Errorf("entry missing essential flag: %v", member)
+		}
+	}
+}
+
+func TestPuzzleMinimalAnalysis_OmittedByDefault(t *testing.T) {
+	router := setupRouter()
+	response := getPuzzleResponse(t, router, "test-seed", "medium")
+	if _, present := response["minimal_techniques"]; present {
+		t.Errorf("expected no minimal_techniques without ?analysis=minimal, got %v", response["minimal_techniques"])
+	}
+}
+
+func TestPuzzleMinimalAnalysis_UnknownValue(t *testing.T) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/test-seed?d=medium&analysis=bogus", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if code := responseErrorCode(t, w); code != ErrCodeInvalidAnalysis {
+		t.Errorf("expected error code %q, got %q", ErrCodeInvalidAnalysis, code)
+	}
+}
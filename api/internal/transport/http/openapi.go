@@ -0,0 +1,334 @@
+package http
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"sudoku-api/pkg/constants"
+)
+
+//go:generate go run sudoku-api/cmd/openapi -out ../../../openapi.json
+
+// A minimal OpenAPI 3.0 generator, reflected from the router's actual route
+// table and the request/response structs the handlers bind to. No third-party
+// framework: the schemas our types need are plain Go kinds plus time.Time and
+// json.RawMessage, and keeping the generator in-tree means the spec can never
+// describe routes that don't exist. Routes whose payloads are still assembled
+// dynamically (the solve family mixes typed moves with legacy map moves) are
+// documented with a generic object schema until they grow typed responses.
+//
+// Component schemas are keyed by bare struct name, which is unambiguous for
+// the types we expose; revisit if two packages ever contribute same-named
+// wire structs.
+
+// routeDoc annotates one route for the generator. request and response hold
+// zero values of the structs the handler binds and writes; nil means
+// "undocumented shape", which renders as a generic object.
+type routeDoc struct {
+	summary  string
+	request  interface{}
+	response interface{}
+}
+
+// routeDocs is keyed by method plus the route constant the router registers
+// with (the path without its /api or /api/v1 prefix). A route missing from
+// this table still appears in the spec - coverage comes from gin's route
+// table, not from here.
+var routeDocs = map[string]routeDoc{
+	"GET " + constants.RouteHealth:  {summary: "Liveness probe (legacy alias of /healthz)"},
+	"GET " + constants.RouteHealthz: {summary: "Liveness probe"},
+	"GET " + constants.RouteReadyz:  {summary: "Readiness probe"},
+	"GET " + constants.RouteMetrics: {summary: "Prometheus metrics"},
+
+	"GET " + constants.RouteVersion:       {summary: "API and solver versions", response: VersionResponse{}},
+	"GET " + constants.RouteDaily:         {summary: "Today's daily puzzle"},
+	"GET " + constants.RoutePuzzleID:      {summary: "Deterministic puzzle for a seed"},
+	"GET " + constants.RouteAnalyze:       {summary: "Difficulty analysis for a seed's puzzle"},
+	"GET " + constants.RoutePuzzleDiff:    {summary: "Technique-level diff between difficulty tiers"},
+	"GET " + constants.RouteThumbnail:     {summary: "SVG thumbnail of the puzzle"},
+	"GET " + constants.RoutePuzzleStats:   {summary: "Play statistics for a puzzle"},
+	"GET " + constants.RoutePractice:      {summary: "Practice puzzle featuring a technique"},
+	"GET " + constants.RouteTechniques:    {summary: "The solver's technique catalog"},
+	"GET " + constants.RoutePuzzlePack:    {summary: "Batch of puzzles for offline play"},
+	"GET " + constants.RoutePuzzlesSearch: {summary: "Search the puzzle bank by technique profile"},
+	"GET " + constants.RoutePuzzleByIndex: {summary: "Puzzle bank entry by index"},
+	"GET " + constants.RouteTutorial:      {summary: "Interactive tutorial definition"},
+	"POST " + constants.RouteTutorialStep: {summary: "Advance a tutorial step", request: TutorialStepRequest{}},
+
+	"POST " + constants.RouteSessionStart:    {summary: "Start a play session", request: SessionStartRequest{}, response: SessionStartResponse{}},
+	"POST " + constants.RouteSessionRefresh:  {summary: "Reissue a session token with a fresh expiry", request: SessionRefreshRequest{}, response: SessionRefreshResponse{}},
+	"POST " + constants.RouteSessionSave:     {summary: "Save game state server-side", request: SessionSaveRequest{}, response: SessionSaveResponse{}},
+	"GET " + constants.RouteSessionRestore:   {summary: "Restore the last saved game state", response: SessionRestoreResponse{}},
+	"POST " + constants.RouteSessionComplete: {summary: "Score a finished game", request: SessionCompleteRequest{}, response: SessionCompleteResponse{}},
+
+	"POST " + constants.RouteSolveNext:     {summary: "Next hint move for the submitted board", request: SolveNextRequest{}},
+	"POST " + constants.RouteSolveAll:      {summary: "Solve the board step by step", request: SolveAllRequest{}},
+	"POST " + constants.RouteSolveCell:     {summary: "Hint for one specific cell", request: SolveCellRequest{}},
+	"POST " + constants.RouteSolveFull:     {summary: "Final solved board", request: SolveFullRequest{}},
+	"GET " + constants.RouteSolveStream:    {summary: "Stream solve moves over SSE"},
+	"POST " + constants.RouteCandidates:    {summary: "Diff pencil marks against the true candidates", request: CandidatesCheckRequest{}, response: CandidatesCheckResponse{}},
+	"POST " + constants.RouteAssistCascade: {summary: "Apply a placement and cascade forced singles", request: AssistCascadeRequest{}},
+	"POST " + constants.RouteExplain:       {summary: "Localized explanation for a move", request: ExplainRequest{}},
+
+	"POST " + constants.RouteValidate:         {summary: "Validate the board against the solution", request: ValidateBoardRequest{}},
+	"POST " + constants.RouteValidateWinnable: {summary: "Yes/no: can the board still be completed", request: ValidateWinnableRequest{}, response: ValidateWinnableResponse{}},
+	"POST " + constants.RouteCustomValidate:   {summary: "Validate a custom (user-entered) puzzle", request: CustomValidateRequest{}},
+	"POST " + constants.RouteCustomResolve:    {summary: "Resolve a custom puzzle to a playable session", request: CustomResolveRequest{}},
+	"POST " + constants.RouteSolveReview:      {summary: "Review a finished solve's move log", request: SolveReviewRequest{}},
+
+	"GET " + constants.RouteWeekly:        {summary: "This week's puzzle rotation"},
+	"POST " + constants.RouteWeeklySubmit: {summary: "Submit a weekly completion time", request: WeeklySubmitRequest{}},
+	"GET " + constants.RouteWeeklyBoard:   {summary: "Weekly leaderboard"},
+
+	"GET " + constants.RouteOpenAPI: {summary: "This document"},
+}
+
+// openapiDoc is built once at the end of RegisterRoutes, from the routes that
+// were actually registered
+var openapiDoc map[string]interface{}
+
+// OpenAPIDocument returns the spec for the most recently registered router;
+// cmd/openapi uses this to emit openapi.json via go generate
+func OpenAPIDocument() map[string]interface{} {
+	return openapiDoc
+}
+
+func openapiHandler(c *gin.Context) {
+	c.JSON(200, openapiDoc)
+}
+
+// buildOpenAPIDocument reflects the route table into an OpenAPI 3.0 document
+func buildOpenAPIDocument(routes []gin.RouteInfo) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	paths := map[string]interface{}{}
+
+	// Deterministic iteration so the generated file is diff-stable
+	sorted := append([]gin.RouteInfo(nil), routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		doc := routeDocs[route.Method+" "+apiRouteSuffix(route.Path)]
+		item, ok := paths[oasPath(route.Path)].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[oasPath(route.Path)] = item
+		}
+		item[strings.ToLower(route.Method)] = operationObject(route, doc, schemas)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Sudoku API",
+			"version":     constants.APIVersion,
+			"description": "Deterministic sudoku puzzles, sessions, and a step-by-step human-technique solver.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+// apiRouteSuffix strips the API group prefix so aliased /api and /api/v1
+// registrations share one routeDocs entry
+func apiRouteSuffix(path string) string {
+	if rest := strings.TrimPrefix(path, constants.RouteAPIV1); rest != path {
+		return rest
+	}
+	if rest := strings.TrimPrefix(path, constants.RouteAPI); rest != path {
+		return rest
+	}
+	return path
+}
+
+// oasPath converts gin's :param segments to OpenAPI's {param} form
+func oasPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func operationObject(route gin.RouteInfo, doc routeDoc, schemas map[string]interface{}) map[string]interface{} {
+	contentType := responseContentType(route.Path)
+	schema := responseSchema(doc, schemas)
+	if contentType != "application/json" {
+		schema = map[string]interface{}{"type": "string"}
+	}
+	op := map[string]interface{}{
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Success",
+				"content": map[string]interface{}{
+					contentType: map[string]interface{}{
+						"schema": schema,
+					},
+				},
+			},
+			"default": map[string]interface{}{
+				"description": "Error envelope shared by every handler",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": schemaForType(reflect.TypeOf(APIErrorResponse{}), schemas),
+					},
+				},
+			},
+		},
+	}
+	if doc.summary != "" {
+		op["summary"] = doc.summary
+	}
+
+	var params []interface{}
+	for _, seg := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(seg, ":") {
+			params = append(params, map[string]interface{}{
+				"name":     seg[1:],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+
+	if doc.request != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schemaForType(reflect.TypeOf(doc.request), schemas),
+				},
+			},
+		}
+	}
+	return op
+}
+
+// responseContentType is application/json for everything except the routes
+// that serve other media outright
+func responseContentType(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".svg"):
+		return "image/svg+xml"
+	case strings.HasSuffix(path, constants.RouteMetrics), strings.HasSuffix(path, constants.RouteSolveStream):
+		return "text/plain"
+	default:
+		return "application/json"
+	}
+}
+
+func responseSchema(doc routeDoc, schemas map[string]interface{}) map[string]interface{} {
+	if doc.response == nil {
+		return map[string]interface{}{"type": "object"}
+	}
+	return schemaForType(reflect.TypeOf(doc.response), schemas)
+}
+
+// schemaForType maps a Go type to an OpenAPI schema, registering named struct
+// types under components/schemas and returning a $ref to them
+func schemaForType(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(json.RawMessage{}):
+		// Opaque client-owned JSON (move history entries)
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaForType(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		if _, ok := schemas[t.Name()]; !ok {
+			// Placeholder first so self-referential types terminate
+			schemas[t.Name()] = map[string]interface{}{"type": "object"}
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		// Interfaces and anything else reflect can't pin down: any value
+		return map[string]interface{}{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			tag := field.Tag.Get("json")
+			if field.Anonymous && tag == "" {
+				// Embedded struct: fields are promoted flat, mirror that
+				embedded := field.Type
+				for embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					collect(embedded)
+					continue
+				}
+			}
+			name := strings.Split(tag, ",")[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = schemaForType(field.Type, schemas)
+			if strings.Contains(field.Tag.Get("binding"), "required") {
+				required = append(required, name)
+			}
+		}
+	}
+	collect(t)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if required != nil {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudoku-api/pkg/constants"
+)
+
+func openapiDocForTest(t *testing.T) map[string]interface{} {
+	t.Helper()
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/openapi.json", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON spec: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPIServed(t *testing.T) {
+	doc := openapiDocForTest(t)
+	if v, _ := doc["openapi"].(string); v != "3.0.3" {
+		t.Errorf("expected openapi 3.0.3, got %v", doc["openapi"])
+	}
+	info, _ := doc["info"].(map[string]interface{})
+	if info == nil {
+		t.Fatal("spec has no info object")
+	}
+	if version, _ := info["version"].(string); version != constants.APIVersion {
+		t.Errorf("expected info.version %s, got %v", constants.APIVersion, info["version"])
+	}
+}
+
+// TestOpenAPICoversAllRoutes is the no-drift invariant: every route the
+// router actually registers must appear in the generated spec, under the
+// matching method.
+func TestOpenAPICoversAllRoutes(t *testing.T) {
+	router := setupRouter()
+	doc := openapiDocForTest(t)
+	paths, _ := doc["paths"].(map[string]interface{})
+	if paths == nil {
+		t.Fatal("spec has no paths object")
+	}
+
+	for _, route := range router.Routes() {
+		oas := route.Path
+		for _, seg := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(seg, ":") {
+				oas = strings.Replace(oas, seg, "{"+seg[1:]+"}", 1)
+			}
+		}
+		item, ok := paths[oas].(map[string]interface{})
+		if !ok {
+			t.Errorf("route %s missing from spec paths", oas)
+			continue
+		}
+		if _, ok := item[strings.ToLower(route.Method)]; !ok {
+			t.Errorf("route %s missing %s operation", oas, route.Method)
+		}
+	}
+}
+
+// TestOpenAPIStructuralRules checks the OpenAPI 3.0 structure we rely on:
+// every operation declares responses with a success and a default error
+// shape, every path parameter is declared, and every $ref resolves to a
+// component schema.
+func TestOpenAPIStructuralRules(t *testing.T) {
+	doc := openapiDocForTest(t)
+	paths := doc["paths"].(map[string]interface{})
+	components, _ := doc["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	if len(schemas) == 0 {
+		t.Fatal("spec has no component schemas")
+	}
+
+	for path, rawItem := range paths {
+		if !strings.HasPrefix(path, "/") {
+			t.Errorf("path %q does not start with /", path)
+		}
+		item := rawItem.(map[string]interface{})
+		for method, rawOp := range item {
+			op, ok := rawOp.(map[string]interface{})
+			if !ok {
+				t.Errorf("%s %s: operation is not an object", method, path)
+				continue
+			}
+			responses, _ := op["responses"].(map[string]interface{})
+			if responses["200"] == nil || responses["default"] == nil {
+				t.Errorf("%s %s: responses must include 200 and default, got %v", method, path, responses)
+			}
+
+			// Every {param} in the path needs a declared path parameter
+			declared := map[string]bool{}
+			if params, ok := op["parameters"].([]interface{}); ok {
+				for _, rawParam := range params {
+					param := rawParam.(map[string]interface{})
+					if param["in"] == "path" {
+						declared[param["name"].(string)] = true
+						if required, _ := param["required"].(bool); !required {
+							t.Errorf("%s %s: path parameter %v must be required", method, path, param["name"])
+						}
+					}
+				}
+			}
+			for _, seg := range strings.Split(path, "/") {
+				if strings.HasPrefix(seg, "{") && !declared[strings.Trim(seg, "{}")] {
+					t.Errorf("%s %s: path parameter %s not declared", method, path, seg)
+				}
+			}
+		}
+	}
+
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := v["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/components/schemas/")
+				if name == ref || schemas[name] == nil {
+					t.Errorf("unresolvable $ref %q", ref)
+				}
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+}
+
+// TestResponseStructWireShapes pins the JSON key sets of the typed responses
+// against the shapes the handler tests (and clients) expect, so a struct
+// refactor cannot silently rename a wire field.
+func TestResponseStructWireShapes(t *testing.T) {
+	cases := []struct {
+		name string
+		body interface{}
+		keys []string
+	}{
+		{"SessionStartResponse", SessionStartResponse{},
+			[]string{"token", "puzzle_id", "started_at", "expires_at", "refreshable_until"}},
+		{"SessionCompleteResponse", SessionCompleteResponse{},
+			[]string{"score", "breakdown", "rank"}},
+		{"CandidatesCheckResponse", CandidatesCheckResponse{},
+			[]string{"strictness", "wrong_marks", "missing_marks", "cells_with_issues"}},
+		{"APIErrorResponse", APIErrorResponse{},
+			[]string{"error"}},
+	}
+	for _, tc := range cases {
+		data, err := json.Marshal(tc.body)
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", tc.name, err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: unmarshal: %v", tc.name, err)
+		}
+		if len(decoded) != len(tc.keys) {
+			t.Errorf("%s: expected %d keys, got %v", tc.name, len(tc.keys), decoded)
+		}
+		for _, key := range tc.keys {
+			if _, ok := decoded[key]; !ok {
+				t.Errorf("%s: missing wire key %q", tc.name, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+)
+
+func getPack(t *testing.T, router http.Handler, url string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", url, nil)
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse pack response: %v", err)
+		}
+	}
+	return w, response
+}
+
+func TestPuzzlePack_Deterministic(t *testing.T) {
+	router := setupRouter()
+
+	url := "/api/puzzles/pack?count=2&difficulties=easy,medium&seed=fixed-pack-seed"
+	w1, _ := getPack(t, router, url)
+	w2, _ := getPack(t, router, url)
+
+	if w1.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w1.Code, w1.Body.String())
+	}
+	if w1.Body.String() != w2.Body.String() {
+		t.Error("same seed and parameters should return an identical pack")
+	}
+	if cc := w1.Header().Get("Cache-Control"); cc == "" {
+		t.Error("expected Cache-Control header on pack response")
+	}
+	if etag := w1.Header().Get("ETag"); etag == "" {
+		t.Error("expected ETag header on pack response")
+	}
+}
+
+func TestPuzzlePack_DifficultyFiltering(t *testing.T) {
+	router := setupRouter()
+
+	w, response := getPack(t, router, "/api/puzzles/pack?count=1&difficulties=easy,medium&seed=s")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	rawPuzzles, ok := response["puzzles"].([]interface{})
+	if !ok || len(rawPuzzles) != 1 {
+		t.Fatalf("expected 1 puzzle, got %v", response["puzzles"])
+	}
+	givens, ok := rawPuzzles[0].(map[string]interface{})["g"].(map[string]interface{})
+	if !ok {
+		t.Fatal("puzzle missing givens map")
+	}
+	if _, hasEasy := givens["e"]; !hasEasy {
+		t.Error("expected easy givens in filtered pack")
+	}
+	if _, hasHard := givens["h"]; hasHard {
+		t.Error("hard givens should be filtered out")
+	}
+
+	if checksum, ok := response["checksum"].(string); !ok || len(checksum) != 64 {
+		t.Errorf("expected 64-char SHA-256 checksum, got %v", response["checksum"])
+	}
+}
+
+func TestPuzzlePack_BadRequests(t *testing.T) {
+	router := setupRouter()
+
+	cases := []struct {
+		name string
+		url  string
+	}{
+		{"bad difficulty", "/api/puzzles/pack?difficulties=easy,bogus"},
+		{"non-numeric count", "/api/puzzles/pack?count=abc"},
+		{"zero count", "/api/puzzles/pack?count=0"},
+		{"unsupported format", "/api/puzzles/pack?format=binary"},
+	}
+
+	for _, tc := range cases {
+		w, _ := getPack(t, router, tc.url)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("[%s] expected 400, got %d: %s", tc.name, w.Code, w.Body.String())
+		}
+	}
+}
+
+// TestPuzzlePack_LoaderRoundTrip verifies a downloaded pack can be registered
+// in a loader (the code path the WASM loadPuzzlePack export uses) and serves
+// identical givens to the source puzzles
+func TestPuzzlePack_LoaderRoundTrip(t *testing.T) {
+	router := setupRouter()
+
+	w, _ := getPack(t, router, "/api/puzzles/pack?count=2&seed=round-trip")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var file puzzles.PuzzleFile
+	if err := json.Unmarshal(w.Body.Bytes(), &file); err != nil {
+		t.Fatalf("pack response should parse as a puzzle file: %v", err)
+	}
+	if len(file.Puzzles) != 2 {
+		t.Fatalf("expected 2 puzzles in pack, got %d", len(file.Puzzles))
+	}
+
+	packLoader := puzzles.NewLoaderFromPuzzles(file.Puzzles)
+	for i, puzzle := range file.Puzzles {
+		givens, solution, err := packLoader.GetPuzzle(i, "medium")
+		if err != nil {
+			t.Fatalf("puzzle %d: %v", i, err)
+		}
+		for _, idx := range puzzle.G["m"] {
+			if givens[idx] == 0 || givens[idx] != solution[idx] {
+				t.Errorf("puzzle %d: given at %d does not round-trip", i, idx)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
+)
+
+// getPuzzleDiff requests the difficulty diff for a seed and decodes the
+// response
+func getPuzzleDiff(t *testing.T, router http.Handler, seed, from, to string) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/"+seed+"/diff?from="+from+"&to="+to, nil)
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return w.Code, response
+}
+
+// TestPuzzleDiffAddedGivens checks that the diff endpoint reports exactly the
+// givens the easier carve grants on top of the harder one, and that the
+// technique deltas agree with separate analysis calls.
+func TestPuzzleDiffAddedGivens(t *testing.T) {
+	router := setupRouter()
+
+	hard, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "hard")
+	if err != nil {
+		t.Fatalf("failed to resolve hard puzzle: %v", err)
+	}
+	easy, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "easy")
+	if err != nil {
+		t.Fatalf("failed to resolve easy puzzle: %v", err)
+	}
+
+	code, response := getPuzzleDiff(t, router, "test-seed", "hard", "easy")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, response)
+	}
+
+	// Added givens must be exactly the set difference of given indices
+	expected := map[int]int{}
+	for i := range easy {
+		if easy[i] != 0 && hard[i] == 0 {
+			expected[i] = easy[i]
+		}
+	}
+	added, ok := response["addedGivens"].([]interface{})
+	if !ok || len(added) != len(expected) {
+		t.Fatalf("expected %d added givens, got %v", len(expected), response["addedGivens"])
+	}
+	for _, entry := range added {
+		given := entry.(map[string]interface{})
+		idx := int(given["index"].(float64))
+		digit, present := expected[idx]
+		if !present {
+			t.Errorf("cell %d reported as added but is not in the set difference", idx)
+		} else if int(given["digit"].(float64)) != digit {
+			t.Errorf("cell %d reported digit %v, want %d", idx, given["digit"], digit)
+		}
+	}
+
+	// Technique deltas agree with analyzing the two puzzles separately
+	solver := human.NewSolver()
+	_, hardCounts, _ := solver.AnalyzePuzzleDifficulty(hard)
+	_, easyCounts, _ := solver.AnalyzePuzzleDifficulty(easy)
+
+	removed, _ := response["removedTechniques"].([]interface{})
+	for _, slug := range removed {
+		if hardCounts[slug.(string)] == 0 || easyCounts[slug.(string)] > 0 {
+			t.Errorf("technique %v reported removed but counts are hard=%d easy=%d",
+				slug, hardCounts[slug.(string)], easyCounts[slug.(string)])
+		}
+	}
+	addedTechs, _ := response["addedTechniques"].([]interface{})
+	for _, slug := range addedTechs {
+		if easyCounts[slug.(string)] == 0 || hardCounts[slug.(string)] > 0 {
+			t.Errorf("technique %v reported added but counts are hard=%d easy=%d",
+				slug, hardCounts[slug.(string)], easyCounts[slug.(string)])
+		}
+	}
+	for slug, n := range hardCounts {
+		if n > 0 && easyCounts[slug] == 0 && !containsSlug(removed, slug) {
+			t.Errorf("technique %s missing from removedTechniques", slug)
+		}
+	}
+	for slug, n := range easyCounts {
+		if n > 0 && hardCounts[slug] == 0 && !containsSlug(addedTechs, slug) {
+			t.Errorf("technique %s missing from addedTechniques", slug)
+		}
+	}
+}
+
+func containsSlug(list []interface{}, slug string) bool {
+	for _, v := range list {
+		if v == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// TestPuzzleDiffValidation covers the difficulty checks on the diff endpoint
+func TestPuzzleDiffValidation(t *testing.T) {
+	router := setupRouter()
+
+	t.Run("from must be harder than to", func(t *testing.T) {
+		if code, _ := getPuzzleDiff(t, router, "test-seed", "easy", "hard"); code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", code)
+		}
+		if code, _ := getPuzzleDiff(t, router, "test-seed", "medium", "medium"); code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", code)
+		}
+	})
+
+	t.Run("unknown difficulties are rejected", func(t *testing.T) {
+		if code, _ := getPuzzleDiff(t, router, "test-seed", "legendary", "easy"); code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", code)
+		}
+		if code, _ := getPuzzleDiff(t, router, "test-seed", "hard", "legendary"); code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", code)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/pkg/constants"
+)
+
+// getPuzzleResponse fetches /api/puzzle/<seed> and decodes the response
+func getPuzzleResponse(t *testing.T, router http.Handler, seed, difficulty string) map[string]interface{} {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/"+seed+"?d="+difficulty, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return response
+}
+
+// TestPuzzleRequiredTechniques covers the required_techniques field served
+// from banks generated with -with-proof
+func TestPuzzleRequiredTechniques(t *testing.T) {
+	router := setupRouter()
+	loader := puzzles.Global()
+
+	seed := "proof-seed"
+	givens, _, index, err := loader.GetPuzzleBySeed(seed, "medium")
+	if err != nil {
+		t.Fatalf("GetPuzzleBySeed failed: %v", err)
+	}
+
+	// Build the proof the same way cmd/generate does, so the served list
+	// must be consistent with re-solving the puzzle
+	solver := human.NewSolver()
+	moves, status := solver.SolveWithSteps(human.NewBoard(givens), constants.MaxSolverSteps)
+	if status != constants.StatusCompleted {
+		t.Fatalf("human solver could not complete fixture puzzle: %s", status)
+	}
+	expected := make(map[string]int)
+	for _, move := range moves {
+		if move.Technique != "fill-candidate" {
+			expected[move.Technique]++
+		}
+	}
+
+	testPuzzles[index].T = map[string]map[string]int{"m": expected}
+	defer func() { testPuzzles[index].T = nil }()
+
+	t.Run("proof is served and matches a re-solve", func(t *testing.T) {
+		response := getPuzzleResponse(t, router, seed, "medium")
+		techniques, ok := response["required_techniques"].(map[string]interface{})
+		if !ok || len(techniques) == 0 {
+			t.Fatalf("expected non-empty required_techniques, got %v", response["required_techniques"])
+		}
+		if len(techniques) != len(expected) {
+			t.Fatalf("expected %d techniques, got %v", len(expected), techniques)
+		}
+		for slug, count := range expected {
+			if got, ok := techniques[slug].(float64); !ok || int(got) != count {
+				t.Errorf("expected %s count %d, got %v", slug, count, techniques[slug])
+			}
+		}
+	})
+
+	t.Run("difficulty without a proof omits the field", func(t *testing.T) {
+		response := getPuzzleResponse(t, router, seed, "hard")
+		if _, present := response["required_techniques"]; present {
+			t.Errorf("expected no required_techniques, got %v", response["required_techniques"])
+		}
+	})
+}
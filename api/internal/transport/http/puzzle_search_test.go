@@ -0,0 +1,202 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+
+	"github.com/gin-gonic/gin"
+)
+
+// searchTestLoader swaps in a copy of the test bank with technique proofs
+// seeded at hard on puzzle 1, restoring the original loader on cleanup. A
+// fresh loader avoids polluting the shared one's lazy search index.
+func searchTestLoader(t *testing.T) {
+	t.Helper()
+	bank := make([]puzzles.CompactPuzzle, len(testPuzzles))
+	copy(bank, testPuzzles)
+	bank[1].T = map[string]map[string]int{"h": {"x-wing": 1, "naked-single": 12}}
+
+	original := puzzles.Global()
+	puzzles.SetGlobal(puzzles.NewLoaderFromPuzzles(bank))
+	t.Cleanup(func() { puzzles.SetGlobal(original) })
+}
+
+func getSearch(t *testing.T, router *gin.Engine, query string) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzles/search"+query, nil)
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response %q: %v", w.Body.String(), err)
+	}
+	return w.Code, response
+}
+
+func searchResultIndices(t *testing.T, response map[string]interface{}) []int {
+	t.Helper()
+	raw, ok := response["results"].([]interface{})
+	if !ok {
+		t.Fatalf("response has no results array: %v", response)
+	}
+	indices := make([]int, len(raw))
+	for i, entry := range raw {
+		indices[i] = int(entry.(map[string]interface{})["index"].(float64))
+	}
+	return indices
+}
+
+func TestPuzzleSearchByTechnique(t *testing.T) {
+	router := setupRouter()
+	searchTestLoader(t)
+
+	code, response := getSearch(t, router, "?difficulty=hard&technique=x-wing")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, response)
+	}
+	if indices := searchResultIndices(t, response); len(indices) != 1 || indices[0] != 1 {
+		t.Errorf("x-wing search returned indices %v, want [1]", indices)
+	}
+	if total := response["total"].(float64); total != 1 {
+		t.Errorf("total = %v, want 1", total)
+	}
+
+	// Both bank puzzles have 28 hard givens; the range filter applies on top
+	code, response = getSearch(t, router, "?difficulty=hard&technique=naked-single&minGivens=29")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, response)
+	}
+	if indices := searchResultIndices(t, response); len(indices) != 0 {
+		t.Errorf("out-of-range search returned indices %v, want none", indices)
+	}
+}
+
+func TestPuzzleSearchTechniqueUnavailable(t *testing.T) {
+	router := setupRouter()
+	searchTestLoader(t)
+
+	// The seeded proofs only cover hard; easy has no technique data at all
+	code, response := getSearch(t, router, "?difficulty=easy&technique=x-wing")
+	if code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d: %v", code, response)
+	}
+	errObj := response["error"].(map[string]interface{})
+	if errObj["code"] != "technique_data_unavailable" {
+		t.Errorf("error code = %v, want technique_data_unavailable", errObj["code"])
+	}
+}
+
+func TestPuzzleSearchValidation(t *testing.T) {
+	router := setupRouter()
+	searchTestLoader(t)
+
+	for _, query := range []string{
+		"?difficulty=nightmare",
+		"?difficulty=hard&minGivens=abc",
+		"?difficulty=hard&offset=-1",
+		"?difficulty=hard&minGivens=30&maxGivens=24",
+	} {
+		if code, response := getSearch(t, router, query); code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %v", query, code, response)
+		}
+	}
+}
+
+func TestPuzzleSearchPagination(t *testing.T) {
+	router := setupRouter()
+	searchTestLoader(t)
+
+	code, response := getSearch(t, router, "?difficulty=hard&limit=1")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, response)
+	}
+	if indices := searchResultIndices(t, response); !equalIntSlices(indices, []int{0}) {
+		t.Errorf("first page returned %v, want [0]", indices)
+	}
+	if total := response["total"].(float64); total != 2 {
+		t.Errorf("total = %v, want 2", total)
+	}
+
+	code, response = getSearch(t, router, "?difficulty=hard&limit=1&offset=1")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, response)
+	}
+	if indices := searchResultIndices(t, response); !equalIntSlices(indices, []int{1}) {
+		t.Errorf("second page returned %v, want [1]", indices)
+	}
+
+	// Past the end: empty page, same total
+	code, response = getSearch(t, router, "?difficulty=hard&offset=5")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, response)
+	}
+	if indices := searchResultIndices(t, response); len(indices) != 0 {
+		t.Errorf("past-the-end page returned %v, want none", indices)
+	}
+}
+
+func TestPuzzleByIndexFetch(t *testing.T) {
+	router := setupRouter()
+	searchTestLoader(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzles/1?d=hard", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	givens := response["givens"].([]interface{})
+	if len(givens) != 81 {
+		t.Fatalf("givens has %d cells, want 81", len(givens))
+	}
+	filled := 0
+	for _, v := range givens {
+		if v.(float64) != 0 {
+			filled++
+		}
+	}
+	if filled != len(testPuzzles[1].G["h"]) {
+		t.Errorf("puzzle has %d givens, want %d", filled, len(testPuzzles[1].G["h"]))
+	}
+	if response["seed"] != "static-1" {
+		t.Errorf("seed = %v, want static-1", response["seed"])
+	}
+
+	// Out-of-range index
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/puzzles/99?d=hard", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an out-of-range index, got %d", w.Code)
+	}
+
+	// Non-numeric index
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/puzzles/notanumber", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a non-numeric index, got %d", w.Code)
+	}
+}
+
+func equalIntSlices(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
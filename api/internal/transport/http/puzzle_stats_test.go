@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/stats"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startStatsSession opens a session for test-seed/medium under its own device
+// ID, so every call yields a distinct token and therefore a distinct play in
+// the stats
+func startStatsSession(t *testing.T, router *gin.Engine, device string) string {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  device,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Token == "" {
+		t.Fatalf("session start failed: %d %s", w.Code, w.Body.String())
+	}
+	return response.Token
+}
+
+func getPuzzleStats(router *gin.Engine, seed, difficulty string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/"+seed+"/stats?d="+difficulty, nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPuzzleStatsEndpoint(t *testing.T) {
+	router := setupRouter()
+
+	// Nothing recorded yet: the endpoint must refuse rather than divide by
+	// zero or serve a rate from one sample
+	w := getPuzzleStats(router, "test-seed", "medium")
+	if w.Code != http.StatusNotFound || errorCode(w) != ErrCodeInsufficientData {
+		t.Fatalf("Expected 404 %s before any plays, got %d %s", ErrCodeInsufficientData, w.Code, w.Body.String())
+	}
+
+	givens, solution, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("Failed to load test puzzle: %v", err)
+	}
+
+	// MinSample-1 sessions save and walk away; that is still below threshold
+	for i := 0; i < stats.MinSample-1; i++ {
+		token := startStatsSession(t, router, fmt.Sprintf("stats-device-%d", i))
+		if w := postSessionSave(router, map[string]interface{}{
+			"token":         token,
+			"current_board": givens,
+		}); w.Code != http.StatusOK {
+			t.Fatalf("save %d failed: %d %s", i, w.Code, w.Body.String())
+		}
+	}
+	w = getPuzzleStats(router, "test-seed", "medium")
+	if w.Code != http.StatusNotFound || errorCode(w) != ErrCodeInsufficientData {
+		t.Fatalf("Expected 404 %s below MinSample, got %d %s", ErrCodeInsufficientData, w.Code, w.Body.String())
+	}
+
+	// One more session completes the puzzle, crossing the threshold
+	token := startStatsSession(t, router, "stats-device-finisher")
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":           token,
+		"final_board":     solution,
+		"elapsed_seconds": 300,
+		"hints_used":      map[string]int{"level1": 2},
+	})
+	cw := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/complete", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(cw, req)
+	if cw.Code != http.StatusOK {
+		t.Fatalf("session complete failed: %d %s", cw.Code, cw.Body.String())
+	}
+
+	w = getPuzzleStats(router, "test-seed", "medium")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 at MinSample plays, got %d %s", w.Code, w.Body.String())
+	}
+	var got stats.PuzzleStats
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to parse stats response: %v", err)
+	}
+	if got.Plays != stats.MinSample || got.Completions != 1 {
+		t.Errorf("plays/completions = %d/%d, want %d/1", got.Plays, got.Completions, stats.MinSample)
+	}
+	if got.CompletionRate != 1.0/float64(stats.MinSample) {
+		t.Errorf("CompletionRate = %v, want %v", got.CompletionRate, 1.0/float64(stats.MinSample))
+	}
+	if got.MedianTimeSeconds != 300 {
+		t.Errorf("MedianTimeSeconds = %d, want 300", got.MedianTimeSeconds)
+	}
+	if got.AvgHints != 2.0/float64(stats.MinSample) {
+		t.Errorf("AvgHints = %v, want %v", got.AvgHints, 2.0/float64(stats.MinSample))
+	}
+
+	t.Run("RejectsInvalidDifficulty", func(t *testing.T) {
+		w := getPuzzleStats(router, "test-seed", "legendary")
+		if w.Code != http.StatusBadRequest || errorCode(w) != ErrCodeInvalidDifficulty {
+			t.Errorf("Expected 400 %s, got %d %s", ErrCodeInvalidDifficulty, w.Code, w.Body.String())
+		}
+	})
+}
@@ -3,17 +3,28 @@ package http
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"sudoku-api/internal/core"
+	"sudoku-api/internal/ingest"
 	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/render"
+	"sudoku-api/internal/state"
+	"sudoku-api/internal/stats"
 	"sudoku-api/internal/sudoku/dp"
 	"sudoku-api/internal/sudoku/human"
+	"sudoku-api/internal/weekly"
 	"sudoku-api/pkg/config"
 	"sudoku-api/pkg/constants"
 
@@ -22,38 +33,176 @@ import (
 
 var cfg *config.Config
 
+// savedGames stores server-side game state keyed by session token. Entries
+// share the session token's TTL, so saved state never outlives its session.
+var savedGames state.Store
+
+// completions guards /session/complete against replays and holds the score
+// histograms backing percentile ranks
+var completions *completionLedger
+
+// puzzleStats aggregates per-puzzle play statistics fed by the session and
+// hint endpoints and served on /puzzle/:seed/stats
+var puzzleStats stats.Recorder
+
 func RegisterRoutes(r *gin.Engine, c *config.Config) {
 	cfg = c
+	// Hand-built configs (tests, embedders) may leave the session durations
+	// zero; fall back to the constants defaults like config.Load does
+	if cfg.SessionTokenExpiry <= 0 {
+		cfg.SessionTokenExpiry = constants.SessionTokenExpiry
+	}
+	if cfg.SessionRefreshGrace <= 0 {
+		cfg.SessionRefreshGrace = constants.SessionRefreshGrace
+	}
+	savedGames = state.NewMemoryStore(cfg.SessionTokenExpiry, nil)
+	completions = newCompletionLedger(cfg.SessionTokenExpiry)
+	weeklyBoard = weekly.NewMemoryLeaderboard(weeklyLeaderboardSize)
+
+	// Play statistics live in memory; with STATS_FILE set they survive
+	// restarts via periodic JSON snapshots
+	statsStore := stats.NewMemoryStore()
+	if c.StatsFile != "" {
+		if err := statsStore.ReadSnapshot(c.StatsFile); err != nil && !os.IsNotExist(err) {
+			log.Printf("ERROR [routes]: restoring stats snapshot: %v", err)
+		}
+		statsStore.StartSnapshotting(c.StatsFile, func(err error) {
+			log.Printf("ERROR [routes]: writing stats snapshot: %v", err)
+		})
+	}
+	puzzleStats = statsStore
 
+	// Honor X-Forwarded-For only from proxies we operate. gin trusts every
+	// proxy by default, which would let any client spoof its IP with a
+	// forged header; with no config the remote address is the client.
+	if err := r.SetTrustedProxies(c.TrustedProxies); err != nil {
+		log.Printf("ERROR [routes]: invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	// Key the daily puzzle permutation with the server secret: the bank walk
+	// order stays stable across restarts without being derivable from the
+	// bank file alone
+	if loader := puzzles.Global(); loader != nil {
+		loader.SetDailyKey(c.JWTSecret)
+	}
+
+	// /health remains as a legacy alias of the /healthz liveness probe;
+	// /readyz is the readiness probe that actually checks components
 	r.GET(constants.RouteHealth, healthHandler)
+	r.GET(constants.RouteHealthz, healthHandler)
+	r.GET(constants.RouteReadyz, readyzHandler)
+
+	// Metrics live at the root, outside the rate-limited API group, so
+	// operators can still scrape while solve traffic is being shed
+	metricsRegistry = nil
+	if c.MetricsEnabled {
+		metricsRegistry = NewMetrics()
+		if loader := puzzles.Global(); loader != nil {
+			metricsRegistry.SetLoadedPuzzles(loader.Count())
+		}
+		r.GET(constants.RouteMetrics, metricsHandler)
+	}
+
+	// One structured entry per request, emitted when the handler chain
+	// finishes. Probe and metrics routes register above this middleware on
+	// purpose: scrapes every few seconds would drown real traffic in the log
+	baseLogger = newLogger(c)
+	r.Use(requestLogMiddleware())
+
+	if len(c.AllowedOrigins) > 0 {
+		r.Use(corsMiddleware(c.AllowedOrigins))
+	}
+
+	// The IP-prefix half of the abuse blocklist fronts every API route;
+	// device_id blocks are checked where the claim surfaces (session start
+	// and the solve guards)
+	if len(c.BlockedIPPrefixes) > 0 {
+		r.Use(blocklistMiddleware())
+	}
 
-	api := r.Group(constants.RouteAPI)
-	{
-		api.GET(constants.RouteVersion, versionHandler)
-		api.GET(constants.RouteDaily, dailyHandler)
-		api.GET(constants.RoutePuzzleID, puzzleHandler)
-		api.GET(constants.RouteAnalyze, puzzleAnalyzeHandler)
-		api.GET(constants.RoutePractice, practiceHandler)
-		api.POST(constants.RouteSessionStart, sessionStartHandler)
-		api.POST(constants.RouteSolveNext, solveNextHandler)
-		api.POST(constants.RouteSolveAll, solveAllHandler)
-		api.POST(constants.RouteSolveFull, solveFullHandler)
-		api.POST(constants.RouteValidate, validateBoardHandler)
-		api.POST(constants.RouteCustomValidate, customValidateHandler)
+	// Solve endpoints get body-size and rate-limit guards: they are by far
+	// the most expensive routes. The limiter is shared across route groups
+	// so aliased paths draw from the same budget
+	var limiter *RateLimiter
+	if c.SolveRatePerMinute > 0 {
+		limiter = NewRateLimiter(c.SolveRatePerMinute, nil)
+	}
+	guard := solveGuards(limiter)
+
+	// The winnable oracle draws from its own, tighter bucket: burning its
+	// quota on guess-and-check must not cost the player their hint budget,
+	// and vice versa
+	var winnableLimiter *RateLimiter
+	if c.WinnableRatePerMinute > 0 {
+		winnableLimiter = NewRateLimiter(c.WinnableRatePerMinute, nil)
 	}
+	winnableGuard := solveGuards(winnableLimiter)
+
+	// /api/v1 is the canonical prefix; the unprefixed /api routes remain
+	// aliased for one deprecation cycle
+	registerAPIRoutes(r.Group(constants.RouteAPIV1), guard, winnableGuard)
+	registerAPIRoutes(r.Group(constants.RouteAPI), guard, winnableGuard)
+
+	// Reflect the finished route table into the OpenAPI document served at
+	// /api/openapi.json (and emitted to openapi.json via go generate)
+	openapiDoc = buildOpenAPIDocument(r.Routes())
 }
 
-func healthHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"version": constants.APIVersion,
-	})
+// registerAPIRoutes attaches every API handler to one route group
+func registerAPIRoutes(api *gin.RouterGroup, guard, winnableGuard gin.HandlerFunc) {
+	api.GET(constants.RouteVersion, versionHandler)
+	api.GET(constants.RouteOpenAPI, openapiHandler)
+	api.GET(constants.RouteDaily, dailyHandler)
+	api.GET(constants.RoutePuzzleID, puzzleHandler)
+	api.GET(constants.RouteAnalyze, puzzleAnalyzeHandler)
+	api.GET(constants.RoutePuzzleDiff, puzzleDiffHandler)
+	api.GET(constants.RouteThumbnail, puzzleThumbnailHandler)
+	api.GET(constants.RoutePuzzleStats, puzzleStatsHandler)
+	api.GET(constants.RoutePractice, practiceHandler)
+	api.GET(constants.RouteTechniques, techniquesHandler)
+	api.GET(constants.RoutePuzzlePack, puzzlePackHandler)
+	api.GET(constants.RoutePuzzlesSearch, puzzlesSearchHandler)
+	api.GET(constants.RoutePuzzleByIndex, puzzleByIndexHandler)
+	api.GET(constants.RouteTutorial, tutorialHandler)
+	api.POST(constants.RouteTutorialStep, tutorialStepHandler)
+	api.POST(constants.RouteSessionStart, sessionStartHandler)
+	api.POST(constants.RouteSessionRefresh, sessionRefreshHandler)
+
+	// Session save carries user-supplied history, so it shares the solve
+	// endpoints' body-size and rate-limit guards
+	api.POST(constants.RouteSessionSave, guard, sessionSaveHandler)
+	api.GET(constants.RouteSessionRestore, sessionRestoreHandler)
+	api.POST(constants.RouteSessionComplete, sessionCompleteHandler)
+	api.POST(constants.RouteSolveNext, guard, solveNextHandler)
+	api.POST(constants.RouteSolveAll, guard, solveAllHandler)
+	api.POST(constants.RouteSolveCell, guard, solveCellHandler)
+	api.POST(constants.RouteSolveFull, guard, solveFullHandler)
+	// The streaming variant is a GET so EventSource can reach it; the token
+	// and board travel as query parameters instead of a body
+	api.GET(constants.RouteSolveStream, guard, solveStreamHandler)
+	api.POST(constants.RouteCandidates, candidatesCheckHandler)
+	api.POST(constants.RouteAssistCascade, guard, assistCascadeHandler)
+	// Explaining an eliminated candidate may replay the solver, so it
+	// shares the solve guards
+	api.POST(constants.RouteExplain, guard, explainHandler)
+	api.POST(constants.RouteValidate, validateBoardHandler)
+	api.POST(constants.RouteValidateWinnable, winnableGuard, validateWinnableHandler)
+	api.POST(constants.RouteCustomValidate, customValidateHandler)
+	api.POST(constants.RouteCustomResolve, customResolveHandler)
+	// Reviewing a history replays the solver per placement, so it shares
+	// the solve guards
+	api.POST(constants.RouteSolveReview, guard, solveReviewHandler)
+	api.GET(constants.RouteWeekly, weeklyHandler)
+	// Submissions carry user-supplied move logs, so they share the solve
+	// endpoints' body-size and rate-limit guards
+	api.POST(constants.RouteWeeklySubmit, guard, weeklySubmitHandler)
+	api.GET(constants.RouteWeeklyBoard, weeklyLeaderboardHandler)
 }
 
 func versionHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"api_version":    constants.APIVersion,
-		"solver_version": constants.SolverVersion,
+	c.JSON(http.StatusOK, VersionResponse{
+		APIVersion:    constants.APIVersion,
+		SolverVersion: constants.SolverVersion,
 	})
 }
 
@@ -68,48 +217,102 @@ func dailyHandler(c *gin.Context) {
 	dateUTC := TodayUTC()
 
 	// Deterministic seed from date
-	seed := constants.DailyPuzzlePrefix + dateUTC
+	seed := core.DailyPuzzleRef(time.Now()).String()
 
 	// Get puzzle index for today if puzzles are loaded
 	var puzzleIndex int
+	resp := gin.H{
+		"date_utc": dateUTC,
+		"seed":     seed,
+	}
 	loader := puzzles.Global()
 	if loader != nil {
 		_, _, puzzleIndex, _ = loader.GetDailyPuzzle(time.Now(), "medium")
+		// The checksum lets clients and operators verify that an index
+		// refers to the same bank entry on both ends
+		if checksum, err := loader.Checksum(puzzleIndex); err == nil {
+			resp["checksum"] = checksum
+		}
 	}
+	resp["puzzle_index"] = puzzleIndex
 
-	c.JSON(http.StatusOK, gin.H{
-		"date_utc":     dateUTC,
-		"seed":         seed,
-		"puzzle_index": puzzleIndex,
-	})
+	c.JSON(http.StatusOK, resp)
+}
+
+// invalidDifficultyError formats the standard rejection message from the
+// active difficulty list, so config-defined tiers show up in it too
+func invalidDifficultyError(difficulty string) string {
+	return fmt.Sprintf("invalid difficulty '%s'. Must be one of: %s",
+		difficulty, strings.Join(constants.DifficultyNames(), ", "))
+}
+
+// remixBySeed resolves a remix seed against the bank: the puzzle at the
+// referenced index is run through the seeded symmetry transform before the
+// difficulty's givens are extracted. The index wraps modulo the bank size so
+// remix seeds stay servable if the bank shrinks.
+func remixBySeed(loader *puzzles.Loader, ref core.PuzzleRef, difficulty string) (givens []int, solution []int, puzzleIndex int, err error) {
+	count := loader.Count()
+	if count == 0 {
+		return nil, nil, 0, errors.New("no puzzles loaded")
+	}
+	puzzleIndex = int(ref.Numeric % int64(count))
+	compact, err := loader.GetCompact(puzzleIndex)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	givens, solution, err = dp.RemixPuzzle(compact, ref.RemixSeed).Expand(difficulty)
+	return
 }
 
 func puzzleHandler(c *gin.Context) {
-	seed := c.Param("seed")
+	ref, err := core.ParsePuzzleRef(c.Param("seed"))
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidSeed, err.Error())
+		return
+	}
+	seed := ref.String()
 	difficulty := core.Difficulty(c.Query("d"))
 
 	if difficulty == "" {
 		difficulty = core.DifficultyMedium
 	}
 
-	// Validate difficulty
-	if difficulty != core.DifficultyEasy &&
-		difficulty != core.DifficultyMedium &&
-		difficulty != core.DifficultyHard &&
-		difficulty != core.DifficultyExtreme &&
-		difficulty != core.DifficultyImpossible {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid difficulty '%s'. Must be one of: %s, %s, %s, %s, %s", difficulty, core.DifficultyEasy, core.DifficultyMedium, core.DifficultyHard, core.DifficultyExtreme, core.DifficultyImpossible)})
+	// Validate difficulty against the active spec list
+	spec, ok := constants.DifficultyByName(string(difficulty))
+	if !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(string(difficulty)))
+		return
+	}
+
+	// Optional presentation transform: the canonical puzzle is still what the
+	// seed identifies, the givens are just served reoriented
+	transformSpec := c.Query("transform")
+	transform, err := core.ParseTransform(transformSpec)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidTransform, err.Error())
+		return
+	}
+
+	// Optional extra analysis attached to the response
+	analysis := c.Query("analysis")
+	if analysis != "" && analysis != "minimal" {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidAnalysis,
+			fmt.Sprintf("invalid analysis '%s'. Must be: minimal", analysis))
 		return
 	}
 
 	var givens []int
 	var puzzleIndex int
+	degraded := false
 
 	// Try pre-generated puzzles first
 	loader := puzzles.Global()
 	if loader != nil {
-		var err error
-		givens, _, puzzleIndex, err = loader.GetPuzzleBySeed(seed, string(difficulty))
+		if ref.Kind == core.SeedRemix {
+			givens, _, puzzleIndex, err = remixBySeed(loader, ref, string(difficulty))
+		} else {
+			givens, _, puzzleIndex, err = loader.GetPuzzleBySeed(seed, string(difficulty))
+		}
 		if err != nil {
 			// Fall through to generation
 			loader = nil
@@ -118,41 +321,192 @@ func puzzleHandler(c *gin.Context) {
 
 	// Fallback: generate puzzle on-demand
 	if loader == nil {
+		// Bank-only tiers guarantee a technique predicate the on-demand
+		// carve cannot honor; without a bank entry there is nothing to serve
+		if len(spec.RequireAnyOf) > 0 {
+			apiError(c, http.StatusNotFound, ErrCodeNoPuzzleFound,
+				fmt.Sprintf("difficulty '%s' is only served from pre-generated puzzle banks", difficulty))
+			return
+		}
 		seedHash := hashSeed(seed)
-		fullGrid := dp.GenerateFullGrid(seedHash)
-		allPuzzles := dp.CarveGivensWithSubset(fullGrid, seedHash)
+		fullGrid := generateFullGrid(seed, seedHash)
+		allPuzzles, degradedTiers := carveForSeed(fullGrid, seedHash)
 		givens = allPuzzles[string(difficulty)]
+		degraded = degradedTiers[string(difficulty)]
 		puzzleIndex = -1 // Indicates generated, not pre-loaded
 	}
 
 	// Generate a deterministic puzzle ID from seed + difficulty
-	puzzleID := seed + constants.PuzzleIDDl + string(difficulty)
+	puzzleID := ref.PuzzleID(string(difficulty))
 
-	c.JSON(http.StatusOK, gin.H{
+	metricsRegistry.IncPuzzleServed(string(difficulty))
+
+	// Analysis runs against the canonical orientation; a transform only
+	// relabels cells and cannot change which techniques the puzzle needs
+	canonicalGivens := givens
+
+	if !transform.IsIdentity() {
+		givens = transform.ApplyCells(givens)
+	}
+
+	// Explicit givenness per cell, so clients don't have to infer it by
+	// comparing the board against the givens array
+	isGiven := make([]bool, len(givens))
+	for i, v := range givens {
+		isGiven[i] = v != 0
+	}
+
+	resp := gin.H{
 		"puzzle_id":    puzzleID,
 		"seed":         seed,
 		"difficulty":   difficulty,
 		"givens":       givens,
+		"is_given":     isGiven,
 		"puzzle_index": puzzleIndex,
-	})
+		// degraded means carving could not reach this tier's givens target,
+		// so the puzzle matches an easier tier for this seed
+		"degraded": degraded,
+	}
+	if transformSpec != "" {
+		resp["transform"] = transformSpec
+	}
+
+	// Banks generated with -with-proof record which techniques the human
+	// solver needed, so clients can show "this puzzle needs X-Wing"
+	if loader != nil {
+		if techniques, err := loader.GetTechniques(puzzleIndex, string(difficulty)); err == nil && len(techniques) > 0 {
+			resp["required_techniques"] = techniques
+		}
+	}
+
+	// ?analysis=minimal attaches the locally-minimal technique set: which
+	// techniques the puzzle genuinely needs, rather than whichever ones the
+	// default solve happened to use
+	if analysis == "minimal" {
+		resp["minimal_techniques"] = minimalTechniquesFor(puzzleID, canonicalGivens)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Cache for minimal technique analyses: the answer is deterministic per
+// puzzle and costs a dozen-odd solver runs, so it is computed once per
+// puzzle ID
+var minimalTechniqueCache = struct {
+	sync.RWMutex
+	sets map[string][]human.MinimalTechnique
+}{
+	sets: make(map[string][]human.MinimalTechnique),
+}
+
+// minimalTechniquesFor returns the cached minimal technique set for a puzzle,
+// computing it on first request. A puzzle the human solver cannot finish
+// yields an empty set.
+func minimalTechniquesFor(puzzleID string, givens []int) []human.MinimalTechnique {
+	minimalTechniqueCache.RLock()
+	set, ok := minimalTechniqueCache.sets[puzzleID]
+	minimalTechniqueCache.RUnlock()
+	if ok {
+		return set
+	}
+
+	set, _ = human.NewSolver().MinimalTechniqueAnalysis(givens)
+	minimalTechniqueCache.Lock()
+	minimalTechniqueCache.sets[puzzleID] = set
+	minimalTechniqueCache.Unlock()
+	return set
+}
+
+// puzzleThumbnailHandler renders the deterministic puzzle for a seed as an
+// SVG preview image for share links. The output is immutable per
+// seed+difficulty, so it is served with long cache headers.
+func puzzleThumbnailHandler(c *gin.Context) {
+	ref, err := core.ParsePuzzleRef(c.Param("seed"))
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidSeed, err.Error())
+		return
+	}
+	seed := ref.String()
+	difficulty := core.Difficulty(c.Query("d"))
+
+	if difficulty == "" {
+		difficulty = core.DifficultyMedium
+	}
+
+	// Validate difficulty against the active spec list
+	if _, ok := constants.DifficultyByName(string(difficulty)); !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(string(difficulty)))
+		return
+	}
+
+	var givens []int
+
+	// Try pre-generated puzzles first
+	loader := puzzles.Global()
+	if loader != nil {
+		givens, _, _, err = loader.GetPuzzleBySeed(seed, string(difficulty))
+		if err != nil {
+			loader = nil
+		}
+	}
+
+	// Fallback: generate puzzle on-demand
+	if loader == nil {
+		seedHash := hashSeed(seed)
+		fullGrid := generateFullGrid(seed, seedHash)
+		allPuzzles, _ := carveForSeed(fullGrid, seedHash)
+		givens = allPuzzles[string(difficulty)]
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", constants.ThumbnailCacheMaxAgeSec))
+	c.Data(http.StatusOK, "image/svg+xml", []byte(render.BoardSVG(givens)))
+}
+
+// puzzleStatsHandler serves the aggregate play statistics behind the "N% of
+// players solved this" surface. Puzzles with fewer than stats.MinSample
+// recorded plays get a structured insufficient-data rejection rather than a
+// rate computed from noise.
+func puzzleStatsHandler(c *gin.Context) {
+	ref, err := core.ParsePuzzleRef(c.Param("seed"))
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidSeed, err.Error())
+		return
+	}
+	difficulty := c.Query("d")
+	if difficulty == "" {
+		difficulty = constants.DifficultyMedium
+	}
+	if _, ok := constants.DifficultyByName(difficulty); !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(difficulty))
+		return
+	}
+
+	aggregate, ok := puzzleStats.GetPuzzleStats(ref.String(), difficulty)
+	if !ok {
+		apiError(c, http.StatusNotFound, ErrCodeInsufficientData,
+			fmt.Sprintf("fewer than %d recorded plays for this puzzle", stats.MinSample))
+		return
+	}
+	c.JSON(http.StatusOK, aggregate)
 }
 
 // puzzleAnalyzeHandler analyzes a puzzle and returns technique requirements
 func puzzleAnalyzeHandler(c *gin.Context) {
-	seed := c.Param("seed")
+	ref, err := core.ParsePuzzleRef(c.Param("seed"))
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidSeed, err.Error())
+		return
+	}
+	seed := ref.String()
 	difficulty := core.Difficulty(c.Query("d"))
 
 	if difficulty == "" {
 		difficulty = core.DifficultyMedium
 	}
 
-	// Validate difficulty
-	if difficulty != core.DifficultyEasy &&
-		difficulty != core.DifficultyMedium &&
-		difficulty != core.DifficultyHard &&
-		difficulty != core.DifficultyExtreme &&
-		difficulty != core.DifficultyImpossible {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid difficulty '%s'. Must be one of: %s, %s, %s, %s, %s", difficulty, core.DifficultyEasy, core.DifficultyMedium, core.DifficultyHard, core.DifficultyExtreme, core.DifficultyImpossible)})
+	// Validate difficulty against the active spec list
+	if _, ok := constants.DifficultyByName(string(difficulty)); !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(string(difficulty)))
 		return
 	}
 
@@ -161,7 +515,6 @@ func puzzleAnalyzeHandler(c *gin.Context) {
 	// Try pre-generated puzzles first
 	loader := puzzles.Global()
 	if loader != nil {
-		var err error
 		givens, _, _, err = loader.GetPuzzleBySeed(seed, string(difficulty))
 		if err != nil {
 			loader = nil
@@ -171,14 +524,14 @@ func puzzleAnalyzeHandler(c *gin.Context) {
 	// Fallback: generate puzzle on-demand
 	if loader == nil {
 		seedHash := hashSeed(seed)
-		fullGrid := dp.GenerateFullGrid(seedHash)
-		allPuzzles := dp.CarveGivensWithSubset(fullGrid, seedHash)
+		fullGrid := generateFullGrid(seed, seedHash)
+		allPuzzles, _ := carveForSeed(fullGrid, seedHash)
 		givens = allPuzzles[string(difficulty)]
 	}
 
 	// Analyze with human solver
 	solver := human.NewSolver()
-	requiredDiff, techniqueCounts, status := solver.AnalyzePuzzleDifficulty(givens)
+	requiredDiff, techniqueCounts, usedUniqueness, status := solver.AnalyzePuzzleDifficultyReport(givens)
 
 	givensCount := 0
 	for _, v := range givens {
@@ -187,6 +540,15 @@ func puzzleAnalyzeHandler(c *gin.Context) {
 		}
 	}
 
+	// Include metadata for the techniques that were used so clients can
+	// render human-friendly labels without hardcoding them
+	var techniqueInfo []human.TechniqueInfo
+	for _, info := range solver.GetRegistry().GetTechniqueInfos() {
+		if techniqueCounts[info.Slug] > 0 {
+			techniqueInfo = append(techniqueInfo, info)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"seed":                seed,
 		"difficulty":          difficulty,
@@ -194,6 +556,94 @@ func puzzleAnalyzeHandler(c *gin.Context) {
 		"required_difficulty": requiredDiff,
 		"status":              status,
 		"techniques":          techniqueCounts,
+		"technique_info":      techniqueInfo,
+		// True when the rating leaned on uniqueness-based techniques, which
+		// are unsound for puzzles with multiple solutions
+		"uniqueness_dependent": usedUniqueness,
+	})
+}
+
+// seedGivens resolves the deterministic carve for a seed and difficulty,
+// preferring pre-generated puzzles and generating on demand otherwise
+func seedGivens(seed, difficulty string) []int {
+	if loader := puzzles.Global(); loader != nil {
+		if givens, _, _, err := loader.GetPuzzleBySeed(seed, difficulty); err == nil {
+			return givens
+		}
+	}
+	seedHash := hashSeed(seed)
+	fullGrid := generateFullGrid(seed, seedHash)
+	allPuzzles, _ := carveForSeed(fullGrid, seedHash)
+	return allPuzzles[difficulty]
+}
+
+// Cache for difficulty diffs: puzzles are deterministic per seed, so a
+// (seed, from, to) triple always produces the same answer
+var puzzleDiffCache = struct {
+	sync.RWMutex
+	diffs map[string]human.PuzzleDiff
+}{
+	diffs: make(map[string]human.PuzzleDiff),
+}
+
+// puzzleDiffHandler reports what dropping from a harder difficulty to an
+// easier one buys the player on the same seed: the extra givens (difficulties
+// are carved as subsets of one solution) and how the set of required
+// techniques changes
+//
+// GET /api/puzzle/:seed/diff?from=hard&to=easy
+func puzzleDiffHandler(c *gin.Context) {
+	ref, err := core.ParsePuzzleRef(c.Param("seed"))
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidSeed, err.Error())
+		return
+	}
+	seed := ref.String()
+
+	from, to := c.Query("from"), c.Query("to")
+	fromRank, ok := constants.DifficultyRank(from)
+	if !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(from))
+		return
+	}
+	toRank, ok := constants.DifficultyRank(to)
+	if !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(to))
+		return
+	}
+	if fromRank <= toRank {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, "from must be harder than to")
+		return
+	}
+
+	key := seed + constants.PuzzleIDDl + from + constants.PuzzleIDDl + to
+	puzzleDiffCache.RLock()
+	diff, cached := puzzleDiffCache.diffs[key]
+	puzzleDiffCache.RUnlock()
+
+	if !cached {
+		diff = human.NewSolver().DiffPuzzles(seedGivens(seed, from), seedGivens(seed, to))
+		puzzleDiffCache.Lock()
+		puzzleDiffCache.diffs[key] = diff
+		puzzleDiffCache.Unlock()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"seed":              seed,
+		"from":              from,
+		"to":                to,
+		"addedGivens":       diff.AddedGivens,
+		"removedTechniques": diff.RemovedTechniques,
+		"addedTechniques":   diff.AddedTechniques,
+	})
+}
+
+// techniquesHandler returns metadata for all registered solving techniques
+// so clients can render names, tiers, and docs links from a single source
+func techniquesHandler(c *gin.Context) {
+	registry := human.NewTechniqueRegistry()
+	c.JSON(http.StatusOK, gin.H{
+		"techniques": registry.GetTechniqueInfos(),
 	})
 }
 
@@ -210,6 +660,19 @@ var practiceCache = struct {
 type practicePuzzle struct {
 	index      int
 	difficulty string
+	// essential records whether the technique's removal alone makes the
+	// puzzle unsolvable, from the minimal technique analysis at discovery
+	essential bool
+}
+
+// minimalMember finds a technique in a minimal set by slug
+func minimalMember(set []human.MinimalTechnique, slug string) (human.MinimalTechnique, bool) {
+	for _, member := range set {
+		if member.Slug == slug {
+			return member, true
+		}
+	}
+	return human.MinimalTechnique{}, false
 }
 
 // practiceHandler finds a puzzle requiring a specific technique for practice purposes
@@ -245,13 +708,13 @@ func practiceHandler(c *gin.Context) {
 	technique := c.Param("technique")
 
 	if technique == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "technique required"})
+		apiError(c, http.StatusBadRequest, ErrCodeTechniqueRequired, "technique required")
 		return
 	}
 
 	loader := puzzles.Global()
 	if loader == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "puzzles not loaded"})
+		apiError(c, http.StatusServiceUnavailable, ErrCodePuzzlesNotLoaded, "puzzles not loaded")
 		return
 	}
 
@@ -262,12 +725,16 @@ func practiceHandler(c *gin.Context) {
 		"naked-single":       {"easy", "medium"},
 		"hidden-single":      {"easy", "medium"},
 		"pointing-pair":      {"easy", "medium", "hard"},
+		"pointing-triple":    {"easy", "medium", "hard"},
 		"box-line-reduction": {"easy", "medium", "hard"},
+		"claiming":           {"easy", "medium", "hard"},
 		"naked-pair":         {"easy", "medium", "hard"},
+		"locked-pair":        {"easy", "medium", "hard"},
 		"hidden-pair":        {"easy", "medium", "hard"},
 
 		// Medium techniques
 		"naked-triple":    {"easy", "medium", "hard", "extreme", "impossible"},
+		"locked-triple":   {"easy", "medium", "hard", "extreme", "impossible"},
 		"hidden-triple":   {"easy", "medium", "hard", "extreme", "impossible"},
 		"naked-quad":      {"hard", "extreme"},
 		"hidden-quad":     {"hard", "extreme"},
@@ -327,6 +794,7 @@ func practiceHandler(c *gin.Context) {
 				"difficulty":   p.difficulty,
 				"givens":       givens,
 				"technique":    technique,
+				"essential":    p.essential,
 				"puzzle_index": p.index,
 				"cached":       true,
 			})
@@ -361,11 +829,25 @@ func practiceHandler(c *gin.Context) {
 
 			// Check if this technique is used
 			if count, ok := techniqueCounts[technique]; ok && count > 0 {
+				// Used is not the same as needed: the default solve may have
+				// fired the technique where an easier route existed. Only
+				// serve puzzles that keep it in their minimal technique set,
+				// so practice actually exercises what it advertises.
+				minimal, minimalStatus := solver.MinimalTechniqueAnalysis(givens)
+				if minimalStatus != "completed" {
+					continue
+				}
+				member, inMinimal := minimalMember(minimal, technique)
+				if !inMinimal {
+					continue
+				}
+
 				// Found one! Cache it (thread-safe write) and return
 				practiceCache.Lock()
 				practiceCache.puzzles[technique] = append(practiceCache.puzzles[technique], practicePuzzle{
 					index:      idx,
 					difficulty: diff,
+					essential:  member.Essential,
 				})
 				practiceCache.Unlock()
 
@@ -375,6 +857,7 @@ func practiceHandler(c *gin.Context) {
 					"difficulty":   diff,
 					"givens":       givens,
 					"technique":    technique,
+					"essential":    member.Essential,
 					"puzzle_index": idx,
 					"cached":       false,
 				})
@@ -384,11 +867,97 @@ func practiceHandler(c *gin.Context) {
 	}
 
 	// Didn't find a puzzle with this technique
-	c.JSON(http.StatusNotFound, gin.H{
-		"error":     "no puzzle found",
-		"technique": technique,
-		"message":   "Could not find a puzzle requiring this technique. Try a different technique or check back later.",
-	})
+	apiErrorDetails(c, http.StatusNotFound, ErrCodeNoPuzzleFound,
+		"Could not find a puzzle requiring this technique. Try a different technique or check back later.",
+		gin.H{"technique": technique})
+}
+
+// solverStrategyFromQuery reads the optional ?strategy= query parameter.
+// Writes a 400 response and returns false for unknown strategies.
+func solverStrategyFromQuery(c *gin.Context) (human.SolverStrategy, bool) {
+	strategy, ok := human.ParseStrategy(c.Query("strategy"))
+	if !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidStrategy, fmt.Sprintf("invalid strategy '%s'. Must be one of: %s, %s, %s", c.Query("strategy"), human.StrategyFirst, human.StrategyPreferAssign, human.StrategyMaxImpact))
+		return human.StrategyFirst, false
+	}
+	return strategy, true
+}
+
+// solverCandidateModeFromQuery reads the optional ?candidates= query
+// parameter controlling fill-candidate bookkeeping in the returned move
+// stream: implicit (default), none, or batched. The solve itself is
+// identical in every mode.
+func solverCandidateModeFromQuery(c *gin.Context) (string, bool) {
+	mode, ok := human.ParseCandidateMode(c.Query("candidates"))
+	if !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest,
+			fmt.Sprintf("invalid candidates mode '%s'. Must be one of: %s, %s, %s", c.Query("candidates"),
+				human.CandidateModeImplicit, human.CandidateModeNone, human.CandidateModeBatched))
+		return human.CandidateModeImplicit, false
+	}
+	return mode, true
+}
+
+// solverMaxTierFromQuery reads the optional ?maxTier= query parameter,
+// which caps the techniques a solve request may use - easy-mode hints
+// shouldn't reveal fish patterns. Empty means no cap.
+func solverMaxTierFromQuery(c *gin.Context) (string, bool) {
+	tier := c.Query("maxTier")
+	switch tier {
+	case "", constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme:
+		return tier, true
+	}
+	apiError(c, http.StatusBadRequest, ErrCodeInvalidTier,
+		fmt.Sprintf("invalid maxTier '%s'. Must be one of: %s, %s, %s, %s", tier,
+			constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme))
+	return "", false
+}
+
+// newSolveSolver builds the solver for one solve request, honoring the
+// optional strategy and maxTier query parameters
+func newSolveSolver(strategy human.SolverStrategy, maxTier string) *human.Solver {
+	solver := human.NewSolver()
+	if maxTier != "" {
+		solver = human.NewSolverUpToTier(maxTier)
+	}
+	solver.SetStrategy(strategy)
+	return solver
+}
+
+// generateFullGrid picks the grid generator based on the seed's prefix.
+// Seeds with the dp.FastGridSeedPrefix ("v2:") use the transformation-based
+// fast path; all other seeds keep the original backtracking generator so
+// existing puzzles are unchanged.
+func generateFullGrid(seed string, seedHash int64) []int {
+	if dp.UseFastGrid(seed) {
+		return dp.GenerateFullGridFast(seedHash)
+	}
+	return dp.GenerateFullGrid(seedHash)
+}
+
+// carveForSeed runs the classic on-demand carve, routed through the
+// opening-quality post-filter when OPENING_QUALITY_CARVE is set
+func carveForSeed(fullGrid []int, seedHash int64) (map[string][]int, map[string]bool) {
+	if cfg != nil && cfg.OpeningQualityCarve {
+		return human.CarveWithOpeningQuality(fullGrid, seedHash, human.DefaultOpeningQuality())
+	}
+	return dp.CarveGivensWithSubset(fullGrid, seedHash)
+}
+
+// solveMoveBudget returns the per-request solver iteration cap
+func solveMoveBudget() int {
+	if cfg != nil && cfg.SolveMaxMoves > 0 {
+		return cfg.SolveMaxMoves
+	}
+	return 2000
+}
+
+// solveTimeBudget returns the per-request wall-time budget for solve loops
+func solveTimeBudget() time.Duration {
+	if cfg != nil && cfg.SolveTimeBudgetMs > 0 {
+		return time.Duration(cfg.SolveTimeBudgetMs) * time.Millisecond
+	}
+	return 10 * time.Second
 }
 
 // hashSeed generates a deterministic hash from a seed string for puzzle generation
@@ -420,121 +989,645 @@ func hashSolution(board []int) string {
 }
 
 type SessionStartRequest struct {
-	Seed       string `json:"seed" binding:"required"`
-	Difficulty string `json:"difficulty" binding:"required"`
-	DeviceID   string `json:"device_id" binding:"required"`
+	Seed        string             `json:"seed" binding:"required"`
+	Difficulty  string             `json:"difficulty" binding:"required"`
+	DeviceID    string             `json:"device_id" binding:"required"`
+	Constraints core.ConstraintSet `json:"constraints"` // optional variant constraints
+	Transform   string             `json:"transform"`   // optional presentation transform (see core.ParseTransform)
 }
 
 func sessionStartHandler(c *gin.Context) {
 	var req SessionStartRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	if deviceBlocked(req.DeviceID) {
+		abortBlocked(c)
+		return
+	}
+
+	// Validate difficulty against the active spec list
+	if _, ok := constants.DifficultyByName(req.Difficulty); !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(req.Difficulty))
+		return
+	}
+
+	ref, err := core.ParsePuzzleRef(req.Seed)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidSeed, err.Error())
 		return
 	}
+	seed := ref.String()
 
-	// Validate difficulty
-	difficulty := core.Difficulty(req.Difficulty)
-	if difficulty != core.DifficultyEasy &&
-		difficulty != core.DifficultyMedium &&
-		difficulty != core.DifficultyHard &&
-		difficulty != core.DifficultyExtreme &&
-		difficulty != core.DifficultyImpossible {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid difficulty '%s'. Must be one of: %s, %s, %s, %s, %s", req.Difficulty, core.DifficultyEasy, core.DifficultyMedium, core.DifficultyHard, core.DifficultyExtreme, core.DifficultyImpossible)})
+	// A presented transform is baked into the token so every later solve
+	// call knows how to map the client's boards back to canonical
+	if _, err := core.ParseTransform(req.Transform); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidTransform, err.Error())
 		return
 	}
 
 	// Generate deterministic puzzle ID
-	puzzleID := req.Seed + constants.PuzzleIDDl + req.Difficulty
+	puzzleID := ref.PuzzleID(req.Difficulty)
+
+	// Resolve the puzzle's solution up front and seal it into the token, so
+	// solve handlers don't have to re-derive it on every request. Variant
+	// constraints change the solution, so only classic sessions carry it.
+	var sealed string
+	if req.Constraints.IsClassic() {
+		var solution []int
+		if loader := puzzles.Global(); loader != nil {
+			if ref.Kind == core.SeedRemix {
+				// Remix sessions must seal the transformed solution, not the
+				// bank puzzle the seed would hash to
+				_, solution, _, _ = remixBySeed(loader, ref, req.Difficulty)
+			} else {
+				_, solution, _, _ = loader.GetPuzzleBySeed(seed, req.Difficulty)
+			}
+		}
+		if len(solution) != constants.TotalCells {
+			solution = generateFullGrid(seed, hashSeed(seed))
+		}
+		sealed = sealSolution(cfg.JWTSecret, puzzleID, solution)
+	}
 
 	now := time.Now()
 	session := SessionToken{
-		DeviceID:   req.DeviceID,
-		PuzzleID:   puzzleID,
-		Seed:       req.Seed,
-		Difficulty: req.Difficulty,
-		StartedAt:  now,
-		ExpiresAt:  now.Add(constants.SessionTokenExpiry),
+		DeviceID:    req.DeviceID,
+		PuzzleID:    puzzleID,
+		Seed:        seed,
+		Difficulty:  req.Difficulty,
+		Constraints: req.Constraints,
+		StartedAt:   now,
+		ExpiresAt:   now.Add(cfg.SessionTokenExpiry),
+		Solution:    sealed,
+		Transform:   req.Transform,
+		// Client metadata for abuse correlation, carried as claims so no
+		// server-side storage is involved
+		IPHash:     hashClientIP(c.ClientIP()),
+		UAFamily:   uaFamily(c.GetHeader("User-Agent")),
+		AppVersion: c.GetHeader("X-App-Version"),
 	}
 
 	token, err := createToken(cfg.JWTSecret, session)
 	if err != nil {
 		log.Printf("ERROR [sessionStart]: failed to create token: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create token"})
+		apiError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to create token")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token":      token,
-		"puzzle_id":  puzzleID,
-		"started_at": now.Format(time.RFC3339),
+	c.JSON(http.StatusOK, SessionStartResponse{
+		Token:            token,
+		PuzzleID:         puzzleID,
+		StartedAt:        now.Format(time.RFC3339),
+		ExpiresAt:        session.ExpiresAt.Format(time.RFC3339),
+		RefreshableUntil: session.ExpiresAt.Add(cfg.SessionRefreshGrace).Format(time.RFC3339),
 	})
 }
 
-type SolveNextRequest struct {
-	Token      string  `json:"token" binding:"required"`
-	Board      []int   `json:"board" binding:"required"`
-	Candidates [][]int `json:"candidates"` // Optional: preserve eliminations
-	Givens     []int   `json:"givens"`     // Original puzzle givens (to identify user-entered cells)
+type SessionRefreshRequest struct {
+	Token string `json:"token" binding:"required"`
 }
 
-func solveNextHandler(c *gin.Context) {
-	var req SolveNextRequest
+// sessionRefreshHandler reissues a session token with identical claims and a
+// fresh expiry. Tokens are accepted until SessionRefreshGrace past their
+// expiry, so a long game can recover from a lapsed token without losing the
+// givens-hash binding; past the grace window the session is gone for good.
+func sessionRefreshHandler(c *gin.Context) {
+	var req SessionRefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
-	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	// Signature check only: expired tokens are exactly what this endpoint
+	// exists to accept
+	session, err := decodeToken(cfg.JWTSecret, req.Token)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token: " + err.Error()})
+		apiTokenError(c, err)
 		return
 	}
 
-	if len(req.Board) != constants.TotalCells {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("board must have %d cells", constants.TotalCells)})
+	if deviceBlocked(session.DeviceID) {
+		abortBlocked(c)
 		return
 	}
 
-	// Get original givens - either from request or regenerate from session
-	givens := req.Givens
-	if len(givens) != constants.TotalCells {
-		// Regenerate givens from session info
-		loader := puzzles.Global()
-		if loader != nil {
-			givens, _, _, _ = loader.GetPuzzleBySeed(session.Seed, session.Difficulty)
-		}
-		if len(givens) != constants.TotalCells {
-			// Fallback: generate on-demand
-			seedHash := hashSeed(session.Seed)
-			fullGrid := dp.GenerateFullGrid(seedHash)
-			allPuzzles := dp.CarveGivensWithSubset(fullGrid, seedHash)
-			givens = allPuzzles[session.Difficulty]
-		}
+	now := time.Now()
+	if now.After(session.ExpiresAt.Add(cfg.SessionRefreshGrace)) {
+		apiError(c, http.StatusUnauthorized, ErrCodeTokenExpired, "token expired past the refresh grace window; start a new session")
+		return
 	}
 
-	// STEP 1: Check for direct conflicts FIRST (before running solver)
-	// These are immediate rule violations: same digit twice in a row/column/box
-	conflicts := dp.FindConflicts(req.Board)
-	if len(conflicts) > 0 {
-		// Find the first conflict involving a user-entered cell (not a given)
-		for _, conflict := range conflicts {
-			// Determine which cell to remove (prefer removing user entry, not given)
-			var badCell int
-			var otherCell int
+	session.ExpiresAt = now.Add(cfg.SessionTokenExpiry)
+	token, err := createToken(cfg.JWTSecret, *session)
+	if err != nil {
+		log.Printf("ERROR [sessionRefresh]: failed to create token: %v", err)
+		apiError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to create token")
+		return
+	}
 
-			cell1IsGiven := givens[conflict.Cell1] != 0
-			cell2IsGiven := givens[conflict.Cell2] != 0
+	c.JSON(http.StatusOK, SessionRefreshResponse{
+		Token:            token,
+		PuzzleID:         session.PuzzleID,
+		ExpiresAt:        session.ExpiresAt.Format(time.RFC3339),
+		RefreshableUntil: session.ExpiresAt.Add(cfg.SessionRefreshGrace).Format(time.RFC3339),
+	})
+}
 
-			if cell1IsGiven && cell2IsGiven {
-				// Both are givens, this shouldn't happen in a valid puzzle, skip
-				continue
-			} else if cell1IsGiven {
-				// Cell1 is given, remove Cell2
-				badCell = conflict.Cell2
-				otherCell = conflict.Cell1
-			} else if cell2IsGiven {
-				// Cell2 is given, remove Cell1
-				badCell = conflict.Cell1
+type SessionSaveRequest struct {
+	Token          string                `json:"token" binding:"required"`
+	CurrentBoard   []int                 `json:"current_board" binding:"required"`
+	Candidates     [][]int               `json:"candidates"`  // Optional: preserve eliminations
+	Annotations    []core.CellAnnotation `json:"annotations"` // Optional: colors and corner/center marks
+	History        []json.RawMessage     `json:"history"`     // Opaque move history, bounded by MaxSavedHistory
+	ElapsedSeconds int                   `json:"elapsed_seconds"`
+}
+
+// sessionSaveHandler stores the caller's game state server-side, keyed by the
+// session token, so the game can be resumed later via /session/restore. The
+// board is validated against the session's givens before it is accepted.
+func sessionSaveHandler(c *gin.Context) {
+	var req SessionSaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.CurrentBoard) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("current_board must have %d cells", constants.TotalCells))
+		return
+	}
+	for i, v := range req.CurrentBoard {
+		if v < 0 || v > constants.GridSize {
+			apiError(c, http.StatusBadRequest, ErrCodeInvalidCellValue, fmt.Sprintf("current_board cell %d has invalid value %d", i, v))
+			return
+		}
+	}
+	if req.Candidates != nil && len(req.Candidates) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidCandidates, fmt.Sprintf("candidates must have %d cells", constants.TotalCells))
+		return
+	}
+	if err := core.ValidateAnnotations(req.Annotations); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidAnnotations, err.Error())
+		return
+	}
+	if len(req.History) > constants.MaxSavedHistory {
+		apiError(c, http.StatusBadRequest, ErrCodeHistoryTooLarge, fmt.Sprintf("history exceeds %d entries", constants.MaxSavedHistory))
+		return
+	}
+
+	// Regenerate the session's givens and reject boards whose given cells
+	// were altered: a tampered save would poison every later restore
+	var givens []int
+	loader := puzzles.Global()
+	if loader != nil {
+		givens, _, _, _ = loader.GetPuzzleBySeed(session.Seed, session.Difficulty)
+	}
+	if len(givens) != constants.TotalCells {
+		// Fallback: generate on-demand
+		seedHash := hashSeed(session.Seed)
+		fullGrid := generateFullGrid(session.Seed, seedHash)
+		allPuzzles, _ := carveForSeed(fullGrid, seedHash)
+		givens = allPuzzles[session.Difficulty]
+	}
+	for i, g := range givens {
+		if g != 0 && req.CurrentBoard[i] != g {
+			apiError(c, http.StatusBadRequest, ErrCodeGivensModified, fmt.Sprintf("given cell %d was modified", i))
+			return
+		}
+	}
+
+	// A saved game counts as a play; it stays an abandon in the stats until
+	// the session completes
+	puzzleStats.RecordAbandon(session.Seed, session.Difficulty, req.Token)
+
+	savedAt := time.Now()
+	savedGames.Save(req.Token, state.SavedGame{
+		CurrentBoard:   req.CurrentBoard,
+		Candidates:     req.Candidates,
+		Annotations:    req.Annotations,
+		History:        req.History,
+		ElapsedSeconds: req.ElapsedSeconds,
+		SavedAt:        savedAt,
+	})
+
+	c.JSON(http.StatusOK, SessionSaveResponse{
+		SavedAt: savedAt.Format(time.RFC3339),
+	})
+}
+
+// sessionRestoreHandler returns the last state saved for the session token in
+// the query string, or 404 when nothing was saved (or the save expired)
+func sessionRestoreHandler(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		apiError(c, http.StatusBadRequest, ErrCodeTokenRequired, "token query parameter is required")
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	game, ok := savedGames.Load(token)
+	if !ok {
+		apiError(c, http.StatusNotFound, ErrCodeNoSavedState, "no saved state for this session")
+		return
+	}
+
+	c.JSON(http.StatusOK, SessionRestoreResponse{
+		PuzzleID:   session.PuzzleID,
+		Difficulty: session.Difficulty,
+		State:      game,
+	})
+}
+
+type SessionCompleteRequest struct {
+	Token          string `json:"token" binding:"required"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	HintsUsed      struct {
+		Level1 int `json:"level1"`
+		Level2 int `json:"level2"`
+		Level3 int `json:"level3"`
+	} `json:"hints_used"`
+	AutoCandidates bool  `json:"auto_candidates"`
+	FinalBoard     []int `json:"final_board" binding:"required"`
+}
+
+// sessionCompleteHandler scores a finished game. The final board is checked
+// against the puzzle's solution, the score is computed server-side from the
+// scoring constants, and each session can complete only once.
+func sessionCompleteHandler(c *gin.Context) {
+	var req SessionCompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.FinalBoard) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("final_board must have %d cells", constants.TotalCells))
+		return
+	}
+	if req.ElapsedSeconds < 0 || req.HintsUsed.Level1 < 0 || req.HintsUsed.Level2 < 0 || req.HintsUsed.Level3 < 0 {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "elapsed_seconds and hint counts must not be negative")
+		return
+	}
+
+	// Resolve the authoritative solution the same way the solve handlers do:
+	// the sealed token claim first, then the bank, then on-demand generation
+	solution := openSolution(cfg.JWTSecret, session.PuzzleID, session.Solution)
+	if solution == nil {
+		if loader := puzzles.Global(); loader != nil {
+			_, solution, _, _ = loader.GetPuzzleBySeed(session.Seed, session.Difficulty)
+		}
+	}
+	if len(solution) != constants.TotalCells {
+		solution = generateFullGrid(session.Seed, hashSeed(session.Seed))
+	}
+
+	for i, v := range req.FinalBoard {
+		if v == 0 {
+			apiError(c, http.StatusBadRequest, ErrCodeBoardNotSolved, "final board is incomplete")
+			return
+		}
+		if v != solution[i] {
+			apiError(c, http.StatusBadRequest, ErrCodeBoardNotSolved, "final board does not match the solution")
+			return
+		}
+	}
+
+	if !completions.markCompleted(req.Token) {
+		apiError(c, http.StatusConflict, ErrCodeAlreadyCompleted, "this session was already scored")
+		return
+	}
+
+	hints := [3]int{req.HintsUsed.Level1, req.HintsUsed.Level2, req.HintsUsed.Level3}
+	breakdown := constants.CompletionScore(session.Difficulty, req.ElapsedSeconds, hints, req.AutoCandidates)
+	rank := completions.recordScore(session.Seed+constants.PuzzleIDDl+session.Difficulty, breakdown.Total)
+	puzzleStats.RecordCompletion(session.Seed, session.Difficulty, req.Token, req.ElapsedSeconds,
+		hints[0]+hints[1]+hints[2])
+
+	c.JSON(http.StatusOK, SessionCompleteResponse{
+		Score:     breakdown.Total,
+		Breakdown: breakdown,
+		Rank:      rank,
+	})
+}
+
+type SolveNextRequest struct {
+	Token       string                `json:"token" binding:"required"`
+	Board       []int                 `json:"board" binding:"required"`
+	Candidates  [][]int               `json:"candidates"`  // Optional: preserve eliminations
+	Annotations []core.CellAnnotation `json:"annotations"` // Optional: center marks stand in for candidates when those are absent
+	Givens      []int                 `json:"givens"`      // Original puzzle givens (to identify user-entered cells)
+	Lang        string                `json:"lang"`        // Optional: language for move explanations (default English)
+	HintCells   []int                 `json:"hintCells"`   // Optional: cells filled by earlier hints, for the completion stats split
+}
+
+// sessionTransform returns the presentation transform carried by the session
+// token. Tokens are only minted after the spec parses, so an unparseable
+// claim (a token from before a spec change, say) falls back to canonical
+// rather than failing the solve call.
+func sessionTransform(session *SessionToken) core.BoardTransform {
+	t, err := core.ParseTransform(session.Transform)
+	if err != nil {
+		return core.IdentityTransform()
+	}
+	return t
+}
+
+// respondSolveNext is the single exit point for solve responses on
+// transformed sessions: handlers build everything in canonical coordinates
+// and this reorients the coordinate-bearing fields - board, candidate grid,
+// typed move, the legacy map-shaped fix moves, and stale candidate refs -
+// into the session's presented orientation just before writing.
+func respondSolveNext(c *gin.Context, t core.BoardTransform, resp gin.H) {
+	if !t.IsIdentity() {
+		if board, ok := resp["board"].([]int); ok {
+			resp["board"] = t.ApplyCells(board)
+		}
+		if cands, ok := resp["candidates"].([][]int); ok {
+			resp["candidates"] = t.ApplyCandidateGrid(cands)
+		}
+		switch move := resp["move"].(type) {
+		case *core.Move:
+			t.ApplyMove(move)
+		case map[string]interface{}:
+			applyTransformToMapMove(t, move)
+		}
+		if stale, ok := resp["stale_candidates"].([]core.CellRef); ok {
+			for i := range stale {
+				stale[i] = t.ApplyCellRef(stale[i])
+			}
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// applyTransformToMapMove reorients the ad-hoc map moves (fix-error,
+// fix-conflict, candidate-error) that predate the typed core.Move responses.
+// Their coordinate-bearing fields are digit, targets, and the highlight
+// lists; explanation text stays canonical like everywhere else.
+func applyTransformToMapMove(t core.BoardTransform, move map[string]interface{}) {
+	if d, ok := move["digit"].(int); ok {
+		move["digit"] = t.ApplyDigit(d)
+	}
+	if targets, ok := move["targets"].([]map[string]int); ok {
+		applyTransformToRefMaps(t, targets)
+	}
+	if highlights, ok := move["highlights"].(map[string]interface{}); ok {
+		for _, v := range highlights {
+			if refs, ok := v.([]map[string]int); ok {
+				applyTransformToRefMaps(t, refs)
+			}
+		}
+	}
+}
+
+func applyTransformToRefMaps(t core.BoardTransform, refs []map[string]int) {
+	for _, ref := range refs {
+		mapped := t.ApplyCellRef(core.CellRef{Row: ref["row"], Col: ref["col"]})
+		ref["row"], ref["col"] = mapped.Row, mapped.Col
+	}
+}
+
+// boardCompleted reports whether a board is genuinely finished: every cell
+// filled, no rule violations under the session's constraints, and every
+// entry matching the authoritative solution when one is available. Both
+// /solve/next and /solve/all settle completion through this check so the two
+// paths cannot disagree about what "finished" means.
+func boardCompleted(cells, solution []int, cs core.ConstraintSet) bool {
+	for _, v := range cells {
+		if v == 0 {
+			return false
+		}
+	}
+	if solution != nil {
+		for i, v := range cells {
+			if v != solution[i] {
+				return false
+			}
+		}
+	}
+	return dp.IsValidWithConstraints(cells, cs)
+}
+
+// completionStats splits a finished board's non-given cells into the
+// player's own entries and hint placements. The split relies on the client's
+// hint log (hintCells); without one every entry counts as the player's.
+func completionStats(cells, givens, hintCells []int) gin.H {
+	filledByHints := 0
+	hinted := make(map[int]bool, len(hintCells))
+	for _, idx := range hintCells {
+		if idx < 0 || idx >= len(cells) || hinted[idx] {
+			continue
+		}
+		if givens[idx] == 0 && cells[idx] != 0 {
+			hinted[idx] = true
+			filledByHints++
+		}
+	}
+	return gin.H{
+		"filledByUser":  countUserEntries(cells, givens) - filledByHints,
+		"filledByHints": filledByHints,
+	}
+}
+
+func solveNextHandler(c *gin.Context) {
+	start := time.Now()
+	defer func() { metricsRegistry.ObserveSolveNextDuration(time.Since(start)) }()
+
+	var req SolveNextRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+	logSession(c, session)
+
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+	if err := core.ValidateAnnotations(req.Annotations); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidAnnotations, err.Error())
+		return
+	}
+	// Players who pencil with center marks instead of the candidate layer
+	// still get hints that respect their marks
+	if req.Candidates == nil {
+		req.Candidates = core.AnnotationsToCandidates(req.Annotations)
+	}
+
+	// A session started with a presentation transform submits everything in
+	// the presented orientation; map it back to canonical before any solving.
+	// Every response below goes out through respondSolveNext, which applies
+	// the forward transform to the coordinate-bearing fields.
+	transform := sessionTransform(session)
+	if !transform.IsIdentity() {
+		inverse := transform.Invert()
+		req.Board = inverse.ApplyCells(req.Board)
+		req.Candidates = inverse.ApplyCandidateGrid(req.Candidates)
+		if len(req.Givens) == constants.TotalCells {
+			req.Givens = inverse.ApplyCells(req.Givens)
+		}
+		for i, idx := range req.HintCells {
+			if idx >= 0 && idx < constants.TotalCells {
+				ref := inverse.ApplyCellRef(core.CellRef{Row: idx / constants.GridSize, Col: idx % constants.GridSize})
+				req.HintCells[i] = ref.Row*constants.GridSize + ref.Col
+			}
+		}
+	}
+
+	// The request is well-formed, so it counts as hint usage no matter which
+	// branch below answers it — fix-error and conflict verdicts are help too
+	puzzleStats.RecordHint(session.Seed, session.Difficulty, req.Token)
+
+	strategy, ok := solverStrategyFromQuery(c)
+	if !ok {
+		return
+	}
+
+	maxTier, ok := solverMaxTierFromQuery(c)
+	if !ok {
+		return
+	}
+
+	// Get original givens - either from request or regenerate from session
+	givens := req.Givens
+	if len(givens) != constants.TotalCells {
+		// Regenerate givens from session info
+		loader := puzzles.Global()
+		if loader != nil {
+			givens, _, _, _ = loader.GetPuzzleBySeed(session.Seed, session.Difficulty)
+		}
+		if len(givens) != constants.TotalCells {
+			// Fallback: generate on-demand
+			seedHash := hashSeed(session.Seed)
+			fullGrid := generateFullGrid(session.Seed, seedHash)
+			allPuzzles, _ := carveForSeed(fullGrid, seedHash)
+			givens = allPuzzles[session.Difficulty]
+		}
+	}
+
+	// STEP -1: A board whose givens admit no solution (typically imported
+	// with a mis-transcribed given) gets a structured verdict instead of a
+	// technique that would march into a contradiction. Cached per token
+	if !checkGivensSolvable(c, req.Token, givens) {
+		return
+	}
+
+	// STEP 0: A fully filled board never yields a technique move, so settle
+	// completion up front. With an authoritative solution in hand the diff
+	// points at the entry that is actually wrong, which beats the conflict
+	// scan below blaming one cell of a conflicting pair
+	boardFull := true
+	for _, v := range req.Board {
+		if v == 0 {
+			boardFull = false
+			break
+		}
+	}
+	if boardFull {
+		solution := sessionSolution(session, givens)
+		if solution != nil {
+			if badCell := firstIncorrectEntry(req.Board, givens, solution); badCell >= 0 {
+				badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+				badDigit := req.Board[badCell]
+
+				fixedBoard := make([]int, len(req.Board))
+				copy(fixedBoard, req.Board)
+				fixedBoard[badCell] = 0
+
+				newBoard := human.NewBoardWithCandidatesAndConstraints(fixedBoard, nil, session.Constraints)
+
+				metricsRegistry.IncFixErrorMove()
+				logTechnique(c, "fix-error")
+				respondSolveNext(c, transform, gin.H{
+					"board":      newBoard.GetCells(),
+					"candidates": newBoard.GetCandidates(),
+					"move": map[string]interface{}{
+						"technique":      "fix-error",
+						"action":         "fix-error",
+						"digit":          badDigit,
+						"explanation":    fmt.Sprintf("The board is full, but the %d at R%dC%d doesn't match the solution. Removing it.", badDigit, badRow+1, badCol+1),
+						"targets":        []map[string]int{{"row": badRow, "col": badCol}},
+						"userEntryCount": countUserEntries(req.Board, givens),
+						"highlights": map[string]interface{}{
+							"primary": []map[string]int{{"row": badRow, "col": badCol}},
+						},
+					},
+				})
+				return
+			}
+		}
+		if boardCompleted(req.Board, solution, session.Constraints) {
+			// Every entry matches the solution - or, without an authoritative
+			// solution, the board is full and violation-free, which for a
+			// classic puzzle is the solution by definition
+			fullBoard := human.NewBoardWithCandidatesAndConstraints(req.Board, nil, session.Constraints)
+			logTechnique(c, "solved")
+			respondSolveNext(c, transform, gin.H{
+				"board":      fullBoard.GetCells(),
+				"candidates": fullBoard.GetCandidates(),
+				"solved":     true,
+				"stats":      completionStats(req.Board, givens, req.HintCells),
+				"move": map[string]interface{}{
+					"technique":   "solved",
+					"action":      "done",
+					"explanation": "The puzzle is solved!",
+				},
+			})
+			return
+		}
+		// Full, conflicting, and no solution to diff against: fall through to
+		// the conflict handling below
+	}
+
+	// STEP 1: Check for direct conflicts FIRST (before running solver)
+	// These are immediate rule violations: same digit twice in a row/column/box
+	conflicts := dp.FindConflicts(req.Board)
+	if len(conflicts) > 0 {
+		// Find the first conflict involving a user-entered cell (not a given)
+		for _, conflict := range conflicts {
+			// Determine which cell to remove (prefer removing user entry, not given)
+			var badCell int
+			var otherCell int
+
+			cell1IsGiven := givens[conflict.Cell1] != 0
+			cell2IsGiven := givens[conflict.Cell2] != 0
+
+			if cell1IsGiven && cell2IsGiven {
+				// Both are givens, this shouldn't happen in a valid puzzle, skip
+				continue
+			} else if cell1IsGiven {
+				// Cell1 is given, remove Cell2
+				badCell = conflict.Cell2
+				otherCell = conflict.Cell1
+			} else if cell2IsGiven {
+				// Cell2 is given, remove Cell1
+				badCell = conflict.Cell1
 				otherCell = conflict.Cell2
 			} else {
 				// Both are user entries - remove the one with higher index (more recently placed, typically)
@@ -577,9 +1670,10 @@ func solveNextHandler(c *gin.Context) {
 			}
 
 			// Reset the board to the fixed state
-			newBoard := human.NewBoardWithCandidates(fixedBoard, fixedCandidates)
+			newBoard := human.NewBoardWithCandidatesAndConstraints(fixedBoard, fixedCandidates, session.Constraints)
 
-			c.JSON(http.StatusOK, gin.H{
+			logTechnique(c, "fix-conflict")
+			respondSolveNext(c, transform, gin.H{
 				"board":      newBoard.GetCells(),
 				"candidates": newBoard.GetCandidates(),
 				"move": map[string]interface{}{
@@ -598,25 +1692,86 @@ func solveNextHandler(c *gin.Context) {
 		}
 	}
 
+	// STEP 1b: The player's pencil marks are trusted from here on, so catch a
+	// mark set that wrongly ruled out a cell's true digit before a technique
+	// builds a hint on top of it (mirrors the fix-error flow for wrong digits)
+	if solution := sessionSolution(session, givens); solution != nil {
+		if badCell := firstCandidateError(req.Board, req.Candidates, solution); badCell >= 0 {
+			badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+			digit := solution[badCell]
+
+			// Preserve user's candidates but restore the missing digit
+			fixedCandidates := make([][]int, constants.TotalCells)
+			for i := 0; i < constants.TotalCells; i++ {
+				if i < len(req.Candidates) && req.Candidates[i] != nil {
+					fixedCandidates[i] = make([]int, len(req.Candidates[i]))
+					copy(fixedCandidates[i], req.Candidates[i])
+				}
+			}
+			fixedCandidates[badCell] = append(fixedCandidates[badCell], digit)
+
+			newBoard := human.NewBoardWithCandidatesAndConstraints(req.Board, fixedCandidates, session.Constraints)
+
+			logTechnique(c, "candidate-error")
+			respondSolveNext(c, transform, gin.H{
+				"board":      newBoard.GetCells(),
+				"candidates": newBoard.GetCandidates(),
+				"move": map[string]interface{}{
+					"technique":   "candidate-error",
+					"action":      "candidate-error",
+					"digit":       digit,
+					"explanation": fmt.Sprintf("Check your pencil marks! R%dC%d can still be %d. Adding it back.", badRow+1, badCol+1, digit),
+					"targets":     []map[string]int{{"row": badRow, "col": badCol}},
+					"highlights": map[string]interface{}{
+						"primary": []map[string]int{{"row": badRow, "col": badCol}},
+					},
+				},
+			})
+			return
+		}
+	}
+
 	// STEP 2: No direct conflicts - proceed with normal solver
 	// Use provided candidates (may be empty/incomplete - solver will fill one at a time)
-	board := human.NewBoardWithCandidates(req.Board, req.Candidates)
-	solver := human.NewSolver()
+	board := human.NewBoardWithCandidatesAndConstraints(req.Board, req.Candidates, session.Constraints)
+	board.AssumeUnique = !session.NonUnique
+	// Flag submitted marks tighter than the visible board justifies before
+	// the solver touches them; hints still run, the client just gets told
+	// which cells look stale
+	staleCandidates := board.ValidateCandidatesAgainstPeers()
+	solver := newSolveSolver(strategy, maxTier)
 	move := solver.FindNextMove(board)
 
 	if move == nil {
-		c.JSON(http.StatusOK, gin.H{"move": nil})
+		metricsRegistry.IncSolverOutcome(constants.StatusStalled)
+		logAttrs(c, slog.String("outcome", constants.StatusStalled))
+		respondSolveNext(c, transform, gin.H{"move": nil})
 		return
 	}
 
 	// STEP 3: Handle contradiction - try to find and fix user error
 	if move.Action == "contradiction" {
+		metricsRegistry.IncSolverOutcome(constants.ActionContradiction)
+		logAttrs(c, slog.String("outcome", constants.ActionContradiction))
+
+		// Rule the player out before hunting for a cell to delete: a
+		// contradiction on a board whose entries are all correct is our bug,
+		// and deleting cells would only mask it
+		if contradictionIsSolverBug(req.Board, givens, sessionSolution(session, givens)) {
+			respondSolveNext(c, transform, gin.H{
+				"board":      board.GetCells(),
+				"candidates": board.GetCandidates(),
+				"move":       solverErrorMove(c, board.GetCells(), move, nil),
+			})
+			return
+		}
+
 		// Find the contradiction cell (first target in the move)
 		if len(move.Targets) > 0 {
 			contradictionCell := move.Targets[0].Row*constants.GridSize + move.Targets[0].Col
 
 			// Analyze which user-entered cell is causing this
-			badCell, badDigit := findBlockingUserCell(board, contradictionCell, req.Board, givens)
+			badCell, badDigit := human.FindBlockingUserCell(board, contradictionCell, req.Board, givens, sessionSolution(session, givens))
 
 			if badCell >= 0 {
 				badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
@@ -638,9 +1793,11 @@ func solveNextHandler(c *gin.Context) {
 				}
 
 				// Reset the board to the fixed state, preserving user's candidates
-				newBoard := human.NewBoardWithCandidates(fixedBoard, fixedCandidates)
+				newBoard := human.NewBoardWithCandidatesAndConstraints(fixedBoard, fixedCandidates, session.Constraints)
 
-				c.JSON(http.StatusOK, gin.H{
+				metricsRegistry.IncFixErrorMove()
+				logTechnique(c, "fix-error")
+				respondSolveNext(c, transform, gin.H{
 					"board":      newBoard.GetCells(),
 					"candidates": newBoard.GetCandidates(),
 					"move": map[string]interface{}{
@@ -659,14 +1816,57 @@ func solveNextHandler(c *gin.Context) {
 			}
 		}
 
-		// Direct analysis failed - try candidate refill diagnostic
-		badCell, badDigit, zeroCandCell := findErrorByCandidateRefill(req.Board, givens)
-
-		if badCell >= 0 {
-			badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
-			zeroCandRow, zeroCandCol := zeroCandCell/constants.GridSize, zeroCandCell%constants.GridSize
+		// Direct analysis failed - check entries against the authoritative
+		// solution before falling back to the slower refill diagnostic
+		if solution := sessionSolution(session, givens); solution != nil {
+			if badCell := firstIncorrectEntry(req.Board, givens, solution); badCell >= 0 {
+				badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+				badDigit := req.Board[badCell]
 
-			// Create a new board without the bad cell
+				fixedBoard := make([]int, len(req.Board))
+				copy(fixedBoard, req.Board)
+				fixedBoard[badCell] = 0
+
+				fixedCandidates := make([][]int, constants.TotalCells)
+				for i := 0; i < constants.TotalCells; i++ {
+					if i == badCell {
+						fixedCandidates[i] = nil // Clear candidates for the fixed cell
+					} else if i < len(req.Candidates) && req.Candidates[i] != nil {
+						fixedCandidates[i] = make([]int, len(req.Candidates[i]))
+						copy(fixedCandidates[i], req.Candidates[i])
+					}
+				}
+
+				newBoard := human.NewBoardWithCandidatesAndConstraints(fixedBoard, fixedCandidates, session.Constraints)
+
+				metricsRegistry.IncFixErrorMove()
+				logTechnique(c, "fix-error")
+				respondSolveNext(c, transform, gin.H{
+					"board":      newBoard.GetCells(),
+					"candidates": newBoard.GetCandidates(),
+					"move": map[string]interface{}{
+						"technique":   "fix-error",
+						"action":      "fix-error",
+						"digit":       badDigit,
+						"explanation": fmt.Sprintf("Found it! The %d at R%dC%d doesn't match the solution. Removing it.", badDigit, badRow+1, badCol+1),
+						"targets":     []map[string]int{{"row": badRow, "col": badCol}},
+						"highlights": map[string]interface{}{
+							"primary": []map[string]int{{"row": badRow, "col": badCol}},
+						},
+					},
+				})
+				return
+			}
+		}
+
+		// Try candidate refill diagnostic
+		badCell, badDigit, zeroCandCell := findErrorByCandidateRefill(req.Board, givens, session.Constraints)
+
+		if badCell >= 0 {
+			badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+			zeroCandRow, zeroCandCol := zeroCandCell/constants.GridSize, zeroCandCell%constants.GridSize
+
+			// Create a new board without the bad cell
 			fixedBoard := make([]int, len(req.Board))
 			copy(fixedBoard, req.Board)
 			fixedBoard[badCell] = 0
@@ -684,9 +1884,11 @@ func solveNextHandler(c *gin.Context) {
 			}
 
 			// Reset the board to the fixed state, preserving user's candidates
-			newBoard := human.NewBoardWithCandidates(fixedBoard, fixedCandidates)
+			newBoard := human.NewBoardWithCandidatesAndConstraints(fixedBoard, fixedCandidates, session.Constraints)
 
-			c.JSON(http.StatusOK, gin.H{
+			metricsRegistry.IncFixErrorMove()
+			logTechnique(c, "fix-error")
+			respondSolveNext(c, transform, gin.H{
 				"board":      newBoard.GetCells(),
 				"candidates": newBoard.GetCandidates(),
 				"move": map[string]interface{}{
@@ -707,7 +1909,8 @@ func solveNextHandler(c *gin.Context) {
 		// Both methods failed - return unpinpointable error
 		userEntryCount := countUserEntries(req.Board, givens)
 
-		c.JSON(http.StatusOK, gin.H{
+		logTechnique(c, "unpinpointable-error")
+		respondSolveNext(c, transform, gin.H{
 			"board":      board.GetCells(),
 			"candidates": board.GetCandidates(),
 			"move": map[string]interface{}{
@@ -720,14 +1923,30 @@ func solveNextHandler(c *gin.Context) {
 		return
 	}
 
-	// Apply the move for normal cases
-	solver.ApplyMove(board, move)
+	// Apply the move for normal cases. A move that fails validation is a
+	// solver bug; report it instead of returning a corrupted board.
+	if _, err := solver.ApplyMove(board, move); err != nil {
+		log.Printf("ERROR [solveNext]: solver produced an invalid move (%s): %v", move.Technique, err)
+		logTechnique(c, move.Technique)
+		apiError(c, http.StatusInternalServerError, ErrCodeInvalidMove, "solver produced an invalid move: "+err.Error())
+		return
+	}
+	if board.IsSolved() {
+		metricsRegistry.IncSolverOutcome(constants.StatusCompleted)
+		logAttrs(c, slog.String("outcome", constants.StatusCompleted))
+	}
 
-	c.JSON(http.StatusOK, gin.H{
+	logTechnique(c, move.Technique)
+	move.LocalizeExplanation(req.Lang)
+	response := gin.H{
 		"board":      board.GetCells(),
 		"candidates": board.GetCandidates(),
 		"move":       move,
-	})
+	}
+	if len(staleCandidates) > 0 {
+		response["stale_candidates"] = staleCandidates
+	}
+	respondSolveNext(c, transform, response)
 }
 
 type SolveAllRequest struct {
@@ -735,100 +1954,7 @@ type SolveAllRequest struct {
 	Board      []int   `json:"board" binding:"required"`
 	Candidates [][]int `json:"candidates"`
 	Givens     []int   `json:"givens"` // Original puzzle givens (to identify user-entered cells)
-}
-
-// findBlockingUserCell analyzes a contradiction and identifies which user-entered cell is causing it
-//
-// Parameters:
-//
-//	board: Current solver board state with all candidates
-//	contradictionCell: Cell index that has no valid candidates (0-80)
-//	originalUserBoard: Board state when solve was called (distinguishes user entries from solver placements)
-//	givens: Original puzzle (distinguishes user entries from given clues)
-//
-// Returns: Cell index and blocking digit, or (-1, 0) if no user error found
-//
-// Strategy: For each digit 1-9, find what's blocking it from the contradiction cell.
-// Only user-entered cells (not givens, not solver placements) are considered.
-// The cell blocking the most candidates is identified as most likely wrong.
-func findBlockingUserCell(board *human.Board, contradictionCell int, originalUserBoard []int, givens []int) (int, int) {
-	row, col := contradictionCell/constants.GridSize, contradictionCell%constants.GridSize
-	boxRow, boxCol := (row/constants.BoxSize)*constants.BoxSize, (col/constants.BoxSize)*constants.BoxSize
-
-	// For each digit, find what's blocking it from this cell
-	// Only consider cells that were user-entered BEFORE solve was called (not solver placements)
-	type blockingCell struct {
-		idx   int
-		digit int
-	}
-	var userBlockers []blockingCell
-
-	for digit := 1; digit <= constants.GridSize; digit++ {
-		// Check row for this digit
-		for c := 0; c < constants.GridSize; c++ {
-			idx := row*constants.GridSize + c
-			if board.Cells[idx] == digit {
-				// This cell blocks 'digit' from contradiction cell
-				// Only consider if: was in original user board AND not a given
-				if originalUserBoard[idx] != 0 && givens[idx] == 0 {
-					userBlockers = append(userBlockers, blockingCell{idx, digit})
-				}
-				break
-			}
-		}
-
-		// Check column for this digit
-		for r := 0; r < constants.GridSize; r++ {
-			idx := r*constants.GridSize + col
-			if board.Cells[idx] == digit {
-				if originalUserBoard[idx] != 0 && givens[idx] == 0 {
-					userBlockers = append(userBlockers, blockingCell{idx, digit})
-				}
-				break
-			}
-		}
-
-		// Check box for this digit
-		for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-			for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-				idx := r*constants.GridSize + c
-				if board.Cells[idx] == digit {
-					if originalUserBoard[idx] != 0 && givens[idx] == 0 {
-						userBlockers = append(userBlockers, blockingCell{idx, digit})
-					}
-					break
-				}
-			}
-		}
-	}
-
-	if len(userBlockers) == 0 {
-		return -1, 0
-	}
-
-	// Count how many times each user cell appears as a blocker
-	// The cell blocking the most candidates is most likely wrong
-	cellCount := make(map[int]int)
-	cellDigit := make(map[int]int)
-	for _, b := range userBlockers {
-		cellCount[b.idx]++
-		cellDigit[b.idx] = b.digit
-	}
-
-	// Find cell with highest count
-	maxCount := 0
-	maxCell := -1
-	for idx, count := range cellCount {
-		if count > maxCount {
-			maxCount = count
-			maxCell = idx
-		}
-	}
-
-	if maxCell >= 0 {
-		return maxCell, cellDigit[maxCell]
-	}
-	return -1, 0
+	Lang       string  `json:"lang"`   // Optional: language for move explanations (default English)
 }
 
 // findErrorByCandidateRefill uses "clear and recalculate" strategy to find user errors
@@ -840,6 +1966,7 @@ func findBlockingUserCell(board *human.Board, contradictionCell int, originalUse
 //
 //	originalUserBoard: Current board state (81 cells, 0 = empty)
 //	givens: Original puzzle clues (distinguishes user entries from givens)
+//	cs: Active variant constraints (candidates and blockers respect them)
 //
 // Returns: (badCell, badDigit, zeroCandidateCell) or (-1, 0, -1) if no error found
 //
@@ -848,10 +1975,10 @@ func findBlockingUserCell(board *human.Board, contradictionCell int, originalUse
 // 2. Find first cell with zero candidates (indicates unsolvable state)
 // 3. For digits 1-9, find which user-entered cells block them from that cell
 // 4. Return first blocking user cell found
-func findErrorByCandidateRefill(originalUserBoard []int, givens []int) (int, int, int) {
+func findErrorByCandidateRefill(originalUserBoard []int, givens []int, cs core.ConstraintSet) (int, int, int) {
 	// Create a fresh board with candidates properly initialized
-	// Use NewBoard which auto-fills candidates based on current cell values
-	freshBoard := human.NewBoard(originalUserBoard)
+	// Use NewBoardWithConstraints which auto-fills candidates based on current cell values
+	freshBoard := human.NewBoardWithConstraints(originalUserBoard, cs)
 
 	// Find any cell with zero candidates
 	for idx := 0; idx < constants.TotalCells; idx++ {
@@ -864,9 +1991,10 @@ func findErrorByCandidateRefill(originalUserBoard []int, givens []int) (int, int
 			// Found a cell with no candidates - this points to an error
 			// Find which user-entered cell is blocking all candidates
 			row, col := idx/constants.GridSize, idx%constants.GridSize
-			boxRow, boxCol := (row/constants.BoxSize)*constants.BoxSize, (col/constants.BoxSize)*constants.BoxSize
 
-			// For each digit, find what's blocking it
+			// For each digit, find what's blocking it. A blocker is any
+			// user-entered peer (classic or variant) holding the digit, or
+			// an orthogonal neighbor within 1 when non-consecutive is active
 			type blocker struct {
 				cellIdx int
 				digit   int
@@ -874,25 +2002,25 @@ func findErrorByCandidateRefill(originalUserBoard []int, givens []int) (int, int
 			var userBlockers []blocker
 
 			for digit := 1; digit <= constants.GridSize; digit++ {
-				// Check row
-				for c := 0; c < constants.GridSize; c++ {
-					cellIdx := row*constants.GridSize + c
-					if originalUserBoard[cellIdx] == digit && givens[cellIdx] == 0 {
-						userBlockers = append(userBlockers, blocker{cellIdx, digit})
+				for cellIdx := 0; cellIdx < constants.TotalCells; cellIdx++ {
+					entry := originalUserBoard[cellIdx]
+					if entry == 0 || givens[cellIdx] != 0 {
+						continue
 					}
-				}
-				// Check column
-				for r := 0; r < constants.GridSize; r++ {
-					cellIdx := r*constants.GridSize + col
-					if originalUserBoard[cellIdx] == digit && givens[cellIdx] == 0 {
+					if entry == digit && human.ArePeersWithConstraints(cellIdx, idx, cs) {
 						userBlockers = append(userBlockers, blocker{cellIdx, digit})
+						continue
 					}
-				}
-				// Check box
-				for r := boxRow; r < boxRow+constants.BoxSize; r++ {
-					for c := boxCol; c < boxCol+constants.BoxSize; c++ {
-						cellIdx := r*constants.GridSize + c
-						if originalUserBoard[cellIdx] == digit && givens[cellIdx] == 0 {
+					if cs.NonConsecutive && (entry == digit-1 || entry == digit+1) {
+						r, c := cellIdx/constants.GridSize, cellIdx%constants.GridSize
+						dr, dc := r-row, c-col
+						if dr < 0 {
+							dr = -dr
+						}
+						if dc < 0 {
+							dc = -dc
+						}
+						if dr+dc == 1 {
 							userBlockers = append(userBlockers, blocker{cellIdx, digit})
 						}
 					}
@@ -928,6 +2056,190 @@ func countUserEntries(board []int, givens []int) int {
 	return count
 }
 
+// solveGivens re-derives a puzzle's solution with the backtracking solver. It
+// is a package variable so tests can observe when handlers fall back to it.
+var solveGivens = dp.Solve
+
+// sessionSolution returns the authoritative solution for a session,
+// preferring the sealed copy carried by newer tokens and falling back to
+// re-solving the givens for classic tokens issued before the solution claim
+// existed. Returns nil when no solution can be derived, and always for
+// non-unique custom sessions: a multi-solution puzzle has no authoritative
+// solution to check entries against.
+func sessionSolution(session *SessionToken, givens []int) []int {
+	if session.NonUnique {
+		return nil
+	}
+	if solution := openSolution(cfg.JWTSecret, session.PuzzleID, session.Solution); solution != nil {
+		return solution
+	}
+	// The backtracking fallback is classic-only: variant constraints change
+	// the solution
+	if !session.Constraints.IsClassic() {
+		return nil
+	}
+	if len(givens) == constants.TotalCells {
+		return solveGivens(givens)
+	}
+	return nil
+}
+
+// firstCandidateError returns the earliest empty cell whose user-supplied
+// pencil marks are non-empty but exclude the cell's solution digit, or -1.
+// Cells with no marks are skipped: the solver fills those itself.
+func firstCandidateError(board []int, candidates [][]int, solution []int) int {
+	for i := 0; i < len(board) && i < len(candidates); i++ {
+		if board[i] != 0 || len(candidates[i]) == 0 {
+			continue
+		}
+		found := false
+		for _, d := range candidates[i] {
+			if d == solution[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstIncorrectEntry returns the first user-entered cell whose digit
+// disagrees with the solution, or -1 if every entry matches it.
+func firstIncorrectEntry(board, givens, solution []int) int {
+	for i, v := range board {
+		if v != 0 && givens[i] == 0 && v != solution[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// contradictionIsSolverBug classifies a contradiction: if the player has no
+// entries at all, or every entry matches the authoritative solution, the
+// solver reasoned itself into a dead end and the bug is ours - there is no
+// user cell worth deleting. With entries but no known solution the call
+// returns false, since the player can't be ruled out.
+func contradictionIsSolverBug(userBoard, givens, solution []int) bool {
+	if countUserEntries(userBoard, givens) == 0 {
+		return true
+	}
+	if solution == nil {
+		return false
+	}
+	return firstIncorrectEntry(userBoard, givens, solution) < 0
+}
+
+// solverErrorHistoryLen is how many preceding moves a solver-error move
+// carries so bug reports show what led up to the contradiction
+const solverErrorHistoryLen = 5
+
+// solverErrorFingerprint hashes the board snapshot and failing technique into
+// a short stable ID so repeated reports of the same solver bug deduplicate
+func solverErrorFingerprint(board []int, technique string) string {
+	h := sha256.New()
+	for _, v := range board {
+		h.Write([]byte{byte(v)})
+	}
+	h.Write([]byte(technique))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// solverErrorMove builds the move payload for a contradiction classified as a
+// solver bug and escalates the request's log entry to Error, attaching the
+// encoded board state so the report can be replayed. recent holds the move
+// payloads applied so far (nil for single-move endpoints); the payload keeps
+// the last solverErrorHistoryLen of them.
+func solverErrorMove(c *gin.Context, board []int, move *core.Move, recent []interface{}) map[string]interface{} {
+	if len(recent) > solverErrorHistoryLen {
+		recent = recent[len(recent)-solverErrorHistoryLen:]
+	}
+	if recent == nil {
+		recent = []interface{}{}
+	}
+
+	fingerprint := solverErrorFingerprint(board, move.Technique)
+	encoded, err := core.EncodeState(board, nil)
+	if err != nil {
+		encoded = ""
+	}
+	logSolverError(c, encoded,
+		slog.String("technique", move.Technique),
+		slog.String("fingerprint", fingerprint),
+	)
+
+	return map[string]interface{}{
+		"technique":   "solver-error",
+		"action":      "solver-error",
+		"explanation": "That one's on us - the solver hit a dead end even though your entries are all correct. Reporting this puzzle helps us fix it.",
+		"board":       board,
+		"recentMoves": recent,
+		"fingerprint": fingerprint,
+	}
+}
+
+// MoveResult represents a single move snapshot returned to clients. In the
+// default format Board and Candidates carry the full state after the move;
+// in delta format both are omitted and Diff carries the changes instead.
+type MoveResult struct {
+	Board      []int          `json:"board,omitempty"`
+	Candidates [][]int        `json:"candidates,omitempty"`
+	Move       interface{}    `json:"move"`
+	Diff       *core.MoveDiff `json:"diff,omitempty"`
+}
+
+// solveFormatFromQuery parses the optional format query parameter on
+// /solve/all. The delta format replaces per-move board snapshots with diffs.
+func solveFormatFromQuery(c *gin.Context) (delta bool, ok bool) {
+	switch c.Query("format") {
+	case "", "full":
+		return false, true
+	case "delta":
+		return true, true
+	default:
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidFormat,
+			fmt.Sprintf("unsupported format '%s'. Use 'full' or 'delta'", c.Query("format")))
+		return false, false
+	}
+}
+
+// deltaMoves rewrites full per-move snapshots as diffs against the previous
+// state, dropping the board and candidates arrays
+func deltaMoves(initialCells []int, initialCands [][]int, moves []MoveResult) []MoveResult {
+	prevCells, prevCands := initialCells, initialCands
+	out := make([]MoveResult, len(moves))
+	for i, mr := range moves {
+		diff := human.DiffStates(prevCells, prevCands, mr.Board, mr.Candidates)
+		out[i] = MoveResult{Move: mr.Move, Diff: &diff}
+		prevCells, prevCands = mr.Board, mr.Candidates
+	}
+	return out
+}
+
+// solveAllResponse assembles the solve/all response body. The delta format
+// sends the starting state once plus per-move diffs instead of a full board
+// and candidate snapshot per move, which cuts the payload by an order of
+// magnitude on long solves.
+func solveAllResponse(delta bool, initialCells []int, initialCands [][]int, moves []MoveResult, solved bool, finalBoard []int) gin.H {
+	if !delta {
+		return gin.H{
+			"moves":      moves,
+			"solved":     solved,
+			"finalBoard": finalBoard,
+		}
+	}
+	return gin.H{
+		"format":            "delta",
+		"initialBoard":      initialCells,
+		"initialCandidates": initialCands,
+		"moves":             deltaMoves(initialCells, initialCands, moves),
+		"solved":            solved,
+		"finalBoard":        finalBoard,
+	}
+}
+
 // solveAllHandler automatically solves a puzzle with error detection and correction
 //
 // This endpoint runs the human solver in a loop, finding and applying moves until:
@@ -951,21 +2263,55 @@ func countUserEntries(board []int, givens []int) int {
 //	moves: Array of move snapshots (board, candidates, move description)
 //	solved: Boolean indicating if puzzle was completely solved
 //	finalBoard: Final board state (81 cells)
+//
+// With ?format=delta each move carries a diff of what changed instead of the
+// full board and candidates arrays, and the response additionally includes
+// initialBoard and initialCandidates so clients can replay the moves.
+//
+// With ?cursor=0&limit=25 the response is paged: moves carries at most limit
+// entries, hasMore reports whether more follow, and nextCursor is a signed
+// continuation token. Resuming re-POSTs the same body with the new cursor;
+// the solver deterministically replays up to the page, so error fixes and
+// diagnostics page through like any other move.
 func solveAllHandler(c *gin.Context) {
 	var req SolveAllRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
+	solveStart := time.Now()
+	timeBudget := solveTimeBudget()
+
 	session, err := verifyToken(cfg.JWTSecret, req.Token)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token: " + err.Error()})
+		apiTokenError(c, err)
 		return
 	}
+	logSession(c, session)
 
 	if len(req.Board) != constants.TotalCells {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("board must have %d cells", constants.TotalCells)})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+
+	strategy, ok := solverStrategyFromQuery(c)
+	if !ok {
+		return
+	}
+
+	maxTier, ok := solverMaxTierFromQuery(c)
+	if !ok {
+		return
+	}
+
+	deltaFormat, ok := solveFormatFromQuery(c)
+	if !ok {
+		return
+	}
+
+	paging, ok := solvePagingFromQuery(c, cfg.JWTSecret, hashSolution(req.Board))
+	if !ok {
 		return
 	}
 
@@ -980,17 +2326,16 @@ func solveAllHandler(c *gin.Context) {
 		if len(givens) != constants.TotalCells {
 			// Fallback: generate on-demand
 			seedHash := hashSeed(session.Seed)
-			fullGrid := dp.GenerateFullGrid(seedHash)
-			allPuzzles := dp.CarveGivensWithSubset(fullGrid, seedHash)
+			fullGrid := generateFullGrid(session.Seed, seedHash)
+			allPuzzles, _ := carveForSeed(fullGrid, seedHash)
 			givens = allPuzzles[session.Difficulty]
 		}
 	}
 
-	// MoveResult represents a single move result snapshot returned to clients
-	type MoveResult struct {
-		Board      []int       `json:"board"`
-		Candidates [][]int     `json:"candidates"`
-		Move       interface{} `json:"move"`
+	// STEP 0: Givens that admit no solution get the structured unsolvable
+	// verdict up front, same as /solve/next. Cached per token
+	if !checkGivensSolvable(c, req.Token, givens) {
+		return
 	}
 
 	// STEP 1: Check for direct conflicts FIRST (before running solver)
@@ -1059,7 +2404,7 @@ func solveAllHandler(c *gin.Context) {
 			}
 
 			// Reset the board to the fixed state
-			newBoard := human.NewBoardWithCandidates(fixedBoard, fixedCandidates)
+			newBoard := human.NewBoardWithCandidatesAndConstraints(fixedBoard, fixedCandidates, session.Constraints)
 
 			// Instead of returning immediately, append this fix as the first move
 			// and continue with autosolving from the fixed board
@@ -1093,21 +2438,32 @@ func solveAllHandler(c *gin.Context) {
 				}
 			}
 
-			board := human.NewBoardWithCandidates(originalUserBoard, nil)
+			// Rebuild from the corrected state, keeping the user's pencil
+			// marks; InitCandidates only fills the cells they didn't pencil
+			board := human.NewBoardWithCandidatesAndConstraints(originalUserBoard, originalUserCandidates, session.Constraints)
+			board.AssumeUnique = !session.NonUnique
 			board.InitCandidates()
-			solver := human.NewSolver()
+			solver := newSolveSolver(strategy, maxTier)
 
 			// Run autosolve loop (similar to STEP 2 logic)
-			maxMoves := 2000
+			maxMoves := solveMoveBudget()
 			maxFixes := 5
 			fixCount := 1 // we already applied one fix
 
 			for i := 0; i < maxMoves; i++ {
+				if time.Since(solveStart) > timeBudget {
+					apiErrorDetails(c, http.StatusRequestTimeout, ErrCodeTimeBudgetExceeded, "solver time budget exceeded", gin.H{"moves_completed": len(moves)})
+					return
+				}
+				if paging.Enabled && len(moves) >= paging.moveCap() {
+					break
+				}
 				if board.IsSolved() {
 					break
 				}
 				move := solver.FindNextMove(board)
 				if move == nil {
+					metricsRegistry.IncSolverOutcome(constants.StatusStalled)
 					userEntryCount := countUserEntries(originalUserBoard, givens)
 					if userEntryCount > 0 {
 						moves = append(moves, MoveResult{
@@ -1125,6 +2481,22 @@ func solveAllHandler(c *gin.Context) {
 				}
 
 				if move.Action == "contradiction" {
+					metricsRegistry.IncSolverOutcome(constants.ActionContradiction)
+
+					// All-correct entries mean the solver broke, not the player
+					if contradictionIsSolverBug(originalUserBoard, givens, sessionSolution(session, givens)) {
+						recent := make([]interface{}, 0, len(moves))
+						for _, mr := range moves {
+							recent = append(recent, mr.Move)
+						}
+						moves = append(moves, MoveResult{
+							Board:      board.GetCells(),
+							Candidates: board.GetCandidates(),
+							Move:       solverErrorMove(c, board.GetCells(), move, recent),
+						})
+						break
+					}
+
 					if fixCount >= maxFixes {
 						userEntryCount := countUserEntries(originalUserBoard, givens)
 						moves = append(moves, MoveResult{
@@ -1142,12 +2514,17 @@ func solveAllHandler(c *gin.Context) {
 
 					if len(move.Targets) > 0 {
 						contradictionCell := move.Targets[0].Row*constants.GridSize + move.Targets[0].Col
-						badCell, badDigit := findBlockingUserCell(board, contradictionCell, originalUserBoard, givens)
+						badCell, badDigit := human.FindBlockingUserCell(board, contradictionCell, originalUserBoard, givens, sessionSolution(session, givens))
 						if badCell >= 0 {
 							badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
 							fixCount++
+							metricsRegistry.IncFixErrorMove()
 							originalUserBoard[badCell] = 0
-							board = human.NewBoardWithCandidates(originalUserBoard, nil)
+							// Reset to the corrected user state. The cleared
+							// cell's marks go with it; everyone else's survive
+							originalUserCandidates[badCell] = nil
+							board = human.NewBoardWithCandidatesAndConstraints(originalUserBoard, originalUserCandidates, session.Constraints)
+							board.AssumeUnique = !session.NonUnique
 							board.InitCandidates()
 							moves = append(moves, MoveResult{
 								Board:      board.GetCells(),
@@ -1168,6 +2545,34 @@ func solveAllHandler(c *gin.Context) {
 						}
 					}
 
+					// Check entries against the authoritative solution before
+					// the slower refill diagnostic
+					if solution := sessionSolution(session, givens); solution != nil {
+						if badCell := firstIncorrectEntry(originalUserBoard, givens, solution); badCell >= 0 {
+							badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+							badDigit := originalUserBoard[badCell]
+							fixCount++
+							metricsRegistry.IncFixErrorMove()
+							originalUserBoard[badCell] = 0
+							board.ClearCell(badCell)
+							moves = append(moves, MoveResult{
+								Board:      board.GetCells(),
+								Candidates: board.GetCandidates(),
+								Move: map[string]interface{}{
+									"technique":   "fix-error",
+									"action":      "fix-error",
+									"digit":       badDigit,
+									"explanation": fmt.Sprintf("Removing incorrect %d from R%dC%d.", badDigit, badRow+1, badCol+1),
+									"targets":     []map[string]int{{"row": badRow, "col": badCol}},
+									"highlights": map[string]interface{}{
+										"primary": []map[string]int{{"row": badRow, "col": badCol}},
+									},
+								},
+							})
+							continue
+						}
+					}
+
 					// Diagnostic candidate refill
 					moves = append(moves, MoveResult{
 						Board:      board.GetCells(),
@@ -1179,11 +2584,12 @@ func solveAllHandler(c *gin.Context) {
 						},
 					})
 
-					badCell, badDigit, zeroCandCell := findErrorByCandidateRefill(originalUserBoard, givens)
+					badCell, badDigit, zeroCandCell := findErrorByCandidateRefill(originalUserBoard, givens, session.Constraints)
 					if badCell >= 0 {
 						badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
 						zeroCandRow, zeroCandCol := zeroCandCell/constants.GridSize, zeroCandCell%constants.GridSize
 						fixCount++
+						metricsRegistry.IncFixErrorMove()
 						originalUserBoard[badCell] = 0
 						board.ClearCell(badCell)
 						moves = append(moves, MoveResult{
@@ -1218,15 +2624,21 @@ func solveAllHandler(c *gin.Context) {
 					break
 				}
 
-				solver.ApplyMove(board, move)
+				if _, err := solver.ApplyMove(board, move); err != nil {
+					log.Printf("ERROR [solveAll]: solver produced an invalid move (%s): %v", move.Technique, err)
+					apiError(c, http.StatusInternalServerError, ErrCodeInvalidMove, "solver produced an invalid move: "+err.Error())
+					return
+				}
+				move.LocalizeExplanation(req.Lang)
 				moves = append(moves, MoveResult{Board: board.GetCells(), Candidates: board.GetCandidates(), Move: move})
 			}
 
-			c.JSON(http.StatusOK, gin.H{
-				"moves":      moves,
-				"solved":     board.IsSolved(),
-				"finalBoard": board.GetCells(),
-			})
+			if board.IsSolved() {
+				metricsRegistry.IncSolverOutcome(constants.StatusCompleted)
+			}
+			logAttrs(c, solveAllLogAttrs(moves, fixCount, time.Since(solveStart))...)
+			// The fix-conflict move diffs against the board as submitted
+			writeSolveAllResponse(c, paging, deltaFormat, req.Board, req.Candidates, moves, board.IsSolved(), board.GetCells())
 			return
 		}
 	}
@@ -1236,10 +2648,16 @@ func solveAllHandler(c *gin.Context) {
 	var board *human.Board
 	if len(req.Candidates) == 0 {
 		// NewBoard initializes candidates for a fresh puzzle (frontend typically omits candidates)
-		board = human.NewBoard(req.Board)
+		board = human.NewBoardWithConstraints(req.Board, session.Constraints)
 	} else {
-		board = human.NewBoardWithCandidates(req.Board, req.Candidates)
+		board = human.NewBoardWithCandidatesAndConstraints(req.Board, req.Candidates, session.Constraints)
 	}
+	board.AssumeUnique = !session.NonUnique
+
+	// Snapshot the state the solve starts from: the delta format diffs every
+	// move against its predecessor beginning here
+	initialCells := board.GetCells()
+	initialCandidates := board.GetCandidates()
 
 	// Keep a copy of the original user board to distinguish user entries from solver placements
 	originalUserBoard := make([]int, len(req.Board))
@@ -1255,24 +2673,45 @@ func solveAllHandler(c *gin.Context) {
 		}
 	}
 
-	solver := human.NewSolver()
+	solver := newSolveSolver(strategy, maxTier)
 
 	// Collect all moves
 
 	var moves []MoveResult
-	maxMoves := 2000
+	maxMoves := solveMoveBudget()
 	maxFixes := 5 // Limit how many user errors we'll fix
 
 	fixCount := 0
 
+	// /solve/all accepts any board, so the session's solution only has
+	// authority when the request's givens actually come from its puzzle
+	solution := sessionSolution(session, givens)
+	for i, g := range givens {
+		if g != 0 && solution != nil && g != solution[i] {
+			solution = nil
+			break
+		}
+	}
+
 	for i := 0; i < maxMoves; i++ {
-		// Check if solved
-		if board.IsSolved() {
+		if time.Since(solveStart) > timeBudget {
+			apiErrorDetails(c, http.StatusRequestTimeout, ErrCodeTimeBudgetExceeded, "solver time budget exceeded", gin.H{"moves_completed": len(moves)})
+			return
+		}
+
+		// A paged request only needs the page plus one lookahead move
+		if paging.Enabled && len(moves) >= paging.moveCap() {
+			break
+		}
+
+		// Check if solved - same completion check as /solve/next
+		if boardCompleted(board.GetCells(), solution, session.Constraints) {
 			break
 		}
 
 		move := solver.FindNextMove(board)
 		if move == nil {
+			metricsRegistry.IncSolverOutcome(constants.StatusStalled)
 			// No more moves found (stalled)
 			// If we've already fixed some errors, there might be more issues
 			// Offer the user a choice
@@ -1294,6 +2733,25 @@ func solveAllHandler(c *gin.Context) {
 
 		// If we hit a contradiction, try to find and fix the user error
 		if move.Action == "contradiction" {
+			metricsRegistry.IncSolverOutcome(constants.ActionContradiction)
+
+			// Rule the player out first: when every entry matches the
+			// solution (or there are none), this contradiction is a solver
+			// bug and the user-cell-removal heuristics below would delete a
+			// correct cell or report a bogus unpinpointable error
+			if contradictionIsSolverBug(originalUserBoard, givens, solution) {
+				recent := make([]interface{}, 0, len(moves))
+				for _, mr := range moves {
+					recent = append(recent, mr.Move)
+				}
+				moves = append(moves, MoveResult{
+					Board:      board.GetCells(),
+					Candidates: board.GetCandidates(),
+					Move:       solverErrorMove(c, board.GetCells(), move, recent),
+				})
+				break
+			}
+
 			if fixCount >= maxFixes {
 				// Too many fixes needed - give up and offer user a choice
 				userEntryCount := countUserEntries(originalUserBoard, givens)
@@ -1315,20 +2773,24 @@ func solveAllHandler(c *gin.Context) {
 				contradictionCell := move.Targets[0].Row*constants.GridSize + move.Targets[0].Col
 
 				// Analyze which user-entered cell is causing this
-				badCell, badDigit := findBlockingUserCell(board, contradictionCell, originalUserBoard, givens)
+				badCell, badDigit := human.FindBlockingUserCell(board, contradictionCell, originalUserBoard, givens, sessionSolution(session, givens))
 
 				if badCell >= 0 {
 					badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
 					fixCount++
+					metricsRegistry.IncFixErrorMove()
 
 					// Update originalUserBoard to remove the bad cell
 					originalUserBoard[badCell] = 0
 
-					// Reset the board to the original user state (minus the fixed cell)
-					// This removes any solver-placed cells that may have been wrong due to the user error
-					// Use nil for candidates so the solver will rebuild them from scratch
-					board = human.NewBoardWithCandidates(originalUserBoard, nil)
-					// Initialize candidates properly based on the corrected board
+					// Reset the board to the original user state (minus the fixed
+					// cell). This removes any solver-placed cells that may have
+					// been wrong due to the user error. The user's own pencil
+					// marks survive the reset — only the fixed cell's go, and
+					// InitCandidates refills just the cells they didn't pencil
+					originalUserCandidates[badCell] = nil
+					board = human.NewBoardWithCandidatesAndConstraints(originalUserBoard, originalUserCandidates, session.Constraints)
+					board.AssumeUnique = !session.NonUnique
 					board.InitCandidates()
 
 					// Record the fix move
@@ -1351,7 +2813,35 @@ func solveAllHandler(c *gin.Context) {
 				}
 			}
 
-			// Direct analysis failed - try candidate refill diagnostic
+			// Direct analysis failed - check entries against the authoritative
+			// solution before the slower refill diagnostic
+			if solution != nil {
+				if badCell := firstIncorrectEntry(originalUserBoard, givens, solution); badCell >= 0 {
+					badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
+					badDigit := originalUserBoard[badCell]
+					fixCount++
+					metricsRegistry.IncFixErrorMove()
+					originalUserBoard[badCell] = 0
+					board.ClearCell(badCell)
+					moves = append(moves, MoveResult{
+						Board:      board.GetCells(),
+						Candidates: board.GetCandidates(),
+						Move: map[string]interface{}{
+							"technique":   "fix-error",
+							"action":      "fix-error",
+							"digit":       badDigit,
+							"explanation": fmt.Sprintf("Removing incorrect %d from R%dC%d.", badDigit, badRow+1, badCol+1),
+							"targets":     []map[string]int{{"row": badRow, "col": badCol}},
+							"highlights": map[string]interface{}{
+								"primary": []map[string]int{{"row": badRow, "col": badCol}},
+							},
+						},
+					})
+					continue
+				}
+			}
+
+			// Try candidate refill diagnostic
 			// This is the "human-like" approach: clear notes, refill, look for zero-candidate cells
 			moves = append(moves, MoveResult{
 				Board:      board.GetCells(),
@@ -1363,12 +2853,13 @@ func solveAllHandler(c *gin.Context) {
 				},
 			})
 
-			badCell, badDigit, zeroCandCell := findErrorByCandidateRefill(originalUserBoard, givens)
+			badCell, badDigit, zeroCandCell := findErrorByCandidateRefill(originalUserBoard, givens, session.Constraints)
 
 			if badCell >= 0 {
 				badRow, badCol := badCell/constants.GridSize, badCell%constants.GridSize
 				zeroCandRow, zeroCandCol := zeroCandCell/constants.GridSize, zeroCandCell%constants.GridSize
 				fixCount++
+				metricsRegistry.IncFixErrorMove()
 
 				// Update originalUserBoard to remove the bad cell (for future reference)
 				originalUserBoard[badCell] = 0
@@ -1414,9 +2905,14 @@ func solveAllHandler(c *gin.Context) {
 		}
 
 		// Apply the move
-		solver.ApplyMove(board, move)
+		if _, err := solver.ApplyMove(board, move); err != nil {
+			log.Printf("ERROR [solveAll]: solver produced an invalid move (%s): %v", move.Technique, err)
+			apiError(c, http.StatusInternalServerError, ErrCodeInvalidMove, "solver produced an invalid move: "+err.Error())
+			return
+		}
 
 		// Record the result
+		move.LocalizeExplanation(req.Lang)
 		moves = append(moves, MoveResult{
 			Board:      board.GetCells(),
 			Candidates: board.GetCandidates(),
@@ -1424,33 +2920,51 @@ func solveAllHandler(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"moves":      moves,
-		"solved":     board.IsSolved(),
-		"finalBoard": board.GetCells(),
-	})
+	solved := boardCompleted(board.GetCells(), solution, session.Constraints)
+	if solved {
+		metricsRegistry.IncSolverOutcome(constants.StatusCompleted)
+	}
+	logAttrs(c, solveAllLogAttrs(moves, fixCount, time.Since(solveStart))...)
+	writeSolveAllResponse(c, paging, deltaFormat, initialCells, initialCandidates, moves, solved, board.GetCells())
 }
 
 type SolveFullRequest struct {
 	Token string `json:"token" binding:"required"`
 	Board []int  `json:"board" binding:"required"`
+	Stats bool   `json:"stats"` // include per-detector timing stats in the response
 }
 
 func solveFullHandler(c *gin.Context) {
 	var req SolveFullRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
-	_, err := verifyToken(cfg.JWTSecret, req.Token)
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token: " + err.Error()})
+		apiTokenError(c, err)
 		return
 	}
+	logSession(c, session)
 
 	if len(req.Board) != constants.TotalCells {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("board must have %d cells", constants.TotalCells)})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+
+	strategy, ok := solverStrategyFromQuery(c)
+	if !ok {
+		return
+	}
+
+	maxTier, ok := solverMaxTierFromQuery(c)
+	if !ok {
+		return
+	}
+
+	candidateMode, ok := solverCandidateModeFromQuery(c)
+	if !ok {
 		return
 	}
 
@@ -1459,29 +2973,185 @@ func solveFullHandler(c *gin.Context) {
 		mode = "human"
 	}
 
+	// Transformed sessions solve in canonical orientation and get the moves
+	// and final board mapped back to the presented one
+	transform := sessionTransform(session)
+	if !transform.IsIdentity() {
+		req.Board = transform.Invert().ApplyCells(req.Board)
+	}
+
 	if mode == "fast" {
 		// Use DP solver
-		solution := dp.Solve(req.Board)
+		solution := dp.SolveWithConstraints(req.Board, session.Constraints)
 		if solution == nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "no solution found"})
+			apiError(c, http.StatusBadRequest, ErrCodeNoSolution, "no solution found")
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"final_board": solution})
+		c.JSON(http.StatusOK, gin.H{"final_board": transform.ApplyCells(solution)})
 		return
 	}
 
 	// Human mode
-	board := human.NewBoard(req.Board)
-	solver := human.NewSolver()
-	moves, reason := solver.SolveWithSteps(board, constants.MaxSolverSteps)
+	board := human.NewBoardWithConstraints(req.Board, session.Constraints)
+	board.AssumeUnique = !session.NonUnique
+	solver := newSolveSolver(strategy, maxTier)
+	if req.Stats {
+		solver.EnableStats()
+	}
+	// The solver's own boundary entry only shows up at debug level
+	solver.SetLogger(baseLogger)
+	solveStart := time.Now()
+	result := solver.SolveWithStepsResultOpts(board, constants.MaxSolverSteps, human.SolveOpts{CandidateMode: candidateMode})
+	moves, reason := result.Moves, result.Status
+	metricsRegistry.IncSolverOutcome(reason)
+
+	techniqueCounts := make(map[string]int, 8)
+	for i := range moves {
+		techniqueCounts[moves[i].Technique]++
+	}
+	logAttrs(c,
+		slog.String("outcome", reason),
+		slog.Any("technique_counts", techniqueCounts),
+		slog.Duration("solve_duration", time.Since(solveStart)),
+	)
+
+	if !transform.IsIdentity() {
+		for i := range moves {
+			transform.ApplyMove(&moves[i])
+		}
+		transform.ApplyMove(result.CycleMove)
+	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"moves":          moves,
-		"final_board":    board.GetCells(),
+		"final_board":    transform.ApplyCells(board.GetCells()),
 		"stopped_reason": reason,
-	})
-}
-
+		"remaining":      result.Remaining,
+		"can_continue":   result.CanContinue,
+		"summary":        solver.Summarize(moves),
+	}
+	if result.CanContinue {
+		response["suggested_max_steps"] = result.SuggestedMaxSteps
+	}
+	if result.CycleMove != nil {
+		response["cycle_move"] = result.CycleMove
+	}
+	if stats := solver.Stats(); stats != nil {
+		timeMs := make(map[string]float64, len(stats.DetectorTime))
+		for slug, d := range stats.DetectorTime {
+			timeMs[slug] = float64(d.Microseconds()) / 1000.0
+		}
+		response["stats"] = gin.H{
+			"detector_calls":   stats.DetectorCalls,
+			"detector_time_ms": timeMs,
+			"hits":             stats.Hits,
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// solveStreamBoard parses the stream endpoint's board query parameter: a
+// TotalCells-character digit string with 0 for empty cells.
+func solveStreamBoard(c *gin.Context) ([]int, bool) {
+	raw := c.Query("board")
+	if len(raw) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize,
+			fmt.Sprintf("board must be a %d-character digit string", constants.TotalCells))
+		return nil, false
+	}
+	cells := make([]int, constants.TotalCells)
+	for i := 0; i < len(raw); i++ {
+		if raw[i] < '0' || raw[i] > '9' {
+			apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "board must contain only digits 0-9")
+			return nil, false
+		}
+		cells[i] = int(raw[i] - '0')
+	}
+	return cells, true
+}
+
+// solveStreamHandler is solveFullHandler as a Server-Sent Events stream. It
+// runs the same human solve but emits each move as it is found instead of
+// buffering the whole list, so clients can drive a progress bar and live move
+// feed during long solves. The stream opens with a state event carrying the
+// initial board and candidates, then one move event per move holding the move
+// and a diff against the previous state (the /solve/all delta format), and
+// closes with a done event carrying the status, summary and final board. The
+// response flushes after every event, and the solve aborts as soon as the
+// client disconnects.
+//
+// EventSource can't send a body, so the token and board travel as query
+// parameters (see solveStreamBoard for the board format).
+func solveStreamHandler(c *gin.Context) {
+	session, err := verifyToken(cfg.JWTSecret, c.Query("token"))
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+	// The body-probing guard can't see a query token, so the device
+	// blocklist is enforced here
+	if deviceBlocked(session.DeviceID) {
+		abortBlocked(c)
+		return
+	}
+	logSession(c, session)
+
+	cells, ok := solveStreamBoard(c)
+	if !ok {
+		return
+	}
+
+	strategy, ok := solverStrategyFromQuery(c)
+	if !ok {
+		return
+	}
+
+	maxTier, ok := solverMaxTierFromQuery(c)
+	if !ok {
+		return
+	}
+
+	board := human.NewBoardWithConstraints(cells, session.Constraints)
+	board.AssumeUnique = !session.NonUnique
+	solver := newSolveSolver(strategy, maxTier)
+	solver.SetLogger(baseLogger)
+
+	c.Header("Cache-Control", "no-cache")
+	emit := func(event string, data interface{}) {
+		c.SSEvent(event, data)
+		c.Writer.Flush()
+	}
+
+	// Snapshot the state the solve starts from: every move event diffs
+	// against its predecessor beginning here
+	prevCells := board.GetCells()
+	prevCands := board.GetCandidates()
+	emit("state", gin.H{"initialBoard": prevCells, "initialCandidates": prevCands})
+
+	ctx := c.Request.Context()
+	solveStart := time.Now()
+	moves, reason := solver.SolveWithStepsFunc(board, constants.MaxSolverSteps, func(move core.Move, cells []int) bool {
+		cands := board.GetCandidates()
+		diff := human.DiffStates(prevCells, prevCands, cells, cands)
+		emit("move", MoveResult{Move: move, Diff: &diff})
+		prevCells, prevCands = cells, cands
+		return ctx.Err() == nil
+	})
+	metricsRegistry.IncSolverOutcome(reason)
+	logAttrs(c,
+		slog.String("outcome", reason),
+		slog.Int("move_count", len(moves)),
+		slog.Duration("solve_duration", time.Since(solveStart)),
+	)
+
+	emit("done", gin.H{
+		"stopped_reason": reason,
+		"move_count":     len(moves),
+		"final_board":    board.GetCells(),
+		"summary":        solver.Summarize(moves),
+	})
+}
+
 // ValidateBoardRequest validates current board state during gameplay
 type ValidateBoardRequest struct {
 	Token string `json:"token" binding:"required"`
@@ -1491,18 +3161,18 @@ type ValidateBoardRequest struct {
 func validateBoardHandler(c *gin.Context) {
 	var req ValidateBoardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
-	_, err := verifyToken(cfg.JWTSecret, req.Token)
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token: " + err.Error()})
+		apiTokenError(c, err)
 		return
 	}
 
 	if len(req.Board) != constants.TotalCells {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("board must have %d cells", constants.TotalCells)})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
 		return
 	}
 
@@ -1530,6 +3200,33 @@ func validateBoardHandler(c *gin.Context) {
 		return
 	}
 
+	// Prefer the authoritative solution carried by the session token: an
+	// entry that disagrees with it is wrong even if the board is still
+	// technically solvable. Tokens issued before the solution claim existed
+	// fall back to the solvability check below.
+	if solution := openSolution(cfg.JWTSecret, session.PuzzleID, session.Solution); solution != nil {
+		incorrectCells := []int{}
+		for i, v := range req.Board {
+			if v != 0 && v != solution[i] {
+				incorrectCells = append(incorrectCells, i)
+			}
+		}
+		if len(incorrectCells) > 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"valid":          false,
+				"reason":         "incorrect",
+				"message":        "Some entries don't match the puzzle's solution",
+				"incorrectCells": incorrectCells,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"valid":   true,
+			"message": "All entries are correct so far!",
+		})
+		return
+	}
+
 	// Check if puzzle is solvable from current state
 	solutions := dp.CountSolutions(req.Board, 1)
 	if solutions == 0 {
@@ -1548,20 +3245,364 @@ func validateBoardHandler(c *gin.Context) {
 	})
 }
 
+// ValidateWinnableRequest asks only whether the game is still on track.
+// Strictness "strict" (default) checks entries against the authoritative
+// solution; "loose" asks whether any completion exists, which is the right
+// question for non-unique custom puzzles where diverging from one solution
+// is fine.
+type ValidateWinnableRequest struct {
+	Token      string `json:"token" binding:"required"`
+	Board      []int  `json:"board" binding:"required"`
+	Strictness string `json:"strictness"`
+}
+
+// validateWinnableHandler answers yes/no whether the board can still reach a
+// solution. Unlike /validate it deliberately reveals nothing else - no
+// reasons, counts, or cell lists - so players can check they're on track
+// without being spoiled. The flip side is that a bare oracle is cheap to
+// abuse for guess-and-check, which is why the route draws from its own,
+// tighter rate bucket.
+func validateWinnableHandler(c *gin.Context) {
+	var req ValidateWinnableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+
+	var winnable bool
+	switch req.Strictness {
+	case "", "strict":
+		// Every filled cell must agree with the solution carried by the
+		// token; tokens issued before the solution claim existed fall back
+		// to the solvability check
+		solution := openSolution(cfg.JWTSecret, session.PuzzleID, session.Solution)
+		if solution == nil {
+			winnable = dp.CountSolutions(req.Board, 1) > 0
+			break
+		}
+		winnable = true
+		for i, v := range req.Board {
+			if v != 0 && v != solution[i] {
+				winnable = false
+				break
+			}
+		}
+	case "loose":
+		// User entries count as givens; one completion is enough even when
+		// the board has diverged from the sealed solution
+		winnable = dp.CountSolutions(req.Board, 1) > 0
+	default:
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "strictness must be strict or loose")
+		return
+	}
+
+	c.JSON(http.StatusOK, ValidateWinnableResponse{Winnable: winnable})
+}
+
+// CandidatesCheckRequest diffs the player's pencil marks against the true
+// candidate set. Strictness "peers" (default) only flags marks conflicting
+// with filled peer cells; "solution" checks marks against the full solution.
+type CandidatesCheckRequest struct {
+	Token      string  `json:"token" binding:"required"`
+	Board      []int   `json:"board" binding:"required"`
+	Candidates [][]int `json:"candidates"`
+	Givens     []int   `json:"givens"`     // Original puzzle givens (regenerated from session if omitted)
+	Strictness string  `json:"strictness"` // "peers" (default) or "solution"
+}
+
+func candidatesCheckHandler(c *gin.Context) {
+	var req CandidatesCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+
+	strictness := req.Strictness
+	if strictness == "" {
+		strictness = human.CheckPeers
+	}
+
+	// Get original givens - either from request or regenerate from session.
+	// Only the solution strictness needs them
+	givens := req.Givens
+	if strictness == human.CheckSolution && len(givens) != constants.TotalCells {
+		loader := puzzles.Global()
+		if loader != nil {
+			givens, _, _, _ = loader.GetPuzzleBySeed(session.Seed, session.Difficulty)
+		}
+		if len(givens) != constants.TotalCells {
+			// Fallback: generate on-demand
+			seedHash := hashSeed(session.Seed)
+			fullGrid := generateFullGrid(session.Seed, seedHash)
+			allPuzzles, _ := carveForSeed(fullGrid, seedHash)
+			givens = allPuzzles[session.Difficulty]
+		}
+	}
+
+	diff, err := human.DiffCandidates(req.Board, req.Candidates, givens, strictness)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, CandidatesCheckResponse{
+		Strictness:    strictness,
+		CandidateDiff: diff,
+	})
+}
+
+type ExplainRequest struct {
+	Token      string  `json:"token" binding:"required"`
+	Board      []int   `json:"board" binding:"required"`
+	Candidates [][]int `json:"candidates"` // optional pencil marks; omitted = full candidates
+	Row        int     `json:"row"`
+	Col        int     `json:"col"`
+	Digit      int     `json:"digit" binding:"required"`
+}
+
+// explainHandler answers "why is digit D impossible or required at R#C#".
+// The heavy lifting (peer conflicts, hidden singles, solver replay for
+// eliminated candidates) lives in human.ExplainCell.
+func explainHandler(c *gin.Context) {
+	var req ExplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	if _, err := verifyToken(cfg.JWTSecret, req.Token); err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+	if req.Row < 0 || req.Row >= constants.GridSize || req.Col < 0 || req.Col >= constants.GridSize {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidCell, "row and col must be within the grid")
+		return
+	}
+
+	var board *human.Board
+	if req.Candidates != nil {
+		board = human.NewBoardWithCandidates(req.Board, req.Candidates)
+	} else {
+		board = human.NewBoard(req.Board)
+	}
+
+	explanation, err := human.ExplainCell(board, req.Row*constants.GridSize+req.Col, req.Digit)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
+
+type SolveCellRequest struct {
+	Token      string  `json:"token" binding:"required"`
+	Board      []int   `json:"board" binding:"required"`
+	Candidates [][]int `json:"candidates"` // optional pencil marks; omitted = full candidates
+	Row        int     `json:"row"`
+	Col        int     `json:"col"`
+	Lang       string  `json:"lang"` // optional language for move explanations (default English)
+}
+
+// solveCellHandler returns the cheapest available move that touches the
+// requested cell — an assignment into it or an elimination from it — rather
+// than wherever the solver would move next. If nothing touches the cell, the
+// nearest move highlighting one of its peers is returned with indirect: true.
+func solveCellHandler(c *gin.Context) {
+	var req SolveCellRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+	if req.Row < 0 || req.Row >= constants.GridSize || req.Col < 0 || req.Col >= constants.GridSize {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidCell, "row and col must be within the grid")
+		return
+	}
+
+	// Transformed sessions submit the board and the requested cell in the
+	// presented orientation; map both back to canonical before solving
+	transform := sessionTransform(session)
+	if !transform.IsIdentity() {
+		inverse := transform.Invert()
+		req.Board = inverse.ApplyCells(req.Board)
+		req.Candidates = inverse.ApplyCandidateGrid(req.Candidates)
+		cell := inverse.ApplyCellRef(core.CellRef{Row: req.Row, Col: req.Col})
+		req.Row, req.Col = cell.Row, cell.Col
+	}
+
+	// Revealing a cell is the heaviest hint there is; it counts like any
+	// other toward the puzzle's hint statistics
+	puzzleStats.RecordHint(session.Seed, session.Difficulty, req.Token)
+
+	maxTier, ok := solverMaxTierFromQuery(c)
+	if !ok {
+		return
+	}
+
+	var board *human.Board
+	if req.Candidates != nil {
+		board = human.NewBoardWithCandidatesAndConstraints(req.Board, req.Candidates, session.Constraints)
+	} else {
+		board = human.NewBoardWithConstraints(req.Board, session.Constraints)
+	}
+	board.AssumeUnique = !session.NonUnique
+	staleCandidates := board.ValidateCandidatesAgainstPeers()
+	solver := newSolveSolver(human.StrategyFirst, maxTier)
+	move := solver.FindMoveForCell(board, req.Row*constants.GridSize+req.Col)
+	move.LocalizeExplanation(req.Lang)
+
+	response := gin.H{"move": move}
+	if len(staleCandidates) > 0 {
+		response["stale_candidates"] = staleCandidates
+	}
+	respondSolveNext(c, transform, response)
+}
+
+type AssistCascadeRequest struct {
+	Token      string  `json:"token" binding:"required"`
+	Board      []int   `json:"board" binding:"required"`
+	Candidates [][]int `json:"candidates"` // optional pencil marks; omitted = full candidates
+	MaxTier    string  `json:"max_tier"`   // tier cap for the cascade; defaults to simple
+	// AllowAssignments lets the cascade fill cells (singles). Off by
+	// default: an assist tidies pencil marks, it doesn't play the game.
+	AllowAssignments bool   `json:"allow_assignments"`
+	Lang             string `json:"lang"` // optional language for move explanations (default English)
+}
+
+// assistCascadeHandler applies every elimination the detectors at or below
+// the requested tier can find, cascading until none fires, and returns the
+// aggregate move list with the resulting board state. Backs the
+// auto-pencil-mark assist mode. The heavy lifting lives in
+// human.ApplyTierCascade.
+func assistCascadeHandler(c *gin.Context) {
+	var req AssistCascadeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+
+	maxTier := req.MaxTier
+	if maxTier == "" {
+		maxTier = constants.TierSimple
+	}
+	switch maxTier {
+	case constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme:
+	default:
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidTier,
+			fmt.Sprintf("invalid max_tier '%s'. Must be one of: %s, %s, %s, %s", maxTier,
+				constants.TierSimple, constants.TierMedium, constants.TierHard, constants.TierExtreme))
+		return
+	}
+
+	var board *human.Board
+	if req.Candidates != nil {
+		board = human.NewBoardWithCandidatesAndConstraints(req.Board, req.Candidates, session.Constraints)
+	} else {
+		board = human.NewBoardWithConstraints(req.Board, session.Constraints)
+	}
+	board.AssumeUnique = !session.NonUnique
+
+	solver := human.NewSolver()
+	moves, err := solver.ApplyTierCascade(board, maxTier, req.AllowAssignments)
+	if err != nil {
+		apiError(c, http.StatusUnprocessableEntity, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	for i := range moves {
+		moves[i].LocalizeExplanation(req.Lang)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"moves":      moves,
+		"board":      board.GetCells(),
+		"candidates": board.GetCandidates(),
+	})
+}
+
 type CustomValidateRequest struct {
-	Givens   []int  `json:"givens" binding:"required"`
-	DeviceID string `json:"device_id" binding:"required"`
+	Givens      []int              `json:"givens" binding:"required"`
+	DeviceID    string             `json:"device_id" binding:"required"`
+	Constraints core.ConstraintSet `json:"constraints"` // optional variant constraints
+	Minimal     bool               `json:"minimal"`     // optional: also report redundant givens
+}
+
+// customSessionToken issues a session token for a validated custom puzzle so
+// the solve endpoints accept it. Custom sessions carry no seed: clients send
+// the givens with each solve request. nonUnique records that the puzzle was
+// validated as having multiple solutions, which makes the solve handlers
+// clear Board.AssumeUnique.
+func customSessionToken(req CustomValidateRequest, puzzleID, sealed string, nonUnique bool) (string, error) {
+	now := time.Now()
+	return createToken(cfg.JWTSecret, SessionToken{
+		DeviceID:    req.DeviceID,
+		PuzzleID:    puzzleID,
+		Difficulty:  constants.DifficultyCustom,
+		Constraints: req.Constraints,
+		StartedAt:   now,
+		ExpiresAt:   now.Add(cfg.SessionTokenExpiry),
+		Solution:    sealed,
+		NonUnique:   nonUnique,
+	})
 }
 
 func customValidateHandler(c *gin.Context) {
 	var req CustomValidateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
 	if len(req.Givens) != constants.TotalCells {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("givens must have %d cells", constants.TotalCells)})
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("givens must have %d cells", constants.TotalCells))
 		return
 	}
 
@@ -1581,8 +3622,8 @@ func customValidateHandler(c *gin.Context) {
 		return
 	}
 
-	// Validate: check for conflicts
-	if !dp.IsValid(req.Givens) {
+	// Validate: check for conflicts (classic plus any variant constraints)
+	if !dp.IsValidWithConstraints(req.Givens, req.Constraints) {
 		c.JSON(http.StatusOK, gin.H{
 			"valid":  false,
 			"reason": "puzzle contains conflicts",
@@ -1590,10 +3631,13 @@ func customValidateHandler(c *gin.Context) {
 		return
 	}
 
-	// Check solvability and uniqueness using DP
-	solutions := dp.CountSolutions(req.Givens, constants.SolutionCountLimit)
+	// Check solvability and uniqueness using DP. Counting is capped and time
+	// budgeted, so a structurally broken grid reports ">=cap" instead of an
+	// exact count.
+	sample := dp.SampleSolutionsWithConstraints(req.Givens, constants.SolutionSampleLimit,
+		constants.SolutionSampleBudgetMs*time.Millisecond, req.Constraints)
 
-	if solutions == 0 {
+	if sample.Count == 0 {
 		c.JSON(http.StatusOK, gin.H{
 			"valid":  false,
 			"reason": "puzzle has no solution",
@@ -1601,22 +3645,417 @@ func customValidateHandler(c *gin.Context) {
 		return
 	}
 
-	if solutions > 1 {
-		c.JSON(http.StatusOK, gin.H{
-			"valid":  true,
-			"unique": false,
-			"reason": "puzzle has multiple solutions",
-		})
-		return
-	}
-
 	// Generate a unique ID for this custom puzzle
 	puzzleHash := hashSolution(req.Givens)
 	puzzleID := "custom-" + puzzleHash[:16]
 
-	c.JSON(http.StatusOK, gin.H{
+	if sample.Count > 1 {
+		// Still playable: issue a session token so the solve endpoints accept
+		// the puzzle, marked non-unique so uniqueness-based techniques (the
+		// UR family, BUG) are never suggested against it
+		token, err := customSessionToken(req, puzzleID, "", true)
+		if err != nil {
+			log.Printf("ERROR [customValidate]: failed to create token: %v", err)
+			apiError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to create token")
+			return
+		}
+		resp := gin.H{
+			"valid":          true,
+			"unique":         false,
+			"reason":         "puzzle has multiple solutions",
+			"solution_count": sample.Count,
+			"puzzle_id":      puzzleID,
+			"token":          token,
+		}
+		if sample.Capped {
+			resp["solution_count"] = fmt.Sprintf(">=%d", sample.Count)
+		} else if sample.Count == 2 {
+			// One given on any differing cell makes the puzzle unique
+			resp["differing_cells"] = sample.DifferingCells()
+		}
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	// The sampler honors the submitted constraints, so its solution is
+	// authoritative for variants too — seal it into the token the way
+	// sessionStartHandler does for generated puzzles
+	token, err := customSessionToken(req, puzzleID, sealSolution(cfg.JWTSecret, puzzleID, sample.First), false)
+	if err != nil {
+		log.Printf("ERROR [customValidate]: failed to create token: %v", err)
+		apiError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to create token")
+		return
+	}
+
+	resp := gin.H{
 		"valid":     true,
 		"unique":    true,
 		"puzzle_id": puzzleID,
+		"token":     token,
+	}
+
+	// Warn when the submission is a symmetry transform of a recent daily:
+	// relabeled or rotated dailies would otherwise enter dedup and
+	// leaderboards as new puzzles. Dailies are classic, so variant
+	// submissions skip the check.
+	if req.Constraints.IsClassic() {
+		if seed := equivalentRecentDaily(req.Givens); seed != "" {
+			resp["equivalent_to_daily"] = seed
+		}
+	}
+
+	// Redundancy report on request only: one capped uniqueness check per
+	// given is cheap but not free
+	if req.Minimal {
+		redundant := dp.RedundantGivensWithConstraints(req.Givens, req.Constraints)
+		resp["minimal"] = len(redundant) == 0
+		resp["redundant_givens"] = redundant
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+type CustomResolveRequest struct {
+	Cells []ingest.CellReading `json:"cells" binding:"required"`
+}
+
+// customResolveHandler ingests an OCR reading of a photographed grid and
+// resolves ambiguous cells into a valid unique puzzle
+//
+// POST /api/custom/resolve {cells: [{digit, confidence, alternatives}]}
+func customResolveHandler(c *gin.Context) {
+	var req CustomResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	if len(req.Cells) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("cells must have %d entries", constants.TotalCells))
+		return
+	}
+
+	result, err := ingest.Resolve(req.Cells)
+	if err != nil {
+		var unresolvable *ingest.UnresolvableError
+		if errors.As(err, &unresolvable) {
+			c.JSON(http.StatusOK, gin.H{
+				"resolved":      false,
+				"reason":        "no combination of readings forms a valid unique puzzle",
+				"problem_cells": unresolvable.ProblemCells,
+			})
+			return
+		}
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resolved":    true,
+		"givens":      result.Givens,
+		"corrections": result.Corrections,
+		"confidence":  result.Confidence,
 	})
 }
+
+type SolveReviewRequest struct {
+	Givens  []int                `json:"givens" binding:"required"`
+	History []human.ReviewAction `json:"history" binding:"required"`
+}
+
+// solveReviewHandler grades a user's move history against the solver
+//
+// POST /api/analysis/solve-review {givens, history: [{type, index, digit, timestamp}]}
+//
+// The response annotates each history entry - derivable (and by which
+// technique), a lucky guess, or a mistake - plus summary stats. History
+// length and total analysis time are bounded; see human.ReviewHistory.
+func solveReviewHandler(c *gin.Context) {
+	var req SolveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	if len(req.Givens) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("givens must have %d cells", constants.TotalCells))
+		return
+	}
+	if len(req.History) > human.MaxReviewHistory {
+		apiError(c, http.StatusBadRequest, ErrCodeHistoryTooLarge, fmt.Sprintf("history exceeds %d entries", human.MaxReviewHistory))
+		return
+	}
+
+	solver := human.NewSolver()
+	review, err := solver.ReviewHistory(req.Givens, req.History)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// puzzlePackHandler exports a batch of puzzles for offline play
+//
+// GET /api/puzzles/pack?count=50&difficulties=easy,medium&format=compact&seed=abc
+//
+// Puzzles are sampled deterministically from the pre-generated loader, seeded
+// by the optional seed parameter, so the same query always returns the same
+// pack and responses are safely cacheable. When no loader is available,
+// puzzles are generated on the fly and count is capped to protect the server.
+// The response includes a SHA-256 checksum of the puzzle payload so clients
+// can verify stored packs.
+func puzzlePackHandler(c *gin.Context) {
+	count := constants.DefaultPackCount
+	if raw := c.Query("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			apiError(c, http.StatusBadRequest, ErrCodeInvalidCount, "count must be a positive integer")
+			return
+		}
+		count = parsed
+	}
+	if count > constants.MaxPackCount {
+		count = constants.MaxPackCount
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = "compact"
+	}
+	if format != "compact" {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidFormat, fmt.Sprintf("unsupported format '%s'. Only 'compact' is available", format))
+		return
+	}
+
+	// Parse and validate the difficulty filter (defaults to all difficulties)
+	difficulties := constants.DifficultyNames()
+	if raw := c.Query("difficulties"); raw != "" {
+		difficulties = strings.Split(raw, ",")
+		for _, d := range difficulties {
+			if _, ok := constants.DifficultyByName(d); !ok {
+				apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(d))
+				return
+			}
+		}
+	}
+
+	seed := c.Query("seed")
+
+	var pack []puzzles.CompactPuzzle
+	loader := puzzles.Global()
+	if loader != nil && loader.Count() > 0 {
+		if count > loader.Count() {
+			count = loader.Count()
+		}
+		// Deterministic sampling: hash the seed to a starting index and take
+		// consecutive puzzles, wrapping around. Simple, collision-free, and
+		// stable for a fixed seed and loader contents
+		start := int(uint64(hashSeed("pack:"+seed)) % uint64(loader.Count()))
+		for i := 0; i < count; i++ {
+			puzzle, err := loader.GetCompact((start + i) % loader.Count())
+			if err != nil {
+				apiError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to read puzzle")
+				return
+			}
+			pack = append(pack, filterPackPuzzle(puzzle, difficulties))
+		}
+	} else {
+		// No loader: generate on demand, with a much smaller cap since each
+		// puzzle requires carving with uniqueness checks
+		if count > constants.MaxGeneratedPack {
+			count = constants.MaxGeneratedPack
+		}
+		for i := 0; i < count; i++ {
+			puzzleSeed := fmt.Sprintf("%s:pack:%d", seed, i)
+			seedHash := hashSeed(puzzleSeed)
+			fullGrid := generateFullGrid(puzzleSeed, seedHash)
+			allPuzzles, _ := carveForSeed(fullGrid, seedHash)
+
+			solution := make([]byte, constants.TotalCells)
+			for j, v := range fullGrid {
+				solution[j] = byte('0' + v)
+			}
+			givensByKey := make(map[string][]int)
+			for _, d := range difficulties {
+				spec, _ := constants.DifficultyByName(d)
+				indices := []int{}
+				for j, v := range allPuzzles[d] {
+					if v != 0 {
+						indices = append(indices, j)
+					}
+				}
+				givensByKey[spec.Key] = indices
+			}
+			pack = append(pack, puzzles.CompactPuzzle{S: string(solution), G: givensByKey})
+		}
+	}
+
+	payload, err := json.Marshal(pack)
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to encode pack")
+		return
+	}
+	sum := sha256.Sum256(payload)
+	checksum := hex.EncodeToString(sum[:])
+
+	// Deterministic content: allow clients and CDNs to cache aggressively
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", constants.PackCacheMaxAgeSec))
+	c.Header("ETag", `"`+checksum+`"`)
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":        len(pack),
+		"difficulties": difficulties,
+		"seed":         seed,
+		"format":       format,
+		"puzzles":      pack,
+		"checksum":     checksum,
+	})
+}
+
+// puzzlesSearchHandler finds bank puzzles by technique, given count, and
+// difficulty
+//
+// GET /api/puzzles/search?technique=x-wing&minGivens=24&maxGivens=30&difficulty=hard&limit=20&offset=0
+//
+// Results are puzzle references (bank index + difficulty + given count), not
+// full givens; clients fetch the actual puzzle via GET /puzzles/:index?d=...
+// The technique filter relies on proof metadata stored in the bank: when the
+// bank was generated without proofs the filter is reported unavailable with
+// a 422 rather than analyzing every puzzle on demand.
+func puzzlesSearchHandler(c *gin.Context) {
+	loader := puzzles.Global()
+	if loader == nil {
+		apiError(c, http.StatusServiceUnavailable, ErrCodePuzzlesNotLoaded, "puzzles not loaded")
+		return
+	}
+
+	difficulty := c.Query("difficulty")
+	if difficulty == "" {
+		difficulty = string(core.DifficultyMedium)
+	}
+	if _, ok := puzzles.DifficultyKey[difficulty]; !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(difficulty))
+		return
+	}
+
+	query := puzzles.SearchQuery{
+		Difficulty: difficulty,
+		Technique:  c.Query("technique"),
+		Limit:      constants.DefaultSearchLimit,
+	}
+	for name, dst := range map[string]*int{
+		"minGivens": &query.MinGivens,
+		"maxGivens": &query.MaxGivens,
+		"offset":    &query.Offset,
+		"limit":     &query.Limit,
+	} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, name+" must be a non-negative integer")
+			return
+		}
+		*dst = parsed
+	}
+	if query.MaxGivens > 0 && query.MinGivens > query.MaxGivens {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "minGivens must not exceed maxGivens")
+		return
+	}
+	if query.Limit < 1 || query.Limit > constants.MaxSearchLimit {
+		query.Limit = constants.DefaultSearchLimit
+	}
+
+	results, total, err := loader.Search(query)
+	if err != nil {
+		if errors.Is(err, puzzles.ErrNoTechniqueData) {
+			apiError(c, http.StatusUnprocessableEntity, ErrCodeTechniqueDataUnavailable,
+				"technique filter unavailable: "+err.Error())
+			return
+		}
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	if results == nil {
+		results = []puzzles.PuzzleRef{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"difficulty": difficulty,
+		"results":    results,
+		"total":      total,
+		"offset":     query.Offset,
+		"limit":      query.Limit,
+	})
+}
+
+// puzzleByIndexHandler serves a single bank puzzle by index, the follow-up
+// fetch for search results
+//
+// GET /api/puzzles/:index?d=hard
+func puzzleByIndexHandler(c *gin.Context) {
+	loader := puzzles.Global()
+	if loader == nil {
+		apiError(c, http.StatusServiceUnavailable, ErrCodePuzzlesNotLoaded, "puzzles not loaded")
+		return
+	}
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil || index < 0 {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "puzzle index must be a non-negative integer")
+		return
+	}
+
+	difficulty := c.Query("d")
+	if difficulty == "" {
+		difficulty = string(core.DifficultyMedium)
+	}
+	if _, ok := puzzles.DifficultyKey[difficulty]; !ok {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidDifficulty, invalidDifficultyError(difficulty))
+		return
+	}
+
+	givens, _, err := loader.GetPuzzle(index, difficulty)
+	if err != nil {
+		apiError(c, http.StatusNotFound, ErrCodeNoPuzzleFound, err.Error())
+		return
+	}
+
+	isGiven := make([]bool, len(givens))
+	for i, v := range givens {
+		isGiven[i] = v != 0
+	}
+
+	// The static seed makes the result usable with session/start directly
+	ref := core.StaticPuzzleRef(index)
+	resp := gin.H{
+		"puzzle_id":    ref.PuzzleID(difficulty),
+		"seed":         ref.String(),
+		"puzzle_index": index,
+		"difficulty":   difficulty,
+		"givens":       givens,
+		"is_given":     isGiven,
+	}
+	if checksum, err := loader.Checksum(index); err == nil {
+		resp["checksum"] = checksum
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// filterPackPuzzle returns a copy of the puzzle keeping only the requested
+// difficulties in its givens map
+func filterPackPuzzle(puzzle puzzles.CompactPuzzle, difficulties []string) puzzles.CompactPuzzle {
+	filtered := puzzles.CompactPuzzle{S: puzzle.S, G: make(map[string][]int, len(difficulties))}
+	for _, d := range difficulties {
+		spec, _ := constants.DifficultyByName(d)
+		if indices, ok := puzzle.G[spec.Key]; ok {
+			filtered.G[spec.Key] = indices
+		}
+	}
+	return filtered
+}
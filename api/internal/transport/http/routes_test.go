@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -54,9 +56,13 @@ func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	cfg := &config.Config{
-		JWTSecret: "test-secret-key",
+		JWTSecret:      "test-secret-key",
+		AllowedOrigins: []string{"https://app.example.test"},
 	}
 	RegisterRoutes(r, cfg)
+	// RegisterRoutes points the request log at stderr; tests run it into the
+	// void instead. logging_test swaps in a real sink where entries matter.
+	baseLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	return r
 }
 
@@ -191,6 +197,41 @@ func TestHTTPRoutes(t *testing.T) {
 		if response["date_utc"] == nil {
 			t.Error("Expected date_utc in response")
 		}
+
+		if response["puzzle_index"] == nil {
+			t.Error("Expected puzzle_index in response")
+		}
+
+		// The test loader is populated, so the bank checksum is included
+		if response["checksum"] == nil {
+			t.Error("Expected checksum in response")
+		}
+	})
+
+	t.Run("DailyWithoutLoader", func(t *testing.T) {
+		loader := puzzles.Global()
+		puzzles.SetGlobal(nil)
+		defer puzzles.SetGlobal(loader)
+		router := setupRouter()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/daily", nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["seed"] == nil {
+			t.Error("Expected seed in response")
+		}
+		if response["checksum"] != nil {
+			t.Error("Expected no checksum without a loader")
+		}
 	})
 
 	t.Run("Puzzle", func(t *testing.T) {
@@ -395,11 +436,17 @@ func TestHTTPRoutes(t *testing.T) {
 		router := setupRouter()
 		token := getValidToken(router)
 
-		// Valid partial board
+		// Valid partial board: the session's givens plus one correct entry
+		// (validation now checks entries against the session's solution)
+		givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
 		validBoard := make([]int, 81)
-		validBoard[0] = 5
-		validBoard[1] = 3
-		validBoard[4] = 7
+		copy(validBoard, givens)
+		for i, v := range givens {
+			if v == 0 {
+				validBoard[i] = solution[i]
+				break
+			}
+		}
 
 		// Board with conflicts (duplicate in row)
 		conflictBoard := make([]int, 81)
@@ -784,7 +831,7 @@ func TestHTTPRoutes(t *testing.T) {
 		// Build a deterministic full grid and carved givens
 		seed := int64(424242)
 		fullGrid := dp.GenerateFullGrid(seed)
-		allPuzzles := dp.CarveGivensWithSubset(fullGrid, seed)
+		allPuzzles, _ := dp.CarveGivensWithSubset(fullGrid, seed)
 		givens := allPuzzles["easy"]
 		if len(givens) != 81 {
 			t.Fatalf("expected givens length 81, got %d", len(givens))
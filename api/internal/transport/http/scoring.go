@@ -0,0 +1,72 @@
+package http
+
+import (
+	"sync"
+	"time"
+)
+
+// completionHistogramCap bounds how many scores are kept per puzzle for the
+// percentile rank; the oldest scores are dropped first.
+const completionHistogramCap = 1000
+
+// completionLedger remembers which sessions have already been scored (so a
+// completion cannot be replayed for a better rank) and keeps a small
+// in-memory score histogram per puzzle for percentile ranks. Entries share
+// the session token's TTL.
+type completionLedger struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	completed map[string]time.Time // session token -> completion time
+	scores    map[string][]int     // seed+difficulty -> recorded scores
+}
+
+func newCompletionLedger(ttl time.Duration) *completionLedger {
+	return &completionLedger{
+		ttl:       ttl,
+		completed: make(map[string]time.Time),
+		scores:    make(map[string][]int),
+	}
+}
+
+// markCompleted records a session's completion. It returns false when the
+// session was already scored within the TTL.
+func (l *completionLedger) markCompleted(token string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	// Sweep expired entries so abandoned sessions don't accumulate
+	for k, at := range l.completed {
+		if now.Sub(at) > l.ttl {
+			delete(l.completed, k)
+		}
+	}
+
+	if _, ok := l.completed[token]; ok {
+		return false
+	}
+	l.completed[token] = now
+	return true
+}
+
+// recordScore adds a score to the puzzle's histogram and returns the
+// percentile rank: the share of recorded completions (including this one)
+// that this score ties or beats, 0-100.
+func (l *completionLedger) recordScore(puzzleKey string, score int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	scores := append(l.scores[puzzleKey], score)
+	if len(scores) > completionHistogramCap {
+		scores = scores[len(scores)-completionHistogramCap:]
+	}
+	l.scores[puzzleKey] = scores
+
+	atOrBelow := 0
+	for _, s := range scores {
+		if s <= score {
+			atOrBelow++
+		}
+	}
+	return atOrBelow * 100 / len(scores)
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"sudoku-api/internal/puzzles"
+)
+
+// postSessionComplete submits a completion and returns the recorder and
+// parsed response body
+func postSessionComplete(t *testing.T, router *gin.Engine, body map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	raw, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/complete", bytes.NewBuffer(raw))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return w, response
+}
+
+// testSeedSolution returns the solution the test loader serves for the
+// session getValidToken starts (seed "test-seed", difficulty "medium")
+func testSeedSolution(t *testing.T) []int {
+	t.Helper()
+	_, solution, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("failed to resolve test puzzle solution: %v", err)
+	}
+	return solution
+}
+
+func TestSessionComplete(t *testing.T) {
+	router := setupRouter()
+	solution := testSeedSolution(t)
+
+	t.Run("clean completion scores base plus bonus", func(t *testing.T) {
+		w, response := postSessionComplete(t, router, map[string]interface{}{
+			"token":           getValidToken(router),
+			"elapsed_seconds": 300,
+			"final_board":     solution,
+		})
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		// medium is tier 2: base 200, +50 for no auto-candidates
+		if score, _ := response["score"].(float64); score != 250 {
+			t.Errorf("expected score 250, got %v", response["score"])
+		}
+		breakdown, _ := response["breakdown"].(map[string]interface{})
+		if base, _ := breakdown["base"].(float64); base != 200 {
+			t.Errorf("expected base 200, got %v", breakdown["base"])
+		}
+		// First completion for this puzzle always ranks 100
+		if rank, _ := response["rank"].(float64); rank != 100 {
+			t.Errorf("expected rank 100, got %v", response["rank"])
+		}
+	})
+
+	t.Run("hints and auto-candidates reduce the score", func(t *testing.T) {
+		w, response := postSessionComplete(t, router, map[string]interface{}{
+			"token":           getValidToken(router),
+			"elapsed_seconds": 300,
+			"hints_used":      map[string]int{"level1": 2, "level2": 1, "level3": 1},
+			"auto_candidates": true,
+			"final_board":     solution,
+		})
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		// base 200 - (2*10 + 25 + 50) hints, no bonus
+		if score, _ := response["score"].(float64); score != 105 {
+			t.Errorf("expected score 105, got %v", response["score"])
+		}
+		// 105 trails the 250 recorded above: 1 of 2 scores at or below
+		if rank, _ := response["rank"].(float64); rank != 50 {
+			t.Errorf("expected rank 50, got %v", response["rank"])
+		}
+	})
+
+	t.Run("incorrect board is rejected", func(t *testing.T) {
+		wrong := make([]int, len(solution))
+		copy(wrong, solution)
+		wrong[0] = solution[0]%9 + 1
+
+		w, response := postSessionComplete(t, router, map[string]interface{}{
+			"token":       getValidToken(router),
+			"final_board": wrong,
+		})
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+		envelope, _ := response["error"].(map[string]interface{})
+		if envelope["code"] != ErrCodeBoardNotSolved {
+			t.Errorf("expected code %q, got %v", ErrCodeBoardNotSolved, envelope["code"])
+		}
+	})
+
+	t.Run("incomplete board is rejected", func(t *testing.T) {
+		incomplete := make([]int, len(solution))
+		copy(incomplete, solution)
+		incomplete[40] = 0
+
+		w, _ := postSessionComplete(t, router, map[string]interface{}{
+			"token":       getValidToken(router),
+			"final_board": incomplete,
+		})
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("duplicate completion returns 409", func(t *testing.T) {
+		token := getValidToken(router)
+		body := map[string]interface{}{
+			"token":       token,
+			"final_board": solution,
+		}
+
+		w, _ := postSessionComplete(t, router, body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("first completion: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		w, response := postSessionComplete(t, router, body)
+		if w.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+		}
+		envelope, _ := response["error"].(map[string]interface{})
+		if envelope["code"] != ErrCodeAlreadyCompleted {
+			t.Errorf("expected code %q, got %v", ErrCodeAlreadyCompleted, envelope["code"])
+		}
+	})
+}
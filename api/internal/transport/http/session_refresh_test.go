@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sudoku-api/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupRefreshRouter builds a router with short session durations so token
+// expiry and the refresh grace window elapse within the test
+func setupRefreshRouter(expiry, grace time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, &config.Config{
+		JWTSecret:           "test-secret-key",
+		SessionTokenExpiry:  expiry,
+		SessionRefreshGrace: grace,
+	})
+	baseLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	return r
+}
+
+func postSessionRefresh(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{"token": token})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// responseErrorCode extracts the machine-readable code from the shared error
+// envelope
+func responseErrorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var response struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse error response: %v. Body: %s", err, w.Body.String())
+	}
+	return response.Error.Code
+}
+
+// TestSessionStartExpiryMetadata verifies the start response tells clients
+// when the token lapses and how long after that it can still be refreshed
+func TestSessionStartExpiryMetadata(t *testing.T) {
+	router := setupRefreshRouter(time.Hour, 30*time.Minute)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  "test-device-123",
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		ExpiresAt        string `json:"expires_at"`
+		RefreshableUntil string `json:"refreshable_until"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, response.ExpiresAt)
+	if err != nil {
+		t.Fatalf("expires_at is not RFC3339: %q", response.ExpiresAt)
+	}
+	refreshableUntil, err := time.Parse(time.RFC3339, response.RefreshableUntil)
+	if err != nil {
+		t.Fatalf("refreshable_until is not RFC3339: %q", response.RefreshableUntil)
+	}
+	if got := refreshableUntil.Sub(expiresAt); got != 30*time.Minute {
+		t.Errorf("Expected the grace window between expiry and refreshable_until, got %v", got)
+	}
+}
+
+// TestSessionRefreshWithinGrace walks the recovery path a long game takes: the
+// token expires mid-game, solve calls start returning token_expired (not
+// invalid_token, so the client knows to refresh), and the refreshed token
+// carries the same claims so the givens-hash check still passes
+func TestSessionRefreshWithinGrace(t *testing.T) {
+	router := setupRefreshRouter(100*time.Millisecond, 10*time.Second)
+	token := getValidToken(router)
+	givens := testSessionGivens(t)
+
+	time.Sleep(250 * time.Millisecond) // past expiry, well inside grace
+
+	// Solve calls must name the expiry specifically so clients refresh
+	// instead of restarting
+	w := postSolveNext(router, token, givens)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401 for expired token, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if code := responseErrorCode(t, w); code != ErrCodeTokenExpired {
+		t.Errorf("Expected error code %q, got %q", ErrCodeTokenExpired, code)
+	}
+
+	w = postSessionRefresh(router, token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 from refresh, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var refreshed struct {
+		Token     string `json:"token"`
+		PuzzleID  string `json:"puzzle_id"`
+		ExpiresAt string `json:"expires_at"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &refreshed); err != nil {
+		t.Fatalf("Failed to parse refresh response: %v", err)
+	}
+	if refreshed.Token == "" || refreshed.Token == token {
+		t.Fatal("Expected a newly issued token")
+	}
+	// The timestamp is second-resolution, so a 100ms expiry only gets it to
+	// "this second" - enough to show it moved past the original expiry
+	expiresAt, err := time.Parse(time.RFC3339, refreshed.ExpiresAt)
+	if err != nil || expiresAt.Before(time.Now().Truncate(time.Second)) {
+		t.Errorf("Expected a renewed expires_at, got %q", refreshed.ExpiresAt)
+	}
+
+	// The refreshed token must keep working against the session's givens:
+	// solve calls succeed and the save path's givens-hash check passes
+	if w = postSolveNext(router, refreshed.Token, givens); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 from solve with refreshed token, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	w = postSessionSave(router, map[string]interface{}{
+		"token":         refreshed.Token,
+		"current_board": givens,
+	})
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 from save with refreshed token, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestSessionRefreshAfterGrace verifies the grace window is a hard boundary
+func TestSessionRefreshAfterGrace(t *testing.T) {
+	router := setupRefreshRouter(50*time.Millisecond, 50*time.Millisecond)
+	token := getValidToken(router)
+
+	time.Sleep(250 * time.Millisecond) // past expiry plus grace
+
+	w := postSessionRefresh(router, token)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401 past the grace window, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if code := responseErrorCode(t, w); code != ErrCodeTokenExpired {
+		t.Errorf("Expected error code %q, got %q", ErrCodeTokenExpired, code)
+	}
+}
+
+// TestSessionRefreshInvalidToken verifies tampered tokens stay invalid_token:
+// only authentic tokens get the grace treatment
+func TestSessionRefreshInvalidToken(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	for name, bad := range map[string]string{
+		"garbage":  "not-a-token",
+		"tampered": "x" + token,
+	} {
+		w := postSessionRefresh(router, bad)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("[%s] Expected status 401, got %d. Body: %s", name, w.Code, w.Body.String())
+			continue
+		}
+		if code := responseErrorCode(t, w); code != ErrCodeInvalidToken {
+			t.Errorf("[%s] Expected error code %q, got %q", name, ErrCodeInvalidToken, code)
+		}
+	}
+}
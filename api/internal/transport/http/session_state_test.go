@@ -0,0 +1,238 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testSessionGivens returns the givens board for the session created by
+// getValidToken (seed "test-seed", difficulty "medium")
+func testSessionGivens(t *testing.T) []int {
+	t.Helper()
+	givens, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("Failed to load test puzzle: %v", err)
+	}
+	return givens
+}
+
+func postSessionSave(router *gin.Engine, body map[string]interface{}) *httptest.ResponseRecorder {
+	bodyBytes, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/save", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func getSessionRestore(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/session/restore?token="+token, nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSessionSaveRestore(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	givens := testSessionGivens(t)
+
+	t.Run("SaveAndRestoreRoundTrip", func(t *testing.T) {
+		// Play one move on top of the givens
+		board := make([]int, constants.TotalCells)
+		copy(board, givens)
+		userCell := -1
+		for i, g := range givens {
+			if g == 0 {
+				userCell = i
+				break
+			}
+		}
+		board[userCell] = 4
+
+		w := postSessionSave(router, map[string]interface{}{
+			"token":           token,
+			"current_board":   board,
+			"history":         []map[string]interface{}{{"cell": userCell, "digit": 4}},
+			"elapsed_seconds": 95,
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = getSessionRestore(router, token)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			PuzzleID string `json:"puzzle_id"`
+			State    struct {
+				CurrentBoard   []int                    `json:"current_board"`
+				History        []map[string]interface{} `json:"history"`
+				ElapsedSeconds int                      `json:"elapsed_seconds"`
+			} `json:"state"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.PuzzleID != "test-seed-medium" {
+			t.Errorf("Expected puzzle_id 'test-seed-medium', got %q", response.PuzzleID)
+		}
+		if len(response.State.CurrentBoard) != constants.TotalCells {
+			t.Fatalf("Expected %d board cells, got %d", constants.TotalCells, len(response.State.CurrentBoard))
+		}
+		if response.State.CurrentBoard[userCell] != 4 {
+			t.Errorf("Expected restored board to keep user entry, got %d", response.State.CurrentBoard[userCell])
+		}
+		if response.State.ElapsedSeconds != 95 {
+			t.Errorf("Expected elapsed_seconds 95, got %d", response.State.ElapsedSeconds)
+		}
+		if len(response.State.History) != 1 {
+			t.Errorf("Expected 1 history entry, got %d", len(response.State.History))
+		}
+	})
+
+	t.Run("AnnotationsRoundTrip", func(t *testing.T) {
+		annotations := make([]map[string]interface{}, constants.TotalCells)
+		for i := range annotations {
+			annotations[i] = map[string]interface{}{}
+		}
+		annotations[5] = map[string]interface{}{
+			"color_index":  3,
+			"corner_marks": []int{1, 2},
+			"center_marks": []int{7, 8, 9},
+		}
+
+		w := postSessionSave(router, map[string]interface{}{
+			"token":         token,
+			"current_board": givens,
+			"annotations":   annotations,
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = getSessionRestore(router, token)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			State struct {
+				Annotations []struct {
+					ColorIndex  int   `json:"color_index"`
+					CornerMarks []int `json:"corner_marks"`
+					CenterMarks []int `json:"center_marks"`
+				} `json:"annotations"`
+			} `json:"state"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.State.Annotations) != constants.TotalCells {
+			t.Fatalf("Expected %d annotation cells, got %d", constants.TotalCells, len(response.State.Annotations))
+		}
+		got := response.State.Annotations[5]
+		if got.ColorIndex != 3 || fmt.Sprint(got.CornerMarks) != "[1 2]" || fmt.Sprint(got.CenterMarks) != "[7 8 9]" {
+			t.Errorf("Annotations did not round-trip: %+v", got)
+		}
+	})
+
+	t.Run("RejectsInvalidAnnotations", func(t *testing.T) {
+		annotations := make([]map[string]interface{}, constants.TotalCells)
+		for i := range annotations {
+			annotations[i] = map[string]interface{}{}
+		}
+		annotations[9] = map[string]interface{}{"center_marks": []int{12}}
+
+		w := postSessionSave(router, map[string]interface{}{
+			"token":         token,
+			"current_board": givens,
+			"annotations":   annotations,
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("RestoreWithoutSaveReturns404", func(t *testing.T) {
+		freshToken := getValidToken(router)
+		w := getSessionRestore(router, freshToken)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("RejectsModifiedGivens", func(t *testing.T) {
+		board := make([]int, constants.TotalCells)
+		copy(board, givens)
+		for i, g := range givens {
+			if g != 0 {
+				// Overwrite a given with a different digit
+				board[i] = g%constants.GridSize + 1
+				break
+			}
+		}
+
+		w := postSessionSave(router, map[string]interface{}{
+			"token":         token,
+			"current_board": board,
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("RejectsInvalidToken", func(t *testing.T) {
+		w := postSessionSave(router, map[string]interface{}{
+			"token":         "not-a-token",
+			"current_board": givens,
+		})
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401 on save, got %d", w.Code)
+		}
+
+		w = getSessionRestore(router, "not-a-token")
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401 on restore, got %d", w.Code)
+		}
+	})
+
+	t.Run("RejectsWrongBoardSize", func(t *testing.T) {
+		w := postSessionSave(router, map[string]interface{}{
+			"token":         token,
+			"current_board": []int{1, 2, 3},
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("RejectsOversizedHistory", func(t *testing.T) {
+		history := make([]map[string]interface{}, constants.MaxSavedHistory+1)
+		for i := range history {
+			history[i] = map[string]interface{}{"cell": i % constants.TotalCells}
+		}
+
+		w := postSessionSave(router, map[string]interface{}{
+			"token":         token,
+			"current_board": givens,
+			"history":       history,
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+		if !bytes.Contains(w.Body.Bytes(), []byte(fmt.Sprintf("%d", constants.MaxSavedHistory))) {
+			t.Errorf("Expected error to mention the history cap, got: %s", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// countSolveFallbacks wraps the solveGivens hook with a counter for the
+// duration of a test, so tests can assert whether handlers had to re-derive
+// the solution instead of reading it from the token.
+func countSolveFallbacks(t *testing.T) *int {
+	t.Helper()
+	calls := 0
+	original := solveGivens
+	solveGivens = func(board []int) []int {
+		calls++
+		return original(board)
+	}
+	t.Cleanup(func() { solveGivens = original })
+	return &calls
+}
+
+func TestSealOpenSolution(t *testing.T) {
+	_, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	sealed := sealSolution("secret", "pid", solution)
+	if sealed == "" {
+		t.Fatal("Expected non-empty sealed solution")
+	}
+
+	opened := openSolution("secret", "pid", sealed)
+	if opened == nil {
+		t.Fatal("Expected sealed solution to round-trip")
+	}
+	for i, v := range solution {
+		if opened[i] != v {
+			t.Fatalf("Cell %d: expected %d, got %d", i, v, opened[i])
+		}
+	}
+
+	// Wrong secret or puzzle ID must not yield a usable grid
+	if openSolution("other-secret", "pid", sealed) != nil {
+		t.Error("Expected nil for wrong secret")
+	}
+	if openSolution("secret", "other-pid", sealed) != nil {
+		t.Error("Expected nil for wrong puzzle ID")
+	}
+	if openSolution("secret", "pid", "") != nil {
+		t.Error("Expected nil for empty claim")
+	}
+	if openSolution("secret", "pid", "not-base64!") != nil {
+		t.Error("Expected nil for undecodable claim")
+	}
+}
+
+func TestSessionTokenCarriesSolution(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	payload, err := base64.URLEncoding.DecodeString(strings.Split(token, ".")[0])
+	if err != nil {
+		t.Fatalf("Failed to decode token payload: %v", err)
+	}
+
+	var session SessionToken
+	if err := json.Unmarshal(payload, &session); err != nil {
+		t.Fatalf("Failed to parse token payload: %v", err)
+	}
+	if session.Solution == "" {
+		t.Fatal("Expected session token to carry a sealed solution")
+	}
+
+	// The claim must decode to the loader's solution for the session's puzzle
+	_, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	opened := openSolution("test-secret-key", session.PuzzleID, session.Solution)
+	if opened == nil {
+		t.Fatal("Expected sealed solution to open with the server secret")
+	}
+	for i, v := range solution {
+		if opened[i] != v {
+			t.Fatalf("Cell %d: expected %d, got %d", i, v, opened[i])
+		}
+	}
+
+	// The raw payload must not expose the solution digits directly
+	var digits strings.Builder
+	for _, v := range solution {
+		digits.WriteByte(byte('0' + v))
+	}
+	if strings.Contains(string(payload), digits.String()) {
+		t.Error("Token payload contains the raw solution string")
+	}
+}
+
+func TestSessionSolution_TokenVersusFallback(t *testing.T) {
+	setupRouter() // initializes cfg
+	calls := countSolveFallbacks(t)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	puzzleID := "test-seed" + constants.PuzzleIDDl + "medium"
+
+	// A token carrying the sealed solution never triggers the fallback
+	session := &SessionToken{
+		PuzzleID: puzzleID,
+		Solution: sealSolution("test-secret-key", puzzleID, solution),
+	}
+	got := sessionSolution(session, givens)
+	if got == nil {
+		t.Fatal("Expected solution from token")
+	}
+	if *calls != 0 {
+		t.Errorf("Expected no solve fallback, got %d calls", *calls)
+	}
+	for i, v := range solution {
+		if got[i] != v {
+			t.Fatalf("Cell %d: expected %d, got %d", i, v, got[i])
+		}
+	}
+
+	// A legacy token without the claim falls back to re-solving the givens
+	legacy := &SessionToken{PuzzleID: puzzleID}
+	got = sessionSolution(legacy, givens)
+	if got == nil {
+		t.Fatal("Expected solution from fallback solve")
+	}
+	if *calls != 1 {
+		t.Errorf("Expected one solve fallback, got %d calls", *calls)
+	}
+	for i, v := range solution {
+		if got[i] != v {
+			t.Fatalf("Cell %d: expected %d, got %d", i, v, got[i])
+		}
+	}
+}
+
+func TestValidateWithTokenSolution(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	calls := countSolveFallbacks(t)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// Place a wrong (but non-conflicting) digit in the first empty cell
+	wrongBoard := make([]int, len(givens))
+	copy(wrongBoard, givens)
+	wrongCell := -1
+	for i, v := range givens {
+		if v != 0 {
+			continue
+		}
+		for d := 1; d <= constants.GridSize; d++ {
+			if d == solution[i] {
+				continue
+			}
+			wrongBoard[i] = d
+			if len(dp.FindConflicts(wrongBoard)) == 0 {
+				wrongCell = i
+				break
+			}
+		}
+		if wrongCell >= 0 {
+			break
+		}
+		wrongBoard[i] = 0
+	}
+	if wrongCell < 0 {
+		t.Fatal("Could not construct a non-conflicting wrong entry")
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"token": token, "board": wrongBoard})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/validate", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["valid"] != false {
+		t.Errorf("Expected valid=false for wrong entry, got %v", response["valid"])
+	}
+	if response["reason"] != "incorrect" {
+		t.Errorf("Expected reason 'incorrect', got %v", response["reason"])
+	}
+	if *calls != 0 {
+		t.Errorf("Expected no solve fallback with token solution, got %d calls", *calls)
+	}
+}
+
+func TestSolveNextWithLegacyToken(t *testing.T) {
+	router := setupRouter()
+
+	// Hand-build a token without the solution claim, as an old client's
+	// session would look
+	givens, _, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	now := time.Now()
+	legacy := SessionToken{
+		DeviceID:   "test-device-123",
+		PuzzleID:   "test-seed" + constants.PuzzleIDDl + "medium",
+		Seed:       "test-seed",
+		Difficulty: "medium",
+		StartedAt:  now,
+		ExpiresAt:  now.Add(constants.SessionTokenExpiry),
+	}
+	token, err := createToken("test-secret-key", legacy)
+	if err != nil {
+		t.Fatalf("Failed to create legacy token: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"token": token, "board": givens})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for legacy token, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if _, ok := response["move"]; !ok {
+		t.Error("Expected a move in the response")
+	}
+}
+
+func TestPuzzleResponseIsGiven(t *testing.T) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/test-seed?d=medium", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Givens  []int  `json:"givens"`
+		IsGiven []bool `json:"is_given"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.IsGiven) != constants.TotalCells {
+		t.Fatalf("Expected %d is_given entries, got %d", constants.TotalCells, len(response.IsGiven))
+	}
+	for i, v := range response.Givens {
+		if response.IsGiven[i] != (v != 0) {
+			t.Errorf("Cell %d: is_given=%v but given value is %d", i, response.IsGiven[i], v)
+		}
+	}
+}
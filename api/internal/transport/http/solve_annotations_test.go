@@ -0,0 +1,48 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+
+	"sudoku-api/internal/sudoku/human"
+)
+
+// TestSolveNextCenterMarkAnnotations checks that center marks stand in for
+// the candidate layer: a solve/next request carrying annotations whose
+// center marks mirror a candidate set gets the same move as one carrying the
+// candidates directly.
+func TestSolveNextCenterMarkAnnotations(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	givens := testSessionGivens(t)
+
+	board := human.NewBoard(givens)
+	board.InitCandidates()
+	candidates := board.GetCandidates()
+
+	withCandidates := solveNextResponse(t, router, map[string]interface{}{
+		"token":      token,
+		"board":      givens,
+		"givens":     givens,
+		"candidates": candidates,
+	})
+
+	annotations := make([]map[string]interface{}, len(candidates))
+	for i, digits := range candidates {
+		annotations[i] = map[string]interface{}{"center_marks": digits}
+	}
+	withAnnotations := solveNextResponse(t, router, map[string]interface{}{
+		"token":       token,
+		"board":       givens,
+		"givens":      givens,
+		"annotations": annotations,
+	})
+
+	if withCandidates["move"] == nil {
+		t.Fatal("expected a move from the candidate request")
+	}
+	if !reflect.DeepEqual(withCandidates["move"], withAnnotations["move"]) {
+		t.Errorf("annotation-driven move diverges from candidate-driven move:\ncandidates:  %v\nannotations: %v",
+			withCandidates["move"], withAnnotations["move"])
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/pkg/constants"
+)
+
+// TestSolveCellEndpoint covers /api/solve/cell, which returns a move touching
+// the requested cell instead of wherever the solver would move next
+func TestSolveCellEndpoint(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("failed to resolve test puzzle: %v", err)
+	}
+
+	postSolveCell := func(t *testing.T, row, col int) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]interface{}{
+			"token": token,
+			"board": givens,
+			"row":   row,
+			"col":   col,
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/solve/cell", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		return w, response
+	}
+
+	t.Run("returns a move relating to the requested cell", func(t *testing.T) {
+		// Pick the first empty cell so some technique is likely to touch it
+		target := -1
+		for i, v := range givens {
+			if v == 0 {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			t.Fatal("test puzzle has no empty cells")
+		}
+
+		w, response := postSolveCell(t, target/constants.GridSize, target%constants.GridSize)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		move, ok := response["move"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected a move, got %v", response["move"])
+		}
+		if move["technique"] == "" {
+			t.Errorf("expected the move to name its technique, got %v", move)
+		}
+	})
+
+	t.Run("rejects out-of-range cell", func(t *testing.T) {
+		w, response := postSolveCell(t, 9, 0)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+		envelope, _ := response["error"].(map[string]interface{})
+		if envelope["code"] != ErrCodeInvalidCell {
+			t.Errorf("expected code %q, got %v", ErrCodeInvalidCell, envelope["code"])
+		}
+	})
+}
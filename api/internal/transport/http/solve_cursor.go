@@ -0,0 +1,180 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSolvePageLimit is the page size when ?cursor is given without a limit
+const defaultSolvePageLimit = 25
+
+// solveCursor is the payload of a continuation token for paged /solve/all
+// requests. The board hash binds the cursor to the request body it was
+// issued for: resuming means re-POSTing the same board with the new cursor,
+// and the deterministic solver replays the same move list server-side.
+type solveCursor struct {
+	Index     int    `json:"index"`
+	BoardHash string `json:"board_hash"`
+}
+
+// solvePaging carries the parsed paging parameters through the solve loop
+type solvePaging struct {
+	Enabled bool
+	Start   int
+	Limit   int
+	Secret  string
+	// BoardHash of the request board, echoed into issued cursors
+	BoardHash string
+}
+
+// moveCap is how many moves the solver must compute before the page can be
+// served: the page itself plus one lookahead move so hasMore is exact
+func (p *solvePaging) moveCap() int {
+	return p.Start + p.Limit + 1
+}
+
+// createSolveCursor signs a continuation token in the same
+// base64(payload).base64(HMAC) format as session tokens
+func createSolveCursor(secret string, cursor solveCursor) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.URLEncoding.EncodeToString(payload)
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(encoded))
+	sig := base64.URLEncoding.EncodeToString(h.Sum(nil))
+	return encoded + "." + sig
+}
+
+// verifySolveCursor validates a continuation token and returns the move
+// index to resume from. Tokens signed for a different board are rejected so
+// a cursor cannot be replayed against another puzzle state.
+func verifySolveCursor(secret, token, boardHash string) (int, error) {
+	encoded, sig, ok := splitToken(token)
+	if !ok {
+		return 0, fmt.Errorf("invalid cursor format")
+	}
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(encoded))
+	expectedSig := base64.URLEncoding.EncodeToString(h.Sum(nil))
+	if subtle.ConstantTimeCompare([]byte(sig), []byte(expectedSig)) != 1 {
+		return 0, fmt.Errorf("invalid cursor signature")
+	}
+
+	payload, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, err
+	}
+	var cursor solveCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return 0, err
+	}
+	if cursor.Index < 0 {
+		return 0, fmt.Errorf("invalid cursor index")
+	}
+	if cursor.BoardHash != boardHash {
+		return 0, fmt.Errorf("cursor was issued for a different board")
+	}
+	return cursor.Index, nil
+}
+
+// splitToken splits an encoded.signature token into its two parts
+func splitToken(token string) (encoded, sig string, ok bool) {
+	for i := 0; i < len(token); i++ {
+		if token[i] == '.' {
+			return token[:i], token[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// solvePagingFromQuery parses the optional cursor and limit query parameters
+// on /solve/all. Paging is off unless at least one of them is present;
+// cursor "0" (or empty with a limit) starts from the first move, anything
+// else must be a continuation token issued for this board.
+func solvePagingFromQuery(c *gin.Context, secret, boardHash string) (*solvePaging, bool) {
+	rawCursor := c.Query("cursor")
+	rawLimit := c.Query("limit")
+	if rawCursor == "" && rawLimit == "" {
+		return &solvePaging{}, true
+	}
+
+	paging := &solvePaging{
+		Enabled:   true,
+		Limit:     defaultSolvePageLimit,
+		Secret:    secret,
+		BoardHash: boardHash,
+	}
+
+	if rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit < 1 {
+			apiError(c, http.StatusBadRequest, ErrCodeInvalidCursor, "limit must be a positive integer")
+			return nil, false
+		}
+		paging.Limit = limit
+	}
+
+	if rawCursor != "" && rawCursor != "0" {
+		index, err := verifySolveCursor(secret, rawCursor, boardHash)
+		if err != nil {
+			apiError(c, http.StatusBadRequest, ErrCodeInvalidCursor, "invalid cursor: "+err.Error())
+			return nil, false
+		}
+		paging.Start = index
+	}
+
+	return paging, true
+}
+
+// pagedSolveAllResponse slices one page out of the computed move list and
+// attaches the continuation token. The solver computed at most moveCap()
+// moves, so a move past the end of the page proves there is more to fetch.
+// In delta format the page's moves are diffed starting from the state before
+// the page, which for every page but the first is the previous move's
+// snapshot - the error-fix flow needs no special casing because fix moves
+// are ordinary entries in the replayed list.
+func pagedSolveAllResponse(p *solvePaging, delta bool, initialCells []int, initialCands [][]int, moves []MoveResult, solved bool, finalBoard []int) gin.H {
+	end := p.Start + p.Limit
+	if end > len(moves) {
+		end = len(moves)
+	}
+	start := p.Start
+	if start > end {
+		start = end
+	}
+	hasMore := len(moves) > end
+
+	if start > 0 {
+		prev := moves[start-1]
+		initialCells, initialCands = prev.Board, prev.Candidates
+	}
+	if hasMore {
+		// Report the state as of the page boundary, not the lookahead move
+		finalBoard = moves[end-1].Board
+	}
+
+	resp := solveAllResponse(delta, initialCells, initialCands, moves[start:end], solved && !hasMore, finalBoard)
+	resp["hasMore"] = hasMore
+	if hasMore {
+		resp["nextCursor"] = createSolveCursor(p.Secret, solveCursor{Index: end, BoardHash: p.BoardHash})
+	}
+	return resp
+}
+
+// writeSolveAllResponse emits either the classic full response or, when the
+// request carried paging parameters, one page of it
+func writeSolveAllResponse(c *gin.Context, p *solvePaging, delta bool, initialCells []int, initialCands [][]int, moves []MoveResult, solved bool, finalBoard []int) {
+	if p.Enabled {
+		c.JSON(http.StatusOK, pagedSolveAllResponse(p, delta, initialCells, initialCands, moves, solved, finalBoard))
+		return
+	}
+	c.JSON(http.StatusOK, solveAllResponse(delta, initialCells, initialCands, moves, solved, finalBoard))
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+)
+
+// TestSolveAllDeltaFormat covers the ?format=delta option on /api/solve/all,
+// which replaces per-move board/candidate snapshots with diffs
+func TestSolveAllDeltaFormat(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("failed to resolve test puzzle: %v", err)
+	}
+
+	postSolveAll := func(t *testing.T, query string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]interface{}{
+			"token":  token,
+			"board":  givens,
+			"givens": givens,
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/solve/all"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		return w, response
+	}
+
+	t.Run("delta moves carry diffs instead of snapshots", func(t *testing.T) {
+		w, response := postSolveAll(t, "?format=delta")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if response["format"] != "delta" {
+			t.Errorf("expected format \"delta\", got %v", response["format"])
+		}
+		initial, ok := response["initialBoard"].([]interface{})
+		if !ok || len(initial) != len(givens) {
+			t.Fatalf("expected initialBoard with %d cells, got %v", len(givens), response["initialBoard"])
+		}
+		if _, present := response["initialCandidates"]; !present {
+			t.Error("expected initialCandidates in delta response")
+		}
+
+		moves, ok := response["moves"].([]interface{})
+		if !ok || len(moves) == 0 {
+			t.Fatalf("expected moves, got %v", response["moves"])
+		}
+
+		// Replay the cell diffs over the initial board and compare against
+		// the final board the solver reports
+		cells := make([]int, len(initial))
+		for i, v := range initial {
+			cells[i] = int(v.(float64))
+		}
+		for i, raw := range moves {
+			mr, _ := raw.(map[string]interface{})
+			if _, present := mr["board"]; present {
+				t.Fatalf("move %d still carries a board snapshot", i)
+			}
+			if _, present := mr["candidates"]; present {
+				t.Fatalf("move %d still carries a candidates snapshot", i)
+			}
+			diff, ok := mr["diff"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("move %d has no diff: %v", i, raw)
+			}
+			cellDiffs, _ := diff["cells"].([]interface{})
+			for _, raw := range cellDiffs {
+				cd, _ := raw.(map[string]interface{})
+				cells[int(cd["index"].(float64))] = int(cd["new"].(float64))
+			}
+		}
+
+		finalBoard, _ := response["finalBoard"].([]interface{})
+		if len(finalBoard) != len(cells) {
+			t.Fatalf("expected finalBoard with %d cells, got %v", len(cells), response["finalBoard"])
+		}
+		for i, v := range finalBoard {
+			if cells[i] != int(v.(float64)) {
+				t.Fatalf("cell %d replays to %d, finalBoard has %v", i, cells[i], v)
+			}
+		}
+		if response["solved"] != true {
+			t.Errorf("expected solved=true, got %v", response["solved"])
+		}
+	})
+
+	t.Run("default format keeps full snapshots", func(t *testing.T) {
+		w, response := postSolveAll(t, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		moves, _ := response["moves"].([]interface{})
+		if len(moves) == 0 {
+			t.Fatal("expected moves in default response")
+		}
+		first, _ := moves[0].(map[string]interface{})
+		if _, present := first["board"]; !present {
+			t.Error("expected full board snapshots in default format")
+		}
+		if _, present := first["diff"]; present {
+			t.Error("default format should not carry diffs")
+		}
+	})
+
+	t.Run("unknown format is rejected", func(t *testing.T) {
+		w, response := postSolveAll(t, "?format=sparse")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+		envelope, _ := response["error"].(map[string]interface{})
+		if envelope["code"] != ErrCodeInvalidFormat {
+			t.Errorf("expected code %q, got %v", ErrCodeInvalidFormat, envelope["code"])
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+)
+
+// TestSolveNextSolvedBoard verifies that a genuinely solved board gets a
+// terminal "solved" move instead of a null hint.
+func TestSolveNextSolvedBoard(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	response := solveNextResponse(t, router, map[string]interface{}{
+		"token":  token,
+		"board":  solution,
+		"givens": givens,
+	})
+
+	move, ok := response["move"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected move in response, got: %v", response)
+	}
+	if technique := move["technique"]; technique != "solved" {
+		t.Errorf("Expected technique 'solved', got %v", technique)
+	}
+	if action := move["action"]; action != "done" {
+		t.Errorf("Expected action 'done', got %v", action)
+	}
+	if solved, _ := response["solved"].(bool); !solved {
+		t.Errorf("Expected solved=true in response, got %v", response["solved"])
+	}
+
+	// Without a hint log every filled cell counts as the player's
+	userEntries := 0
+	for _, g := range givens {
+		if g == 0 {
+			userEntries++
+		}
+	}
+	stats, ok := response["stats"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected stats in response, got: %v", response)
+	}
+	if n := int(stats["filledByUser"].(float64)); n != userEntries {
+		t.Errorf("Expected filledByUser %d, got %d", userEntries, n)
+	}
+	if n := int(stats["filledByHints"].(float64)); n != 0 {
+		t.Errorf("Expected filledByHints 0, got %d", n)
+	}
+}
+
+// TestSolveNextSolvedBoardHintStats verifies that the client's hint log
+// splits the completion stats, with given cells and duplicates ignored.
+func TestSolveNextSolvedBoardHintStats(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	userEntries := 0
+	givenCell := -1
+	var hintCells []int
+	for i, g := range givens {
+		if g != 0 {
+			givenCell = i
+			continue
+		}
+		userEntries++
+		if len(hintCells) < 3 {
+			hintCells = append(hintCells, i)
+		}
+	}
+	// A given cell and a duplicate must not inflate the hint count
+	hintCells = append(hintCells, givenCell, hintCells[0])
+
+	response := solveNextResponse(t, router, map[string]interface{}{
+		"token":     token,
+		"board":     solution,
+		"givens":    givens,
+		"hintCells": hintCells,
+	})
+
+	stats, ok := response["stats"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected stats in response, got: %v", response)
+	}
+	if n := int(stats["filledByHints"].(float64)); n != 3 {
+		t.Errorf("Expected filledByHints 3, got %d", n)
+	}
+	if n := int(stats["filledByUser"].(float64)); n != userEntries-3 {
+		t.Errorf("Expected filledByUser %d, got %d", userEntries-3, n)
+	}
+}
+
+// TestSolveNextUnfinishedBoard verifies that an unfinished, valid board
+// still gets a technique move rather than a premature completion verdict.
+func TestSolveNextUnfinishedBoard(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, _, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	response := solveNextResponse(t, router, map[string]interface{}{
+		"token":  token,
+		"board":  givens,
+		"givens": givens,
+	})
+
+	move, ok := response["move"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected move in response, got: %v", response)
+	}
+	if technique := move["technique"]; technique == "solved" || technique == "" {
+		t.Errorf("Expected a technique move, got %v", technique)
+	}
+	if solved, _ := response["solved"].(bool); solved {
+		t.Error("Expected solved to be absent or false for an unfinished board")
+	}
+}
+
+// TestSolveNextCompletedButWrong verifies that a fully filled board with a
+// wrong entry gets a fix-error move diffed against the solution, rather than
+// the conflict scan blaming an arbitrary cell of a conflicting pair.
+func TestSolveNextCompletedButWrong(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// Fill the whole board correctly, then break one user-entered cell
+	board := make([]int, len(solution))
+	copy(board, solution)
+
+	badCell := -1
+	userEntries := 0
+	for i, g := range givens {
+		if g == 0 {
+			userEntries++
+			if badCell < 0 {
+				badCell = i
+			}
+		}
+	}
+	if badCell < 0 {
+		t.Fatal("test puzzle has no user-entered cells")
+	}
+	badDigit := solution[badCell]%9 + 1
+	board[badCell] = badDigit
+
+	response := solveNextResponse(t, router, map[string]interface{}{
+		"token":  token,
+		"board":  board,
+		"givens": givens,
+	})
+
+	move, ok := response["move"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected move in response, got: %v", response)
+	}
+	if technique := move["technique"]; technique != "fix-error" {
+		t.Errorf("Expected technique 'fix-error', got %v", technique)
+	}
+	if digit := int(move["digit"].(float64)); digit != badDigit {
+		t.Errorf("Expected fixed digit %d, got %d", badDigit, digit)
+	}
+
+	targets := move["targets"].([]interface{})
+	target := targets[0].(map[string]interface{})
+	if row, col := int(target["row"].(float64)), int(target["col"].(float64)); row*9+col != badCell {
+		t.Errorf("Expected fix at cell %d, got R%dC%d", badCell, row+1, col+1)
+	}
+
+	if count := int(move["userEntryCount"].(float64)); count != userEntries {
+		t.Errorf("Expected userEntryCount %d, got %d", userEntries, count)
+	}
+
+	// The wrong entry is cleared on the returned board
+	newBoard := response["board"].([]interface{})
+	if v := int(newBoard[badCell].(float64)); v != 0 {
+		t.Errorf("Expected the wrong entry at cell %d to be cleared, got %d", badCell, v)
+	}
+}
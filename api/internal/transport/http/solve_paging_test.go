@@ -0,0 +1,139 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+)
+
+// TestSolveAllPaging covers the ?cursor/?limit paging option on
+// /api/solve/all, which serves the move list in pages with a signed
+// continuation token
+func TestSolveAllPaging(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, _, _, err := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	if err != nil {
+		t.Fatalf("failed to resolve test puzzle: %v", err)
+	}
+
+	postSolveAll := func(t *testing.T, query string, board []int) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]interface{}{
+			"token":  token,
+			"board":  board,
+			"givens": givens,
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/solve/all"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		return w, response
+	}
+
+	// moveJSON normalizes a decoded move entry for comparison across requests
+	moveJSON := func(t *testing.T, entry interface{}) string {
+		t.Helper()
+		raw, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatalf("marshal move: %v", err)
+		}
+		return string(raw)
+	}
+
+	t.Run("paging concatenates to the full move list", func(t *testing.T) {
+		w, full := postSolveAll(t, "", givens)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		fullMoves := full["moves"].([]interface{})
+		if len(fullMoves) <= 7 {
+			t.Fatalf("test puzzle solves in %d moves; need more than one page", len(fullMoves))
+		}
+
+		var paged []interface{}
+		query := "?cursor=0&limit=7"
+		for page := 0; ; page++ {
+			if page > len(fullMoves) {
+				t.Fatal("paging never terminated")
+			}
+			w, response := postSolveAll(t, query, givens)
+			if w.Code != http.StatusOK {
+				t.Fatalf("page %d: expected 200, got %d: %s", page, w.Code, w.Body.String())
+			}
+			moves := response["moves"].([]interface{})
+			if len(moves) > 7 {
+				t.Fatalf("page %d: %d moves exceeds limit", page, len(moves))
+			}
+			paged = append(paged, moves...)
+
+			if response["hasMore"] != true {
+				if response["solved"] != full["solved"] {
+					t.Errorf("final page solved = %v, want %v", response["solved"], full["solved"])
+				}
+				if moveJSON(t, response["finalBoard"]) != moveJSON(t, full["finalBoard"]) {
+					t.Error("final page board differs from the non-paged final board")
+				}
+				break
+			}
+			cursor, ok := response["nextCursor"].(string)
+			if !ok || cursor == "" {
+				t.Fatalf("page %d: hasMore without nextCursor: %v", page, response)
+			}
+			query = "?cursor=" + cursor + "&limit=7"
+		}
+
+		if len(paged) != len(fullMoves) {
+			t.Fatalf("paged through %d moves, non-paged returned %d", len(paged), len(fullMoves))
+		}
+		for i := range fullMoves {
+			if moveJSON(t, paged[i]) != moveJSON(t, fullMoves[i]) {
+				t.Errorf("move %d differs between paged and non-paged responses", i)
+			}
+		}
+	})
+
+	t.Run("forged cursor is rejected", func(t *testing.T) {
+		w, _ := postSolveAll(t, "?cursor=not-a-cursor&limit=7", givens)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for malformed cursor, got %d", w.Code)
+		}
+
+		// A genuine cursor with a tampered signature must also fail
+		_, first := postSolveAll(t, "?cursor=0&limit=7", givens)
+		cursor := first["nextCursor"].(string)
+		tampered := cursor[:len(cursor)-2] + "xx"
+		w, _ = postSolveAll(t, "?cursor="+tampered+"&limit=7", givens)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for tampered cursor, got %d", w.Code)
+		}
+	})
+
+	t.Run("cursor is bound to the board it was issued for", func(t *testing.T) {
+		_, first := postSolveAll(t, "?cursor=0&limit=7", givens)
+		cursor := first["nextCursor"].(string)
+
+		other := make([]int, len(givens))
+		copy(other, givens)
+		for i, v := range other {
+			if v != 0 {
+				other[i] = 0 // drop a given so the board hash changes
+				break
+			}
+		}
+		w, _ := postSolveAll(t, "?cursor="+cursor+"&limit=7", other)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for cursor issued for another board, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sseEvent is one parsed server-sent event
+type sseEvent struct {
+	name string
+	data json.RawMessage
+}
+
+// parseSSE splits a text/event-stream body into its events
+func parseSSE(t *testing.T, body string) []sseEvent {
+	t.Helper()
+	var events []sseEvent
+	for _, block := range strings.Split(body, "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		var ev sseEvent
+		for _, line := range strings.Split(block, "\n") {
+			switch {
+			case strings.HasPrefix(line, "event:"):
+				ev.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				ev.data = json.RawMessage(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+			}
+		}
+		if ev.name == "" {
+			t.Fatalf("event block without a name: %q", block)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// boardQueryString renders a cells slice as the stream endpoint's board
+// query parameter
+func boardQueryString(board []int) string {
+	var b strings.Builder
+	for _, v := range board {
+		b.WriteByte(byte('0' + v))
+	}
+	return b.String()
+}
+
+// TestSolveStreamMatchesSolveFull runs the same solve through /solve/full and
+// /solve/stream and checks the stream delivers the identical move list: a
+// state event, one move event per move in order, then a done event agreeing
+// on status and final board.
+func TestSolveStreamMatchesSolveFull(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	board := getPuzzleGivens(t, router, "static-0")
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"token": token, "board": board})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/full", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /api/solve/full: status %d. Body: %s", w.Code, w.Body.String())
+	}
+	var full struct {
+		Moves []struct {
+			Technique string `json:"technique"`
+			StepIndex int    `json:"step_index"`
+		} `json:"moves"`
+		StoppedReason string `json:"stopped_reason"`
+		FinalBoard    []int  `json:"final_board"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &full); err != nil {
+		t.Fatalf("Failed to parse solve/full response: %v", err)
+	}
+	if len(full.Moves) == 0 {
+		t.Fatal("solve/full produced no moves; the comparison needs a real solve")
+	}
+
+	sw := httptest.NewRecorder()
+	sreq, _ := http.NewRequest("GET", "/api/solve/stream?token="+token+"&board="+boardQueryString(board), nil)
+	router.ServeHTTP(sw, sreq)
+	if sw.Code != http.StatusOK {
+		t.Fatalf("GET /api/solve/stream: status %d. Body: %s", sw.Code, sw.Body.String())
+	}
+	if ct := sw.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Errorf("expected a text/event-stream response, got %q", ct)
+	}
+
+	events := parseSSE(t, sw.Body.String())
+	if len(events) < 2 {
+		t.Fatalf("expected at least a state and a done event, got %d events", len(events))
+	}
+	if events[0].name != "state" {
+		t.Errorf("first event is %q, want state", events[0].name)
+	}
+	done := events[len(events)-1]
+	if done.name != "done" {
+		t.Fatalf("last event is %q, want done", done.name)
+	}
+
+	moveEvents := events[1 : len(events)-1]
+	if len(moveEvents) != len(full.Moves) {
+		t.Fatalf("stream emitted %d move events, solve/full returned %d moves", len(moveEvents), len(full.Moves))
+	}
+	for i, ev := range moveEvents {
+		if ev.name != "move" {
+			t.Fatalf("event %d is %q, want move", i+1, ev.name)
+		}
+		var mr struct {
+			Move struct {
+				Technique string `json:"technique"`
+				StepIndex int    `json:"step_index"`
+			} `json:"move"`
+			Diff json.RawMessage `json:"diff"`
+		}
+		if err := json.Unmarshal(ev.data, &mr); err != nil {
+			t.Fatalf("move event %d is not valid JSON: %v", i, err)
+		}
+		if mr.Move.Technique != full.Moves[i].Technique || mr.Move.StepIndex != full.Moves[i].StepIndex {
+			t.Fatalf("move %d diverged: stream %s/%d, solve/full %s/%d",
+				i, mr.Move.Technique, mr.Move.StepIndex, full.Moves[i].Technique, full.Moves[i].StepIndex)
+		}
+		if len(mr.Diff) == 0 {
+			t.Fatalf("move event %d carries no diff; the stream should use the delta format", i)
+		}
+	}
+
+	var summary struct {
+		StoppedReason string `json:"stopped_reason"`
+		MoveCount     int    `json:"move_count"`
+		FinalBoard    []int  `json:"final_board"`
+	}
+	if err := json.Unmarshal(done.data, &summary); err != nil {
+		t.Fatalf("done event is not valid JSON: %v", err)
+	}
+	if summary.StoppedReason != full.StoppedReason {
+		t.Errorf("stopped_reason diverged: stream %q, solve/full %q", summary.StoppedReason, full.StoppedReason)
+	}
+	if summary.MoveCount != len(full.Moves) {
+		t.Errorf("done reports %d moves, solve/full returned %d", summary.MoveCount, len(full.Moves))
+	}
+	if !reflect.DeepEqual(summary.FinalBoard, full.FinalBoard) {
+		t.Error("final boards diverged between the stream and solve/full")
+	}
+}
+
+// TestSolveStreamStopsOnDisconnect serves the stream to a request whose
+// context is already cancelled: the first callback sees the dead context, the
+// solver aborts, and the done event reports it.
+func TestSolveStreamStopsOnDisconnect(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	board := getPuzzleGivens(t, router, "static-0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/solve/stream?token="+token+"&board="+boardQueryString(board), nil)
+	router.ServeHTTP(w, req.WithContext(ctx))
+
+	events := parseSSE(t, w.Body.String())
+	done := events[len(events)-1]
+	if done.name != "done" {
+		t.Fatalf("last event is %q, want done", done.name)
+	}
+	var summary struct {
+		StoppedReason string `json:"stopped_reason"`
+	}
+	if err := json.Unmarshal(done.data, &summary); err != nil {
+		t.Fatalf("done event is not valid JSON: %v", err)
+	}
+	if summary.StoppedReason != "aborted" {
+		t.Errorf("expected the solve to abort on disconnect, got status %q", summary.StoppedReason)
+	}
+	// state + the single move whose callback noticed the cancellation + done
+	if len(events) > 3 {
+		t.Errorf("solver kept emitting after the disconnect: %d events", len(events))
+	}
+}
+
+func TestSolveStreamRejectsBadRequests(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	board := boardQueryString(getPuzzleGivens(t, router, "static-0"))
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{"Missing token", "/api/solve/stream?board=" + board, http.StatusUnauthorized},
+		{"Invalid token", "/api/solve/stream?token=bogus&board=" + board, http.StatusUnauthorized},
+		{"Missing board", "/api/solve/stream?token=" + token, http.StatusBadRequest},
+		{"Malformed board", "/api/solve/stream?token=" + token + "&board=" + strings.Repeat("x", 81), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
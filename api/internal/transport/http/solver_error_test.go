@@ -0,0 +1,311 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/human"
+)
+
+func TestContradictionClassification(t *testing.T) {
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// A fresh board can't contain a user error
+	if !contradictionIsSolverBug(givens, givens, solution) {
+		t.Error("Expected solver bug classification for a board with no user entries")
+	}
+	if !contradictionIsSolverBug(givens, givens, nil) {
+		t.Error("Expected solver bug classification for a fresh board even without a solution")
+	}
+
+	// Find an empty cell to play with
+	empty := -1
+	for i, v := range givens {
+		if v == 0 {
+			empty = i
+			break
+		}
+	}
+	if empty < 0 {
+		t.Fatal("No empty cell in test puzzle")
+	}
+
+	// A correct entry still rules the player out
+	board := make([]int, len(givens))
+	copy(board, givens)
+	board[empty] = solution[empty]
+	if !contradictionIsSolverBug(board, givens, solution) {
+		t.Error("Expected solver bug classification when every entry matches the solution")
+	}
+
+	// A wrong entry, or entries with no solution to check against, do not
+	board[empty] = solution[empty]%9 + 1
+	if contradictionIsSolverBug(board, givens, solution) {
+		t.Error("Expected user error classification for an incorrect entry")
+	}
+	if contradictionIsSolverBug(board, givens, nil) {
+		t.Error("Expected user error classification when entries exist but no solution is known")
+	}
+}
+
+func TestSolverErrorFingerprint(t *testing.T) {
+	givens, _, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	fp := solverErrorFingerprint(givens, "naked-single")
+	if fp == "" {
+		t.Fatal("Expected non-empty fingerprint")
+	}
+	if fp != solverErrorFingerprint(givens, "naked-single") {
+		t.Error("Expected fingerprint to be stable across calls")
+	}
+	if fp == solverErrorFingerprint(givens, "hidden-single") {
+		t.Error("Expected fingerprint to change with the technique")
+	}
+	other := make([]int, len(givens))
+	copy(other, givens)
+	other[0] = other[0]%9 + 1
+	if fp == solverErrorFingerprint(other, "naked-single") {
+		t.Error("Expected fingerprint to change with the board")
+	}
+}
+
+func TestSolveAllReportsSolverError(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// Simulate a wrong elimination mid-solve on a clean board: take the true
+	// candidates and strip the solution digit from one bivalue cell, which
+	// forces a wrong naked single and a downstream contradiction even though
+	// the player never entered anything.
+	candidates := human.NewBoardWithConstraints(givens, core.ConstraintSet{}).GetCandidates()
+	poisoned := -1
+	for i, v := range givens {
+		if v != 0 || len(candidates[i]) != 2 {
+			continue
+		}
+		kept := []int{}
+		for _, d := range candidates[i] {
+			if d != solution[i] {
+				kept = append(kept, d)
+			}
+		}
+		candidates[i] = kept
+		poisoned = i
+		break
+	}
+	if poisoned < 0 {
+		t.Fatal("Could not find a bivalue cell to poison")
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":      token,
+		"board":      givens,
+		"candidates": candidates,
+		"givens":     givens,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/all", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Moves []struct {
+			Move map[string]interface{} `json:"move"`
+		} `json:"moves"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	var solverError map[string]interface{}
+	for _, mr := range resp.Moves {
+		technique, _ := mr.Move["technique"].(string)
+		switch technique {
+		case "fix-error", "unpinpointable-error":
+			t.Fatalf("Expected no %s move on a clean board, got: %v", technique, mr.Move["explanation"])
+		case "solver-error":
+			solverError = mr.Move
+		}
+	}
+	if solverError == nil {
+		t.Fatal("Expected a solver-error move after the injected wrong elimination")
+	}
+
+	// The report must carry everything a deduplicatable bug report needs
+	if fp, _ := solverError["fingerprint"].(string); fp == "" {
+		t.Error("Expected solver-error move to carry a fingerprint")
+	}
+	if board, ok := solverError["board"].([]interface{}); !ok || len(board) != 81 {
+		t.Error("Expected solver-error move to carry the board snapshot")
+	}
+	recent, ok := solverError["recentMoves"].([]interface{})
+	if !ok {
+		t.Fatal("Expected solver-error move to carry recent moves")
+	}
+	if len(recent) > solverErrorHistoryLen {
+		t.Errorf("Expected at most %d recent moves, got %d", solverErrorHistoryLen, len(recent))
+	}
+}
+
+// postSolveNext sends one solve/next request and returns the decoded response.
+func solveNextResponse(t *testing.T, router http.Handler, payload map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return resp
+}
+
+func TestSolveNextCandidateError(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// Wrongly remove the solution digit from the first empty cell that keeps
+	// at least one other mark
+	candidates := human.NewBoardWithConstraints(givens, core.ConstraintSet{}).GetCandidates()
+	poisoned := -1
+	for i, v := range givens {
+		if v != 0 || len(candidates[i]) < 2 {
+			continue
+		}
+		kept := []int{}
+		for _, d := range candidates[i] {
+			if d != solution[i] {
+				kept = append(kept, d)
+			}
+		}
+		candidates[i] = kept
+		poisoned = i
+		break
+	}
+	if poisoned < 0 {
+		t.Fatal("Could not find a cell to poison")
+	}
+
+	resp := solveNextResponse(t, router, map[string]interface{}{
+		"token":      token,
+		"board":      givens,
+		"candidates": candidates,
+		"givens":     givens,
+	})
+
+	move, ok := resp["move"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a move, got: %v", resp)
+	}
+	if technique, _ := move["technique"].(string); technique != "candidate-error" {
+		t.Fatalf("Expected candidate-error move, got %q: %v", technique, move["explanation"])
+	}
+	if digit, _ := move["digit"].(float64); int(digit) != solution[poisoned] {
+		t.Errorf("Expected digit %d, got %v", solution[poisoned], move["digit"])
+	}
+	targets, _ := move["targets"].([]interface{})
+	if len(targets) != 1 {
+		t.Fatalf("Expected one target, got %v", targets)
+	}
+	target := targets[0].(map[string]interface{})
+	cell := int(target["row"].(float64))*9 + int(target["col"].(float64))
+	if cell != poisoned {
+		t.Errorf("Expected target cell %d, got %d", poisoned, cell)
+	}
+
+	// The returned candidates must have the digit restored
+	respCandidates, _ := resp["candidates"].([]interface{})
+	if len(respCandidates) != 81 {
+		t.Fatalf("Expected 81 candidate lists, got %d", len(respCandidates))
+	}
+	restored := false
+	for _, d := range respCandidates[poisoned].([]interface{}) {
+		if int(d.(float64)) == solution[poisoned] {
+			restored = true
+		}
+	}
+	if !restored {
+		t.Error("Expected the response candidates to restore the solution digit")
+	}
+}
+
+func TestSolveNextRespectsUserEliminations(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// Legitimately eliminate a non-solution digit the auto-candidates still
+	// carry; the hint must neither flag it nor suggest removing it again
+	candidates := human.NewBoardWithConstraints(givens, core.ConstraintSet{}).GetCandidates()
+	trimmed, removed := -1, 0
+	for i, v := range givens {
+		if v != 0 || len(candidates[i]) < 3 {
+			continue
+		}
+		kept := []int{}
+		for _, d := range candidates[i] {
+			if removed == 0 && d != solution[i] {
+				removed = d
+				continue
+			}
+			kept = append(kept, d)
+		}
+		candidates[i] = kept
+		trimmed = i
+		break
+	}
+	if trimmed < 0 {
+		t.Fatal("Could not find a cell to trim")
+	}
+
+	resp := solveNextResponse(t, router, map[string]interface{}{
+		"token":      token,
+		"board":      givens,
+		"candidates": candidates,
+		"givens":     givens,
+	})
+
+	move, ok := resp["move"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a move, got: %v", resp)
+	}
+	technique, _ := move["technique"].(string)
+	switch technique {
+	case "candidate-error", "fix-error", "fix-conflict", "unpinpointable-error":
+		t.Fatalf("Expected a normal technique move, got %q: %v", technique, move["explanation"])
+	}
+
+	// A hint that re-eliminates the already-removed candidate is redundant
+	if action, _ := move["action"].(string); action == "eliminate" {
+		if digit, _ := move["digit"].(float64); int(digit) == removed {
+			if targets, ok := move["targets"].([]interface{}); ok {
+				for _, raw := range targets {
+					target := raw.(map[string]interface{})
+					cell := int(target["row"].(float64))*9 + int(target["col"].(float64))
+					if cell == trimmed {
+						t.Errorf("Hint re-suggests eliminating %d from cell %d, which the user already removed", removed, trimmed)
+					}
+				}
+			}
+		}
+	}
+}
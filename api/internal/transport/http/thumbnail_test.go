@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestThumbnailEndpoint(t *testing.T) {
+	router := setupRouter()
+
+	t.Run("serves SVG with long cache headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/puzzle/test-seed/thumbnail.svg?d=hard", nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "image/svg+xml" {
+			t.Errorf("Content-Type = %q, want image/svg+xml", ct)
+		}
+		if cc := w.Header().Get("Cache-Control"); !strings.Contains(cc, "max-age=604800") {
+			t.Errorf("Cache-Control = %q, want long max-age", cc)
+		}
+		body := w.Body.String()
+		if !strings.HasPrefix(body, "<svg") || !strings.HasSuffix(body, "</svg>") {
+			t.Error("response body is not an SVG document")
+		}
+		if strings.Count(body, "<text") == 0 {
+			t.Error("expected digit <text> elements in the thumbnail")
+		}
+	})
+
+	t.Run("deterministic per seed", func(t *testing.T) {
+		get := func() string {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/puzzle/test-seed/thumbnail.svg?d=hard", nil)
+			router.ServeHTTP(w, req)
+			return w.Body.String()
+		}
+		if get() != get() {
+			t.Error("same seed produced different thumbnails")
+		}
+	})
+
+	t.Run("invalid difficulty rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/puzzle/test-seed/thumbnail.svg?d=bogus", nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
@@ -6,22 +6,52 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
 )
 
 type SessionToken struct {
-	DeviceID   string    `json:"device_id"`
-	PuzzleID   string    `json:"puzzle_id"`
-	Seed       string    `json:"seed"`
-	Difficulty string    `json:"difficulty"`
-	StartedAt  time.Time `json:"started_at"`
-	ExpiresAt  time.Time `json:"expires_at"`
+	DeviceID    string             `json:"device_id"`
+	PuzzleID    string             `json:"puzzle_id"`
+	Seed        string             `json:"seed"`
+	Difficulty  string             `json:"difficulty"`
+	Constraints core.ConstraintSet `json:"constraints"`
+	StartedAt   time.Time          `json:"started_at"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+	// Solution carries the puzzle's authoritative solution sealed against the
+	// server secret (see sealSolution), so solve handlers don't have to
+	// re-derive it. Empty on tokens issued before this claim existed.
+	Solution string `json:"solution,omitempty"`
+	// NonUnique marks custom sessions whose puzzle was validated as having
+	// multiple solutions; solve handlers clear Board.AssumeUnique for them so
+	// uniqueness-based techniques (the UR family, BUG) are never suggested.
+	NonUnique bool `json:"non_unique,omitempty"`
+	// Transform carries the presentation transform spec the session was
+	// started with (see core.ParseTransform). Solve handlers map submitted
+	// boards back to canonical orientation through it and map returned moves
+	// forward. Empty means the canonical orientation.
+	Transform string `json:"transform,omitempty"`
+	// IPHash, UAFamily and AppVersion are client metadata captured at
+	// session start so abuse investigations can correlate sessions without a
+	// database. The IP is stored as a truncated hash, never raw. All three
+	// are empty on tokens issued before these claims existed.
+	IPHash     string `json:"ip_hash,omitempty"`
+	UAFamily   string `json:"ua_family,omitempty"`
+	AppVersion string `json:"app_version,omitempty"`
 }
 
 // session token helpers are defined in this file
 
+// errTokenExpired distinguishes a structurally valid but expired token from a
+// malformed or tampered one, so handlers can tell clients to refresh instead
+// of restarting the session
+var errTokenExpired = errors.New("token expired")
+
 // createToken generates a signed JWT-style session token with HMAC-SHA256 authentication
 //
 // This token format is NOT standard JWT - it's a custom format for this API:
@@ -70,6 +100,22 @@ func createToken(secret string, session SessionToken) (string, error) {
 //
 // Returns: Decoded SessionToken pointer or error (invalid format, signature mismatch, expired)
 func verifyToken(secret, token string) (*SessionToken, error) {
+	session, err := decodeToken(secret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, errTokenExpired
+	}
+
+	return session, nil
+}
+
+// decodeToken authenticates a token's signature and decodes its claims
+// without checking expiry. Only /session/refresh should use it directly: the
+// grace window there deliberately accepts recently expired tokens.
+func decodeToken(secret, token string) (*SessionToken, error) {
 	parts := strings.Split(token, ".")
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid token format")
@@ -97,9 +143,57 @@ func verifyToken(secret, token string) (*SessionToken, error) {
 		return nil, err
 	}
 
-	if time.Now().After(session.ExpiresAt) {
-		return nil, fmt.Errorf("token expired")
+	return &session, nil
+}
+
+// sealSolution encodes a solved grid for embedding in a session token. The
+// token payload is only signed, not encrypted, so the digits are XORed with a
+// keystream derived from the server secret before base64 encoding - enough to
+// stop a client from reading the solution straight out of the token.
+func sealSolution(secret, puzzleID string, solution []int) string {
+	if len(solution) != constants.TotalCells {
+		return ""
 	}
+	buf := make([]byte, len(solution))
+	stream := solutionKeystream(secret, puzzleID, len(buf))
+	for i, v := range solution {
+		buf[i] = byte(v) ^ stream[i] //nolint:gosec // digits are 1-9
+	}
+	return base64.URLEncoding.EncodeToString(buf)
+}
 
-	return &session, nil
+// openSolution reverses sealSolution. It returns nil for tokens without a
+// sealed solution, or whose claim doesn't decode to a full grid of digits.
+func openSolution(secret, puzzleID, sealed string) []int {
+	if sealed == "" {
+		return nil
+	}
+	buf, err := base64.URLEncoding.DecodeString(sealed)
+	if err != nil || len(buf) != constants.TotalCells {
+		return nil
+	}
+	stream := solutionKeystream(secret, puzzleID, len(buf))
+	solution := make([]int, len(buf))
+	for i, b := range buf {
+		v := int(b ^ stream[i])
+		if v < 1 || v > constants.GridSize {
+			return nil
+		}
+		solution[i] = v
+	}
+	return solution
+}
+
+// solutionKeystream derives n bytes from the server secret and puzzle ID by
+// chaining HMAC-SHA256 blocks, so sealing is deterministic per puzzle.
+func solutionKeystream(secret, puzzleID string, n int) []byte {
+	stream := make([]byte, 0, n)
+	block := []byte(puzzleID)
+	for len(stream) < n {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(block)
+		block = mac.Sum(nil)
+		stream = append(stream, block...)
+	}
+	return stream[:n]
 }
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"sudoku-api/internal/core"
+)
+
+// startSessionWithTransform starts a test-seed/medium session carrying a
+// presentation transform and returns its token
+func startSessionWithTransform(t *testing.T, router *gin.Engine, spec string) string {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  "test-device-123",
+		"transform":  spec,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("session start with transform %q returned %d: %s", spec, w.Code, w.Body.String())
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Token == "" {
+		t.Fatalf("no token in session start response: %s", w.Body.String())
+	}
+	return resp.Token
+}
+
+// fetchPuzzleGivens loads the deterministic test puzzle, optionally reoriented
+func fetchPuzzleGivens(t *testing.T, router *gin.Engine, spec string) []int {
+	t.Helper()
+	url := "/api/puzzle/test-seed?d=medium"
+	if spec != "" {
+		url += "&transform=" + spec
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", url, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("puzzle fetch with transform %q returned %d: %s", spec, w.Code, w.Body.String())
+	}
+	var resp struct {
+		Givens []int `json:"givens"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode puzzle response: %v", err)
+	}
+	return resp.Givens
+}
+
+type transformedSolveResponse struct {
+	Board      []int      `json:"board"`
+	Candidates [][]int    `json:"candidates"`
+	Move       *core.Move `json:"move"`
+}
+
+// postSolveNextBoard submits a board that is its own givens to /api/solve/next
+func postSolveNextBoard(router *gin.Engine, token string, board []int) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":  token,
+		"board":  board,
+		"givens": board,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPuzzleEndpointServesTransformedGivens(t *testing.T) {
+	router := setupRouter()
+
+	canonical := fetchPuzzleGivens(t, router, "")
+	rotated := fetchPuzzleGivens(t, router, "rotate90")
+
+	tr, err := core.ParseTransform("rotate90")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+	if !reflect.DeepEqual(rotated, tr.ApplyCells(canonical)) {
+		t.Error("rotated givens are not the canonical givens under rotate90")
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/puzzle/test-seed?d=medium&transform=shuffle", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || errorCode(w) != ErrCodeInvalidTransform {
+		t.Errorf("unknown transform returned %d code %q, want 400 %q", w.Code, errorCode(w), ErrCodeInvalidTransform)
+	}
+}
+
+func TestSessionStartRejectsInvalidTransform(t *testing.T) {
+	router := setupRouter()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"seed":       "test-seed",
+		"difficulty": "medium",
+		"device_id":  "test-device-123",
+		"transform":  "rotate45",
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/session/start", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || errorCode(w) != ErrCodeInvalidTransform {
+		t.Errorf("invalid transform returned %d code %q, want 400 %q", w.Code, errorCode(w), ErrCodeInvalidTransform)
+	}
+}
+
+// TestTransformedSessionHintRoundTrip runs the same hint request through a
+// canonical session and through a session presented rotated and relabeled.
+// Inverse-transforming the rotated session's move must reproduce the
+// canonical session's move field for field.
+func TestTransformedSessionHintRoundTrip(t *testing.T) {
+	router := setupRouter()
+	const spec = "rotate90,relabel:234567891"
+	tr, err := core.ParseTransform(spec)
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+
+	canonicalGivens := fetchPuzzleGivens(t, router, "")
+	canonicalToken := getValidToken(router)
+	transformedToken := startSessionWithTransform(t, router, spec)
+
+	wCanonical := postSolveNextBoard(router, canonicalToken, canonicalGivens)
+	wTransformed := postSolveNextBoard(router, transformedToken, tr.ApplyCells(canonicalGivens))
+	if wCanonical.Code != http.StatusOK || wTransformed.Code != http.StatusOK {
+		t.Fatalf("solve/next returned %d canonical, %d transformed", wCanonical.Code, wTransformed.Code)
+	}
+
+	var canonical, transformed transformedSolveResponse
+	if err := json.Unmarshal(wCanonical.Body.Bytes(), &canonical); err != nil {
+		t.Fatalf("failed to decode canonical response: %v", err)
+	}
+	if err := json.Unmarshal(wTransformed.Body.Bytes(), &transformed); err != nil {
+		t.Fatalf("failed to decode transformed response: %v", err)
+	}
+	if canonical.Move == nil || transformed.Move == nil {
+		t.Fatal("one of the sessions produced no move")
+	}
+
+	inverse := tr.Invert()
+	if !reflect.DeepEqual(inverse.ApplyCells(transformed.Board), canonical.Board) {
+		t.Error("transformed board does not invert back to the canonical board")
+	}
+	if !reflect.DeepEqual(inverse.ApplyCandidateGrid(transformed.Candidates), canonical.Candidates) {
+		t.Error("transformed candidates do not invert back to the canonical candidates")
+	}
+
+	inverse.ApplyMove(transformed.Move)
+	if transformed.Move.Technique != canonical.Move.Technique || transformed.Move.Action != canonical.Move.Action {
+		t.Fatalf("techniques diverged: %s/%s vs %s/%s",
+			transformed.Move.Technique, transformed.Move.Action, canonical.Move.Technique, canonical.Move.Action)
+	}
+	if transformed.Move.Digit != canonical.Move.Digit {
+		t.Errorf("digit = %d after inverse, want %d", transformed.Move.Digit, canonical.Move.Digit)
+	}
+	if !reflect.DeepEqual(transformed.Move.Targets, canonical.Move.Targets) {
+		t.Errorf("targets = %v after inverse, want %v", transformed.Move.Targets, canonical.Move.Targets)
+	}
+	if !reflect.DeepEqual(transformed.Move.Eliminations, canonical.Move.Eliminations) {
+		t.Errorf("eliminations = %v after inverse, want %v", transformed.Move.Eliminations, canonical.Move.Eliminations)
+	}
+	if !reflect.DeepEqual(transformed.Move.Highlights, canonical.Move.Highlights) {
+		t.Errorf("highlights = %+v after inverse, want %+v", transformed.Move.Highlights, canonical.Move.Highlights)
+	}
+	if !reflect.DeepEqual(transformed.Move.Unit, canonical.Move.Unit) {
+		t.Errorf("unit = %+v after inverse, want %+v", transformed.Move.Unit, canonical.Move.Unit)
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sudoku-api/internal/tutorial"
+	"sudoku-api/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tutorialHandler returns one embedded tutorial puzzle plus its script
+// metadata: per-step state hashes and allowed user actions. The scripted
+// moves themselves are revealed one at a time by tutorialStepHandler so
+// clients can't skip ahead by accident.
+func tutorialHandler(c *gin.Context) {
+	t, ok := tutorial.Get(c.Param("id"))
+	if !ok {
+		apiError(c, http.StatusNotFound, ErrCodeTutorialNotFound,
+			fmt.Sprintf("no tutorial %q (available: %s)", c.Param("id"), strings.Join(tutorial.IDs(), ", ")))
+		return
+	}
+
+	steps := make([]gin.H, len(t.Steps))
+	for i, step := range t.Steps {
+		steps[i] = gin.H{
+			"board_hash":           step.BoardHash,
+			"allowed_user_actions": step.AllowedUserActions,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":         t.ID,
+		"title":      t.Title,
+		"concept":    t.Concept,
+		"intro":      t.Intro,
+		"givens":     t.Givens,
+		"step_count": len(t.Steps),
+		"steps":      steps,
+	})
+}
+
+type TutorialStepRequest struct {
+	Board []int `json:"board" binding:"required"`
+	Step  int   `json:"step"`
+}
+
+// tutorialStepHandler reveals the next scripted step once the client board
+// matches the state the script expects at that point. A diverged board gets
+// a resync response listing exactly which cells differ, so the client can
+// walk the user back on script instead of guessing.
+func tutorialStepHandler(c *gin.Context) {
+	t, ok := tutorial.Get(c.Param("id"))
+	if !ok {
+		apiError(c, http.StatusNotFound, ErrCodeTutorialNotFound,
+			fmt.Sprintf("no tutorial %q (available: %s)", c.Param("id"), strings.Join(tutorial.IDs(), ", ")))
+		return
+	}
+
+	var req TutorialStepRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	if len(req.Board) != constants.TotalCells {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidBoardSize, fmt.Sprintf("board must have %d cells", constants.TotalCells))
+		return
+	}
+	if req.Step < 0 || req.Step >= len(t.Steps) {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("step must be between 0 and %d", len(t.Steps)-1))
+		return
+	}
+
+	if diffs := t.Diff(req.Step, req.Board); len(diffs) > 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"resync":        true,
+			"step":          req.Step,
+			"expected_hash": t.Steps[req.Step].BoardHash,
+			"differences":   diffs,
+			"message":       "board does not match this step; fix the listed cells or restart from the givens",
+		})
+		return
+	}
+
+	step := t.Steps[req.Step]
+	c.JSON(http.StatusOK, gin.H{
+		"step":                 req.Step,
+		"move":                 step.Move,
+		"allowed_user_actions": step.AllowedUserActions,
+		"done":                 req.Step == len(t.Steps)-1,
+	})
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/tutorial"
+)
+
+func getTutorialResponse(t *testing.T, router http.Handler, id string) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/tutorial/"+id, nil)
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return w.Code, response
+}
+
+func postTutorialStep(t *testing.T, router http.Handler, id string, step int, board []int) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"board": board, "step": step})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/tutorial/"+id+"/step", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response
+}
+
+func TestTutorialMetadata(t *testing.T) {
+	router := setupRouter()
+
+	code, response := getTutorialResponse(t, router, "pairs")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %v", code, response)
+	}
+	if response["id"] != "pairs" || response["concept"] != "pairs" {
+		t.Errorf("Unexpected tutorial identity: %v", response)
+	}
+	givens := response["givens"].([]interface{})
+	if len(givens) != 81 {
+		t.Errorf("Expected 81 givens, got %d", len(givens))
+	}
+	steps := response["steps"].([]interface{})
+	if float64(len(steps)) != response["step_count"].(float64) {
+		t.Errorf("step_count %v does not match %d steps", response["step_count"], len(steps))
+	}
+	// Metadata must not leak the scripted moves
+	first := steps[0].(map[string]interface{})
+	if _, leaked := first["move"]; leaked {
+		t.Error("tutorial metadata exposes the scripted move")
+	}
+	if first["board_hash"] == "" {
+		t.Error("step metadata missing board_hash")
+	}
+
+	if code, _ := getTutorialResponse(t, router, "no-such-tutorial"); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown tutorial, got %d", code)
+	}
+}
+
+// TestTutorialWalkthrough drives the singles script end to end through the
+// API: each returned move is applied to the board, and the final step
+// reports done with the grid complete.
+func TestTutorialWalkthrough(t *testing.T) {
+	router := setupRouter()
+
+	tut, ok := tutorial.Get("singles")
+	if !ok {
+		t.Fatal("singles tutorial missing")
+	}
+
+	board := make([]int, len(tut.Givens))
+	copy(board, tut.Givens)
+
+	for i := range tut.Steps {
+		response := postTutorialStep(t, router, "singles", i, board)
+		if response["resync"] != nil {
+			t.Fatalf("Step %d unexpectedly asked for a resync: %v", i, response)
+		}
+
+		move := response["move"].(map[string]interface{})
+		if move["action"] != "assign" {
+			t.Fatalf("Step %d: expected assign move, got %v", i, move["action"])
+		}
+		digit := int(move["digit"].(float64))
+		for _, raw := range move["targets"].([]interface{}) {
+			target := raw.(map[string]interface{})
+			board[int(target["row"].(float64))*9+int(target["col"].(float64))] = digit
+		}
+
+		done, _ := response["done"].(bool)
+		if done != (i == len(tut.Steps)-1) {
+			t.Errorf("Step %d: done=%v", i, done)
+		}
+	}
+
+	for idx, v := range board {
+		if v == 0 {
+			t.Errorf("Walkthrough left cell %d empty", idx)
+		}
+	}
+}
+
+// TestTutorialResync checks that a diverged board gets the resync response
+// with the correct differing cells instead of the scripted move.
+func TestTutorialResync(t *testing.T) {
+	router := setupRouter()
+
+	tut, ok := tutorial.Get("pointing")
+	if !ok {
+		t.Fatal("pointing tutorial missing")
+	}
+
+	board := make([]int, len(tut.Givens))
+	copy(board, tut.Givens)
+	wrongCell := -1
+	for idx, v := range board {
+		if v == 0 {
+			wrongCell = idx
+			board[idx] = 5
+			break
+		}
+	}
+
+	response := postTutorialStep(t, router, "pointing", 0, board)
+	if resync, _ := response["resync"].(bool); !resync {
+		t.Fatalf("Expected resync response, got: %v", response)
+	}
+	if _, leaked := response["move"]; leaked {
+		t.Error("resync response leaks the scripted move")
+	}
+
+	diffs := response["differences"].([]interface{})
+	if len(diffs) != 1 {
+		t.Fatalf("Expected 1 differing cell, got %v", diffs)
+	}
+	diff := diffs[0].(map[string]interface{})
+	row, col := int(diff["row"].(float64)), int(diff["col"].(float64))
+	if row*9+col != wrongCell {
+		t.Errorf("Expected diff at cell %d, got R%dC%d", wrongCell, row+1, col+1)
+	}
+	if int(diff["expected"].(float64)) != 0 || int(diff["actual"].(float64)) != 5 {
+		t.Errorf("Unexpected diff payload: %v", diff)
+	}
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// ============================================================================
+// Upfront Solvability Check
+// ============================================================================
+//
+// Boards imported from another app sometimes carry a mis-transcribed given:
+// no rule is broken, but the grid admits no solution, and the solve handlers
+// would offer techniques that march straight into a contradiction. The check
+// below classifies the failure before any technique runs — either the givens
+// conflict outright, or they are over-constrained, in which case each given's
+// removal is probed to point at the likely transcription error. The probe
+// costs up to one dp solve per given, so the verdict is cached per token;
+// repeated hint requests on the same puzzle don't redo it.
+
+// unsolvableCache remembers the last solvability verdict per session token,
+// keyed alongside a hash of the givens it was computed for
+var unsolvableCache = struct {
+	sync.RWMutex
+	entries map[string]unsolvableEntry
+}{
+	entries: make(map[string]unsolvableEntry),
+}
+
+type unsolvableEntry struct {
+	givensHash string
+	report     *dp.UnsolvableReport // nil means the givens are solvable
+}
+
+// unsolvableCacheLimit bounds the cache; tokens churn with sessions, so on
+// overflow the map is simply dropped and rebuilt
+const unsolvableCacheLimit = 4096
+
+// checkGivensSolvable verifies the givens admit at least one solution,
+// consulting the per-token cache first. When they don't, it writes the
+// structured unsolvable-puzzle error and returns false; the caller returns
+// immediately.
+func checkGivensSolvable(c *gin.Context, token string, givens []int) bool {
+	hash := hashSolution(givens)
+
+	unsolvableCache.RLock()
+	entry, hit := unsolvableCache.entries[token]
+	unsolvableCache.RUnlock()
+
+	if !hit || entry.givensHash != hash {
+		entry = unsolvableEntry{givensHash: hash, report: dp.AnalyzeUnsolvable(givens)}
+		unsolvableCache.Lock()
+		if len(unsolvableCache.entries) >= unsolvableCacheLimit {
+			unsolvableCache.entries = make(map[string]unsolvableEntry)
+		}
+		unsolvableCache.entries[token] = entry
+		unsolvableCache.Unlock()
+	}
+
+	if entry.report == nil {
+		return true
+	}
+	writeUnsolvable(c, entry.report)
+	return false
+}
+
+// writeUnsolvable renders an UnsolvableReport as the shared error envelope,
+// with the classification and per-case context in the details object.
+func writeUnsolvable(c *gin.Context, report *dp.UnsolvableReport) {
+	details := gin.H{"reason": report.Reason}
+	message := "the givens admit no solution"
+
+	switch report.Reason {
+	case dp.ReasonConflictingGivens:
+		message = "the givens conflict with each other"
+		conflicts := make([]gin.H, len(report.Conflicts))
+		for i, conflict := range report.Conflicts {
+			conflicts[i] = gin.H{
+				"cell1": cellRefJSON(conflict.Cell1),
+				"cell2": cellRefJSON(conflict.Cell2),
+				"digit": conflict.Value,
+				"type":  conflict.Type,
+			}
+		}
+		details["conflicts"] = conflicts
+	case dp.ReasonOverConstrained:
+		message = "the givens break no rule but admit no solution; one of them is likely mis-transcribed"
+		hints := make([]gin.H, len(report.RemovableGivens))
+		for i, cell := range report.RemovableGivens {
+			hints[i] = cellRefJSON(cell)
+		}
+		details["removal_hints"] = hints
+	}
+
+	apiErrorDetails(c, http.StatusUnprocessableEntity, ErrCodeUnsolvablePuzzle,
+		fmt.Sprintf("unsolvable puzzle: %s", message), details)
+}
+
+// cellRefJSON renders a cell index as the row/col object the API uses
+func cellRefJSON(cell int) gin.H {
+	return gin.H{"row": cell / constants.GridSize, "col": cell % constants.GridSize}
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// unsolvableFixtureGivens carves a solvable puzzle the fixtures corrupt
+func unsolvableFixtureGivens() []int {
+	full := dp.GenerateFullGridFast(17)
+	return dp.CarveGivens(full, 30, 17)
+}
+
+// postSolveNextImport submits a board that is its own givens (the import
+// case) to /api/solve/next and returns the recorder
+func postSolveNextImport(router http.Handler, token string, givens []int) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":  token,
+		"board":  givens,
+		"givens": givens,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/next", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// unsolvableDetails unwraps the details object of an unsolvable_puzzle error
+func unsolvableDetails(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+	if code := errorCode(w); code != ErrCodeUnsolvablePuzzle {
+		t.Fatalf("expected error code %q, got %q", ErrCodeUnsolvablePuzzle, code)
+	}
+	var resp struct {
+		Error struct {
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return resp.Error.Details
+}
+
+func TestSolveNextReportsConflictingGivens(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens := unsolvableFixtureGivens()
+	idx := -1
+	for i, v := range givens {
+		if v != 0 {
+			idx = i
+			break
+		}
+	}
+	rowStart := idx / constants.GridSize * constants.GridSize
+	for col := rowStart; col < rowStart+constants.GridSize; col++ {
+		if givens[col] == 0 {
+			givens[col] = givens[idx]
+			break
+		}
+	}
+
+	details := unsolvableDetails(t, postSolveNextImport(router, token, givens))
+	if details["reason"] != dp.ReasonConflictingGivens {
+		t.Errorf("expected reason %q, got %v", dp.ReasonConflictingGivens, details["reason"])
+	}
+	conflicts, ok := details["conflicts"].([]interface{})
+	if !ok || len(conflicts) == 0 {
+		t.Errorf("expected the conflicting pairs in the details, got %v", details["conflicts"])
+	}
+}
+
+func TestSolveNextReportsOverConstrainedWithRemovalHint(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	// Mis-transcribe one given: a wrong digit that breaks no rule directly
+	// but leaves the grid without a solution
+	givens := unsolvableFixtureGivens()
+	altered := -1
+	for i, v := range givens {
+		if v == 0 || altered >= 0 {
+			continue
+		}
+		for d := 1; d <= constants.GridSize; d++ {
+			if d == v {
+				continue
+			}
+			givens[i] = d
+			if len(dp.FindConflicts(givens)) == 0 && dp.CountSolutions(givens, 1) == 0 {
+				altered = i
+				break
+			}
+		}
+		if altered < 0 {
+			givens[i] = v
+		}
+	}
+	if altered < 0 {
+		t.Fatal("no conflict-free mis-transcription found; pick another fixture seed")
+	}
+
+	details := unsolvableDetails(t, postSolveNextImport(router, token, givens))
+	if details["reason"] != dp.ReasonOverConstrained {
+		t.Errorf("expected reason %q, got %v", dp.ReasonOverConstrained, details["reason"])
+	}
+	hints, ok := details["removal_hints"].([]interface{})
+	if !ok || len(hints) == 0 {
+		t.Fatalf("expected removal hints in the details, got %v", details["removal_hints"])
+	}
+	wantRow := float64(altered / constants.GridSize)
+	wantCol := float64(altered % constants.GridSize)
+	found := false
+	for _, h := range hints {
+		hint := h.(map[string]interface{})
+		if hint["row"] == wantRow && hint["col"] == wantCol {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("removal hints %v do not include the mis-transcribed given at R%dC%d", hints, int(wantRow)+1, int(wantCol)+1)
+	}
+
+	// The verdict is cached per token; a repeat request answers identically
+	// without redoing the 81-solve probe
+	repeat := unsolvableDetails(t, postSolveNextImport(router, token, givens))
+	if repeat["reason"] != dp.ReasonOverConstrained {
+		t.Errorf("cached verdict diverged: %v", repeat["reason"])
+	}
+}
+
+func TestSolveAllReportsUnsolvableGivens(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens := unsolvableFixtureGivens()
+	idx := -1
+	for i, v := range givens {
+		if v != 0 {
+			idx = i
+			break
+		}
+	}
+	rowStart := idx / constants.GridSize * constants.GridSize
+	for col := rowStart; col < rowStart+constants.GridSize; col++ {
+		if givens[col] == 0 {
+			givens[col] = givens[idx]
+			break
+		}
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":  token,
+		"board":  givens,
+		"givens": givens,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/solve/all", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	details := unsolvableDetails(t, w)
+	if details["reason"] != dp.ReasonConflictingGivens {
+		t.Errorf("expected reason %q, got %v", dp.ReasonConflictingGivens, details["reason"])
+	}
+}
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/config"
+	"sudoku-api/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+func postValidateWinnable(router *gin.Engine, token string, board []int, strictness string) *httptest.ResponseRecorder {
+	payload := map[string]interface{}{
+		"token": token,
+		"board": board,
+	}
+	if strictness != "" {
+		payload["strictness"] = strictness
+	}
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/validate/winnable", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// wrongNonConflictingBoard copies the givens and places one digit that
+// disagrees with the solution without conflicting with any peer, returning
+// the board and the altered cell
+func wrongNonConflictingBoard(t *testing.T, givens, solution []int) ([]int, int) {
+	t.Helper()
+	board := make([]int, len(givens))
+	copy(board, givens)
+	for i, v := range givens {
+		if v != 0 {
+			continue
+		}
+		for d := 1; d <= constants.GridSize; d++ {
+			if d == solution[i] {
+				continue
+			}
+			board[i] = d
+			if len(dp.FindConflicts(board)) == 0 {
+				return board, i
+			}
+		}
+		board[i] = 0
+	}
+	t.Fatal("Could not construct a non-conflicting wrong entry")
+	return nil, -1
+}
+
+func TestValidateWinnable_Strict(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// The untouched givens are on track
+	w := postValidateWinnable(router, token, givens, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["winnable"] != true {
+		t.Errorf("Expected winnable=true for untouched givens, got %v", response["winnable"])
+	}
+
+	// The response must carry nothing besides the verdict - no reasons,
+	// counts, or cell lists a client could mine for hints
+	if len(response) != 1 {
+		t.Errorf("Expected only the winnable key in the response, got %v", response)
+	}
+
+	// A correct entry keeps the game winnable
+	correct := make([]int, len(givens))
+	copy(correct, givens)
+	for i, v := range givens {
+		if v == 0 {
+			correct[i] = solution[i]
+			break
+		}
+	}
+	w = postValidateWinnable(router, token, correct, "strict")
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["winnable"] != true {
+		t.Errorf("Expected winnable=true for a correct entry, got %v", response["winnable"])
+	}
+
+	// A wrong entry is fatal in strict mode, even when it conflicts with
+	// nothing on the board yet
+	wrong, _ := wrongNonConflictingBoard(t, givens, solution)
+	w = postValidateWinnable(router, token, wrong, "strict")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	response = nil
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["winnable"] != false {
+		t.Errorf("Expected winnable=false for a wrong entry, got %v", response["winnable"])
+	}
+	if len(response) != 1 {
+		t.Errorf("Expected only the winnable key in the response, got %v", response)
+	}
+}
+
+func TestValidateWinnable_Loose(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, solution, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	// A sparse board that diverges from the sealed solution: strict says the
+	// game is lost, loose accepts it because some completion still exists
+	divergent := make([]int, constants.TotalCells)
+	for i := range divergent {
+		if givens[i] == 0 {
+			divergent[i] = solution[i]%constants.GridSize + 1
+			break
+		}
+	}
+	w := postValidateWinnable(router, token, divergent, "strict")
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["winnable"] != false {
+		t.Errorf("Expected strict winnable=false for divergent board, got %v", response["winnable"])
+	}
+	w = postValidateWinnable(router, token, divergent, "loose")
+	response = nil
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["winnable"] != true {
+		t.Errorf("Expected loose winnable=true for divergent board, got %v", response["winnable"])
+	}
+
+	// With the full givens in place the puzzle has a unique solution, so a
+	// wrong entry leaves no completion even in loose mode
+	wrong, _ := wrongNonConflictingBoard(t, givens, solution)
+	w = postValidateWinnable(router, token, wrong, "loose")
+	response = nil
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["winnable"] != false {
+		t.Errorf("Expected loose winnable=false for a dead-end board, got %v", response["winnable"])
+	}
+}
+
+func TestValidateWinnable_InvalidStrictness(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+
+	givens, _, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+	w := postValidateWinnable(router, token, givens, "fuzzy")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if code := responseErrorCode(t, w); code != ErrCodeInvalidRequest {
+		t.Errorf("Expected error code %q, got %q", ErrCodeInvalidRequest, code)
+	}
+}
+
+func TestValidateWinnable_SeparateRateBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router, &config.Config{
+		JWTSecret:             "test-secret-key",
+		SolveRatePerMinute:    100,
+		WinnableRatePerMinute: 2,
+	})
+	token := startSession(t, router, "winnable-bucket-device")
+
+	givens, _, _, _ := puzzles.Global().GetPuzzleBySeed("test-seed", "medium")
+
+	for i := 0; i < 2; i++ {
+		if w := postValidateWinnable(router, token, givens, ""); w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected status 200, got %d. Body: %s", i+1, w.Code, w.Body.String())
+		}
+	}
+	w := postValidateWinnable(router, token, givens, "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 past the winnable quota, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	// Exhausting the winnable bucket must not touch the solve budget
+	if w := postSolveNext(router, token, givens); w.Code != http.StatusOK {
+		t.Errorf("Expected solve/next to remain available, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
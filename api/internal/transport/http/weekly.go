@@ -0,0 +1,116 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/weekly"
+	"sudoku-api/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// weeklyLeaderboardSize is how many verified entries each week's board keeps
+const weeklyLeaderboardSize = 100
+
+// weeklyBoard ranks verified weekly completions. In-memory behind the
+// weekly.Leaderboard interface; see that package for the persistence story.
+var weeklyBoard weekly.Leaderboard
+
+// WeeklySubmitRequest carries a completion claim for the current weekly
+// puzzle: the session token plus the full move log the server replays
+type WeeklySubmitRequest struct {
+	Token   string             `json:"token" binding:"required"`
+	MoveLog []weekly.MoveEntry `json:"moveLog" binding:"required"`
+}
+
+// weeklyPuzzle resolves the competition puzzle for t from the bank
+func weeklyPuzzle(t time.Time) (week, seed, difficulty string, givens, solution []int, err error) {
+	week = weekly.WeekKey(t)
+	seed = weekly.Seed(week)
+	difficulty = weekly.Difficulty(t)
+
+	loader := puzzles.Global()
+	if loader == nil {
+		err = fmt.Errorf("no puzzles loaded")
+		return
+	}
+	givens, solution, _, err = loader.GetPuzzleBySeed(seed, difficulty)
+	return
+}
+
+// weeklyHandler reports the current week's competition: the seed derived
+// from the ISO week and the tier the rotation features
+func weeklyHandler(c *gin.Context) {
+	now := time.Now()
+	week := weekly.WeekKey(now)
+	c.JSON(http.StatusOK, gin.H{
+		"week":       week,
+		"seed":       weekly.Seed(week),
+		"difficulty": weekly.Difficulty(now),
+		"rotation":   constants.DifficultyNames(),
+	})
+}
+
+// weeklySubmitHandler verifies a completion claim by replaying its move log
+// against the week's givens. The official time comes from the log's
+// timestamps; a client-claimed number never reaches the leaderboard.
+func weeklySubmitHandler(c *gin.Context) {
+	var req WeeklySubmitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	session, err := verifyToken(cfg.JWTSecret, req.Token)
+	if err != nil {
+		apiTokenError(c, err)
+		return
+	}
+
+	week, _, difficulty, givens, solution, err := weeklyPuzzle(time.Now())
+	if err != nil {
+		apiError(c, http.StatusServiceUnavailable, ErrCodePuzzlesNotLoaded, "weekly puzzle unavailable: "+err.Error())
+		return
+	}
+
+	official, err := weekly.Replay(givens, solution, req.MoveLog)
+	if err != nil {
+		apiError(c, http.StatusUnprocessableEntity, ErrCodeInvalidMoveLog, err.Error())
+		return
+	}
+
+	rank := weeklyBoard.Submit(week, weekly.Entry{
+		DeviceID:       session.DeviceID,
+		OfficialMillis: official,
+		Moves:          len(req.MoveLog),
+		SubmittedAt:    time.Now().UTC(),
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"week":            week,
+		"difficulty":      difficulty,
+		"official_millis": official,
+		"rank":            rank,
+		"ranked":          rank > 0,
+	})
+}
+
+// weeklyLeaderboardHandler returns a week's ranked entries, defaulting to
+// the current week; past weeks stay queryable via ?week=2026-W01
+func weeklyLeaderboardHandler(c *gin.Context) {
+	week := c.Query("week")
+	if week == "" {
+		week = weekly.WeekKey(time.Now())
+	}
+	entries := weeklyBoard.Top(week)
+	if entries == nil {
+		entries = []weekly.Entry{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"week":    week,
+		"entries": entries,
+	})
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sudoku-api/internal/puzzles"
+	"sudoku-api/internal/weekly"
+)
+
+// currentWeeklyPuzzle resolves the same puzzle the handlers will, so tests
+// can build replayable move logs for it
+func currentWeeklyPuzzle(t *testing.T) (givens, solution []int) {
+	t.Helper()
+	now := time.Now()
+	var err error
+	givens, solution, _, err = puzzles.Global().GetPuzzleBySeed(weekly.Seed(weekly.WeekKey(now)), weekly.Difficulty(now))
+	if err != nil {
+		t.Fatalf("could not resolve the weekly puzzle: %v", err)
+	}
+	return
+}
+
+// weeklyMoveLog fills every empty cell with its solution digit, one second
+// apart, which clears the minimum-time floor
+func weeklyMoveLog(givens, solution []int) []weekly.MoveEntry {
+	var log []weekly.MoveEntry
+	for i, v := range givens {
+		if v == 0 {
+			log = append(log, weekly.MoveEntry{Index: i, Digit: solution[i], TMillis: int64(len(log)+1) * 1000})
+		}
+	}
+	return log
+}
+
+func postWeeklySubmit(router http.Handler, token string, log []weekly.MoveEntry) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{
+		"token":   token,
+		"moveLog": log,
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/weekly/submit", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWeeklyAnnouncesSeedAndRotation(t *testing.T) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/weekly", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Week       string   `json:"week"`
+		Seed       string   `json:"seed"`
+		Difficulty string   `json:"difficulty"`
+		Rotation   []string `json:"rotation"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	now := time.Now()
+	if resp.Week != weekly.WeekKey(now) {
+		t.Errorf("week = %q, want %q", resp.Week, weekly.WeekKey(now))
+	}
+	if resp.Seed != weekly.Seed(resp.Week) {
+		t.Errorf("seed = %q, want derived from the week key", resp.Seed)
+	}
+	if resp.Difficulty != weekly.Difficulty(now) {
+		t.Errorf("difficulty = %q, want this week's rotation pick", resp.Difficulty)
+	}
+	if len(resp.Rotation) == 0 {
+		t.Error("expected the rotation list in the response")
+	}
+}
+
+func TestWeeklySubmitAcceptsAndRanksVerifiedLog(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	givens, solution := currentWeeklyPuzzle(t)
+	log := weeklyMoveLog(givens, solution)
+
+	w := postWeeklySubmit(router, token, log)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Week           string `json:"week"`
+		OfficialMillis int64  `json:"official_millis"`
+		Rank           int    `json:"rank"`
+		Ranked         bool   `json:"ranked"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if want := log[len(log)-1].TMillis; resp.OfficialMillis != want {
+		t.Errorf("official_millis = %d, want the log's final timestamp %d", resp.OfficialMillis, want)
+	}
+	if !resp.Ranked || resp.Rank != 1 {
+		t.Errorf("rank = %d (ranked %v), want 1 on an empty board", resp.Rank, resp.Ranked)
+	}
+
+	// The leaderboard reflects the verified time, not anything the client
+	// claimed
+	lw := httptest.NewRecorder()
+	lreq, _ := http.NewRequest("GET", "/api/weekly/leaderboard", nil)
+	router.ServeHTTP(lw, lreq)
+	if lw.Code != http.StatusOK {
+		t.Fatalf("leaderboard returned %d: %s", lw.Code, lw.Body.String())
+	}
+	var board struct {
+		Week    string         `json:"week"`
+		Entries []weekly.Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(lw.Body.Bytes(), &board); err != nil {
+		t.Fatalf("invalid leaderboard JSON: %v", err)
+	}
+	if len(board.Entries) != 1 || board.Entries[0].OfficialMillis != resp.OfficialMillis {
+		t.Errorf("leaderboard entries = %+v, want one entry at %dms", board.Entries, resp.OfficialMillis)
+	}
+}
+
+func TestWeeklySubmitRejectsWrongFinalBoard(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	givens, solution := currentWeeklyPuzzle(t)
+	log := weeklyMoveLog(givens, solution)
+	last := &log[len(log)-1]
+	last.Digit = last.Digit%9 + 1
+
+	w := postWeeklySubmit(router, token, log)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+	if code := errorCode(w); code != ErrCodeInvalidMoveLog {
+		t.Errorf("expected error code %q, got %q", ErrCodeInvalidMoveLog, code)
+	}
+}
+
+func TestWeeklySubmitRejectsTimeTravel(t *testing.T) {
+	router := setupRouter()
+	token := getValidToken(router)
+	givens, solution := currentWeeklyPuzzle(t)
+	log := weeklyMoveLog(givens, solution)
+	log[len(log)/2].TMillis = 1
+
+	w := postWeeklySubmit(router, token, log)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+	if code := errorCode(w); code != ErrCodeInvalidMoveLog {
+		t.Errorf("expected error code %q, got %q", ErrCodeInvalidMoveLog, code)
+	}
+}
@@ -0,0 +1,161 @@
+// Package tutorial serves the fixed onboarding walkthroughs: three embedded
+// puzzles (singles, pairs, pointing) whose entire hint sequence is
+// hand-authored rather than solver-derived, so the wording can teach one
+// concept at a time. Each script step carries the exact board state it
+// expects, identified by a hash derived at load time by replaying the script
+// from the givens, which lets handlers verify a client is still on script
+// and point out exactly which cells diverged when it is not.
+package tutorial
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"sudoku-api/internal/core"
+	"sudoku-api/pkg/constants"
+)
+
+//go:embed tutorials.json
+var tutorialData []byte
+
+// Step is one scripted hint: the move to reveal, the actions the client
+// should let the user take to apply it, and the board state it expects.
+type Step struct {
+	// BoardHash identifies the cell state this step expects. It is not
+	// stored in tutorials.json; load time derives it by replay so authors
+	// never hand-maintain hashes.
+	BoardHash string `json:"board_hash"`
+	// Move is the scripted hint with hand-authored wording. Elimination
+	// steps leave the cells untouched, so consecutive elimination steps
+	// share a BoardHash and clients disambiguate with the step index.
+	Move               core.Move `json:"move"`
+	AllowedUserActions []string  `json:"allowed_user_actions"`
+}
+
+// Tutorial is one embedded onboarding puzzle plus its walkthrough script.
+type Tutorial struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Concept string `json:"concept"`
+	Intro   string `json:"intro"`
+	Givens  []int  `json:"givens"`
+	Steps   []Step `json:"steps"`
+
+	// boards[i] is the expected cell state entering step i;
+	// boards[len(Steps)] is the state after the final step
+	boards [][]int
+}
+
+// CellDiff reports one cell where a client board differs from the state a
+// script step expects.
+type CellDiff struct {
+	Row      int `json:"row"`
+	Col      int `json:"col"`
+	Expected int `json:"expected"`
+	Actual   int `json:"actual"`
+}
+
+var tutorials = mustLoad()
+
+// mustLoad parses the embedded scripts and replays each one from its givens
+// to derive per-step board states and hashes. The data is compiled into the
+// binary, so any inconsistency is a build artifact error worth failing
+// loudly on at startup.
+func mustLoad() map[string]*Tutorial {
+	var list []*Tutorial
+	if err := json.Unmarshal(tutorialData, &list); err != nil {
+		panic(fmt.Sprintf("tutorial: bad embedded tutorials.json: %v", err))
+	}
+
+	byID := make(map[string]*Tutorial, len(list))
+	for _, t := range list {
+		if len(t.Givens) != constants.TotalCells {
+			panic(fmt.Sprintf("tutorial %q: givens has %d cells", t.ID, len(t.Givens)))
+		}
+		if _, dup := byID[t.ID]; dup {
+			panic(fmt.Sprintf("tutorial %q: duplicate id", t.ID))
+		}
+
+		board := make([]int, constants.TotalCells)
+		copy(board, t.Givens)
+		t.boards = make([][]int, 0, len(t.Steps)+1)
+		for i := range t.Steps {
+			snapshot := make([]int, constants.TotalCells)
+			copy(snapshot, board)
+			t.boards = append(t.boards, snapshot)
+			t.Steps[i].BoardHash = HashBoard(snapshot)
+
+			move := &t.Steps[i].Move
+			if move.Action == "assign" {
+				for _, target := range move.Targets {
+					idx := target.Row*constants.GridSize + target.Col
+					if board[idx] != 0 {
+						panic(fmt.Sprintf("tutorial %q step %d: assign targets filled cell R%dC%d", t.ID, i, target.Row+1, target.Col+1))
+					}
+					board[idx] = move.Digit
+				}
+			}
+		}
+		final := make([]int, constants.TotalCells)
+		copy(final, board)
+		t.boards = append(t.boards, final)
+
+		byID[t.ID] = t
+	}
+	return byID
+}
+
+// HashBoard returns the hex digest identifying a cell state in script steps.
+func HashBoard(cells []int) string {
+	buf := make([]byte, len(cells))
+	for i, v := range cells {
+		buf[i] = byte(v)
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the tutorial with the given id.
+func Get(id string) (*Tutorial, bool) {
+	t, ok := tutorials[id]
+	return t, ok
+}
+
+// IDs lists the available tutorial ids in stable order.
+func IDs() []string {
+	ids := make([]string, 0, len(tutorials))
+	for id := range tutorials {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ExpectedBoard returns a copy of the cell state entering the given step;
+// step == len(Steps) yields the state after the final step.
+func (t *Tutorial) ExpectedBoard(step int) []int {
+	board := make([]int, constants.TotalCells)
+	copy(board, t.boards[step])
+	return board
+}
+
+// Diff lists the cells where a client board differs from the state the
+// given step expects; an on-script board yields no diffs.
+func (t *Tutorial) Diff(step int, board []int) []CellDiff {
+	var diffs []CellDiff
+	for idx, expected := range t.boards[step] {
+		if board[idx] != expected {
+			diffs = append(diffs, CellDiff{
+				Row:      idx / constants.GridSize,
+				Col:      idx % constants.GridSize,
+				Expected: expected,
+				Actual:   board[idx],
+			})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,128 @@
+package tutorial
+
+import (
+	"testing"
+
+	"sudoku-api/internal/sudoku/dp"
+	"sudoku-api/pkg/constants"
+)
+
+// TestScriptsReplayToCompletion walks every embedded script from its givens:
+// each step's hash must match the replayed state, every scripted assignment
+// must agree with the puzzle's true solution, and no scripted elimination
+// may remove a solution digit.
+func TestScriptsReplayToCompletion(t *testing.T) {
+	ids := IDs()
+	if len(ids) != 3 {
+		t.Fatalf("expected 3 embedded tutorials, got %v", ids)
+	}
+
+	for _, id := range ids {
+		tut, ok := Get(id)
+		if !ok {
+			t.Fatalf("Get(%q) failed for listed id", id)
+		}
+		solution := dp.Solve(tut.Givens)
+		if solution == nil {
+			t.Fatalf("tutorial %q: givens have no solution", id)
+		}
+
+		board := make([]int, constants.TotalCells)
+		copy(board, tut.Givens)
+		for i, step := range tut.Steps {
+			if got := HashBoard(board); got != step.BoardHash {
+				t.Fatalf("tutorial %q step %d: replayed hash %s != scripted %s", id, i, got, step.BoardHash)
+			}
+			if step.Move.Explanation == "" {
+				t.Errorf("tutorial %q step %d: missing explanation", id, i)
+			}
+			if len(step.AllowedUserActions) == 0 {
+				t.Errorf("tutorial %q step %d: no allowed user actions", id, i)
+			}
+
+			switch step.Move.Action {
+			case "assign":
+				for _, target := range step.Move.Targets {
+					idx := target.Row*constants.GridSize + target.Col
+					if solution[idx] != step.Move.Digit {
+						t.Fatalf("tutorial %q step %d: assigns %d at R%dC%d but solution has %d",
+							id, i, step.Move.Digit, target.Row+1, target.Col+1, solution[idx])
+					}
+					board[idx] = step.Move.Digit
+				}
+			case "eliminate":
+				for _, elim := range step.Move.Eliminations {
+					if solution[elim.Row*constants.GridSize+elim.Col] == elim.Digit {
+						t.Fatalf("tutorial %q step %d: eliminates the true digit %d at R%dC%d",
+							id, i, elim.Digit, elim.Row+1, elim.Col+1)
+					}
+				}
+			default:
+				t.Fatalf("tutorial %q step %d: unexpected action %q", id, i, step.Move.Action)
+			}
+		}
+
+		final := tut.ExpectedBoard(len(tut.Steps))
+		for idx := range board {
+			if board[idx] != final[idx] {
+				t.Fatalf("tutorial %q: replayed final board differs from ExpectedBoard at cell %d", id, idx)
+			}
+		}
+	}
+
+	// The singles walkthrough ends with the grid complete
+	singles, _ := Get("singles")
+	for idx, v := range singles.ExpectedBoard(len(singles.Steps)) {
+		if v == 0 {
+			t.Errorf("singles tutorial leaves cell %d empty after the final step", idx)
+		}
+	}
+}
+
+// TestDiffReportsDivergedCells checks the resync path: a board off script
+// yields exactly the cells that differ from the expected state.
+func TestDiffReportsDivergedCells(t *testing.T) {
+	tut, ok := Get("pairs")
+	if !ok {
+		t.Fatal("pairs tutorial missing")
+	}
+
+	board := tut.ExpectedBoard(0)
+	if diffs := tut.Diff(0, board); len(diffs) != 0 {
+		t.Fatalf("on-script board reported diffs: %v", diffs)
+	}
+
+	// Fill one cell the script did not ask for and clear a given
+	var empty, given int = -1, -1
+	for idx, v := range board {
+		if v == 0 && empty < 0 {
+			empty = idx
+		}
+		if v != 0 && given < 0 {
+			given = idx
+		}
+	}
+	board[empty] = 9
+	wantGiven := board[given]
+	board[given] = 0
+
+	diffs := tut.Diff(0, board)
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 diffs, got %v", diffs)
+	}
+	for _, d := range diffs {
+		idx := d.Row*constants.GridSize + d.Col
+		switch idx {
+		case empty:
+			if d.Expected != 0 || d.Actual != 9 {
+				t.Errorf("diff at cell %d: got %+v, want expected=0 actual=9", idx, d)
+			}
+		case given:
+			if d.Expected != wantGiven || d.Actual != 0 {
+				t.Errorf("diff at cell %d: got %+v, want expected=%d actual=0", idx, d, wantGiven)
+			}
+		default:
+			t.Errorf("unexpected diff at cell %d: %+v", idx, d)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package weekly runs the puzzle-of-the-week competition. A week is keyed by
+// its ISO year and week number; the key derives the puzzle seed, and the week
+// number rotates the featured difficulty tier. Completion claims are never
+// taken at face value: the server replays the submitted move log against the
+// puzzle's givens and computes the official time from the log's timestamps,
+// so a client-claimed number has nowhere to enter the ranking.
+package weekly
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"sudoku-api/pkg/constants"
+)
+
+// MinOfficialMillis is the sanity floor on a verified time. No human solves
+// any tier faster; logs below it are replay scripts, not solves.
+const MinOfficialMillis = 30_000
+
+// MaxLogEntries bounds a submitted move log. A solve needs at most one entry
+// per empty cell plus corrections; anything past this is garbage or abuse.
+const MaxLogEntries = 2000
+
+// SeedPrefix starts every weekly seed, e.g. "weekly-2026-W35"
+const SeedPrefix = "weekly-"
+
+// MoveEntry is one replayable step of a solve: a digit placed at a cell
+// (0 erases) at tMillis since the solve started. Deliberately minimal — no
+// candidates — so logs stay small enough to submit whole.
+type MoveEntry struct {
+	Index   int   `json:"index"`
+	Digit   int   `json:"digit"`
+	TMillis int64 `json:"tMillis"`
+}
+
+// WeekKey returns the ISO week key for t, e.g. "2026-W35"
+func WeekKey(t time.Time) string {
+	year, week := t.UTC().ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// Seed returns the deterministic puzzle seed for a week key
+func Seed(week string) string {
+	return SeedPrefix + week
+}
+
+// Difficulty returns the tier featured in t's week, rotating through the
+// active difficulty list one tier per week, easiest first
+func Difficulty(t time.Time) string {
+	names := constants.DifficultyNames()
+	_, week := t.UTC().ISOWeek()
+	return names[(week-1)%len(names)]
+}
+
+// Replay verifies a move log against a puzzle and returns the official solve
+// time in milliseconds. It checks every move writes a legal digit to a
+// non-given cell, timestamps never run backwards, the final board equals the
+// solution, and the elapsed time clears the sanity floor.
+func Replay(givens, solution []int, log []MoveEntry) (int64, error) {
+	if len(log) == 0 {
+		return 0, fmt.Errorf("move log is empty")
+	}
+	if len(log) > MaxLogEntries {
+		return 0, fmt.Errorf("move log exceeds %d entries", MaxLogEntries)
+	}
+
+	board := make([]int, len(givens))
+	copy(board, givens)
+
+	var last int64
+	for i, m := range log {
+		if m.Index < 0 || m.Index >= constants.TotalCells {
+			return 0, fmt.Errorf("move %d: cell index %d out of range", i, m.Index)
+		}
+		if m.Digit < 0 || m.Digit > constants.GridSize {
+			return 0, fmt.Errorf("move %d: digit %d out of range", i, m.Digit)
+		}
+		if givens[m.Index] != 0 {
+			return 0, fmt.Errorf("move %d: cell %d is a given", i, m.Index)
+		}
+		if m.TMillis < last {
+			return 0, fmt.Errorf("move %d: timestamp %dms runs backwards from %dms", i, m.TMillis, last)
+		}
+		last = m.TMillis
+		board[m.Index] = m.Digit
+	}
+
+	for i, v := range board {
+		if v != solution[i] {
+			return 0, fmt.Errorf("final board does not match the solution")
+		}
+	}
+
+	if last < MinOfficialMillis {
+		return 0, fmt.Errorf("official time %dms is below the %dms sanity floor", last, MinOfficialMillis)
+	}
+	return last, nil
+}
+
+// Entry is one verified completion on a week's leaderboard
+type Entry struct {
+	DeviceID       string    `json:"device_id"`
+	OfficialMillis int64     `json:"official_millis"`
+	Moves          int       `json:"moves"`
+	SubmittedAt    time.Time `json:"submitted_at"`
+}
+
+// Leaderboard stores verified completions keyed by week. The default
+// implementation is in memory; a database-backed one can be plugged in later
+// without touching handlers.
+type Leaderboard interface {
+	// Submit records a verified entry and returns its 1-based rank, or 0
+	// when it did not place (slower than the device's standing entry, or
+	// off the end of the board)
+	Submit(week string, entry Entry) int
+	// Top returns the week's ranked entries, fastest first
+	Top(week string) []Entry
+}
+
+// MemoryLeaderboard is the default Leaderboard: per-week slices sorted
+// fastest first, capped at a fixed size, keeping each device's best time
+type MemoryLeaderboard struct {
+	mu    sync.Mutex
+	size  int
+	weeks map[string][]Entry
+}
+
+// NewMemoryLeaderboard creates a leaderboard keeping the top size entries
+// per week
+func NewMemoryLeaderboard(size int) *MemoryLeaderboard {
+	return &MemoryLeaderboard{size: size, weeks: make(map[string][]Entry)}
+}
+
+// Submit implements Leaderboard
+func (l *MemoryLeaderboard) Submit(week string, entry Entry) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := l.weeks[week]
+
+	// One slot per device: a slower resubmission leaves the standing time
+	for i, e := range entries {
+		if e.DeviceID != entry.DeviceID {
+			continue
+		}
+		if e.OfficialMillis <= entry.OfficialMillis {
+			return 0
+		}
+		entries = append(entries[:i], entries[i+1:]...)
+		break
+	}
+
+	entries = append(entries, entry)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].OfficialMillis != entries[j].OfficialMillis {
+			return entries[i].OfficialMillis < entries[j].OfficialMillis
+		}
+		return entries[i].SubmittedAt.Before(entries[j].SubmittedAt)
+	})
+	if len(entries) > l.size {
+		entries = entries[:l.size]
+	}
+	l.weeks[week] = entries
+
+	for i, e := range entries {
+		if e.DeviceID == entry.DeviceID && e.OfficialMillis == entry.OfficialMillis {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Top implements Leaderboard
+func (l *MemoryLeaderboard) Top(week string) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := l.weeks[week]
+	out := make([]Entry, len(entries))
+	copy(out, entries)
+	return out
+}
@@ -0,0 +1,171 @@
+package weekly
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sudoku-api/internal/sudoku/dp"
+)
+
+// replayPuzzle carves a deterministic puzzle and returns its givens and
+// solution for building move logs
+func replayPuzzle() (givens, solution []int) {
+	solution = dp.GenerateFullGridFast(7)
+	givens = dp.CarveGivens(solution, 30, 7)
+	return
+}
+
+// validLog fills every empty cell with its solution digit, one second apart
+func validLog(givens, solution []int) []MoveEntry {
+	var log []MoveEntry
+	for i, v := range givens {
+		if v == 0 {
+			log = append(log, MoveEntry{Index: i, Digit: solution[i], TMillis: int64(len(log)+1) * 1000})
+		}
+	}
+	return log
+}
+
+func TestWeekKeyAndSeed(t *testing.T) {
+	// 2026-01-01 falls in ISO week 1 of 2026
+	key := WeekKey(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	if key != "2026-W01" {
+		t.Errorf("WeekKey = %q, want 2026-W01", key)
+	}
+	if Seed(key) != "weekly-2026-W01" {
+		t.Errorf("Seed = %q, want weekly-2026-W01", Seed(key))
+	}
+}
+
+func TestDifficultyRotatesWeekly(t *testing.T) {
+	base := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC) // Monday of week 2
+	if Difficulty(base) == Difficulty(base.AddDate(0, 0, 7)) {
+		t.Error("expected consecutive weeks to feature different tiers")
+	}
+}
+
+func TestReplayAcceptsValidLog(t *testing.T) {
+	givens, solution := replayPuzzle()
+	log := validLog(givens, solution)
+
+	official, err := Replay(givens, solution, log)
+	if err != nil {
+		t.Fatalf("Replay rejected a valid log: %v", err)
+	}
+	if want := log[len(log)-1].TMillis; official != want {
+		t.Errorf("official time = %dms, want the last timestamp %dms", official, want)
+	}
+}
+
+func TestReplayRejectsWrongFinalBoard(t *testing.T) {
+	givens, solution := replayPuzzle()
+	log := validLog(givens, solution)
+	last := &log[len(log)-1]
+	last.Digit = last.Digit%9 + 1
+
+	if _, err := Replay(givens, solution, log); err == nil {
+		t.Error("expected rejection for a final board that misses the solution")
+	}
+}
+
+func TestReplayRejectsTimeTravel(t *testing.T) {
+	givens, solution := replayPuzzle()
+	log := validLog(givens, solution)
+	log[len(log)/2].TMillis = 1 // jumps backwards mid-log
+
+	_, err := Replay(givens, solution, log)
+	if err == nil {
+		t.Fatal("expected rejection for backwards timestamps")
+	}
+	if !strings.Contains(err.Error(), "backwards") {
+		t.Errorf("error %q does not name the timestamp violation", err)
+	}
+}
+
+func TestReplayRejectsGivenOverwrite(t *testing.T) {
+	givens, solution := replayPuzzle()
+	log := validLog(givens, solution)
+	for i, v := range givens {
+		if v != 0 {
+			log[0] = MoveEntry{Index: i, Digit: v, TMillis: 500}
+			break
+		}
+	}
+
+	if _, err := Replay(givens, solution, log); err == nil {
+		t.Error("expected rejection for a move into a given cell")
+	}
+}
+
+func TestReplayRejectsImplausiblyFastSolve(t *testing.T) {
+	givens, solution := replayPuzzle()
+	log := validLog(givens, solution)
+	for i := range log {
+		log[i].TMillis = int64(i + 1) // whole solve inside ~50ms
+	}
+
+	if _, err := Replay(givens, solution, log); err == nil {
+		t.Error("expected rejection below the minimum-time sanity floor")
+	}
+}
+
+func TestReplay300MoveLogIsFast(t *testing.T) {
+	givens, solution := replayPuzzle()
+	log := validLog(givens, solution)
+
+	// Pad with erase/rewrite churn on the first empty cell to reach 300
+	// moves, the way a hesitant player's log looks
+	churn := log[0]
+	tick := log[len(log)-1].TMillis
+	for len(log) < 300 {
+		tick += 100
+		log = append(log, MoveEntry{Index: churn.Index, Digit: 0, TMillis: tick})
+		tick += 100
+		log = append(log, MoveEntry{Index: churn.Index, Digit: churn.Digit, TMillis: tick})
+	}
+
+	start := time.Now()
+	if _, err := Replay(givens, solution, log); err != nil {
+		t.Fatalf("Replay rejected the padded log: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Errorf("replay of %d moves took %v, want well under 10ms", len(log), elapsed)
+	}
+}
+
+func TestMemoryLeaderboardRanksAndCaps(t *testing.T) {
+	board := NewMemoryLeaderboard(2)
+	now := time.Now()
+
+	if rank := board.Submit("2026-W01", Entry{DeviceID: "a", OfficialMillis: 90_000, SubmittedAt: now}); rank != 1 {
+		t.Errorf("first entry ranked %d, want 1", rank)
+	}
+	if rank := board.Submit("2026-W01", Entry{DeviceID: "b", OfficialMillis: 60_000, SubmittedAt: now}); rank != 1 {
+		t.Errorf("faster entry ranked %d, want 1", rank)
+	}
+
+	// A slower resubmission leaves the device's standing time
+	if rank := board.Submit("2026-W01", Entry{DeviceID: "b", OfficialMillis: 80_000, SubmittedAt: now}); rank != 0 {
+		t.Errorf("slower resubmission ranked %d, want 0", rank)
+	}
+	// A faster one replaces it
+	if rank := board.Submit("2026-W01", Entry{DeviceID: "b", OfficialMillis: 50_000, SubmittedAt: now}); rank != 1 {
+		t.Errorf("improved resubmission ranked %d, want 1", rank)
+	}
+
+	// The board is full; a slower third device does not place
+	if rank := board.Submit("2026-W01", Entry{DeviceID: "c", OfficialMillis: 120_000, SubmittedAt: now}); rank != 0 {
+		t.Errorf("overflow entry ranked %d, want 0", rank)
+	}
+
+	top := board.Top("2026-W01")
+	if len(top) != 2 || top[0].DeviceID != "b" || top[1].DeviceID != "a" {
+		t.Errorf("top = %+v, want b then a", top)
+	}
+
+	// Weeks don't bleed into each other
+	if len(board.Top("2026-W02")) != 0 {
+		t.Error("expected an empty board for an unplayed week")
+	}
+}
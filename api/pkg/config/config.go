@@ -1,14 +1,93 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sudoku-api/pkg/constants"
 )
 
 type Config struct {
 	JWTSecret   string
 	Port        string
 	PuzzlesFile string
+
+	// Solve endpoint guards
+	SolveRatePerMinute int // per-device solve requests per minute (0 disables limiting)
+	// WinnableRatePerMinute caps /validate/winnable separately and more
+	// tightly than the solve endpoints: a yes/no oracle is cheap to abuse
+	// for guess-and-check (WINNABLE_RATE_PER_MINUTE, 0 disables limiting)
+	WinnableRatePerMinute int
+	MaxRequestBytes       int64 // request body cap for solve endpoints (0 disables)
+	SolveMaxMoves         int   // solver iteration budget per request
+	SolveTimeBudgetMs     int   // wall-time budget per solve request in milliseconds
+
+	MetricsEnabled bool // expose Prometheus metrics on /metrics
+
+	// SessionTokenExpiry is the lifetime of issued session tokens
+	// (SESSION_TOKEN_EXPIRY, a Go duration string) and SessionRefreshGrace is
+	// how long past expiry /session/refresh still accepts one
+	// (SESSION_REFRESH_GRACE). Zero or negative values fall back to the
+	// constants package defaults.
+	SessionTokenExpiry  time.Duration
+	SessionRefreshGrace time.Duration
+
+	// LogLevel and LogFormat shape the structured request log: level is one
+	// of debug/info/warn/error (LOG_LEVEL) and format is "text" or "json"
+	// (LOG_FORMAT)
+	LogLevel  string
+	LogFormat string
+
+	// OpeningQualityCarve routes on-demand carving through the
+	// opening-dispersion post-filter (OPENING_QUALITY_CARVE), re-carving
+	// seeds whose easy/medium openings cluster in too few boxes or rows.
+	// Off by default: the filter re-solves the carved tiers, a cost
+	// on-demand generation may not want to pay.
+	OpeningQualityCarve bool
+
+	// ReadyWhenDegraded lets /readyz report ready even without a loaded
+	// puzzle bank (READY_WHEN_DEGRADED), for environments that accept
+	// slow on-demand generation over dropping out of the load balancer
+	ReadyWhenDegraded bool
+
+	// AllowedOrigins lists origins permitted to call the API cross-origin
+	// (CORS_ALLOWED_ORIGINS, comma-separated; "*" allows any). Empty
+	// disables CORS entirely.
+	AllowedOrigins []string
+
+	// ExtraDifficulties holds config-defined difficulty tiers appended to
+	// the built-in five (EXTRA_DIFFICULTIES, a JSON array of DifficultySpec)
+	ExtraDifficulties []constants.DifficultySpec
+
+	// TrustedProxies lists proxy IPs or CIDRs whose X-Forwarded-For headers
+	// are honored when resolving the client IP (TRUSTED_PROXIES,
+	// comma-separated). When empty, forwarding headers are ignored and the
+	// remote address is taken as the client.
+	TrustedProxies []string
+
+	// TechniqueWeightsFile points at a calibrated technique-weights JSON
+	// written by cmd/calibrate (TECHNIQUE_WEIGHTS_FILE). When set, its
+	// weights override the built-in registry scores at startup; empty keeps
+	// the defaults.
+	TechniqueWeightsFile string
+
+	// StatsFile points at the JSON snapshot backing per-puzzle play
+	// statistics (STATS_FILE). When set, stats are restored from it at
+	// startup and snapshotted back periodically; empty keeps stats in
+	// memory only.
+	StatsFile string
+
+	// BlockedDevices and BlockedIPPrefixes form the abuse blocklist
+	// (BLOCKED_DEVICES and BLOCKED_IP_PREFIXES, comma-separated). Devices
+	// match the device_id claim exactly; prefixes match against the hashed
+	// client IP. Matching requests are rejected with 403.
+	BlockedDevices    []string
+	BlockedIPPrefixes []string
 }
 
 // Load loads configuration from environment variables.
@@ -28,16 +107,110 @@ func Load() (*Config, error) {
 		return nil, errors.New("SECURITY ERROR: JWT_SECRET must be at least 32 characters long")
 	}
 
+	extraDifficulties, err := getEnvDifficulties("EXTRA_DIFFICULTIES")
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		JWTSecret:   jwtSecret,
 		Port:        getEnv("PORT", "8080"),
 		PuzzlesFile: getEnv("PUZZLES_FILE", "/data/puzzles.json"),
+
+		SolveRatePerMinute:    getEnvInt("SOLVE_RATE_PER_MINUTE", 120),
+		WinnableRatePerMinute: getEnvInt("WINNABLE_RATE_PER_MINUTE", 30),
+		MaxRequestBytes:       int64(getEnvInt("MAX_REQUEST_BYTES", 1<<20)),
+		SolveMaxMoves:         getEnvInt("SOLVE_MAX_MOVES", 2000),
+		SolveTimeBudgetMs:     getEnvInt("SOLVE_TIME_BUDGET_MS", 10000),
+
+		MetricsEnabled: getEnvBool("METRICS_ENABLED", false),
+
+		SessionTokenExpiry:  getEnvDuration("SESSION_TOKEN_EXPIRY", constants.SessionTokenExpiry),
+		SessionRefreshGrace: getEnvDuration("SESSION_REFRESH_GRACE", constants.SessionRefreshGrace),
+
+		LogLevel:  getEnv("LOG_LEVEL", "info"),
+		LogFormat: getEnv("LOG_FORMAT", "text"),
+
+		OpeningQualityCarve: getEnvBool("OPENING_QUALITY_CARVE", false),
+
+		ReadyWhenDegraded: getEnvBool("READY_WHEN_DEGRADED", false),
+
+		AllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS"),
+
+		ExtraDifficulties: extraDifficulties,
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+		TechniqueWeightsFile: getEnv("TECHNIQUE_WEIGHTS_FILE", ""),
+
+		StatsFile: getEnv("STATS_FILE", ""),
+
+		BlockedDevices:    getEnvList("BLOCKED_DEVICES"),
+		BlockedIPPrefixes: getEnvList("BLOCKED_IP_PREFIXES"),
 	}, nil
 }
 
+// getEnvDifficulties parses a JSON array of difficulty specs and verifies the
+// combined list (built-ins plus extras) would be valid, so a bad deploy fails
+// at startup instead of serving broken difficulties
+func getEnvDifficulties(key string) ([]constants.DifficultySpec, error) {
+	val := os.Getenv(key)
+	if val == "" {
+		return nil, nil
+	}
+	var specs []constants.DifficultySpec
+	if err := json.Unmarshal([]byte(val), &specs); err != nil {
+		return nil, fmt.Errorf("%s: invalid JSON: %w", key, err)
+	}
+	combined := append(constants.DefaultDifficultySpecs(), specs...)
+	if err := constants.SetDifficultySpecs(combined); err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return specs, nil
+}
+
 func getEnv(key, fallback string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
 	}
 	return fallback
 }
+
+// getEnvList parses a comma-separated list, dropping empty entries
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getEnvInt(key string, fallback int) int {
+	if val := os.Getenv(key); val != "" {
+		if parsed, err := strconv.Atoi(val); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getEnvDuration parses a Go duration string like "24h" or "90m"
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if parsed, err := time.ParseDuration(val); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if parsed, err := strconv.ParseBool(val); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
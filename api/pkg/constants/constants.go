@@ -14,40 +14,58 @@ const (
 const (
 	MaxSolverSteps     = 5000
 	SolutionCountLimit = 2
+
+	// Custom puzzle validation samples the solution space so authors can see
+	// how broken a multi-solution puzzle is
+	SolutionSampleLimit    = 1000
+	SolutionSampleBudgetMs = 500
+)
+
+// Puzzle pack export limits
+const (
+	DefaultPackCount   = 50  // default pack size when count is omitted
+	MaxPackCount       = 500 // cap when serving from the pre-generated loader
+	MaxGeneratedPack   = 10  // cap when falling back to on-the-fly generation
+	PackCacheMaxAgeSec = 86400
+)
+
+// Puzzle bank search pagination
+const (
+	DefaultSearchLimit = 20  // page size when limit is omitted or out of range
+	MaxSearchLimit     = 100 // largest page a client may request
+)
+
+// Thumbnail rendering. Puzzles are deterministic per seed+difficulty, so
+// thumbnails can be cached far longer than packs
+const (
+	ThumbnailCacheMaxAgeSec = 604800 // 7 days
 )
 
 // Session
 const (
 	SessionTokenExpiry = 24 * time.Hour
+	// SessionRefreshGrace is how long past expiry a token is still accepted
+	// by /session/refresh, so a long game survives its token lapsing
+	SessionRefreshGrace = time.Hour
+	MaxSavedHistory     = 500 // cap on history entries in a saved game state
 )
 
-// Difficulties
+// Difficulties. Target givens, compact keys, and technique budgets live in
+// the DifficultySpec list (difficulty.go).
 const (
 	DifficultyEasy       = "easy"
 	DifficultyMedium     = "medium"
 	DifficultyHard       = "hard"
 	DifficultyExtreme    = "extreme"
 	DifficultyImpossible = "impossible"
+	// DifficultyDiabolical is bank-only: it guarantees an extreme-tier
+	// technique is required, which is too expensive to verify per request
+	DifficultyDiabolical = "diabolical"
+	// DifficultyCustom labels sessions for user-submitted puzzles; it has no
+	// spec and is never generated
+	DifficultyCustom = "custom"
 )
 
-// Difficulty compact keys (for puzzle file format)
-var DifficultyKeys = map[string]string{
-	DifficultyEasy:       "e",
-	DifficultyMedium:     "m",
-	DifficultyHard:       "h",
-	DifficultyExtreme:    "x",
-	DifficultyImpossible: "i",
-}
-
-// Target givens by difficulty (for 9x9 puzzles)
-var TargetGivens = map[string]int{
-	DifficultyEasy:       35,
-	DifficultyMedium:     30,
-	DifficultyHard:       25,
-	DifficultyExtreme:    22,
-	DifficultyImpossible: 17,
-}
-
 // Technique tiers
 const (
 	TierSimple  = "simple"
@@ -68,6 +86,11 @@ const (
 	StatusCompleted       = "completed"
 	StatusStalled         = "stalled"
 	StatusMaxStepsReached = "max_steps_reached"
+	StatusAborted         = "aborted"
+	StatusCycleDetected   = "cycle_detected"
+	// StatusUnratable marks a difficulty analysis refused because the board's
+	// candidates were supplied externally rather than derived from the cells
+	StatusUnratable = "unratable"
 )
 
 // API version
@@ -93,18 +116,47 @@ const (
 
 // HTTP route paths
 const (
-	RouteHealth         = "/health"
-	RouteAPI            = "/api"
-	RouteVersion        = "/version"
-	RouteDaily          = "/daily"
-	RoutePuzzle         = "/puzzle"
-	RoutePuzzleID       = "/puzzle/:seed"
-	RouteAnalyze        = "/puzzle/:seed/analyze"
-	RoutePractice       = "/practice/:technique"
-	RouteSessionStart   = "/session/start"
-	RouteSolveNext      = "/solve/next"
-	RouteSolveAll       = "/solve/all"
-	RouteSolveFull      = "/solve/full"
-	RouteValidate       = "/validate"
-	RouteCustomValidate = "/custom/validate"
+	RouteHealth           = "/health"
+	RouteHealthz          = "/healthz"
+	RouteReadyz           = "/readyz"
+	RouteMetrics          = "/metrics"
+	RouteAPI              = "/api"
+	RouteAPIV1            = "/api/v1"
+	RouteVersion          = "/version"
+	RouteDaily            = "/daily"
+	RoutePuzzle           = "/puzzle"
+	RoutePuzzleID         = "/puzzle/:seed"
+	RouteAnalyze          = "/puzzle/:seed/analyze"
+	RoutePuzzleDiff       = "/puzzle/:seed/diff"
+	RouteThumbnail        = "/puzzle/:seed/thumbnail.svg"
+	RoutePuzzleStats      = "/puzzle/:seed/stats"
+	RoutePractice         = "/practice/:technique"
+	RouteTechniques       = "/techniques"
+	RoutePuzzlePack       = "/puzzles/pack"
+	RoutePuzzlesSearch    = "/puzzles/search"
+	RoutePuzzleByIndex    = "/puzzles/:index"
+	RouteTutorial         = "/tutorial/:id"
+	RouteTutorialStep     = "/tutorial/:id/step"
+	RouteSessionStart     = "/session/start"
+	RouteSessionRefresh   = "/session/refresh"
+	RouteSessionSave      = "/session/save"
+	RouteSessionRestore   = "/session/restore"
+	RouteSessionComplete  = "/session/complete"
+	RouteSolveNext        = "/solve/next"
+	RouteSolveAll         = "/solve/all"
+	RouteSolveCell        = "/solve/cell"
+	RouteSolveFull        = "/solve/full"
+	RouteSolveStream      = "/solve/stream"
+	RouteCandidates       = "/candidates/check"
+	RouteAssistCascade    = "/assist/cascade"
+	RouteExplain          = "/explain"
+	RouteValidate         = "/validate"
+	RouteValidateWinnable = "/validate/winnable"
+	RouteCustomValidate   = "/custom/validate"
+	RouteCustomResolve    = "/custom/resolve"
+	RouteSolveReview      = "/analysis/solve-review"
+	RouteOpenAPI          = "/openapi.json"
+	RouteWeekly           = "/weekly"
+	RouteWeeklySubmit     = "/weekly/submit"
+	RouteWeeklyBoard      = "/weekly/leaderboard"
 )
@@ -0,0 +1,124 @@
+package constants
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DifficultySpec describes one difficulty tier: its public name, the compact
+// key used in puzzle files, the carving target, and the technique budget a
+// puzzle of this difficulty is allowed to require.
+type DifficultySpec struct {
+	Name         string `json:"name"`
+	Key          string `json:"key"`
+	TargetGivens int    `json:"target_givens"`
+	MaxTier      string `json:"max_tier"`
+	MinScore     int    `json:"min_score"`
+	MaxScore     int    `json:"max_score"`
+
+	// RequireAnyOf lists technique slugs of which at least one must be
+	// required by the puzzle. Only technique-driven carving
+	// (human.CarveGivensByTechniques) consumes it; the classic given-count
+	// carve cannot judge the predicate and skips the tier entirely, making
+	// it bank-only. Empty means the tier is defined by TargetGivens.
+	RequireAnyOf []string `json:"require_any_of,omitempty"`
+}
+
+// DefaultDifficultySpecs returns the built-in tiers, easiest first. The
+// TargetGivens values are load-bearing: carving consumes them, so changing
+// them changes every existing seed's puzzles.
+func DefaultDifficultySpecs() []DifficultySpec {
+	return []DifficultySpec{
+		{Name: DifficultyEasy, Key: "e", TargetGivens: 40, MaxTier: TierSimple, MinScore: 0, MaxScore: 40},
+		{Name: DifficultyMedium, Key: "m", TargetGivens: 34, MaxTier: TierMedium, MinScore: 45, MaxScore: 70},
+		{Name: DifficultyHard, Key: "h", TargetGivens: 28, MaxTier: TierMedium, MinScore: 45, MaxScore: 70},
+		{Name: DifficultyExtreme, Key: "x", TargetGivens: 24, MaxTier: TierHard, MinScore: 75, MaxScore: 95},
+		{Name: DifficultyImpossible, Key: "i", TargetGivens: 20, MaxTier: TierExtreme, MinScore: 100, MaxScore: 135},
+		// Diabolical is carried by RequireAnyOf, not its given count: the
+		// set lists every extreme-tier technique, so satisfying it means no
+		// solve exists within the hard tier — at least one extreme technique
+		// is genuinely required. That predicate makes the tier bank-only
+		// (see RequireAnyOf).
+		{Name: DifficultyDiabolical, Key: "d", TargetGivens: 19, MaxTier: TierExtreme, MinScore: 140, MaxScore: 180,
+			RequireAnyOf: []string{
+				"finned-x-wing", "finned-swordfish", "grouped-x-cycles",
+				"aic", "als-xz", "als-xy-wing", "als-xy-chain",
+				"sue-de-coq", "death-blossom", "digit-forcing-chain", "forcing-chain",
+			}},
+	}
+}
+
+// difficultySpecs is the active list, replaced at startup when config
+// defines extra tiers. Sorted easiest (most givens) first.
+var difficultySpecs = DefaultDifficultySpecs()
+
+// DifficultySpecs returns the active difficulty tiers, easiest first
+func DifficultySpecs() []DifficultySpec {
+	return difficultySpecs
+}
+
+// SetDifficultySpecs installs a new difficulty list (defaults plus any
+// config-defined tiers). Names and keys must be unique and carving targets
+// must stay within a valid puzzle's bounds.
+func SetDifficultySpecs(specs []DifficultySpec) error {
+	if len(specs) == 0 {
+		return fmt.Errorf("difficulty list cannot be empty")
+	}
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, spec := range specs {
+		if spec.Name == "" || spec.Key == "" {
+			return fmt.Errorf("difficulty %q: name and key are required", spec.Name)
+		}
+		if names[spec.Name] {
+			return fmt.Errorf("duplicate difficulty name %q", spec.Name)
+		}
+		if keys[spec.Key] {
+			return fmt.Errorf("duplicate difficulty key %q", spec.Key)
+		}
+		if spec.TargetGivens < MinGivens || spec.TargetGivens > TotalCells {
+			return fmt.Errorf("difficulty %q: target givens %d outside %d-%d", spec.Name, spec.TargetGivens, MinGivens, TotalCells)
+		}
+		names[spec.Name] = true
+		keys[spec.Key] = true
+	}
+
+	sorted := make([]DifficultySpec, len(specs))
+	copy(sorted, specs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TargetGivens > sorted[j].TargetGivens
+	})
+	difficultySpecs = sorted
+	return nil
+}
+
+// DifficultyByName returns the active spec for a difficulty name
+func DifficultyByName(name string) (DifficultySpec, bool) {
+	for _, spec := range difficultySpecs {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return DifficultySpec{}, false
+}
+
+// DifficultyRank returns a difficulty's position in the active spec list
+// (easiest first), for comparing difficulties by hardness. The second return
+// is false for unknown names.
+func DifficultyRank(name string) (int, bool) {
+	for i, spec := range difficultySpecs {
+		if spec.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// DifficultyNames returns the active difficulty names, easiest first
+func DifficultyNames() []string {
+	names := make([]string, len(difficultySpecs))
+	for i, spec := range difficultySpecs {
+		names[i] = spec.Name
+	}
+	return names
+}
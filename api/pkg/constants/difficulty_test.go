@@ -0,0 +1,76 @@
+package constants
+
+import "testing"
+
+func restoreDefaultSpecs(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		if err := SetDifficultySpecs(DefaultDifficultySpecs()); err != nil {
+			t.Fatalf("failed to restore default specs: %v", err)
+		}
+	})
+}
+
+func TestSetDifficultySpecs(t *testing.T) {
+	restoreDefaultSpecs(t)
+
+	t.Run("rejects empty list", func(t *testing.T) {
+		if err := SetDifficultySpecs(nil); err == nil {
+			t.Error("expected error for empty list")
+		}
+	})
+
+	t.Run("rejects duplicate names and keys", func(t *testing.T) {
+		dupeName := append(DefaultDifficultySpecs(),
+			DifficultySpec{Name: DifficultyEasy, Key: "z", TargetGivens: 50, MaxTier: TierSimple})
+		if err := SetDifficultySpecs(dupeName); err == nil {
+			t.Error("expected error for duplicate name")
+		}
+		dupeKey := append(DefaultDifficultySpecs(),
+			DifficultySpec{Name: "beginner", Key: "e", TargetGivens: 50, MaxTier: TierSimple})
+		if err := SetDifficultySpecs(dupeKey); err == nil {
+			t.Error("expected error for duplicate key")
+		}
+	})
+
+	t.Run("rejects out-of-range givens target", func(t *testing.T) {
+		bad := append(DefaultDifficultySpecs(),
+			DifficultySpec{Name: "broken", Key: "z", TargetGivens: MinGivens - 1, MaxTier: TierSimple})
+		if err := SetDifficultySpecs(bad); err == nil {
+			t.Error("expected error for target below minimum givens")
+		}
+	})
+
+	t.Run("sorts easiest first regardless of input order", func(t *testing.T) {
+		specs := append(DefaultDifficultySpecs(),
+			DifficultySpec{Name: "beginner", Key: "b", TargetGivens: 45, MaxTier: TierSimple})
+		if err := SetDifficultySpecs(specs); err != nil {
+			t.Fatalf("SetDifficultySpecs: %v", err)
+		}
+		active := DifficultySpecs()
+		if active[0].Name != "beginner" {
+			t.Errorf("expected beginner first, got %s", active[0].Name)
+		}
+		for i := 1; i < len(active); i++ {
+			if active[i].TargetGivens > active[i-1].TargetGivens {
+				t.Errorf("specs not sorted by givens at index %d", i)
+			}
+		}
+	})
+}
+
+func TestDifficultyByName(t *testing.T) {
+	restoreDefaultSpecs(t)
+
+	spec, ok := DifficultyByName(DifficultyImpossible)
+	if !ok {
+		t.Fatal("impossible should be a known difficulty")
+	}
+	if spec.TargetGivens != 20 || spec.Key != "i" {
+		t.Errorf("unexpected impossible spec: %+v", spec)
+	}
+
+	if _, ok := DifficultyByName("nightmare"); ok {
+		t.Error("nightmare should not be a known difficulty")
+	}
+}
@@ -0,0 +1,85 @@
+package constants
+
+// Completion scoring. The score for a finished game is computed server-side
+// from data the session already carries, so clients cannot inflate it:
+//
+//	score = base − hint penalties − time penalty + bonuses
+//
+// where
+//   - base grows with the difficulty tier: ScoreBaseStep times the tier's
+//     1-based position in the active difficulty list (easy=1)
+//   - each hint costs ScoreHintPenalties[level]: pointing out a cell is
+//     cheap, revealing a digit is expensive
+//   - every full minute past ScoreParSeconds costs ScoreTimePenaltyPerMinute,
+//     capped at ScoreMaxTimePenalty so a long think never zeroes a game
+//   - finishing without auto-candidates earns ScoreNoAutoCandidatesBonus
+//
+// The result never goes below zero.
+const (
+	// ScoreBaseStep is the base-score increment per difficulty tier
+	ScoreBaseStep = 100
+
+	// ScoreParSeconds is how long a game may take before time decay starts
+	ScoreParSeconds = 10 * 60
+
+	// ScoreTimePenaltyPerMinute is deducted per full minute past par
+	ScoreTimePenaltyPerMinute = 2
+
+	// ScoreMaxTimePenalty caps the total time decay
+	ScoreMaxTimePenalty = 60
+
+	// ScoreNoAutoCandidatesBonus rewards solving without auto-candidates
+	ScoreNoAutoCandidatesBonus = 50
+)
+
+// ScoreHintPenalties is the per-use cost of each hint tier: level 1 (which
+// technique applies), level 2 (where to look), level 3 (the digit itself).
+var ScoreHintPenalties = [3]int{10, 25, 50}
+
+// ScoreBreakdown itemizes a completion score so clients can display how the
+// total came together. Penalties are stored as positive amounts.
+type ScoreBreakdown struct {
+	Base                 int `json:"base"`
+	HintPenalty          int `json:"hint_penalty"`
+	TimePenalty          int `json:"time_penalty"`
+	NoAutoCandidateBonus int `json:"no_auto_candidates_bonus"`
+	Total                int `json:"total"`
+}
+
+// CompletionScore computes the score for a finished game. hints counts uses
+// of each hint tier (level 1 to 3); unknown difficulties score as the
+// easiest tier.
+func CompletionScore(difficulty string, elapsedSeconds int, hints [3]int, autoCandidates bool) ScoreBreakdown {
+	tier := 1
+	for i, spec := range DifficultySpecs() {
+		if spec.Name == difficulty {
+			tier = i + 1
+			break
+		}
+	}
+
+	b := ScoreBreakdown{Base: tier * ScoreBaseStep}
+
+	for level, used := range hints {
+		if used > 0 {
+			b.HintPenalty += used * ScoreHintPenalties[level]
+		}
+	}
+
+	if elapsedSeconds > ScoreParSeconds {
+		b.TimePenalty = (elapsedSeconds - ScoreParSeconds) / 60 * ScoreTimePenaltyPerMinute
+		if b.TimePenalty > ScoreMaxTimePenalty {
+			b.TimePenalty = ScoreMaxTimePenalty
+		}
+	}
+
+	if !autoCandidates {
+		b.NoAutoCandidateBonus = ScoreNoAutoCandidatesBonus
+	}
+
+	b.Total = b.Base - b.HintPenalty - b.TimePenalty + b.NoAutoCandidateBonus
+	if b.Total < 0 {
+		b.Total = 0
+	}
+	return b
+}
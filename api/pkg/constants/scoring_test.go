@@ -0,0 +1,58 @@
+package constants
+
+import "testing"
+
+func TestCompletionScore(t *testing.T) {
+	tests := []struct {
+		name           string
+		difficulty     string
+		elapsedSeconds int
+		hints          [3]int
+		autoCandidates bool
+		want           ScoreBreakdown
+	}{
+		{
+			name:       "clean easy game under par",
+			difficulty: DifficultyEasy, elapsedSeconds: 300,
+			want: ScoreBreakdown{Base: 100, NoAutoCandidateBonus: 50, Total: 150},
+		},
+		{
+			name:       "hard game with mixed hints",
+			difficulty: DifficultyHard, elapsedSeconds: 300,
+			hints: [3]int{2, 1, 1}, autoCandidates: true,
+			want: ScoreBreakdown{Base: 300, HintPenalty: 2*10 + 25 + 50, Total: 205},
+		},
+		{
+			name:       "time decay past par",
+			difficulty: DifficultyMedium, elapsedSeconds: ScoreParSeconds + 5*60 + 30,
+			autoCandidates: true,
+			want:           ScoreBreakdown{Base: 200, TimePenalty: 10, Total: 190},
+		},
+		{
+			name:       "time decay is capped",
+			difficulty: DifficultyImpossible, elapsedSeconds: ScoreParSeconds + 24*3600,
+			autoCandidates: true,
+			want:           ScoreBreakdown{Base: 500, TimePenalty: ScoreMaxTimePenalty, Total: 440},
+		},
+		{
+			name:       "score never goes negative",
+			difficulty: DifficultyEasy, elapsedSeconds: ScoreParSeconds + 24*3600,
+			hints: [3]int{0, 0, 10}, autoCandidates: true,
+			want: ScoreBreakdown{Base: 100, HintPenalty: 500, TimePenalty: ScoreMaxTimePenalty, Total: 0},
+		},
+		{
+			name:       "unknown difficulty scores as easiest tier",
+			difficulty: "nightmare", elapsedSeconds: 60, autoCandidates: true,
+			want: ScoreBreakdown{Base: 100, Total: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompletionScore(tt.difficulty, tt.elapsedSeconds, tt.hints, tt.autoCandidates)
+			if got != tt.want {
+				t.Errorf("CompletionScore() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}